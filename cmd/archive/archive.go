@@ -0,0 +1,99 @@
+package archive
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	flagDryRun    = "dry-run"
+	flagRetention = "retention"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "archive",
+		Short: "moves the events of closed aggregates into the archive",
+		Long: `moves the events of closed aggregates into the archive table eventstore.events2_archive.
+An aggregate is closed if its latest event is one of Eventstore.Archive.ClosingEventTypes
+and older than Eventstore.Archive.Retention.
+Only events processed by all projections of the instance are archived.
+The closing event is kept, archived aggregates are rehydrated as soon as they are queried by their id,
+therefore Eventstore.Archive.Enabled and Eventstore.Archive.ClosingEventTypes must be the same on all ZITADEL nodes.
+Projections starting from the beginning (new projections and rebuilds) rehydrate all archived aggregates of the instance,
+queries by event type (e.g. ListEvents) don't return archived events.
+Requirements:
+- setup must be executed`,
+		Example: `archive --dry-run
+archive --retention 2160h`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := MustNewConfig(viper.GetViper())
+			if retention, _ := cmd.Flags().GetDuration(flagRetention); retention > 0 {
+				config.Eventstore.Archive.Retention = retention
+			}
+			dryRun, _ := cmd.Flags().GetBool(flagDryRun)
+			return archive(cmd, config, dryRun)
+		},
+	}
+	cmd.Flags().Bool(flagDryRun, false, "lists the first batch of closed aggregates without archiving them")
+	cmd.Flags().Duration(flagRetention, 0, "overwrites Eventstore.Archive.Retention")
+	return cmd
+}
+
+func archive(cmd *cobra.Command, config *Config, dryRun bool) error {
+	archiveConfig := &config.Eventstore.Archive
+	if !archiveConfig.Enabled {
+		return zerrors.ThrowPreconditionFailed(nil, "ARCHI-eeK5o", "Eventstore.Archive.Enabled must be set to rehydrate archived aggregates")
+	}
+	if len(archiveConfig.ClosingEventTypes) == 0 {
+		return zerrors.ThrowPreconditionFailed(nil, "ARCHI-Ahz4i", "Eventstore.Archive.ClosingEventTypes must not be empty")
+	}
+	if archiveConfig.BatchSize == 0 {
+		archiveConfig.BatchSize = 1000
+	}
+
+	client, err := database.Connect(config.Database, false)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx := cmd.Context()
+	now := time.Now()
+	var archivedAggregates, archivedEvents uint64
+	for {
+		aggregates, err := eventstore.ClosedAggregates(ctx, client, archiveConfig, now, archiveConfig.BatchSize)
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			for _, aggregate := range aggregates {
+				cmd.Printf("%s\t%s\t%s\n", aggregate.InstanceID, aggregate.AggregateType, aggregate.AggregateID)
+			}
+			return nil
+		}
+		if len(aggregates) == 0 {
+			break
+		}
+		batchAggregates, batchEvents, err := eventstore.ArchiveAggregates(ctx, client, aggregates)
+		if err != nil {
+			return err
+		}
+		archivedAggregates += batchAggregates
+		archivedEvents += batchEvents
+		logging.WithFields("aggregates", archivedAggregates, "events", archivedEvents).Info("archived batch")
+		if batchAggregates == 0 {
+			// all aggregates of the batch were changed in the meantime
+			break
+		}
+	}
+	logging.WithFields("aggregates", archivedAggregates, "events", archivedEvents).Info("archive done")
+	return nil
+}
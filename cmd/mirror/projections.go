@@ -211,6 +211,7 @@ func projections(
 		config.Projections.Customizations["notificationsquotas"],
 		config.Projections.Customizations["backchannel"],
 		config.Projections.Customizations["telemetry"],
+		config.Projections.Customizations["notifications_backchannel_auth"],
 		config.Notifications,
 		*config.Telemetry,
 		config.ExternalDomain,
@@ -0,0 +1,77 @@
+package projections
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/cmd/encryption"
+	"github.com/zitadel/zitadel/cmd/key"
+	crypto_db "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_es "github.com/zitadel/zitadel/internal/eventstore/repository/sql"
+	new_es "github.com/zitadel/zitadel/internal/eventstore/v3"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+func rebuildCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rebuild <name>",
+		Short: "rebuilds a projection without downtime",
+		Long: `rebuilds the projection into shadow tables while the live tables keep serving.
+The progress is tracked in projections.current_states under the name of the projection suffixed with _shadow
+and can be followed using the status command.
+As soon as the shadow tables caught up with the live tables for all instances,
+the live tables are replaced by the shadow tables in a single transaction.
+An interrupted rebuild continues where it stopped.
+Requirements:
+- setup must be executed`,
+		Example: `projections rebuild projections.users14`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := MustNewConfig(viper.GetViper())
+			masterKey, err := key.MasterKey(cmd)
+			if err != nil {
+				return err
+			}
+			return rebuild(cmd, config, masterKey, args[0])
+		},
+	}
+	key.AddMasterKeyFlag(cmd)
+	return cmd
+}
+
+func rebuild(cmd *cobra.Command, config *Config, masterKey, name string) error {
+	ctx := cmd.Context()
+	client, err := database.Connect(config.Database, false)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	keyStorage, err := crypto_db.NewKeyStorage(client, masterKey)
+	if err != nil {
+		return err
+	}
+	keys, err := encryption.EnsureEncryptionKeys(ctx, config.EncryptionKeys, keyStorage)
+	if err != nil {
+		return err
+	}
+
+	newEventstore := new_es.NewEventstore(client)
+	config.Eventstore.Querier = old_es.NewPostgres(client)
+	config.Eventstore.Pusher = newEventstore
+	config.Eventstore.Searcher = newEventstore
+	es := eventstore.NewEventstore(config.Eventstore)
+
+	if err = projection.Create(ctx, client, es, config.Projections, keys.OIDC, keys.SAML, config.SystemAPIUsers); err != nil {
+		return err
+	}
+	logging.WithFields("projection", name).Info("rebuild started")
+	if err = projection.Rebuild(ctx, name); err != nil {
+		return err
+	}
+	logging.WithFields("projection", name).Info("rebuild done")
+	return nil
+}
@@ -0,0 +1,57 @@
+package projections
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+)
+
+func statusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status [name]",
+		Short: "shows the progress of the projection rebuilds per instance",
+		Long: `shows the progress of the running projection rebuilds per instance.
+The progress is read from the view projections.rebuild_status.
+If a name is provided, only the progress of the rebuild of this projection is shown.`,
+		Example: `projections status
+projections status projections.users14`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := MustNewConfig(viper.GetViper())
+			var name string
+			if len(args) > 0 {
+				name = args[0]
+			}
+			return status(cmd, config, name)
+		},
+	}
+}
+
+func status(cmd *cobra.Command, config *Config, name string) error {
+	client, err := database.Connect(config.Database, false)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	progress, err := handler.RebuildStatus(cmd.Context(), client, name)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROJECTION\tINSTANCE\tLIVE POSITION\tSHADOW POSITION\tSHADOW UPDATED\tCAUGHT UP")
+	for _, p := range progress {
+		var updated string
+		if !p.ShadowLastUpdated.IsZero() {
+			updated = p.ShadowLastUpdated.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%t\n", p.ProjectionName, p.InstanceID, p.LivePosition, p.ShadowPosition, updated, p.CaughtUp)
+	}
+	return w.Flush()
+}
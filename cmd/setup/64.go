@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 64.sql
+	addAuthorizationDetails string
+)
+
+type AuthorizationDetails struct {
+	dbClient *database.DB
+}
+
+func (mig *AuthorizationDetails) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addAuthorizationDetails)
+	return err
+}
+
+func (mig *AuthorizationDetails) String() string {
+	return "64_add_authorization_details"
+}
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS projections.projects4 ADD COLUMN IF NOT EXISTS authorization_details_types TEXT[];
+ALTER TABLE IF EXISTS projections.auth_requests ADD COLUMN IF NOT EXISTS authorization_details JSONB;
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 65.sql
+	addRecoveryCodeCheckedAt string
+)
+
+type RecoveryCodes struct {
+	dbClient *database.DB
+}
+
+func (mig *RecoveryCodes) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addRecoveryCodeCheckedAt)
+	return err
+}
+
+func (mig *RecoveryCodes) String() string {
+	return "65_add_recovery_code_checked_at"
+}
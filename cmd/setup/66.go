@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 66.sql
+	addPasswordComplexityCheckBreached string
+)
+
+type PasswordComplexityCheckBreached struct {
+	dbClient *database.DB
+}
+
+func (mig *PasswordComplexityCheckBreached) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addPasswordComplexityCheckBreached)
+	return err
+}
+
+func (mig *PasswordComplexityCheckBreached) String() string {
+	return "66_add_password_complexity_check_breached"
+}
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 67.sql
+	addWebAuthNAttestation string
+)
+
+type WebAuthNAttestation struct {
+	dbClient *database.DB
+}
+
+func (mig *WebAuthNAttestation) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addWebAuthNAttestation)
+	return err
+}
+
+func (mig *WebAuthNAttestation) String() string {
+	return "67_add_webauthn_attestation"
+}
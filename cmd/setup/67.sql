@@ -0,0 +1,6 @@
+ALTER TABLE IF EXISTS projections.security_policies2 ADD COLUMN IF NOT EXISTS webauthn_attestation_required BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE IF EXISTS projections.security_policies2 ADD COLUMN IF NOT EXISTS webauthn_allowed_aaguids TEXT[];
+ALTER TABLE IF EXISTS projections.security_policies2 ADD COLUMN IF NOT EXISTS webauthn_denied_aaguids TEXT[];
+ALTER TABLE IF EXISTS projections.security_policies2 ADD COLUMN IF NOT EXISTS webauthn_certification_levels TEXT[];
+ALTER TABLE IF EXISTS projections.user_auth_methods5 ADD COLUMN IF NOT EXISTS aaguid TEXT;
+ALTER TABLE IF EXISTS projections.user_auth_methods5 ADD COLUMN IF NOT EXISTS model_name TEXT;
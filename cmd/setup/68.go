@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 68.sql
+	createEventSinkCursors string
+)
+
+type CreateEventSinkCursors struct {
+	dbClient *database.DB
+}
+
+func (mig *CreateEventSinkCursors) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, createEventSinkCursors)
+	return err
+}
+
+func (mig *CreateEventSinkCursors) String() string {
+	return "68_create_event_sink_cursors"
+}
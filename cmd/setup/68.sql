@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS eventstore.event_sink_cursors (
+    sink_name TEXT NOT NULL PRIMARY KEY,
+    "position" NUMERIC NOT NULL DEFAULT 0,
+    "offset" INT4 NOT NULL DEFAULT 0,
+    change_date TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 69.sql
+	createEventArchive string
+)
+
+type CreateEventArchive struct {
+	dbClient *database.DB
+}
+
+func (mig *CreateEventArchive) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, createEventArchive)
+	return err
+}
+
+func (mig *CreateEventArchive) String() string {
+	return "69_create_event_archive"
+}
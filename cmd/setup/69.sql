@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS eventstore.events2_archive (
+    LIKE eventstore.events2 INCLUDING DEFAULTS
+    , PRIMARY KEY (instance_id, aggregate_type, aggregate_id, "sequence")
+);
+
+CREATE TABLE IF NOT EXISTS eventstore.archived_aggregates (
+    instance_id TEXT NOT NULL
+    , aggregate_type TEXT NOT NULL
+    , aggregate_id TEXT NOT NULL
+    , event_count INT8 NOT NULL
+    , archived_at TIMESTAMPTZ NOT NULL DEFAULT now()
+    , PRIMARY KEY (instance_id, aggregate_type, aggregate_id)
+);
+
+CREATE INDEX IF NOT EXISTS archived_aggregates_id ON eventstore.archived_aggregates (aggregate_id);
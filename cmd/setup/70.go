@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 70.sql
+	createSnapshots string
+)
+
+type CreateSnapshots struct {
+	dbClient *database.DB
+}
+
+func (mig *CreateSnapshots) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, createSnapshots)
+	return err
+}
+
+func (mig *CreateSnapshots) String() string {
+	return "70_create_snapshots"
+}
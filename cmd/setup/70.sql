@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS eventstore.snapshots (
+    instance_id TEXT NOT NULL
+    , snapshot_type TEXT NOT NULL
+    , query_hash TEXT NOT NULL
+    , version INT2 NOT NULL
+    , "position" NUMERIC NOT NULL
+    , "offset" INT4 NOT NULL
+    , payload JSONB NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL DEFAULT now()
+    , PRIMARY KEY (instance_id, snapshot_type, query_hash)
+);
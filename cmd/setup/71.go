@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 71.sql
+	createRebuildStatus string
+)
+
+type CreateRebuildStatus struct {
+	dbClient *database.DB
+}
+
+func (mig *CreateRebuildStatus) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, createRebuildStatus)
+	return err
+}
+
+func (mig *CreateRebuildStatus) String() string {
+	return "71_create_rebuild_status"
+}
@@ -0,0 +1,20 @@
+CREATE OR REPLACE VIEW projections.rebuild_status AS
+SELECT
+    live.projection_name
+    , live.instance_id
+    , live."position" AS live_position
+    , shadow."position" AS shadow_position
+    , shadow.event_date AS shadow_event_date
+    , shadow.last_updated AS shadow_last_updated
+    , COALESCE(shadow."position", 0) >= COALESCE(live."position", 0) AS caught_up
+FROM
+    projections.current_states live
+LEFT JOIN
+    projections.current_states shadow
+    ON shadow.projection_name = live.projection_name || '_shadow'
+    AND shadow.instance_id = live.instance_id
+WHERE
+    EXISTS (
+        SELECT 1 FROM projections.current_states s WHERE s.projection_name = live.projection_name || '_shadow'
+    )
+;
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 72.sql
+	addClaimMapping string
+)
+
+type IDPTemplate6ClaimMapping struct {
+	dbClient *database.DB
+}
+
+func (mig *IDPTemplate6ClaimMapping) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addClaimMapping)
+	return err
+}
+
+func (mig *IDPTemplate6ClaimMapping) String() string {
+	return "72_idp_templates6_add_claim_mapping"
+}
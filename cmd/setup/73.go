@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 73.sql
+	addIDPTemplate6ProviderTables string
+)
+
+type IDPTemplate6ProviderTables struct {
+	dbClient *database.DB
+}
+
+func (mig *IDPTemplate6ProviderTables) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addIDPTemplate6ProviderTables)
+	return err
+}
+
+func (mig *IDPTemplate6ProviderTables) String() string {
+	return "73_idp_templates6_add_okta_keycloak_adfs_bitbucket_discord"
+}
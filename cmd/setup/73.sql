@@ -0,0 +1,52 @@
+CREATE TABLE IF NOT EXISTS projections.idp_templates6_okta (
+    idp_id TEXT NOT NULL
+    , instance_id TEXT NOT NULL
+    , issuer TEXT NOT NULL
+    , client_id TEXT NOT NULL
+    , client_secret JSONB NOT NULL
+    , scopes TEXT[]
+    , PRIMARY KEY (instance_id, idp_id)
+    , CONSTRAINT fk_okta_ref_idp_templates6 FOREIGN KEY (instance_id, idp_id) REFERENCES projections.idp_templates6 ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS projections.idp_templates6_keycloak (
+    idp_id TEXT NOT NULL
+    , instance_id TEXT NOT NULL
+    , issuer TEXT NOT NULL
+    , client_id TEXT NOT NULL
+    , client_secret JSONB NOT NULL
+    , scopes TEXT[]
+    , PRIMARY KEY (instance_id, idp_id)
+    , CONSTRAINT fk_keycloak_ref_idp_templates6 FOREIGN KEY (instance_id, idp_id) REFERENCES projections.idp_templates6 ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS projections.idp_templates6_adfs (
+    idp_id TEXT NOT NULL
+    , instance_id TEXT NOT NULL
+    , issuer TEXT NOT NULL
+    , client_id TEXT NOT NULL
+    , client_secret JSONB NOT NULL
+    , scopes TEXT[]
+    , PRIMARY KEY (instance_id, idp_id)
+    , CONSTRAINT fk_adfs_ref_idp_templates6 FOREIGN KEY (instance_id, idp_id) REFERENCES projections.idp_templates6 ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS projections.idp_templates6_bitbucket (
+    idp_id TEXT NOT NULL
+    , instance_id TEXT NOT NULL
+    , client_id TEXT NOT NULL
+    , client_secret JSONB NOT NULL
+    , scopes TEXT[]
+    , PRIMARY KEY (instance_id, idp_id)
+    , CONSTRAINT fk_bitbucket_ref_idp_templates6 FOREIGN KEY (instance_id, idp_id) REFERENCES projections.idp_templates6 ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS projections.idp_templates6_discord (
+    idp_id TEXT NOT NULL
+    , instance_id TEXT NOT NULL
+    , client_id TEXT NOT NULL
+    , client_secret JSONB NOT NULL
+    , scopes TEXT[]
+    , PRIMARY KEY (instance_id, idp_id)
+    , CONSTRAINT fk_discord_ref_idp_templates6 FOREIGN KEY (instance_id, idp_id) REFERENCES projections.idp_templates6 ON DELETE CASCADE
+);
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 74.sql
+	createBlockedAggregates string
+)
+
+type CreateBlockedAggregates struct {
+	dbClient *database.DB
+}
+
+func (mig *CreateBlockedAggregates) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, createBlockedAggregates)
+	return err
+}
+
+func (mig *CreateBlockedAggregates) String() string {
+	return "74_create_blocked_aggregates"
+}
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS projections.blocked_aggregates (
+    projection_name TEXT NOT NULL
+    , instance_id TEXT NOT NULL
+    , aggregate_type TEXT NOT NULL
+    , aggregate_id TEXT NOT NULL
+    , "sequence" INT8 NOT NULL
+    , last_sequence INT8 NOT NULL
+    , retry_at TIMESTAMPTZ NOT NULL
+    , PRIMARY KEY (projection_name, instance_id, aggregate_type, aggregate_id)
+);
+
+CREATE INDEX IF NOT EXISTS blocked_aggregates_retry_at ON projections.blocked_aggregates (projection_name, retry_at);
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 75.sql
+	createShardLeases string
+)
+
+type CreateShardLeases struct {
+	dbClient *database.DB
+}
+
+func (mig *CreateShardLeases) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, createShardLeases)
+	return err
+}
+
+func (mig *CreateShardLeases) String() string {
+	return "75_create_shard_leases"
+}
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS projections.shard_leases (
+    projection_name TEXT NOT NULL
+    , shard INT8 NOT NULL
+    , "owner" TEXT NOT NULL
+    , taken_over BOOLEAN NOT NULL
+    , expires_at TIMESTAMPTZ NOT NULL
+    , PRIMARY KEY (projection_name, shard)
+);
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 76.sql
+	createDPoPProofs string
+)
+
+type CreateDPoPProofs struct {
+	dbClient *database.DB
+}
+
+func (mig *CreateDPoPProofs) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, createDPoPProofs)
+	return err
+}
+
+func (mig *CreateDPoPProofs) String() string {
+	return "76_create_dpop_proofs"
+}
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS auth.dpop_proofs (
+    id TEXT NOT NULL PRIMARY KEY
+    , expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS dpop_proofs_expires_at ON auth.dpop_proofs (expires_at);
@@ -156,6 +156,23 @@ type Steps struct {
 	s57CreateResourceCounts                 *CreateResourceCounts
 	s58ReplaceLoginNames3View               *ReplaceLoginNames3View
 	s59SetupWebkeys                         *SetupWebkeys
+	s60Apps7OIDCConfigsRequirePAR           *Apps7OIDCConfigsRequirePAR
+	s61Apps7OIDCConfigsRequireDPoP          *Apps7OIDCConfigsRequireDPoP
+	s62Apps7OIDCConfigsBackchannelAuth      *Apps7OIDCConfigsBackchannelAuth
+	s63Apps7OIDCConfigsTLSClientAuth        *Apps7OIDCConfigsTLSClientAuth
+	s64AuthorizationDetails                 *AuthorizationDetails
+	s65RecoveryCodes                        *RecoveryCodes
+	s66PasswordComplexityCheckBreached      *PasswordComplexityCheckBreached
+	s67WebAuthNAttestation                  *WebAuthNAttestation
+	s68CreateEventSinkCursors               *CreateEventSinkCursors
+	s69CreateEventArchive                   *CreateEventArchive
+	s70CreateSnapshots                      *CreateSnapshots
+	s71CreateRebuildStatus                  *CreateRebuildStatus
+	s72IDPTemplate6ClaimMapping             *IDPTemplate6ClaimMapping
+	s73IDPTemplate6ProviderTables           *IDPTemplate6ProviderTables
+	s74CreateBlockedAggregates              *CreateBlockedAggregates
+	s75CreateShardLeases                    *CreateShardLeases
+	s76CreateDPoPProofs                     *CreateDPoPProofs
 }
 
 func MustNewSteps(v *viper.Viper) *Steps {
@@ -217,6 +217,23 @@ func Setup(ctx context.Context, config *Config, steps *Steps, masterKey string)
 	steps.s56IDPTemplate6SAMLFederatedLogout = &IDPTemplate6SAMLFederatedLogout{dbClient: dbClient}
 	steps.s57CreateResourceCounts = &CreateResourceCounts{dbClient: dbClient}
 	steps.s58ReplaceLoginNames3View = &ReplaceLoginNames3View{dbClient: dbClient}
+	steps.s60Apps7OIDCConfigsRequirePAR = &Apps7OIDCConfigsRequirePAR{dbClient: dbClient}
+	steps.s61Apps7OIDCConfigsRequireDPoP = &Apps7OIDCConfigsRequireDPoP{dbClient: dbClient}
+	steps.s62Apps7OIDCConfigsBackchannelAuth = &Apps7OIDCConfigsBackchannelAuth{dbClient: dbClient}
+	steps.s63Apps7OIDCConfigsTLSClientAuth = &Apps7OIDCConfigsTLSClientAuth{dbClient: dbClient}
+	steps.s64AuthorizationDetails = &AuthorizationDetails{dbClient: dbClient}
+	steps.s65RecoveryCodes = &RecoveryCodes{dbClient: dbClient}
+	steps.s66PasswordComplexityCheckBreached = &PasswordComplexityCheckBreached{dbClient: dbClient}
+	steps.s67WebAuthNAttestation = &WebAuthNAttestation{dbClient: dbClient}
+	steps.s68CreateEventSinkCursors = &CreateEventSinkCursors{dbClient: dbClient}
+	steps.s69CreateEventArchive = &CreateEventArchive{dbClient: dbClient}
+	steps.s70CreateSnapshots = &CreateSnapshots{dbClient: dbClient}
+	steps.s71CreateRebuildStatus = &CreateRebuildStatus{dbClient: dbClient}
+	steps.s72IDPTemplate6ClaimMapping = &IDPTemplate6ClaimMapping{dbClient: dbClient}
+	steps.s73IDPTemplate6ProviderTables = &IDPTemplate6ProviderTables{dbClient: dbClient}
+	steps.s74CreateBlockedAggregates = &CreateBlockedAggregates{dbClient: dbClient}
+	steps.s75CreateShardLeases = &CreateShardLeases{dbClient: dbClient}
+	steps.s76CreateDPoPProofs = &CreateDPoPProofs{dbClient: dbClient}
 
 	err = projection.Create(ctx, dbClient, eventstoreClient, config.Projections, nil, nil, nil)
 	logging.OnError(err).Fatal("unable to start projections")
@@ -264,6 +281,23 @@ func Setup(ctx context.Context, config *Config, steps *Steps, masterKey string)
 		steps.s56IDPTemplate6SAMLFederatedLogout,
 		steps.s57CreateResourceCounts,
 		steps.s58ReplaceLoginNames3View,
+		steps.s60Apps7OIDCConfigsRequirePAR,
+		steps.s61Apps7OIDCConfigsRequireDPoP,
+		steps.s62Apps7OIDCConfigsBackchannelAuth,
+		steps.s63Apps7OIDCConfigsTLSClientAuth,
+		steps.s64AuthorizationDetails,
+		steps.s65RecoveryCodes,
+		steps.s66PasswordComplexityCheckBreached,
+		steps.s67WebAuthNAttestation,
+		steps.s68CreateEventSinkCursors,
+		steps.s69CreateEventArchive,
+		steps.s70CreateSnapshots,
+		steps.s71CreateRebuildStatus,
+		steps.s72IDPTemplate6ClaimMapping,
+		steps.s73IDPTemplate6ProviderTables,
+		steps.s74CreateBlockedAggregates,
+		steps.s75CreateShardLeases,
+		steps.s76CreateDPoPProofs,
 	} {
 		setupErr = executeMigration(ctx, eventstoreClient, step, "migration failed")
 		if setupErr != nil {
@@ -26,9 +26,13 @@ import (
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventsink"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/execution"
+	"github.com/zitadel/zitadel/internal/exporter"
 	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/idptokens"
+	"github.com/zitadel/zitadel/internal/ldapsync"
 	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/notification/handlers"
 	"github.com/zitadel/zitadel/internal/query/projection"
@@ -36,6 +40,7 @@ import (
 	metrics "github.com/zitadel/zitadel/internal/telemetry/metrics/config"
 	profiler "github.com/zitadel/zitadel/internal/telemetry/profiler/config"
 	tracing "github.com/zitadel/zitadel/internal/telemetry/tracing/config"
+	"github.com/zitadel/zitadel/internal/webauthn"
 )
 
 type Config struct {
@@ -50,6 +55,7 @@ type Config struct {
 	HTTP2HostHeader     string
 	HTTP1HostHeader     string
 	WebAuthNName        string
+	WebAuthNMetadata    webauthn.MetadataConfig
 	Database            database.Config
 	Caches              *connector.CachesConfig
 	Tracing             tracing.Config
@@ -58,6 +64,10 @@ type Config struct {
 	Projections         projection.Config
 	Notifications       handlers.WorkerConfig
 	Executions          execution.WorkerConfig
+	LDAPSyncs           ldapsync.WorkerConfig
+	IDPTokenRefreshes   idptokens.WorkerConfig
+	EventSinks          map[string]*eventsink.Config
+	Exporters           map[string]*exporter.Config
 	Auth                auth_es.Config
 	Admin               admin_es.Config
 	UserAgentCookie     *middleware.UserAgentCookieConfig
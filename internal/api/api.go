@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"net/http"
+	"slices"
 	"sort"
 	"strings"
 
@@ -78,8 +79,13 @@ func New(
 	tlsConfig *tls.Config,
 	externalDomain string,
 	hostHeaders []string,
+	certificateHeader string,
 	accessInterceptor *http_mw.AccessInterceptor,
 ) (_ *API, err error) {
+	if certificateHeader != "" {
+		// the gateway passes the client certificate of the reverse proxy to the grpc server
+		hostHeaders = append(slices.Clip(hostHeaders), strings.ToLower(certificateHeader))
+	}
 	api := &API{
 		port:              port,
 		verifier:          verifier,
@@ -90,7 +96,7 @@ func New(
 		hostHeaders:       hostHeaders,
 	}
 
-	api.grpcServer = server.CreateServer(api.verifier, systemAuthz, authZ, queries, externalDomain, tlsConfig, accessInterceptor.AccessService())
+	api.grpcServer = server.CreateServer(api.verifier, systemAuthz, authZ, queries, externalDomain, tlsConfig, accessInterceptor.AccessService(), certificateHeader)
 	api.grpcGateway, err = server.CreateGateway(ctx, port, hostHeaders, accessInterceptor, tlsConfig)
 	if err != nil {
 		return nil, err
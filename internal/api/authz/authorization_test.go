@@ -1,9 +1,11 @@
 package authz
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -402,3 +404,34 @@ func Test_systemMembershipsToUserPermissions(t *testing.T) {
 		})
 	}
 }
+
+func TestSetSystemCtxData(t *testing.T) {
+	roleMap := []RoleMapping{
+		{Role: "IAM_OWNER", Permissions: []string{"user.read", "user.write"}},
+	}
+	memberships := Memberships{
+		{MemberType: MemberTypeIAM, AggregateID: "instance1", Roles: []string{"IAM_OWNER"}},
+	}
+	ctx, err := SetSystemCtxData(context.Background(), "SYSTEM", memberships, roleMap, "user.write")
+	require.NoError(t, err)
+
+	ctxData := GetCtxData(ctx)
+	assert.False(t, ctxData.IsZero())
+	assert.Equal(t, "SYSTEM", ctxData.UserID)
+	assert.Equal(t, memberships, ctxData.SystemMemberships)
+	assert.Equal(t, []SystemUserPermissions{
+		{MemberType: MemberTypeIAM, AggregateID: "instance1", Permissions: []string{"user.read", "user.write"}},
+	}, ctxData.SystemUserPermissions)
+	assert.Equal(t, []string{"user.write"}, GetRequestPermissionsFromCtx(ctx))
+}
+
+func TestSetSystemCtxData_missingPermission(t *testing.T) {
+	roleMap := []RoleMapping{
+		{Role: "IAM_OWNER", Permissions: []string{"user.read"}},
+	}
+	memberships := Memberships{
+		{MemberType: MemberTypeIAM, AggregateID: "instance1", Roles: []string{"IAM_OWNER"}},
+	}
+	_, err := SetSystemCtxData(context.Background(), "SYSTEM", memberships, roleMap, "user.write")
+	assert.True(t, zerrors.IsPermissionDenied(err))
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/zitadel/logging"
 
+	"github.com/zitadel/zitadel/internal/api/dpop"
 	"github.com/zitadel/zitadel/internal/api/grpc"
 	http_util "github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -101,11 +102,11 @@ func (s SystemTokenVerifierFunc) VerifySystemToken(ctx context.Context, token st
 func VerifyTokenAndCreateCtxData(ctx context.Context, token, orgID, orgDomain string, t APITokenVerifier, systemRoleMap []RoleMapping) (_ CtxData, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
-	tokenWOBearer, err := extractBearerToken(token)
+	tokenWOBearer, tokenCtx, err := extractToken(ctx, token)
 	if err != nil {
 		return CtxData{}, err
 	}
-	userID, clientID, agentID, prefLang, resourceOwner, err := t.VerifyAccessToken(ctx, tokenWOBearer)
+	userID, clientID, agentID, prefLang, resourceOwner, err := t.VerifyAccessToken(tokenCtx, tokenWOBearer)
 	var sysMemberships Memberships
 	if err != nil && !zerrors.IsUnauthenticated(err) {
 		return CtxData{}, err
@@ -164,6 +165,23 @@ func SetCtxData(ctx context.Context, ctxData CtxData) context.Context {
 	return context.WithValue(ctx, dataKey, ctxData)
 }
 
+// SetSystemCtxData sets the context data for background jobs which act with the memberships of a system user.
+// The memberships are mapped to permissions by the role mappings of the system users,
+// the permissions of requiredPermission are set as the permissions of the request.
+// An error is returned if the role mappings do not grant the requiredPermission to the memberships.
+func SetSystemCtxData(ctx context.Context, userID string, memberships Memberships, systemRoleMappings []RoleMapping, requiredPermission string) (context.Context, error) {
+	requestPermissions, _ := mapMembershipsToPermissions(requiredPermission, memberships, systemRoleMappings)
+	if len(requestPermissions) == 0 {
+		return nil, zerrors.ThrowPermissionDenied(nil, "AUTH-Ohsh8", "No matching permissions found")
+	}
+	ctx = context.WithValue(ctx, requestPermissionsKey, requestPermissions)
+	return SetCtxData(ctx, CtxData{
+		UserID:                userID,
+		SystemMemberships:     memberships,
+		SystemUserPermissions: systemMembershipsToUserPermissions(memberships, systemRoleMappings),
+	}), nil
+}
+
 func GetCtxData(ctx context.Context) CtxData {
 	ctxData, _ := ctx.Value(dataKey).(CtxData)
 	return ctxData
@@ -188,6 +206,26 @@ func checkOrigin(ctx context.Context, origins []string) error {
 	return zerrors.ThrowPermissionDenied(nil, "AUTH-DZG21", "Errors.OriginNotAllowed")
 }
 
+// extractToken returns the access token of the authorization header.
+// A token presented with the DPoP scheme requires a valid proof of the request (see [dpop.WithRequest]).
+// The thumbprint of the proof's key is set into the returned context, so the token verifier can check the binding of the token.
+func extractToken(ctx context.Context, token string) (_ string, _ context.Context, err error) {
+	accessToken, ok := strings.CutPrefix(token, dpop.AuthScheme)
+	if !ok {
+		accessToken, err = extractBearerToken(token)
+		return accessToken, ctx, err
+	}
+	request := dpop.RequestFromContext(ctx)
+	if request == nil || request.Proof == "" {
+		return "", nil, zerrors.ThrowUnauthenticated(nil, "AUTH-Oe7ah", "invalid auth header")
+	}
+	thumbprint, err := dpop.Verify(ctx, request.Proof, request.Method, request.URI, accessToken)
+	if err != nil {
+		return "", nil, err
+	}
+	return accessToken, dpop.WithThumbprint(ctx, thumbprint), nil
+}
+
 func extractBearerToken(token string) (part string, err error) {
 	parts := strings.Split(token, BearerPrefix)
 	if len(parts) != 2 {
@@ -0,0 +1,205 @@
+// Package dpop implements the verification of proofs of possession
+// for sender-constrained tokens (DPoP, https://www.rfc-editor.org/rfc/rfc9449).
+package dpop
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// TokenType is returned as token_type for DPoP bound tokens
+	// and used as authorization scheme when presenting them.
+	TokenType = "DPoP"
+	// AuthScheme is the prefix of the authorization header for DPoP bound tokens.
+	AuthScheme = TokenType + " "
+
+	proofType = "dpop+jwt"
+
+	// MaxProofAge is the maximum age of a proof.
+	// The jti of used proofs are remembered until the proof expires (see [ReplayStore]).
+	MaxProofAge = time.Minute
+	// ClockSkew is the time a proof may be issued in the future.
+	ClockSkew = 5 * time.Second
+)
+
+// SigningAlgorithms are the supported (asymmetric) signing algorithms of proofs.
+var SigningAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+// SigningAlgorithmNames returns the [SigningAlgorithms] as strings, e.g. for the discovery endpoint.
+func SigningAlgorithmNames() []string {
+	names := make([]string, len(SigningAlgorithms))
+	for i, alg := range SigningAlgorithms {
+		names[i] = string(alg)
+	}
+	return names
+}
+
+type proofClaims struct {
+	ID              string  `json:"jti"`
+	HTTPMethod      string  `json:"htm"`
+	HTTPURI         string  `json:"htu"`
+	IssuedAt        float64 `json:"iat"`
+	AccessTokenHash string  `json:"ath,omitempty"`
+}
+
+// Verify verifies the proof for a request with the method and the uri
+// and returns the JWK SHA-256 thumbprint (https://www.rfc-editor.org/rfc/rfc7638) of the public key of the proof.
+// If the method is empty, it's not verified.
+// If the uri has no path, only the origin of the htu claim is verified.
+// If an access token is passed, the proof must contain its hash in the ath claim.
+// Each proof is only accepted once.
+func Verify(ctx context.Context, proof, method, uri, accessToken string) (thumbprint string, err error) {
+	jws, err := jose.ParseSignedCompact(proof, SigningAlgorithms)
+	if err != nil {
+		return "", zerrors.ThrowUnauthenticated(err, "DPOP-Ohm4e", "Errors.Token.Invalid")
+	}
+	if len(jws.Signatures) != 1 {
+		return "", zerrors.ThrowUnauthenticated(nil, "DPOP-ieR3o", "Errors.Token.Invalid")
+	}
+	header := jws.Signatures[0].Protected
+	if typ, _ := header.ExtraHeaders[jose.HeaderType].(string); typ != proofType {
+		return "", zerrors.ThrowUnauthenticated(nil, "DPOP-Oaj4a", "Errors.Token.Invalid")
+	}
+	key := header.JSONWebKey
+	if key == nil || !key.IsPublic() || !key.Valid() {
+		return "", zerrors.ThrowUnauthenticated(nil, "DPOP-ua7Ae", "Errors.Token.Invalid")
+	}
+	payload, err := jws.Verify(key)
+	if err != nil {
+		return "", zerrors.ThrowUnauthenticated(err, "DPOP-Ahx2u", "Errors.Token.Invalid")
+	}
+	claims := new(proofClaims)
+	if err = json.Unmarshal(payload, claims); err != nil {
+		return "", zerrors.ThrowUnauthenticated(err, "DPOP-Yie6o", "Errors.Token.Invalid")
+	}
+	if err = claims.verify(method, uri, accessToken); err != nil {
+		return "", err
+	}
+	thumbprint, err = Thumbprint(key)
+	if err != nil {
+		return "", err
+	}
+	added, err := usedProofs.Add(ctx, thumbprint+"."+claims.ID, time.Unix(int64(claims.IssuedAt), 0).Add(MaxProofAge))
+	if err != nil {
+		return "", err
+	}
+	if !added {
+		return "", zerrors.ThrowUnauthenticated(nil, "DPOP-ahT4o", "Errors.Token.Invalid")
+	}
+	return thumbprint, nil
+}
+
+func (c *proofClaims) verify(method, uri, accessToken string) error {
+	if c.ID == "" {
+		return zerrors.ThrowUnauthenticated(nil, "DPOP-aeK8i", "Errors.Token.Invalid")
+	}
+	if method != "" && c.HTTPMethod != method {
+		return zerrors.ThrowUnauthenticated(nil, "DPOP-Eeph9", "Errors.Token.Invalid")
+	}
+	if !matchesURI(c.HTTPURI, uri) {
+		return zerrors.ThrowUnauthenticated(nil, "DPOP-gu4Th", "Errors.Token.Invalid")
+	}
+	issuedAt := time.Unix(int64(c.IssuedAt), 0)
+	if now := time.Now(); issuedAt.Before(now.Add(-MaxProofAge)) || issuedAt.After(now.Add(ClockSkew)) {
+		return zerrors.ThrowUnauthenticated(nil, "DPOP-Tho7u", "Errors.Token.Invalid")
+	}
+	if accessToken != "" && c.AccessTokenHash != AccessTokenHash(accessToken) {
+		return zerrors.ThrowUnauthenticated(nil, "DPOP-Ooz4i", "Errors.Token.Invalid")
+	}
+	return nil
+}
+
+// RequestPath returns the path of the request as sent by the client, which is the path of the htu claim,
+// even if a prefix was stripped by the router (see [http.StripPrefix]).
+func RequestPath(r *http.Request) string {
+	if uri, err := url.ParseRequestURI(r.RequestURI); err == nil && uri.Path != "" {
+		return uri.Path
+	}
+	return r.URL.Path
+}
+
+// matchesURI compares the htu claim with the expected uri without query and fragment parts.
+func matchesURI(htu, expected string) bool {
+	claimed, err := url.Parse(htu)
+	if err != nil {
+		return false
+	}
+	want, err := url.Parse(expected)
+	if err != nil {
+		return false
+	}
+	if !strings.EqualFold(claimed.Scheme, want.Scheme) || !strings.EqualFold(claimed.Host, want.Host) {
+		return false
+	}
+	return want.Path == "" || claimed.Path == want.Path
+}
+
+// Thumbprint returns the base64url encoded JWK SHA-256 thumbprint of the key.
+func Thumbprint(key *jose.JSONWebKey) (string, error) {
+	thumbprint, err := key.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", zerrors.ThrowUnauthenticated(err, "DPOP-Iu8ei", "Errors.Token.Invalid")
+	}
+	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
+}
+
+// AccessTokenHash returns the base64url encoded SHA-256 hash of the access token used in the ath claim.
+func AccessTokenHash(accessToken string) string {
+	hash := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+type key int
+
+const (
+	proofKey key = iota
+	thumbprintKey
+)
+
+// Request is the proof and the request it was sent with.
+type Request struct {
+	Proof  string
+	Method string
+	URI    string
+}
+
+// WithRequest sets the proof of the request into the context,
+// so it can be verified as soon as the access token is known.
+func WithRequest(ctx context.Context, request *Request) context.Context {
+	return context.WithValue(ctx, proofKey, request)
+}
+
+// RequestFromContext returns the proof of the request set by [WithRequest].
+func RequestFromContext(ctx context.Context) *Request {
+	request, _ := ctx.Value(proofKey).(*Request)
+	return request
+}
+
+// WithThumbprint sets the thumbprint of a verified proof into the context.
+func WithThumbprint(ctx context.Context, thumbprint string) context.Context {
+	return context.WithValue(ctx, thumbprintKey, thumbprint)
+}
+
+// ThumbprintFromContext returns the thumbprint of the verified proof set by [WithThumbprint].
+// An empty string is returned if the access token was not presented with a proof.
+func ThumbprintFromContext(ctx context.Context) string {
+	thumbprint, _ := ctx.Value(thumbprintKey).(string)
+	return thumbprint
+}
@@ -0,0 +1,231 @@
+package dpop
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func createProof(t *testing.T, key *ecdsa.PrivateKey, typ string, embedKey bool, claims map[string]any) string {
+	t.Helper()
+	opts := new(jose.SignerOptions).WithType(jose.ContentType(typ))
+	if embedKey {
+		opts.EmbedJWK = true
+	}
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key}, opts)
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	jws, err := signer.Sign(payload)
+	require.NoError(t, err)
+	proof, err := jws.CompactSerialize()
+	require.NoError(t, err)
+	return proof
+}
+
+func TestVerify(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	thumbprint, err := Thumbprint(&jose.JSONWebKey{Key: key.Public()})
+	require.NoError(t, err)
+
+	var proofs int
+	validClaims := func(modify func(map[string]any)) map[string]any {
+		proofs++
+		claims := map[string]any{
+			"jti": strconv.Itoa(proofs),
+			"htm": "POST",
+			"htu": "https://issuer.com/oauth/v2/token",
+			"iat": time.Now().Unix(),
+		}
+		if modify != nil {
+			modify(claims)
+		}
+		return claims
+	}
+
+	type args struct {
+		proof       string
+		method      string
+		uri         string
+		accessToken string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			name: "invalid proof",
+			args: args{
+				proof:  "invalid",
+				method: "POST",
+				uri:    "https://issuer.com/oauth/v2/token",
+			},
+			wantErr: zerrors.ThrowUnauthenticated(nil, "DPOP-Ohm4e", "Errors.Token.Invalid"),
+		},
+		{
+			name: "wrong type",
+			args: args{
+				proof:  createProof(t, key, "JWT", true, validClaims(nil)),
+				method: "POST",
+				uri:    "https://issuer.com/oauth/v2/token",
+			},
+			wantErr: zerrors.ThrowUnauthenticated(nil, "DPOP-Oaj4a", "Errors.Token.Invalid"),
+		},
+		{
+			name: "missing key",
+			args: args{
+				proof:  createProof(t, key, proofType, false, validClaims(nil)),
+				method: "POST",
+				uri:    "https://issuer.com/oauth/v2/token",
+			},
+			wantErr: zerrors.ThrowUnauthenticated(nil, "DPOP-ua7Ae", "Errors.Token.Invalid"),
+		},
+		{
+			name: "missing jti",
+			args: args{
+				proof:  createProof(t, key, proofType, true, validClaims(func(c map[string]any) { delete(c, "jti") })),
+				method: "POST",
+				uri:    "https://issuer.com/oauth/v2/token",
+			},
+			wantErr: zerrors.ThrowUnauthenticated(nil, "DPOP-aeK8i", "Errors.Token.Invalid"),
+		},
+		{
+			name: "wrong method",
+			args: args{
+				proof:  createProof(t, key, proofType, true, validClaims(nil)),
+				method: "GET",
+				uri:    "https://issuer.com/oauth/v2/token",
+			},
+			wantErr: zerrors.ThrowUnauthenticated(nil, "DPOP-Eeph9", "Errors.Token.Invalid"),
+		},
+		{
+			name: "wrong uri",
+			args: args{
+				proof:  createProof(t, key, proofType, true, validClaims(nil)),
+				method: "POST",
+				uri:    "https://issuer.com/oidc/v1/userinfo",
+			},
+			wantErr: zerrors.ThrowUnauthenticated(nil, "DPOP-gu4Th", "Errors.Token.Invalid"),
+		},
+		{
+			name: "expired",
+			args: args{
+				proof:  createProof(t, key, proofType, true, validClaims(func(c map[string]any) { c["iat"] = time.Now().Add(-2 * MaxProofAge).Unix() })),
+				method: "POST",
+				uri:    "https://issuer.com/oauth/v2/token",
+			},
+			wantErr: zerrors.ThrowUnauthenticated(nil, "DPOP-Tho7u", "Errors.Token.Invalid"),
+		},
+		{
+			name: "issued in the future",
+			args: args{
+				proof:  createProof(t, key, proofType, true, validClaims(func(c map[string]any) { c["iat"] = time.Now().Add(time.Minute).Unix() })),
+				method: "POST",
+				uri:    "https://issuer.com/oauth/v2/token",
+			},
+			wantErr: zerrors.ThrowUnauthenticated(nil, "DPOP-Tho7u", "Errors.Token.Invalid"),
+		},
+		{
+			name: "missing access token hash",
+			args: args{
+				proof:       createProof(t, key, proofType, true, validClaims(nil)),
+				method:      "POST",
+				uri:         "https://issuer.com/oauth/v2/token",
+				accessToken: "token",
+			},
+			wantErr: zerrors.ThrowUnauthenticated(nil, "DPOP-Ooz4i", "Errors.Token.Invalid"),
+		},
+		{
+			name: "valid",
+			args: args{
+				proof:  createProof(t, key, proofType, true, validClaims(nil)),
+				method: "POST",
+				uri:    "https://issuer.com/oauth/v2/token?query",
+			},
+			want: thumbprint,
+		},
+		{
+			name: "valid with access token hash",
+			args: args{
+				proof: createProof(t, key, proofType, true, validClaims(func(c map[string]any) {
+					c["htm"] = "GET"
+					c["htu"] = "https://issuer.com/oidc/v1/userinfo"
+					c["ath"] = AccessTokenHash("token")
+				})),
+				method:      "GET",
+				uri:         "https://issuer.com/oidc/v1/userinfo",
+				accessToken: "token",
+			},
+			want: thumbprint,
+		},
+		{
+			name: "valid origin only",
+			args: args{
+				proof: createProof(t, key, proofType, true, validClaims(nil)),
+				uri:   "https://issuer.com",
+			},
+			want: thumbprint,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Verify(context.Background(), tt.args.proof, tt.args.method, tt.args.uri, tt.args.accessToken)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVerify_replay(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	proof := createProof(t, key, proofType, true, map[string]any{
+		"jti": "replay",
+		"htm": "POST",
+		"htu": "https://issuer.com/oauth/v2/token",
+		"iat": time.Now().Unix(),
+	})
+
+	_, err = Verify(context.Background(), proof, "POST", "https://issuer.com/oauth/v2/token", "")
+	require.NoError(t, err)
+	_, err = Verify(context.Background(), proof, "POST", "https://issuer.com/oauth/v2/token", "")
+	require.ErrorIs(t, err, zerrors.ThrowUnauthenticated(nil, "DPOP-ahT4o", "Errors.Token.Invalid"))
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Nil(t, RequestFromContext(ctx))
+	assert.Empty(t, ThumbprintFromContext(ctx))
+
+	request := &Request{Proof: "proof", Method: "GET", URI: "https://issuer.com"}
+	ctx = WithThumbprint(WithRequest(ctx, request), "thumbprint")
+	assert.Equal(t, request, RequestFromContext(ctx))
+	assert.Equal(t, "thumbprint", ThumbprintFromContext(ctx))
+}
+
+func TestRequestPath(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/assets/v1/org/policy/label/logo?query", nil)
+	assert.Equal(t, "/assets/v1/org/policy/label/logo", RequestPath(r))
+
+	var stripped string
+	http.StripPrefix("/assets/v1", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stripped = RequestPath(r)
+	})).ServeHTTP(httptest.NewRecorder(), r)
+	assert.Equal(t, "/assets/v1/org/policy/label/logo", stripped)
+}
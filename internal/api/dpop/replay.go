@@ -0,0 +1,102 @@
+package dpop
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// ReplayStore remembers the used proofs until they expire (RFC 9449, section 11.1).
+type ReplayStore interface {
+	// Add returns false if the proof was already used.
+	Add(ctx context.Context, id string, expiration time.Time) (bool, error)
+}
+
+var usedProofs ReplayStore = &replayCache{proofs: make(map[string]time.Time)}
+
+// SetReplayStore sets the store of the used proofs.
+// The store must be shared by all processes, otherwise a proof could be replayed on another process.
+// Until it's set, the used proofs are only remembered by the current process.
+func SetReplayStore(store ReplayStore) {
+	usedProofs = store
+}
+
+// replayCache remembers the used proofs in memory.
+type replayCache struct {
+	mu         sync.Mutex
+	proofs     map[string]time.Time
+	lastPruned time.Time
+}
+
+func (c *replayCache) Add(_ context.Context, id string, expiration time.Time) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if now.Sub(c.lastPruned) > MaxProofAge {
+		for proof, expiresAt := range c.proofs {
+			if expiresAt.Before(now) {
+				delete(c.proofs, proof)
+			}
+		}
+		c.lastPruned = now
+	}
+	if expiresAt, ok := c.proofs[id]; ok && expiresAt.After(now) {
+		return false, nil
+	}
+	c.proofs[id] = expiration
+	return true, nil
+}
+
+const (
+	// an expired proof is replaced, as it's rejected by its age anyway
+	addProofStmt = "INSERT INTO auth.dpop_proofs (id, expires_at) VALUES ($1, $2)" +
+		" ON CONFLICT (id) DO UPDATE SET expires_at = EXCLUDED.expires_at WHERE auth.dpop_proofs.expires_at < now()"
+	pruneProofsStmt = "DELETE FROM auth.dpop_proofs WHERE expires_at < now()"
+)
+
+// databaseStore remembers the used proofs in the database, so they are shared by all processes.
+type databaseStore struct {
+	client *database.DB
+
+	mu         sync.Mutex
+	lastPruned time.Time
+}
+
+// NewDatabaseReplayStore returns a [ReplayStore] shared by all processes using the database.
+func NewDatabaseReplayStore(client *database.DB) ReplayStore {
+	return &databaseStore{client: client}
+}
+
+func (s *databaseStore) Add(ctx context.Context, id string, expiration time.Time) (bool, error) {
+	s.prune(ctx)
+	res, err := s.client.ExecContext(ctx, addProofStmt, id, expiration)
+	if err != nil {
+		return false, zerrors.ThrowInternal(err, "DPOP-eeT3u", "Errors.Internal")
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, zerrors.ThrowInternal(err, "DPOP-Aesh0", "Errors.Internal")
+	}
+	return rows == 1, nil
+}
+
+// prune deletes the expired proofs at most once per [MaxProofAge] of the process.
+func (s *databaseStore) prune(ctx context.Context) {
+	s.mu.Lock()
+	now := time.Now()
+	due := now.Sub(s.lastPruned) > MaxProofAge
+	if due {
+		s.lastPruned = now
+	}
+	s.mu.Unlock()
+	if !due {
+		return
+	}
+	_, err := s.client.ExecContext(ctx, pruneProofsStmt)
+	logging.OnError(err).Warn("unable to prune expired dpop proofs")
+}
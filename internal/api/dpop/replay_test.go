@@ -0,0 +1,90 @@
+package dpop
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+func Test_databaseStore_Add(t *testing.T) {
+	expiration := time.Now().Add(MaxProofAge)
+	tests := []struct {
+		name    string
+		pruned  bool
+		expect  func(mock sqlmock.Sqlmock)
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "unused, expired proofs pruned",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(pruneProofsStmt)).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(regexp.QuoteMeta(addProofStmt)).
+					WithArgs("thumbprint.jti", expiration).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want: true,
+		},
+		{
+			name:   "used",
+			pruned: true,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(addProofStmt)).
+					WithArgs("thumbprint.jti", expiration).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			want: false,
+		},
+		{
+			name: "prune failed",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(pruneProofsStmt)).
+					WillReturnError(errors.New("unavailable"))
+				mock.ExpectExec(regexp.QuoteMeta(addProofStmt)).
+					WithArgs("thumbprint.jti", expiration).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			want: true,
+		},
+		{
+			name:   "add failed",
+			pruned: true,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(addProofStmt)).
+					WithArgs("thumbprint.jti", expiration).
+					WillReturnError(errors.New("unavailable"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			tt.expect(mock)
+
+			store := NewDatabaseReplayStore(&database.DB{DB: db}).(*databaseStore)
+			if tt.pruned {
+				store.lastPruned = time.Now()
+			}
+			got, err := store.Add(context.Background(), "thumbprint.jti", expiration)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+
+	org_grpc "github.com/zitadel/zitadel/internal/api/grpc/org"
+	user_grpc "github.com/zitadel/zitadel/internal/api/grpc/user"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) GetUserByIDAsOf(ctx context.Context, req *admin_pb.GetUserByIDAsOfRequest) (*admin_pb.GetUserByIDAsOfResponse, error) {
+	asOf, err := asOfToQuery(req.GetAsOf())
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.query.GetUserByIDAsOf(ctx, req.GetUserId(), asOf)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetUserByIDAsOfResponse{
+		User: user_grpc.UserToPb(user, s.assetsAPIDomain(ctx)),
+	}, nil
+}
+
+func (s *Server) ListUserGrantsAsOf(ctx context.Context, req *admin_pb.ListUserGrantsAsOfRequest) (*admin_pb.ListUserGrantsAsOfResponse, error) {
+	asOf, err := asOfToQuery(req.GetAsOf())
+	if err != nil {
+		return nil, err
+	}
+	grants, err := s.query.UserGrantsAsOf(ctx, req.GetUserId(), asOf)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListUserGrantsAsOfResponse{
+		Result: user_grpc.UserGrantsToPb(s.assetsAPIDomain(ctx), grants),
+	}, nil
+}
+
+func (s *Server) ListUserMembershipsAsOf(ctx context.Context, req *admin_pb.ListUserMembershipsAsOfRequest) (*admin_pb.ListUserMembershipsAsOfResponse, error) {
+	asOf, err := asOfToQuery(req.GetAsOf())
+	if err != nil {
+		return nil, err
+	}
+	memberships, err := s.query.MembershipsAsOf(ctx, req.GetUserId(), asOf)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListUserMembershipsAsOfResponse{
+		Result: user_grpc.MembershipsToMembershipsPb(memberships),
+	}, nil
+}
+
+func (s *Server) GetOrgByIDAsOf(ctx context.Context, req *admin_pb.GetOrgByIDAsOfRequest) (*admin_pb.GetOrgByIDAsOfResponse, error) {
+	asOf, err := asOfToQuery(req.GetAsOf())
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.query.OrgByIDAsOf(ctx, req.GetId(), asOf)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetOrgByIDAsOfResponse{
+		Org: org_grpc.OrgViewToPb(org),
+	}, nil
+}
+
+func asOfToQuery(asOf *admin_pb.AsOf) (*query.AsOf, error) {
+	if date := asOf.GetDate(); date != nil {
+		return &query.AsOf{Date: date.AsTime()}, nil
+	}
+	position, err := decimal.NewFromString(asOf.GetPosition())
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "ADMIN-eiG7o", "Errors.Query.InvalidRequest")
+	}
+	return &query.AsOf{Position: position}, nil
+}
@@ -0,0 +1,56 @@
+package admin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/query"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func Test_asOfToQuery(t *testing.T) {
+	date := time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		asOf    *admin_pb.AsOf
+		want    *query.AsOf
+		wantErr bool
+	}{
+		{
+			name: "position",
+			asOf: &admin_pb.AsOf{AsOf: &admin_pb.AsOf_Position{Position: "1712662457.365215"}},
+			want: &query.AsOf{Position: decimal.RequireFromString("1712662457.365215")},
+		},
+		{
+			name: "date",
+			asOf: &admin_pb.AsOf{AsOf: &admin_pb.AsOf_Date{Date: timestamppb.New(date)}},
+			want: &query.AsOf{Date: date},
+		},
+		{
+			name:    "invalid position",
+			asOf:    &admin_pb.AsOf{AsOf: &admin_pb.AsOf_Position{Position: "invalid"}},
+			wantErr: true,
+		},
+		{
+			name:    "missing",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := asOfToQuery(tt.asOf)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Position.Equal(got.Position))
+			assert.Equal(t, tt.want.Date, got.Date)
+		})
+	}
+}
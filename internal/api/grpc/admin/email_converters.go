@@ -62,9 +62,61 @@ func emailProviderToPb(config *query.SMTPConfig) settings_pb.EmailConfig {
 	if config.HTTPConfig != nil {
 		return httpToPb(config.HTTPConfig)
 	}
+	if config.APIConfig != nil {
+		return emailAPIToPb(config.APIConfig)
+	}
 	return nil
 }
 
+func emailAPIToPb(config *query.EmailAPI) *settings_pb.EmailProvider_Api {
+	return &settings_pb.EmailProvider_Api{
+		Api: &settings_pb.EmailProviderAPI{
+			Provider:       emailAPIProviderToPb(config.Provider),
+			SenderAddress:  config.SenderAddress,
+			SenderName:     config.SenderName,
+			ReplyToAddress: config.ReplyToAddress,
+			Endpoint:       config.Endpoint,
+			Region:         config.Region,
+			Domain:         config.Domain,
+			KeyId:          config.KeyID,
+		},
+	}
+}
+
+func emailAPIProviderToPb(provider domain.EmailAPIProvider) settings_pb.EmailAPIProvider {
+	switch provider {
+	case domain.EmailAPIProviderSES:
+		return settings_pb.EmailAPIProvider_EMAIL_API_PROVIDER_SES
+	case domain.EmailAPIProviderSendGrid:
+		return settings_pb.EmailAPIProvider_EMAIL_API_PROVIDER_SENDGRID
+	case domain.EmailAPIProviderMailgun:
+		return settings_pb.EmailAPIProvider_EMAIL_API_PROVIDER_MAILGUN
+	case domain.EmailAPIProviderJSON:
+		return settings_pb.EmailAPIProvider_EMAIL_API_PROVIDER_JSON
+	case domain.EmailAPIProviderUnspecified:
+		return settings_pb.EmailAPIProvider_EMAIL_API_PROVIDER_UNSPECIFIED
+	default:
+		return settings_pb.EmailAPIProvider_EMAIL_API_PROVIDER_UNSPECIFIED
+	}
+}
+
+func emailAPIProviderToDomain(provider settings_pb.EmailAPIProvider) domain.EmailAPIProvider {
+	switch provider {
+	case settings_pb.EmailAPIProvider_EMAIL_API_PROVIDER_SES:
+		return domain.EmailAPIProviderSES
+	case settings_pb.EmailAPIProvider_EMAIL_API_PROVIDER_SENDGRID:
+		return domain.EmailAPIProviderSendGrid
+	case settings_pb.EmailAPIProvider_EMAIL_API_PROVIDER_MAILGUN:
+		return domain.EmailAPIProviderMailgun
+	case settings_pb.EmailAPIProvider_EMAIL_API_PROVIDER_JSON:
+		return domain.EmailAPIProviderJSON
+	case settings_pb.EmailAPIProvider_EMAIL_API_PROVIDER_UNSPECIFIED:
+		return domain.EmailAPIProviderUnspecified
+	default:
+		return domain.EmailAPIProviderUnspecified
+	}
+}
+
 func httpToPb(http *query.HTTP) *settings_pb.EmailProvider_Http {
 	return &settings_pb.EmailProvider_Http{
 		Http: &settings_pb.EmailProviderHTTP{
@@ -131,6 +183,38 @@ func updateEmailProviderHTTPToConfig(ctx context.Context, req *admin_pb.UpdateEm
 	}
 }
 
+func addEmailProviderAPIToConfig(ctx context.Context, req *admin_pb.AddEmailProviderAPIRequest) *command.AddSMTPConfigAPI {
+	return &command.AddSMTPConfigAPI{
+		ResourceOwner:  authz.GetInstance(ctx).InstanceID(),
+		Description:    req.Description,
+		Provider:       emailAPIProviderToDomain(req.Provider),
+		SenderAddress:  req.SenderAddress,
+		SenderName:     req.SenderName,
+		ReplyToAddress: req.ReplyToAddress,
+		Endpoint:       req.Endpoint,
+		Region:         req.Region,
+		Domain:         req.Domain,
+		KeyID:          req.KeyId,
+		APIKey:         req.ApiKey,
+	}
+}
+
+func updateEmailProviderAPIToConfig(ctx context.Context, req *admin_pb.UpdateEmailProviderAPIRequest) *command.ChangeSMTPConfigAPI {
+	return &command.ChangeSMTPConfigAPI{
+		ResourceOwner:  authz.GetInstance(ctx).InstanceID(),
+		ID:             req.Id,
+		Description:    req.Description,
+		SenderAddress:  req.SenderAddress,
+		SenderName:     req.SenderName,
+		ReplyToAddress: req.ReplyToAddress,
+		Endpoint:       req.Endpoint,
+		Region:         req.Region,
+		Domain:         req.Domain,
+		KeyID:          req.KeyId,
+		APIKey:         req.ApiKey,
+	}
+}
+
 func testEmailProviderSMTPToConfig(req *admin_pb.TestEmailProviderSMTPRequest) *smtp.Config {
 	return &smtp.Config{
 		Tls:      req.Tls,
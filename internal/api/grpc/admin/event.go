@@ -5,13 +5,18 @@ import (
 	"slices"
 	"time"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
 	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
 )
 
 const (
 	maxLimit = 1000
+	// streamEventsPollInterval is the time to wait for new events after all stored events are streamed
+	streamEventsPollInterval = time.Second
 )
 
 func (s *Server) ListEvents(ctx context.Context, in *admin_pb.ListEventsRequest) (*admin_pb.ListEventsResponse, error) {
@@ -27,6 +32,46 @@ func (s *Server) ListEvents(ctx context.Context, in *admin_pb.ListEventsRequest)
 	return admin_pb.EventsToPb(ctx, events)
 }
 
+func (s *Server) StreamEvents(in *admin_pb.StreamEventsRequest, stream admin_pb.AdminService_StreamEventsServer) error {
+	ctx := stream.Context()
+	position, err := decimal.NewFromString(in.GetPosition())
+	if in.GetPosition() == "" {
+		position, err = decimal.Decimal{}, nil
+	}
+	if err != nil {
+		return zerrors.ThrowInvalidArgument(err, "ADMIN-Ro5ei", "Errors.InvalidArgument")
+	}
+	offset := in.GetOffset()
+	for {
+		events, err := s.query.SearchEvents(ctx, streamEventsRequestToFilter(ctx, in, position, offset))
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if event.Position.Equal(position) {
+				offset++
+			} else {
+				position, offset = event.Position, 1
+			}
+			resp, err := admin_pb.StreamEventToPb(event, offset)
+			if err != nil {
+				return err
+			}
+			if err = stream.Send(resp); err != nil {
+				return err
+			}
+		}
+		if len(events) == maxLimit {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(streamEventsPollInterval):
+		}
+	}
+}
+
 func (s *Server) ListEventTypes(ctx context.Context, in *admin_pb.ListEventTypesRequest) (*admin_pb.ListEventTypesResponse, error) {
 	eventTypes := s.query.SearchEventTypes(ctx)
 	return admin_pb.EventTypesToPb(eventTypes), nil
@@ -109,6 +154,42 @@ func eventRequestToFilter(ctx context.Context, req *admin_pb.ListEventsRequest)
 	return builder, nil
 }
 
+// streamEventsRequestToFilter queries the events after the events already sent.
+// Events pushed in the same transaction share the position,
+// the offset skips the events of the position already sent.
+func streamEventsRequestToFilter(ctx context.Context, req *admin_pb.StreamEventsRequest, position decimal.Decimal, offset uint32) *eventstore.SearchQueryBuilder {
+	eventTypes := make([]eventstore.EventType, len(req.EventTypes))
+	for i, eventType := range req.EventTypes {
+		eventTypes[i] = eventstore.EventType(eventType)
+	}
+	aggregateTypes := make([]eventstore.AggregateType, len(req.AggregateTypes))
+	for i, aggregateType := range req.AggregateTypes {
+		aggregateTypes[i] = eventstore.AggregateType(aggregateType)
+	}
+	if len(aggregateTypes) == 0 {
+		aggregateTypes = aggregateTypesFromEventTypes(eventTypes)
+	}
+	aggregateTypes = slices.Compact(aggregateTypes)
+
+	builder := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		OrderAsc().
+		InstanceID(authz.GetInstance(ctx).InstanceID()).
+		Limit(maxLimit).
+		AwaitOpenTransactions().
+		ResourceOwner(req.ResourceOwner).
+		PositionAtLeast(position)
+	if offset > 0 {
+		builder = builder.Offset(offset)
+	}
+	if len(aggregateTypes) > 0 || len(eventTypes) > 0 {
+		builder.AddQuery().
+			AggregateTypes(aggregateTypes...).
+			EventTypes(eventTypes...).
+			Builder()
+	}
+	return builder
+}
+
 func aggregateTypesFromEventTypes(eventTypes []eventstore.EventType) []eventstore.AggregateType {
 	aggregateTypes := make([]eventstore.AggregateType, 0, len(eventTypes))
 
@@ -1,13 +1,20 @@
 package admin
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/deviceauth"
 	"github.com/zitadel/zitadel/internal/repository/org"
 	"github.com/zitadel/zitadel/internal/repository/user"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
 )
 
 func Test_aggregateTypesFromEventTypes(t *testing.T) {
@@ -56,3 +63,60 @@ func Test_aggregateTypesFromEventTypes(t *testing.T) {
 		})
 	}
 }
+
+func Test_streamEventsRequestToFilter(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instanceID")
+	tests := []struct {
+		name               string
+		req                *admin_pb.StreamEventsRequest
+		position           decimal.Decimal
+		offset             uint32
+		wantAggregateTypes []eventstore.AggregateType
+		wantEventTypes     []eventstore.EventType
+	}{
+		{
+			name: "without filters",
+			req:  &admin_pb.StreamEventsRequest{},
+		},
+		{
+			name:     "resume with offset",
+			req:      &admin_pb.StreamEventsRequest{},
+			position: decimal.NewFromFloat(1712662457.365215),
+			offset:   2,
+		},
+		{
+			name: "aggregate types from event types",
+			req: &admin_pb.StreamEventsRequest{
+				EventTypes: []string{string(user.MachineAddedEventType), string(user.HumanAddedType)},
+			},
+			wantAggregateTypes: []eventstore.AggregateType{user.AggregateType},
+			wantEventTypes:     []eventstore.EventType{user.MachineAddedEventType, user.HumanAddedType},
+		},
+		{
+			name: "aggregate types",
+			req: &admin_pb.StreamEventsRequest{
+				AggregateTypes: []string{string(org.AggregateType)},
+			},
+			wantAggregateTypes: []eventstore.AggregateType{org.AggregateType},
+			wantEventTypes:     []eventstore.EventType{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := streamEventsRequestToFilter(ctx, tt.req, tt.position, tt.offset)
+			assert.Equal(t, "instanceID", *got.GetInstanceID())
+			assert.False(t, got.GetDesc())
+			assert.Equal(t, uint64(maxLimit), got.GetLimit())
+			assert.True(t, got.GetAwaitOpenTransactions())
+			assert.True(t, tt.position.Equal(got.GetPositionAtLeast()))
+			assert.Equal(t, tt.offset, got.GetOffset())
+			if tt.wantAggregateTypes == nil {
+				assert.Empty(t, got.GetQueries())
+				return
+			}
+			require.Len(t, got.GetQueries(), 1)
+			assert.Equal(t, tt.wantAggregateTypes, got.GetQueries()[0].GetAggregateTypes())
+			assert.Equal(t, tt.wantEventTypes, got.GetQueries()[0].GetEventTypes())
+		})
+	}
+}
@@ -516,11 +516,12 @@ func (s *Server) getPasswordComplexityPolicy(ctx context.Context, orgID string)
 	}
 	if !queriedPasswordComplexity.IsDefault {
 		return &management_pb.AddCustomPasswordComplexityPolicyRequest{
-			MinLength:    queriedPasswordComplexity.MinLength,
-			HasUppercase: queriedPasswordComplexity.HasUppercase,
-			HasLowercase: queriedPasswordComplexity.HasLowercase,
-			HasNumber:    queriedPasswordComplexity.HasNumber,
-			HasSymbol:    queriedPasswordComplexity.HasSymbol,
+			MinLength:     queriedPasswordComplexity.MinLength,
+			HasUppercase:  queriedPasswordComplexity.HasUppercase,
+			HasLowercase:  queriedPasswordComplexity.HasLowercase,
+			HasNumber:     queriedPasswordComplexity.HasNumber,
+			HasSymbol:     queriedPasswordComplexity.HasSymbol,
+			CheckBreached: queriedPasswordComplexity.CheckBreached,
 		}, nil
 	}
 	return nil, nil
@@ -802,23 +803,28 @@ func (s *Server) getProjectsAndApps(ctx context.Context, org string) ([]*v1_pb.D
 				oidcApps = append(oidcApps, &v1_pb.DataOIDCApplication{
 					AppId: app.ID,
 					App: &management_pb.AddOIDCAppRequest{
-						ProjectId:                app.ProjectID,
-						Name:                     app.Name,
-						RedirectUris:             app.OIDCConfig.RedirectURIs,
-						ResponseTypes:            responseTypes,
-						GrantTypes:               grantTypes,
-						AppType:                  app_pb.OIDCAppType(app.OIDCConfig.AppType),
-						AuthMethodType:           app_pb.OIDCAuthMethodType(app.OIDCConfig.AuthMethodType),
-						PostLogoutRedirectUris:   app.OIDCConfig.PostLogoutRedirectURIs,
-						Version:                  app_pb.OIDCVersion(app.OIDCConfig.Version),
-						DevMode:                  app.OIDCConfig.IsDevMode,
-						AccessTokenType:          app_pb.OIDCTokenType(app.OIDCConfig.AccessTokenType),
-						AccessTokenRoleAssertion: app.OIDCConfig.AssertAccessTokenRole,
-						IdTokenRoleAssertion:     app.OIDCConfig.AssertIDTokenRole,
-						IdTokenUserinfoAssertion: app.OIDCConfig.AssertIDTokenUserinfo,
-						ClockSkew:                durationpb.New(app.OIDCConfig.ClockSkew),
-						AdditionalOrigins:        app.OIDCConfig.AdditionalOrigins,
-						SkipNativeAppSuccessPage: app.OIDCConfig.SkipNativeAppSuccessPage,
+						ProjectId:                             app.ProjectID,
+						Name:                                  app.Name,
+						RedirectUris:                          app.OIDCConfig.RedirectURIs,
+						ResponseTypes:                         responseTypes,
+						GrantTypes:                            grantTypes,
+						AppType:                               app_pb.OIDCAppType(app.OIDCConfig.AppType),
+						AuthMethodType:                        app_pb.OIDCAuthMethodType(app.OIDCConfig.AuthMethodType),
+						PostLogoutRedirectUris:                app.OIDCConfig.PostLogoutRedirectURIs,
+						Version:                               app_pb.OIDCVersion(app.OIDCConfig.Version),
+						DevMode:                               app.OIDCConfig.IsDevMode,
+						AccessTokenType:                       app_pb.OIDCTokenType(app.OIDCConfig.AccessTokenType),
+						AccessTokenRoleAssertion:              app.OIDCConfig.AssertAccessTokenRole,
+						IdTokenRoleAssertion:                  app.OIDCConfig.AssertIDTokenRole,
+						IdTokenUserinfoAssertion:              app.OIDCConfig.AssertIDTokenUserinfo,
+						ClockSkew:                             durationpb.New(app.OIDCConfig.ClockSkew),
+						AdditionalOrigins:                     app.OIDCConfig.AdditionalOrigins,
+						SkipNativeAppSuccessPage:              app.OIDCConfig.SkipNativeAppSuccessPage,
+						RequirePushedAuthorizationRequests:    app.OIDCConfig.RequirePushedAuthorizationRequests,
+						RequireDpop:                           app.OIDCConfig.RequireDPoP,
+						BackchannelTokenDeliveryMode:          app_pb.OIDCBackchannelTokenDeliveryMode(app.OIDCConfig.BackchannelTokenDeliveryMode),
+						BackchannelClientNotificationEndpoint: app.OIDCConfig.BackchannelClientNotificationEndpoint,
+						TlsClientAuthSubjectDn:                app.OIDCConfig.TLSClientAuthSubjectDN,
 					},
 				})
 			}
@@ -186,6 +186,11 @@ func SecurityPolicyToPb(policy *query.SecurityPolicy) *settings_pb.SecurityPolic
 		EnableIframeEmbedding: policy.EnableIframeEmbedding,
 		AllowedOrigins:        policy.AllowedOrigins,
 		EnableImpersonation:   policy.EnableImpersonation,
+
+		WebauthnAttestationRequired: policy.WebAuthNAttestationRequired,
+		WebauthnAllowedAaguids:      policy.WebAuthNAllowedAAGUIDs,
+		WebauthnDeniedAaguids:       policy.WebAuthNDeniedAAGUIDs,
+		WebauthnCertificationLevels: policy.WebAuthNCertificationLevels,
 	}
 }
 
@@ -194,5 +199,11 @@ func securityPolicyToCommand(req *admin_pb.SetSecurityPolicyRequest) *command.Se
 		EnableIframeEmbedding: req.GetEnableIframeEmbedding(),
 		AllowedOrigins:        req.GetAllowedOrigins(),
 		EnableImpersonation:   req.GetEnableImpersonation(),
+		WebAuthNAttestation: domain.WebAuthNAttestationPolicy{
+			Required:            req.GetWebauthnAttestationRequired(),
+			AllowedAAGUIDs:      req.GetWebauthnAllowedAaguids(),
+			DeniedAAGUIDs:       req.GetWebauthnDeniedAaguids(),
+			CertificationLevels: req.GetWebauthnCertificationLevels(),
+		},
 	}
 }
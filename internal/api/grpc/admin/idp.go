@@ -164,6 +164,14 @@ func (s *Server) GetProviderByID(ctx context.Context, req *admin_pb.GetProviderB
 	return &admin_pb.GetProviderByIDResponse{Idp: idp_grpc.ProviderToPb(idp)}, nil
 }
 
+func (s *Server) EvaluateProviderClaimMapping(ctx context.Context, req *admin_pb.EvaluateProviderClaimMappingRequest) (*admin_pb.EvaluateProviderClaimMappingResponse, error) {
+	result, err := idp_grpc.EvaluateClaimMapping(req.GetClaimMapping(), req.GetIdToken(), req.GetUserinfo())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.EvaluateProviderClaimMappingResponse{Result: result}, nil
+}
+
 func (s *Server) ListProviders(ctx context.Context, req *admin_pb.ListProvidersRequest) (*admin_pb.ListProvidersResponse, error) {
 	queries, err := listProvidersToQuery(authz.GetInstance(ctx).InstanceID(), req)
 	if err != nil {
@@ -363,6 +371,111 @@ func (s *Server) UpdateGitLabSelfHostedProvider(ctx context.Context, req *admin_
 	}, nil
 }
 
+func (s *Server) AddOktaProvider(ctx context.Context, req *admin_pb.AddOktaProviderRequest) (*admin_pb.AddOktaProviderResponse, error) {
+	id, details, err := s.command.AddInstanceOktaProvider(ctx, addOktaProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddOktaProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateOktaProvider(ctx context.Context, req *admin_pb.UpdateOktaProviderRequest) (*admin_pb.UpdateOktaProviderResponse, error) {
+	details, err := s.command.UpdateInstanceOktaProvider(ctx, req.Id, updateOktaProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateOktaProviderResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) AddKeycloakProvider(ctx context.Context, req *admin_pb.AddKeycloakProviderRequest) (*admin_pb.AddKeycloakProviderResponse, error) {
+	id, details, err := s.command.AddInstanceKeycloakProvider(ctx, addKeycloakProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddKeycloakProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateKeycloakProvider(ctx context.Context, req *admin_pb.UpdateKeycloakProviderRequest) (*admin_pb.UpdateKeycloakProviderResponse, error) {
+	details, err := s.command.UpdateInstanceKeycloakProvider(ctx, req.Id, updateKeycloakProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateKeycloakProviderResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) AddADFSProvider(ctx context.Context, req *admin_pb.AddADFSProviderRequest) (*admin_pb.AddADFSProviderResponse, error) {
+	id, details, err := s.command.AddInstanceADFSProvider(ctx, addADFSProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddADFSProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateADFSProvider(ctx context.Context, req *admin_pb.UpdateADFSProviderRequest) (*admin_pb.UpdateADFSProviderResponse, error) {
+	details, err := s.command.UpdateInstanceADFSProvider(ctx, req.Id, updateADFSProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateADFSProviderResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) AddBitbucketProvider(ctx context.Context, req *admin_pb.AddBitbucketProviderRequest) (*admin_pb.AddBitbucketProviderResponse, error) {
+	id, details, err := s.command.AddInstanceBitbucketProvider(ctx, addBitbucketProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddBitbucketProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateBitbucketProvider(ctx context.Context, req *admin_pb.UpdateBitbucketProviderRequest) (*admin_pb.UpdateBitbucketProviderResponse, error) {
+	details, err := s.command.UpdateInstanceBitbucketProvider(ctx, req.Id, updateBitbucketProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateBitbucketProviderResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) AddDiscordProvider(ctx context.Context, req *admin_pb.AddDiscordProviderRequest) (*admin_pb.AddDiscordProviderResponse, error) {
+	id, details, err := s.command.AddInstanceDiscordProvider(ctx, addDiscordProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddDiscordProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateDiscordProvider(ctx context.Context, req *admin_pb.UpdateDiscordProviderRequest) (*admin_pb.UpdateDiscordProviderResponse, error) {
+	details, err := s.command.UpdateInstanceDiscordProvider(ctx, req.Id, updateDiscordProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateDiscordProviderResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) AddGoogleProvider(ctx context.Context, req *admin_pb.AddGoogleProviderRequest) (*admin_pb.AddGoogleProviderResponse, error) {
 	id, details, err := s.command.AddInstanceGoogleProvider(ctx, addGoogleProviderToCommand(req))
 	if err != nil {
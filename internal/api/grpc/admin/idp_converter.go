@@ -216,6 +216,7 @@ func addGenericOAuthProviderToCommand(req *admin_pb.AddGenericOAuthProviderReque
 		Scopes:                req.Scopes,
 		IDAttribute:           req.IdAttribute,
 		UsePKCE:               req.UsePkce,
+		ClaimMapping:          idp_grpc.ClaimMappingToCommand(req.ClaimMapping),
 		IDPOptions:            idp_grpc.OptionsToCommand(req.ProviderOptions),
 	}
 }
@@ -231,6 +232,7 @@ func updateGenericOAuthProviderToCommand(req *admin_pb.UpdateGenericOAuthProvide
 		Scopes:                req.Scopes,
 		IDAttribute:           req.IdAttribute,
 		UsePKCE:               req.UsePkce,
+		ClaimMapping:          idp_grpc.ClaimMappingToCommand(req.ClaimMapping),
 		IDPOptions:            idp_grpc.OptionsToCommand(req.ProviderOptions),
 	}
 }
@@ -244,6 +246,7 @@ func addGenericOIDCProviderToCommand(req *admin_pb.AddGenericOIDCProviderRequest
 		Scopes:           req.Scopes,
 		IsIDTokenMapping: req.IsIdTokenMapping,
 		UsePKCE:          req.UsePkce,
+		ClaimMapping:     idp_grpc.ClaimMappingToCommand(req.ClaimMapping),
 		IDPOptions:       idp_grpc.OptionsToCommand(req.ProviderOptions),
 	}
 }
@@ -257,6 +260,7 @@ func updateGenericOIDCProviderToCommand(req *admin_pb.UpdateGenericOIDCProviderR
 		Scopes:           req.Scopes,
 		IsIDTokenMapping: req.IsIdTokenMapping,
 		UsePKCE:          req.UsePkce,
+		ClaimMapping:     idp_grpc.ClaimMappingToCommand(req.ClaimMapping),
 		IDPOptions:       idp_grpc.OptionsToCommand(req.ProviderOptions),
 	}
 }
@@ -395,6 +399,112 @@ func updateGitLabSelfHostedProviderToCommand(req *admin_pb.UpdateGitLabSelfHoste
 	}
 }
 
+func addOktaProviderToCommand(req *admin_pb.AddOktaProviderRequest) command.OktaProvider {
+	return command.OktaProvider{
+		Name:         req.Name,
+		Issuer:       req.Issuer,
+		ClientID:     req.ClientId,
+		ClientSecret: req.ClientSecret,
+		Scopes:       req.Scopes,
+		IDPOptions:   idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
+func updateOktaProviderToCommand(req *admin_pb.UpdateOktaProviderRequest) command.OktaProvider {
+	return command.OktaProvider{
+		Name:         req.Name,
+		Issuer:       req.Issuer,
+		ClientID:     req.ClientId,
+		ClientSecret: req.ClientSecret,
+		Scopes:       req.Scopes,
+		IDPOptions:   idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
+func addKeycloakProviderToCommand(req *admin_pb.AddKeycloakProviderRequest) command.KeycloakProvider {
+	return command.KeycloakProvider{
+		Name:         req.Name,
+		Issuer:       req.Issuer,
+		ClientID:     req.ClientId,
+		ClientSecret: req.ClientSecret,
+		Scopes:       req.Scopes,
+		IDPOptions:   idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
+func updateKeycloakProviderToCommand(req *admin_pb.UpdateKeycloakProviderRequest) command.KeycloakProvider {
+	return command.KeycloakProvider{
+		Name:         req.Name,
+		Issuer:       req.Issuer,
+		ClientID:     req.ClientId,
+		ClientSecret: req.ClientSecret,
+		Scopes:       req.Scopes,
+		IDPOptions:   idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
+func addADFSProviderToCommand(req *admin_pb.AddADFSProviderRequest) command.ADFSProvider {
+	return command.ADFSProvider{
+		Name:         req.Name,
+		Issuer:       req.Issuer,
+		ClientID:     req.ClientId,
+		ClientSecret: req.ClientSecret,
+		Scopes:       req.Scopes,
+		IDPOptions:   idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
+func updateADFSProviderToCommand(req *admin_pb.UpdateADFSProviderRequest) command.ADFSProvider {
+	return command.ADFSProvider{
+		Name:         req.Name,
+		Issuer:       req.Issuer,
+		ClientID:     req.ClientId,
+		ClientSecret: req.ClientSecret,
+		Scopes:       req.Scopes,
+		IDPOptions:   idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
+func addBitbucketProviderToCommand(req *admin_pb.AddBitbucketProviderRequest) command.BitbucketProvider {
+	return command.BitbucketProvider{
+		Name:         req.Name,
+		ClientID:     req.ClientId,
+		ClientSecret: req.ClientSecret,
+		Scopes:       req.Scopes,
+		IDPOptions:   idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
+func updateBitbucketProviderToCommand(req *admin_pb.UpdateBitbucketProviderRequest) command.BitbucketProvider {
+	return command.BitbucketProvider{
+		Name:         req.Name,
+		ClientID:     req.ClientId,
+		ClientSecret: req.ClientSecret,
+		Scopes:       req.Scopes,
+		IDPOptions:   idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
+func addDiscordProviderToCommand(req *admin_pb.AddDiscordProviderRequest) command.DiscordProvider {
+	return command.DiscordProvider{
+		Name:         req.Name,
+		ClientID:     req.ClientId,
+		ClientSecret: req.ClientSecret,
+		Scopes:       req.Scopes,
+		IDPOptions:   idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
+func updateDiscordProviderToCommand(req *admin_pb.UpdateDiscordProviderRequest) command.DiscordProvider {
+	return command.DiscordProvider{
+		Name:         req.Name,
+		ClientID:     req.ClientId,
+		ClientSecret: req.ClientSecret,
+		Scopes:       req.Scopes,
+		IDPOptions:   idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
 func addGoogleProviderToCommand(req *admin_pb.AddGoogleProviderRequest) command.GoogleProvider {
 	return command.GoogleProvider{
 		Name:         req.Name,
@@ -0,0 +1,122 @@
+package admin
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	object_pb "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/query"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+const defaultLDAPSyncReportsLimit = 10
+
+func (s *Server) SetLDAPProviderSync(ctx context.Context, req *admin_pb.SetLDAPProviderSyncRequest) (*admin_pb.SetLDAPProviderSyncResponse, error) {
+	details, err := s.command.SetInstanceLDAPSync(ctx, req.GetId(), setLDAPProviderSyncToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetLDAPProviderSyncResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GetLDAPProviderSync(ctx context.Context, req *admin_pb.GetLDAPProviderSyncRequest) (*admin_pb.GetLDAPProviderSyncResponse, error) {
+	sync, err := s.query.LDAPSyncByIDPID(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetLDAPProviderSyncResponse{
+		Sync: ldapSyncToPb(sync, authz.GetInstance(ctx).InstanceID()),
+	}, nil
+}
+
+func (s *Server) RemoveLDAPProviderSync(ctx context.Context, req *admin_pb.RemoveLDAPProviderSyncRequest) (*admin_pb.RemoveLDAPProviderSyncResponse, error) {
+	details, err := s.command.RemoveInstanceLDAPSync(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RemoveLDAPProviderSyncResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListLDAPProviderSyncReports(ctx context.Context, req *admin_pb.ListLDAPProviderSyncReportsRequest) (*admin_pb.ListLDAPProviderSyncReportsResponse, error) {
+	limit := uint64(req.GetLimit())
+	if limit == 0 {
+		limit = defaultLDAPSyncReportsLimit
+	}
+	reports, err := s.query.LDAPSyncReports(ctx, req.GetId(), limit)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListLDAPProviderSyncReportsResponse{
+		Result: ldapSyncReportsToPb(reports),
+	}, nil
+}
+
+func setLDAPProviderSyncToCommand(req *admin_pb.SetLDAPProviderSyncRequest) *command.LDAPSync {
+	groupGrants := make([]*command.LDAPSyncGroupGrant, len(req.GetGroupGrants()))
+	for i, grant := range req.GetGroupGrants() {
+		groupGrants[i] = &command.LDAPSyncGroupGrant{
+			Group:     grant.GetGroup(),
+			ProjectID: grant.GetProjectId(),
+			RoleKeys:  grant.GetRoleKeys(),
+		}
+	}
+	return &command.LDAPSync{
+		Interval:          req.GetInterval().AsDuration(),
+		Filter:            req.GetFilter(),
+		PageSize:          req.GetPageSize(),
+		OrganizationID:    req.GetOrganizationId(),
+		DeactivateMissing: req.GetDeactivateMissing(),
+		GroupAttribute:    req.GetGroupAttribute(),
+		GroupGrants:       groupGrants,
+	}
+}
+
+func ldapSyncToPb(sync *query.LDAPSync, instanceID string) *admin_pb.LDAPProviderSync {
+	groupGrants := make([]*admin_pb.LDAPProviderSyncGroupGrant, len(sync.GroupGrants))
+	for i, grant := range sync.GroupGrants {
+		groupGrants[i] = &admin_pb.LDAPProviderSyncGroupGrant{
+			Group:     grant.Group,
+			ProjectId: grant.ProjectID,
+			RoleKeys:  grant.RoleKeys,
+		}
+	}
+	return &admin_pb.LDAPProviderSync{
+		Details:           object_pb.ToViewDetailsPb(sync.Sequence, sync.CreationDate, sync.ChangeDate, instanceID),
+		Interval:          durationpb.New(sync.Interval),
+		Filter:            sync.Filter,
+		PageSize:          sync.PageSize,
+		OrganizationId:    sync.OrganizationID,
+		DeactivateMissing: sync.DeactivateMissing,
+		GroupAttribute:    sync.GroupAttribute,
+		GroupGrants:       groupGrants,
+	}
+}
+
+func ldapSyncReportsToPb(reports []*query.LDAPSyncReport) []*admin_pb.LDAPProviderSyncReport {
+	result := make([]*admin_pb.LDAPProviderSyncReport, len(reports))
+	for i, report := range reports {
+		result[i] = &admin_pb.LDAPProviderSyncReport{
+			StartedAt:     timestamppb.New(report.StartedAt),
+			EndedAt:       timestamppb.New(report.EndedAt),
+			Created:       report.Created,
+			Updated:       report.Updated,
+			Deactivated:   report.Deactivated,
+			Reactivated:   report.Reactivated,
+			Unchanged:     report.Unchanged,
+			GrantsAdded:   report.GrantsAdded,
+			GrantsChanged: report.GrantsChanged,
+			GrantsRemoved: report.GrantsRemoved,
+			Failed:        report.Failed,
+			Errors:        report.Errors,
+		}
+	}
+	return result
+}
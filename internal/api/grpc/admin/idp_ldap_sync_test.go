@@ -0,0 +1,73 @@
+package admin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/zitadel/zitadel/internal/command"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func Test_setLDAPProviderSyncToCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *admin_pb.SetLDAPProviderSyncRequest
+		want *command.LDAPSync
+	}{
+		{
+			name: "minimal",
+			req: &admin_pb.SetLDAPProviderSyncRequest{
+				Id:             "idp1",
+				Interval:       durationpb.New(time.Hour),
+				OrganizationId: "org1",
+			},
+			want: &command.LDAPSync{
+				Interval:       time.Hour,
+				OrganizationID: "org1",
+				GroupGrants:    []*command.LDAPSyncGroupGrant{},
+			},
+		},
+		{
+			name: "all fields",
+			req: &admin_pb.SetLDAPProviderSyncRequest{
+				Id:                "idp1",
+				Interval:          durationpb.New(time.Hour),
+				Filter:            "(department=sales)",
+				PageSize:          100,
+				OrganizationId:    "org1",
+				DeactivateMissing: true,
+				GroupAttribute:    "memberOf",
+				GroupGrants: []*admin_pb.LDAPProviderSyncGroupGrant{
+					{
+						Group:     "cn=sales,ou=groups,dc=example,dc=com",
+						ProjectId: "project1",
+						RoleKeys:  []string{"role1", "role2"},
+					},
+				},
+			},
+			want: &command.LDAPSync{
+				Interval:          time.Hour,
+				Filter:            "(department=sales)",
+				PageSize:          100,
+				OrganizationID:    "org1",
+				DeactivateMissing: true,
+				GroupAttribute:    "memberOf",
+				GroupGrants: []*command.LDAPSyncGroupGrant{
+					{
+						Group:     "cn=sales,ou=groups,dc=example,dc=com",
+						ProjectID: "project1",
+						RoleKeys:  []string{"role1", "role2"},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, setLDAPProviderSyncToCommand(tt.req))
+		})
+	}
+}
@@ -25,6 +25,7 @@ var iamRoles = []string{
 	"IAM_USER_MANAGER",
 	"IAM_ADMIN_IMPERSONATOR",
 	"IAM_END_USER_IMPERSONATOR",
+	"IAM_IDP_TOKEN_READER",
 	"IAM_LOGIN_CLIENT",
 }
 
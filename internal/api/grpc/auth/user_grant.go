@@ -25,6 +25,7 @@ func ListMyUserGrantsRequestToQuery(ctx context.Context, req *auth_pb.ListMyUser
 		Queries: []query.SearchQuery{
 			userGrantUserID,
 		},
+		IncludeGroupGrants: true,
 	}, nil
 }
 
@@ -0,0 +1,129 @@
+package group
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/pkg/grpc/group/v2"
+)
+
+func (s *Server) CreateGroup(ctx context.Context, req *group.CreateGroupRequest) (*group.CreateGroupResponse, error) {
+	add := groupCreateToCommand(req)
+	details, err := s.command.AddGroup(ctx, add)
+	if err != nil {
+		return nil, err
+	}
+	return &group.CreateGroupResponse{
+		Id:           add.AggregateID,
+		CreationDate: timestampOrNil(details.EventDate),
+	}, nil
+}
+
+func groupCreateToCommand(req *group.CreateGroupRequest) *command.AddGroup {
+	var aggregateID string
+	if req.Id != nil {
+		aggregateID = *req.Id
+	}
+	return &command.AddGroup{
+		ObjectRoot: models.ObjectRoot{
+			ResourceOwner: req.OrganizationId,
+			AggregateID:   aggregateID,
+		},
+		Name:        req.Name,
+		Description: req.Description,
+	}
+}
+
+func (s *Server) UpdateGroup(ctx context.Context, req *group.UpdateGroupRequest) (*group.UpdateGroupResponse, error) {
+	details, err := s.command.ChangeGroup(ctx, &command.ChangeGroup{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: req.Id,
+		},
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &group.UpdateGroupResponse{
+		ChangeDate: timestampOrNil(details.EventDate),
+	}, nil
+}
+
+func (s *Server) DeleteGroup(ctx context.Context, req *group.DeleteGroupRequest) (*group.DeleteGroupResponse, error) {
+	details, err := s.command.RemoveGroup(ctx, req.Id, "")
+	if err != nil {
+		return nil, err
+	}
+	return &group.DeleteGroupResponse{
+		DeletionDate: timestampOrNil(details.EventDate),
+	}, nil
+}
+
+func (s *Server) AddGroupMembers(ctx context.Context, req *group.AddGroupMembersRequest) (*group.AddGroupMembersResponse, error) {
+	details, err := s.command.AddGroupMembers(ctx, req.GroupId, "", req.UserIds...)
+	if err != nil {
+		return nil, err
+	}
+	return &group.AddGroupMembersResponse{
+		ChangeDate: timestampOrNil(details.EventDate),
+	}, nil
+}
+
+func (s *Server) RemoveGroupMembers(ctx context.Context, req *group.RemoveGroupMembersRequest) (*group.RemoveGroupMembersResponse, error) {
+	details, err := s.command.RemoveGroupMembers(ctx, req.GroupId, "", req.UserIds...)
+	if err != nil {
+		return nil, err
+	}
+	return &group.RemoveGroupMembersResponse{
+		ChangeDate: timestampOrNil(details.EventDate),
+	}, nil
+}
+
+func (s *Server) CreateGroupGrant(ctx context.Context, req *group.CreateGroupGrantRequest) (*group.CreateGroupGrantResponse, error) {
+	add := &command.AddGroupGrant{
+		GroupID:        req.GroupId,
+		ProjectID:      req.ProjectId,
+		ProjectGrantID: req.GetProjectGrantId(),
+		RoleKeys:       req.RoleKeys,
+	}
+	details, err := s.command.AddGroupGrant(ctx, add)
+	if err != nil {
+		return nil, err
+	}
+	return &group.CreateGroupGrantResponse{
+		Id:           add.GrantID,
+		CreationDate: timestampOrNil(details.EventDate),
+	}, nil
+}
+
+func (s *Server) UpdateGroupGrant(ctx context.Context, req *group.UpdateGroupGrantRequest) (*group.UpdateGroupGrantResponse, error) {
+	details, err := s.command.ChangeGroupGrant(ctx, req.GroupId, "", req.GrantId, req.RoleKeys)
+	if err != nil {
+		return nil, err
+	}
+	return &group.UpdateGroupGrantResponse{
+		ChangeDate: timestampOrNil(details.EventDate),
+	}, nil
+}
+
+func (s *Server) DeleteGroupGrant(ctx context.Context, req *group.DeleteGroupGrantRequest) (*group.DeleteGroupGrantResponse, error) {
+	details, err := s.command.RemoveGroupGrant(ctx, req.GroupId, "", req.GrantId)
+	if err != nil {
+		return nil, err
+	}
+	return &group.DeleteGroupGrantResponse{
+		DeletionDate: timestampOrNil(details.EventDate),
+	}, nil
+}
+
+func timestampOrNil(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,187 @@
+package group
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/filter/v2"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+	"github.com/zitadel/zitadel/pkg/grpc/group/v2"
+)
+
+func (s *Server) GetGroup(ctx context.Context, req *group.GetGroupRequest) (*group.GetGroupResponse, error) {
+	g, err := s.query.GetGroupByID(ctx, req.Id, s.checkPermission)
+	if err != nil {
+		return nil, err
+	}
+	return &group.GetGroupResponse{
+		Group: groupToPb(g),
+	}, nil
+}
+
+func (s *Server) ListGroups(ctx context.Context, req *group.ListGroupsRequest) (*group.ListGroupsResponse, error) {
+	queries, err := s.listGroupsRequestToModel(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.query.SearchGroups(ctx, queries, s.checkPermission)
+	if err != nil {
+		return nil, err
+	}
+	return &group.ListGroupsResponse{
+		Groups:     groupsToPb(resp.Groups),
+		Pagination: filter.QueryToPaginationPb(queries.SearchRequest, resp.SearchResponse),
+	}, nil
+}
+
+func (s *Server) listGroupsRequestToModel(req *group.ListGroupsRequest) (*query.GroupSearchQueries, error) {
+	offset, limit, asc, err := filter.PaginationPbToQuery(s.systemDefaults, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	queries, err := groupFiltersToQuery(req.Filters)
+	if err != nil {
+		return nil, err
+	}
+	return &query.GroupSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        offset,
+			Limit:         limit,
+			Asc:           asc,
+			SortingColumn: groupFieldNameToSortingColumn(req.SortingColumn),
+		},
+		Queries: queries,
+	}, nil
+}
+
+func groupFieldNameToSortingColumn(field *group.GroupFieldName) query.Column {
+	if field == nil {
+		return query.GroupColumnCreationDate
+	}
+	switch *field {
+	case group.GroupFieldName_GROUP_FIELD_NAME_ID:
+		return query.GroupColumnID
+	case group.GroupFieldName_GROUP_FIELD_NAME_CHANGE_DATE:
+		return query.GroupColumnChangeDate
+	case group.GroupFieldName_GROUP_FIELD_NAME_NAME:
+		return query.GroupColumnName
+	case group.GroupFieldName_GROUP_FIELD_NAME_CREATION_DATE,
+		group.GroupFieldName_GROUP_FIELD_NAME_UNSPECIFIED:
+		return query.GroupColumnCreationDate
+	default:
+		return query.GroupColumnCreationDate
+	}
+}
+
+func groupFiltersToQuery(filters []*group.GroupSearchFilter) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(filters))
+	for i, f := range filters {
+		q[i], err = groupFilterToModel(f)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func groupFilterToModel(f *group.GroupSearchFilter) (query.SearchQuery, error) {
+	switch q := f.Filter.(type) {
+	case *group.GroupSearchFilter_NameFilter:
+		return query.NewGroupNameSearchQuery(filter.TextMethodPbToQuery(q.NameFilter.Method), q.NameFilter.GetName())
+	case *group.GroupSearchFilter_GroupIdsFilter:
+		return query.NewGroupIDsSearchQuery(q.GroupIdsFilter.GetGroupIds())
+	case *group.GroupSearchFilter_OrganizationIdFilter:
+		return query.NewGroupResourceOwnerSearchQuery(q.OrganizationIdFilter.GetOrganizationId())
+	case *group.GroupSearchFilter_MemberFilter:
+		return query.NewGroupMemberSearchQuery(q.MemberFilter.GetUserId())
+	default:
+		return nil, zerrors.ThrowInvalidArgument(nil, "GROUP-vR9Xe", "Errors.Query.InvalidRequest")
+	}
+}
+
+func (s *Server) ListGroupMembers(ctx context.Context, req *group.ListGroupMembersRequest) (*group.ListGroupMembersResponse, error) {
+	offset, limit, asc, err := filter.PaginationPbToQuery(s.systemDefaults, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	queries := &query.GroupMemberSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        offset,
+			Limit:         limit,
+			Asc:           asc,
+			SortingColumn: query.GroupMemberColumnCreationDate,
+		},
+	}
+	resp, err := s.query.SearchGroupMembers(ctx, req.GroupId, queries, s.checkPermission)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]*group.GroupMember, len(resp.Members))
+	for i, member := range resp.Members {
+		members[i] = &group.GroupMember{
+			GroupId:      member.GroupID,
+			UserId:       member.UserID,
+			CreationDate: timestamppb.New(member.CreationDate),
+		}
+	}
+	return &group.ListGroupMembersResponse{
+		Members:    members,
+		Pagination: filter.QueryToPaginationPb(queries.SearchRequest, resp.SearchResponse),
+	}, nil
+}
+
+func (s *Server) ListGroupGrants(ctx context.Context, req *group.ListGroupGrantsRequest) (*group.ListGroupGrantsResponse, error) {
+	offset, limit, asc, err := filter.PaginationPbToQuery(s.systemDefaults, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	queries := &query.GroupGrantSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        offset,
+			Limit:         limit,
+			Asc:           asc,
+			SortingColumn: query.GroupGrantColumnCreationDate,
+		},
+	}
+	resp, err := s.query.SearchGroupGrants(ctx, req.GroupId, queries, s.checkPermission)
+	if err != nil {
+		return nil, err
+	}
+	grants := make([]*group.GroupGrant, len(resp.Grants))
+	for i, grant := range resp.Grants {
+		grants[i] = &group.GroupGrant{
+			Id:             grant.ID,
+			GroupId:        grant.GroupID,
+			CreationDate:   timestamppb.New(grant.CreationDate),
+			ChangeDate:     timestamppb.New(grant.ChangeDate),
+			ProjectId:      grant.ProjectID,
+			ProjectGrantId: grant.ProjectGrantID,
+			RoleKeys:       grant.Roles,
+		}
+	}
+	return &group.ListGroupGrantsResponse{
+		Grants:     grants,
+		Pagination: filter.QueryToPaginationPb(queries.SearchRequest, resp.SearchResponse),
+	}, nil
+}
+
+func groupsToPb(groups []*query.Group) []*group.Group {
+	g := make([]*group.Group, len(groups))
+	for i, gr := range groups {
+		g[i] = groupToPb(gr)
+	}
+	return g
+}
+
+func groupToPb(g *query.Group) *group.Group {
+	return &group.Group{
+		Id:             g.ID,
+		OrganizationId: g.ResourceOwner,
+		CreationDate:   timestamppb.New(g.CreationDate),
+		ChangeDate:     timestamppb.New(g.ChangeDate),
+		Name:           g.Name,
+		Description:    g.Description,
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp/mapping"
 	"github.com/zitadel/zitadel/internal/idp/providers/azuread"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/repository/idp"
@@ -313,6 +314,100 @@ func LDAPAttributesToCommand(attributes *idp_pb.LDAPAttributes) idp.LDAPAttribut
 	}
 }
 
+func ClaimMappingToCommand(claimMapping *idp_pb.ClaimMapping) *mapping.Mapping {
+	if claimMapping == nil {
+		return nil
+	}
+	m := &mapping.Mapping{
+		ID:                claimMapping.Id,
+		FirstName:         claimMapping.FirstName,
+		LastName:          claimMapping.LastName,
+		DisplayName:       claimMapping.DisplayName,
+		NickName:          claimMapping.NickName,
+		PreferredUsername: claimMapping.PreferredUsername,
+		Email:             claimMapping.Email,
+		EmailVerified:     claimMapping.EmailVerified,
+		Phone:             claimMapping.Phone,
+		PhoneVerified:     claimMapping.PhoneVerified,
+		PreferredLanguage: claimMapping.PreferredLanguage,
+		AvatarURL:         claimMapping.AvatarUrl,
+		Profile:           claimMapping.Profile,
+	}
+	for _, metadata := range claimMapping.Metadata {
+		m.Metadata = append(m.Metadata, &mapping.MetadataMapping{
+			Key:        metadata.Key,
+			Expression: metadata.Expression,
+		})
+	}
+	for _, grant := range claimMapping.Grants {
+		m.Grants = append(m.Grants, &mapping.GrantMapping{
+			Condition:      grant.Condition,
+			ProjectID:      grant.ProjectId,
+			ProjectGrantID: grant.ProjectGrantId,
+			RoleKeys:       grant.RoleKeys,
+		})
+	}
+	if m.IsEmpty() {
+		return nil
+	}
+	return m
+}
+
+// EvaluateClaimMapping evaluates the claim mapping on the claims of the (unverified) id_token or the userinfo payload.
+func EvaluateClaimMapping(claimMapping *idp_pb.ClaimMapping, idToken, userinfo string) (*idp_pb.ClaimMappingResult, error) {
+	m := ClaimMappingToCommand(claimMapping)
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	payload := []byte(userinfo)
+	if idToken != "" {
+		var err error
+		payload, err = mapping.IDTokenPayload(idToken)
+		if err != nil {
+			return nil, err
+		}
+	}
+	result, err := m.Evaluate(payload)
+	if err != nil {
+		return nil, err
+	}
+	return ClaimMappingResultToPb(result), nil
+}
+
+func ClaimMappingResultToPb(result *mapping.Result) *idp_pb.ClaimMappingResult {
+	res := &idp_pb.ClaimMappingResult{
+		Id:                result.ID,
+		FirstName:         result.FirstName,
+		LastName:          result.LastName,
+		DisplayName:       result.DisplayName,
+		NickName:          result.NickName,
+		PreferredUsername: result.PreferredUsername,
+		Email:             result.Email,
+		EmailVerified:     result.EmailVerified,
+		Phone:             result.Phone,
+		PhoneVerified:     result.PhoneVerified,
+		PreferredLanguage: result.PreferredLanguage,
+		AvatarUrl:         result.AvatarURL,
+		Profile:           result.Profile,
+		Metadata:          make([]*idp_pb.ClaimMappingResultMetadata, len(result.Metadata)),
+		Grants:            make([]*idp_pb.ClaimMappingResultGrant, len(result.Grants)),
+	}
+	for i, metadata := range result.Metadata {
+		res.Metadata[i] = &idp_pb.ClaimMappingResultMetadata{
+			Key:   metadata.Key,
+			Value: metadata.Value,
+		}
+	}
+	for i, grant := range result.Grants {
+		res.Grants[i] = &idp_pb.ClaimMappingResultGrant{
+			ProjectId:      grant.ProjectID,
+			ProjectGrantId: grant.ProjectGrantID,
+			RoleKeys:       grant.RoleKeys,
+		}
+	}
+	return res
+}
+
 func AzureADTenantToCommand(tenant *idp_pb.AzureADTenant) string {
 	if tenant == nil {
 		return string(azuread.CommonTenant)
@@ -408,6 +503,16 @@ func providerTypeToPb(idpType domain.IDPType) idp_pb.ProviderType {
 		return idp_pb.ProviderType_PROVIDER_TYPE_GITLAB
 	case domain.IDPTypeGitLabSelfHosted:
 		return idp_pb.ProviderType_PROVIDER_TYPE_GITLAB_SELF_HOSTED
+	case domain.IDPTypeOkta:
+		return idp_pb.ProviderType_PROVIDER_TYPE_OKTA
+	case domain.IDPTypeKeycloak:
+		return idp_pb.ProviderType_PROVIDER_TYPE_KEYCLOAK
+	case domain.IDPTypeADFS:
+		return idp_pb.ProviderType_PROVIDER_TYPE_ADFS
+	case domain.IDPTypeBitbucket:
+		return idp_pb.ProviderType_PROVIDER_TYPE_BITBUCKET
+	case domain.IDPTypeDiscord:
+		return idp_pb.ProviderType_PROVIDER_TYPE_DISCORD
 	case domain.IDPTypeGoogle:
 		return idp_pb.ProviderType_PROVIDER_TYPE_GOOGLE
 	case domain.IDPTypeApple:
@@ -463,6 +568,26 @@ func configToPb(config *query.IDPTemplate) *idp_pb.ProviderConfig {
 		gitlabSelfHostedConfigToPb(providerConfig, config.GitLabSelfHostedIDPTemplate)
 		return providerConfig
 	}
+	if config.OktaIDPTemplate != nil {
+		oktaConfigToPb(providerConfig, config.OktaIDPTemplate)
+		return providerConfig
+	}
+	if config.KeycloakIDPTemplate != nil {
+		keycloakConfigToPb(providerConfig, config.KeycloakIDPTemplate)
+		return providerConfig
+	}
+	if config.ADFSIDPTemplate != nil {
+		adfsConfigToPb(providerConfig, config.ADFSIDPTemplate)
+		return providerConfig
+	}
+	if config.BitbucketIDPTemplate != nil {
+		bitbucketConfigToPb(providerConfig, config.BitbucketIDPTemplate)
+		return providerConfig
+	}
+	if config.DiscordIDPTemplate != nil {
+		discordConfigToPb(providerConfig, config.DiscordIDPTemplate)
+		return providerConfig
+	}
 	if config.GoogleIDPTemplate != nil {
 		googleConfigToPb(providerConfig, config.GoogleIDPTemplate)
 		return providerConfig
@@ -505,6 +630,7 @@ func oauthConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.OAut
 			Scopes:                template.Scopes,
 			IdAttribute:           template.IDAttribute,
 			UsePkce:               template.UsePKCE,
+			ClaimMapping:          claimMappingToPb(template.ClaimMapping),
 		},
 	}
 }
@@ -517,6 +643,7 @@ func oidcConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.OIDCI
 			Scopes:           template.Scopes,
 			IsIdTokenMapping: template.IsIDTokenMapping,
 			UsePkce:          template.UsePKCE,
+			ClaimMapping:     claimMappingToPb(template.ClaimMapping),
 		},
 	}
 }
@@ -598,6 +725,54 @@ func gitlabSelfHostedConfigToPb(providerConfig *idp_pb.ProviderConfig, template
 	}
 }
 
+func oktaConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.OktaIDPTemplate) {
+	providerConfig.Config = &idp_pb.ProviderConfig_Okta{
+		Okta: &idp_pb.OktaConfig{
+			ClientId: template.ClientID,
+			Issuer:   template.Issuer,
+			Scopes:   template.Scopes,
+		},
+	}
+}
+
+func keycloakConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.KeycloakIDPTemplate) {
+	providerConfig.Config = &idp_pb.ProviderConfig_Keycloak{
+		Keycloak: &idp_pb.KeycloakConfig{
+			ClientId: template.ClientID,
+			Issuer:   template.Issuer,
+			Scopes:   template.Scopes,
+		},
+	}
+}
+
+func adfsConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.ADFSIDPTemplate) {
+	providerConfig.Config = &idp_pb.ProviderConfig_Adfs{
+		Adfs: &idp_pb.ADFSConfig{
+			ClientId: template.ClientID,
+			Issuer:   template.Issuer,
+			Scopes:   template.Scopes,
+		},
+	}
+}
+
+func bitbucketConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.BitbucketIDPTemplate) {
+	providerConfig.Config = &idp_pb.ProviderConfig_Bitbucket{
+		Bitbucket: &idp_pb.BitbucketConfig{
+			ClientId: template.ClientID,
+			Scopes:   template.Scopes,
+		},
+	}
+}
+
+func discordConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.DiscordIDPTemplate) {
+	providerConfig.Config = &idp_pb.ProviderConfig_Discord{
+		Discord: &idp_pb.DiscordConfig{
+			ClientId: template.ClientID,
+			Scopes:   template.Scopes,
+		},
+	}
+}
+
 func googleConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.GoogleIDPTemplate) {
 	providerConfig.Config = &idp_pb.ProviderConfig_Google{
 		Google: &idp_pb.GoogleConfig{
@@ -646,6 +821,44 @@ func ldapAttributesToPb(attributes idp.LDAPAttributes) *idp_pb.LDAPAttributes {
 	}
 }
 
+func claimMappingToPb(claimMapping *mapping.Mapping) *idp_pb.ClaimMapping {
+	if claimMapping == nil {
+		return nil
+	}
+	pb := &idp_pb.ClaimMapping{
+		Id:                claimMapping.ID,
+		FirstName:         claimMapping.FirstName,
+		LastName:          claimMapping.LastName,
+		DisplayName:       claimMapping.DisplayName,
+		NickName:          claimMapping.NickName,
+		PreferredUsername: claimMapping.PreferredUsername,
+		Email:             claimMapping.Email,
+		EmailVerified:     claimMapping.EmailVerified,
+		Phone:             claimMapping.Phone,
+		PhoneVerified:     claimMapping.PhoneVerified,
+		PreferredLanguage: claimMapping.PreferredLanguage,
+		AvatarUrl:         claimMapping.AvatarURL,
+		Profile:           claimMapping.Profile,
+		Metadata:          make([]*idp_pb.ClaimMappingMetadata, len(claimMapping.Metadata)),
+		Grants:            make([]*idp_pb.ClaimMappingGrant, len(claimMapping.Grants)),
+	}
+	for i, metadata := range claimMapping.Metadata {
+		pb.Metadata[i] = &idp_pb.ClaimMappingMetadata{
+			Key:        metadata.Key,
+			Expression: metadata.Expression,
+		}
+	}
+	for i, grant := range claimMapping.Grants {
+		pb.Grants[i] = &idp_pb.ClaimMappingGrant{
+			Condition:      grant.Condition,
+			ProjectId:      grant.ProjectID,
+			ProjectGrantId: grant.ProjectGrantID,
+			RoleKeys:       grant.RoleKeys,
+		}
+	}
+	return pb
+}
+
 func appleConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.AppleIDPTemplate) {
 	providerConfig.Config = &idp_pb.ProviderConfig_Apple{
 		Apple: &idp_pb.AppleConfig{
@@ -77,6 +77,16 @@ func idpTypeToPb(idpType domain.IDPType) idp_pb.IDPType {
 		return idp_pb.IDPType_IDP_TYPE_GITLAB
 	case domain.IDPTypeGitLabSelfHosted:
 		return idp_pb.IDPType_IDP_TYPE_GITLAB_SELF_HOSTED
+	case domain.IDPTypeOkta:
+		return idp_pb.IDPType_IDP_TYPE_OKTA
+	case domain.IDPTypeKeycloak:
+		return idp_pb.IDPType_IDP_TYPE_KEYCLOAK
+	case domain.IDPTypeADFS:
+		return idp_pb.IDPType_IDP_TYPE_ADFS
+	case domain.IDPTypeBitbucket:
+		return idp_pb.IDPType_IDP_TYPE_BITBUCKET
+	case domain.IDPTypeDiscord:
+		return idp_pb.IDPType_IDP_TYPE_DISCORD
 	case domain.IDPTypeGoogle:
 		return idp_pb.IDPType_IDP_TYPE_GOOGLE
 	case domain.IDPTypeApple:
@@ -132,6 +142,26 @@ func configToPb(config *query.IDPTemplate) *idp_pb.IDPConfig {
 		gitlabSelfHostedConfigToPb(idpConfig, config.GitLabSelfHostedIDPTemplate)
 		return idpConfig
 	}
+	if config.OktaIDPTemplate != nil {
+		oktaConfigToPb(idpConfig, config.OktaIDPTemplate)
+		return idpConfig
+	}
+	if config.KeycloakIDPTemplate != nil {
+		keycloakConfigToPb(idpConfig, config.KeycloakIDPTemplate)
+		return idpConfig
+	}
+	if config.ADFSIDPTemplate != nil {
+		adfsConfigToPb(idpConfig, config.ADFSIDPTemplate)
+		return idpConfig
+	}
+	if config.BitbucketIDPTemplate != nil {
+		bitbucketConfigToPb(idpConfig, config.BitbucketIDPTemplate)
+		return idpConfig
+	}
+	if config.DiscordIDPTemplate != nil {
+		discordConfigToPb(idpConfig, config.DiscordIDPTemplate)
+		return idpConfig
+	}
 	if config.GoogleIDPTemplate != nil {
 		googleConfigToPb(idpConfig, config.GoogleIDPTemplate)
 		return idpConfig
@@ -265,6 +295,54 @@ func gitlabSelfHostedConfigToPb(idpConfig *idp_pb.IDPConfig, template *query.Git
 	}
 }
 
+func oktaConfigToPb(idpConfig *idp_pb.IDPConfig, template *query.OktaIDPTemplate) {
+	idpConfig.Config = &idp_pb.IDPConfig_Okta{
+		Okta: &idp_pb.OktaConfig{
+			ClientId: template.ClientID,
+			Issuer:   template.Issuer,
+			Scopes:   template.Scopes,
+		},
+	}
+}
+
+func keycloakConfigToPb(idpConfig *idp_pb.IDPConfig, template *query.KeycloakIDPTemplate) {
+	idpConfig.Config = &idp_pb.IDPConfig_Keycloak{
+		Keycloak: &idp_pb.KeycloakConfig{
+			ClientId: template.ClientID,
+			Issuer:   template.Issuer,
+			Scopes:   template.Scopes,
+		},
+	}
+}
+
+func adfsConfigToPb(idpConfig *idp_pb.IDPConfig, template *query.ADFSIDPTemplate) {
+	idpConfig.Config = &idp_pb.IDPConfig_Adfs{
+		Adfs: &idp_pb.ADFSConfig{
+			ClientId: template.ClientID,
+			Issuer:   template.Issuer,
+			Scopes:   template.Scopes,
+		},
+	}
+}
+
+func bitbucketConfigToPb(idpConfig *idp_pb.IDPConfig, template *query.BitbucketIDPTemplate) {
+	idpConfig.Config = &idp_pb.IDPConfig_Bitbucket{
+		Bitbucket: &idp_pb.BitbucketConfig{
+			ClientId: template.ClientID,
+			Scopes:   template.Scopes,
+		},
+	}
+}
+
+func discordConfigToPb(idpConfig *idp_pb.IDPConfig, template *query.DiscordIDPTemplate) {
+	idpConfig.Config = &idp_pb.IDPConfig_Discord{
+		Discord: &idp_pb.DiscordConfig{
+			ClientId: template.ClientID,
+			Scopes:   template.Scopes,
+		},
+	}
+}
+
 func googleConfigToPb(idpConfig *idp_pb.IDPConfig, template *query.GoogleIDPTemplate) {
 	idpConfig.Config = &idp_pb.IDPConfig_Google{
 		Google: &idp_pb.GoogleConfig{
@@ -156,6 +156,14 @@ func (s *Server) GetProviderByID(ctx context.Context, req *mgmt_pb.GetProviderBy
 	return &mgmt_pb.GetProviderByIDResponse{Idp: idp_grpc.ProviderToPb(idp)}, nil
 }
 
+func (s *Server) EvaluateProviderClaimMapping(ctx context.Context, req *mgmt_pb.EvaluateProviderClaimMappingRequest) (*mgmt_pb.EvaluateProviderClaimMappingResponse, error) {
+	result, err := idp_grpc.EvaluateClaimMapping(req.GetClaimMapping(), req.GetIdToken(), req.GetUserinfo())
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.EvaluateProviderClaimMappingResponse{Result: result}, nil
+}
+
 func (s *Server) ListProviders(ctx context.Context, req *mgmt_pb.ListProvidersRequest) (*mgmt_pb.ListProvidersResponse, error) {
 	queries, err := listProvidersToQuery(ctx, req)
 	if err != nil {
@@ -355,6 +363,111 @@ func (s *Server) UpdateGitLabSelfHostedProvider(ctx context.Context, req *mgmt_p
 	}, nil
 }
 
+func (s *Server) AddOktaProvider(ctx context.Context, req *mgmt_pb.AddOktaProviderRequest) (*mgmt_pb.AddOktaProviderResponse, error) {
+	id, details, err := s.command.AddOrgOktaProvider(ctx, authz.GetCtxData(ctx).OrgID, addOktaProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddOktaProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateOktaProvider(ctx context.Context, req *mgmt_pb.UpdateOktaProviderRequest) (*mgmt_pb.UpdateOktaProviderResponse, error) {
+	details, err := s.command.UpdateOrgOktaProvider(ctx, authz.GetCtxData(ctx).OrgID, req.Id, updateOktaProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateOktaProviderResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) AddKeycloakProvider(ctx context.Context, req *mgmt_pb.AddKeycloakProviderRequest) (*mgmt_pb.AddKeycloakProviderResponse, error) {
+	id, details, err := s.command.AddOrgKeycloakProvider(ctx, authz.GetCtxData(ctx).OrgID, addKeycloakProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddKeycloakProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateKeycloakProvider(ctx context.Context, req *mgmt_pb.UpdateKeycloakProviderRequest) (*mgmt_pb.UpdateKeycloakProviderResponse, error) {
+	details, err := s.command.UpdateOrgKeycloakProvider(ctx, authz.GetCtxData(ctx).OrgID, req.Id, updateKeycloakProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateKeycloakProviderResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) AddADFSProvider(ctx context.Context, req *mgmt_pb.AddADFSProviderRequest) (*mgmt_pb.AddADFSProviderResponse, error) {
+	id, details, err := s.command.AddOrgADFSProvider(ctx, authz.GetCtxData(ctx).OrgID, addADFSProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddADFSProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateADFSProvider(ctx context.Context, req *mgmt_pb.UpdateADFSProviderRequest) (*mgmt_pb.UpdateADFSProviderResponse, error) {
+	details, err := s.command.UpdateOrgADFSProvider(ctx, authz.GetCtxData(ctx).OrgID, req.Id, updateADFSProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateADFSProviderResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) AddBitbucketProvider(ctx context.Context, req *mgmt_pb.AddBitbucketProviderRequest) (*mgmt_pb.AddBitbucketProviderResponse, error) {
+	id, details, err := s.command.AddOrgBitbucketProvider(ctx, authz.GetCtxData(ctx).OrgID, addBitbucketProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddBitbucketProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateBitbucketProvider(ctx context.Context, req *mgmt_pb.UpdateBitbucketProviderRequest) (*mgmt_pb.UpdateBitbucketProviderResponse, error) {
+	details, err := s.command.UpdateOrgBitbucketProvider(ctx, authz.GetCtxData(ctx).OrgID, req.Id, updateBitbucketProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateBitbucketProviderResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) AddDiscordProvider(ctx context.Context, req *mgmt_pb.AddDiscordProviderRequest) (*mgmt_pb.AddDiscordProviderResponse, error) {
+	id, details, err := s.command.AddOrgDiscordProvider(ctx, authz.GetCtxData(ctx).OrgID, addDiscordProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddDiscordProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateDiscordProvider(ctx context.Context, req *mgmt_pb.UpdateDiscordProviderRequest) (*mgmt_pb.UpdateDiscordProviderResponse, error) {
+	details, err := s.command.UpdateOrgDiscordProvider(ctx, authz.GetCtxData(ctx).OrgID, req.Id, updateDiscordProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateDiscordProviderResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) AddGoogleProvider(ctx context.Context, req *mgmt_pb.AddGoogleProviderRequest) (*mgmt_pb.AddGoogleProviderResponse, error) {
 	id, details, err := s.command.AddOrgGoogleProvider(ctx, authz.GetCtxData(ctx).OrgID, addGoogleProviderToCommand(req))
 	if err != nil {
@@ -30,6 +30,7 @@ var iamRoles = []string{
 	"ORG_USER_SELF_MANAGER",
 	"ORG_ADMIN_IMPERSONATOR",
 	"ORG_END_USER_IMPERSONATOR",
+	"ORG_IDP_TOKEN_READER",
 }
 
 func TestServer_ListOrgMemberRoles(t *testing.T) {
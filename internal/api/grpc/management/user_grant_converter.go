@@ -34,6 +34,8 @@ func ListUserGrantsRequestToQuery(ctx context.Context, req *mgmt_pb.ListUserGran
 			Limit:  limit,
 			Asc:    asc,
 		},
+		// grants inherited through groups are not returned,
+		// as their ids cannot be passed to the commands of user grants
 		Queries: queries,
 	}
 
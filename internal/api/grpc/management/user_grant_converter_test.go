@@ -0,0 +1,33 @@
+package management
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+	"github.com/zitadel/zitadel/pkg/grpc/user"
+)
+
+func TestListUserGrantsRequestToQuery(t *testing.T) {
+	ctx := authz.NewMockContext("instanceID", "orgID", "userID")
+	queries, err := ListUserGrantsRequestToQuery(ctx, &mgmt_pb.ListUserGrantRequest{
+		Queries: []*user.UserGrantQuery{
+			{Query: &user.UserGrantQuery_UserIdQuery{UserIdQuery: &user.UserGrantUserIDQuery{UserId: "userID"}}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Len(t, queries.Queries, 2, "user id and owner query")
+	assert.False(t, queries.IncludeGroupGrants, "the ids of group grants cannot be used for the user grant commands")
+
+	queries, err = ListUserGrantsRequestToQuery(ctx, &mgmt_pb.ListUserGrantRequest{
+		Queries: []*user.UserGrantQuery{
+			{Query: &user.UserGrantQuery_WithGrantedQuery{WithGrantedQuery: &user.UserGrantWithGrantedQuery{WithGranted: true}}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Len(t, queries.Queries, 1, "no owner query for granted grants")
+	assert.False(t, queries.IncludeGroupGrants)
+}
@@ -95,8 +95,10 @@ func AuthMethodToPb(mfa *query.AuthMethod) *user_pb.AuthFactor {
 	case domain.UserAuthMethodTypeU2F:
 		factor.Type = &user_pb.AuthFactor_U2F{
 			U2F: &user_pb.AuthFactorU2F{
-				Id:   mfa.TokenID,
-				Name: mfa.Name,
+				Id:        mfa.TokenID,
+				Name:      mfa.Name,
+				Aaguid:    mfa.AAGUID,
+				ModelName: mfa.ModelName,
 			},
 		}
 	case domain.UserAuthMethodTypeOTPSMS:
@@ -107,6 +109,10 @@ func AuthMethodToPb(mfa *query.AuthMethod) *user_pb.AuthFactor {
 		factor.Type = &user_pb.AuthFactor_OtpEmail{
 			OtpEmail: &user_pb.AuthFactorOTPEmail{},
 		}
+	case domain.UserAuthMethodTypeRecoveryCode:
+		factor.Type = &user_pb.AuthFactor_RecoveryCodes{
+			RecoveryCodes: &user_pb.AuthFactorRecoveryCodes{},
+		}
 	case domain.UserAuthMethodTypeUnspecified:
 	case domain.UserAuthMethodTypePasswordless:
 	case domain.UserAuthMethodTypePassword:
@@ -135,6 +141,8 @@ func AuthFactorToPb(authFactor user_pb.AuthFactors) domain.UserAuthMethodType {
 		return domain.UserAuthMethodTypeOTPEmail
 	case user_pb.AuthFactors_U2F:
 		return domain.UserAuthMethodTypeU2F
+	case user_pb.AuthFactors_RECOVERY_CODES:
+		return domain.UserAuthMethodTypeRecoveryCode
 	default:
 		return domain.UserAuthMethodTypeUnspecified
 	}
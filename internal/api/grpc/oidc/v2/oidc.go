@@ -7,6 +7,7 @@ import (
 	"github.com/zitadel/logging"
 	"github.com/zitadel/oidc/v3/pkg/op"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/zitadel/zitadel/internal/api/grpc/object/v2"
@@ -77,6 +78,55 @@ func (s *Server) AuthorizeOrDenyDeviceAuthorization(ctx context.Context, req *oi
 	return &oidc_pb.AuthorizeOrDenyDeviceAuthorizationResponse{}, nil
 }
 
+func (s *Server) ListBackchannelAuthenticationRequests(ctx context.Context, req *oidc_pb.ListBackchannelAuthenticationRequestsRequest) (*oidc_pb.ListBackchannelAuthenticationRequestsResponse, error) {
+	session, err := s.query.SessionByID(ctx, true, req.GetSession().GetSessionId(), req.GetSession().GetSessionToken(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if session.UserFactor.UserID == "" {
+		return &oidc_pb.ListBackchannelAuthenticationRequestsResponse{}, nil
+	}
+	authRequests, err := s.query.BackchannelAuthRequestsByUserID(ctx, session.UserFactor.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &oidc_pb.ListBackchannelAuthenticationRequestsResponse{
+		BackchannelAuthenticationRequests: backchannelAuthRequestsToPb(authRequests),
+	}, nil
+}
+
+func (s *Server) AuthorizeOrDenyBackchannelAuthentication(ctx context.Context, req *oidc_pb.AuthorizeOrDenyBackchannelAuthenticationRequest) (_ *oidc_pb.AuthorizeOrDenyBackchannelAuthenticationResponse, err error) {
+	switch decision := req.GetDecision().(type) {
+	case *oidc_pb.AuthorizeOrDenyBackchannelAuthenticationRequest_Authorize:
+		_, err = s.command.ApproveBackchannelAuthWithSession(ctx, req.GetBackchannelAuthenticationId(), decision.Authorize.GetSessionId(), decision.Authorize.GetSessionToken())
+	case *oidc_pb.AuthorizeOrDenyBackchannelAuthenticationRequest_Deny:
+		_, err = s.command.DenyBackchannelAuthWithSession(ctx, req.GetBackchannelAuthenticationId(), decision.Deny.GetSessionId(), decision.Deny.GetSessionToken())
+	default:
+		return nil, zerrors.ThrowUnimplementedf(nil, "OIDCv2-Ohgh3", "decision oneOf %T in method AuthorizeOrDenyBackchannelAuthentication not implemented", decision)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &oidc_pb.AuthorizeOrDenyBackchannelAuthenticationResponse{}, nil
+}
+
+func backchannelAuthRequestsToPb(authRequests []*domain.AuthRequestBackchannel) []*oidc_pb.BackchannelAuthenticationRequest {
+	out := make([]*oidc_pb.BackchannelAuthenticationRequest, len(authRequests))
+	for i, a := range authRequests {
+		out[i] = &oidc_pb.BackchannelAuthenticationRequest{
+			Id:             a.ID,
+			CreationDate:   timestamppb.New(a.CreationDate),
+			ClientId:       a.ClientID,
+			Scope:          a.Scopes,
+			BindingMessage: a.BindingMessage,
+			ExpirationDate: timestamppb.New(a.Expires),
+			AppName:        a.AppName,
+			ProjectName:    a.ProjectName,
+		}
+	}
+	return out
+}
+
 func authRequestToPb(a *query.AuthRequest) *oidc_pb.AuthRequest {
 	pba := &oidc_pb.AuthRequest{
 		Id:           a.ID,
@@ -92,9 +142,26 @@ func authRequestToPb(a *query.AuthRequest) *oidc_pb.AuthRequest {
 	if a.MaxAge != nil {
 		pba.MaxAge = durationpb.New(*a.MaxAge)
 	}
+	pba.AuthorizationDetails = authorizationDetailsToPb(a.AuthorizationDetails)
 	return pba
 }
 
+func authorizationDetailsToPb(details domain.AuthorizationDetails) []*structpb.Struct {
+	if len(details) == 0 {
+		return nil
+	}
+	out := make([]*structpb.Struct, 0, len(details))
+	for _, detail := range details {
+		pb, err := structpb.NewStruct(detail)
+		if err != nil {
+			logging.WithError(err).Warn("cannot convert authorization detail")
+			continue
+		}
+		out = append(out, pb)
+	}
+	return out
+}
+
 func promptsToPb(promps []domain.Prompt) []oidc_pb.Prompt {
 	out := make([]oidc_pb.Prompt, len(promps))
 	for i, p := range promps {
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/zitadel/zitadel/internal/domain"
@@ -36,6 +37,9 @@ func Test_authRequestToPb(t *testing.T) {
 		LoginHint:  gu.Ptr("foo@bar.com"),
 		MaxAge:     gu.Ptr(time.Minute),
 		HintUserID: gu.Ptr("userID"),
+		AuthorizationDetails: domain.AuthorizationDetails{
+			{"type": "payment_initiation", "instructedAmount": map[string]any{"currency": "EUR", "amount": "123.50"}},
+		},
 	}
 	want := &oidc_pb.AuthRequest{
 		Id:           "authID",
@@ -56,6 +60,15 @@ func Test_authRequestToPb(t *testing.T) {
 		LoginHint:  gu.Ptr("foo@bar.com"),
 		MaxAge:     durationpb.New(time.Minute),
 		HintUserId: gu.Ptr("userID"),
+		AuthorizationDetails: []*structpb.Struct{
+			{Fields: map[string]*structpb.Value{
+				"type": structpb.NewStringValue("payment_initiation"),
+				"instructedAmount": structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{
+					"currency": structpb.NewStringValue("EUR"),
+					"amount":   structpb.NewStringValue("123.50"),
+				}}),
+			}},
+		},
 	}
 	got := authRequestToPb(arg)
 	if !proto.Equal(want, got) {
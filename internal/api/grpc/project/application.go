@@ -44,27 +44,32 @@ func AppConfigToPb(app *query.App) app_pb.AppConfig {
 func AppOIDCConfigToPb(app *query.OIDCApp) *app_pb.App_OidcConfig {
 	return &app_pb.App_OidcConfig{
 		OidcConfig: &app_pb.OIDCConfig{
-			RedirectUris:             app.RedirectURIs,
-			ResponseTypes:            OIDCResponseTypesFromModel(app.ResponseTypes),
-			GrantTypes:               OIDCGrantTypesFromModel(app.GrantTypes),
-			AppType:                  OIDCApplicationTypeToPb(app.AppType),
-			ClientId:                 app.ClientID,
-			AuthMethodType:           OIDCAuthMethodTypeToPb(app.AuthMethodType),
-			PostLogoutRedirectUris:   app.PostLogoutRedirectURIs,
-			Version:                  OIDCVersionToPb(domain.OIDCVersion(app.Version)),
-			NoneCompliant:            len(app.ComplianceProblems) != 0,
-			ComplianceProblems:       ComplianceProblemsToLocalizedMessages(app.ComplianceProblems),
-			DevMode:                  app.IsDevMode,
-			AccessTokenType:          oidcTokenTypeToPb(app.AccessTokenType),
-			AccessTokenRoleAssertion: app.AssertAccessTokenRole,
-			IdTokenRoleAssertion:     app.AssertIDTokenRole,
-			IdTokenUserinfoAssertion: app.AssertIDTokenUserinfo,
-			ClockSkew:                durationpb.New(app.ClockSkew),
-			AdditionalOrigins:        app.AdditionalOrigins,
-			AllowedOrigins:           app.AllowedOrigins,
-			SkipNativeAppSuccessPage: app.SkipNativeAppSuccessPage,
-			BackChannelLogoutUri:     app.BackChannelLogoutURI,
-			LoginVersion:             loginVersionToPb(app.LoginVersion, app.LoginBaseURI),
+			RedirectUris:                          app.RedirectURIs,
+			ResponseTypes:                         OIDCResponseTypesFromModel(app.ResponseTypes),
+			GrantTypes:                            OIDCGrantTypesFromModel(app.GrantTypes),
+			AppType:                               OIDCApplicationTypeToPb(app.AppType),
+			ClientId:                              app.ClientID,
+			AuthMethodType:                        OIDCAuthMethodTypeToPb(app.AuthMethodType),
+			PostLogoutRedirectUris:                app.PostLogoutRedirectURIs,
+			Version:                               OIDCVersionToPb(domain.OIDCVersion(app.Version)),
+			NoneCompliant:                         len(app.ComplianceProblems) != 0,
+			ComplianceProblems:                    ComplianceProblemsToLocalizedMessages(app.ComplianceProblems),
+			DevMode:                               app.IsDevMode,
+			AccessTokenType:                       oidcTokenTypeToPb(app.AccessTokenType),
+			AccessTokenRoleAssertion:              app.AssertAccessTokenRole,
+			IdTokenRoleAssertion:                  app.AssertIDTokenRole,
+			IdTokenUserinfoAssertion:              app.AssertIDTokenUserinfo,
+			ClockSkew:                             durationpb.New(app.ClockSkew),
+			AdditionalOrigins:                     app.AdditionalOrigins,
+			AllowedOrigins:                        app.AllowedOrigins,
+			SkipNativeAppSuccessPage:              app.SkipNativeAppSuccessPage,
+			BackChannelLogoutUri:                  app.BackChannelLogoutURI,
+			LoginVersion:                          loginVersionToPb(app.LoginVersion, app.LoginBaseURI),
+			RequirePushedAuthorizationRequests:    app.RequirePushedAuthorizationRequests,
+			RequireDpop:                           app.RequireDPoP,
+			BackchannelTokenDeliveryMode:          OIDCBackchannelTokenDeliveryModeToPb(app.BackchannelTokenDeliveryMode),
+			BackchannelClientNotificationEndpoint: app.BackchannelClientNotificationEndpoint,
+			TlsClientAuthSubjectDn:                app.TLSClientAuthSubjectDN,
 		},
 	}
 }
@@ -158,6 +163,8 @@ func OIDCGrantTypesFromModel(grantTypes []domain.OIDCGrantType) []app_pb.OIDCGra
 			oidcGrantTypes[i] = app_pb.OIDCGrantType_OIDC_GRANT_TYPE_DEVICE_CODE
 		case domain.OIDCGrantTypeTokenExchange:
 			oidcGrantTypes[i] = app_pb.OIDCGrantType_OIDC_GRANT_TYPE_TOKEN_EXCHANGE
+		case domain.OIDCGrantTypeCIBA:
+			oidcGrantTypes[i] = app_pb.OIDCGrantType_OIDC_GRANT_TYPE_CIBA
 		}
 	}
 	return oidcGrantTypes
@@ -180,6 +187,8 @@ func OIDCGrantTypesToDomain(grantTypes []app_pb.OIDCGrantType) []domain.OIDCGran
 			oidcGrantTypes[i] = domain.OIDCGrantTypeDeviceCode
 		case app_pb.OIDCGrantType_OIDC_GRANT_TYPE_TOKEN_EXCHANGE:
 			oidcGrantTypes[i] = domain.OIDCGrantTypeTokenExchange
+		case app_pb.OIDCGrantType_OIDC_GRANT_TYPE_CIBA:
+			oidcGrantTypes[i] = domain.OIDCGrantTypeCIBA
 		}
 	}
 	return oidcGrantTypes
@@ -220,6 +229,10 @@ func OIDCAuthMethodTypeToPb(authType domain.OIDCAuthMethodType) app_pb.OIDCAuthM
 		return app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_NONE
 	case domain.OIDCAuthMethodTypePrivateKeyJWT:
 		return app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT
+	case domain.OIDCAuthMethodTypeTLSClientAuth:
+		return app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_TLS_CLIENT_AUTH
+	case domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth:
+		return app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_SELF_SIGNED_TLS_CLIENT_AUTH
 	default:
 		return app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_BASIC
 	}
@@ -235,6 +248,10 @@ func OIDCAuthMethodTypeToDomain(authType app_pb.OIDCAuthMethodType) domain.OIDCA
 		return domain.OIDCAuthMethodTypeNone
 	case app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT:
 		return domain.OIDCAuthMethodTypePrivateKeyJWT
+	case app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_TLS_CLIENT_AUTH:
+		return domain.OIDCAuthMethodTypeTLSClientAuth
+	case app_pb.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_SELF_SIGNED_TLS_CLIENT_AUTH:
+		return domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth
 	default:
 		return domain.OIDCAuthMethodTypeBasic
 	}
@@ -278,6 +295,28 @@ func OIDCTokenTypeToDomain(tokenType app_pb.OIDCTokenType) domain.OIDCTokenType
 	}
 }
 
+func OIDCBackchannelTokenDeliveryModeToPb(mode domain.OIDCBackchannelTokenDeliveryMode) app_pb.OIDCBackchannelTokenDeliveryMode {
+	switch mode {
+	case domain.OIDCBackchannelTokenDeliveryModePoll:
+		return app_pb.OIDCBackchannelTokenDeliveryMode_OIDC_BACKCHANNEL_TOKEN_DELIVERY_MODE_POLL
+	case domain.OIDCBackchannelTokenDeliveryModePing:
+		return app_pb.OIDCBackchannelTokenDeliveryMode_OIDC_BACKCHANNEL_TOKEN_DELIVERY_MODE_PING
+	default:
+		return app_pb.OIDCBackchannelTokenDeliveryMode_OIDC_BACKCHANNEL_TOKEN_DELIVERY_MODE_POLL
+	}
+}
+
+func OIDCBackchannelTokenDeliveryModeToDomain(mode app_pb.OIDCBackchannelTokenDeliveryMode) domain.OIDCBackchannelTokenDeliveryMode {
+	switch mode {
+	case app_pb.OIDCBackchannelTokenDeliveryMode_OIDC_BACKCHANNEL_TOKEN_DELIVERY_MODE_POLL:
+		return domain.OIDCBackchannelTokenDeliveryModePoll
+	case app_pb.OIDCBackchannelTokenDeliveryMode_OIDC_BACKCHANNEL_TOKEN_DELIVERY_MODE_PING:
+		return domain.OIDCBackchannelTokenDeliveryModePing
+	default:
+		return domain.OIDCBackchannelTokenDeliveryModePoll
+	}
+}
+
 func ComplianceProblemsToLocalizedMessages(problems []string) []*message_pb.LocalizedMessage {
 	converted := make([]*message_pb.LocalizedMessage, len(problems))
 	for i, p := range problems {
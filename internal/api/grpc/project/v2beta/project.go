@@ -79,15 +79,20 @@ func projectUpdateToCommand(req *project_pb.UpdateProjectRequest) *command.Chang
 	if req.PrivateLabelingSetting != nil {
 		labeling = gu.Ptr(privateLabelingSettingToDomain(*req.PrivateLabelingSetting))
 	}
+	var authorizationDetailsTypes *[]string
+	if req.AuthorizationDetailsTypes != nil {
+		authorizationDetailsTypes = gu.Ptr(req.GetAuthorizationDetailsTypes().GetTypes())
+	}
 	return &command.ChangeProject{
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: req.Id,
 		},
-		Name:                   req.Name,
-		ProjectRoleAssertion:   req.ProjectRoleAssertion,
-		ProjectRoleCheck:       req.ProjectRoleCheck,
-		HasProjectCheck:        req.HasProjectCheck,
-		PrivateLabelingSetting: labeling,
+		Name:                      req.Name,
+		ProjectRoleAssertion:      req.ProjectRoleAssertion,
+		ProjectRoleCheck:          req.ProjectRoleCheck,
+		HasProjectCheck:           req.HasProjectCheck,
+		PrivateLabelingSetting:    labeling,
+		AuthorizationDetailsTypes: authorizationDetailsTypes,
 	}
 }
 
@@ -146,6 +146,8 @@ func projectToPb(project *query.Project) *project_pb.Project {
 		ProjectAccessRequired:  project.HasProjectCheck,
 		ProjectRoleAssertion:   project.ProjectRoleAssertion,
 		AuthorizationRequired:  project.ProjectRoleCheck,
+
+		AuthorizationDetailsTypes: project.AuthorizationDetailsTypes,
 	}
 }
 
@@ -16,11 +16,14 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/zitadel/zitadel/internal/api/dpop"
 	client_middleware "github.com/zitadel/zitadel/internal/api/grpc/client/middleware"
+	"github.com/zitadel/zitadel/internal/api/grpc/server/middleware"
 	http_utils "github.com/zitadel/zitadel/internal/api/http"
 	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/telemetry/metrics"
@@ -34,6 +37,7 @@ const (
 var (
 	customHeaders = []string{
 		"x-zitadel-",
+		http_utils.DPoP,
 	}
 	jsonMarshaler = &runtime.JSONPb{
 		UnmarshalOptions: protojson.UnmarshalOptions{
@@ -71,6 +75,7 @@ var (
 			runtime.WithMarshalerOption(mimeWildcard, jsonMarshaler),
 			runtime.WithMarshalerOption(runtime.MIMEWildcard, jsonMarshaler),
 			runtime.WithIncomingHeaderMatcher(headerMatcher(hostHeaders)),
+			runtime.WithMetadata(requestMetadata),
 			runtime.WithOutgoingHeaderMatcher(runtime.DefaultHeaderMatcher),
 			runtime.WithForwardResponseOption(responseForwarder),
 			runtime.WithRoutingErrorHandler(httpErrorHandler),
@@ -90,6 +95,12 @@ var (
 		}
 	}
 
+	// requestMetadata passes the method and path of the HTTP request,
+	// so the DPoP proof of the request can be verified by the grpc server
+	requestMetadata = func(_ context.Context, r *http.Request) metadata.MD {
+		return middleware.GatewayMetadata(r.Method, dpop.RequestPath(r))
+	}
+
 	responseForwarder = func(ctx context.Context, w http.ResponseWriter, resp proto.Message) error {
 		setRequestURIPattern(ctx)
 		t, ok := resp.(CustomHTTPResponse)
@@ -2,24 +2,36 @@ package middleware
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"crypto/x509"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/dpop"
 	grpc_util "github.com/zitadel/zitadel/internal/api/grpc"
 	"github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/mtls"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 )
 
-func AuthorizationInterceptor(verifier authz.APITokenVerifier, systemUserPermissions authz.Config, authConfig authz.Config) grpc.UnaryServerInterceptor {
+// AuthorizationInterceptor checks the authorization of the calls.
+// The certificateHeader is the header in which a reverse proxy terminating TLS passes the client certificate,
+// it is used to check certificate-bound tokens, if the connection itself has no client certificate.
+// Calls through the gateway pass the header as well.
+func AuthorizationInterceptor(verifier authz.APITokenVerifier, systemUserPermissions authz.Config, authConfig authz.Config, certificateHeader string) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		return authorize(ctx, req, info, handler, verifier, systemUserPermissions, authConfig)
+		return authorize(ctx, req, info, handler, verifier, systemUserPermissions, authConfig, certificateHeader)
 	}
 }
 
-func authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler, verifier authz.APITokenVerifier, systemUserPermissions authz.Config, authConfig authz.Config) (_ interface{}, err error) {
+func authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler, verifier authz.APITokenVerifier, systemUserPermissions authz.Config, authConfig authz.Config, certificateHeader string) (_ interface{}, err error) {
 	authOpt, needsToken := verifier.CheckAuthMethod(info.FullMethod)
 	if !needsToken {
 		return handler(ctx, req)
@@ -33,6 +45,13 @@ func authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
 		return nil, status.Error(codes.Unauthenticated, "auth header missing")
 	}
 
+	if proof := grpc_util.GetHeader(authCtx, http.DPoP); proof != "" {
+		authCtx = dpop.WithRequest(authCtx, dpopRequest(authCtx, proof, info.FullMethod))
+	}
+	if certificates := clientCertificates(authCtx, certificateHeader); len(certificates) > 0 {
+		authCtx = mtls.WithCertificates(authCtx, certificates)
+	}
+
 	orgID, orgDomain := orgIDAndDomainFromRequest(authCtx, req)
 	ctxSetter, err := authz.CheckUserAuthorization(authCtx, req, authToken, orgID, orgDomain, verifier, systemUserPermissions.RolePermissionMappings, authConfig.RolePermissionMappings, authOpt, info.FullMethod)
 	if err != nil {
@@ -42,6 +61,65 @@ func authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
 	return handler(ctxSetter(ctx), req)
 }
 
+// gatewaySecret authenticates the calls of the in-process gateway.
+// It's generated per process and only sent over the gateway connection,
+// so the gateway metadata of any other call is ignored.
+var gatewaySecret = rand.Text()
+
+// GatewayMetadata returns the metadata the in-process gateway passes with the call of an HTTP request.
+func GatewayMetadata(method, path string) metadata.MD {
+	return metadata.Pairs(
+		http.GatewayMethod, method,
+		http.GatewayPath, path,
+		http.GatewaySecret, gatewaySecret,
+	)
+}
+
+// dpopRequest returns the request the DPoP proof has to be issued for.
+// Calls of the in-process gateway are checked against the method and path of the HTTP request passed by the gateway,
+// all other calls against the gRPC request itself, which is a POST to the full method.
+func dpopRequest(ctx context.Context, proof, fullMethod string) *dpop.Request {
+	method, path := "POST", fullMethod
+	if fromGateway(ctx) {
+		method, path = lastHeader(ctx, http.GatewayMethod), lastHeader(ctx, http.GatewayPath)
+	}
+	return &dpop.Request{
+		Proof:  proof,
+		Method: method,
+		URI:    http.DomainContext(ctx).Origin() + path,
+	}
+}
+
+// fromGateway returns true if the call was made by the in-process gateway.
+func fromGateway(ctx context.Context) bool {
+	secret := lastHeader(ctx, http.GatewaySecret)
+	return secret != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(gatewaySecret)) == 1
+}
+
+// lastHeader returns the last value of the metadata,
+// as the values of the gateway are appended to the headers of the HTTP request.
+func lastHeader(ctx context.Context, key string) string {
+	values := metadata.ValueFromIncomingContext(ctx, key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[len(values)-1]
+}
+
+// clientCertificates returns the client certificate chain of a direct TLS connection
+// or, if there is none, the certificate passed by a reverse proxy in the header.
+func clientCertificates(ctx context.Context, header string) []*x509.Certificate {
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.PeerCertificates) > 0 {
+			return tlsInfo.State.PeerCertificates
+		}
+	}
+	if header == "" {
+		return nil
+	}
+	return mtls.CertificatesFromHeader(grpc_util.GetHeader(ctx, header))
+}
+
 func orgIDAndDomainFromRequest(ctx context.Context, req interface{}) (id, domain string) {
 	orgID := grpc_util.GetHeader(ctx, http.ZitadelOrgID)
 	oz, ok := req.(OrganizationFromRequest)
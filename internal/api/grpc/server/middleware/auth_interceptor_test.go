@@ -6,10 +6,13 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/dpop"
+	"github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
@@ -255,7 +258,7 @@ func Test_authorize(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := authorize(tt.args.ctx, tt.args.req, tt.args.info, tt.args.handler, tt.args.verifier(), tt.args.authConfig, tt.args.authConfig)
+			got, err := authorize(tt.args.ctx, tt.args.req, tt.args.info, tt.args.handler, tt.args.verifier(), tt.args.authConfig, tt.args.authConfig, "")
 			if (err != nil) != tt.res.wantErr {
 				t.Errorf("authorize() error = %v, wantErr %v", err, tt.res.wantErr)
 				return
@@ -266,3 +269,47 @@ func Test_authorize(t *testing.T) {
 		})
 	}
 }
+
+func Test_dpopRequest(t *testing.T) {
+	domainCtx := http.WithDomainContext(context.Background(), &http.DomainCtx{Protocol: "https", InstanceHost: "issuer.com"})
+	tests := []struct {
+		name string
+		md   metadata.MD
+		want *dpop.Request
+	}{
+		{
+			name: "direct call",
+			md:   metadata.Pairs(),
+			want: &dpop.Request{Proof: "proof", Method: "POST", URI: "https://issuer.com/zitadel.user.v2.UserService/GetUserByID"},
+		},
+		{
+			name: "direct call with gateway metadata, ignored",
+			md:   metadata.Pairs(http.GatewayMethod, "GET", http.GatewayPath, "/v2/users/1"),
+			want: &dpop.Request{Proof: "proof", Method: "POST", URI: "https://issuer.com/zitadel.user.v2.UserService/GetUserByID"},
+		},
+		{
+			name: "direct call with wrong gateway secret, ignored",
+			md:   metadata.Pairs(http.GatewayMethod, "GET", http.GatewayPath, "/v2/users/1", http.GatewaySecret, "wrong"),
+			want: &dpop.Request{Proof: "proof", Method: "POST", URI: "https://issuer.com/zitadel.user.v2.UserService/GetUserByID"},
+		},
+		{
+			name: "gateway call",
+			md:   GatewayMetadata("GET", "/v2/users/1"),
+			want: &dpop.Request{Proof: "proof", Method: "GET", URI: "https://issuer.com/v2/users/1"},
+		},
+		{
+			name: "gateway call with metadata of the client, gateway values used",
+			md: metadata.Join(
+				metadata.Pairs(http.GatewayMethod, "POST", http.GatewayPath, "/other"),
+				GatewayMetadata("GET", "/v2/users/1"),
+			),
+			want: &dpop.Request{Proof: "proof", Method: "GET", URI: "https://issuer.com/v2/users/1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(domainCtx, tt.md)
+			assert.Equal(t, tt.want, dpopRequest(ctx, "proof", "/zitadel.user.v2.UserService/GetUserByID"))
+		})
+	}
+}
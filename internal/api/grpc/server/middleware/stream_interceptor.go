@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/gerrors"
+)
+
+// StreamRequestInterceptor applies unary interceptors to server streams.
+// The interceptor is called as soon as the handler receives the request
+// and the stream continues with the context passed to the unary handler,
+// so instance, authorization and validation work the same as for unary calls.
+// Sent responses are translated and returned errors are converted to gRPC errors.
+// Client and bidirectional streams (e.g. server reflection) are passed through unchanged.
+func StreamRequestInterceptor(interceptor grpc.UnaryServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if info.IsClientStream {
+			return handler(srv, stream)
+		}
+		intercepted := &interceptedServerStream{
+			ServerStream: stream,
+			ctx:          stream.Context(),
+			interceptor:  interceptor,
+			info: &grpc.UnaryServerInfo{
+				Server:     srv,
+				FullMethod: info.FullMethod,
+			},
+		}
+		err := handler(srv, intercepted)
+		if err != nil && intercepted.intercepted {
+			if translator, translatorErr := getTranslator(intercepted.ctx); translatorErr == nil {
+				err = translateError(intercepted.ctx, err, translator)
+			}
+		}
+		return gerrors.ZITADELToGRPCError(err)
+	}
+}
+
+type interceptedServerStream struct {
+	grpc.ServerStream
+	ctx         context.Context
+	interceptor grpc.UnaryServerInterceptor
+	info        *grpc.UnaryServerInfo
+	intercepted bool
+}
+
+func (s *interceptedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// RecvMsg receives the request and passes it through the interceptor.
+func (s *interceptedServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.intercepted {
+		return nil
+	}
+	_, err := s.interceptor(s.ctx, m, s.info, func(ctx context.Context, _ interface{}) (interface{}, error) {
+		s.ctx = ctx
+		s.intercepted = true
+		return nil, nil
+	})
+	return err
+}
+
+// SendMsg translates the localized fields of the response.
+func (s *interceptedServerStream) SendMsg(m interface{}) error {
+	if loc, ok := m.(localizers); ok {
+		if translator, err := getTranslator(s.ctx); err == nil {
+			translateFields(s.ctx, loc, translator)
+		}
+	}
+	return s.ServerStream.SendMsg(m)
+}
@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type ctxKey struct{}
+
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []interface{}
+}
+
+func (s *mockServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *mockServerStream) RecvMsg(interface{}) error {
+	return nil
+}
+
+func (s *mockServerStream) SendMsg(m interface{}) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func TestStreamRequestInterceptor(t *testing.T) {
+	setValue := func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(context.WithValue(ctx, ctxKey{}, "value"), req)
+	}
+	deny := func(context.Context, interface{}, *grpc.UnaryServerInfo, grpc.UnaryHandler) (interface{}, error) {
+		return nil, status.Error(codes.PermissionDenied, "denied")
+	}
+	tests := []struct {
+		name        string
+		interceptor grpc.UnaryServerInterceptor
+		info        *grpc.StreamServerInfo
+		handler     grpc.StreamHandler
+		wantCode    codes.Code
+		wantSent    int
+	}{
+		{
+			name:        "context of interceptor",
+			interceptor: setValue,
+			info:        &grpc.StreamServerInfo{IsServerStream: true},
+			handler: func(_ interface{}, stream grpc.ServerStream) error {
+				if err := stream.RecvMsg(&mockReq{}); err != nil {
+					return err
+				}
+				if stream.Context().Value(ctxKey{}) != "value" {
+					return status.Error(codes.Internal, "context not set")
+				}
+				return stream.SendMsg(&mockReq{})
+			},
+			wantCode: codes.OK,
+			wantSent: 1,
+		},
+		{
+			name:        "interceptor error",
+			interceptor: deny,
+			info:        &grpc.StreamServerInfo{IsServerStream: true},
+			handler: func(_ interface{}, stream grpc.ServerStream) error {
+				if err := stream.RecvMsg(&mockReq{}); err != nil {
+					return err
+				}
+				return stream.SendMsg(&mockReq{})
+			},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:        "handler error",
+			interceptor: setValue,
+			info:        &grpc.StreamServerInfo{IsServerStream: true},
+			handler: func(_ interface{}, stream grpc.ServerStream) error {
+				return zerrors.ThrowNotFound(nil, "TEST-ohX3a", "not found")
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:        "client stream passed through",
+			interceptor: deny,
+			info:        &grpc.StreamServerInfo{IsClientStream: true, IsServerStream: true},
+			handler: func(_ interface{}, stream grpc.ServerStream) error {
+				if err := stream.RecvMsg(&mockReq{}); err != nil {
+					return err
+				}
+				return stream.SendMsg(&mockReq{})
+			},
+			wantCode: codes.OK,
+			wantSent: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := &mockServerStream{ctx: context.Background()}
+			err := StreamRequestInterceptor(tt.interceptor)(nil, stream, tt.info, tt.handler)
+			require.Equal(t, tt.wantCode, status.Code(err), err)
+			assert.Len(t, stream.sent, tt.wantSent)
+		})
+	}
+}
+
+func TestStreamRequestInterceptor_reflection(t *testing.T) {
+	deny := func(context.Context, interface{}, *grpc.UnaryServerInfo, grpc.UnaryHandler) (interface{}, error) {
+		return nil, status.Error(codes.PermissionDenied, "denied")
+	}
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(grpc.StreamInterceptor(StreamRequestInterceptor(deny)))
+	reflection.Register(server)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	stream, err := grpc_reflection_v1alpha.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+	err = stream.Send(&grpc_reflection_v1alpha.ServerReflectionRequest{
+		MessageRequest: &grpc_reflection_v1alpha.ServerReflectionRequest_ListServices{},
+	})
+	require.NoError(t, err)
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	services := make([]string, 0, len(resp.GetListServicesResponse().GetService()))
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	assert.Contains(t, services, grpc_reflection_v1alpha.ServerReflection_ServiceDesc.ServiceName)
+	require.NoError(t, stream.CloseSend())
+}
@@ -42,6 +42,7 @@ func CreateServer(
 	externalDomain string,
 	tlsConfig *tls.Config,
 	accessSvc *logstore.Service[*record.AccessLog],
+	certificateHeader string,
 ) *grpc.Server {
 	metricTypes := []metrics.MetricType{metrics.MetricTypeTotalCount, metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode}
 	serverOptions := []grpc.ServerOption{
@@ -54,7 +55,7 @@ func CreateServer(
 				middleware.AccessStorageInterceptor(accessSvc),
 				middleware.ErrorHandler(),
 				middleware.LimitsInterceptor(system_pb.SystemService_ServiceDesc.ServiceName),
-				middleware.AuthorizationInterceptor(verifier, systemAuthz, authConfig),
+				middleware.AuthorizationInterceptor(verifier, systemAuthz, authConfig, certificateHeader),
 				middleware.TranslationHandler(),
 				middleware.QuotaExhaustedInterceptor(accessSvc, system_pb.SystemService_ServiceDesc.ServiceName),
 				middleware.ExecutionHandler(queries),
@@ -63,6 +64,20 @@ func CreateServer(
 				middleware.ActivityInterceptor(),
 			),
 		),
+		grpc.StreamInterceptor(
+			middleware.StreamRequestInterceptor(
+				grpc_middleware.ChainUnaryServer(
+					middleware.CallDurationHandler(),
+					middleware.InstanceInterceptor(queries, externalDomain, system_pb.SystemService_ServiceDesc.ServiceName, healthpb.Health_ServiceDesc.ServiceName),
+					middleware.ErrorHandler(),
+					middleware.LimitsInterceptor(system_pb.SystemService_ServiceDesc.ServiceName),
+					middleware.AuthorizationInterceptor(verifier, systemAuthz, authConfig, certificateHeader),
+					middleware.TranslationHandler(),
+					middleware.ValidationHandler(),
+					middleware.ServiceHandler(),
+				),
+			),
+		),
 		grpc.StatsHandler(middleware.DefaultTracingServer()),
 	}
 	if tlsConfig != nil {
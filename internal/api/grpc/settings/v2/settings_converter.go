@@ -101,6 +101,7 @@ func passwordComplexitySettingsToPb(current *query.PasswordComplexityPolicy) *se
 		RequiresNumber:    current.HasNumber,
 		RequiresSymbol:    current.HasSymbol,
 		ResourceOwnerType: isDefaultToResourceOwnerTypePb(current.IsDefault),
+		CheckBreached:     current.CheckBreached,
 	}
 }
 
@@ -229,6 +230,16 @@ func idpTypeToPb(idpType domain.IDPType) settings.IdentityProviderType {
 		return settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_APPLE
 	case domain.IDPTypeSAML:
 		return settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_SAML
+	case domain.IDPTypeOkta:
+		return settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_OKTA
+	case domain.IDPTypeKeycloak:
+		return settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_KEYCLOAK
+	case domain.IDPTypeADFS:
+		return settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_ADFS
+	case domain.IDPTypeBitbucket:
+		return settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_BITBUCKET
+	case domain.IDPTypeDiscord:
+		return settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_DISCORD
 	default:
 		return settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_UNSPECIFIED
 	}
@@ -241,6 +252,12 @@ func securityPolicyToSettingsPb(policy *query.SecurityPolicy) *settings.Security
 			AllowedOrigins: policy.AllowedOrigins,
 		},
 		EnableImpersonation: policy.EnableImpersonation,
+		WebAuthnAttestation: &settings.WebAuthNAttestationSettings{
+			Required:            policy.WebAuthNAttestationRequired,
+			AllowedAaguids:      policy.WebAuthNAllowedAAGUIDs,
+			DeniedAaguids:       policy.WebAuthNDeniedAAGUIDs,
+			CertificationLevels: policy.WebAuthNCertificationLevels,
+		},
 	}
 }
 
@@ -249,5 +266,11 @@ func securitySettingsToCommand(req *settings.SetSecuritySettingsRequest) *comman
 		EnableIframeEmbedding: req.GetEmbeddedIframe().GetEnabled(),
 		AllowedOrigins:        req.GetEmbeddedIframe().GetAllowedOrigins(),
 		EnableImpersonation:   req.GetEnableImpersonation(),
+		WebAuthNAttestation: domain.WebAuthNAttestationPolicy{
+			Required:            req.GetWebAuthnAttestation().GetRequired(),
+			AllowedAAGUIDs:      req.GetWebAuthnAttestation().GetAllowedAaguids(),
+			DeniedAAGUIDs:       req.GetWebAuthnAttestation().GetDeniedAaguids(),
+			CertificationLevels: req.GetWebAuthnAttestation().GetCertificationLevels(),
+		},
 	}
 }
@@ -216,12 +216,13 @@ func Test_multiFactorTypeToPb(t *testing.T) {
 
 func Test_passwordComplexitySettingsToPb(t *testing.T) {
 	arg := &query.PasswordComplexityPolicy{
-		MinLength:    12,
-		HasUppercase: true,
-		HasLowercase: true,
-		HasNumber:    true,
-		HasSymbol:    true,
-		IsDefault:    true,
+		MinLength:     12,
+		HasUppercase:  true,
+		HasLowercase:  true,
+		HasNumber:     true,
+		HasSymbol:     true,
+		CheckBreached: true,
+		IsDefault:     true,
 	}
 	want := &settings.PasswordComplexitySettings{
 		MinLength:         12,
@@ -230,6 +231,7 @@ func Test_passwordComplexitySettingsToPb(t *testing.T) {
 		RequiresNumber:    true,
 		RequiresSymbol:    true,
 		ResourceOwnerType: settings.ResourceOwnerType_RESOURCE_OWNER_TYPE_INSTANCE,
+		CheckBreached:     true,
 	}
 
 	got := passwordComplexitySettingsToPb(arg)
@@ -499,6 +501,26 @@ func Test_idpTypeToPb(t *testing.T) {
 			args: args{domain.IDPTypeSAML},
 			want: settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_SAML,
 		},
+		{
+			args: args{domain.IDPTypeOkta},
+			want: settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_OKTA,
+		},
+		{
+			args: args{domain.IDPTypeKeycloak},
+			want: settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_KEYCLOAK,
+		},
+		{
+			args: args{domain.IDPTypeADFS},
+			want: settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_ADFS,
+		},
+		{
+			args: args{domain.IDPTypeBitbucket},
+			want: settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_BITBUCKET,
+		},
+		{
+			args: args{domain.IDPTypeDiscord},
+			want: settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_DISCORD,
+		},
 		{
 			args: args{99},
 			want: settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_UNSPECIFIED,
@@ -520,11 +542,21 @@ func Test_securityPolicyToSettingsPb(t *testing.T) {
 			AllowedOrigins: []string{"foo", "bar"},
 		},
 		EnableImpersonation: true,
+		WebAuthnAttestation: &settings.WebAuthNAttestationSettings{
+			Required:            true,
+			AllowedAaguids:      []string{"cb69481e-8ff7-4039-93ec-0a2729a154a8"},
+			DeniedAaguids:       []string{"ee882879-721c-4913-9775-3dfcce97072a"},
+			CertificationLevels: []string{"FIDO_CERTIFIED_L2"},
+		},
 	}
 	got := securityPolicyToSettingsPb(&query.SecurityPolicy{
-		EnableIframeEmbedding: true,
-		AllowedOrigins:        []string{"foo", "bar"},
-		EnableImpersonation:   true,
+		EnableIframeEmbedding:       true,
+		AllowedOrigins:              []string{"foo", "bar"},
+		EnableImpersonation:         true,
+		WebAuthNAttestationRequired: true,
+		WebAuthNAllowedAAGUIDs:      []string{"cb69481e-8ff7-4039-93ec-0a2729a154a8"},
+		WebAuthNDeniedAAGUIDs:       []string{"ee882879-721c-4913-9775-3dfcce97072a"},
+		WebAuthNCertificationLevels: []string{"FIDO_CERTIFIED_L2"},
 	})
 	assert.Equal(t, want, got)
 }
@@ -534,6 +566,11 @@ func Test_securitySettingsToCommand(t *testing.T) {
 		EnableIframeEmbedding: true,
 		AllowedOrigins:        []string{"foo", "bar"},
 		EnableImpersonation:   true,
+		WebAuthNAttestation: domain.WebAuthNAttestationPolicy{
+			Required:            true,
+			DeniedAAGUIDs:       []string{"ee882879-721c-4913-9775-3dfcce97072a"},
+			CertificationLevels: []string{"FIDO_CERTIFIED_L2"},
+		},
 	}
 	got := securitySettingsToCommand(&settings.SetSecuritySettingsRequest{
 		EmbeddedIframe: &settings.EmbeddedIframeSettings{
@@ -541,6 +578,11 @@ func Test_securitySettingsToCommand(t *testing.T) {
 			AllowedOrigins: []string{"foo", "bar"},
 		},
 		EnableImpersonation: true,
+		WebAuthnAttestation: &settings.WebAuthNAttestationSettings{
+			Required:            true,
+			DeniedAaguids:       []string{"ee882879-721c-4913-9775-3dfcce97072a"},
+			CertificationLevels: []string{"FIDO_CERTIFIED_L2"},
+		},
 	})
 	assert.Equal(t, want, got)
 }
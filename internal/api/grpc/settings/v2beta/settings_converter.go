@@ -218,7 +218,12 @@ func idpTypeToPb(idpType domain.IDPType) settings.IdentityProviderType {
 		return settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_GOOGLE
 	case domain.IDPTypeSAML:
 		return settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_SAML
-	case domain.IDPTypeApple:
+	case domain.IDPTypeApple,
+		domain.IDPTypeOkta,
+		domain.IDPTypeKeycloak,
+		domain.IDPTypeADFS,
+		domain.IDPTypeBitbucket,
+		domain.IDPTypeDiscord:
 		// Handle all remaining cases so the linter succeeds
 		return settings.IdentityProviderType_IDENTITY_PROVIDER_TYPE_UNSPECIFIED
 	default:
@@ -211,8 +211,10 @@ func AuthMethodToPb(mfa *query.AuthMethod) *user_pb.AuthFactor {
 	case domain.UserAuthMethodTypeU2F:
 		factor.Type = &user_pb.AuthFactor_U2F{
 			U2F: &user_pb.AuthFactorU2F{
-				Id:   mfa.TokenID,
-				Name: mfa.Name,
+				Id:        mfa.TokenID,
+				Name:      mfa.Name,
+				Aaguid:    mfa.AAGUID,
+				ModelName: mfa.ModelName,
 			},
 		}
 	case domain.UserAuthMethodTypeOTPSMS:
@@ -248,9 +250,11 @@ func UserAuthMethodsToWebAuthNTokenPb(methods *query.AuthMethods) []*user_pb.Web
 
 func UserAuthMethodToWebAuthNTokenPb(token *query.AuthMethod) *user_pb.WebAuthNToken {
 	return &user_pb.WebAuthNToken{
-		Id:    token.TokenID,
-		State: MFAStateToPb(token.State),
-		Name:  token.Name,
+		Id:        token.TokenID,
+		State:     MFAStateToPb(token.State),
+		Name:      token.Name,
+		Aaguid:    token.AAGUID,
+		ModelName: token.ModelName,
 	}
 }
 
@@ -3,6 +3,8 @@ package user
 import (
 	"context"
 
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	"github.com/zitadel/zitadel/internal/api/grpc/object/v2"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -82,6 +84,20 @@ func (s *Server) RemoveIDPLink(ctx context.Context, req *user.RemoveIDPLinkReque
 	}, nil
 }
 
+func (s *Server) GetIDPLinkAccessToken(ctx context.Context, req *user.GetIDPLinkAccessTokenRequest) (*user.GetIDPLinkAccessTokenResponse, error) {
+	token, err := s.command.GetUserIDPLinkAccessToken(ctx, req.GetUserId(), "", req.GetIdpId(), req.GetLinkedUserId())
+	if err != nil {
+		return nil, err
+	}
+	resp := &user.GetIDPLinkAccessTokenResponse{
+		AccessToken: token.AccessToken,
+	}
+	if !token.Expiry.IsZero() {
+		resp.ExpirationDate = timestamppb.New(token.Expiry)
+	}
+	return resp, nil
+}
+
 func RemoveIDPLinkRequestToDomain(ctx context.Context, req *user.RemoveIDPLinkRequest) *domain.UserIDPLink {
 	return &domain.UserIDPLink{
 		ObjectRoot: models.ObjectRoot{
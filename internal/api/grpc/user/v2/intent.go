@@ -16,15 +16,21 @@ import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/mapping"
+	"github.com/zitadel/zitadel/internal/idp/providers/adfs"
 	"github.com/zitadel/zitadel/internal/idp/providers/apple"
 	"github.com/zitadel/zitadel/internal/idp/providers/azuread"
+	"github.com/zitadel/zitadel/internal/idp/providers/bitbucket"
+	"github.com/zitadel/zitadel/internal/idp/providers/discord"
 	"github.com/zitadel/zitadel/internal/idp/providers/github"
 	"github.com/zitadel/zitadel/internal/idp/providers/gitlab"
 	"github.com/zitadel/zitadel/internal/idp/providers/google"
 	"github.com/zitadel/zitadel/internal/idp/providers/jwt"
+	"github.com/zitadel/zitadel/internal/idp/providers/keycloak"
 	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
 	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
 	"github.com/zitadel/zitadel/internal/idp/providers/oidc"
+	"github.com/zitadel/zitadel/internal/idp/providers/okta"
 	"github.com/zitadel/zitadel/internal/idp/providers/saml"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -171,7 +177,7 @@ func (s *Server) RetrieveIdentityProviderIntent(ctx context.Context, req *user.R
 		case *oauth.Provider:
 			idpUser, err = unmarshalRawIdpUser(intent.IDPUser, p.User())
 		case *oidc.Provider:
-			idpUser, err = unmarshalIdpUser(intent.IDPUser, oidc.InitUser())
+			idpUser, err = unmarshalIdpUser(intent.IDPUser, p.User())
 		case *jwt.Provider:
 			idpUser, err = unmarshalIdpUser(intent.IDPUser, jwt.InitUser())
 		case *azuread.Provider:
@@ -180,6 +186,16 @@ func (s *Server) RetrieveIdentityProviderIntent(ctx context.Context, req *user.R
 			idpUser, err = unmarshalIdpUser(intent.IDPUser, &github.User{})
 		case *gitlab.Provider:
 			idpUser, err = unmarshalIdpUser(intent.IDPUser, oidc.InitUser())
+		case *okta.Provider:
+			idpUser, err = unmarshalIdpUser(intent.IDPUser, okta.InitUser())
+		case *keycloak.Provider:
+			idpUser, err = unmarshalIdpUser(intent.IDPUser, keycloak.InitUser())
+		case *adfs.Provider:
+			idpUser, err = unmarshalIdpUser(intent.IDPUser, adfs.InitUser())
+		case *bitbucket.Provider:
+			idpUser, err = unmarshalIdpUser(intent.IDPUser, &bitbucket.User{})
+		case *discord.Provider:
+			idpUser, err = unmarshalIdpUser(intent.IDPUser, &discord.User{})
 		case *google.Provider:
 			idpUser, err = unmarshalIdpUser(intent.IDPUser, google.InitUser())
 		case *saml.Provider:
@@ -365,5 +381,12 @@ func idpUserToAddHumanUser(idpUser idp.User, idpID string) *user.AddHumanUserReq
 			addHumanUser.Phone.Verification = &user.SetHumanPhone_IsVerified{IsVerified: isPhoneVerified}
 		}
 	}
+	// the mapped grants can not be set on the user creation,
+	// they are applied (together with the metadata for existing users) when the intent is checked on a session
+	if mapped, ok := idpUser.(*mapping.User); ok {
+		for _, metadata := range mapped.Metadata() {
+			addHumanUser.Metadata = append(addHumanUser.Metadata, &user.SetMetadataEntry{Key: metadata.Key, Value: metadata.Value})
+		}
+	}
 	return addHumanUser
 }
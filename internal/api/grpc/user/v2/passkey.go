@@ -162,9 +162,11 @@ func authMethodsToPasskeyPb(methods *query.AuthMethods) []*user.Passkey {
 
 func authMethodToPasskeyPb(token *query.AuthMethod) *user.Passkey {
 	return &user.Passkey{
-		Id:    token.TokenID,
-		State: mfaStateToPb(token.State),
-		Name:  token.Name,
+		Id:        token.TokenID,
+		State:     mfaStateToPb(token.State),
+		Name:      token.Name,
+		Aaguid:    token.AAGUID,
+		ModelName: token.ModelName,
 	}
 }
 
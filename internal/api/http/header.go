@@ -11,6 +11,7 @@ import (
 
 const (
 	Authorization    = "authorization"
+	DPoP             = "dpop"
 	Accept           = "accept"
 	AcceptLanguage   = "accept-language"
 	CacheControl     = "cache-control"
@@ -27,6 +28,9 @@ const (
 	ForwardedProto   = "x-forwarded-proto"
 	Forwarded        = "forwarded"
 	ZitadelForwarded = "x-zitadel-forwarded"
+	GatewayMethod    = "zitadel-gateway-method"
+	GatewayPath      = "zitadel-gateway-path"
+	GatewaySecret    = "zitadel-gateway-secret"
 	XUserAgent       = "x-user-agent"
 	XGrpcWeb         = "x-grpc-web"
 	XRequestedWith   = "x-requested-with"
@@ -8,22 +8,29 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/dpop"
 	http_util "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/mtls"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
 type AuthInterceptor struct {
-	verifier         authz.APITokenVerifier
-	authConfig       authz.Config
-	systemAuthConfig authz.Config
+	verifier          authz.APITokenVerifier
+	authConfig        authz.Config
+	systemAuthConfig  authz.Config
+	certificateHeader string
 }
 
-func AuthorizationInterceptor(verifier authz.APITokenVerifier, systemAuthConfig authz.Config, authConfig authz.Config) *AuthInterceptor {
+// AuthorizationInterceptor checks the authorization of the requests.
+// The certificateHeader is the header in which a reverse proxy terminating TLS passes the client certificate,
+// it is used to check certificate-bound tokens, if the connection itself has no client certificate.
+func AuthorizationInterceptor(verifier authz.APITokenVerifier, systemAuthConfig authz.Config, authConfig authz.Config, certificateHeader string) *AuthInterceptor {
 	return &AuthInterceptor{
-		verifier:         verifier,
-		authConfig:       authConfig,
-		systemAuthConfig: systemAuthConfig,
+		verifier:          verifier,
+		authConfig:        authConfig,
+		systemAuthConfig:  systemAuthConfig,
+		certificateHeader: certificateHeader,
 	}
 }
 
@@ -33,7 +40,7 @@ func (a *AuthInterceptor) Handler(next http.Handler) http.Handler {
 
 func (a *AuthInterceptor) HandlerFunc(next http.Handler) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		ctx, err := authorize(r, a.verifier, a.systemAuthConfig, a.authConfig)
+		ctx, err := authorize(r, a.verifier, a.systemAuthConfig, a.authConfig, a.certificateHeader)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusUnauthorized)
 			return
@@ -46,7 +53,7 @@ func (a *AuthInterceptor) HandlerFunc(next http.Handler) http.HandlerFunc {
 
 func (a *AuthInterceptor) HandlerFuncWithError(next HandlerFuncWithError) HandlerFuncWithError {
 	return func(w http.ResponseWriter, r *http.Request) error {
-		ctx, err := authorize(r, a.verifier, a.systemAuthConfig, a.authConfig)
+		ctx, err := authorize(r, a.verifier, a.systemAuthConfig, a.authConfig, a.certificateHeader)
 		if err != nil {
 			return err
 		}
@@ -58,7 +65,7 @@ func (a *AuthInterceptor) HandlerFuncWithError(next HandlerFuncWithError) Handle
 
 type httpReq struct{}
 
-func authorize(r *http.Request, verifier authz.APITokenVerifier, systemAuthConfig authz.Config, authConfig authz.Config) (_ context.Context, err error) {
+func authorize(r *http.Request, verifier authz.APITokenVerifier, systemAuthConfig authz.Config, authConfig authz.Config, certificateHeader string) (_ context.Context, err error) {
 	ctx := r.Context()
 
 	authOpt, needsToken := checkAuthMethod(r, verifier)
@@ -73,6 +80,13 @@ func authorize(r *http.Request, verifier authz.APITokenVerifier, systemAuthConfi
 		return nil, zerrors.ThrowUnauthenticated(nil, "AUT-1179", "auth header missing")
 	}
 
+	if proof := r.Header.Get(http_util.DPoP); proof != "" {
+		authCtx = dpop.WithRequest(authCtx, &dpop.Request{Proof: proof, Method: r.Method, URI: http_util.DomainContext(authCtx).Origin() + dpop.RequestPath(r)})
+	}
+	if certificates := mtls.CertificatesFromRequest(r, certificateHeader); len(certificates) > 0 {
+		authCtx = mtls.WithCertificates(authCtx, certificates)
+	}
+
 	ctxSetter, err := authz.CheckUserAuthorization(authCtx, &httpReq{}, authToken, http_util.GetOrgID(r), "", verifier, systemAuthConfig.RolePermissionMappings, authConfig.RolePermissionMappings, authOpt, r.RequestURI)
 	if err != nil {
 		return nil, err
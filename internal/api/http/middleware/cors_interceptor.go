@@ -17,6 +17,7 @@ var (
 			http_utils.Accept,
 			http_utils.AcceptLanguage,
 			http_utils.Authorization,
+			http_utils.DPoP,
 			http_utils.ZitadelOrgID,
 			http_utils.XUserAgent,
 			http_utils.XGrpcWeb,
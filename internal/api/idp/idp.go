@@ -25,15 +25,20 @@ import (
 	"github.com/zitadel/zitadel/internal/domain/federatedlogout"
 	"github.com/zitadel/zitadel/internal/form"
 	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/adfs"
 	"github.com/zitadel/zitadel/internal/idp/providers/apple"
 	"github.com/zitadel/zitadel/internal/idp/providers/azuread"
+	"github.com/zitadel/zitadel/internal/idp/providers/bitbucket"
+	"github.com/zitadel/zitadel/internal/idp/providers/discord"
 	"github.com/zitadel/zitadel/internal/idp/providers/github"
 	"github.com/zitadel/zitadel/internal/idp/providers/gitlab"
 	"github.com/zitadel/zitadel/internal/idp/providers/google"
 	"github.com/zitadel/zitadel/internal/idp/providers/jwt"
+	"github.com/zitadel/zitadel/internal/idp/providers/keycloak"
 	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
 	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
 	openid "github.com/zitadel/zitadel/internal/idp/providers/oidc"
+	"github.com/zitadel/zitadel/internal/idp/providers/okta"
 	saml2 "github.com/zitadel/zitadel/internal/idp/providers/saml"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -553,6 +558,16 @@ func (h *Handler) fetchIDPUserFromCode(ctx context.Context, identityProvider idp
 		session = oauth.NewSession(provider.Provider, code, idpArguments)
 	case *gitlab.Provider:
 		session = openid.NewSession(provider.Provider, code, idpArguments)
+	case *okta.Provider:
+		session = openid.NewSession(provider.Provider, code, idpArguments)
+	case *keycloak.Provider:
+		session = openid.NewSession(provider.Provider, code, idpArguments)
+	case *adfs.Provider:
+		session = openid.NewSession(provider.Provider, code, idpArguments)
+	case *bitbucket.Provider:
+		session = bitbucket.NewSession(provider, code, idpArguments)
+	case *discord.Provider:
+		session = oauth.NewSession(provider.Provider, code, idpArguments)
 	case *google.Provider:
 		session = openid.NewSession(provider.Provider, code, idpArguments)
 	case *apple.Provider:
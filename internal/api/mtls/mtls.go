@@ -0,0 +1,201 @@
+// Package mtls implements the mutual TLS client authentication
+// and certificate-bound access tokens (https://www.rfc-editor.org/rfc/rfc8705).
+package mtls
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// ConfirmationMethod is the member of the confirmation (cnf) claim
+// containing the thumbprint of the certificate a token is bound to.
+const ConfirmationMethod = "x5t#S256"
+
+type certificatesKey struct{}
+
+// WithCertificates sets the client certificate chain (leaf first) of the request into the context.
+func WithCertificates(ctx context.Context, certificates []*x509.Certificate) context.Context {
+	return context.WithValue(ctx, certificatesKey{}, certificates)
+}
+
+// CertificatesFromContext returns the client certificate chain (leaf first) set by [WithCertificates].
+func CertificatesFromContext(ctx context.Context) []*x509.Certificate {
+	certificates, _ := ctx.Value(certificatesKey{}).([]*x509.Certificate)
+	return certificates
+}
+
+// CertificateFromContext returns the client certificate set by [WithCertificates] or nil if there is none.
+func CertificateFromContext(ctx context.Context) *x509.Certificate {
+	certificates := CertificatesFromContext(ctx)
+	if len(certificates) == 0 {
+		return nil
+	}
+	return certificates[0]
+}
+
+// ThumbprintFromContext returns the thumbprint of the client certificate set by [WithCertificates]
+// or an empty string if there is none.
+func ThumbprintFromContext(ctx context.Context) string {
+	certificate := CertificateFromContext(ctx)
+	if certificate == nil {
+		return ""
+	}
+	return Thumbprint(certificate)
+}
+
+// Thumbprint returns the base64url encoded SHA-256 hash of the DER encoded certificate (x5t#S256).
+func Thumbprint(certificate *x509.Certificate) string {
+	hash := sha256.Sum256(certificate.Raw)
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// ParseCertificate parses a client certificate passed by a reverse proxy in a header.
+// The certificate can either be an (URL encoded) PEM or a base64 encoded DER.
+func ParseCertificate(value string) (*x509.Certificate, error) {
+	value = strings.TrimSpace(value)
+	var err error
+	if strings.Contains(value, "%") {
+		// reverse proxies (e.g. nginx' $ssl_client_escaped_cert) URL encode the PEM
+		if value, err = url.PathUnescape(value); err != nil {
+			return nil, zerrors.ThrowUnauthenticated(err, "MTLS-ahX7e", "Errors.Project.App.ClientCertificateInvalid")
+		}
+	}
+	var der []byte
+	if block, _ := pem.Decode([]byte(value)); block != nil {
+		der = block.Bytes
+	} else if der, err = base64.StdEncoding.DecodeString(value); err != nil {
+		return nil, zerrors.ThrowUnauthenticated(err, "MTLS-Eif3o", "Errors.Project.App.ClientCertificateInvalid")
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, zerrors.ThrowUnauthenticated(err, "MTLS-Gah9u", "Errors.Project.App.ClientCertificateInvalid")
+	}
+	return certificate, nil
+}
+
+// CertificatesFromRequest returns the client certificate chain of the TLS connection of the request
+// or, if there is none, the certificate passed by a reverse proxy in the header (see [CertificatesFromHeader]).
+func CertificatesFromRequest(r *http.Request, header string) []*x509.Certificate {
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		return r.TLS.PeerCertificates
+	}
+	if header == "" {
+		return nil
+	}
+	return CertificatesFromHeader(r.Header.Get(header))
+}
+
+// CertificatesFromHeader returns the certificate passed by a reverse proxy in the value of a header.
+// An invalid certificate is ignored, so requests not using mutual TLS aren't affected,
+// clients authenticating with a certificate or presenting a certificate-bound token will fail on its verification.
+func CertificatesFromHeader(value string) []*x509.Certificate {
+	if value == "" {
+		return nil
+	}
+	certificate, err := ParseCertificate(value)
+	if err != nil {
+		return nil
+	}
+	return []*x509.Certificate{certificate}
+}
+
+// VerifyChain verifies the certificate chain (leaf first) against the roots (PKI method, RFC 8705 section 2.1)
+// and checks the subject distinguished name (RFC 4514) of the leaf certificate.
+// If roots is nil, the system pool is used.
+func VerifyChain(certificates []*x509.Certificate, roots *x509.CertPool, subjectDN string) error {
+	if len(certificates) == 0 {
+		return zerrors.ThrowUnauthenticated(nil, "MTLS-ooY8e", "Errors.Project.App.ClientCertificateInvalid")
+	}
+	intermediates := x509.NewCertPool()
+	for _, certificate := range certificates[1:] {
+		intermediates.AddCert(certificate)
+	}
+	_, err := certificates[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return zerrors.ThrowUnauthenticated(err, "MTLS-Ohc4k", "Errors.Project.App.ClientCertificateInvalid")
+	}
+	if !EqualDN(certificates[0].Subject.String(), subjectDN) {
+		return zerrors.ThrowUnauthenticated(nil, "MTLS-Xu3ba", "Errors.Project.App.ClientCertificateInvalid")
+	}
+	return nil
+}
+
+// VerifyPublicKey checks that the public key of the certificate is one of the registered keys
+// (self-signed certificate method, RFC 8705 section 2.2).
+// The chain and validity of the certificate are not verified, as the key itself is trusted.
+func VerifyPublicKey(certificate *x509.Certificate, keys []crypto.PublicKey) error {
+	if certificate == nil {
+		return zerrors.ThrowUnauthenticated(nil, "MTLS-ieS2a", "Errors.Project.App.ClientCertificateInvalid")
+	}
+	publicKey, ok := certificate.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+	if !ok {
+		return zerrors.ThrowUnauthenticated(nil, "MTLS-Pha2e", "Errors.Project.App.ClientCertificateInvalid")
+	}
+	for _, key := range keys {
+		if publicKey.Equal(key) {
+			return nil
+		}
+	}
+	return zerrors.ThrowUnauthenticated(nil, "MTLS-quu6E", "Errors.Project.App.ClientCertificateInvalid")
+}
+
+// EqualDN compares two distinguished names in their string representation (RFC 4514),
+// ignoring whitespace around the attributes and the case of the attribute types.
+func EqualDN(a, b string) bool {
+	attributesA, attributesB := splitDN(a), splitDN(b)
+	if len(attributesA) != len(attributesB) || len(attributesA) == 0 {
+		return false
+	}
+	for i := range attributesA {
+		if attributesA[i] != attributesB[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func splitDN(dn string) []string {
+	var (
+		attributes []string
+		current    strings.Builder
+		escaped    bool
+	)
+	appendAttribute := func() {
+		attribute := strings.TrimSpace(current.String())
+		current.Reset()
+		if attribute == "" {
+			return
+		}
+		if typ, value, ok := strings.Cut(attribute, "="); ok {
+			attribute = strings.ToUpper(strings.TrimSpace(typ)) + "=" + strings.TrimSpace(value)
+		}
+		attributes = append(attributes, attribute)
+	}
+	for _, r := range dn {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ',' || r == '+':
+			appendAttribute()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	appendAttribute()
+	return attributes
+}
@@ -0,0 +1,287 @@
+package mtls
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCertificate(t *testing.T, subject pkix.Name, usage x509.ExtKeyUsage, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      subject,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return certificate, key
+}
+
+func TestCertificatesFromContext(t *testing.T) {
+	ca, _ := newCertificate(t, pkix.Name{CommonName: "ca"}, x509.ExtKeyUsageClientAuth, nil, nil)
+
+	assert.Nil(t, CertificateFromContext(context.Background()))
+	assert.Nil(t, CertificateFromContext(WithCertificates(context.Background(), nil)))
+	assert.Equal(t, ca, CertificateFromContext(WithCertificates(context.Background(), []*x509.Certificate{ca})))
+}
+
+func TestThumbprint(t *testing.T) {
+	certificate, _ := newCertificate(t, pkix.Name{CommonName: "client"}, x509.ExtKeyUsageClientAuth, nil, nil)
+	hash := sha256.Sum256(certificate.Raw)
+
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(hash[:]), Thumbprint(certificate))
+	assert.Equal(t, Thumbprint(certificate), ThumbprintFromContext(WithCertificates(context.Background(), []*x509.Certificate{certificate})))
+	assert.Empty(t, ThumbprintFromContext(context.Background()))
+}
+
+func TestParseCertificate(t *testing.T) {
+	certificate, _ := newCertificate(t, pkix.Name{CommonName: "client"}, x509.ExtKeyUsageClientAuth, nil, nil)
+	pemEncoded := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw}))
+
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{
+			name:  "pem",
+			value: pemEncoded,
+		},
+		{
+			name:  "url encoded pem",
+			value: url.PathEscape(pemEncoded),
+		},
+		{
+			name:  "base64 der",
+			value: base64.StdEncoding.EncodeToString(certificate.Raw),
+		},
+		{
+			name:    "invalid encoding",
+			value:   "not a certificate",
+			wantErr: true,
+		},
+		{
+			name:    "invalid certificate",
+			value:   base64.StdEncoding.EncodeToString([]byte("certificate")),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCertificate(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, certificate.Raw, got.Raw)
+		})
+	}
+}
+
+func TestCertificatesFromRequest(t *testing.T) {
+	connection, _ := newCertificate(t, pkix.Name{CommonName: "connection"}, x509.ExtKeyUsageClientAuth, nil, nil)
+	proxy, _ := newCertificate(t, pkix.Name{CommonName: "proxy"}, x509.ExtKeyUsageClientAuth, nil, nil)
+	proxyHeader := base64.StdEncoding.EncodeToString(proxy.Raw)
+
+	tests := []struct {
+		name    string
+		tls     *tls.ConnectionState
+		headers map[string]string
+		header  string
+		want    []*x509.Certificate
+	}{
+		{
+			name: "no certificate",
+		},
+		{
+			name: "tls connection",
+			tls:  &tls.ConnectionState{PeerCertificates: []*x509.Certificate{connection}},
+			headers: map[string]string{
+				"X-Client-Cert": proxyHeader,
+			},
+			header: "X-Client-Cert",
+			want:   []*x509.Certificate{connection},
+		},
+		{
+			name: "header",
+			headers: map[string]string{
+				"X-Client-Cert": proxyHeader,
+			},
+			header: "x-client-cert",
+			want:   []*x509.Certificate{proxy},
+		},
+		{
+			name: "header not configured",
+			headers: map[string]string{
+				"X-Client-Cert": proxyHeader,
+			},
+		},
+		{
+			name: "invalid header",
+			headers: map[string]string{
+				"X-Client-Cert": "not a certificate",
+			},
+			header: "X-Client-Cert",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.TLS = tt.tls
+			for name, value := range tt.headers {
+				r.Header.Set(name, value)
+			}
+			assert.Equal(t, tt.want, CertificatesFromRequest(r, tt.header))
+		})
+	}
+}
+
+func TestVerifyChain(t *testing.T) {
+	ca, caKey := newCertificate(t, pkix.Name{CommonName: "ca"}, x509.ExtKeyUsageClientAuth, nil, nil)
+	client, _ := newCertificate(t, pkix.Name{CommonName: "client", Organization: []string{"zitadel"}}, x509.ExtKeyUsageClientAuth, ca, caKey)
+	server, _ := newCertificate(t, pkix.Name{CommonName: "client", Organization: []string{"zitadel"}}, x509.ExtKeyUsageServerAuth, ca, caKey)
+	other, _ := newCertificate(t, pkix.Name{CommonName: "other"}, x509.ExtKeyUsageClientAuth, nil, nil)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	tests := []struct {
+		name         string
+		certificates []*x509.Certificate
+		subjectDN    string
+		wantErr      bool
+	}{
+		{
+			name:      "no certificate",
+			subjectDN: "CN=client,O=zitadel",
+			wantErr:   true,
+		},
+		{
+			name:         "untrusted",
+			certificates: []*x509.Certificate{other},
+			subjectDN:    "CN=other",
+			wantErr:      true,
+		},
+		{
+			name:         "no client auth usage",
+			certificates: []*x509.Certificate{server},
+			subjectDN:    "CN=client,O=zitadel",
+			wantErr:      true,
+		},
+		{
+			name:         "subject mismatch",
+			certificates: []*x509.Certificate{client},
+			subjectDN:    "CN=other,O=zitadel",
+			wantErr:      true,
+		},
+		{
+			name:         "ok",
+			certificates: []*x509.Certificate{client},
+			subjectDN:    "cn=client, O=zitadel",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyChain(tt.certificates, roots, tt.subjectDN)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestVerifyPublicKey(t *testing.T) {
+	certificate, key := newCertificate(t, pkix.Name{CommonName: "client"}, x509.ExtKeyUsageClientAuth, nil, nil)
+	_, otherKey := newCertificate(t, pkix.Name{CommonName: "other"}, x509.ExtKeyUsageClientAuth, nil, nil)
+
+	tests := []struct {
+		name        string
+		certificate *x509.Certificate
+		keys        []crypto.PublicKey
+		wantErr     bool
+	}{
+		{
+			name:    "no certificate",
+			keys:    []crypto.PublicKey{&key.PublicKey},
+			wantErr: true,
+		},
+		{
+			name:        "no keys",
+			certificate: certificate,
+			wantErr:     true,
+		},
+		{
+			name:        "other key",
+			certificate: certificate,
+			keys:        []crypto.PublicKey{&otherKey.PublicKey},
+			wantErr:     true,
+		},
+		{
+			name:        "ok",
+			certificate: certificate,
+			keys:        []crypto.PublicKey{&otherKey.PublicKey, &key.PublicKey},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyPublicKey(tt.certificate, tt.keys)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEqualDN(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{"equal", "CN=client,O=zitadel", "CN=client,O=zitadel", true},
+		{"whitespace and type case", "CN=client,O=zitadel", " cn = client , o=zitadel", true},
+		{"value case", "CN=client,O=zitadel", "CN=Client,O=zitadel", false},
+		{"order", "CN=client,O=zitadel", "O=zitadel,CN=client", false},
+		{"escaped comma", `CN=client\,O=zitadel`, "CN=client,O=zitadel", false},
+		{"empty", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, EqualDN(tt.a, tt.b))
+		})
+	}
+}
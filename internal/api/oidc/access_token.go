@@ -35,6 +35,10 @@ type accessToken struct {
 	tokenExpiration   time.Time
 	isPAT             bool
 	actor             *domain.TokenActor
+	dpopJKT           string
+	certThumbprint    string
+
+	authorizationDetails domain.AuthorizationDetails
 }
 
 var ErrInvalidTokenFormat = errors.New("invalid token format")
@@ -113,6 +117,10 @@ func accessTokenV2(tokenID, subject string, token *query.OIDCSessionAccessTokenR
 		tokenCreation:     token.AccessTokenCreation,
 		tokenExpiration:   token.AccessTokenExpiration,
 		actor:             token.Actor,
+		dpopJKT:           token.DPoPJKT,
+		certThumbprint:    token.CertThumbprint,
+
+		authorizationDetails: token.AuthorizationDetails,
 	}
 }
 
@@ -43,36 +43,81 @@ func (o *OPStorage) CreateAuthRequest(ctx context.Context, req *oidc.AuthRequest
 		span.EndWithError(err)
 	}()
 
+	loginClient, loginV2, err := o.loginClient(ctx, req.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	if loginV2 {
+		return o.createAuthRequestLoginClient(ctx, req, userID, loginClient)
+	}
+	return o.createAuthRequest(ctx, req, userID, "")
+}
+
+// loginClient returns the login client passed by the login
+// and whether the auth requests of the client are handled by the login v2.
+func (o *OPStorage) loginClient(ctx context.Context, clientID string) (loginClient string, loginV2 bool, err error) {
 	// for backwards compatibility we pass the login client if set
 	headers, _ := http_utils.HeadersFromCtx(ctx)
-	loginClient := headers.Get(LoginClientHeader)
+	loginClient = headers.Get(LoginClientHeader)
 
 	// for backwards compatibility we'll use the new login if the header is set (no matter the other configs)
 	if loginClient != "" {
-		return o.createAuthRequestLoginClient(ctx, req, userID, loginClient)
+		return loginClient, true, nil
 	}
 
 	// if the instance requires the v2 login, use it no matter what the application configured
 	if authz.GetFeatures(ctx).LoginV2.Required {
-		return o.createAuthRequestLoginClient(ctx, req, userID, loginClient)
+		return loginClient, true, nil
 	}
 
-	version, err := o.query.OIDCClientLoginVersion(ctx, req.ClientID)
+	version, err := o.query.OIDCClientLoginVersion(ctx, clientID)
 	if err != nil {
-		return nil, err
+		return "", false, err
 	}
+	// since we already checked for a login header, an unspecified version falls back to the v1 login
+	return loginClient, version == domain.LoginVersion2, nil
+}
 
-	switch version {
-	case domain.LoginVersion1:
-		return o.createAuthRequest(ctx, req, userID)
-	case domain.LoginVersion2:
-		return o.createAuthRequestLoginClient(ctx, req, userID, loginClient)
-	case domain.LoginVersionUnspecified:
-		fallthrough
-	default:
-		// since we already checked for a login header, we can fall back to the v1 login
-		return o.createAuthRequest(ctx, req, userID)
+// usePushedAuthRequest uses the pushed authorization request (RFC 9126) at the authorization endpoint.
+// For the login v2 the pushed auth request is continued as auth request.
+// The login v1 keeps its auth requests in its own storage,
+// so the pushed auth request is handed over with its ID.
+func (o *OPStorage) usePushedAuthRequest(ctx context.Context, id string, req *oidc.AuthRequest) (_ op.AuthRequest, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() {
+		err = oidcError(err)
+		span.EndWithError(err)
+	}()
+
+	loginClient, loginV2, err := o.loginClient(ctx, req.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	if !loginV2 {
+		pushed, err := o.command.UsePushedAuthRequest(ctx, id, req.ClientID, nil)
+		if err != nil {
+			return nil, err
+		}
+		var hintUserID string
+		if pushed.HintUserID != nil {
+			hintUserID = *pushed.HintUserID
+		}
+		return o.createAuthRequest(withAuthorizationDetails(ctx, pushed.AuthorizationDetails, nil), req, hintUserID, strings.TrimPrefix(id, command.IDPrefixV2))
 	}
+	scope, audience, err := o.createAuthRequestScopeAndAudience(ctx, req.ClientID, req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	pushed, err := o.command.UsePushedAuthRequest(ctx, id, req.ClientID, &command.PushedAuthRequestLogin{
+		LoginClient:      loginClient,
+		Scope:            scope,
+		Audience:         audience,
+		NeedRefreshToken: slices.Contains(scope, oidc.ScopeOfflineAccess),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &AuthRequestV2{pushed}, nil
 }
 
 func (o *OPStorage) createAuthRequestScopeAndAudience(ctx context.Context, clientID string, reqScope []string) (scope, audience []string, err error) {
@@ -93,6 +138,10 @@ func (o *OPStorage) createAuthRequestScopeAndAudience(ctx context.Context, clien
 }
 
 func (o *OPStorage) createAuthRequestLoginClient(ctx context.Context, req *oidc.AuthRequest, hintUserID, loginClient string) (op.AuthRequest, error) {
+	authorizationDetails, err := authorizationDetailsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	scope, audience, err := o.createAuthRequestScopeAndAudience(ctx, req.ClientID, req.Scopes)
 	if err != nil {
 		return nil, err
@@ -113,6 +162,8 @@ func (o *OPStorage) createAuthRequestLoginClient(ctx context.Context, req *oidc.
 		UILocales:        UILocalesToBusiness(req.UILocales),
 		MaxAge:           MaxAgeToBusiness(req.MaxAge),
 		Issuer:           o.contextToIssuer(ctx),
+
+		AuthorizationDetails: authorizationDetails,
 	}
 	if req.LoginHint != "" {
 		authRequest.LoginHint = &req.LoginHint
@@ -128,17 +179,25 @@ func (o *OPStorage) createAuthRequestLoginClient(ctx context.Context, req *oidc.
 	return &AuthRequestV2{aar}, nil
 }
 
-func (o *OPStorage) createAuthRequest(ctx context.Context, req *oidc.AuthRequest, userID string) (_ op.AuthRequest, err error) {
+// createAuthRequest creates the auth request of the login v1.
+// If no id is passed, a new one is generated.
+func (o *OPStorage) createAuthRequest(ctx context.Context, req *oidc.AuthRequest, userID, id string) (_ op.AuthRequest, err error) {
 	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
 	if !ok {
 		return nil, zerrors.ThrowPreconditionFailed(nil, "OIDC-sd436", "no user agent id")
 	}
+	authorizationDetails, err := authorizationDetailsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 	scope, audience, err := o.createAuthRequestScopeAndAudience(ctx, req.ClientID, req.Scopes)
 	if err != nil {
 		return nil, err
 	}
 	req.Scopes = scope
 	authRequest := CreateAuthRequestToBusiness(ctx, req, userAgentID, userID, audience)
+	authRequest.ID = id
+	authRequest.Request.(*domain.AuthRequestOIDC).AuthorizationDetails = authorizationDetails
 	resp, err := o.repo.CreateAuthRequest(ctx, authRequest)
 	if err != nil {
 		return nil, err
@@ -610,6 +669,9 @@ func (s *Server) CreateTokenCallbackURL(ctx context.Context, req op.AuthRequest)
 	if !ok {
 		return "", zerrors.ThrowInternal(nil, "OIDC-waeN6", "Error.Internal")
 	}
+	if client.client.RequireDPoP {
+		return "", errImplicitFlowDPoP()
+	}
 
 	session, state, err := s.command.CreateOIDCSessionFromAuthRequest(
 		setContextUserSystem(ctx),
@@ -617,6 +679,9 @@ func (s *Server) CreateTokenCallbackURL(ctx context.Context, req op.AuthRequest)
 		implicitFlowComplianceChecker(),
 		slices.Contains(client.GrantTypes(), oidc.GrantTypeRefreshToken),
 		client.client.BackChannelLogoutURI,
+		"", // tokens of the implicit flow are not sent to the token endpoint and can't be bound
+		"",
+		nil,
 	)
 	if err != nil {
 		return "", err
@@ -625,7 +690,7 @@ func (s *Server) CreateTokenCallbackURL(ctx context.Context, req op.AuthRequest)
 	if err != nil {
 		return "", err
 	}
-	callback, err := op.AuthResponseURL(req.GetRedirectURI(), req.GetResponseType(), req.GetResponseMode(), resp, provider.Encoder())
+	callback, err := op.AuthResponseURL(req.GetRedirectURI(), req.GetResponseType(), req.GetResponseMode(), resp.AccessTokenResponse, provider.Encoder())
 	if err != nil {
 		return "", err
 	}
@@ -697,6 +762,11 @@ func (s *Server) authResponseToken(authReq *AuthRequest, authorizer op.Authorize
 	if !ok {
 		return zerrors.ThrowInternal(nil, "OIDC-waeN6", "Error.Internal")
 	}
+	if client.client.RequireDPoP {
+		err = errImplicitFlowDPoP()
+		op.AuthRequestError(w, r, authReq, err, authorizer)
+		return err
+	}
 
 	scope := authReq.GetScopes()
 	session, err := s.command.CreateOIDCSession(ctx,
@@ -716,6 +786,10 @@ func (s *Server) authResponseToken(authReq *AuthRequest, authorizer op.Authorize
 		slices.Contains(scope, oidc.ScopeOfflineAccess),
 		authReq.SessionID,
 		authReq.oidc().ResponseType,
+		"", // tokens of the implicit flow are not sent to the token endpoint and can't be bound
+		"",
+		authReq.oidc().AuthorizationDetails,
+		nil,
 	)
 	if err != nil {
 		op.AuthRequestError(w, r, authReq, err, authorizer)
@@ -728,14 +802,14 @@ func (s *Server) authResponseToken(authReq *AuthRequest, authorizer op.Authorize
 	}
 
 	if authReq.GetResponseMode() == oidc.ResponseModeFormPost {
-		if err = op.AuthResponseFormPost(w, authReq.GetRedirectURI(), resp, authorizer.Encoder()); err != nil {
+		if err = op.AuthResponseFormPost(w, authReq.GetRedirectURI(), resp.AccessTokenResponse, authorizer.Encoder()); err != nil {
 			op.AuthRequestError(w, r, authReq, err, authorizer)
 			return err
 		}
 		return nil
 	}
 
-	callback, err := op.AuthResponseURL(authReq.GetRedirectURI(), authReq.GetResponseType(), authReq.GetResponseMode(), resp, authorizer.Encoder())
+	callback, err := op.AuthResponseURL(authReq.GetRedirectURI(), authReq.GetResponseType(), authReq.GetResponseMode(), resp.AccessTokenResponse, authorizer.Encoder())
 	if err != nil {
 		op.AuthRequestError(w, r, authReq, err, authorizer)
 		return err
@@ -176,6 +176,25 @@ func PromptToBusiness(oidcPrompt []string) []domain.Prompt {
 	return prompts
 }
 
+func PromptToOIDC(prompts []domain.Prompt) []string {
+	oidcPrompts := make([]string, 0, len(prompts))
+	for _, prompt := range prompts {
+		switch prompt {
+		case domain.PromptNone:
+			oidcPrompts = append(oidcPrompts, oidc.PromptNone)
+		case domain.PromptLogin:
+			oidcPrompts = append(oidcPrompts, oidc.PromptLogin)
+		case domain.PromptConsent:
+			oidcPrompts = append(oidcPrompts, oidc.PromptConsent)
+		case domain.PromptSelectAccount:
+			oidcPrompts = append(oidcPrompts, oidc.PromptSelectAccount)
+		case domain.PromptCreate:
+			oidcPrompts = append(oidcPrompts, "create")
+		}
+	}
+	return oidcPrompts
+}
+
 func ACRValuesToBusiness(values []string) []domain.LevelOfAssurance {
 	return nil
 }
@@ -191,6 +210,21 @@ func UILocalesToBusiness(tags []language.Tag) []string {
 	return locales
 }
 
+func UILocalesToOIDC(locales []string) []language.Tag {
+	if locales == nil {
+		return nil
+	}
+	tags := make([]language.Tag, 0, len(locales))
+	for _, locale := range locales {
+		tag, err := language.Parse(locale)
+		if err != nil {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
 func GetSelectedIDPIDFromScopes(scopes oidc.SpaceDelimitedArray) string {
 	for _, scope := range scopes {
 		if strings.HasPrefix(scope, domain.SelectIDPScope) {
@@ -208,6 +242,14 @@ func MaxAgeToBusiness(maxAge *uint) *time.Duration {
 	return &dur
 }
 
+func MaxAgeToOIDC(maxAge *time.Duration) *uint {
+	if maxAge == nil {
+		return nil
+	}
+	seconds := uint(*maxAge / time.Second)
+	return &seconds
+}
+
 func ResponseTypeToBusiness(responseType oidc.ResponseType) domain.OIDCResponseType {
 	switch responseType {
 	case oidc.ResponseTypeCode:
@@ -287,7 +329,8 @@ func AMRFromMFAType(mfaType domain.MFAType) string {
 	switch mfaType {
 	case domain.MFATypeTOTP,
 		domain.MFATypeOTPSMS,
-		domain.MFATypeOTPEmail:
+		domain.MFATypeOTPEmail,
+		domain.MFATypeRecoveryCode:
 		return OTP
 	case domain.MFATypeU2F,
 		domain.MFATypeU2FUserVerification:
@@ -127,3 +127,36 @@ func TestPromptToBusiness(t *testing.T) {
 		})
 	}
 }
+
+func TestPromptToOIDC(t *testing.T) {
+	type args struct {
+		prompts []domain.Prompt
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "unspecified",
+			args: args{nil},
+			want: []string{},
+		},
+		{
+			name: "prompt_none",
+			args: args{[]domain.Prompt{domain.PromptNone}},
+			want: []string{oidc.PromptNone},
+		},
+		{
+			name: "prompt_login create",
+			args: args{[]domain.Prompt{domain.PromptLogin, domain.PromptCreate}},
+			want: []string{oidc.PromptLogin, "create"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PromptToOIDC(tt.args.prompts)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
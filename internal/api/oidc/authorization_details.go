@@ -0,0 +1,84 @@
+package oidc
+
+import (
+	"context"
+	"maps"
+	"net/url"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+const (
+	authorizationDetailsParam = "authorization_details"
+	authorizationDetailsClaim = "authorization_details"
+)
+
+// errInvalidAuthorizationDetails is returned if the authorization_details are malformed,
+// contain types not declared by the project of the client or exceed the granted authorization (RFC 9396, section 5).
+func errInvalidAuthorizationDetails() *oidc.Error {
+	return &oidc.Error{
+		ErrorType: "invalid_authorization_details",
+	}
+}
+
+// accessTokenResponse extends the [oidc.AccessTokenResponse]
+// with the authorization_details the access token was issued for (RFC 9396, section 7).
+type accessTokenResponse struct {
+	*oidc.AccessTokenResponse
+	AuthorizationDetails domain.AuthorizationDetails `json:"authorization_details,omitempty"`
+}
+
+// authorizationDetailsFromForm parses the authorization_details parameter of a request (RFC 9396, section 2)
+// and validates the types against the ones declared by the project of the client.
+func authorizationDetailsFromForm(form url.Values, client *Client) (domain.AuthorizationDetails, error) {
+	details, err := domain.ParseAuthorizationDetails(form.Get(authorizationDetailsParam))
+	if err != nil {
+		return nil, errInvalidAuthorizationDetails().WithDescription("authorization_details must be a JSON array").WithParent(err)
+	}
+	if err = details.Validate(client.client.AuthorizationDetailsTypes); err != nil {
+		return nil, errInvalidAuthorizationDetails().WithDescription("authorization_details type not allowed for the client").WithParent(err)
+	}
+	return details, nil
+}
+
+type authorizationDetailsKey struct{}
+
+type authorizationDetailsRequest struct {
+	details domain.AuthorizationDetails
+	err     error
+}
+
+// withAuthorizationDetails passes the requested authorization_details to [OPStorage.CreateAuthRequest],
+// as the [oidc.AuthRequest] of the OIDC library does not contain them.
+// Invalid authorization_details are passed as error, so it's returned only after the redirect_uri is validated.
+func withAuthorizationDetails(ctx context.Context, details domain.AuthorizationDetails, err error) context.Context {
+	if len(details) == 0 && err == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, authorizationDetailsKey{}, &authorizationDetailsRequest{details: details, err: err})
+}
+
+func authorizationDetailsFromContext(ctx context.Context) (domain.AuthorizationDetails, error) {
+	request, ok := ctx.Value(authorizationDetailsKey{}).(*authorizationDetailsRequest)
+	if !ok {
+		return nil, nil
+	}
+	return request.details, request.err
+}
+
+// authorizationDetailsClaims returns a copy of the claims with the authorization_details claim
+// for JWT access tokens and introspection responses (RFC 9396, section 9).
+// If there are no authorization details the claims are returned unchanged.
+func authorizationDetailsClaims(claims map[string]any, details domain.AuthorizationDetails) map[string]any {
+	if len(details) == 0 {
+		return claims
+	}
+	claims = maps.Clone(claims)
+	if claims == nil {
+		claims = make(map[string]any, 1)
+	}
+	claims[authorizationDetailsClaim] = details
+	return claims
+}
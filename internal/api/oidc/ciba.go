@@ -0,0 +1,340 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	httphelper "github.com/zitadel/oidc/v3/pkg/http"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// GrantTypeCIBA is the grant type of the token request
+	// of the client initiated backchannel authentication (CIBA) flow.
+	GrantTypeCIBA oidc.GrantType = "urn:openid:params:grant-type:ciba"
+
+	BackchannelAuthDefaultLifetime     = 5 * time.Minute
+	BackchannelAuthDefaultPollInterval = 5 * time.Second
+
+	backchannelBindingMessageMaxLength = 200
+	// backchannelSlowDownIncrease is added to the poll interval of a request after a too early poll (CIBA Core, section 11).
+	backchannelSlowDownIncrease = 5 * time.Second
+)
+
+type BackchannelAuthConfig struct {
+	Lifetime     time.Duration
+	PollInterval time.Duration
+}
+
+// withDefaults returns the config with sane defaults for empty values.
+// Safe to call when c is nil.
+func (c *BackchannelAuthConfig) withDefaults() BackchannelAuthConfig {
+	out := BackchannelAuthConfig{
+		Lifetime:     BackchannelAuthDefaultLifetime,
+		PollInterval: BackchannelAuthDefaultPollInterval,
+	}
+	if c == nil {
+		return out
+	}
+	if c.Lifetime != 0 {
+		out.Lifetime = c.Lifetime
+	}
+	if c.PollInterval != 0 {
+		out.PollInterval = c.PollInterval
+	}
+	return out
+}
+
+var backchannelTokenDeliveryModes = []string{"poll", "ping"}
+
+type backchannelAuthRequest struct {
+	Scopes                  oidc.SpaceDelimitedArray `schema:"scope"`
+	ClientNotificationToken string                   `schema:"client_notification_token"`
+	LoginHintToken          string                   `schema:"login_hint_token"`
+	IDTokenHint             string                   `schema:"id_token_hint"`
+	LoginHint               string                   `schema:"login_hint"`
+	BindingMessage          string                   `schema:"binding_message"`
+	UserCode                string                   `schema:"user_code"`
+	RequestedExpiry         int64                    `schema:"requested_expiry"`
+}
+
+type backchannelAuthResponse struct {
+	AuthReqID string `json:"auth_req_id"`
+	ExpiresIn uint64 `json:"expires_in"`
+	Interval  uint64 `json:"interval,omitempty"`
+}
+
+type backchannelTokenRequest struct {
+	GrantType oidc.GrantType `schema:"grant_type"`
+	AuthReqID string         `schema:"auth_req_id"`
+}
+
+func backchannelAuthEndpoint(endpoints *EndpointConfig) *op.Endpoint {
+	if endpoints == nil || endpoints.BackchannelAuth == nil || endpoints.BackchannelAuth.Path == "" {
+		return op.NewEndpoint("/oauth/v2/bc-authorize")
+	}
+	return op.NewEndpointWithURL(endpoints.BackchannelAuth.Path, endpoints.BackchannelAuth.URL)
+}
+
+// backchannelAuthHandler implements the backchannel authentication endpoint
+// of the client initiated backchannel authentication (CIBA) flow.
+// The authenticated client identifies the user by a login_hint or id_token_hint
+// and receives an auth_req_id, which can be exchanged for tokens at the token endpoint,
+// as soon as the user approved the request.
+func (s *Server) backchannelAuthHandler(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.backchannelAuth(r)
+	if err != nil {
+		op.WriteError(w, r, oidcError(err), s.getLogger(r.Context()))
+		return
+	}
+	httphelper.MarshalJSON(w, resp)
+}
+
+func (s *Server) backchannelAuth(r *http.Request) (_ *backchannelAuthResponse, err error) {
+	ctx, span := tracing.NewSpan(r.Context())
+	defer func() { span.EndWithError(err) }()
+
+	if err = r.ParseForm(); err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("error parsing form").WithParent(err)
+	}
+	client, err := s.verifyBackchannelClient(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	req := new(backchannelAuthRequest)
+	if err = s.Provider().Decoder().Decode(req, r.PostForm); err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("error decoding form").WithParent(err)
+	}
+	if err = validateBackchannelAuthRequest(req, client.client.BackchannelTokenDeliveryMode); err != nil {
+		return nil, err
+	}
+	user, err := s.backchannelAuthUser(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	scope, audience, err := s.Provider().Storage().(*OPStorage).createAuthRequestScopeAndAudience(ctx, client.GetID(), req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	lifetime := s.backchannelAuthConfig.Lifetime
+	if requested := time.Duration(req.RequestedExpiry) * time.Second; requested > 0 && requested < lifetime {
+		lifetime = requested
+	}
+	authRequest := &command.BackchannelAuthRequest{
+		ClientID:                client.GetID(),
+		UserID:                  user.ID,
+		UserOrgID:               user.ResourceOwner,
+		Scopes:                  scope,
+		Audience:                audience,
+		BindingMessage:          req.BindingMessage,
+		Expires:                 time.Now().Add(lifetime),
+		NeedRefreshToken:        slices.Contains(scope, oidc.ScopeOfflineAccess),
+		DeliveryMode:            client.client.BackchannelTokenDeliveryMode,
+		NotificationEndpoint:    client.client.BackchannelClientNotificationEndpoint,
+		ClientNotificationToken: req.ClientNotificationToken,
+	}
+	if _, err = s.command.AddBackchannelAuth(ctx, authRequest); err != nil {
+		return nil, err
+	}
+	resp := &backchannelAuthResponse{
+		AuthReqID: authRequest.ID,
+		ExpiresIn: uint64(lifetime / time.Second),
+	}
+	if authRequest.DeliveryMode == domain.OIDCBackchannelTokenDeliveryModePoll {
+		resp.Interval = uint64(s.backchannelAuthConfig.PollInterval / time.Second)
+	}
+	return resp, nil
+}
+
+func validateBackchannelAuthRequest(req *backchannelAuthRequest, mode domain.OIDCBackchannelTokenDeliveryMode) error {
+	if !slices.Contains(req.Scopes, oidc.ScopeOpenID) {
+		return oidc.ErrInvalidScope().WithDescription("scope openid is required")
+	}
+	if req.LoginHintToken != "" {
+		return oidc.ErrInvalidRequest().WithDescription("login_hint_token is not supported")
+	}
+	if req.UserCode != "" {
+		return oidc.ErrInvalidRequest().WithDescription("user_code is not supported")
+	}
+	if (req.LoginHint == "") == (req.IDTokenHint == "") {
+		return oidc.ErrInvalidRequest().WithDescription("exactly one of login_hint or id_token_hint must be provided")
+	}
+	if len(req.BindingMessage) > backchannelBindingMessageMaxLength {
+		return (&oidc.Error{ErrorType: "invalid_binding_message"}).WithDescription("binding_message must not exceed %d characters", backchannelBindingMessageMaxLength)
+	}
+	if req.RequestedExpiry < 0 {
+		return oidc.ErrInvalidRequest().WithDescription("requested_expiry must be positive")
+	}
+	if mode == domain.OIDCBackchannelTokenDeliveryModePing && req.ClientNotificationToken == "" {
+		return oidc.ErrInvalidRequest().WithDescription("client_notification_token is required")
+	}
+	return nil
+}
+
+// verifyBackchannelClient authenticates the client and checks that it is allowed to use the CIBA grant.
+func (s *Server) verifyBackchannelClient(ctx context.Context, r *http.Request) (*Client, error) {
+	opClient, err := s.verifyPushingClient(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	client, ok := opClient.(*Client)
+	if !ok {
+		return nil, zerrors.ThrowInternal(nil, "OIDC-ooX4a", "Error.Internal")
+	}
+	if !slices.Contains(client.client.GrantTypes, domain.OIDCGrantTypeCIBA) {
+		return nil, oidc.ErrUnauthorizedClient().WithDescription("client is not allowed to use the CIBA grant")
+	}
+	return client, nil
+}
+
+// backchannelAuthUser resolves the active user the request is initiated for
+// by the subject of the id_token_hint or the login name in the login_hint.
+func (s *Server) backchannelAuthUser(ctx context.Context, req *backchannelAuthRequest) (_ *query.User, err error) {
+	var user *query.User
+	if req.IDTokenHint != "" {
+		claims, err := op.VerifyIDTokenHint[*oidc.IDTokenClaims](ctx, req.IDTokenHint, s.Provider().IDTokenHintVerifier(ctx))
+		if err != nil {
+			return nil, errUnknownUserID().WithDescription("invalid id_token_hint").WithParent(err)
+		}
+		user, err = s.query.GetUserByID(ctx, false, claims.GetSubject())
+	} else {
+		user, err = s.query.GetUserByLoginName(ctx, false, req.LoginHint)
+	}
+	if err != nil {
+		return nil, errUnknownUserID().WithParent(err)
+	}
+	if user.State != domain.UserStateActive {
+		return nil, errUnknownUserID()
+	}
+	return user, nil
+}
+
+func errUnknownUserID() *oidc.Error {
+	return &oidc.Error{
+		ErrorType:   "unknown_user_id",
+		Description: "the user could not be identified",
+	}
+}
+
+// backchannelTokenHandler intercepts token requests using the CIBA grant type,
+// which is not handled by the token endpoint of the OIDC library.
+// All other requests are passed to the next handler.
+func (s *Server) backchannelTokenHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != s.Endpoints().Token.Relative() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if err := r.ParseForm(); err != nil || oidc.GrantType(r.PostForm.Get("grant_type")) != GrantTypeCIBA {
+			next.ServeHTTP(w, r)
+			return
+		}
+		resp, err := s.backchannelToken(r)
+		if err != nil {
+			op.RequestError(w, r, oidcError(err), s.getLogger(r.Context()))
+			return
+		}
+		httphelper.MarshalJSON(w, resp)
+	})
+}
+
+func (s *Server) backchannelToken(r *http.Request) (_ *accessTokenResponse, err error) {
+	ctx, span := tracing.NewSpan(r.Context())
+	defer func() { span.EndWithError(err) }()
+
+	client, err := s.verifyBackchannelClient(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	req := new(backchannelTokenRequest)
+	if err = s.Provider().Decoder().Decode(req, r.PostForm); err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("error decoding form").WithParent(err)
+	}
+	if req.AuthReqID == "" {
+		return nil, oidc.ErrInvalidRequest().WithDescription("auth_req_id missing")
+	}
+	if client.client.BackchannelTokenDeliveryMode == domain.OIDCBackchannelTokenDeliveryModePoll &&
+		!s.backchannelPolls.poll(req.AuthReqID, s.backchannelAuthConfig.PollInterval, s.backchannelAuthConfig.Lifetime, time.Now()) {
+		return nil, oidc.ErrSlowDown()
+	}
+	dpopJKT, err := s.verifyTokenRequestDPoP(ctx, r.Header, client.client.RequireDPoP)
+	if err != nil {
+		return nil, err
+	}
+	session, err := s.command.CreateOIDCSessionFromBackchannelAuth(ctx, req.AuthReqID, client.GetID(), client.client.BackChannelLogoutURI, dpopJKT, clientCertificateThumbprint(ctx, client))
+	if err == nil {
+		return s.accessTokenResponseFromSession(ctx, client, session, "", client.client.ProjectID, client.client.ProjectRoleAssertion, client.client.AccessTokenRoleAssertion, client.client.IDTokenRoleAssertion, client.client.IDTokenUserinfoAssertion)
+	}
+
+	var target command.BackchannelAuthStateError
+	if errors.As(err, &target) {
+		switch domain.BackchannelAuthState(target) {
+		case domain.BackchannelAuthStateInitiated:
+			return nil, oidc.ErrAuthorizationPending()
+		case domain.BackchannelAuthStateExpired:
+			return nil, &oidc.Error{ErrorType: oidc.ExpiredToken, Description: "The \"auth_req_id\" has expired."}
+		case domain.BackchannelAuthStateDenied:
+			return nil, oidc.ErrAccessDenied()
+		}
+	}
+	return nil, oidc.ErrInvalidGrant().WithParent(err).WithReturnParentToClient(authz.GetFeatures(ctx).DebugOIDCParentError)
+}
+
+// backchannelPolls records the last token request per auth_req_id
+// to enforce the poll interval returned by the backchannel authentication endpoint.
+// The polls are recorded per process.
+type backchannelPolls struct {
+	mu         sync.Mutex
+	polls      map[string]*backchannelPoll
+	lastPruned time.Time
+}
+
+type backchannelPoll struct {
+	last     time.Time
+	interval time.Duration
+}
+
+func newBackchannelPolls() *backchannelPolls {
+	return &backchannelPolls{polls: make(map[string]*backchannelPoll)}
+}
+
+// poll records the token request for the auth_req_id and returns false
+// if the interval since the last request did not elapse yet.
+// Each too early request increases the interval of the auth_req_id.
+// Polls older than the lifetime of the requests are pruned.
+func (p *backchannelPolls) poll(id string, interval, lifetime time.Duration, now time.Time) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if now.Sub(p.lastPruned) > lifetime {
+		for pollID, poll := range p.polls {
+			if now.Sub(poll.last) > lifetime {
+				delete(p.polls, pollID)
+			}
+		}
+		p.lastPruned = now
+	}
+	last, ok := p.polls[id]
+	if !ok {
+		p.polls[id] = &backchannelPoll{last: now, interval: interval}
+		return true
+	}
+	tooEarly := now.Sub(last.last) < last.interval
+	if tooEarly {
+		last.interval += backchannelSlowDownIncrease
+	}
+	last.last = now
+	return !tooEarly
+}
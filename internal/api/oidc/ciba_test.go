@@ -0,0 +1,151 @@
+package oidc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+func TestBackchannelAuthConfig_withDefaults(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *BackchannelAuthConfig
+		want   BackchannelAuthConfig
+	}{
+		{
+			name:   "nil",
+			config: nil,
+			want: BackchannelAuthConfig{
+				Lifetime:     BackchannelAuthDefaultLifetime,
+				PollInterval: BackchannelAuthDefaultPollInterval,
+			},
+		},
+		{
+			name: "custom",
+			config: &BackchannelAuthConfig{
+				Lifetime:     time.Minute,
+				PollInterval: time.Second,
+			},
+			want: BackchannelAuthConfig{
+				Lifetime:     time.Minute,
+				PollInterval: time.Second,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.config.withDefaults())
+		})
+	}
+}
+
+func Test_validateBackchannelAuthRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *backchannelAuthRequest
+		mode    domain.OIDCBackchannelTokenDeliveryMode
+		wantErr string
+	}{
+		{
+			name: "missing openid scope",
+			req: &backchannelAuthRequest{
+				Scopes:    oidc.SpaceDelimitedArray{oidc.ScopeProfile},
+				LoginHint: "user",
+			},
+			wantErr: string(oidc.InvalidScope),
+		},
+		{
+			name: "login_hint_token",
+			req: &backchannelAuthRequest{
+				Scopes:         oidc.SpaceDelimitedArray{oidc.ScopeOpenID},
+				LoginHintToken: "token",
+			},
+			wantErr: string(oidc.InvalidRequest),
+		},
+		{
+			name: "no hint",
+			req: &backchannelAuthRequest{
+				Scopes: oidc.SpaceDelimitedArray{oidc.ScopeOpenID},
+			},
+			wantErr: string(oidc.InvalidRequest),
+		},
+		{
+			name: "both hints",
+			req: &backchannelAuthRequest{
+				Scopes:      oidc.SpaceDelimitedArray{oidc.ScopeOpenID},
+				LoginHint:   "user",
+				IDTokenHint: "token",
+			},
+			wantErr: string(oidc.InvalidRequest),
+		},
+		{
+			name: "binding message too long",
+			req: &backchannelAuthRequest{
+				Scopes:         oidc.SpaceDelimitedArray{oidc.ScopeOpenID},
+				LoginHint:      "user",
+				BindingMessage: string(make([]byte, backchannelBindingMessageMaxLength+1)),
+			},
+			wantErr: "invalid_binding_message",
+		},
+		{
+			name: "ping without notification token",
+			req: &backchannelAuthRequest{
+				Scopes:    oidc.SpaceDelimitedArray{oidc.ScopeOpenID},
+				LoginHint: "user",
+			},
+			mode:    domain.OIDCBackchannelTokenDeliveryModePing,
+			wantErr: string(oidc.InvalidRequest),
+		},
+		{
+			name: "poll",
+			req: &backchannelAuthRequest{
+				Scopes:         oidc.SpaceDelimitedArray{oidc.ScopeOpenID},
+				LoginHint:      "user",
+				BindingMessage: "W4SCT",
+			},
+		},
+		{
+			name: "ping",
+			req: &backchannelAuthRequest{
+				Scopes:                  oidc.SpaceDelimitedArray{oidc.ScopeOpenID},
+				IDTokenHint:             "token",
+				ClientNotificationToken: "notification",
+			},
+			mode: domain.OIDCBackchannelTokenDeliveryModePing,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBackchannelAuthRequest(tt.req, tt.mode)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var oidcErr *oidc.Error
+			if assert.ErrorAs(t, err, &oidcErr) {
+				assert.Equal(t, tt.wantErr, string(oidcErr.ErrorType))
+			}
+		})
+	}
+}
+
+func Test_backchannelPolls_poll(t *testing.T) {
+	now := time.Now()
+	polls := newBackchannelPolls()
+
+	assert.True(t, polls.poll("id1", 5*time.Second, time.Minute, now), "first poll")
+	assert.True(t, polls.poll("id2", 5*time.Second, time.Minute, now), "other request")
+	assert.False(t, polls.poll("id1", 5*time.Second, time.Minute, now.Add(4*time.Second)), "too early")
+	assert.False(t, polls.poll("id1", 5*time.Second, time.Minute, now.Add(13*time.Second)), "interval increased")
+	assert.True(t, polls.poll("id1", 5*time.Second, time.Minute, now.Add(28*time.Second)), "increased interval elapsed")
+	assert.True(t, polls.poll("id2", 5*time.Second, time.Minute, now.Add(28*time.Second)), "interval elapsed")
+
+	polls.poll("id3", 5*time.Second, time.Minute, now.Add(2*time.Minute))
+	assert.NotContains(t, polls.polls, "id1", "pruned")
+	assert.NotContains(t, polls.polls, "id2", "pruned")
+	assert.Contains(t, polls.polls, "id3")
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/zitadel/zitadel/internal/actions/object"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	api_http "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/mtls"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -39,6 +40,8 @@ const (
 	ClaimResourceOwnerID            = ScopeResourceOwner + ":id"
 	ClaimResourceOwnerName          = ScopeResourceOwner + ":name"
 	ClaimResourceOwnerPrimaryDomain = ScopeResourceOwner + ":primary_domain"
+	ScopeGroups                     = "urn:zitadel:iam:user:groups"
+	ClaimGroups                     = "groups"
 	ClaimActionLogFormat            = "urn:zitadel:iam:action:%s:log"
 
 	oidcCtx = "oidc"
@@ -355,6 +358,10 @@ func (o *OPStorage) setUserinfo(ctx context.Context, userInfo *oidc.UserInfo, us
 			if err := o.setUserInfoResourceOwner(ctx, userInfo, userID); err != nil {
 				return err
 			}
+		case ScopeGroups:
+			if err := o.setUserInfoGroups(ctx, userInfo, userID); err != nil {
+				return err
+			}
 		case ScopeProjectsRoles:
 			allRoles = true
 		default:
@@ -433,6 +440,17 @@ func (o *OPStorage) setUserInfoMetadata(ctx context.Context, userInfo *oidc.User
 	return nil
 }
 
+func (o *OPStorage) setUserInfoGroups(ctx context.Context, userInfo *oidc.UserInfo, userID string) error {
+	groups, err := o.assertUserGroups(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(groups) > 0 {
+		userInfo.AppendClaims(ClaimGroups, groups)
+	}
+	return nil
+}
+
 func (o *OPStorage) setUserInfoResourceOwner(ctx context.Context, userInfo *oidc.UserInfo, userID string) error {
 	resourceOwnerClaims, err := o.assertUserResourceOwner(ctx, userID)
 	if err != nil {
@@ -618,6 +636,14 @@ func (o *OPStorage) GetPrivateClaimsFromScopes(ctx context.Context, userID, clie
 			for claim, value := range resourceOwnerClaims {
 				claims = appendClaim(claims, claim, value)
 			}
+		case ScopeGroups:
+			groups, err := o.assertUserGroups(ctx, userID)
+			if err != nil {
+				return nil, err
+			}
+			if len(groups) > 0 {
+				claims = appendClaim(claims, ClaimGroups, groups)
+			}
 		case ScopeProjectsRoles:
 			allRoles = true
 		}
@@ -820,6 +846,7 @@ func (o *OPStorage) assertRoles(ctx context.Context, userID, applicationID strin
 			userIDQuery,
 			activeQuery,
 		},
+		IncludeGroupGrants: true,
 	}, true)
 	if err != nil {
 		return nil, nil, err
@@ -856,6 +883,22 @@ func (o *OPStorage) assertUserMetaData(ctx context.Context, userID string) (map[
 	return userMetaData, nil
 }
 
+func (o *OPStorage) assertUserGroups(ctx context.Context, userID string) ([]string, error) {
+	memberQuery, err := query.NewGroupMemberSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := o.query.SearchGroups(ctx, &query.GroupSearchQueries{Queries: []query.SearchQuery{memberQuery}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(groups.Groups))
+	for i, group := range groups.Groups {
+		names[i] = group.Name
+	}
+	return names, nil
+}
+
 func (o *OPStorage) assertUserResourceOwner(ctx context.Context, userID string) (map[string]string, error) {
 	user, err := o.query.GetUserByID(ctx, true, userID)
 	if err != nil {
@@ -984,7 +1027,8 @@ func (s *Server) VerifyClient(ctx context.Context, r *op.Request[op.ClientCreden
 	if err != nil {
 		return nil, err
 	}
-	client, err := s.query.ActiveOIDCClientByID(ctx, clientID, assertion)
+	// the keys are also needed to verify self-signed client certificates
+	client, err := s.query.ActiveOIDCClientByID(ctx, clientID, assertion || mtls.CertificateFromContext(ctx) != nil)
 	if zerrors.IsNotFound(err) {
 		return nil, oidc.ErrInvalidClient().WithParent(err).WithReturnParentToClient(authz.GetFeatures(ctx).DebugOIDCParentError).WithDescription("no active client not found")
 	}
@@ -1003,6 +1047,8 @@ func (s *Server) VerifyClient(ctx context.Context, r *op.Request[op.ClientCreden
 		err = s.verifyClientSecret(ctx, client, r.Data.ClientSecret)
 	case domain.OIDCAuthMethodTypePrivateKeyJWT:
 		err = s.verifyClientAssertion(ctx, client, r.Data.ClientAssertion)
+	case domain.OIDCAuthMethodTypeTLSClientAuth, domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth:
+		err = s.verifyClientCertificate(ctx, client)
 	case domain.OIDCAuthMethodTypeNone:
 	}
 	if err != nil {
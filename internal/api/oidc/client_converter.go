@@ -167,6 +167,10 @@ func authMethodToOIDC(authType domain.OIDCAuthMethodType) oidc.AuthMethod {
 		return oidc.AuthMethodNone
 	case domain.OIDCAuthMethodTypePrivateKeyJWT:
 		return oidc.AuthMethodPrivateKeyJWT
+	case domain.OIDCAuthMethodTypeTLSClientAuth:
+		return AuthMethodTLSClientAuth
+	case domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth:
+		return AuthMethodSelfSignedTLSClientAuth
 	default:
 		return oidc.AuthMethodBasic
 	}
@@ -213,6 +217,8 @@ func grantTypeToOIDC(grantType domain.OIDCGrantType) oidc.GrantType {
 		return oidc.GrantTypeDeviceCode
 	case domain.OIDCGrantTypeTokenExchange:
 		return oidc.GrantTypeTokenExchange
+	case domain.OIDCGrantTypeCIBA:
+		return GrantTypeCIBA
 	default:
 		return oidc.GrantTypeCode
 	}
@@ -268,6 +274,9 @@ func isScopeAllowed(scope string, allowedScopes ...string) bool {
 	if scope == ScopeResourceOwner {
 		return true
 	}
+	if scope == ScopeGroups {
+		return true
+	}
 	if scope == ScopeProjectsRoles {
 		return true
 	}
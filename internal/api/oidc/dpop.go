@@ -0,0 +1,110 @@
+package oidc
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"strings"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/dpop"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/mtls"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	confirmationClaim    = "cnf"
+	confirmationClaimJKT = "jkt"
+)
+
+// errInvalidDPoPProof is returned by the token endpoint if the DPoP proof is missing or invalid (RFC 9449, section 5).
+func errInvalidDPoPProof() *oidc.Error {
+	return &oidc.Error{
+		ErrorType: "invalid_dpop_proof",
+	}
+}
+
+// errImplicitFlowDPoP is returned if a client requiring DPoP uses the implicit flow,
+// as tokens returned from the authorization endpoint can't be bound to a key.
+func errImplicitFlowDPoP() *oidc.Error {
+	return oidc.ErrUnauthorizedClient().WithDescription("implicit flow not allowed for clients requiring DPoP")
+}
+
+// verifyTokenRequestDPoP verifies the DPoP proof (RFC 9449) of a token request
+// and returns the thumbprint of its key, which the issued tokens will be bound to.
+// Requests without a proof are only accepted if DPoP is not required.
+func (s *Server) verifyTokenRequestDPoP(ctx context.Context, header http.Header, requireDPoP bool) (string, error) {
+	proofs := header.Values(http_utils.DPoP)
+	if len(proofs) == 0 {
+		if requireDPoP {
+			return "", errInvalidDPoPProof().WithDescription("DPoP proof required")
+		}
+		return "", nil
+	}
+	if len(proofs) > 1 {
+		return "", errInvalidDPoPProof().WithDescription("multiple DPoP proofs")
+	}
+	thumbprint, err := dpop.Verify(ctx, proofs[0], http.MethodPost, s.Endpoints().Token.Absolute(op.IssuerFromContext(ctx)), "")
+	if err != nil {
+		return "", errInvalidDPoPProof().WithDescription("invalid DPoP proof").WithParent(err)
+	}
+	return thumbprint, nil
+}
+
+// checkAccessTokenDPoP checks that a DPoP bound access token is presented with a valid proof of the bound key
+// and a token presented with the DPoP scheme is bound (see [dpopSchemeHandler]).
+func checkAccessTokenDPoP(ctx context.Context, token *accessToken, tkn, method, uri string) (err error) {
+	var thumbprint string
+	if request := dpop.RequestFromContext(ctx); request != nil {
+		thumbprint, err = dpop.Verify(ctx, request.Proof, method, uri, tkn)
+		if err != nil {
+			return err
+		}
+	}
+	if token.dpopJKT != thumbprint {
+		return zerrors.ThrowUnauthenticated(nil, "OIDC-Xoo1e", "Errors.OIDCSession.Token.Invalid")
+	}
+	return nil
+}
+
+// dpopSchemeHandler allows access tokens to be presented with the DPoP authorization scheme (RFC 9449, section 7.1).
+// As the OIDC library only accepts the Bearer scheme, the header is rewritten
+// and the DPoP proof is passed in the context, so the endpoint can verify the binding of the token.
+func dpopSchemeHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get(http_utils.Authorization), dpop.AuthScheme)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		r = r.Clone(dpop.WithRequest(r.Context(), &dpop.Request{Proof: r.Header.Get(http_utils.DPoP)}))
+		r.Header.Set(http_utils.Authorization, oidc.PrefixBearer+token)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// confirmationClaims returns a copy of the claims with the confirmation claim of a bound token.
+// The confirmation contains the thumbprint of the key of a DPoP bound token (jkt, RFC 9449, section 6)
+// and the thumbprint of the client certificate of a certificate-bound token (x5t#S256, RFC 8705, section 3.1).
+// If the token is not bound, the claims are returned unchanged.
+func confirmationClaims(claims map[string]any, jkt, x5t string) map[string]any {
+	confirmation := make(map[string]string, 2)
+	if jkt != "" {
+		confirmation[confirmationClaimJKT] = jkt
+	}
+	if x5t != "" {
+		confirmation[mtls.ConfirmationMethod] = x5t
+	}
+	if len(confirmation) == 0 {
+		return claims
+	}
+	claims = maps.Clone(claims)
+	if claims == nil {
+		claims = make(map[string]any, 1)
+	}
+	claims[confirmationClaim] = confirmation
+	return claims
+}
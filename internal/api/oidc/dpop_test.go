@@ -0,0 +1,68 @@
+package oidc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/dpop"
+)
+
+func Test_dpopSchemeHandler(t *testing.T) {
+	tests := []struct {
+		name          string
+		authorization string
+		proof         string
+		wantHeader    string
+		wantRequest   *dpop.Request
+	}{
+		{
+			name:          "bearer",
+			authorization: "Bearer token",
+			wantHeader:    "Bearer token",
+		},
+		{
+			name:          "dpop",
+			authorization: "DPoP token",
+			proof:         "proof",
+			wantHeader:    "Bearer token",
+			wantRequest:   &dpop.Request{Proof: "proof"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/oidc/v1/userinfo", nil)
+			req.Header.Set("Authorization", tt.authorization)
+			if tt.proof != "" {
+				req.Header.Set("DPoP", tt.proof)
+			}
+			dpopSchemeHandler(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.wantHeader, r.Header.Get("Authorization"))
+				assert.Equal(t, tt.wantRequest, dpop.RequestFromContext(r.Context()))
+			})).ServeHTTP(httptest.NewRecorder(), req)
+		})
+	}
+}
+
+func Test_confirmationClaims(t *testing.T) {
+	claims := map[string]any{"foo": "bar"}
+	got := confirmationClaims(claims, "jkt", "")
+	assert.Equal(t, map[string]any{
+		"foo": "bar",
+		"cnf": map[string]string{"jkt": "jkt"},
+	}, got)
+	assert.Equal(t, map[string]any{"foo": "bar"}, claims)
+	assert.Equal(t, map[string]any{
+		"cnf": map[string]string{"jkt": "jkt"},
+	}, confirmationClaims(nil, "jkt", ""))
+	assert.Equal(t, map[string]any{
+		"foo": "bar",
+		"cnf": map[string]string{"x5t#S256": "x5t"},
+	}, confirmationClaims(claims, "", "x5t"))
+	assert.Equal(t, map[string]any{
+		"cnf": map[string]string{"jkt": "jkt", "x5t#S256": "x5t"},
+	}, confirmationClaims(nil, "jkt", "x5t"))
+	assert.Equal(t, claims, confirmationClaims(claims, "", ""))
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/zitadel/oidc/v3/pkg/op"
 
 	http_util "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
@@ -38,6 +39,9 @@ func oidcError(err error) error {
 	}
 
 	statusCode, _ := http_util.ZitadelErrorToHTTPStatusCode(err)
+	if zError.GetMessage() == domain.AuthorizationDetailsInvalid {
+		return op.NewStatusError(errInvalidAuthorizationDetails().WithParent(err).WithDescription("%s", zError.GetMessage()), statusCode)
+	}
 	newOidcErr := oidc.ErrServerError
 	if statusCode < 500 {
 		newOidcErr = oidc.ErrInvalidRequest
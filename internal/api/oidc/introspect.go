@@ -11,6 +11,7 @@ import (
 	"github.com/zitadel/oidc/v3/pkg/op"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/dpop"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -132,6 +133,12 @@ func (s *Server) Introspect(ctx context.Context, r *op.Request[op.IntrospectionR
 		Actor:                           actorDomainToClaims(token.actor),
 	}
 	introspectionResp.SetUserInfo(userInfo)
+	if token.dpopJKT != "" {
+		introspectionResp.TokenType = dpop.TokenType
+	}
+	// the resource server has to check the binding of the token against the proof or certificate of its request
+	introspectionResp.Claims = confirmationClaims(introspectionResp.Claims, token.dpopJKT, token.certThumbprint)
+	introspectionResp.Claims = authorizationDetailsClaims(introspectionResp.Claims, token.authorizationDetails)
 	return op.NewResponse(introspectionResp), nil
 }
 
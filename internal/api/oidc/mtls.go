@@ -0,0 +1,132 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"os"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/mtls"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// The OIDC library does not define the mutual TLS client authentication methods (RFC 8705, section 2).
+const (
+	AuthMethodTLSClientAuth           oidc.AuthMethod = "tls_client_auth"
+	AuthMethodSelfSignedTLSClientAuth oidc.AuthMethod = "self_signed_tls_client_auth"
+)
+
+// MTLSConfig configures the mutual TLS client authentication and certificate-bound access tokens (RFC 8705).
+type MTLSConfig struct {
+	// CertificateHeader is the header in which a reverse proxy terminating TLS passes the client certificate.
+	// It is only used, if the connection itself has no client certificate.
+	CertificateHeader string
+	// CACertPath is the path to a PEM file with the certificate authorities trusted for the tls_client_auth method.
+	// If empty, the system pool is used.
+	CACertPath string
+}
+
+// ClientCertificateHeader returns the configured header of the client certificate.
+// Safe to call when c is nil.
+func (c *MTLSConfig) ClientCertificateHeader() string {
+	if c == nil {
+		return ""
+	}
+	return c.CertificateHeader
+}
+
+// roots returns the certificate authorities trusted for the tls_client_auth method.
+// Safe to call when c is nil.
+func (c *MTLSConfig) roots() (*x509.CertPool, error) {
+	if c == nil || c.CACertPath == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(c.CACertPath)
+	if err != nil {
+		return nil, err
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(data) {
+		return nil, zerrors.ThrowInvalidArgument(nil, "OIDC-ieF5e", "no certificates found in mtls ca cert path")
+	}
+	return roots, nil
+}
+
+// clientCertificateHandler passes the client certificate of the TLS connection,
+// or if there is none, from the configured header of the reverse proxy in the context.
+// As the OIDC library does not pass the TLS state to the endpoints, the certificate can't be read from the request there.
+// Clients authenticating with an invalid certificate in the header will fail in [Server.verifyClientCertificate].
+func clientCertificateHandler(config *MTLSConfig) func(http.Handler) http.Handler {
+	header := config.ClientCertificateHeader()
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if certificates := mtls.CertificatesFromRequest(r, header); len(certificates) > 0 {
+				r = r.WithContext(mtls.WithCertificates(r.Context(), certificates))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// verifyClientCertificate authenticates the client with the certificate of the request (RFC 8705, section 2).
+func (s *Server) verifyClientCertificate(ctx context.Context, client *query.OIDCClient) error {
+	certificates := mtls.CertificatesFromContext(ctx)
+	if len(certificates) == 0 {
+		return oidc.ErrInvalidClient().WithDescription("no client certificate")
+	}
+	var err error
+	switch client.AuthMethodType {
+	case domain.OIDCAuthMethodTypeTLSClientAuth:
+		err = mtls.VerifyChain(certificates, s.mtlsRoots, client.TLSClientAuthSubjectDN)
+	case domain.OIDCAuthMethodTypeSelfSignedTLSClientAuth:
+		err = mtls.VerifyPublicKey(certificates[0], clientPublicKeys(client.PublicKeys))
+	}
+	if err != nil {
+		return oidc.ErrInvalidClient().WithParent(err).WithReturnParentToClient(authz.GetFeatures(ctx).DebugOIDCParentError).WithDescription("invalid client certificate")
+	}
+	return nil
+}
+
+// clientPublicKeys parses the PEM encoded public keys of the client.
+// Other than for private_key_jwt, the keys of self-signed certificates are not restricted to RSA.
+func clientPublicKeys(keys map[string][]byte) []crypto.PublicKey {
+	publicKeys := make([]crypto.PublicKey, 0, len(keys))
+	for _, key := range keys {
+		block, _ := pem.Decode(key)
+		if block == nil {
+			continue
+		}
+		publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			continue
+		}
+		publicKeys = append(publicKeys, publicKey)
+	}
+	return publicKeys
+}
+
+// clientCertificateThumbprint returns the thumbprint of the certificate the client authenticated with,
+// which the issued access tokens will be bound to (RFC 8705, section 3).
+// Clients using other authentication methods receive unbound tokens.
+func clientCertificateThumbprint(ctx context.Context, client *Client) string {
+	if !client.client.AuthMethodType.IsMutualTLS() {
+		return ""
+	}
+	return mtls.ThumbprintFromContext(ctx)
+}
+
+// checkAccessTokenCertificate checks that a certificate-bound access token
+// is presented over a connection with the bound certificate (RFC 8705, section 3).
+func checkAccessTokenCertificate(ctx context.Context, token *accessToken) error {
+	if token.certThumbprint != "" && token.certThumbprint != mtls.ThumbprintFromContext(ctx) {
+		return zerrors.ThrowUnauthenticated(nil, "OIDC-oPh4i", "Errors.OIDCSession.Token.Invalid")
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/mtls"
+)
+
+func newTestCertificate(t *testing.T) (*x509.Certificate, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	return certificate, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey})
+}
+
+func Test_clientCertificateHandler(t *testing.T) {
+	certificate, _ := newTestCertificate(t)
+	encoded := url.PathEscape(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw})))
+
+	tests := []struct {
+		name   string
+		config *MTLSConfig
+		header string
+		want   *x509.Certificate
+	}{
+		{
+			name:   "no config",
+			header: encoded,
+		},
+		{
+			name:   "no header",
+			config: &MTLSConfig{CertificateHeader: "X-Client-Cert"},
+		},
+		{
+			name:   "invalid header",
+			config: &MTLSConfig{CertificateHeader: "X-Client-Cert"},
+			header: "invalid",
+		},
+		{
+			name:   "header",
+			config: &MTLSConfig{CertificateHeader: "X-Client-Cert"},
+			header: encoded,
+			want:   certificate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *x509.Certificate
+			handler := clientCertificateHandler(tt.config)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got = mtls.CertificateFromContext(r.Context())
+			}))
+			r := httptest.NewRequest(http.MethodPost, "/oauth/v2/token", nil)
+			if tt.header != "" {
+				r.Header.Set("X-Client-Cert", tt.header)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+			if tt.want == nil {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, tt.want.Raw, got.Raw)
+		})
+	}
+}
+
+func Test_clientPublicKeys(t *testing.T) {
+	certificate, publicKey := newTestCertificate(t)
+	keys := clientPublicKeys(map[string][]byte{
+		"key1":    publicKey,
+		"invalid": []byte("invalid"),
+	})
+	require.Len(t, keys, 1)
+	assert.NoError(t, mtls.VerifyPublicKey(certificate, keys))
+}
+
+func Test_checkAccessTokenCertificate(t *testing.T) {
+	certificate, _ := newTestCertificate(t)
+	ctx := mtls.WithCertificates(context.Background(), []*x509.Certificate{certificate})
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		token   *accessToken
+		wantErr bool
+	}{
+		{
+			name:  "unbound token",
+			ctx:   context.Background(),
+			token: &accessToken{},
+		},
+		{
+			name:  "unbound token with certificate",
+			ctx:   ctx,
+			token: &accessToken{},
+		},
+		{
+			name:    "bound token without certificate",
+			ctx:     context.Background(),
+			token:   &accessToken{certThumbprint: mtls.Thumbprint(certificate)},
+			wantErr: true,
+		},
+		{
+			name:    "bound token with other certificate",
+			ctx:     ctx,
+			token:   &accessToken{certThumbprint: "other"},
+			wantErr: true,
+		},
+		{
+			name:  "bound token",
+			ctx:   ctx,
+			token: &accessToken{certThumbprint: mtls.Thumbprint(certificate)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkAccessTokenCertificate(tt.ctx, tt.token)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 	"github.com/zitadel/oidc/v3/pkg/op"
 
@@ -40,6 +41,9 @@ type Config struct {
 	JWKSCacheControlMaxAge            time.Duration
 	CustomEndpoints                   *EndpointConfig
 	DeviceAuth                        *DeviceAuthorizationConfig
+	PushedAuthRequestLifetime         time.Duration
+	BackchannelAuth                   *BackchannelAuthConfig
+	MTLS                              *MTLSConfig
 	DefaultLoginURLV2                 string
 	DefaultLogoutURLV2                string
 	PublicKeyCacheMaxAge              time.Duration
@@ -55,6 +59,9 @@ type EndpointConfig struct {
 	EndSession    *Endpoint
 	Keys          *Endpoint
 	DeviceAuth    *Endpoint
+	PAR           *Endpoint
+	// BackchannelAuth is the endpoint of the client initiated backchannel authentication (CIBA) flow.
+	BackchannelAuth *Endpoint
 }
 
 type Endpoint struct {
@@ -146,6 +153,10 @@ func NewServer(
 	if err != nil {
 		return nil, zerrors.ThrowInternal(err, "OIDC-Aij4e", "cannot create secret hasher")
 	}
+	mtlsRoots, err := config.MTLS.roots()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "OIDC-Sho4a", "cannot load mtls ca certificates")
+	}
 	server := &Server{
 		LegacyServer: op.NewLegacyServer(&Provider{
 			Provider:          provider,
@@ -153,6 +164,7 @@ func NewServer(
 			idTokenHintKeySet: idTokenHintKeySet,
 		}, endpoints(config.CustomEndpoints)),
 		repo:                       repo,
+		storage:                    storage,
 		query:                      query,
 		command:                    command,
 		accessTokenKeySet:          accessTokenKeySet,
@@ -168,25 +180,55 @@ func NewServer(
 		encAlg:                     encryptionAlg,
 		opCrypto:                   op.NewAESCrypto(opConfig.CryptoKey),
 		assetAPIPrefix:             assets.AssetAPI(),
+		parEndpoint:                parEndpoint(config.CustomEndpoints),
+		parLifetime:                config.PushedAuthRequestLifetime,
+		backchannelAuthEndpoint:    backchannelAuthEndpoint(config.CustomEndpoints),
+		backchannelAuthConfig:      config.BackchannelAuth.withDefaults(),
+		backchannelPolls:           newBackchannelPolls(),
+		mtlsRoots:                  mtlsRoots,
+	}
+	if server.parLifetime == 0 {
+		server.parLifetime = PushedAuthRequestDefaultLifetime
 	}
 	metricTypes := []metrics.MetricType{metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode, metrics.MetricTypeTotalCount}
-	server.Handler = op.RegisterLegacyServer(server,
-		server.authorizeCallbackHandler,
-		op.WithFallbackLogger(fallbackLogger),
-		op.WithHTTPMiddleware(
-			middleware.MetricsHandler(metricTypes),
-			middleware.TelemetryHandler(),
-			middleware.NoCacheInterceptor().Handler,
-			instanceHandler,
-			userAgentCookie,
-			http_utils.CopyHeadersToContext,
-			accessHandler.HandleWithPublicAuthPathPrefixes(publicAuthPathPrefixes(config.CustomEndpoints)),
-			middleware.ActivityHandler,
-		))
+	server.Handler = server.createHandler(
+		middleware.MetricsHandler(metricTypes),
+		middleware.TelemetryHandler(),
+		middleware.NoCacheInterceptor().Handler,
+		instanceHandler,
+		userAgentCookie,
+		http_utils.CopyHeadersToContext,
+		dpopSchemeHandler,
+		clientCertificateHandler(config.MTLS),
+		accessHandler.HandleWithPublicAuthPathPrefixes(publicAuthPathPrefixes(config.CustomEndpoints)),
+		middleware.ActivityHandler,
+		server.backchannelTokenHandler,
+	)
 
 	return server, nil
 }
 
+// createHandler registers the endpoints of the op package and the ones implemented by the server itself (PAR and CIBA).
+// The latter can't be added to the router of the op package using [op.WithSetRouter],
+// because [op.RegisterLegacyServer] adds a middleware after the options and chi panics on middlewares defined after routes.
+// Therefore, they're served by a separate router, which passes all other requests to the op package.
+func (s *Server) createHandler(middlewares ...func(http.Handler) http.Handler) http.Handler {
+	opHandler := op.RegisterLegacyServer(s,
+		s.authorizeCallbackHandler,
+		op.WithFallbackLogger(s.fallbackLogger),
+		op.WithHTTPMiddleware(middlewares...),
+	)
+	router := chi.NewRouter()
+	router.Group(func(r chi.Router) {
+		r.Use(middlewares...)
+		r.Use(op.NewIssuerInterceptor(s.Provider().IssuerFromRequest).Handler)
+		r.Post(s.parEndpoint.Relative(), s.pushedAuthRequestHandler)
+		r.Post(s.backchannelAuthEndpoint.Relative(), s.backchannelAuthHandler)
+	})
+	router.NotFound(opHandler.ServeHTTP)
+	return router
+}
+
 func ContextToIssuer(ctx context.Context) string {
 	return http_utils.DomainContext(ctx).Origin()
 }
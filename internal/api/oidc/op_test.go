@@ -0,0 +1,82 @@
+package oidc
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v3/pkg/op"
+)
+
+func TestServer_createHandler(t *testing.T) {
+	//nolint:staticcheck
+	provider, err := op.NewForwardedOpenIDProvider("path", &op.Config{}, nil)
+	require.NoError(t, err)
+	server := &Server{
+		LegacyServer:            op.NewLegacyServer(provider, endpoints(nil)),
+		fallbackLogger:          slog.Default(),
+		parEndpoint:             parEndpoint(nil),
+		backchannelAuthEndpoint: backchannelAuthEndpoint(nil),
+	}
+	var middlewareCalls int
+	middleware := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			middlewareCalls++
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	var handler http.Handler
+	require.NotPanics(t, func() {
+		handler = server.createHandler(middleware)
+	})
+
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		wantStatus      int
+		wantMiddlewares int
+	}{
+		{
+			name:            "op endpoint",
+			method:          http.MethodGet,
+			path:            "/healthz",
+			wantStatus:      http.StatusOK,
+			wantMiddlewares: 1,
+		},
+		{
+			name:            "par endpoint, method not allowed",
+			method:          http.MethodGet,
+			path:            "/oauth/v2/par",
+			wantStatus:      http.StatusMethodNotAllowed,
+			wantMiddlewares: 0,
+		},
+		{
+			name:            "backchannel authentication endpoint, method not allowed",
+			method:          http.MethodGet,
+			path:            "/oauth/v2/bc-authorize",
+			wantStatus:      http.StatusMethodNotAllowed,
+			wantMiddlewares: 0,
+		},
+		{
+			name:            "unknown endpoint, not found",
+			method:          http.MethodGet,
+			path:            "/unknown",
+			wantStatus:      http.StatusNotFound,
+			wantMiddlewares: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			middlewareCalls = 0
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.path, nil))
+			assert.Equal(t, tt.wantStatus, recorder.Code)
+			assert.Equal(t, tt.wantMiddlewares, middlewareCalls)
+		})
+	}
+}
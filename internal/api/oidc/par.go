@@ -0,0 +1,219 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	httphelper "github.com/zitadel/oidc/v3/pkg/http"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	PushedAuthRequestDefaultLifetime = time.Minute
+
+	requestURIParam  = "request_uri"
+	requestURIPrefix = "urn:ietf:params:oauth:request_uri:"
+)
+
+// discoveryConfiguration extends the [oidc.DiscoveryConfiguration]
+// with the metadata of the pushed authorization request endpoint (RFC 9126),
+// the supported algorithms of DPoP proofs (RFC 9449),
+// the client initiated backchannel authentication (CIBA)
+// and the certificate-bound access tokens of mutual TLS (RFC 8705).
+type discoveryConfiguration struct {
+	*oidc.DiscoveryConfiguration
+	PushedAuthorizationRequestEndpoint     string   `json:"pushed_authorization_request_endpoint,omitempty"`
+	DPoPSigningAlgValuesSupported          []string `json:"dpop_signing_alg_values_supported,omitempty"`
+	BackchannelAuthenticationEndpoint      string   `json:"backchannel_authentication_endpoint,omitempty"`
+	BackchannelTokenDeliveryModesSupported []string `json:"backchannel_token_delivery_modes_supported,omitempty"`
+	TLSClientCertificateBoundAccessTokens  bool     `json:"tls_client_certificate_bound_access_tokens,omitempty"`
+}
+
+type pushedAuthRequestResponse struct {
+	RequestURI string `json:"request_uri"`
+	ExpiresIn  uint64 `json:"expires_in"`
+}
+
+func parEndpoint(endpoints *EndpointConfig) *op.Endpoint {
+	if endpoints == nil || endpoints.PAR == nil || endpoints.PAR.Path == "" {
+		return op.NewEndpoint("/oauth/v2/par")
+	}
+	return op.NewEndpointWithURL(endpoints.PAR.Path, endpoints.PAR.URL)
+}
+
+// pushedAuthRequestHandler implements the pushed authorization request endpoint (RFC 9126).
+// The authenticated client pushes the parameters of an authorization request
+// and receives a request_uri, which can be passed to the authorization endpoint once before it expires.
+func (s *Server) pushedAuthRequestHandler(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.pushedAuthRequest(r)
+	if err != nil {
+		op.WriteError(w, r, oidcError(err), s.getLogger(r.Context()))
+		return
+	}
+	httphelper.MarshalJSONWithStatus(w, resp, http.StatusCreated)
+}
+
+func (s *Server) pushedAuthRequest(r *http.Request) (_ *pushedAuthRequestResponse, err error) {
+	ctx, span := tracing.NewSpan(r.Context())
+	defer func() { span.EndWithError(err) }()
+
+	if err = r.ParseForm(); err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("error parsing form").WithParent(err)
+	}
+	client, err := s.verifyPushingClient(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	authReq := new(oidc.AuthRequest)
+	if err = s.Provider().Decoder().Decode(authReq, r.PostForm); err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("error decoding form").WithParent(err)
+	}
+	if r.PostForm.Has(requestURIParam) {
+		return nil, oidc.ErrInvalidRequest().WithDescription("request_uri must not be pushed")
+	}
+	if authReq.RequestParam != "" {
+		if !s.Provider().RequestObjectSupported() {
+			return nil, oidc.ErrRequestNotSupported()
+		}
+		if err = op.ParseRequestObject(ctx, authReq, s.Provider().Storage(), op.IssuerFromContext(ctx)); err != nil {
+			return nil, err
+		}
+	}
+	if authReq.ClientID == "" {
+		authReq.ClientID = client.GetID()
+	}
+	if authReq.ClientID != client.GetID() {
+		return nil, oidc.ErrInvalidRequest().WithDescription("client_id does not match the authenticated client")
+	}
+	hintUserID, err := op.ValidateAuthRequestClient(ctx, authReq, client, s.Provider().IDTokenHintVerifier(ctx))
+	if err != nil {
+		return nil, err
+	}
+	zClient, ok := client.(*Client)
+	if !ok {
+		return nil, zerrors.ThrowInternal(nil, "OIDC-Eequ4", "Error.Internal")
+	}
+	authorizationDetails, err := authorizationDetailsFromForm(r.PostForm, zClient)
+	if err != nil {
+		return nil, err
+	}
+
+	authRequest := &command.AuthRequest{
+		ClientID:      authReq.ClientID,
+		RedirectURI:   authReq.RedirectURI,
+		State:         authReq.State,
+		Nonce:         authReq.Nonce,
+		Scope:         authReq.Scopes,
+		ResponseType:  ResponseTypeToBusiness(authReq.ResponseType),
+		ResponseMode:  ResponseModeToBusiness(authReq.ResponseMode),
+		CodeChallenge: CodeChallengeToBusiness(authReq.CodeChallenge, authReq.CodeChallengeMethod),
+		Prompt:        PromptToBusiness(authReq.Prompt),
+		UILocales:     UILocalesToBusiness(authReq.UILocales),
+		MaxAge:        MaxAgeToBusiness(authReq.MaxAge),
+		Issuer:        ContextToIssuer(ctx),
+
+		AuthorizationDetails: authorizationDetails,
+	}
+	if authReq.LoginHint != "" {
+		authRequest.LoginHint = &authReq.LoginHint
+	}
+	if hintUserID != "" {
+		authRequest.HintUserID = &hintUserID
+	}
+	pushed, err := s.command.AddPushedAuthRequest(ctx, authRequest, time.Now().Add(s.parLifetime))
+	if err != nil {
+		return nil, err
+	}
+	return &pushedAuthRequestResponse{
+		RequestURI: requestURIPrefix + pushed.ID,
+		ExpiresIn:  uint64(s.parLifetime / time.Second),
+	}, nil
+}
+
+// verifyPushingClient authenticates the client the same way as on the token endpoint.
+func (s *Server) verifyPushingClient(ctx context.Context, r *http.Request) (_ op.Client, err error) {
+	cc := new(op.ClientCredentials)
+	if err = s.Provider().Decoder().Decode(cc, r.PostForm); err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("error decoding form").WithParent(err)
+	}
+	// Basic auth takes precedence, so if set it overwrites the form data.
+	if clientID, clientSecret, ok := r.BasicAuth(); ok {
+		if cc.ClientID, err = url.QueryUnescape(clientID); err != nil {
+			return nil, oidc.ErrInvalidClient().WithDescription("invalid basic auth header").WithParent(err)
+		}
+		if cc.ClientSecret, err = url.QueryUnescape(clientSecret); err != nil {
+			return nil, oidc.ErrInvalidClient().WithDescription("invalid basic auth header").WithParent(err)
+		}
+	}
+	if cc.ClientID == "" && cc.ClientAssertion == "" {
+		return nil, oidc.ErrInvalidRequest().WithDescription("client_id or client_assertion must be provided")
+	}
+	if cc.ClientAssertion != "" && cc.ClientAssertionType != oidc.ClientAssertionTypeJWTAssertion {
+		return nil, oidc.ErrInvalidRequest().WithDescription("invalid client_assertion_type %s", cc.ClientAssertionType)
+	}
+	return s.VerifyClient(ctx, &op.Request[op.ClientCredentials]{
+		Method: r.Method,
+		URL:    r.URL,
+		Header: r.Header,
+		Form:   r.PostForm,
+		Data:   cc,
+	})
+}
+
+// getPushedAuthRequest resolves the request_uri passed to the authorization endpoint
+// to the parameters of the pushed authorization request.
+func (s *Server) getPushedAuthRequest(ctx context.Context, requestURI, clientID string) (_ *oidc.AuthRequest, err error) {
+	defer func() { err = oidcError(err) }()
+
+	id, ok := strings.CutPrefix(requestURI, requestURIPrefix)
+	if !ok || id == "" {
+		return nil, oidc.ErrInvalidRequest().WithDescription("invalid request_uri")
+	}
+	pushed, err := s.command.GetPushedAuthRequest(ctx, id, clientID)
+	if err != nil {
+		return nil, err
+	}
+	return pushedAuthRequestToOIDC(pushed.AuthRequest), nil
+}
+
+// authorizePushedAuthRequest uses the pushed authorization request as auth request for the login.
+// The request_uri can't be used again afterwards.
+// The user of the id_token_hint was already resolved when the request was pushed.
+func (s *Server) authorizePushedAuthRequest(ctx context.Context, r *op.ClientRequest[oidc.AuthRequest], requestURI string) (_ *op.Redirect, err error) {
+	req, err := s.storage.usePushedAuthRequest(ctx, strings.TrimPrefix(requestURI, requestURIPrefix), r.Data)
+	if err != nil {
+		return op.TryErrorRedirect(ctx, r.Data, oidc.DefaultToServerError(err, "unable to use pushed auth request"), s.Provider().Encoder(), s.getLogger(ctx))
+	}
+	return op.NewRedirect(r.Client.LoginURL(req.GetID())), nil
+}
+
+func pushedAuthRequestToOIDC(authRequest *command.AuthRequest) *oidc.AuthRequest {
+	authReq := &oidc.AuthRequest{
+		Scopes:       authRequest.Scope,
+		ResponseType: ResponseTypeToOIDC(authRequest.ResponseType),
+		ClientID:     authRequest.ClientID,
+		RedirectURI:  authRequest.RedirectURI,
+		State:        authRequest.State,
+		Nonce:        authRequest.Nonce,
+		ResponseMode: ResponseModeToOIDC(authRequest.ResponseMode),
+		Prompt:       PromptToOIDC(authRequest.Prompt),
+		MaxAge:       MaxAgeToOIDC(authRequest.MaxAge),
+		UILocales:    UILocalesToOIDC(authRequest.UILocales),
+	}
+	if authRequest.LoginHint != nil {
+		authReq.LoginHint = *authRequest.LoginHint
+	}
+	if challenge := CodeChallengeToOIDC(authRequest.CodeChallenge); challenge != nil {
+		authReq.CodeChallenge = challenge.Challenge
+		authReq.CodeChallengeMethod = challenge.Method
+	}
+	return authReq
+}
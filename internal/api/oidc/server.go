@@ -2,6 +2,7 @@ package oidc
 
 import (
 	"context"
+	"crypto/x509"
 	"log/slog"
 	"net/http"
 	"time"
@@ -11,12 +12,14 @@ import (
 	"github.com/zitadel/oidc/v3/pkg/op"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/dpop"
 	"github.com/zitadel/zitadel/internal/auth/repository"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/i18n"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
 type Server struct {
@@ -24,6 +27,7 @@ type Server struct {
 	*op.LegacyServer
 
 	repo              repository.Repository
+	storage           *OPStorage
 	query             *query.Queries
 	command           *command.Commands
 	accessTokenKeySet *oidcKeySet
@@ -43,6 +47,15 @@ type Server struct {
 	opCrypto            op.Crypto
 
 	assetAPIPrefix func(ctx context.Context) string
+
+	parEndpoint *op.Endpoint
+	parLifetime time.Duration
+
+	backchannelAuthEndpoint *op.Endpoint
+	backchannelAuthConfig   BackchannelAuthConfig
+	backchannelPolls        *backchannelPolls
+
+	mtlsRoots *x509.CertPool
 }
 
 func endpoints(endpointConfig *EndpointConfig) op.Endpoints {
@@ -127,21 +140,50 @@ func (s *Server) Discovery(ctx context.Context, r *op.Request[struct{}]) (_ *op.
 	if len(allowedLanguages) == 0 {
 		allowedLanguages = i18n.SupportedLanguages()
 	}
-	return op.NewResponse(s.createDiscoveryConfig(ctx, allowedLanguages)), nil
+	return op.NewResponse(&discoveryConfiguration{
+		DiscoveryConfiguration:                 s.createDiscoveryConfig(ctx, allowedLanguages),
+		PushedAuthorizationRequestEndpoint:     s.parEndpoint.Absolute(op.IssuerFromContext(ctx)),
+		DPoPSigningAlgValuesSupported:          dpop.SigningAlgorithmNames(),
+		BackchannelAuthenticationEndpoint:      s.backchannelAuthEndpoint.Absolute(op.IssuerFromContext(ctx)),
+		BackchannelTokenDeliveryModesSupported: backchannelTokenDeliveryModes,
+		TLSClientCertificateBoundAccessTokens:  true,
+	}), nil
 }
 
 func (s *Server) VerifyAuthRequest(ctx context.Context, r *op.Request[oidc.AuthRequest]) (_ *op.ClientRequest[oidc.AuthRequest], err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	return s.LegacyServer.VerifyAuthRequest(ctx, r)
+	if requestURI := r.Form.Get(requestURIParam); requestURI != "" {
+		r.Data, err = s.getPushedAuthRequest(ctx, requestURI, r.Data.ClientID)
+		if err != nil {
+			return nil, err
+		}
+		return s.LegacyServer.VerifyAuthRequest(ctx, r)
+	}
+	cr, err := s.LegacyServer.VerifyAuthRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	if client, ok := cr.Client.(*Client); ok && client.client.RequirePAR {
+		return nil, oidc.ErrInvalidRequest().WithDescription("pushed authorization request required")
+	}
+	return cr, nil
 }
 
 func (s *Server) Authorize(ctx context.Context, r *op.ClientRequest[oidc.AuthRequest]) (_ *op.Redirect, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	return s.LegacyServer.Authorize(ctx, r)
+	if requestURI := r.Form.Get(requestURIParam); requestURI != "" {
+		return s.authorizePushedAuthRequest(ctx, r, requestURI)
+	}
+	client, ok := r.Client.(*Client)
+	if !ok {
+		return nil, zerrors.ThrowInternal(nil, "OIDC-ieX9o", "Error.Internal")
+	}
+	details, err := authorizationDetailsFromForm(r.Form, client)
+	return s.LegacyServer.Authorize(withAuthorizationDetails(ctx, details, err), r)
 }
 
 func (s *Server) DeviceAuthorization(ctx context.Context, r *op.ClientRequest[oidc.DeviceAuthorizationRequest]) (_ *op.Response, err error) {
@@ -186,11 +228,11 @@ func (s *Server) createDiscoveryConfig(ctx context.Context, supportedUILocales o
 			string(oidc.ResponseModeFragment),
 			string(oidc.ResponseModeFormPost),
 		},
-		GrantTypesSupported:                                op.GrantTypes(s.Provider()),
+		GrantTypesSupported:                                append(op.GrantTypes(s.Provider()), GrantTypeCIBA),
 		SubjectTypesSupported:                              op.SubjectTypes(s.Provider()),
 		IDTokenSigningAlgValuesSupported:                   supportedSigningAlgs(ctx),
 		RequestObjectSigningAlgValuesSupported:             op.RequestObjectSigAlgorithms(s.Provider()),
-		TokenEndpointAuthMethodsSupported:                  op.AuthMethodsTokenEndpoint(s.Provider()),
+		TokenEndpointAuthMethodsSupported:                  append(op.AuthMethodsTokenEndpoint(s.Provider()), AuthMethodTLSClientAuth, AuthMethodSelfSignedTLSClientAuth),
 		TokenEndpointAuthSigningAlgValuesSupported:         op.TokenSigAlgorithms(s.Provider()),
 		IntrospectionEndpointAuthSigningAlgValuesSupported: op.IntrospectionSigAlgorithms(s.Provider()),
 		IntrospectionEndpointAuthMethodsSupported:          op.AuthMethodsIntrospectionEndpoint(s.Provider()),
@@ -13,6 +13,7 @@ import (
 	"github.com/zitadel/oidc/v3/pkg/op"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/dpop"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -31,15 +32,21 @@ In some cases step 1 till 3 are completely implemented in the command package,
 for example the v2 code exchange and refresh token.
 */
 
-func (s *Server) accessTokenResponseFromSession(ctx context.Context, client op.Client, session *command.OIDCSession, state, projectID string, projectRoleAssertion, accessTokenRoleAssertion, idTokenRoleAssertion, userInfoAssertion bool) (_ *oidc.AccessTokenResponse, err error) {
+func (s *Server) accessTokenResponseFromSession(ctx context.Context, client op.Client, session *command.OIDCSession, state, projectID string, projectRoleAssertion, accessTokenRoleAssertion, idTokenRoleAssertion, userInfoAssertion bool) (_ *accessTokenResponse, err error) {
 	getUserInfo := s.getUserInfo(session.UserID, projectID, projectRoleAssertion, userInfoAssertion, session.Scope)
 	getSigner := s.getSignerOnce()
 
-	resp := &oidc.AccessTokenResponse{
-		TokenType:    oidc.BearerToken,
-		RefreshToken: session.RefreshToken,
-		ExpiresIn:    timeToOIDCExpiresIn(session.Expiration),
-		State:        state,
+	resp := &accessTokenResponse{
+		AccessTokenResponse: &oidc.AccessTokenResponse{
+			TokenType:    oidc.BearerToken,
+			RefreshToken: session.RefreshToken,
+			ExpiresIn:    timeToOIDCExpiresIn(session.Expiration),
+			State:        state,
+		},
+		AuthorizationDetails: session.AuthorizationDetails,
+	}
+	if session.DPoPJKT != "" {
+		resp.TokenType = dpop.TokenType
 	}
 
 	// If the session does not have a token ID, it is an implicit ID-Token only response.
@@ -202,6 +209,8 @@ func (s *Server) createJWT(ctx context.Context, client op.Client, session *comma
 	)
 	claims.Actor = actorDomainToClaims(session.Actor)
 	claims.Claims = userInfo.Claims
+	claims.Claims = confirmationClaims(claims.Claims, session.DPoPJKT, session.CertThumbprint)
+	claims.Claims = authorizationDetailsClaims(claims.Claims, session.AuthorizationDetails)
 
 	return crypto.Sign(claims, signer)
 }
@@ -30,6 +30,10 @@ func (s *Server) ClientCredentialsExchange(ctx context.Context, r *op.ClientRequ
 	if err != nil {
 		return nil, err
 	}
+	dpopJKT, err := s.verifyTokenRequestDPoP(ctx, r.Header, false)
+	if err != nil {
+		return nil, err
+	}
 
 	session, err := s.command.CreateOIDCSession(ctx,
 		client.userID,
@@ -48,6 +52,10 @@ func (s *Server) ClientCredentialsExchange(ctx context.Context, r *op.ClientRequ
 		false,
 		"",
 		domain.OIDCResponseTypeUnspecified,
+		dpopJKT,
+		"",
+		nil,
+		nil,
 	)
 	if err != nil {
 		return nil, err
@@ -26,12 +26,21 @@ func (s *Server) CodeExchange(ctx context.Context, r *op.ClientRequest[oidc.Acce
 	if !ok {
 		return nil, zerrors.ThrowInternal(nil, "OIDC-Ae2ph", "Error.Internal")
 	}
+	dpopJKT, err := s.verifyTokenRequestDPoP(ctx, r.Header, client.client.RequireDPoP)
+	if err != nil {
+		return nil, err
+	}
 
 	plainCode, err := s.decryptCode(ctx, r.Data.Code)
 	if err != nil {
 		return nil, zerrors.ThrowInvalidArgument(err, "OIDC-ahLi2", "Errors.User.Code.Invalid")
 	}
 
+	authorizationDetails, err := authorizationDetailsFromForm(r.Form, client)
+	if err != nil {
+		return nil, err
+	}
+
 	var (
 		session *command.OIDCSession
 	)
@@ -42,9 +51,12 @@ func (s *Server) CodeExchange(ctx context.Context, r *op.ClientRequest[oidc.Acce
 			codeExchangeComplianceChecker(client, r.Data),
 			slices.Contains(client.GrantTypes(), oidc.GrantTypeRefreshToken),
 			client.client.BackChannelLogoutURI,
+			dpopJKT,
+			clientCertificateThumbprint(ctx, client),
+			authorizationDetails,
 		)
 	} else {
-		session, err = s.codeExchangeV1(ctx, client, r.Data, r.Data.Code)
+		session, err = s.codeExchangeV1(ctx, client, r.Data, r.Data.Code, dpopJKT, authorizationDetails)
 	}
 	if err != nil {
 		return nil, err
@@ -53,7 +65,7 @@ func (s *Server) CodeExchange(ctx context.Context, r *op.ClientRequest[oidc.Acce
 }
 
 // codeExchangeV1 creates a v2 token from a v1 auth request.
-func (s *Server) codeExchangeV1(ctx context.Context, client *Client, req *oidc.AccessTokenRequest, code string) (session *command.OIDCSession, err error) {
+func (s *Server) codeExchangeV1(ctx context.Context, client *Client, req *oidc.AccessTokenRequest, code, dpopJKT string, authorizationDetails domain.AuthorizationDetails) (session *command.OIDCSession, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
@@ -89,6 +101,10 @@ func (s *Server) codeExchangeV1(ctx context.Context, client *Client, req *oidc.A
 		slices.Contains(scope, oidc.ScopeOfflineAccess),
 		authReq.SessionID,
 		authReq.oidc().ResponseType,
+		dpopJKT,
+		clientCertificateThumbprint(ctx, client),
+		authReq.oidc().AuthorizationDetails,
+		authorizationDetails,
 	)
 	if err != nil {
 		return nil, err
@@ -40,6 +40,10 @@ func (s *Server) JWTProfile(ctx context.Context, r *op.Request[oidc.JWTProfileGr
 	if err != nil {
 		return nil, err
 	}
+	dpopJKT, err := s.verifyTokenRequestDPoP(ctx, r.Header, false)
+	if err != nil {
+		return nil, err
+	}
 
 	session, err := s.command.CreateOIDCSession(ctx,
 		client.userID,
@@ -58,6 +62,10 @@ func (s *Server) JWTProfile(ctx context.Context, r *op.Request[oidc.JWTProfileGr
 		false,
 		"",
 		domain.OIDCResponseTypeUnspecified,
+		dpopJKT,
+		"",
+		nil,
+		nil,
 	)
 	if err != nil {
 		return nil, err
@@ -25,13 +25,21 @@ func (s *Server) RefreshToken(ctx context.Context, r *op.ClientRequest[oidc.Refr
 	if !ok {
 		return nil, zerrors.ThrowInternal(nil, "OIDC-ga0EP", "Error.Internal")
 	}
+	dpopJKT, err := s.verifyTokenRequestDPoP(ctx, r.Header, client.client.RequireDPoP)
+	if err != nil {
+		return nil, err
+	}
 
-	session, err := s.command.ExchangeOIDCSessionRefreshAndAccessToken(ctx, r.Data.RefreshToken, r.Data.Scopes, refreshTokenComplianceChecker())
+	authorizationDetails, err := authorizationDetailsFromForm(r.Form, client)
+	if err != nil {
+		return nil, err
+	}
+	session, err := s.command.ExchangeOIDCSessionRefreshAndAccessToken(ctx, r.Data.RefreshToken, r.Data.Scopes, refreshTokenComplianceChecker(), dpopJKT, clientCertificateThumbprint(ctx, client), authorizationDetails)
 	if err == nil {
 		return response(s.accessTokenResponseFromSession(ctx, client, session, "", client.client.ProjectID, client.client.ProjectRoleAssertion, client.client.AccessTokenRoleAssertion, client.client.IDTokenRoleAssertion, client.client.IDTokenUserinfoAssertion))
 	} else if errors.Is(err, zerrors.ThrowPreconditionFailed(nil, "OIDCS-JOI23", "Errors.OIDCSession.RefreshTokenInvalid")) {
 		// We try again for v1 tokens when we encountered specific parsing error
-		return s.refreshTokenV1(ctx, client, r)
+		return s.refreshTokenV1(ctx, client, r, dpopJKT, authorizationDetails)
 	}
 	return nil, err
 }
@@ -40,8 +48,13 @@ func (s *Server) RefreshToken(ctx context.Context, r *op.ClientRequest[oidc.Refr
 // When valid a v2 OIDC session is created and v2 tokens are returned.
 // This "upgrades" existing v1 sessions to v2 session without requiring users to re-login.
 //
+// v1 refresh tokens are not granted any authorization details, so requesting them is rejected.
+//
 // This function can be removed when we retire the v1 token repo.
-func (s *Server) refreshTokenV1(ctx context.Context, client *Client, r *op.ClientRequest[oidc.RefreshTokenRequest]) (_ *op.Response, err error) {
+func (s *Server) refreshTokenV1(ctx context.Context, client *Client, r *op.ClientRequest[oidc.RefreshTokenRequest], dpopJKT string, authorizationDetails domain.AuthorizationDetails) (_ *op.Response, err error) {
+	if len(authorizationDetails) > 0 {
+		return nil, errInvalidAuthorizationDetails().WithDescription("authorization_details are not granted to the refresh token")
+	}
 	refreshToken, err := s.repo.RefreshTokenByToken(ctx, r.Data.RefreshToken)
 	if err != nil {
 		return nil, err
@@ -70,6 +83,10 @@ func (s *Server) refreshTokenV1(ctx context.Context, client *Client, r *op.Clien
 		true,
 		"",
 		domain.OIDCResponseTypeUnspecified,
+		dpopJKT,
+		clientCertificateThumbprint(ctx, client),
+		nil, // v1 refresh tokens are not granted any authorization details
+		nil,
 	)
 	if err != nil {
 		return nil, err
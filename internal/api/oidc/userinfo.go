@@ -46,6 +46,12 @@ func (s *Server) UserInfo(ctx context.Context, r *op.Request[oidc.UserInfoReques
 	if err != nil {
 		return nil, op.NewStatusError(oidc.ErrAccessDenied().WithDescription("access token invalid").WithParent(err).WithReturnParentToClient(authz.GetFeatures(ctx).DebugOIDCParentError), http.StatusUnauthorized)
 	}
+	if err = checkAccessTokenDPoP(ctx, token, r.Data.AccessToken, r.Method, s.Endpoints().Userinfo.Absolute(op.IssuerFromContext(ctx))); err != nil {
+		return nil, op.NewStatusError(oidc.ErrAccessDenied().WithDescription("access token invalid").WithParent(err).WithReturnParentToClient(authz.GetFeatures(ctx).DebugOIDCParentError), http.StatusUnauthorized)
+	}
+	if err = checkAccessTokenCertificate(ctx, token); err != nil {
+		return nil, op.NewStatusError(oidc.ErrAccessDenied().WithDescription("access token invalid").WithParent(err).WithReturnParentToClient(authz.GetFeatures(ctx).DebugOIDCParentError), http.StatusUnauthorized)
+	}
 
 	var (
 		projectID string
@@ -197,6 +203,8 @@ func userInfoToOIDC(user *query.OIDCUserInfo, userInfoAssertion bool, scope []st
 			setUserInfoMetadata(user.Metadata, out)
 		case ScopeResourceOwner:
 			setUserInfoOrgClaims(user, out)
+		case ScopeGroups:
+			setUserInfoGroups(user.Groups, out)
 		default:
 			if claim, ok := strings.CutPrefix(s, domain.OrgDomainPrimaryScope); ok {
 				out.AppendClaims(domain.OrgDomainPrimaryClaim, claim)
@@ -264,6 +272,17 @@ func userInfoPhoneToOIDC(user *query.User) oidc.UserInfoPhone {
 	return oidc.UserInfoPhone{}
 }
 
+func setUserInfoGroups(groups []query.UserInfoGroup, out *oidc.UserInfo) {
+	if len(groups) == 0 {
+		return
+	}
+	names := make([]string, len(groups))
+	for i, group := range groups {
+		names[i] = group.Name
+	}
+	out.AppendClaims(ClaimGroups, names)
+}
+
 func setUserInfoMetadata(metadata []query.UserMetadata, out *oidc.UserInfo) {
 	if len(metadata) == 0 {
 		return
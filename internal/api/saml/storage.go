@@ -490,6 +490,7 @@ func (p *Storage) getGrants(ctx context.Context, userID, applicationID string) (
 			userIDQuery,
 			activeQuery,
 		},
+		IncludeGroupGrants: true,
 	}, true)
 }
 
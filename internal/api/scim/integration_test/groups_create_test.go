@@ -0,0 +1,177 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/scim/resources"
+	"github.com/zitadel/zitadel/internal/api/scim/schemas"
+	"github.com/zitadel/zitadel/internal/integration"
+	"github.com/zitadel/zitadel/internal/integration/scim"
+	"github.com/zitadel/zitadel/internal/test"
+)
+
+func TestCreateGroup(t *testing.T) {
+	member := createHumanUser(t, CTX, Instance.DefaultOrg.Id, 0)
+	tests := []struct {
+		name        string
+		body        []byte
+		ctx         context.Context
+		orgID       string
+		wantMembers []string
+		wantErr     bool
+		errorStatus int
+	}{
+		{
+			name: "without members",
+			body: groupJson(gofakeit.AppName()),
+		},
+		{
+			name:        "with members",
+			body:        groupJson(gofakeit.AppName(), member.UserId),
+			wantMembers: []string{member.UserId},
+		},
+		{
+			name:    "missing display name",
+			body:    groupJson(""),
+			wantErr: true,
+		},
+		{
+			name:    "unknown member",
+			body:    groupJson(gofakeit.AppName(), "unknown"),
+			wantErr: true,
+		},
+		{
+			name:        "not authenticated",
+			body:        groupJson(gofakeit.AppName()),
+			ctx:         context.Background(),
+			wantErr:     true,
+			errorStatus: http.StatusUnauthorized,
+		},
+		{
+			name:        "no permissions",
+			body:        groupJson(gofakeit.AppName()),
+			ctx:         Instance.WithAuthorization(CTX, integration.UserTypeNoPermission),
+			wantErr:     true,
+			errorStatus: http.StatusNotFound,
+		},
+		{
+			name:        "another org",
+			body:        groupJson(gofakeit.AppName()),
+			orgID:       SecondaryOrganization.OrganizationId,
+			wantErr:     true,
+			errorStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = CTX
+			}
+
+			orgID := tt.orgID
+			if orgID == "" {
+				orgID = Instance.DefaultOrg.Id
+			}
+
+			createdGroup, err := Instance.Client.SCIM.Groups.Create(ctx, orgID, tt.body)
+			if tt.wantErr {
+				statusCode := tt.errorStatus
+				if statusCode == 0 {
+					statusCode = http.StatusBadRequest
+				}
+				scim.RequireScimError(t, statusCode, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, createdGroup.ID)
+			assert.EqualValues(t, []schemas.ScimSchemaType{schemas.IdGroup}, createdGroup.Resource.Schemas)
+			assert.Equal(t, tt.wantMembers, groupMemberIDs(createdGroup))
+
+			retryDuration, tick := integration.WaitForAndTickWithMaxDuration(CTX, time.Minute)
+			require.EventuallyWithT(t, func(ttt *assert.CollectT) {
+				fetchedGroup, err := Instance.Client.SCIM.Groups.Get(CTX, orgID, createdGroup.ID)
+				require.NoError(ttt, err)
+				assert.Equal(ttt, createdGroup.DisplayName, fetchedGroup.DisplayName)
+				assert.ElementsMatch(ttt, tt.wantMembers, groupMemberIDs(fetchedGroup))
+			}, retryDuration, tick)
+		})
+	}
+}
+
+func TestCreateGroup_bulkMemberReference(t *testing.T) {
+	resp, err := Instance.Client.SCIM.Bulk(CTX, Instance.DefaultOrg.Id, test.Must(json.Marshal(&scim.BulkRequest{
+		Schemas: []schemas.ScimSchemaType{schemas.IdBulkRequest},
+		Operations: []*scim.BulkRequestOperation{
+			{
+				Method: http.MethodPost,
+				BulkID: "user",
+				Path:   "/Users",
+				Data:   minimalUserJson,
+			},
+			{
+				Method: http.MethodPost,
+				BulkID: "group",
+				Path:   "/Groups",
+				Data:   groupJson(gofakeit.AppName(), "bulkId:user"),
+			},
+		},
+	})))
+	require.NoError(t, err)
+	require.Len(t, resp.Operations, 2)
+	for _, op := range resp.Operations {
+		require.Nil(t, op.Response, "bulk operation %s failed", op.BulkID)
+	}
+
+	userID := path.Base(resp.Operations[0].Location)
+	groupID := path.Base(resp.Operations[1].Location)
+	defer func() {
+		_ = Instance.Client.SCIM.Groups.Delete(CTX, Instance.DefaultOrg.Id, groupID)
+		_ = Instance.Client.SCIM.Users.Delete(CTX, Instance.DefaultOrg.Id, userID)
+	}()
+
+	retryDuration, tick := integration.WaitForAndTickWithMaxDuration(CTX, time.Minute)
+	require.EventuallyWithT(t, func(ttt *assert.CollectT) {
+		fetchedGroup, err := Instance.Client.SCIM.Groups.Get(CTX, Instance.DefaultOrg.Id, groupID)
+		require.NoError(ttt, err)
+		assert.Equal(ttt, []string{userID}, groupMemberIDs(fetchedGroup))
+	}, retryDuration, tick)
+}
+
+func groupJson(displayName string, memberIDs ...string) []byte {
+	members := make([]map[string]string, len(memberIDs))
+	for i, memberID := range memberIDs {
+		members[i] = map[string]string{"value": memberID}
+	}
+	return test.Must(json.Marshal(map[string]any{
+		"schemas":     []schemas.ScimSchemaType{schemas.IdGroup},
+		"displayName": displayName,
+		"members":     members,
+	}))
+}
+
+func createGroup(t *testing.T, memberIDs ...string) *resources.ScimGroup {
+	group, err := Instance.Client.SCIM.Groups.Create(CTX, Instance.DefaultOrg.Id, groupJson(gofakeit.AppName(), memberIDs...))
+	require.NoError(t, err)
+	return group
+}
+
+func groupMemberIDs(group *resources.ScimGroup) []string {
+	var ids []string
+	for _, member := range group.Members {
+		ids = append(ids, member.Value)
+	}
+	return ids
+}
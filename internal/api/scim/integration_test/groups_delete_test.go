@@ -0,0 +1,78 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/integration"
+	"github.com/zitadel/zitadel/internal/integration/scim"
+)
+
+func TestDeleteGroup_errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		orgID       string
+		errorStatus int
+	}{
+		{
+			name:        "not authenticated",
+			ctx:         context.Background(),
+			errorStatus: http.StatusUnauthorized,
+		},
+		{
+			name:        "no permissions",
+			ctx:         Instance.WithAuthorization(CTX, integration.UserTypeNoPermission),
+			errorStatus: http.StatusNotFound,
+		},
+		{
+			name:        "unknown group id",
+			errorStatus: http.StatusNotFound,
+		},
+		{
+			name:        "another org",
+			orgID:       SecondaryOrganization.OrganizationId,
+			errorStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = CTX
+			}
+
+			orgID := tt.orgID
+			if orgID == "" {
+				orgID = Instance.DefaultOrg.Id
+			}
+			err := Instance.Client.SCIM.Groups.Delete(ctx, orgID, "1")
+			scim.RequireScimError(t, tt.errorStatus, err)
+		})
+	}
+}
+
+func TestDeleteGroup_ensureReallyDeleted(t *testing.T) {
+	member := createHumanUser(t, CTX, Instance.DefaultOrg.Id, 0)
+	createdGroup := createGroup(t, member.UserId)
+
+	err := Instance.Client.SCIM.Groups.Delete(CTX, Instance.DefaultOrg.Id, createdGroup.ID)
+	require.NoError(t, err)
+
+	// ensure it is really deleted => try to delete again => should 404
+	err = Instance.Client.SCIM.Groups.Delete(CTX, Instance.DefaultOrg.Id, createdGroup.ID)
+	scim.RequireScimError(t, http.StatusNotFound, err)
+
+	retryDuration, tick := integration.WaitForAndTickWithMaxDuration(CTX, time.Minute)
+	require.EventuallyWithT(t, func(tt *assert.CollectT) {
+		_, err = Instance.Client.SCIM.Groups.Get(CTX, Instance.DefaultOrg.Id, createdGroup.ID)
+		scim.RequireScimError(tt, http.StatusNotFound, err)
+	}, retryDuration, tick)
+}
@@ -0,0 +1,119 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/integration"
+	"github.com/zitadel/zitadel/internal/integration/scim"
+)
+
+func TestReplaceGroup(t *testing.T) {
+	oldMember := createHumanUser(t, CTX, Instance.DefaultOrg.Id, 0)
+	newMember := createHumanUser(t, CTX, Instance.DefaultOrg.Id, 1)
+	tests := []struct {
+		name        string
+		displayName string
+		memberIDs   []string
+		ctx         context.Context
+		orgID       string
+		groupID     string
+		wantErr     bool
+		errorStatus int
+	}{
+		{
+			name:        "name and members",
+			displayName: gofakeit.AppName(),
+			memberIDs:   []string{newMember.UserId},
+		},
+		{
+			name:        "remove all members",
+			displayName: gofakeit.AppName(),
+		},
+		{
+			name:        "unknown member",
+			displayName: gofakeit.AppName(),
+			memberIDs:   []string{newMember.UserId, "unknown"},
+			wantErr:     true,
+		},
+		{
+			name:        "unknown group",
+			displayName: gofakeit.AppName(),
+			groupID:     "unknown",
+			wantErr:     true,
+			errorStatus: http.StatusNotFound,
+		},
+		{
+			name:        "no permissions",
+			displayName: gofakeit.AppName(),
+			ctx:         Instance.WithAuthorization(CTX, integration.UserTypeNoPermission),
+			wantErr:     true,
+			errorStatus: http.StatusNotFound,
+		},
+		{
+			name:        "another org",
+			displayName: gofakeit.AppName(),
+			orgID:       SecondaryOrganization.OrganizationId,
+			wantErr:     true,
+			errorStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			createdGroup := createGroup(t, oldMember.UserId)
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = CTX
+			}
+
+			orgID := tt.orgID
+			if orgID == "" {
+				orgID = Instance.DefaultOrg.Id
+			}
+
+			groupID := tt.groupID
+			if groupID == "" {
+				groupID = createdGroup.ID
+			}
+
+			replacedGroup, err := Instance.Client.SCIM.Groups.Replace(ctx, orgID, groupID, groupJson(tt.displayName, tt.memberIDs...))
+			if tt.wantErr {
+				statusCode := tt.errorStatus
+				if statusCode == 0 {
+					statusCode = http.StatusBadRequest
+				}
+				scim.RequireScimError(t, statusCode, err)
+
+				// the replace is all or nothing, neither the name nor the members changed
+				retryDuration, tick := integration.WaitForAndTickWithMaxDuration(CTX, time.Minute)
+				require.EventuallyWithT(t, func(ttt *assert.CollectT) {
+					fetchedGroup, err := Instance.Client.SCIM.Groups.Get(CTX, Instance.DefaultOrg.Id, createdGroup.ID)
+					require.NoError(ttt, err)
+					assert.Equal(ttt, createdGroup.DisplayName, fetchedGroup.DisplayName)
+					assert.Equal(ttt, []string{oldMember.UserId}, groupMemberIDs(fetchedGroup))
+				}, retryDuration, tick)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.displayName, replacedGroup.DisplayName)
+			assert.Equal(t, tt.memberIDs, groupMemberIDs(replacedGroup))
+
+			retryDuration, tick := integration.WaitForAndTickWithMaxDuration(CTX, time.Minute)
+			require.EventuallyWithT(t, func(ttt *assert.CollectT) {
+				fetchedGroup, err := Instance.Client.SCIM.Groups.Get(CTX, Instance.DefaultOrg.Id, createdGroup.ID)
+				require.NoError(ttt, err)
+				assert.Equal(ttt, tt.displayName, fetchedGroup.DisplayName)
+				assert.ElementsMatch(ttt, tt.memberIDs, groupMemberIDs(fetchedGroup))
+			}, retryDuration, tick)
+		})
+	}
+}
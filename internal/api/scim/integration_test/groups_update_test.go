@@ -0,0 +1,140 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/integration"
+	"github.com/zitadel/zitadel/internal/integration/scim"
+)
+
+func TestUpdateGroup(t *testing.T) {
+	oldMember := createHumanUser(t, CTX, Instance.DefaultOrg.Id, 0)
+	newMember := createHumanUser(t, CTX, Instance.DefaultOrg.Id, 1)
+	tests := []struct {
+		name        string
+		body        []byte
+		ctx         context.Context
+		orgID       string
+		groupID     string
+		wantMembers []string
+		wantErr     bool
+		errorStatus int
+	}{
+		{
+			name:        "add member",
+			body:        groupMembersPatchBody("add", "members", newMember.UserId),
+			wantMembers: []string{oldMember.UserId, newMember.UserId},
+		},
+		{
+			name:        "remove member by value",
+			body:        groupMembersPatchBody("remove", "members", oldMember.UserId),
+			wantMembers: nil,
+		},
+		{
+			name:        "remove member by filter",
+			body:        []byte(fmt.Sprintf(`{"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"], "Operations": [{"op": "remove", "path": "members[value eq \"%s\"]"}]}`, oldMember.UserId)),
+			wantMembers: nil,
+		},
+		{
+			name:        "replace members",
+			body:        groupMembersPatchBody("replace", "members", newMember.UserId),
+			wantMembers: []string{newMember.UserId},
+		},
+		{
+			name:        "rename",
+			body:        simpleReplacePatchBody("displayName", `"renamed group"`),
+			wantMembers: []string{oldMember.UserId},
+		},
+		{
+			name:    "add unknown member",
+			body:    groupMembersPatchBody("add", "members", "unknown"),
+			wantErr: true,
+		},
+		{
+			name:        "unknown group",
+			body:        groupMembersPatchBody("add", "members", newMember.UserId),
+			groupID:     "unknown",
+			wantErr:     true,
+			errorStatus: http.StatusNotFound,
+		},
+		{
+			name:        "no permissions",
+			body:        groupMembersPatchBody("add", "members", newMember.UserId),
+			ctx:         Instance.WithAuthorization(CTX, integration.UserTypeNoPermission),
+			wantErr:     true,
+			errorStatus: http.StatusNotFound,
+		},
+		{
+			name:        "another org",
+			body:        groupMembersPatchBody("add", "members", newMember.UserId),
+			orgID:       SecondaryOrganization.OrganizationId,
+			wantErr:     true,
+			errorStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			createdGroup := createGroup(t, oldMember.UserId)
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = CTX
+			}
+
+			orgID := tt.orgID
+			if orgID == "" {
+				orgID = Instance.DefaultOrg.Id
+			}
+
+			groupID := tt.groupID
+			if groupID == "" {
+				groupID = createdGroup.ID
+			}
+
+			err := Instance.Client.SCIM.Groups.Update(ctx, orgID, groupID, tt.body)
+			if tt.wantErr {
+				statusCode := tt.errorStatus
+				if statusCode == 0 {
+					statusCode = http.StatusBadRequest
+				}
+				scim.RequireScimError(t, statusCode, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			retryDuration, tick := integration.WaitForAndTickWithMaxDuration(CTX, time.Minute)
+			require.EventuallyWithT(t, func(ttt *assert.CollectT) {
+				fetchedGroup, err := Instance.Client.SCIM.Groups.Get(CTX, Instance.DefaultOrg.Id, createdGroup.ID)
+				require.NoError(ttt, err)
+				assert.ElementsMatch(ttt, tt.wantMembers, groupMemberIDs(fetchedGroup))
+			}, retryDuration, tick)
+		})
+	}
+}
+
+func groupMembersPatchBody(op, path, memberID string) []byte {
+	return []byte(fmt.Sprintf(
+		`{
+		  "schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		  "Operations": [
+			{
+			  "op": "%s",
+			  "path": "%s",
+			  "value": [{"value": "%s"}]
+			}
+		  ]
+		}`,
+		op,
+		path,
+		memberID,
+	))
+}
@@ -0,0 +1,318 @@
+package resources
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	"github.com/muhlemmer/gu"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/scim/metadata"
+	"github.com/zitadel/zitadel/internal/api/scim/resources/filter"
+	"github.com/zitadel/zitadel/internal/api/scim/resources/patch"
+	scim_schemas "github.com/zitadel/zitadel/internal/api/scim/schemas"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const groupMemberTypeUser = "User"
+
+type GroupsHandler struct {
+	command         *command.Commands
+	query           *query.Queries
+	filterEvaluator *filter.Evaluator
+	schema          *scim_schemas.ResourceSchema
+}
+
+type ScimGroup struct {
+	*scim_schemas.Resource `scim:"ignoreInSchema"`
+	ID                     string             `json:"id" scim:"ignoreInSchema"`
+	DisplayName            string             `json:"displayName,omitempty" scim:"required,unique"`
+	Members                []*ScimGroupMember `json:"members,omitempty"`
+}
+
+type ScimGroupMember struct {
+	Value   string `json:"value" scim:"required"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+	Type    string `json:"type,omitempty"`
+}
+
+func NewGroupsHandler(
+	command *command.Commands,
+	query *query.Queries) ResourceHandler[*ScimGroup] {
+	return &GroupsHandler{
+		command,
+		query,
+		filter.NewEvaluator(scim_schemas.IdGroup),
+		scim_schemas.BuildSchema(scim_schemas.SchemaBuilderArgs{
+			ID:           scim_schemas.IdGroup,
+			Name:         scim_schemas.GroupResourceType,
+			EndpointName: scim_schemas.GroupsResourceType,
+			Description:  "Group",
+			Resource:     new(ScimGroup),
+		}),
+	}
+}
+
+func (g *ScimGroup) GetResource() *scim_schemas.Resource {
+	return g.Resource
+}
+
+func (g *ScimGroup) GetSchemas() []scim_schemas.ScimSchemaType {
+	if g.Resource == nil {
+		return nil
+	}
+
+	return g.Resource.Schemas
+}
+
+func (h *GroupsHandler) Schema() *scim_schemas.ResourceSchema {
+	return h.schema
+}
+
+func (h *GroupsHandler) NewResource() *ScimGroup {
+	return new(ScimGroup)
+}
+
+func (h *GroupsHandler) Create(ctx context.Context, group *ScimGroup) (*ScimGroup, error) {
+	if err := group.resolveMemberBulkIDs(ctx); err != nil {
+		return nil, err
+	}
+
+	addGroup := &command.AddGroup{
+		ObjectRoot: models.ObjectRoot{
+			ResourceOwner: authz.GetCtxData(ctx).OrgID,
+		},
+		Name: group.DisplayName,
+		// the members are added together with the group,
+		// so no empty group is left behind if a member is invalid
+		MemberIDs: group.memberIDs(),
+	}
+
+	details, err := h.command.AddGroup(ctx, addGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	details.ID = addGroup.AggregateID
+	h.mapDetailsToScimGroup(ctx, group, details)
+	return group, nil
+}
+
+func (h *GroupsHandler) Replace(ctx context.Context, id string, group *ScimGroup) (*ScimGroup, error) {
+	details, err := h.applyChanges(ctx, id, group)
+	if err != nil {
+		return nil, err
+	}
+
+	h.mapDetailsToScimGroup(ctx, group, details)
+	return group, nil
+}
+
+func (h *GroupsHandler) Update(ctx context.Context, id string, operations patch.OperationCollection) error {
+	orgID := authz.GetCtxData(ctx).OrgID
+	groupWM, err := h.command.GroupWriteModel(ctx, id, orgID)
+	if err != nil {
+		return err
+	}
+
+	group := h.mapWriteModelToScimGroup(ctx, groupWM)
+	if err = h.applyPatches(group, operations); err != nil {
+		return err
+	}
+
+	_, err = h.applyChanges(ctx, id, group)
+	return err
+}
+
+func (h *GroupsHandler) Delete(ctx context.Context, id string) error {
+	orgID := authz.GetCtxData(ctx).OrgID
+
+	// the remove command succeeds if the group does not exist,
+	// scim requires a not found error in this case.
+	if _, err := h.command.GroupWriteModel(ctx, id, orgID); err != nil {
+		return err
+	}
+
+	_, err := h.command.RemoveGroup(ctx, id, orgID)
+	return err
+}
+
+func (h *GroupsHandler) Get(ctx context.Context, id string) (*ScimGroup, error) {
+	group, err := h.query.GetGroupByID(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if group.ResourceOwner != authz.GetCtxData(ctx).OrgID {
+		return nil, zerrors.ThrowNotFound(nil, "SCIM-GRP01", "Errors.Group.NotFound")
+	}
+
+	members, err := h.query.GroupMembersByGroupIDs(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+
+	return h.mapToScimGroup(ctx, group, members.Members), nil
+}
+
+func (h *GroupsHandler) List(ctx context.Context, request *ListRequest) (*ListResponse[*ScimGroup], error) {
+	q, err := h.buildListQuery(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := h.query.SearchGroups(ctx, q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Count == 0 || len(groups.Groups) == 0 {
+		return NewListResponse(groups.SearchResponse.Count, q.SearchRequest, make([]*ScimGroup, 0)), nil
+	}
+
+	members, err := h.query.GroupMembersByGroupIDs(ctx, groupsToIDs(groups.Groups))
+	if err != nil {
+		return nil, err
+	}
+
+	scimGroups := h.mapToScimGroups(ctx, groups.Groups, members.Members)
+	return NewListResponse(groups.SearchResponse.Count, q.SearchRequest, scimGroups), nil
+}
+
+// applyChanges updates the display name and sets the members of the group
+// to the state of the provided scim group in a single push.
+func (h *GroupsHandler) applyChanges(ctx context.Context, id string, group *ScimGroup) (*domain.ObjectDetails, error) {
+	if err := group.resolveMemberBulkIDs(ctx); err != nil {
+		return nil, err
+	}
+
+	// we rely on the change detection of the write model to only push events if the group really changed
+	memberIDs := group.memberIDs()
+	details, err := h.command.ChangeGroup(ctx, &command.ChangeGroup{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID:   id,
+			ResourceOwner: authz.GetCtxData(ctx).OrgID,
+		},
+		Name:      &group.DisplayName,
+		MemberIDs: &memberIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	details.ID = id
+	return details, nil
+}
+
+// resolveMemberBulkIDs replaces the bulkId references of the members
+// with the ids of the users created by previous operations of the same bulk request.
+func (g *ScimGroup) resolveMemberBulkIDs(ctx context.Context) (err error) {
+	for _, member := range g.Members {
+		if member == nil {
+			continue
+		}
+
+		if member.Value, err = metadata.ResolveScimBulkIDIfNeeded(ctx, member.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *ScimGroup) memberIDs() []string {
+	ids := make([]string, 0, len(g.Members))
+	for _, member := range g.Members {
+		if member == nil || member.Value == "" || slices.Contains(ids, member.Value) {
+			continue
+		}
+
+		ids = append(ids, member.Value)
+	}
+	return ids
+}
+
+func (h *GroupsHandler) mapDetailsToScimGroup(ctx context.Context, group *ScimGroup, details *domain.ObjectDetails) {
+	group.ID = details.ID
+	group.Resource = buildResource(ctx, h, details)
+	group.Members = h.mapToScimGroupMembers(ctx, group.memberIDs())
+}
+
+// mapWriteModelToScimGroup maps the current state of the group, which is used as base to apply patches.
+func (h *GroupsHandler) mapWriteModelToScimGroup(ctx context.Context, group *command.GroupWriteModel) *ScimGroup {
+	return &ScimGroup{
+		Resource: &scim_schemas.Resource{
+			ID:      group.AggregateID,
+			Schemas: []scim_schemas.ScimSchemaType{scim_schemas.IdGroup},
+		},
+		ID:          group.AggregateID,
+		DisplayName: group.Name,
+		Members:     h.mapToScimGroupMembers(ctx, group.MemberIDs),
+	}
+}
+
+func (h *GroupsHandler) mapToScimGroups(ctx context.Context, groups []*query.Group, members []*query.GroupMember) []*ScimGroup {
+	membersByGroupID := make(map[string][]*query.GroupMember, len(groups))
+	for _, member := range members {
+		membersByGroupID[member.GroupID] = append(membersByGroupID[member.GroupID], member)
+	}
+
+	result := make([]*ScimGroup, len(groups))
+	for i, group := range groups {
+		result[i] = h.mapToScimGroup(ctx, group, membersByGroupID[group.ID])
+	}
+	return result
+}
+
+func (h *GroupsHandler) mapToScimGroup(ctx context.Context, group *query.Group, members []*query.GroupMember) *ScimGroup {
+	memberIDs := make([]string, len(members))
+	for i, member := range members {
+		memberIDs[i] = member.UserID
+	}
+
+	return &ScimGroup{
+		Resource: &scim_schemas.Resource{
+			ID:      group.ID,
+			Schemas: []scim_schemas.ScimSchemaType{scim_schemas.IdGroup},
+			Meta: &scim_schemas.ResourceMeta{
+				ResourceType: scim_schemas.GroupResourceType,
+				Created:      gu.Ptr(group.CreationDate.UTC()),
+				LastModified: gu.Ptr(group.ChangeDate.UTC()),
+				Version:      strconv.FormatUint(group.Sequence, 10),
+				Location:     scim_schemas.BuildLocationForResource(ctx, h.schema.PluralName, group.ID),
+			},
+		},
+		ID:          group.ID,
+		DisplayName: group.Name,
+		Members:     h.mapToScimGroupMembers(ctx, memberIDs),
+	}
+}
+
+func (h *GroupsHandler) mapToScimGroupMembers(ctx context.Context, userIDs []string) []*ScimGroupMember {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	members := make([]*ScimGroupMember, len(userIDs))
+	for i, userID := range userIDs {
+		members[i] = &ScimGroupMember{
+			Value: userID,
+			Ref:   scim_schemas.BuildLocationForResource(ctx, scim_schemas.UsersResourceType, userID),
+			Type:  groupMemberTypeUser,
+		}
+	}
+	return members
+}
+
+func groupsToIDs(groups []*query.Group) []string {
+	ids := make([]string, len(groups))
+	for i, group := range groups {
+		ids[i] = group.ID
+	}
+	return ids
+}
@@ -0,0 +1,96 @@
+package resources
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/api/scim/resources/filter"
+	"github.com/zitadel/zitadel/internal/api/scim/resources/patch"
+	"github.com/zitadel/zitadel/internal/api/scim/serrors"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const groupMembersAttributeName = "members"
+
+type groupPatcher struct {
+	handler *GroupsHandler
+}
+
+func (h *GroupsHandler) applyPatches(group *ScimGroup, operations patch.OperationCollection) error {
+	patcher := &groupPatcher{
+		handler: h,
+	}
+
+	genericOperations := make(patch.OperationCollection, 0, len(operations))
+	for _, op := range operations {
+		handled, err := applyMemberValueRemovePatch(group, op)
+		if err != nil {
+			return err
+		}
+
+		if !handled {
+			genericOperations = append(genericOperations, op)
+		}
+	}
+
+	// all changes are detected by comparing the patched group with the current state,
+	// therefore the patcher does not need to track the modified attributes.
+	return genericOperations.Apply(patcher, group)
+}
+
+// applyMemberValueRemovePatch handles remove operations on the members attribute which provide the members to remove as value
+// (e.g. { "op": "remove", "path": "members", "value": [{ "value": "123" }] }).
+// According to RFC7644 the value should be ignored and all members would be removed,
+// but several widely used scim clients (e.g. Microsoft Entra ID) remove members this way.
+func applyMemberValueRemovePatch(group *ScimGroup, op *patch.Operation) (bool, error) {
+	if !isMemberValueRemoveOperation(op) {
+		return false, nil
+	}
+
+	value := op.Value
+	if !strings.HasPrefix(strings.TrimSpace(string(value)), "[") {
+		value = append(append([]byte("["), value...), ']')
+	}
+
+	var membersToRemove []*ScimGroupMember
+	if err := json.Unmarshal(value, &membersToRemove); err != nil {
+		return false, serrors.ThrowInvalidValue(zerrors.ThrowInvalidArgument(err, "SCIM-GRPp1", "Invalid members value for remove operation"))
+	}
+
+	group.Members = slices.DeleteFunc(group.Members, func(member *ScimGroupMember) bool {
+		return slices.ContainsFunc(membersToRemove, func(memberToRemove *ScimGroupMember) bool {
+			return memberToRemove != nil && memberToRemove.Value == member.Value
+		})
+	})
+	return true, nil
+}
+
+func isMemberValueRemoveOperation(op *patch.Operation) bool {
+	if !strings.EqualFold(string(op.Operation), string(patch.OperationTypeRemove)) || op.Path.IsZero() || op.Path.AttrPath == nil {
+		return false
+	}
+
+	if op.Path.AttrPath.SubAttr != nil || !strings.EqualFold(op.Path.AttrPath.AttrName, groupMembersAttributeName) {
+		return false
+	}
+
+	value := strings.TrimSpace(string(op.Value))
+	return value != "" && value != "null"
+}
+
+func (p *groupPatcher) FilterEvaluator() *filter.Evaluator {
+	return p.handler.filterEvaluator
+}
+
+func (p *groupPatcher) Added([]string) error {
+	return nil
+}
+
+func (p *groupPatcher) Replaced([]string) error {
+	return nil
+}
+
+func (p *groupPatcher) Removed([]string) error {
+	return nil
+}
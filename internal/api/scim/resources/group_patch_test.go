@@ -0,0 +1,144 @@
+package resources
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/scim/resources/filter"
+	"github.com/zitadel/zitadel/internal/api/scim/resources/patch"
+	"github.com/zitadel/zitadel/internal/api/scim/schemas"
+	"github.com/zitadel/zitadel/internal/test"
+)
+
+func TestGroupsHandler_applyPatches(t *testing.T) {
+	tests := []struct {
+		name            string
+		op              *patch.Operation
+		wantDisplayName string
+		wantMemberIDs   []string
+		wantErr         bool
+	}{
+		{
+			name: "replace display name",
+			op: &patch.Operation{
+				Operation: patch.OperationTypeReplace,
+				Path:      test.Must(filter.ParsePath("displayName")),
+				Value:     json.RawMessage(`"developers"`),
+			},
+			wantDisplayName: "developers",
+			wantMemberIDs:   []string{"user1", "user2"},
+		},
+		{
+			name: "replace without path",
+			op: &patch.Operation{
+				Operation: patch.OperationTypeReplace,
+				Value:     json.RawMessage(`{ "displayName": "developers" }`),
+			},
+			wantDisplayName: "developers",
+			wantMemberIDs:   []string{"user1", "user2"},
+		},
+		{
+			name: "add members",
+			op: &patch.Operation{
+				Operation: patch.OperationTypeAdd,
+				Path:      test.Must(filter.ParsePath("members")),
+				Value:     json.RawMessage(`[{ "value": "user2" }, { "value": "user3" }]`),
+			},
+			wantDisplayName: "group",
+			wantMemberIDs:   []string{"user1", "user2", "user3"},
+		},
+		{
+			name: "replace members",
+			op: &patch.Operation{
+				Operation: patch.OperationTypeReplace,
+				Path:      test.Must(filter.ParsePath("members")),
+				Value:     json.RawMessage(`[{ "value": "user3" }]`),
+			},
+			wantDisplayName: "group",
+			wantMemberIDs:   []string{"user3"},
+		},
+		{
+			name: "remove member by filter",
+			op: &patch.Operation{
+				Operation: patch.OperationTypeRemove,
+				Path:      test.Must(filter.ParsePath(`members[value eq "user1"]`)),
+			},
+			wantDisplayName: "group",
+			wantMemberIDs:   []string{"user2"},
+		},
+		{
+			name: "remove member by value",
+			op: &patch.Operation{
+				Operation: patch.OperationTypeRemove,
+				Path:      test.Must(filter.ParsePath("members")),
+				Value:     json.RawMessage(`[{ "value": "user2" }]`),
+			},
+			wantDisplayName: "group",
+			wantMemberIDs:   []string{"user1"},
+		},
+		{
+			name: "remove member by single value",
+			op: &patch.Operation{
+				Operation: patch.OperationTypeRemove,
+				Path:      test.Must(filter.ParsePath("members")),
+				Value:     json.RawMessage(`{ "value": "user2" }`),
+			},
+			wantDisplayName: "group",
+			wantMemberIDs:   []string{"user1"},
+		},
+		{
+			name: "remove all members",
+			op: &patch.Operation{
+				Operation: patch.OperationTypeRemove,
+				Path:      test.Must(filter.ParsePath("members")),
+			},
+			wantDisplayName: "group",
+			wantMemberIDs:   []string{},
+		},
+		{
+			name: "remove member invalid value",
+			op: &patch.Operation{
+				Operation: patch.OperationTypeRemove,
+				Path:      test.Must(filter.ParsePath("members")),
+				Value:     json.RawMessage(`"user2"`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "add unknown path",
+			op: &patch.Operation{
+				Operation: patch.OperationTypeAdd,
+				Path:      test.Must(filter.ParsePath("fooBar")),
+				Value:     json.RawMessage(`"foo"`),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &GroupsHandler{
+				filterEvaluator: filter.NewEvaluator(schemas.IdGroup),
+			}
+			group := &ScimGroup{
+				DisplayName: "group",
+				Members: []*ScimGroupMember{
+					{Value: "user1"},
+					{Value: "user2"},
+				},
+			}
+
+			err := handler.applyPatches(group, patch.OperationCollection{tt.op})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDisplayName, group.DisplayName)
+			assert.Equal(t, tt.wantMemberIDs, group.memberIDs())
+		})
+	}
+}
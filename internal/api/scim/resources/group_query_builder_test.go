@@ -0,0 +1,57 @@
+package resources
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/muhlemmer/gu"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/scim/resources/filter"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/test"
+)
+
+func Test_buildGroupMemberQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   *filter.CompValue
+		op      *filter.CompareOp
+		want    query.SearchQuery
+		wantErr bool
+	}{
+		{
+			name:  "equals",
+			value: &filter.CompValue{StringValue: gu.Ptr("user1")},
+			op:    &filter.CompareOp{Equal: true},
+			want:  test.Must(query.NewGroupMemberSearchQuery("user1")),
+		},
+		{
+			name:    "unsupported operator",
+			value:   &filter.CompValue{StringValue: gu.Ptr("user1")},
+			op:      &filter.CompareOp{NotEqual: true},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported comparison value",
+			value:   &filter.CompValue{Int: gu.Ptr(10)},
+			op:      &filter.CompareOp{Equal: true},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildGroupMemberQuery(context.Background(), tt.value, tt.op)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("buildGroupMemberQuery() got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
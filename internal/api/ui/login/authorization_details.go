@@ -0,0 +1,59 @@
+package login
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+type authorizationDetailData struct {
+	Type   string
+	Fields []authorizationDetailField
+}
+
+type authorizationDetailField struct {
+	Name  string
+	Value string
+}
+
+// getAuthorizationDetailsData returns the authorization_details (RFC 9396) requested by the client,
+// so the user sees for what exactly (e.g. an amount or an account) consent is given.
+func getAuthorizationDetailsData(authReq *domain.AuthRequest) []authorizationDetailData {
+	if authReq == nil {
+		return nil
+	}
+	oidcRequest, ok := authReq.Request.(*domain.AuthRequestOIDC)
+	if !ok || len(oidcRequest.AuthorizationDetails) == 0 {
+		return nil
+	}
+	details := make([]authorizationDetailData, len(oidcRequest.AuthorizationDetails))
+	for i, detail := range oidcRequest.AuthorizationDetails {
+		details[i].Type = detail.Type()
+		for name, value := range detail {
+			if name == "type" {
+				continue
+			}
+			details[i].Fields = append(details[i].Fields, authorizationDetailField{
+				Name:  name,
+				Value: authorizationDetailValue(value),
+			})
+		}
+		slices.SortFunc(details[i].Fields, func(a, b authorizationDetailField) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+	}
+	return details
+}
+
+func authorizationDetailValue(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
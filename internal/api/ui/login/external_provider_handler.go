@@ -25,15 +25,21 @@ import (
 	"github.com/zitadel/zitadel/internal/domain/federatedlogout"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/mapping"
+	"github.com/zitadel/zitadel/internal/idp/providers/adfs"
 	"github.com/zitadel/zitadel/internal/idp/providers/apple"
 	"github.com/zitadel/zitadel/internal/idp/providers/azuread"
+	"github.com/zitadel/zitadel/internal/idp/providers/bitbucket"
+	"github.com/zitadel/zitadel/internal/idp/providers/discord"
 	"github.com/zitadel/zitadel/internal/idp/providers/github"
 	"github.com/zitadel/zitadel/internal/idp/providers/gitlab"
 	"github.com/zitadel/zitadel/internal/idp/providers/google"
 	"github.com/zitadel/zitadel/internal/idp/providers/jwt"
+	"github.com/zitadel/zitadel/internal/idp/providers/keycloak"
 	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
 	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
 	openid "github.com/zitadel/zitadel/internal/idp/providers/oidc"
+	"github.com/zitadel/zitadel/internal/idp/providers/okta"
 	"github.com/zitadel/zitadel/internal/idp/providers/saml"
 	"github.com/zitadel/zitadel/internal/idp/providers/saml/requesttracker"
 	"github.com/zitadel/zitadel/internal/query"
@@ -170,6 +176,16 @@ func (l *Login) handleIDP(w http.ResponseWriter, r *http.Request, authReq *domai
 		provider, err = l.gitlabProvider(r.Context(), identityProvider)
 	case domain.IDPTypeGitLabSelfHosted:
 		provider, err = l.gitlabSelfHostedProvider(r.Context(), identityProvider)
+	case domain.IDPTypeOkta:
+		provider, err = l.oktaProvider(r.Context(), identityProvider)
+	case domain.IDPTypeKeycloak:
+		provider, err = l.keycloakProvider(r.Context(), identityProvider)
+	case domain.IDPTypeADFS:
+		provider, err = l.adfsProvider(r.Context(), identityProvider)
+	case domain.IDPTypeBitbucket:
+		provider, err = l.bitbucketProvider(r.Context(), identityProvider)
+	case domain.IDPTypeDiscord:
+		provider, err = l.discordProvider(r.Context(), identityProvider)
 	case domain.IDPTypeGoogle:
 		provider, err = l.googleProvider(r.Context(), identityProvider)
 	case domain.IDPTypeApple:
@@ -325,6 +341,41 @@ func (l *Login) handleExternalLoginCallback(w http.ResponseWriter, r *http.Reque
 			return
 		}
 		session = openid.NewSession(provider.Provider, data.Code, authReq.SelectedIDPConfigArgs)
+	case domain.IDPTypeOkta:
+		provider, err := l.oktaProvider(r.Context(), identityProvider)
+		if err != nil {
+			l.externalAuthCallbackFailed(w, r, authReq, nil, nil, err)
+			return
+		}
+		session = openid.NewSession(provider.Provider, data.Code, authReq.SelectedIDPConfigArgs)
+	case domain.IDPTypeKeycloak:
+		provider, err := l.keycloakProvider(r.Context(), identityProvider)
+		if err != nil {
+			l.externalAuthCallbackFailed(w, r, authReq, nil, nil, err)
+			return
+		}
+		session = openid.NewSession(provider.Provider, data.Code, authReq.SelectedIDPConfigArgs)
+	case domain.IDPTypeADFS:
+		provider, err := l.adfsProvider(r.Context(), identityProvider)
+		if err != nil {
+			l.externalAuthCallbackFailed(w, r, authReq, nil, nil, err)
+			return
+		}
+		session = openid.NewSession(provider.Provider, data.Code, authReq.SelectedIDPConfigArgs)
+	case domain.IDPTypeBitbucket:
+		provider, err := l.bitbucketProvider(r.Context(), identityProvider)
+		if err != nil {
+			l.externalAuthCallbackFailed(w, r, authReq, nil, nil, err)
+			return
+		}
+		session = bitbucket.NewSession(provider, data.Code, authReq.SelectedIDPConfigArgs)
+	case domain.IDPTypeDiscord:
+		provider, err := l.discordProvider(r.Context(), identityProvider)
+		if err != nil {
+			l.externalAuthCallbackFailed(w, r, authReq, nil, nil, err)
+			return
+		}
+		session = oauth.NewSession(provider.Provider, data.Code, authReq.SelectedIDPConfigArgs)
 	case domain.IDPTypeGoogle:
 		provider, err := l.googleProvider(r.Context(), identityProvider)
 		if err != nil {
@@ -491,16 +542,29 @@ func (l *Login) handleExternalUserAuthenticated(
 			return
 		}
 	}
-	if len(externalUser.Metadatas) > 0 {
-		_, err = l.command.BulkSetUserMetadata(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID, externalUser.Metadatas...)
+	if len(externalUser.Metadatas) > 0 || len(externalUser.UserGrants) > 0 {
+		_, err = l.command.ApplyIDPUserMapping(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID, externalUser.Metadatas, externalUser.UserGrants)
 		if err != nil && !userLinked {
 			l.renderError(w, r, authReq, err)
 			return
 		}
 	}
+	l.setExternalUserTokens(r.Context(), authReq, externalUser, session)
 	callback(w, r, authReq)
 }
 
+// setExternalUserTokens stores the tokens of the identity provider on the link of the user,
+// so they can be refreshed and retrieved later on.
+// Any error is only logged, since it must not prevent the user from logging in.
+func (l *Login) setExternalUserTokens(ctx context.Context, authReq *domain.AuthRequest, externalUser *domain.ExternalUser, session idp.Session) {
+	idpTokens := tokens(session)
+	if authReq.UserID == "" || idpTokens == nil || idpTokens.Token == nil {
+		return
+	}
+	err := l.command.SetUserIDPLinkTokens(setContext(ctx, authReq.UserOrgID), authReq.UserID, authReq.UserOrgID, externalUser.IDPConfigID, externalUser.ExternalUserID, idpTokens.Token)
+	logging.WithFields("authReq", authReq.ID, "user", authReq.UserID).OnError(err).Error("unable to store tokens of external user")
+}
+
 // checkAutoLinking checks if a user with the provided information (username or email) already exists within ZITADEL.
 // The decision, which information will be checked is based on the IdP template option.
 // The function returns a boolean whether a user was found or not.
@@ -796,6 +860,14 @@ func (l *Login) registerExternalUser(w http.ResponseWriter, r *http.Request, aut
 		l.renderError(w, r, authReq, err)
 		return
 	}
+	// the mapped grants are merged into the grants of the actions, the same way as on every later login
+	if len(externalUser.UserGrants) > 0 {
+		_, err = l.command.ApplyIDPUserMapping(setContext(r.Context(), resourceOwner), authReq.UserID, resourceOwner, nil, externalUser.UserGrants)
+		if err != nil {
+			l.renderError(w, r, authReq, err)
+			return
+		}
+	}
 	l.renderNextStep(w, r, authReq)
 }
 
@@ -1028,7 +1100,7 @@ func (l *Login) oidcProvider(ctx context.Context, identityProvider *query.IDPTem
 	if err != nil {
 		return nil, err
 	}
-	opts := make([]openid.ProviderOpts, 1, 3)
+	opts := make([]openid.ProviderOpts, 1, 4)
 	opts[0] = openid.WithSelectAccount()
 	if identityProvider.OIDCIDPTemplate.IsIDTokenMapping {
 		opts = append(opts, openid.WithIDTokenMapping())
@@ -1038,6 +1110,9 @@ func (l *Login) oidcProvider(ctx context.Context, identityProvider *query.IDPTem
 		// we do not pass any cookie handler, since we store the verifier internally, rather than in a cookie
 		opts = append(opts, openid.WithRelyingPartyOption(rp.WithPKCE(nil)))
 	}
+	if !identityProvider.OIDCIDPTemplate.ClaimMapping.IsEmpty() {
+		opts = append(opts, openid.WithClaimMapping(identityProvider.OIDCIDPTemplate.ClaimMapping))
+	}
 
 	return openid.New(identityProvider.Name,
 		identityProvider.OIDCIDPTemplate.Issuer,
@@ -1077,11 +1152,14 @@ func (l *Login) oauthProvider(ctx context.Context, identityProvider *query.IDPTe
 		Scopes:      identityProvider.OAuthIDPTemplate.Scopes,
 	}
 
-	opts := make([]oauth.ProviderOpts, 0, 1)
+	opts := make([]oauth.ProviderOpts, 0, 2)
 	if identityProvider.OAuthIDPTemplate.UsePKCE {
 		// we do not pass any cookie handler, since we store the verifier internally, rather than in a cookie
 		opts = append(opts, oauth.WithRelyingPartyOption(rp.WithPKCE(nil)))
 	}
+	if !identityProvider.OAuthIDPTemplate.ClaimMapping.IsEmpty() {
+		opts = append(opts, oauth.WithClaimMapping(identityProvider.OAuthIDPTemplate.ClaimMapping))
+	}
 	return oauth.New(
 		config,
 		identityProvider.Name,
@@ -1222,6 +1300,77 @@ func (l *Login) gitlabSelfHostedProvider(ctx context.Context, identityProvider *
 	)
 }
 
+func (l *Login) oktaProvider(ctx context.Context, identityProvider *query.IDPTemplate) (*okta.Provider, error) {
+	secret, err := crypto.DecryptString(identityProvider.OktaIDPTemplate.ClientSecret, l.idpConfigAlg)
+	if err != nil {
+		return nil, err
+	}
+	return okta.New(
+		identityProvider.Name,
+		identityProvider.OktaIDPTemplate.Issuer,
+		identityProvider.OktaIDPTemplate.ClientID,
+		secret,
+		l.baseURL(ctx)+EndpointExternalLoginCallback,
+		identityProvider.OktaIDPTemplate.Scopes,
+	)
+}
+
+func (l *Login) keycloakProvider(ctx context.Context, identityProvider *query.IDPTemplate) (*keycloak.Provider, error) {
+	secret, err := crypto.DecryptString(identityProvider.KeycloakIDPTemplate.ClientSecret, l.idpConfigAlg)
+	if err != nil {
+		return nil, err
+	}
+	return keycloak.New(
+		identityProvider.Name,
+		identityProvider.KeycloakIDPTemplate.Issuer,
+		identityProvider.KeycloakIDPTemplate.ClientID,
+		secret,
+		l.baseURL(ctx)+EndpointExternalLoginCallback,
+		identityProvider.KeycloakIDPTemplate.Scopes,
+	)
+}
+
+func (l *Login) adfsProvider(ctx context.Context, identityProvider *query.IDPTemplate) (*adfs.Provider, error) {
+	secret, err := crypto.DecryptString(identityProvider.ADFSIDPTemplate.ClientSecret, l.idpConfigAlg)
+	if err != nil {
+		return nil, err
+	}
+	return adfs.New(
+		identityProvider.Name,
+		identityProvider.ADFSIDPTemplate.Issuer,
+		identityProvider.ADFSIDPTemplate.ClientID,
+		secret,
+		l.baseURL(ctx)+EndpointExternalLoginCallback,
+		identityProvider.ADFSIDPTemplate.Scopes,
+	)
+}
+
+func (l *Login) bitbucketProvider(ctx context.Context, identityProvider *query.IDPTemplate) (*bitbucket.Provider, error) {
+	secret, err := crypto.DecryptString(identityProvider.BitbucketIDPTemplate.ClientSecret, l.idpConfigAlg)
+	if err != nil {
+		return nil, err
+	}
+	return bitbucket.New(
+		identityProvider.BitbucketIDPTemplate.ClientID,
+		secret,
+		l.baseURL(ctx)+EndpointExternalLoginCallback,
+		identityProvider.BitbucketIDPTemplate.Scopes,
+	)
+}
+
+func (l *Login) discordProvider(ctx context.Context, identityProvider *query.IDPTemplate) (*discord.Provider, error) {
+	secret, err := crypto.DecryptString(identityProvider.DiscordIDPTemplate.ClientSecret, l.idpConfigAlg)
+	if err != nil {
+		return nil, err
+	}
+	return discord.New(
+		identityProvider.DiscordIDPTemplate.ClientID,
+		secret,
+		l.baseURL(ctx)+EndpointExternalLoginCallback,
+		identityProvider.DiscordIDPTemplate.Scopes,
+	)
+}
+
 func (l *Login) appleProvider(ctx context.Context, identityProvider *query.IDPTemplate) (*apple.Provider, error) {
 	privateKey, err := crypto.Decrypt(identityProvider.AppleIDPTemplate.PrivateKey, l.idpConfigAlg)
 	if err != nil {
@@ -1304,12 +1453,14 @@ func tokens(session idp.Session) *oidc.Tokens[*oidc.IDTokenClaims] {
 		return s.Tokens()
 	case *apple.Session:
 		return s.Tokens
+	case *bitbucket.Session:
+		return s.Tokens
 	}
 	return nil
 }
 
 func mapIDPUserToExternalUser(user idp.User, id string) *domain.ExternalUser {
-	return &domain.ExternalUser{
+	externalUser := &domain.ExternalUser{
 		IDPConfigID:       id,
 		ExternalUserID:    user.GetID(),
 		PreferredUsername: user.GetPreferredUsername(),
@@ -1323,6 +1474,11 @@ func mapIDPUserToExternalUser(user idp.User, id string) *domain.ExternalUser {
 		Phone:             user.GetPhone(),
 		IsPhoneVerified:   user.IsPhoneVerified(),
 	}
+	if mapped, ok := user.(*mapping.User); ok {
+		externalUser.Metadatas = mapped.Metadata()
+		externalUser.UserGrants = mapped.UserGrants()
+	}
+	return externalUser
 }
 
 func mapExternalUserToLoginUser(externalUser *domain.ExternalUser, mustBeDomain bool) (*domain.Human, *domain.UserIDPLink, []*domain.Metadata) {
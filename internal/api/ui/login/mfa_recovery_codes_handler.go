@@ -0,0 +1,62 @@
+package login
+
+import (
+	"net/http"
+
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	tmplMFARecoveryCodes      = "mfarecoverycodes"
+	tmplMFARecoveryCodeVerify = "mfarecoverycodeverify"
+)
+
+type mfaRecoveryCodesData struct {
+	userData
+	RecoveryCodes []string
+}
+
+// handleMFARecoveryCodes generates a new set of recovery codes for the user and displays them once.
+// It's only possible if the user already verified a second factor in the current auth request.
+func (l *Login) handleMFARecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	authReq, err := l.ensureAuthRequest(r)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if len(authReq.MFAsVerified) == 0 {
+		l.renderError(w, r, authReq, zerrors.ThrowPreconditionFailed(nil, "LOGIN-ieG4o", "Errors.User.MFA.RecoveryCodes.MFANotVerified"))
+		return
+	}
+	codes, err := l.command.GenerateHumanRecoveryCodes(setUserContext(r.Context(), authReq.UserID, authReq.UserOrgID), authReq.UserID, authReq.UserOrgID)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	translator := l.getTranslator(r.Context(), authReq)
+	data := &mfaRecoveryCodesData{
+		userData:      l.getUserData(r, authReq, translator, "InitMFARecoveryCodes.Title", "InitMFARecoveryCodes.Description", nil),
+		RecoveryCodes: codes.Codes,
+	}
+	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplMFARecoveryCodes], data, nil)
+}
+
+func (l *Login) handleMFARecoveryCodeVerification(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, step *domain.MFAVerificationStep, code string) {
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	err := l.authRepo.VerifyMFARecoveryCode(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, code, userAgentID, domain.BrowserInfoFromRequest(r))
+
+	metadata, actionErr := l.runPostInternalAuthenticationActions(authReq, r, authMethodRecoveryCode, err)
+	if err == nil && actionErr == nil && len(metadata) > 0 {
+		_, err = l.command.BulkSetUserMetadata(r.Context(), authReq.UserID, authReq.UserOrgID, metadata...)
+	} else if actionErr != nil && err == nil {
+		err = actionErr
+	}
+
+	if err != nil {
+		l.renderMFAVerifySelected(w, r, authReq, step, domain.MFATypeRecoveryCode, err)
+		return
+	}
+	l.renderNextStep(w, r, authReq)
+}
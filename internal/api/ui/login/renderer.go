@@ -63,6 +63,8 @@ func CreateRenderer(pathPrefix string, staticStorage static.Storage, cookieName
 		tmplMFAU2FInit:                   "mfa_init_u2f.html",
 		tmplU2FVerification:              "mfa_verification_u2f.html",
 		tmplMFAInitDone:                  "mfa_init_done.html",
+		tmplMFARecoveryCodes:             "mfa_recovery_codes.html",
+		tmplMFARecoveryCodeVerify:        "mfa_verify_recovery_code.html",
 		tmplMailVerification:             "mail_verification.html",
 		tmplMailVerified:                 "mail_verified.html",
 		tmplInitPassword:                 "init_password.html",
@@ -165,6 +167,9 @@ func CreateRenderer(pathPrefix string, staticStorage static.Storage, cookieName
 		"mfaVerifyUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointMFAVerify)
 		},
+		"mfaRecoveryCodesUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointMFARecoveryCodes)
+		},
 		"mfaPromptUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointMFAPrompt)
 		},
@@ -410,6 +415,7 @@ func (l *Login) getBaseData(r *http.Request, authReq *domain.AuthRequest, transl
 		baseData.LoginPolicy = authReq.LoginPolicy
 		baseData.LabelPolicy = authReq.LabelPolicy
 		baseData.IDPProviders = authReq.AllowedExternalIDPs
+		baseData.AuthorizationDetails = getAuthorizationDetailsData(authReq)
 		if authReq.PrivacyPolicy == nil {
 			return baseData
 		}
@@ -664,6 +670,7 @@ type baseData struct {
 	IDPProviders           []*domain.IDPProvider
 	LabelPolicy            *domain.LabelPolicy
 	LoginTexts             []*domain.CustomLoginText
+	AuthorizationDetails   []authorizationDetailData
 }
 
 type errorData struct {
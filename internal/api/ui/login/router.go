@@ -39,6 +39,7 @@ const (
 	EndpointMFAOTPVerify                  = "/mfa/otp/verify"
 	EndpointMFAInitU2FVerify              = "/mfa/init/u2f/verify"
 	EndpointU2FVerification               = "/mfa/u2f/verify"
+	EndpointMFARecoveryCodes              = "/mfa/recoverycodes"
 	EndpointMailVerification              = "/mail/verification"
 	EndpointMailVerified                  = "/mail/verified"
 	EndpointRegisterOption                = "/register/option"
@@ -108,6 +109,7 @@ func CreateRouter(login *Login, interceptors ...mux.MiddlewareFunc) *mux.Router
 	router.HandleFunc(EndpointMFAOTPVerify, login.handleOTPVerificationCheck).Methods(http.MethodPost)
 	router.HandleFunc(EndpointMFAInitU2FVerify, login.handleRegisterU2F).Methods(http.MethodPost)
 	router.HandleFunc(EndpointU2FVerification, login.handleU2FVerification).Methods(http.MethodPost)
+	router.HandleFunc(EndpointMFARecoveryCodes, login.handleMFARecoveryCodes).Methods(http.MethodPost)
 	router.HandleFunc(EndpointMailVerification, login.handleMailVerification).Methods(http.MethodGet)
 	router.HandleFunc(EndpointMailVerification, login.handleMailVerificationCheck).Methods(http.MethodPost)
 	router.HandleFunc(EndpointChangePassword, login.handleChangePassword).Methods(http.MethodPost)
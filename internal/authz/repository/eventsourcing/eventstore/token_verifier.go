@@ -15,7 +15,9 @@ import (
 	"github.com/zitadel/oidc/v3/pkg/op"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/dpop"
 	http_util "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/mtls"
 	"github.com/zitadel/zitadel/internal/authz/repository/eventsourcing/view"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/crypto"
@@ -100,6 +102,10 @@ func (repo *TokenVerifierRepo) VerifyAccessToken(ctx context.Context, tokenStrin
 	if strings.HasPrefix(tokenID, command.IDPrefixV2) {
 		return repo.verifyAccessTokenV2(ctx, tokenID, verifierClientID, projectID)
 	}
+	// only access tokens of OIDC sessions can be bound to a DPoP key
+	if dpop.ThumbprintFromContext(ctx) != "" {
+		return "", "", "", "", "", zerrors.ThrowUnauthenticated(nil, "APP-ooPh4", "invalid token")
+	}
 	if sessionID, ok := strings.CutPrefix(tokenID, authz.SessionTokenPrefix); ok {
 		userID, clientID, resourceOwner, err = repo.verifySessionToken(ctx, sessionID, tokenString)
 		return
@@ -143,6 +149,12 @@ func (repo *TokenVerifierRepo) verifyAccessTokenV2(ctx context.Context, token, v
 	if activeToken.Actor != nil {
 		return "", "", "", "", "", zerrors.ThrowPermissionDenied(nil, "APP-Shi0J", "Errors.TokenExchange.Token.NotForAPI")
 	}
+	if err = activeToken.CheckDPoPBinding(dpop.ThumbprintFromContext(ctx)); err != nil {
+		return "", "", "", "", "", err
+	}
+	if err = activeToken.CheckCertificateBinding(mtls.ThumbprintFromContext(ctx)); err != nil {
+		return "", "", "", "", "", err
+	}
 	if err = verifyAudience(activeToken.Audience, verifierClientID, projectID); err != nil {
 		return "", "", "", "", "", err
 	}
@@ -231,6 +243,9 @@ func authMethodsFromSession(session *query.Session) []domain.UserAuthMethodType
 	if !session.OTPEmailFactor.OTPCheckedAt.IsZero() {
 		types = append(types, domain.UserAuthMethodTypeOTPEmail)
 	}
+	if !session.RecoveryCodeFactor.RecoveryCodeCheckedAt.IsZero() {
+		types = append(types, domain.UserAuthMethodTypeRecoveryCode)
+	}
 	return types
 }
 
@@ -19,6 +19,9 @@ const (
 	PurposeOrganization
 	PurposeIdPFormCallback
 	PurposeFederatedLogout
+	PurposeIntrospectionClient
+	PurposeOIDCUserInfo
+	PurposeWebKeySet
 )
 
 // Cache stores objects with a value of type `V`.
@@ -92,6 +95,7 @@ const (
 	ConnectorMemory
 	ConnectorPostgres
 	ConnectorRedis
+	ConnectorTiered
 )
 
 type Config struct {
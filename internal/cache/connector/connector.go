@@ -10,6 +10,7 @@ import (
 	"github.com/zitadel/zitadel/internal/cache/connector/noop"
 	"github.com/zitadel/zitadel/internal/cache/connector/pg"
 	"github.com/zitadel/zitadel/internal/cache/connector/redis"
+	"github.com/zitadel/zitadel/internal/cache/connector/tiered"
 	"github.com/zitadel/zitadel/internal/database"
 )
 
@@ -18,12 +19,17 @@ type CachesConfig struct {
 		Memory   gomap.Config
 		Postgres pg.Config
 		Redis    redis.Config
+		Tiered   tiered.Config
 	}
 	Instance         *cache.Config
 	Milestones       *cache.Config
 	Organization     *cache.Config
 	IdPFormCallbacks *cache.Config
 	FederatedLogouts *cache.Config
+
+	IntrospectionClients *cache.Config
+	OIDCUserInfos        *cache.Config
+	WebKeySets           *cache.Config
 }
 
 type Connectors struct {
@@ -31,18 +37,41 @@ type Connectors struct {
 	Memory   *gomap.Connector
 	Postgres *pg.Connector
 	Redis    *redis.Connector
+	Tiered   *tiered.Connector
 }
 
 func StartConnectors(conf *CachesConfig, client *database.DB) (Connectors, error) {
 	if conf == nil {
 		return Connectors{}, nil
 	}
-	return Connectors{
+	connectors := Connectors{
 		Config:   *conf,
 		Memory:   gomap.NewConnector(conf.Connectors.Memory),
 		Postgres: pg.NewConnector(conf.Connectors.Postgres, client),
 		Redis:    redis.NewConnector(conf.Connectors.Redis),
-	}, nil
+	}
+	var err error
+	connectors.Tiered, err = startTieredConnector(conf.Connectors.Tiered, connectors, client)
+	if err != nil {
+		return Connectors{}, err
+	}
+	return connectors, nil
+}
+
+func startTieredConnector(conf tiered.Config, connectors Connectors, client *database.DB) (*tiered.Connector, error) {
+	if !conf.Enabled {
+		return nil, nil
+	}
+	var broadcaster tiered.Broadcaster
+	switch {
+	case conf.Backend == cache.ConnectorPostgres && connectors.Postgres != nil:
+		broadcaster = tiered.NewPostgresBroadcaster(client.Pool, conf.Channel)
+	case conf.Backend == cache.ConnectorRedis && connectors.Redis != nil:
+		broadcaster = tiered.NewRedisBroadcaster(connectors.Redis.Client, conf.Channel)
+	default:
+		return nil, fmt.Errorf("tiered cache backend %q not enabled", conf.Backend)
+	}
+	return tiered.NewConnector(conf, broadcaster), nil
 }
 
 func StartCache[I ~int, K ~string, V cache.Entry[I, K]](background context.Context, indices []I, purpose cache.Purpose, conf *cache.Config, connectors Connectors) (cache.Cache[I, K, V], error) {
@@ -67,6 +96,17 @@ func StartCache[I ~int, K ~string, V cache.Entry[I, K]](background context.Conte
 		c := redis.NewCache[I, K, V](*conf, connectors.Redis, db, indices)
 		return c, nil
 	}
+	if conf.Connector == cache.ConnectorTiered && connectors.Tiered != nil {
+		sharedConf := *conf
+		sharedConf.Connector = connectors.Tiered.Config.Backend
+		shared, err := StartCache[I, K, V](background, indices, purpose, &sharedConf, connectors)
+		if err != nil {
+			return nil, err
+		}
+		c := tiered.NewCache[I, K, V](background, purpose, *conf, indices, shared, connectors.Tiered)
+		connectors.Tiered.Config.AutoPrune.StartAutoPrune(background, c, purpose)
+		return c, nil
+	}
 
 	return nil, fmt.Errorf("cache connector %q not enabled", conf.Connector)
 }
@@ -0,0 +1,101 @@
+package tiered
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+type redisBroadcaster struct {
+	client  *redis.Client
+	channel string
+}
+
+// NewRedisBroadcaster returns a [Broadcaster] using Redis pub/sub.
+func NewRedisBroadcaster(client *redis.Client, channel string) Broadcaster {
+	if channel == "" {
+		channel = DefaultChannel
+	}
+	return &redisBroadcaster{
+		client:  client,
+		channel: channel,
+	}
+}
+
+func (b *redisBroadcaster) Publish(ctx context.Context, payload []byte) error {
+	return b.client.Publish(ctx, b.channel, payload).Err()
+}
+
+func (b *redisBroadcaster) Listen(ctx context.Context, subscribed func(ctx context.Context), handle func(ctx context.Context, payload []byte)) error {
+	sub := b.client.Subscribe(ctx, b.channel)
+	defer sub.Close()
+
+	for {
+		msg, err := sub.Receive(ctx)
+		if err != nil {
+			return err
+		}
+		switch msg := msg.(type) {
+		case *redis.Subscription:
+			if msg.Kind == "subscribe" {
+				subscribed(ctx)
+			}
+		case *redis.Message:
+			handle(ctx, []byte(msg.Payload))
+		}
+	}
+}
+
+// maxNotifyPayload is the maximum payload size of NOTIFY in the default Postgres configuration.
+const maxNotifyPayload = 8000
+
+type pgBroadcaster struct {
+	pool    *pgxpool.Pool
+	channel string
+}
+
+// NewPostgresBroadcaster returns a [Broadcaster] using Postgres LISTEN/NOTIFY.
+// Each node holds one dedicated connection from the pool for listening.
+func NewPostgresBroadcaster(pool *pgxpool.Pool, channel string) Broadcaster {
+	if channel == "" {
+		channel = DefaultChannel
+	}
+	return &pgBroadcaster{
+		pool:    pool,
+		channel: channel,
+	}
+}
+
+func (b *pgBroadcaster) Publish(ctx context.Context, payload []byte) error {
+	if len(payload) >= maxNotifyPayload {
+		return ErrPayloadTooLarge
+	}
+	_, err := b.pool.Exec(ctx, "SELECT pg_notify($1, $2)", b.channel, string(payload))
+	return err
+}
+
+func (b *pgBroadcaster) Listen(ctx context.Context, subscribed func(ctx context.Context), handle func(ctx context.Context, payload []byte)) error {
+	poolConn, err := b.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire listener connection: %w", err)
+	}
+	// the connection is removed from the pool,
+	// so the LISTEN state never leaks to other users of the pool.
+	conn := poolConn.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err = conn.Exec(ctx, "LISTEN "+pgx.Identifier{b.channel}.Sanitize()); err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	subscribed(ctx)
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		handle(ctx, []byte(notification.Payload))
+	}
+}
@@ -0,0 +1,180 @@
+package tiered
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/cache"
+)
+
+const DefaultChannel = "zitadel_cache_invalidation"
+
+type Config struct {
+	Enabled bool
+	// Backend is the shared cache connector behind the local memory cache.
+	// Must be "postgres" or "redis" and the respective connector must be enabled.
+	// Invalidations are broadcasted through Postgres LISTEN/NOTIFY or Redis pub/sub.
+	Backend cache.Connector
+	// Channel on which invalidations are broadcasted between nodes.
+	// Defaults to [DefaultChannel].
+	Channel string
+	// LocalMaxAge limits the age of objects in the local memory cache,
+	// in case an invalidation message was missed.
+	// The lower value of the cache's MaxAge and LocalMaxAge is used.
+	// 0 uses the cache's MaxAge.
+	LocalMaxAge time.Duration
+	// AutoPrune removes invalidated or expired objects from the local memory cache.
+	AutoPrune cache.AutoPruneConfig
+	// ReconnectInterval is the time to wait before a broken subscription is restarted.
+	ReconnectInterval time.Duration
+	// Log allows logging of the invalidation subscription.
+	Log *logging.Config
+}
+
+// Broadcaster distributes invalidation messages between nodes.
+type Broadcaster interface {
+	// Publish sends the payload to all subscribed nodes, including the publisher itself.
+	Publish(ctx context.Context, payload []byte) error
+	// Listen blocks until ctx is done or the subscription fails.
+	// It calls subscribed once the subscription is established
+	// and handle for each received payload.
+	Listen(ctx context.Context, subscribed func(ctx context.Context), handle func(ctx context.Context, payload []byte)) error
+}
+
+type Connector struct {
+	Config      Config
+	nodeID      string
+	broadcaster Broadcaster
+	logger      *slog.Logger
+
+	mu       sync.RWMutex
+	handlers map[cache.Purpose][]func(ctx context.Context, msg *message)
+	listen   sync.Once
+}
+
+func NewConnector(config Config, broadcaster Broadcaster) *Connector {
+	if !config.Enabled {
+		return nil
+	}
+	if config.ReconnectInterval <= 0 {
+		config.ReconnectInterval = time.Second
+	}
+	logger := slog.Default()
+	if config.Log != nil {
+		logger = config.Log.Slog()
+	}
+	nodeID := uuid.NewString()
+	return &Connector{
+		Config:      config,
+		nodeID:      nodeID,
+		broadcaster: broadcaster,
+		logger:      logger.With("cache_connector", cache.ConnectorTiered, "node", nodeID),
+		handlers:    make(map[cache.Purpose][]func(ctx context.Context, msg *message)),
+	}
+}
+
+type operation int
+
+const (
+	operationInvalidate operation = iota + 1
+	operationDelete
+	operationTruncate
+)
+
+type message struct {
+	Node      string        `json:"node"`
+	Purpose   cache.Purpose `json:"purpose"`
+	Operation operation     `json:"op"`
+	Index     int           `json:"index,omitempty"`
+	Keys      []string      `json:"keys,omitempty"`
+}
+
+// ErrPayloadTooLarge can be returned by a [Broadcaster] if the payload exceeds its limits.
+// The connector falls back to broadcasting a truncate of the affected cache.
+var ErrPayloadTooLarge = errors.New("cache invalidation payload too large")
+
+func (c *Connector) publish(ctx context.Context, msg *message) error {
+	msg.Node = c.nodeID
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	err = c.broadcaster.Publish(ctx, payload)
+	if !errors.Is(err, ErrPayloadTooLarge) {
+		return err
+	}
+	c.logger.WarnContext(ctx, "invalidation too large, broadcasting truncate", "purpose", msg.Purpose, "keys", len(msg.Keys))
+	return c.publish(ctx, &message{
+		Purpose:   msg.Purpose,
+		Operation: operationTruncate,
+	})
+}
+
+// register adds the handler for messages of the purpose
+// and starts listening for messages if not done already.
+func (c *Connector) register(background context.Context, purpose cache.Purpose, handler func(ctx context.Context, msg *message)) {
+	c.mu.Lock()
+	c.handlers[purpose] = append(c.handlers[purpose], handler)
+	c.mu.Unlock()
+
+	c.listen.Do(func() {
+		go c.subscribe(background)
+	})
+}
+
+func (c *Connector) subscribe(background context.Context) {
+	var resubscribed bool
+	subscribed := func(ctx context.Context) {
+		// invalidations might have been missed while the subscription was broken.
+		if resubscribed {
+			c.truncateAll(ctx)
+		}
+		resubscribed = true
+	}
+	for {
+		err := c.broadcaster.Listen(background, subscribed, c.handle)
+		if background.Err() != nil {
+			return
+		}
+		c.logger.ErrorContext(background, "cache invalidation subscription", "err", err)
+		select {
+		case <-background.Done():
+			return
+		case <-time.After(c.Config.ReconnectInterval):
+		}
+	}
+}
+
+func (c *Connector) handle(ctx context.Context, payload []byte) {
+	msg := new(message)
+	if err := json.Unmarshal(payload, msg); err != nil {
+		c.logger.ErrorContext(ctx, "decode cache invalidation", "err", err)
+		return
+	}
+	if msg.Node == c.nodeID {
+		return
+	}
+	c.mu.RLock()
+	handlers := c.handlers[msg.Purpose]
+	c.mu.RUnlock()
+	for _, handler := range handlers {
+		handler(ctx, msg)
+	}
+}
+
+func (c *Connector) truncateAll(ctx context.Context) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for purpose, handlers := range c.handlers {
+		for _, handler := range handlers {
+			handler(ctx, &message{Purpose: purpose, Operation: operationTruncate})
+		}
+	}
+}
@@ -0,0 +1,188 @@
+// Package tiered provides a cache which keeps objects in local memory,
+// in front of a cache shared between all nodes.
+// Invalidations are broadcasted to the other nodes,
+// so they can remove the object from their local memory.
+package tiered
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/cache/connector/gomap"
+)
+
+type tieredCache[I ~int, K ~string, V cache.Entry[I, K]] struct {
+	purpose   cache.Purpose
+	local     cache.PrunerCache[I, K, V]
+	shared    cache.Cache[I, K, V]
+	connector *Connector
+	logger    *slog.Logger
+
+	// mu guards the generation, which is increased by every invalidation of the local memory.
+	// Objects read from the shared cache are only stored in local memory
+	// if no invalidation happened during the read, as they might be stale.
+	mu         sync.RWMutex
+	generation uint64
+}
+
+// NewCache returns a cache that serves objects from local memory
+// and falls back to the shared cache on a local miss.
+// Objects found in the shared cache are stored in local memory for subsequent calls.
+//
+// Invalidate, Delete and Truncate are applied to both tiers
+// and broadcasted to the other nodes, which apply them to their local memory.
+// Set is not broadcasted, as the shared cache already invalidates existing objects
+// and nodes are expected to call Invalidate when an object changes.
+func NewCache[I ~int, K ~string, V cache.Entry[I, K]](background context.Context, purpose cache.Purpose, config cache.Config, indices []I, shared cache.Cache[I, K, V], connector *Connector) cache.PrunerCache[I, K, V] {
+	localConfig := config
+	if maxAge := connector.Config.LocalMaxAge; maxAge > 0 && (localConfig.MaxAge <= 0 || maxAge < localConfig.MaxAge) {
+		localConfig.MaxAge = maxAge
+	}
+	c := &tieredCache[I, K, V]{
+		purpose:   purpose,
+		local:     gomap.NewCache[I, K, V](background, indices, localConfig),
+		shared:    shared,
+		connector: connector,
+		logger:    connector.logger.With("cache_purpose", purpose),
+	}
+	connector.register(background, purpose, c.handle)
+	return c
+}
+
+func (c *tieredCache[I, K, V]) Get(ctx context.Context, index I, key K) (value V, ok bool) {
+	if value, ok = c.local.Get(ctx, index, key); ok {
+		return value, true
+	}
+	generation := c.currentGeneration()
+	if value, ok = c.shared.Get(ctx, index, key); ok {
+		c.setLocal(ctx, value, generation)
+	}
+	return value, ok
+}
+
+func (c *tieredCache[I, K, V]) currentGeneration() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.generation
+}
+
+// setLocal stores the value in local memory, unless the local memory was invalidated since the generation.
+func (c *tieredCache[I, K, V]) setLocal(ctx context.Context, value V, generation uint64) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.generation != generation {
+		return
+	}
+	c.local.Set(ctx, value)
+}
+
+// invalidateLocal applies the invalidation to the local memory and increases the generation.
+func (c *tieredCache[I, K, V]) invalidateLocal(invalidate func() error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	return invalidate()
+}
+
+func (c *tieredCache[I, K, V]) Set(ctx context.Context, value V) {
+	c.shared.Set(ctx, value)
+	c.local.Set(ctx, value)
+}
+
+func (c *tieredCache[I, K, V]) Invalidate(ctx context.Context, index I, keys ...K) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	err := errors.Join(
+		c.shared.Invalidate(ctx, index, keys...),
+		c.invalidateLocal(func() error { return c.local.Invalidate(ctx, index, keys...) }),
+	)
+	if err != nil {
+		return err
+	}
+	return c.connector.publish(ctx, &message{
+		Purpose:   c.purpose,
+		Operation: operationInvalidate,
+		Index:     int(index),
+		Keys:      keysToStrings(keys),
+	})
+}
+
+func (c *tieredCache[I, K, V]) Delete(ctx context.Context, index I, keys ...K) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	err := errors.Join(
+		c.shared.Delete(ctx, index, keys...),
+		c.invalidateLocal(func() error { return c.local.Delete(ctx, index, keys...) }),
+	)
+	if err != nil {
+		return err
+	}
+	return c.connector.publish(ctx, &message{
+		Purpose:   c.purpose,
+		Operation: operationDelete,
+		Index:     int(index),
+		Keys:      keysToStrings(keys),
+	})
+}
+
+func (c *tieredCache[I, K, V]) Truncate(ctx context.Context) error {
+	err := errors.Join(
+		c.shared.Truncate(ctx),
+		c.invalidateLocal(func() error { return c.local.Truncate(ctx) }),
+	)
+	if err != nil {
+		return err
+	}
+	return c.connector.publish(ctx, &message{
+		Purpose:   c.purpose,
+		Operation: operationTruncate,
+	})
+}
+
+// Prune the local memory.
+// The shared cache is pruned by its own connector.
+func (c *tieredCache[I, K, V]) Prune(ctx context.Context) error {
+	return c.local.Prune(ctx)
+}
+
+// handle applies an invalidation broadcasted by another node to the local memory.
+func (c *tieredCache[I, K, V]) handle(ctx context.Context, msg *message) {
+	var err error
+	switch msg.Operation {
+	case operationInvalidate:
+		err = c.invalidateLocal(func() error { return c.local.Invalidate(ctx, I(msg.Index), stringsToKeys[K](msg.Keys)...) })
+	case operationDelete:
+		err = c.invalidateLocal(func() error { return c.local.Delete(ctx, I(msg.Index), stringsToKeys[K](msg.Keys)...) })
+	case operationTruncate:
+		err = c.invalidateLocal(func() error { return c.local.Truncate(ctx) })
+	default:
+		c.logger.WarnContext(ctx, "unknown cache invalidation operation", "operation", msg.Operation)
+		return
+	}
+	if err != nil {
+		c.logger.ErrorContext(ctx, "apply cache invalidation", "err", err, "operation", msg.Operation, "index", msg.Index)
+		return
+	}
+	c.logger.DebugContext(ctx, "applied cache invalidation", "operation", msg.Operation, "index", msg.Index, "keys", msg.Keys)
+}
+
+func keysToStrings[K ~string](keys []K) []string {
+	s := make([]string, len(keys))
+	for i, key := range keys {
+		s[i] = string(key)
+	}
+	return s
+}
+
+func stringsToKeys[K ~string](s []string) []K {
+	keys := make([]K, len(s))
+	for i, key := range s {
+		keys[i] = K(key)
+	}
+	return keys
+}
@@ -0,0 +1,314 @@
+package tiered
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/cache/connector/gomap"
+)
+
+type testIndex int
+
+const (
+	testIndexID testIndex = iota
+	testIndexName
+)
+
+var testIndices = []testIndex{
+	testIndexID,
+	testIndexName,
+}
+
+type testObject struct {
+	id    string
+	names []string
+}
+
+func (o *testObject) Keys(index testIndex) []string {
+	switch index {
+	case testIndexID:
+		return []string{o.id}
+	case testIndexName:
+		return o.names
+	default:
+		return nil
+	}
+}
+
+var testConfig = cache.Config{
+	MaxAge:     time.Minute,
+	LastUseAge: time.Minute,
+	Log: &logging.Config{
+		Level:     "debug",
+		AddSource: true,
+	},
+}
+
+type testNode struct {
+	cache  cache.PrunerCache[testIndex, string, *testObject]
+	local  cache.PrunerCache[testIndex, string, *testObject]
+	shared cache.Cache[testIndex, string, *testObject]
+}
+
+// prepareNodes starts count nodes which share a cache and broadcast through the same Redis server.
+func prepareNodes(t *testing.T, count int) []testNode {
+	server := miniredis.RunT(t)
+	shared := gomap.NewCache[testIndex, string, *testObject](context.Background(), testIndices, testConfig)
+	background, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	nodes := make([]testNode, count)
+	for i := range nodes {
+		client := redis.NewClient(&redis.Options{
+			Addr:             server.Addr(),
+			DisableIndentity: true,
+		})
+		t.Cleanup(func() { client.Close() })
+		broadcaster := &readyBroadcaster{
+			Broadcaster: NewRedisBroadcaster(client, ""),
+			ready:       make(chan struct{}),
+		}
+		connector := NewConnector(Config{Enabled: true}, broadcaster)
+		c := NewCache[testIndex, string, *testObject](background, cache.PurposeAuthzInstance, testConfig, testIndices, shared, connector)
+		<-broadcaster.ready
+		nodes[i] = testNode{
+			cache:  c,
+			local:  c.(*tieredCache[testIndex, string, *testObject]).local,
+			shared: shared,
+		}
+	}
+	return nodes
+}
+
+// readyBroadcaster signals when the first subscription is established.
+type readyBroadcaster struct {
+	Broadcaster
+	ready chan struct{}
+	once  sync.Once
+}
+
+func (b *readyBroadcaster) Listen(ctx context.Context, subscribed func(ctx context.Context), handle func(ctx context.Context, payload []byte)) error {
+	return b.Broadcaster.Listen(ctx, func(ctx context.Context) {
+		subscribed(ctx)
+		b.once.Do(func() { close(b.ready) })
+	}, handle)
+}
+
+func Test_tieredCache_Get(t *testing.T) {
+	nodes := prepareNodes(t, 1)
+	ctx := context.Background()
+	obj := &testObject{
+		id:    "id",
+		names: []string{"foo", "bar"},
+	}
+	nodes[0].shared.Set(ctx, obj)
+
+	_, ok := nodes[0].local.Get(ctx, testIndexID, "id")
+	require.False(t, ok, "local miss before get")
+
+	got, ok := nodes[0].cache.Get(ctx, testIndexName, "foo")
+	require.True(t, ok)
+	assert.Equal(t, obj, got)
+
+	got, ok = nodes[0].local.Get(ctx, testIndexID, "id")
+	require.True(t, ok, "local hit after get")
+	assert.Equal(t, obj, got)
+
+	_, ok = nodes[0].cache.Get(ctx, testIndexID, "spanac")
+	assert.False(t, ok)
+}
+
+// invalidatingCache invalidates the object while it's read from the shared cache.
+type invalidatingCache struct {
+	cache.Cache[testIndex, string, *testObject]
+	invalidate func()
+}
+
+func (c *invalidatingCache) Get(ctx context.Context, index testIndex, key string) (*testObject, bool) {
+	value, ok := c.Cache.Get(ctx, index, key)
+	c.invalidate()
+	return value, ok
+}
+
+func Test_tieredCache_Get_invalidatedDuringRead(t *testing.T) {
+	nodes := prepareNodes(t, 1)
+	ctx := context.Background()
+	obj := &testObject{
+		id:    "id",
+		names: []string{"foo", "bar"},
+	}
+	nodes[0].shared.Set(ctx, obj)
+	tiered := nodes[0].cache.(*tieredCache[testIndex, string, *testObject])
+	tiered.shared = &invalidatingCache{
+		Cache: nodes[0].shared,
+		invalidate: func() {
+			tiered.handle(ctx, &message{
+				Purpose:   cache.PurposeAuthzInstance,
+				Operation: operationInvalidate,
+				Index:     int(testIndexID),
+				Keys:      []string{"id"},
+			})
+		},
+	}
+
+	got, ok := nodes[0].cache.Get(ctx, testIndexID, "id")
+	require.True(t, ok)
+	assert.Equal(t, obj, got)
+
+	_, ok = nodes[0].local.Get(ctx, testIndexID, "id")
+	assert.False(t, ok, "local miss after invalidation during get")
+}
+
+func Test_tieredCache_Set(t *testing.T) {
+	nodes := prepareNodes(t, 1)
+	ctx := context.Background()
+	obj := &testObject{
+		id:    "id",
+		names: []string{"foo", "bar"},
+	}
+	nodes[0].cache.Set(ctx, obj)
+
+	got, ok := nodes[0].local.Get(ctx, testIndexID, "id")
+	require.True(t, ok)
+	assert.Equal(t, obj, got)
+	got, ok = nodes[0].shared.Get(ctx, testIndexID, "id")
+	require.True(t, ok)
+	assert.Equal(t, obj, got)
+}
+
+func Test_tieredCache_Invalidate(t *testing.T) {
+	nodes := prepareNodes(t, 2)
+	ctx := context.Background()
+	obj := &testObject{
+		id:    "id",
+		names: []string{"foo", "bar"},
+	}
+	nodes[0].cache.Set(ctx, obj)
+	_, ok := nodes[1].cache.Get(ctx, testIndexID, "id")
+	require.True(t, ok, "warm up local cache of the second node")
+
+	err := nodes[0].cache.Invalidate(ctx, testIndexName, "bar")
+	require.NoError(t, err)
+
+	_, ok = nodes[0].cache.Get(ctx, testIndexID, "id")
+	assert.False(t, ok)
+	_, ok = nodes[0].shared.Get(ctx, testIndexID, "id")
+	assert.False(t, ok)
+	assert.Eventually(t, func() bool {
+		_, ok := nodes[1].cache.Get(ctx, testIndexID, "id")
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+}
+
+func Test_tieredCache_Delete(t *testing.T) {
+	nodes := prepareNodes(t, 2)
+	ctx := context.Background()
+	obj := &testObject{
+		id:    "id",
+		names: []string{"foo", "bar"},
+	}
+	nodes[0].cache.Set(ctx, obj)
+	_, ok := nodes[1].cache.Get(ctx, testIndexID, "id")
+	require.True(t, ok, "warm up local cache of the second node")
+
+	err := nodes[0].cache.Delete(ctx, testIndexName, "bar")
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		_, ok := nodes[1].local.Get(ctx, testIndexName, "bar")
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+	// other keys are not affected
+	_, ok = nodes[1].local.Get(ctx, testIndexName, "foo")
+	assert.True(t, ok)
+	_, ok = nodes[0].cache.Get(ctx, testIndexID, "id")
+	assert.True(t, ok)
+}
+
+func Test_tieredCache_Truncate(t *testing.T) {
+	nodes := prepareNodes(t, 2)
+	ctx := context.Background()
+	obj := &testObject{
+		id:    "id",
+		names: []string{"foo", "bar"},
+	}
+	nodes[0].cache.Set(ctx, obj)
+	_, ok := nodes[1].cache.Get(ctx, testIndexID, "id")
+	require.True(t, ok, "warm up local cache of the second node")
+
+	err := nodes[0].cache.Truncate(ctx)
+	require.NoError(t, err)
+
+	_, ok = nodes[0].shared.Get(ctx, testIndexID, "id")
+	assert.False(t, ok)
+	assert.Eventually(t, func() bool {
+		_, ok := nodes[1].local.Get(ctx, testIndexID, "id")
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+}
+
+type payloadLimitBroadcaster struct {
+	limit     int
+	published [][]byte
+}
+
+func (b *payloadLimitBroadcaster) Publish(_ context.Context, payload []byte) error {
+	if len(payload) > b.limit {
+		return ErrPayloadTooLarge
+	}
+	b.published = append(b.published, payload)
+	return nil
+}
+
+func (b *payloadLimitBroadcaster) Listen(ctx context.Context, subscribed func(ctx context.Context), _ func(ctx context.Context, payload []byte)) error {
+	subscribed(ctx)
+	<-ctx.Done()
+	return nil
+}
+
+func TestConnector_publish_payloadTooLarge(t *testing.T) {
+	broadcaster := &payloadLimitBroadcaster{limit: 100}
+	connector := NewConnector(Config{Enabled: true}, broadcaster)
+
+	err := connector.publish(context.Background(), &message{
+		Purpose:   cache.PurposeOrganization,
+		Operation: operationInvalidate,
+		Keys:      []string{"a-very-long-key-which-does-not-fit", "another-very-long-key-which-does-not-fit"},
+	})
+	require.NoError(t, err)
+	require.Len(t, broadcaster.published, 1)
+	assert.JSONEq(t, `{"node":"`+connector.nodeID+`","purpose":3,"op":3}`, string(broadcaster.published[0]))
+}
+
+func TestConnector_handle(t *testing.T) {
+	connector := NewConnector(Config{Enabled: true}, &payloadLimitBroadcaster{})
+	var received []*message
+	connector.handlers[cache.PurposeOrganization] = append(connector.handlers[cache.PurposeOrganization], func(_ context.Context, msg *message) {
+		received = append(received, msg)
+	})
+
+	ctx := context.Background()
+	connector.handle(ctx, []byte(`{"node":"`+connector.nodeID+`","purpose":3,"op":3}`))
+	assert.Empty(t, received, "own messages are ignored")
+	connector.handle(ctx, []byte(`{"node":"other","purpose":1,"op":3}`))
+	assert.Empty(t, received, "other purposes are ignored")
+	connector.handle(ctx, []byte(`invalid`))
+	assert.Empty(t, received)
+	connector.handle(ctx, []byte(`{"node":"other","purpose":3,"op":1,"index":1,"keys":["foo"]}`))
+	assert.Equal(t, []*message{{
+		Node:      "other",
+		Purpose:   cache.PurposeOrganization,
+		Operation: operationInvalidate,
+		Index:     1,
+		Keys:      []string{"foo"},
+	}}, received)
+}
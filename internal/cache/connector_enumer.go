@@ -7,11 +7,11 @@ import (
 	"strings"
 )
 
-const _ConnectorName = "memorypostgresredis"
+const _ConnectorName = "memorypostgresredistiered"
 
-var _ConnectorIndex = [...]uint8{0, 0, 6, 14, 19}
+var _ConnectorIndex = [...]uint8{0, 0, 6, 14, 19, 25}
 
-const _ConnectorLowerName = "memorypostgresredis"
+const _ConnectorLowerName = "memorypostgresredistiered"
 
 func (i Connector) String() string {
 	if i < 0 || i >= Connector(len(_ConnectorIndex)-1) {
@@ -28,9 +28,10 @@ func _ConnectorNoOp() {
 	_ = x[ConnectorMemory-(1)]
 	_ = x[ConnectorPostgres-(2)]
 	_ = x[ConnectorRedis-(3)]
+	_ = x[ConnectorTiered-(4)]
 }
 
-var _ConnectorValues = []Connector{ConnectorUnspecified, ConnectorMemory, ConnectorPostgres, ConnectorRedis}
+var _ConnectorValues = []Connector{ConnectorUnspecified, ConnectorMemory, ConnectorPostgres, ConnectorRedis, ConnectorTiered}
 
 var _ConnectorNameToValueMap = map[string]Connector{
 	_ConnectorName[0:0]:        ConnectorUnspecified,
@@ -41,6 +42,8 @@ var _ConnectorNameToValueMap = map[string]Connector{
 	_ConnectorLowerName[6:14]:  ConnectorPostgres,
 	_ConnectorName[14:19]:      ConnectorRedis,
 	_ConnectorLowerName[14:19]: ConnectorRedis,
+	_ConnectorName[19:25]:      ConnectorTiered,
+	_ConnectorLowerName[19:25]: ConnectorTiered,
 }
 
 var _ConnectorNames = []string{
@@ -48,6 +51,7 @@ var _ConnectorNames = []string{
 	_ConnectorName[0:6],
 	_ConnectorName[6:14],
 	_ConnectorName[14:19],
+	_ConnectorName[19:25],
 }
 
 // ConnectorString retrieves an enum value from the enum constants string name.
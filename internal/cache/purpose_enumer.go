@@ -7,11 +7,11 @@ import (
 	"strings"
 )
 
-const _PurposeName = "unspecifiedauthz_instancemilestonesorganizationid_p_form_callbackfederated_logout"
+const _PurposeName = "unspecifiedauthz_instancemilestonesorganizationid_p_form_callbackfederated_logoutintrospection_clientoidc_user_infoweb_key_set"
 
-var _PurposeIndex = [...]uint8{0, 11, 25, 35, 47, 65, 81}
+var _PurposeIndex = [...]uint8{0, 11, 25, 35, 47, 65, 81, 101, 115, 126}
 
-const _PurposeLowerName = "unspecifiedauthz_instancemilestonesorganizationid_p_form_callbackfederated_logout"
+const _PurposeLowerName = "unspecifiedauthz_instancemilestonesorganizationid_p_form_callbackfederated_logoutintrospection_clientoidc_user_infoweb_key_set"
 
 func (i Purpose) String() string {
 	if i < 0 || i >= Purpose(len(_PurposeIndex)-1) {
@@ -30,23 +30,32 @@ func _PurposeNoOp() {
 	_ = x[PurposeOrganization-(3)]
 	_ = x[PurposeIdPFormCallback-(4)]
 	_ = x[PurposeFederatedLogout-(5)]
+	_ = x[PurposeIntrospectionClient-(6)]
+	_ = x[PurposeOIDCUserInfo-(7)]
+	_ = x[PurposeWebKeySet-(8)]
 }
 
-var _PurposeValues = []Purpose{PurposeUnspecified, PurposeAuthzInstance, PurposeMilestones, PurposeOrganization, PurposeIdPFormCallback, PurposeFederatedLogout}
+var _PurposeValues = []Purpose{PurposeUnspecified, PurposeAuthzInstance, PurposeMilestones, PurposeOrganization, PurposeIdPFormCallback, PurposeFederatedLogout, PurposeIntrospectionClient, PurposeOIDCUserInfo, PurposeWebKeySet}
 
 var _PurposeNameToValueMap = map[string]Purpose{
-	_PurposeName[0:11]:       PurposeUnspecified,
-	_PurposeLowerName[0:11]:  PurposeUnspecified,
-	_PurposeName[11:25]:      PurposeAuthzInstance,
-	_PurposeLowerName[11:25]: PurposeAuthzInstance,
-	_PurposeName[25:35]:      PurposeMilestones,
-	_PurposeLowerName[25:35]: PurposeMilestones,
-	_PurposeName[35:47]:      PurposeOrganization,
-	_PurposeLowerName[35:47]: PurposeOrganization,
-	_PurposeName[47:65]:      PurposeIdPFormCallback,
-	_PurposeLowerName[47:65]: PurposeIdPFormCallback,
-	_PurposeName[65:81]:      PurposeFederatedLogout,
-	_PurposeLowerName[65:81]: PurposeFederatedLogout,
+	_PurposeName[0:11]:         PurposeUnspecified,
+	_PurposeLowerName[0:11]:    PurposeUnspecified,
+	_PurposeName[11:25]:        PurposeAuthzInstance,
+	_PurposeLowerName[11:25]:   PurposeAuthzInstance,
+	_PurposeName[25:35]:        PurposeMilestones,
+	_PurposeLowerName[25:35]:   PurposeMilestones,
+	_PurposeName[35:47]:        PurposeOrganization,
+	_PurposeLowerName[35:47]:   PurposeOrganization,
+	_PurposeName[47:65]:        PurposeIdPFormCallback,
+	_PurposeLowerName[47:65]:   PurposeIdPFormCallback,
+	_PurposeName[65:81]:        PurposeFederatedLogout,
+	_PurposeLowerName[65:81]:   PurposeFederatedLogout,
+	_PurposeName[81:101]:       PurposeIntrospectionClient,
+	_PurposeLowerName[81:101]:  PurposeIntrospectionClient,
+	_PurposeName[101:115]:      PurposeOIDCUserInfo,
+	_PurposeLowerName[101:115]: PurposeOIDCUserInfo,
+	_PurposeName[115:126]:      PurposeWebKeySet,
+	_PurposeLowerName[115:126]: PurposeWebKeySet,
 }
 
 var _PurposeNames = []string{
@@ -56,6 +65,9 @@ var _PurposeNames = []string{
 	_PurposeName[35:47],
 	_PurposeName[47:65],
 	_PurposeName[65:81],
+	_PurposeName[81:101],
+	_PurposeName[101:115],
+	_PurposeName[115:126],
 }
 
 // PurposeString retrieves an enum value from the enum constants string name.
@@ -6,6 +6,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/authrequest"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -30,6 +31,8 @@ type AuthRequest struct {
 	HintUserID       *string
 	NeedRefreshToken bool
 	Issuer           string
+
+	AuthorizationDetails domain.AuthorizationDetails
 }
 
 type CurrentAuthRequest struct {
@@ -43,6 +46,20 @@ type CurrentAuthRequest struct {
 const IDPrefixV2 = "V2_"
 
 func (c *Commands) AddAuthRequest(ctx context.Context, authRequest *AuthRequest) (_ *CurrentAuthRequest, err error) {
+	return c.addAuthRequest(ctx, authRequest, time.Time{})
+}
+
+// AddPushedAuthRequest stores a pushed authorization request (RFC 9126) as auth request.
+// The request can be referenced once by its ID (the request_uri) until the expiration,
+// see [Commands.UsePushedAuthRequest].
+func (c *Commands) AddPushedAuthRequest(ctx context.Context, authRequest *AuthRequest, expiration time.Time) (_ *CurrentAuthRequest, err error) {
+	if expiration.IsZero() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ohp5e", "Errors.Invalid.Argument")
+	}
+	return c.addAuthRequest(ctx, authRequest, expiration)
+}
+
+func (c *Commands) addAuthRequest(ctx context.Context, authRequest *AuthRequest, pushedExpiration time.Time) (_ *CurrentAuthRequest, err error) {
 	authRequestID, err := c.idGenerator.Next()
 	if err != nil {
 		return nil, err
@@ -55,9 +72,10 @@ func (c *Commands) AddAuthRequest(ctx context.Context, authRequest *AuthRequest)
 	if writeModel.AuthRequestState != domain.AuthRequestStateUnspecified {
 		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Sf3gt", "Errors.AuthRequest.AlreadyExisting")
 	}
-	err = c.pushAppendAndReduce(ctx, writeModel, authrequest.NewAddedEvent(
+	aggregate := &authrequest.NewAggregate(authRequest.ID, authz.GetInstance(ctx).InstanceID()).Aggregate
+	events := []eventstore.Command{authrequest.NewAddedEvent(
 		ctx,
-		&authrequest.NewAggregate(authRequest.ID, authz.GetInstance(ctx).InstanceID()).Aggregate,
+		aggregate,
 		authRequest.LoginClient,
 		authRequest.ClientID,
 		authRequest.RedirectURI,
@@ -75,19 +93,92 @@ func (c *Commands) AddAuthRequest(ctx context.Context, authRequest *AuthRequest)
 		authRequest.HintUserID,
 		authRequest.NeedRefreshToken,
 		authRequest.Issuer,
-	))
+		authRequest.AuthorizationDetails,
+	)}
+	if !pushedExpiration.IsZero() {
+		events = append(events, authrequest.NewPushedEvent(ctx, aggregate, pushedExpiration))
+	}
+	if err = c.pushAppendAndReduce(ctx, writeModel, events...); err != nil {
+		return nil, err
+	}
+	return authRequestWriteModelToCurrentAuthRequest(writeModel), nil
+}
+
+// PushedAuthRequestLogin holds the parameters resolved at the authorization endpoint
+// to continue a pushed authorization request as auth request of the login v2.
+type PushedAuthRequestLogin struct {
+	LoginClient      string
+	Scope            []string
+	Audience         []string
+	NeedRefreshToken bool
+}
+
+// GetPushedAuthRequest returns the pushed authorization request referenced by the request_uri.
+// An error is returned if the request does not exist, was already used, is expired or belongs to another client.
+func (c *Commands) GetPushedAuthRequest(ctx context.Context, id, clientID string) (_ *CurrentAuthRequest, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel, err := c.getPushedAuthRequestWriteModel(ctx, id, clientID)
 	if err != nil {
 		return nil, err
 	}
 	return authRequestWriteModelToCurrentAuthRequest(writeModel), nil
 }
 
+// UsePushedAuthRequest returns the pushed authorization request referenced by the request_uri
+// and ensures it can't be used again.
+// If login is set, the pushed authorization request is continued as auth request of the login v2,
+// otherwise it's only a template for the auth request of the login v1.
+// An error is returned if the request does not exist, was already used, is expired or belongs to another client.
+func (c *Commands) UsePushedAuthRequest(ctx context.Context, id, clientID string, login *PushedAuthRequestLogin) (_ *CurrentAuthRequest, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel, err := c.getPushedAuthRequestWriteModel(ctx, id, clientID)
+	if err != nil {
+		return nil, err
+	}
+	used := authrequest.NewRequestURIUsedEvent(ctx, writeModel.aggregate)
+	if login != nil {
+		used = authrequest.NewRequestURIUsedLoginV2Event(ctx, writeModel.aggregate,
+			login.LoginClient,
+			login.Scope,
+			login.Audience,
+			login.NeedRefreshToken,
+		)
+	}
+	// the unique constraint of the event prevents concurrent requests from using the request_uri as well
+	if err = c.pushAppendAndReduce(ctx, writeModel, used); err != nil {
+		return nil, err
+	}
+	return authRequestWriteModelToCurrentAuthRequest(writeModel), nil
+}
+
+func (c *Commands) getPushedAuthRequestWriteModel(ctx context.Context, id, clientID string) (*AuthRequestWriteModel, error) {
+	writeModel, err := c.getAuthRequestWriteModel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.AuthRequestState != domain.AuthRequestStateAdded ||
+		writeModel.PushedExpiration.IsZero() ||
+		writeModel.RequestURIUsed ||
+		writeModel.ClientID != clientID {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Iev5u", "Errors.AuthRequest.RequestURIInvalid")
+	}
+	if time.Now().After(writeModel.PushedExpiration) {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-eeL4o", "Errors.AuthRequest.RequestURIInvalid")
+	}
+	return writeModel, nil
+}
+
 func (c *Commands) LinkSessionToAuthRequest(ctx context.Context, id, sessionID, sessionToken string, checkLoginClient bool, projectPermissionCheck domain.ProjectPermissionCheck) (*domain.ObjectDetails, *CurrentAuthRequest, error) {
 	writeModel, err := c.getAuthRequestWriteModel(ctx, id)
 	if err != nil {
 		return nil, nil, err
 	}
-	if writeModel.AuthRequestState == domain.AuthRequestStateUnspecified {
+	// pushed auth requests only hold the parameters until they are used at the authorization endpoint
+	if writeModel.AuthRequestState == domain.AuthRequestStateUnspecified || !writeModel.PushedExpiration.IsZero() {
 		return nil, nil, zerrors.ThrowNotFound(nil, "COMMAND-jae5P", "Errors.AuthRequest.NotExisting")
 	}
 	if writeModel.AuthRequestState != domain.AuthRequestStateAdded {
@@ -183,6 +274,8 @@ func authRequestWriteModelToCurrentAuthRequest(writeModel *AuthRequestWriteModel
 			LoginHint:     writeModel.LoginHint,
 			HintUserID:    writeModel.HintUserID,
 			Issuer:        writeModel.Issuer,
+
+			AuthorizationDetails: writeModel.AuthorizationDetails,
 		},
 		SessionID:   writeModel.SessionID,
 		UserID:      writeModel.UserID,
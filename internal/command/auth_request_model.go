@@ -37,6 +37,10 @@ type AuthRequestWriteModel struct {
 	AuthRequestState domain.AuthRequestState
 	NeedRefreshToken bool
 	Issuer           string
+	PushedExpiration time.Time
+	RequestURIUsed   bool
+
+	AuthorizationDetails domain.AuthorizationDetails
 }
 
 func NewAuthRequestWriteModel(ctx context.Context, id string) *AuthRequestWriteModel {
@@ -70,6 +74,7 @@ func (m *AuthRequestWriteModel) Reduce() error {
 			m.AuthRequestState = domain.AuthRequestStateAdded
 			m.NeedRefreshToken = e.NeedRefreshToken
 			m.Issuer = e.Issuer
+			m.AuthorizationDetails = e.AuthorizationDetails
 		case *authrequest.SessionLinkedEvent:
 			m.SessionID = e.SessionID
 			m.UserID = e.UserID
@@ -83,6 +88,19 @@ func (m *AuthRequestWriteModel) Reduce() error {
 			m.AuthRequestState = domain.AuthRequestStateCodeExchanged
 		case *authrequest.SucceededEvent:
 			m.AuthRequestState = domain.AuthRequestStateSucceeded
+		case *authrequest.PushedEvent:
+			m.PushedExpiration = e.Expiration
+		case *authrequest.RequestURIUsedEvent:
+			m.RequestURIUsed = true
+			if !e.LoginV2 {
+				continue
+			}
+			// the pushed auth request is continued as auth request of the login
+			m.LoginClient = e.LoginClient
+			m.Scope = e.Scope
+			m.Audience = e.Audience
+			m.NeedRefreshToken = e.NeedRefreshToken
+			m.PushedExpiration = time.Time{}
 		}
 	}
 
@@ -63,6 +63,7 @@ func TestCommands_AddAuthRequest(t *testing.T) {
 								nil,
 								false,
 								"issuer",
+								nil,
 							),
 						),
 					),
@@ -103,6 +104,7 @@ func TestCommands_AddAuthRequest(t *testing.T) {
 							gu.Ptr("hintUserID"),
 							false,
 							"issuer",
+							nil,
 						),
 					),
 				),
@@ -172,6 +174,367 @@ func TestCommands_AddAuthRequest(t *testing.T) {
 	}
 }
 
+func TestCommands_AddPushedAuthRequest(t *testing.T) {
+	mockCtx := authz.NewMockContext("instanceID", "orgID", "loginClient")
+	expiration := time.Now().Add(time.Minute)
+	type fields struct {
+		eventstore  func(*testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx        context.Context
+		request    *AuthRequest
+		expiration time.Time
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *CurrentAuthRequest
+		wantErr error
+	}{
+		{
+			"missing expiration error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx:     mockCtx,
+				request: &AuthRequest{},
+			},
+			nil,
+			zerrors.ThrowInvalidArgument(nil, "COMMAND-Ohp5e", "Errors.Invalid.Argument"),
+		},
+		{
+			"pushed",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						authrequest.NewAddedEvent(mockCtx, &authrequest.NewAggregate("V2_id", "instanceID").Aggregate,
+							"",
+							"clientID",
+							"redirectURI",
+							"state",
+							"nonce",
+							[]string{"openid"},
+							nil,
+							domain.OIDCResponseTypeCode,
+							domain.OIDCResponseModeQuery,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							gu.Ptr("hintUserID"),
+							false,
+							"issuer",
+							nil,
+						),
+						authrequest.NewPushedEvent(mockCtx, &authrequest.NewAggregate("V2_id", "instanceID").Aggregate,
+							expiration,
+						),
+					),
+				),
+				idGenerator: mock.NewIDGeneratorExpectIDs(t, "id"),
+			},
+			args{
+				ctx: mockCtx,
+				request: &AuthRequest{
+					ClientID:     "clientID",
+					RedirectURI:  "redirectURI",
+					State:        "state",
+					Nonce:        "nonce",
+					Scope:        []string{"openid"},
+					ResponseType: domain.OIDCResponseTypeCode,
+					ResponseMode: domain.OIDCResponseModeQuery,
+					HintUserID:   gu.Ptr("hintUserID"),
+					Issuer:       "issuer",
+				},
+				expiration: expiration,
+			},
+			&CurrentAuthRequest{
+				AuthRequest: &AuthRequest{
+					ID:           "V2_id",
+					ClientID:     "clientID",
+					RedirectURI:  "redirectURI",
+					State:        "state",
+					Nonce:        "nonce",
+					Scope:        []string{"openid"},
+					ResponseType: domain.OIDCResponseTypeCode,
+					ResponseMode: domain.OIDCResponseModeQuery,
+					HintUserID:   gu.Ptr("hintUserID"),
+					Issuer:       "issuer",
+				},
+			},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore(t),
+				idGenerator: tt.fields.idGenerator,
+			}
+			got, err := c.AddPushedAuthRequest(tt.args.ctx, tt.args.request, tt.args.expiration)
+			require.ErrorIs(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCommands_UsePushedAuthRequest(t *testing.T) {
+	mockCtx := authz.NewMockContext("instanceID", "orgID", "loginClient")
+	addedEvent := func() eventstore.Event {
+		return eventFromEventPusher(
+			authrequest.NewAddedEvent(mockCtx, &authrequest.NewAggregate("V2_id", "instanceID").Aggregate,
+				"",
+				"clientID",
+				"redirectURI",
+				"state",
+				"nonce",
+				[]string{"openid"},
+				nil,
+				domain.OIDCResponseTypeCode,
+				domain.OIDCResponseModeQuery,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				false,
+				"issuer",
+				nil,
+			),
+		)
+	}
+	pushedEvent := func(expiration time.Time) eventstore.Event {
+		return eventFromEventPusher(
+			authrequest.NewPushedEvent(mockCtx, &authrequest.NewAggregate("V2_id", "instanceID").Aggregate,
+				expiration,
+			),
+		)
+	}
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx      context.Context
+		id       string
+		clientID string
+		login    *PushedAuthRequestLogin
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *CurrentAuthRequest
+		wantErr error
+	}{
+		{
+			"not existing error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:      mockCtx,
+				id:       "V2_id",
+				clientID: "clientID",
+			},
+			nil,
+			zerrors.ThrowInvalidArgument(nil, "COMMAND-Iev5u", "Errors.AuthRequest.RequestURIInvalid"),
+		},
+		{
+			"not pushed error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						addedEvent(),
+					),
+				),
+			},
+			args{
+				ctx:      mockCtx,
+				id:       "V2_id",
+				clientID: "clientID",
+			},
+			nil,
+			zerrors.ThrowInvalidArgument(nil, "COMMAND-Iev5u", "Errors.AuthRequest.RequestURIInvalid"),
+		},
+		{
+			"already used error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						addedEvent(),
+						pushedEvent(time.Now().Add(time.Minute)),
+						eventFromEventPusher(
+							authrequest.NewRequestURIUsedEvent(mockCtx, &authrequest.NewAggregate("V2_id", "instanceID").Aggregate),
+						),
+					),
+				),
+			},
+			args{
+				ctx:      mockCtx,
+				id:       "V2_id",
+				clientID: "clientID",
+			},
+			nil,
+			zerrors.ThrowInvalidArgument(nil, "COMMAND-Iev5u", "Errors.AuthRequest.RequestURIInvalid"),
+		},
+		{
+			"other client error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						addedEvent(),
+						pushedEvent(time.Now().Add(time.Minute)),
+					),
+				),
+			},
+			args{
+				ctx:      mockCtx,
+				id:       "V2_id",
+				clientID: "otherClientID",
+			},
+			nil,
+			zerrors.ThrowInvalidArgument(nil, "COMMAND-Iev5u", "Errors.AuthRequest.RequestURIInvalid"),
+		},
+		{
+			"expired error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						addedEvent(),
+						pushedEvent(time.Now().Add(-time.Minute)),
+					),
+				),
+			},
+			args{
+				ctx:      mockCtx,
+				id:       "V2_id",
+				clientID: "clientID",
+			},
+			nil,
+			zerrors.ThrowInvalidArgument(nil, "COMMAND-eeL4o", "Errors.AuthRequest.RequestURIInvalid"),
+		},
+		{
+			"used",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						addedEvent(),
+						pushedEvent(time.Now().Add(time.Minute)),
+					),
+					expectPush(
+						authrequest.NewRequestURIUsedEvent(mockCtx, &authrequest.NewAggregate("V2_id", "instanceID").Aggregate),
+					),
+				),
+			},
+			args{
+				ctx:      mockCtx,
+				id:       "V2_id",
+				clientID: "clientID",
+			},
+			&CurrentAuthRequest{
+				AuthRequest: &AuthRequest{
+					ID:           "V2_id",
+					ClientID:     "clientID",
+					RedirectURI:  "redirectURI",
+					State:        "state",
+					Nonce:        "nonce",
+					Scope:        []string{"openid"},
+					ResponseType: domain.OIDCResponseTypeCode,
+					ResponseMode: domain.OIDCResponseModeQuery,
+					Issuer:       "issuer",
+				},
+			},
+			nil,
+		},
+		{
+			"used concurrently error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						addedEvent(),
+						pushedEvent(time.Now().Add(time.Minute)),
+					),
+					expectPushFailed(
+						zerrors.ThrowAlreadyExists(nil, "V3-DKcAh", "Errors.AuthRequest.RequestURIInvalid"),
+						authrequest.NewRequestURIUsedEvent(mockCtx, &authrequest.NewAggregate("V2_id", "instanceID").Aggregate),
+					),
+				),
+			},
+			args{
+				ctx:      mockCtx,
+				id:       "V2_id",
+				clientID: "clientID",
+			},
+			nil,
+			zerrors.ThrowAlreadyExists(nil, "V3-DKcAh", "Errors.AuthRequest.RequestURIInvalid"),
+		},
+		{
+			"used for login v2",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						addedEvent(),
+						pushedEvent(time.Now().Add(time.Minute)),
+					),
+					expectPush(
+						authrequest.NewRequestURIUsedLoginV2Event(mockCtx, &authrequest.NewAggregate("V2_id", "instanceID").Aggregate,
+							"loginClient",
+							[]string{"openid", "offline_access"},
+							[]string{"audience"},
+							true,
+						),
+					),
+				),
+			},
+			args{
+				ctx:      mockCtx,
+				id:       "V2_id",
+				clientID: "clientID",
+				login: &PushedAuthRequestLogin{
+					LoginClient:      "loginClient",
+					Scope:            []string{"openid", "offline_access"},
+					Audience:         []string{"audience"},
+					NeedRefreshToken: true,
+				},
+			},
+			&CurrentAuthRequest{
+				AuthRequest: &AuthRequest{
+					ID:           "V2_id",
+					LoginClient:  "loginClient",
+					ClientID:     "clientID",
+					RedirectURI:  "redirectURI",
+					State:        "state",
+					Nonce:        "nonce",
+					Scope:        []string{"openid", "offline_access"},
+					Audience:     []string{"audience"},
+					ResponseType: domain.OIDCResponseTypeCode,
+					ResponseMode: domain.OIDCResponseModeQuery,
+					Issuer:       "issuer",
+				},
+			},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.UsePushedAuthRequest(tt.args.ctx, tt.args.id, tt.args.clientID, tt.args.login)
+			require.ErrorIs(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 	mockCtx := authz.NewMockContext("instanceID", "orgID", "loginClient")
 	type fields struct {
@@ -239,6 +602,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								true,
 								"issuer",
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -282,6 +646,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								true,
 								"issuer",
+								nil,
 							),
 						),
 					),
@@ -324,6 +689,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								true,
 								"issuer",
+								nil,
 							),
 						),
 					),
@@ -364,6 +730,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								true,
 								"issuer",
+								nil,
 							),
 						),
 					),
@@ -427,6 +794,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								true,
 								"issuer",
+								nil,
 							),
 						),
 					),
@@ -479,6 +847,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								true,
 								"issuer",
+								nil,
 							),
 						),
 					),
@@ -569,6 +938,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								true,
 								"issuer",
+								nil,
 							),
 						),
 					),
@@ -660,6 +1030,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								true,
 								"issuer",
+								nil,
 							),
 						),
 					),
@@ -752,6 +1123,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								true,
 								"issuer",
+								nil,
 							),
 						),
 					),
@@ -845,6 +1217,7 @@ func TestCommands_LinkSessionToAuthRequest(t *testing.T) {
 								nil,
 								true,
 								"issuer",
+								nil,
 							),
 						),
 					),
@@ -969,6 +1342,7 @@ func TestCommands_FailAuthRequest(t *testing.T) {
 								nil,
 								true,
 								"issuer",
+								nil,
 							),
 						),
 					),
@@ -1071,6 +1445,7 @@ func TestCommands_AddAuthRequestCode(t *testing.T) {
 								gu.Ptr("hintUserID"),
 								true,
 								"issuer",
+								nil,
 							),
 						),
 					),
@@ -1110,6 +1485,7 @@ func TestCommands_AddAuthRequestCode(t *testing.T) {
 								gu.Ptr("hintUserID"),
 								true,
 								"issuer",
+								nil,
 							),
 						),
 						eventFromEventPusher(
@@ -0,0 +1,331 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/backchannelauth"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// BackchannelAuthRequest is a client initiated backchannel authentication (CIBA) request,
+// which has to be approved by the user on a separate device.
+type BackchannelAuthRequest struct {
+	ID               string
+	ClientID         string
+	UserID           string
+	UserOrgID        string
+	Scopes           []string
+	Audience         []string
+	BindingMessage   string
+	Expires          time.Time
+	NeedRefreshToken bool
+
+	DeliveryMode            domain.OIDCBackchannelTokenDeliveryMode
+	NotificationEndpoint    string
+	ClientNotificationToken string
+}
+
+func (c *Commands) AddBackchannelAuth(ctx context.Context, request *BackchannelAuthRequest) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if request.UserID == "" || request.ClientID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ahf3u", "Errors.BackchannelAuth.Invalid")
+	}
+	if request.DeliveryMode == domain.OIDCBackchannelTokenDeliveryModePing && request.ClientNotificationToken == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ieb4o", "Errors.BackchannelAuth.NotificationTokenMissing")
+	}
+	request.ID, err = c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	var notificationToken *crypto.CryptoValue
+	if request.ClientNotificationToken != "" {
+		notificationToken, err = crypto.Encrypt([]byte(request.ClientNotificationToken), c.keyAlgorithm)
+		if err != nil {
+			return nil, err
+		}
+	}
+	model := NewBackchannelAuthWriteModel(request.ID, authz.GetInstance(ctx).InstanceID())
+	pushedEvents, err := c.eventstore.Push(ctx, backchannelauth.NewAddedEvent(
+		ctx,
+		model.aggregate,
+		request.ClientID,
+		request.UserID,
+		request.UserOrgID,
+		request.Scopes,
+		request.Audience,
+		request.BindingMessage,
+		request.Expires,
+		request.NeedRefreshToken,
+		request.DeliveryMode,
+		request.NotificationEndpoint,
+		notificationToken,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(model, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&model.WriteModel), nil
+}
+
+// ApproveBackchannelAuthWithSession approves the backchannel authentication request
+// with the session of the user the request was initiated for.
+func (c *Commands) ApproveBackchannelAuthWithSession(ctx context.Context, id, sessionID, sessionToken string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	model, sessionWriteModel, err := c.backchannelAuthWithSession(ctx, id, sessionID, sessionToken)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, backchannelauth.NewApprovedEvent(
+		ctx,
+		model.aggregate,
+		sessionWriteModel.AuthMethodTypes(),
+		sessionWriteModel.AuthenticationTime(),
+		sessionWriteModel.PreferredLanguage,
+		sessionWriteModel.UserAgent,
+		sessionID,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(model, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&model.WriteModel), nil
+}
+
+// DenyBackchannelAuthWithSession denies the backchannel authentication request
+// with the session of the user the request was initiated for.
+func (c *Commands) DenyBackchannelAuthWithSession(ctx context.Context, id, sessionID, sessionToken string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	model, _, err := c.backchannelAuthWithSession(ctx, id, sessionID, sessionToken)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, backchannelauth.NewCanceledEvent(ctx, model.aggregate, domain.BackchannelAuthCanceledDenied))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(model, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&model.WriteModel), nil
+}
+
+func (c *Commands) backchannelAuthWithSession(ctx context.Context, id, sessionID, sessionToken string) (*BackchannelAuthWriteModel, *SessionWriteModel, error) {
+	model, err := c.getBackchannelAuthWriteModelByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !model.State.Exists() {
+		return nil, nil, zerrors.ThrowNotFound(nil, "COMMAND-eeJ3u", "Errors.BackchannelAuth.NotFound")
+	}
+	if model.State != domain.BackchannelAuthStateInitiated || model.Expires.Before(time.Now()) {
+		return nil, nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ohn5a", "Errors.BackchannelAuth.AlreadyHandled")
+	}
+	if err := c.checkPermission(ctx, domain.PermissionSessionLink, model.ResourceOwner, ""); err != nil {
+		return nil, nil, err
+	}
+
+	sessionWriteModel := NewSessionWriteModel(sessionID, authz.GetInstance(ctx).InstanceID())
+	err = c.eventstore.FilterToQueryReducer(ctx, sessionWriteModel)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = sessionWriteModel.CheckIsActive(); err != nil {
+		return nil, nil, err
+	}
+	if err := c.sessionTokenVerifier(ctx, sessionToken, sessionWriteModel.AggregateID, sessionWriteModel.TokenID); err != nil {
+		return nil, nil, err
+	}
+	if sessionWriteModel.UserID != model.UserID {
+		return nil, nil, zerrors.ThrowPermissionDenied(nil, "COMMAND-ieP3a", "Errors.BackchannelAuth.UserMismatch")
+	}
+	return model, sessionWriteModel, nil
+}
+
+func (c *Commands) CancelBackchannelAuth(ctx context.Context, id string, reason domain.BackchannelAuthCanceled) (*domain.ObjectDetails, error) {
+	model, err := c.getBackchannelAuthWriteModelByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !model.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Ae3ph", "Errors.BackchannelAuth.NotFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, backchannelauth.NewCanceledEvent(ctx, model.aggregate, reason))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(model, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&model.WriteModel), nil
+}
+
+func (c *Commands) getBackchannelAuthWriteModelByID(ctx context.Context, id string) (*BackchannelAuthWriteModel, error) {
+	model := &BackchannelAuthWriteModel{
+		WriteModel: eventstore.WriteModel{AggregateID: id},
+	}
+	err := c.eventstore.FilterToQueryReducer(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+	model.aggregate = backchannelauth.NewAggregate(model.AggregateID, model.InstanceID)
+	return model, nil
+}
+
+// NotifyBackchannelAuthClient informs clients using the ping mode,
+// that the backchannel authentication request was handled by the user
+// and the result can be retrieved from the token endpoint.
+// It's called by the notification worker, which retries failed notifications.
+// A [zerrors.IsNotFound] or [zerrors.IsPreconditionFailed] error means the client can't be notified at all.
+func (c *Commands) NotifyBackchannelAuthClient(ctx context.Context, id string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	model, err := c.getBackchannelAuthWriteModelByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !model.State.Exists() {
+		return zerrors.ThrowNotFound(nil, "COMMAND-Xah6i", "Errors.BackchannelAuth.NotFound")
+	}
+	if model.DeliveryMode != domain.OIDCBackchannelTokenDeliveryModePing || model.NotificationEndpoint == "" {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-ooG5e", "Errors.BackchannelAuth.NotificationDisabled")
+	}
+	token, err := crypto.DecryptString(model.ClientNotificationToken, c.keyAlgorithm)
+	if err != nil {
+		return zerrors.ThrowPreconditionFailed(err, "COMMAND-Uo5ai", "Errors.BackchannelAuth.NotificationTokenMissing")
+	}
+	body, err := json.Marshal(struct {
+		AuthReqID string `json:"auth_req_id"`
+	}{model.AggregateID})
+	if err != nil {
+		return zerrors.ThrowInternal(err, "COMMAND-aeR4o", "Errors.Internal")
+	}
+	if err = sendBackchannelAuthNotification(ctx, c.httpClient, model.NotificationEndpoint, token, body); err != nil {
+		return zerrors.ThrowInternal(err, "COMMAND-Ciu0e", "Errors.BackchannelAuth.NotificationFailed")
+	}
+	return nil
+}
+
+func sendBackchannelAuthNotification(ctx context.Context, client *http.Client, endpoint, token string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+type BackchannelAuthStateError domain.BackchannelAuthState
+
+func (e BackchannelAuthStateError) Error() string {
+	return fmt.Sprintf("backchannel auth state not approved: %s", domain.BackchannelAuthState(e).String())
+}
+
+// CreateOIDCSessionFromBackchannelAuth creates a new OIDC session if the backchannel authentication
+// was approved by the user.
+// A [BackchannelAuthStateError] is returned if the request was not approved,
+// containing a [domain.BackchannelAuthState] which can be used to inform the client about the state.
+//
+// Same as for the device authorization, an explicit state takes precedence over expiry.
+func (c *Commands) CreateOIDCSessionFromBackchannelAuth(ctx context.Context, id, clientID, backChannelLogoutURI, dpopJKT, certThumbprint string) (_ *OIDCSession, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	model, err := c.getBackchannelAuthWriteModelByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if model.State.Exists() && model.ClientID != clientID {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Quo8e", "Errors.BackchannelAuth.NotFound")
+	}
+
+	switch model.State {
+	case domain.BackchannelAuthStateApproved:
+		break
+	case domain.BackchannelAuthStateUndefined:
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-aiG8o", "Errors.BackchannelAuth.NotFound")
+
+	case domain.BackchannelAuthStateInitiated:
+		if model.Expires.Before(time.Now()) {
+			c.asyncPush(ctx, backchannelauth.NewCanceledEvent(ctx, model.aggregate, domain.BackchannelAuthCanceledExpired))
+			return nil, BackchannelAuthStateError(domain.BackchannelAuthStateExpired)
+		}
+		fallthrough
+	case domain.BackchannelAuthStateDenied, domain.BackchannelAuthStateExpired, domain.BackchannelAuthStateDone:
+		fallthrough
+	default:
+		return nil, BackchannelAuthStateError(model.State)
+	}
+
+	cmd, err := c.newOIDCSessionAddEvents(ctx, model.UserID, model.UserOrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd.AddSession(ctx,
+		model.UserID,
+		model.UserOrgID,
+		model.SessionID,
+		model.ClientID,
+		model.Audience,
+		model.Scopes,
+		model.UserAuthMethods,
+		model.AuthTime,
+		"",
+		model.PreferredLanguage,
+		model.UserAgent,
+		nil,
+	)
+	cmd.RegisterLogout(ctx, model.SessionID, model.UserID, model.ClientID, backChannelLogoutURI)
+	if err = cmd.AddAccessToken(ctx, model.Scopes, model.UserID, model.UserOrgID, domain.TokenReasonAuthRequest, nil, dpopJKT, certThumbprint, nil); err != nil {
+		return nil, err
+	}
+
+	if model.NeedRefreshToken {
+		if err = cmd.AddRefreshToken(ctx, model.UserID, dpopJKT); err != nil {
+			return nil, err
+		}
+	}
+	cmd.BackchannelAuthRequestDone(ctx, model.aggregate)
+	return cmd.PushEvents(ctx)
+}
+
+func (cmd *OIDCSessionEvents) BackchannelAuthRequestDone(ctx context.Context, backchannelAuthAggregate *eventstore.Aggregate) {
+	cmd.events = append(cmd.events, backchannelauth.NewDoneEvent(ctx, backchannelAuthAggregate))
+}
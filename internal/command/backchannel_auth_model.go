@@ -0,0 +1,94 @@
+package command
+
+import (
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/backchannelauth"
+)
+
+type BackchannelAuthWriteModel struct {
+	eventstore.WriteModel
+	aggregate *eventstore.Aggregate
+
+	ClientID          string
+	UserID            string
+	UserOrgID         string
+	Scopes            []string
+	Audience          []string
+	BindingMessage    string
+	Expires           time.Time
+	NeedRefreshToken  bool
+	State             domain.BackchannelAuthState
+	UserAuthMethods   []domain.UserAuthMethodType
+	AuthTime          time.Time
+	PreferredLanguage *language.Tag
+	UserAgent         *domain.UserAgent
+	SessionID         string
+
+	DeliveryMode            domain.OIDCBackchannelTokenDeliveryMode
+	NotificationEndpoint    string
+	ClientNotificationToken *crypto.CryptoValue
+}
+
+func NewBackchannelAuthWriteModel(id, resourceOwner string) *BackchannelAuthWriteModel {
+	return &BackchannelAuthWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   id,
+			ResourceOwner: resourceOwner,
+		},
+		aggregate: backchannelauth.NewAggregate(id, resourceOwner),
+	}
+}
+
+func (m *BackchannelAuthWriteModel) Reduce() error {
+	for _, event := range m.Events {
+		switch e := event.(type) {
+		case *backchannelauth.AddedEvent:
+			m.ClientID = e.ClientID
+			m.UserID = e.UserID
+			m.UserOrgID = e.UserOrgID
+			m.Scopes = e.Scopes
+			m.Audience = e.Audience
+			m.BindingMessage = e.BindingMessage
+			m.Expires = e.Expires
+			m.NeedRefreshToken = e.NeedRefreshToken
+			m.State = e.State
+			m.DeliveryMode = e.DeliveryMode
+			m.NotificationEndpoint = e.NotificationEndpoint
+			m.ClientNotificationToken = e.ClientNotificationToken
+		case *backchannelauth.ApprovedEvent:
+			m.State = domain.BackchannelAuthStateApproved
+			m.UserAuthMethods = e.UserAuthMethods
+			m.AuthTime = e.AuthTime
+			m.PreferredLanguage = e.PreferredLanguage
+			m.UserAgent = e.UserAgent
+			m.SessionID = e.SessionID
+		case *backchannelauth.CanceledEvent:
+			m.State = e.Reason.State()
+		case *backchannelauth.DoneEvent:
+			m.State = domain.BackchannelAuthStateDone
+		}
+	}
+
+	return m.WriteModel.Reduce()
+}
+
+func (m *BackchannelAuthWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(m.ResourceOwner).
+		AddQuery().
+		AggregateTypes(backchannelauth.AggregateType).
+		AggregateIDs(m.AggregateID).
+		EventTypes(
+			backchannelauth.AddedEventType,
+			backchannelauth.ApprovedEventType,
+			backchannelauth.CanceledEventType,
+			backchannelauth.DoneEventType,
+		).
+		Builder()
+}
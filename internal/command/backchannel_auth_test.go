@@ -0,0 +1,475 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/muhlemmer/gu"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/backchannelauth"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommands_AddBackchannelAuth(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	pushErr := errors.New("pushErr")
+	now := time.Now()
+
+	type fields struct {
+		eventstore  func(*testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		request     *BackchannelAuthRequest
+		wantID      string
+		wantDetails *domain.ObjectDetails
+		wantErr     error
+	}{
+		{
+			name: "missing user, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			request: &BackchannelAuthRequest{
+				ClientID: "clientID",
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "COMMAND-Ahf3u", "Errors.BackchannelAuth.Invalid"),
+		},
+		{
+			name: "ping without notification token, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			request: &BackchannelAuthRequest{
+				ClientID:     "clientID",
+				UserID:       "userID",
+				DeliveryMode: domain.OIDCBackchannelTokenDeliveryModePing,
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "COMMAND-Ieb4o", "Errors.BackchannelAuth.NotificationTokenMissing"),
+		},
+		{
+			name: "push error",
+			fields: fields{
+				eventstore: expectEventstore(expectPushFailed(pushErr,
+					backchannelauth.NewAddedEvent(
+						ctx,
+						backchannelauth.NewAggregate("id", "instance1"),
+						"clientID", "userID", "orgID",
+						[]string{"openid"},
+						[]string{"projectID", "clientID"},
+						"binding", now, false,
+						domain.OIDCBackchannelTokenDeliveryModePoll, "", nil,
+					),
+				)),
+				idGenerator: mock.ExpectID(t, "id"),
+			},
+			request: &BackchannelAuthRequest{
+				ClientID:       "clientID",
+				UserID:         "userID",
+				UserOrgID:      "orgID",
+				Scopes:         []string{"openid"},
+				Audience:       []string{"projectID", "clientID"},
+				BindingMessage: "binding",
+				Expires:        now,
+			},
+			wantErr: pushErr,
+		},
+		{
+			name: "success",
+			fields: fields{
+				eventstore: expectEventstore(expectPush(
+					backchannelauth.NewAddedEvent(
+						ctx,
+						backchannelauth.NewAggregate("id", "instance1"),
+						"clientID", "userID", "orgID",
+						[]string{"openid", "offline_access"},
+						[]string{"projectID", "clientID"},
+						"binding", now, true,
+						domain.OIDCBackchannelTokenDeliveryModePoll, "", nil,
+					),
+				)),
+				idGenerator: mock.ExpectID(t, "id"),
+			},
+			request: &BackchannelAuthRequest{
+				ClientID:         "clientID",
+				UserID:           "userID",
+				UserOrgID:        "orgID",
+				Scopes:           []string{"openid", "offline_access"},
+				Audience:         []string{"projectID", "clientID"},
+				BindingMessage:   "binding",
+				Expires:          now,
+				NeedRefreshToken: true,
+			},
+			wantID: "id",
+			wantDetails: &domain.ObjectDetails{
+				ResourceOwner: "instance1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore(t),
+				idGenerator: tt.fields.idGenerator,
+			}
+			gotDetails, err := c.AddBackchannelAuth(ctx, tt.request)
+			require.ErrorIs(t, err, tt.wantErr)
+			assertObjectDetails(t, tt.wantDetails, gotDetails)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.wantID, tt.request.ID)
+			}
+		})
+	}
+}
+
+func TestCommands_ApproveBackchannelAuthWithSession(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	expires := time.Now().Add(time.Minute)
+
+	addedEvent := func(userID string, expires time.Time) eventstore.Event {
+		return eventFromEventPusherWithInstanceID(
+			"instance1",
+			backchannelauth.NewAddedEvent(
+				ctx,
+				backchannelauth.NewAggregate("id", "instance1"),
+				"clientID", userID, "orgID",
+				[]string{"openid"},
+				[]string{"projectID", "clientID"},
+				"binding", expires, false,
+				domain.OIDCBackchannelTokenDeliveryModePoll, "", nil,
+			),
+		)
+	}
+	sessionEvents := expectFilter(
+		eventFromEventPusher(
+			session.NewAddedEvent(ctx,
+				&session.NewAggregate("sessionID", "instance1").Aggregate,
+				&domain.UserAgent{
+					FingerprintID: gu.Ptr("fp1"),
+					IP:            net.ParseIP("1.2.3.4"),
+					Description:   gu.Ptr("firefox"),
+					Header:        http.Header{"foo": []string{"bar"}},
+				},
+			),
+		),
+		eventFromEventPusher(
+			session.NewUserCheckedEvent(ctx, &session.NewAggregate("sessionID", "instance1").Aggregate,
+				"userID", "orgID", testNow, &language.Afrikaans),
+		),
+		eventFromEventPusher(
+			session.NewPasswordCheckedEvent(ctx, &session.NewAggregate("sessionID", "instance1").Aggregate,
+				testNow),
+		),
+		eventFromEventPusherWithCreationDateNow(
+			session.NewLifetimeSetEvent(ctx, &session.NewAggregate("sessionID", "instance1").Aggregate,
+				2*time.Minute),
+		),
+	)
+
+	type fields struct {
+		eventstore      func(*testing.T) *eventstore.Eventstore
+		tokenVerifier   func(ctx context.Context, sessionToken, sessionID, tokenID string) (err error)
+		checkPermission domain.PermissionCheck
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		wantDetails *domain.ObjectDetails
+		wantErr     error
+	}{
+		{
+			name: "not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			wantErr: zerrors.ThrowNotFound(nil, "COMMAND-eeJ3u", "Errors.BackchannelAuth.NotFound"),
+		},
+		{
+			name: "already handled, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						addedEvent("userID", expires),
+						eventFromEventPusherWithInstanceID(
+							"instance1",
+							backchannelauth.NewCanceledEvent(ctx, backchannelauth.NewAggregate("id", "instance1"), domain.BackchannelAuthCanceledDenied),
+						),
+					),
+				),
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ohn5a", "Errors.BackchannelAuth.AlreadyHandled"),
+		},
+		{
+			name: "expired, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(addedEvent("userID", time.Now().Add(-time.Minute))),
+				),
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ohn5a", "Errors.BackchannelAuth.AlreadyHandled"),
+		},
+		{
+			name: "missing permission, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(addedEvent("userID", expires)),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			wantErr: zerrors.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied"),
+		},
+		{
+			name: "other user, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(addedEvent("otherUserID", expires)),
+					sessionEvents,
+				),
+				tokenVerifier:   newMockTokenVerifierValid(),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			wantErr: zerrors.ThrowPermissionDenied(nil, "COMMAND-ieP3a", "Errors.BackchannelAuth.UserMismatch"),
+		},
+		{
+			name: "approved",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(addedEvent("userID", expires)),
+					sessionEvents,
+					expectPush(
+						backchannelauth.NewApprovedEvent(
+							ctx, backchannelauth.NewAggregate("id", "instance1"),
+							[]domain.UserAuthMethodType{domain.UserAuthMethodTypePassword},
+							testNow, &language.Afrikaans, &domain.UserAgent{
+								FingerprintID: gu.Ptr("fp1"),
+								IP:            net.ParseIP("1.2.3.4"),
+								Description:   gu.Ptr("firefox"),
+								Header:        http.Header{"foo": []string{"bar"}},
+							},
+							"sessionID",
+						),
+					),
+				),
+				tokenVerifier:   newMockTokenVerifierValid(),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			wantDetails: &domain.ObjectDetails{
+				ResourceOwner: "instance1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:           tt.fields.eventstore(t),
+				sessionTokenVerifier: tt.fields.tokenVerifier,
+				checkPermission:      tt.fields.checkPermission,
+			}
+			gotDetails, err := c.ApproveBackchannelAuthWithSession(ctx, "id", "sessionID", "sessionToken")
+			require.ErrorIs(t, err, tt.wantErr)
+			assertObjectDetails(t, tt.wantDetails, gotDetails)
+		})
+	}
+}
+
+func TestCommands_CreateOIDCSessionFromBackchannelAuth(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+
+	addedEvent := func(expires time.Time) eventstore.Event {
+		return eventFromEventPusherWithInstanceID(
+			"instance1",
+			backchannelauth.NewAddedEvent(
+				ctx,
+				backchannelauth.NewAggregate("id", "instance1"),
+				"clientID", "userID", "orgID",
+				[]string{"openid"},
+				[]string{"projectID", "clientID"},
+				"binding", expires, false,
+				domain.OIDCBackchannelTokenDeliveryModePoll, "", nil,
+			),
+		)
+	}
+
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		clientID   string
+		wantErr    error
+	}{
+		{
+			name: "filter error",
+			eventstore: expectEventstore(
+				expectFilterError(io.ErrClosedPipe),
+			),
+			clientID: "clientID",
+			wantErr:  io.ErrClosedPipe,
+		},
+		{
+			name: "not found",
+			eventstore: expectEventstore(
+				expectFilter(),
+			),
+			clientID: "clientID",
+			wantErr:  zerrors.ThrowNotFound(nil, "COMMAND-aiG8o", "Errors.BackchannelAuth.NotFound"),
+		},
+		{
+			name: "other client",
+			eventstore: expectEventstore(
+				expectFilter(addedEvent(time.Now().Add(time.Minute))),
+			),
+			clientID: "otherClientID",
+			wantErr:  zerrors.ThrowNotFound(nil, "COMMAND-Quo8e", "Errors.BackchannelAuth.NotFound"),
+		},
+		{
+			name: "not yet approved",
+			eventstore: expectEventstore(
+				expectFilter(addedEvent(time.Now().Add(time.Minute))),
+			),
+			clientID: "clientID",
+			wantErr:  BackchannelAuthStateError(domain.BackchannelAuthStateInitiated),
+		},
+		{
+			name: "expired",
+			eventstore: expectEventstore(
+				expectFilter(addedEvent(time.Now().Add(-time.Minute))),
+				expectPushSlow(time.Second/10,
+					backchannelauth.NewCanceledEvent(ctx, backchannelauth.NewAggregate("id", "instance1"), domain.BackchannelAuthCanceledExpired),
+				),
+			),
+			clientID: "clientID",
+			wantErr:  BackchannelAuthStateError(domain.BackchannelAuthStateExpired),
+		},
+		{
+			name: "denied",
+			eventstore: expectEventstore(
+				expectFilter(
+					addedEvent(time.Now().Add(time.Minute)),
+					eventFromEventPusherWithInstanceID(
+						"instance1",
+						backchannelauth.NewCanceledEvent(ctx, backchannelauth.NewAggregate("id", "instance1"), domain.BackchannelAuthCanceledDenied),
+					),
+				),
+			),
+			clientID: "clientID",
+			wantErr:  BackchannelAuthStateError(domain.BackchannelAuthStateDenied),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore(t),
+			}
+			got, err := c.CreateOIDCSessionFromBackchannelAuth(ctx, "id", tt.clientID, "", "", "")
+			c.jobs.Wait()
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, got)
+		})
+	}
+}
+
+func TestCommands_NotifyBackchannelAuthClient(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	var (
+		gotToken string
+		gotBody  []byte
+	)
+	client := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotToken = r.Header.Get("Authorization")
+		gotBody, _ = io.ReadAll(r.Body)
+		if r.URL.Path == "/failing" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer client.Close()
+
+	addedEvent := func(mode domain.OIDCBackchannelTokenDeliveryMode, endpoint string) eventstore.Event {
+		return eventFromEventPusherWithInstanceID(
+			"instance1",
+			backchannelauth.NewAddedEvent(
+				ctx,
+				backchannelauth.NewAggregate("id", "instance1"),
+				"clientID", "userID", "orgID",
+				[]string{"openid"},
+				[]string{"projectID", "clientID"},
+				"binding", time.Now().Add(time.Minute), false,
+				mode, endpoint,
+				&crypto.CryptoValue{
+					CryptoType: crypto.TypeEncryption,
+					Algorithm:  "enc",
+					KeyID:      "id",
+					Crypted:    []byte("token"),
+				},
+			),
+		)
+	}
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		wantErr    func(error) bool
+	}{
+		{
+			name:       "not found",
+			eventstore: expectEventstore(expectFilter()),
+			wantErr:    zerrors.IsNotFound,
+		},
+		{
+			name: "poll mode",
+			eventstore: expectEventstore(
+				expectFilter(addedEvent(domain.OIDCBackchannelTokenDeliveryModePoll, "")),
+			),
+			wantErr: zerrors.IsPreconditionFailed,
+		},
+		{
+			name: "notification failed",
+			eventstore: expectEventstore(
+				expectFilter(addedEvent(domain.OIDCBackchannelTokenDeliveryModePing, client.URL+"/failing")),
+			),
+			wantErr: zerrors.IsInternal,
+		},
+		{
+			name: "notified",
+			eventstore: expectEventstore(
+				expectFilter(addedEvent(domain.OIDCBackchannelTokenDeliveryModePing, client.URL)),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotToken, gotBody = "", nil
+			c := &Commands{
+				eventstore:   tt.eventstore(t),
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				httpClient:   client.Client(),
+			}
+			err := c.NotifyBackchannelAuthClient(ctx, "id")
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Bearer token", gotToken)
+			assert.JSONEq(t, `{"auth_req_id":"id"}`, string(gotBody))
+		})
+	}
+}
@@ -43,6 +43,7 @@ type Commands struct {
 	newEncryptedCode            encrypedCodeFunc
 	newEncryptedCodeWithDefault encryptedCodeWithDefaultFunc
 	newHashedSecret             hashedSecretFunc
+	newRecoveryCodes            recoveryCodesFunc
 
 	eventstore     *eventstore.Eventstore
 	static         static.Storage
@@ -59,6 +60,7 @@ type Commands struct {
 	targetEncryption                crypto.EncryptionAlgorithm
 	userPasswordHasher              *crypto.Hasher
 	secretHasher                    *crypto.Hasher
+	passwordBreachChecker           crypto.PasswordBreachChecker
 	machineKeySize                  int
 	applicationKeySize              int
 	domainVerificationAlg           crypto.EncryptionAlgorithm
@@ -136,6 +138,10 @@ func StartCommands(
 	if err != nil {
 		return nil, fmt.Errorf("password hasher: %w", err)
 	}
+	passwordBreachChecker, err := defaults.PasswordBreachCheck.NewPasswordBreachChecker(httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("password breach check: %w", err)
+	}
 	caches, err := startCaches(ctx, cacheConnectors)
 	if err != nil {
 		return nil, fmt.Errorf("caches: %w", err)
@@ -161,6 +167,7 @@ func StartCommands(
 		targetEncryption:                targetEncryption,
 		userPasswordHasher:              userPasswordHasher,
 		secretHasher:                    secretHasher,
+		passwordBreachChecker:           passwordBreachChecker,
 		machineKeySize:                  int(defaults.SecretGenerators.MachineKeySize),
 		applicationKeySize:              int(defaults.SecretGenerators.ApplicationKeySize),
 		domainVerificationAlg:           domainVerificationEncryption,
@@ -206,6 +213,7 @@ func StartCommands(
 	if defaultSecretGenerators != nil && defaultSecretGenerators.ClientSecret != nil {
 		repo.newHashedSecret = newHashedSecretWithDefault(secretHasher, defaultSecretGenerators.ClientSecret)
 	}
+	repo.newRecoveryCodes = newRecoveryCodesFunc(secretHasher)
 	repo.phoneCodeVerifier = repo.phoneCodeVerifierFromConfig
 	return repo, nil
 }
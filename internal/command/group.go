@@ -0,0 +1,459 @@
+package command
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type AddGroup struct {
+	models.ObjectRoot
+
+	Name        string
+	Description string
+	// MemberIDs are the users added as members together with the group
+	MemberIDs []string
+}
+
+func (g *AddGroup) IsValid() error {
+	if g.ResourceOwner == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-3rNfW", "Errors.ResourceOwnerMissing")
+	}
+	if g.Name = strings.TrimSpace(g.Name); g.Name == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Yw2xq", "Errors.Group.Invalid")
+	}
+	return nil
+}
+
+func (c *Commands) AddGroup(ctx context.Context, add *AddGroup) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if err := add.IsValid(); err != nil {
+		return nil, err
+	}
+	if err := c.checkOrgExists(ctx, add.ResourceOwner); err != nil {
+		return nil, err
+	}
+	if add.AggregateID == "" {
+		add.AggregateID, err = c.idGenerator.Next()
+		if err != nil {
+			return nil, err
+		}
+	}
+	wm, err := c.getGroupWriteModelByID(ctx, add.AggregateID, add.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if wm.State.Exists() {
+		return nil, zerrors.ThrowAlreadyExists(nil, "COMMAND-Kq3Ms", "Errors.Group.AlreadyExists")
+	}
+	if err := c.checkPermission(ctx, domain.PermissionGroupWrite, wm.ResourceOwner, wm.AggregateID); err != nil {
+		return nil, err
+	}
+	agg := group.AggregateFromWriteModel(ctx, &wm.WriteModel)
+	memberCmds, err := c.groupMemberAddedEvents(ctx, wm, agg, add.MemberIDs)
+	if err != nil {
+		return nil, err
+	}
+	return c.pushAppendAndReduceDetails(ctx, wm,
+		append([]eventstore.Command{group.NewAddedEvent(ctx, agg, add.Name, add.Description)}, memberCmds...)...,
+	)
+}
+
+type ChangeGroup struct {
+	models.ObjectRoot
+
+	Name        *string
+	Description *string
+	// MemberIDs sets the members of the group to exactly these users if not nil.
+	// The members are changed in the same push as the name and description.
+	MemberIDs *[]string
+}
+
+func (g *ChangeGroup) IsValid() error {
+	if g.AggregateID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-0fDhN", "Errors.IDMissing")
+	}
+	if g.Name != nil {
+		if *g.Name = strings.TrimSpace(*g.Name); *g.Name == "" {
+			return zerrors.ThrowInvalidArgument(nil, "COMMAND-zPe3v", "Errors.Group.Invalid")
+		}
+	}
+	return nil
+}
+
+func (c *Commands) ChangeGroup(ctx context.Context, change *ChangeGroup) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if err := change.IsValid(); err != nil {
+		return nil, err
+	}
+	wm, err := c.getGroupWriteModelByID(ctx, change.AggregateID, change.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !wm.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-6dJqB", "Errors.Group.NotFound")
+	}
+	if err := c.checkPermission(ctx, domain.PermissionGroupWrite, wm.ResourceOwner, wm.AggregateID); err != nil {
+		return nil, err
+	}
+
+	agg := group.AggregateFromWriteModel(ctx, &wm.WriteModel)
+	changes := make([]group.Changes, 0, 2)
+	if change.Name != nil && *change.Name != wm.Name {
+		changes = append(changes, group.ChangeName(wm.Name, *change.Name))
+	}
+	if change.Description != nil && *change.Description != wm.Description {
+		changes = append(changes, group.ChangeDescription(*change.Description))
+	}
+	cmds := make([]eventstore.Command, 0, 1)
+	if len(changes) > 0 {
+		cmds = append(cmds, group.NewChangedEvent(ctx, agg, changes))
+	}
+	if change.MemberIDs != nil {
+		memberCmds, err := c.groupMembersSetEvents(ctx, wm, agg, *change.MemberIDs)
+		if err != nil {
+			return nil, err
+		}
+		cmds = append(cmds, memberCmds...)
+	}
+	if len(cmds) == 0 {
+		return writeModelToObjectDetails(&wm.WriteModel), nil
+	}
+	return c.pushAppendAndReduceDetails(ctx, wm, cmds...)
+}
+
+// RemoveGroup removes the group including its members and grants.
+// If the group does not exist, the call succeeds as the desired state is already reached.
+func (c *Commands) RemoveGroup(ctx context.Context, groupID, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if groupID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-bT1ro", "Errors.IDMissing")
+	}
+	wm, err := c.getGroupWriteModelByID(ctx, groupID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !wm.State.Exists() {
+		return writeModelToObjectDetails(&wm.WriteModel), nil
+	}
+	if err := c.checkPermission(ctx, domain.PermissionGroupDelete, wm.ResourceOwner, wm.AggregateID); err != nil {
+		return nil, err
+	}
+	return c.pushAppendAndReduceDetails(ctx, wm,
+		group.NewRemovedEvent(ctx, group.AggregateFromWriteModel(ctx, &wm.WriteModel), wm.Name),
+	)
+}
+
+// AddGroupMembers adds the given users to the group.
+// Users which are already members of the group are ignored.
+func (c *Commands) AddGroupMembers(ctx context.Context, groupID, resourceOwner string, userIDs ...string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if groupID == "" || len(userIDs) == 0 {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-9Ls0e", "Errors.Group.Member.Invalid")
+	}
+	wm, err := c.getGroupWriteModelByID(ctx, groupID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !wm.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Wm8Kd", "Errors.Group.NotFound")
+	}
+	if err := c.checkPermission(ctx, domain.PermissionGroupWrite, wm.ResourceOwner, wm.AggregateID); err != nil {
+		return nil, err
+	}
+
+	cmds, err := c.groupMemberAddedEvents(ctx, wm, group.AggregateFromWriteModel(ctx, &wm.WriteModel), userIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(cmds) == 0 {
+		return writeModelToObjectDetails(&wm.WriteModel), nil
+	}
+	return c.pushAppendAndReduceDetails(ctx, wm, cmds...)
+}
+
+// groupMemberAddedEvents returns the events for the existing users, which are not yet members of the group.
+func (c *Commands) groupMemberAddedEvents(ctx context.Context, wm *GroupWriteModel, agg *eventstore.Aggregate, userIDs []string) ([]eventstore.Command, error) {
+	cmds := make([]eventstore.Command, 0, len(userIDs))
+	for _, userID := range slices.Compact(slices.Sorted(slices.Values(userIDs))) {
+		if userID == "" || wm.hasMember(userID) {
+			continue
+		}
+		if err := c.checkGroupMemberExists(ctx, userID, wm.ResourceOwner); err != nil {
+			return nil, err
+		}
+		cmds = append(cmds, group.NewMemberAddedEvent(ctx, agg, userID))
+	}
+	return cmds, nil
+}
+
+// checkGroupMemberExists checks that the user exists in the organization of the group.
+// Users of other organizations are reported as not found.
+func (c *Commands) checkGroupMemberExists(ctx context.Context, userID, resourceOwner string) error {
+	user, err := c.userWriteModelByID(ctx, userID, "")
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(user.UserState) || user.ResourceOwner != resourceOwner {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Oov9e", "Errors.User.NotFound")
+	}
+	return nil
+}
+
+// RemoveGroupMembers removes the given users from the group.
+// Users which are not members of the group are ignored.
+func (c *Commands) RemoveGroupMembers(ctx context.Context, groupID, resourceOwner string, userIDs ...string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if groupID == "" || len(userIDs) == 0 {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-x7GfM", "Errors.Group.Member.Invalid")
+	}
+	wm, err := c.getGroupWriteModelByID(ctx, groupID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !wm.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Ekq2B", "Errors.Group.NotFound")
+	}
+	if err := c.checkPermission(ctx, domain.PermissionGroupWrite, wm.ResourceOwner, wm.AggregateID); err != nil {
+		return nil, err
+	}
+
+	agg := group.AggregateFromWriteModel(ctx, &wm.WriteModel)
+	cmds := make([]eventstore.Command, 0, len(userIDs))
+	for _, userID := range slices.Compact(slices.Sorted(slices.Values(userIDs))) {
+		if !wm.hasMember(userID) {
+			continue
+		}
+		cmds = append(cmds, group.NewMemberRemovedEvent(ctx, agg, userID))
+	}
+	if len(cmds) == 0 {
+		return writeModelToObjectDetails(&wm.WriteModel), nil
+	}
+	return c.pushAppendAndReduceDetails(ctx, wm, cmds...)
+}
+
+// SetGroupMembers sets the members of the group to exactly the given users.
+// Members which are not part of userIDs are removed, missing ones are added.
+func (c *Commands) SetGroupMembers(ctx context.Context, groupID, resourceOwner string, userIDs ...string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if groupID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Qe4pY", "Errors.Group.Member.Invalid")
+	}
+	wm, err := c.getGroupWriteModelByID(ctx, groupID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !wm.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-mF0sK", "Errors.Group.NotFound")
+	}
+	if err := c.checkPermission(ctx, domain.PermissionGroupWrite, wm.ResourceOwner, wm.AggregateID); err != nil {
+		return nil, err
+	}
+
+	cmds, err := c.groupMembersSetEvents(ctx, wm, group.AggregateFromWriteModel(ctx, &wm.WriteModel), userIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(cmds) == 0 {
+		return writeModelToObjectDetails(&wm.WriteModel), nil
+	}
+	return c.pushAppendAndReduceDetails(ctx, wm, cmds...)
+}
+
+// groupMembersSetEvents returns the events to remove the members which are not part of userIDs
+// and to add the missing users.
+// All added users are checked before any event is returned.
+func (c *Commands) groupMembersSetEvents(ctx context.Context, wm *GroupWriteModel, agg *eventstore.Aggregate, userIDs []string) ([]eventstore.Command, error) {
+	userIDs = slices.Compact(slices.Sorted(slices.Values(userIDs)))
+	cmds := make([]eventstore.Command, 0, len(userIDs)+len(wm.MemberIDs))
+	for _, memberID := range wm.MemberIDs {
+		if !slices.Contains(userIDs, memberID) {
+			cmds = append(cmds, group.NewMemberRemovedEvent(ctx, agg, memberID))
+		}
+	}
+	addedCmds, err := c.groupMemberAddedEvents(ctx, wm, agg, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	return append(cmds, addedCmds...), nil
+}
+
+type AddGroupGrant struct {
+	GroupID        string
+	ResourceOwner  string
+	GrantID        string
+	ProjectID      string
+	ProjectGrantID string
+	RoleKeys       []string
+}
+
+func (g *AddGroupGrant) IsValid() error {
+	if g.GroupID == "" || g.ProjectID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-o1vZc", "Errors.Group.Grant.Invalid")
+	}
+	return nil
+}
+
+// AddGroupGrant grants roles of a project (or project grant) to all members of the group.
+// The id of the created grant is set on the passed [AddGroupGrant].
+func (c *Commands) AddGroupGrant(ctx context.Context, add *AddGroupGrant) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if err := add.IsValid(); err != nil {
+		return nil, err
+	}
+	wm, err := c.getGroupWriteModelByID(ctx, add.GroupID, add.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !wm.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-t9XnE", "Errors.Group.NotFound")
+	}
+	if err := c.checkPermission(ctx, domain.PermissionGroupGrantWrite, wm.ResourceOwner, wm.AggregateID); err != nil {
+		return nil, err
+	}
+	if wm.grantForProject(add.ProjectID, add.ProjectGrantID) != "" {
+		return nil, zerrors.ThrowAlreadyExists(nil, "COMMAND-Sd1hQ", "Errors.Group.Grant.AlreadyExists")
+	}
+	if err := c.checkGroupGrantPreCondition(ctx, add.ProjectID, add.ProjectGrantID, wm.ResourceOwner, add.RoleKeys); err != nil {
+		return nil, err
+	}
+	if add.GrantID == "" {
+		add.GrantID, err = c.idGenerator.Next()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.pushAppendAndReduceDetails(ctx, wm,
+		group.NewGrantAddedEvent(ctx,
+			group.AggregateFromWriteModel(ctx, &wm.WriteModel),
+			add.GrantID,
+			add.ProjectID,
+			add.ProjectGrantID,
+			add.RoleKeys,
+		),
+	)
+}
+
+// ChangeGroupGrant replaces the granted roles of an existing grant of the group.
+func (c *Commands) ChangeGroupGrant(ctx context.Context, groupID, resourceOwner, grantID string, roleKeys []string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if groupID == "" || grantID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-cE4dW", "Errors.Group.Grant.Invalid")
+	}
+	wm, err := c.getGroupWriteModelByID(ctx, groupID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !wm.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Rr0jT", "Errors.Group.NotFound")
+	}
+	grant, ok := wm.Grants[grantID]
+	if !ok {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-nE1Kp", "Errors.Group.Grant.NotFound")
+	}
+	if err := c.checkPermission(ctx, domain.PermissionGroupGrantWrite, wm.ResourceOwner, wm.AggregateID); err != nil {
+		return nil, err
+	}
+	if slices.Equal(grant.RoleKeys, roleKeys) {
+		return writeModelToObjectDetails(&wm.WriteModel), nil
+	}
+	if err := c.checkGroupGrantPreCondition(ctx, grant.ProjectID, grant.ProjectGrantID, wm.ResourceOwner, roleKeys); err != nil {
+		return nil, err
+	}
+	return c.pushAppendAndReduceDetails(ctx, wm,
+		group.NewGrantChangedEvent(ctx, group.AggregateFromWriteModel(ctx, &wm.WriteModel), grantID, roleKeys),
+	)
+}
+
+// RemoveGroupGrant removes a grant from the group.
+// If the grant does not exist, the call succeeds as the desired state is already reached.
+func (c *Commands) RemoveGroupGrant(ctx context.Context, groupID, resourceOwner, grantID string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if groupID == "" || grantID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ub6fA", "Errors.Group.Grant.Invalid")
+	}
+	wm, err := c.getGroupWriteModelByID(ctx, groupID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := wm.Grants[grantID]; !ok || !wm.State.Exists() {
+		return writeModelToObjectDetails(&wm.WriteModel), nil
+	}
+	if err := c.checkPermission(ctx, domain.PermissionGroupGrantDelete, wm.ResourceOwner, wm.AggregateID); err != nil {
+		return nil, err
+	}
+	return c.pushAppendAndReduceDetails(ctx, wm,
+		group.NewGrantRemovedEvent(ctx, group.AggregateFromWriteModel(ctx, &wm.WriteModel), grantID),
+	)
+}
+
+func (c *Commands) checkGroupGrantPreCondition(ctx context.Context, projectID, projectGrantID, resourceOwner string, roleKeys []string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	preConditions := NewGroupGrantPreConditionReadModel(projectID, projectGrantID, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, preConditions); err != nil {
+		return err
+	}
+	if projectGrantID == "" && !preConditions.ProjectExists {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Hk3Rm", "Errors.Project.NotFound")
+	}
+	if projectGrantID != "" && !preConditions.ProjectGrantExists {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Q8ubn", "Errors.Project.Grant.NotFound")
+	}
+	for _, roleKey := range roleKeys {
+		if !slices.Contains(preConditions.ExistingRoleKeys, roleKey) {
+			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-2pWkL", "Errors.Project.Role.NotFound")
+		}
+	}
+	return nil
+}
+
+func (c *Commands) getGroupWriteModelByID(ctx context.Context, groupID, resourceOwner string) (_ *GroupWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	wm := NewGroupWriteModel(groupID, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, wm); err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// GroupWriteModel returns the current state of the group.
+// It returns a not found error if the group does not exist.
+func (c *Commands) GroupWriteModel(ctx context.Context, groupID, resourceOwner string) (_ *GroupWriteModel, err error) {
+	wm, err := c.getGroupWriteModelByID(ctx, groupID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !wm.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-p3Ndw", "Errors.Group.NotFound")
+	}
+	return wm, nil
+}
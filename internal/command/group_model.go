@@ -0,0 +1,198 @@
+package command
+
+import (
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+type GroupWriteModel struct {
+	eventstore.WriteModel
+
+	Name        string
+	Description string
+	State       domain.GroupState
+
+	MemberIDs []string
+	Grants    map[string]*GroupGrant
+}
+
+type GroupGrant struct {
+	ProjectID      string
+	ProjectGrantID string
+	RoleKeys       []string
+}
+
+func NewGroupWriteModel(id, resourceOwner string) *GroupWriteModel {
+	return &GroupWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   id,
+			ResourceOwner: resourceOwner,
+		},
+		Grants: make(map[string]*GroupGrant),
+	}
+}
+
+func (wm *GroupWriteModel) GetWriteModel() *eventstore.WriteModel {
+	return &wm.WriteModel
+}
+
+func (wm *GroupWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *group.AddedEvent:
+			wm.Name = e.Name
+			wm.Description = e.Description
+			wm.State = domain.GroupStateActive
+		case *group.ChangedEvent:
+			if e.Name != nil {
+				wm.Name = *e.Name
+			}
+			if e.Description != nil {
+				wm.Description = *e.Description
+			}
+		case *group.RemovedEvent:
+			wm.State = domain.GroupStateRemoved
+			wm.MemberIDs = nil
+			wm.Grants = make(map[string]*GroupGrant)
+		case *group.MemberAddedEvent:
+			wm.MemberIDs = append(wm.MemberIDs, e.UserID)
+		case *group.MemberRemovedEvent:
+			wm.MemberIDs = slices.DeleteFunc(wm.MemberIDs, func(id string) bool {
+				return id == e.UserID
+			})
+		case *group.GrantAddedEvent:
+			wm.Grants[e.GrantID] = &GroupGrant{
+				ProjectID:      e.ProjectID,
+				ProjectGrantID: e.ProjectGrantID,
+				RoleKeys:       e.RoleKeys,
+			}
+		case *group.GrantChangedEvent:
+			if grant, ok := wm.Grants[e.GrantID]; ok {
+				grant.RoleKeys = e.RoleKeys
+			}
+		case *group.GrantRemovedEvent:
+			delete(wm.Grants, e.GrantID)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *GroupWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(group.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			group.AddedEventType,
+			group.ChangedEventType,
+			group.RemovedEventType,
+			group.MemberAddedEventType,
+			group.MemberRemovedEventType,
+			group.GrantAddedEventType,
+			group.GrantChangedEventType,
+			group.GrantRemovedEventType,
+		).
+		Builder()
+}
+
+func (wm *GroupWriteModel) hasMember(userID string) bool {
+	return slices.Contains(wm.MemberIDs, userID)
+}
+
+// grantForProject returns the id of the existing grant on the project (grant), if any.
+func (wm *GroupWriteModel) grantForProject(projectID, projectGrantID string) string {
+	for id, grant := range wm.Grants {
+		if grant.ProjectID == projectID && grant.ProjectGrantID == projectGrantID {
+			return id
+		}
+	}
+	return ""
+}
+
+// GroupGrantPreConditionReadModel checks if the project (or project grant)
+// exists and collects the role keys which can be granted to a group.
+type GroupGrantPreConditionReadModel struct {
+	eventstore.WriteModel
+
+	ProjectID          string
+	ProjectGrantID     string
+	ResourceOwner      string
+	ProjectExists      bool
+	ProjectGrantExists bool
+	ExistingRoleKeys   []string
+}
+
+func NewGroupGrantPreConditionReadModel(projectID, projectGrantID, resourceOwner string) *GroupGrantPreConditionReadModel {
+	return &GroupGrantPreConditionReadModel{
+		ProjectID:      projectID,
+		ProjectGrantID: projectGrantID,
+		ResourceOwner:  resourceOwner,
+	}
+}
+
+func (wm *GroupGrantPreConditionReadModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.ProjectAddedEvent:
+			if wm.ProjectGrantID == "" && wm.ResourceOwner == e.Aggregate().ResourceOwner {
+				wm.ProjectExists = true
+			}
+		case *project.ProjectRemovedEvent:
+			wm.ProjectExists = false
+			wm.ProjectGrantExists = false
+		case *project.GrantAddedEvent:
+			if wm.ProjectGrantID == e.GrantID && wm.ResourceOwner == e.GrantedOrgID {
+				wm.ProjectGrantExists = true
+				wm.ExistingRoleKeys = e.RoleKeys
+			}
+		case *project.GrantChangedEvent:
+			if wm.ProjectGrantID == e.GrantID {
+				wm.ExistingRoleKeys = e.RoleKeys
+			}
+		case *project.GrantCascadeChangedEvent:
+			if wm.ProjectGrantID == e.GrantID {
+				wm.ExistingRoleKeys = e.RoleKeys
+			}
+		case *project.GrantRemovedEvent:
+			if wm.ProjectGrantID == e.GrantID {
+				wm.ProjectGrantExists = false
+				wm.ExistingRoleKeys = []string{}
+			}
+		case *project.RoleAddedEvent:
+			if wm.ProjectGrantID != "" {
+				continue
+			}
+			wm.ExistingRoleKeys = append(wm.ExistingRoleKeys, e.Key)
+		case *project.RoleRemovedEvent:
+			if wm.ProjectGrantID != "" {
+				continue
+			}
+			wm.ExistingRoleKeys = slices.DeleteFunc(wm.ExistingRoleKeys, func(key string) bool {
+				return key == e.Key
+			})
+		}
+	}
+	return nil
+}
+
+func (wm *GroupGrantPreConditionReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.ProjectID).
+		EventTypes(
+			project.ProjectAddedType,
+			project.ProjectRemovedType,
+			project.GrantAddedType,
+			project.GrantChangedType,
+			project.GrantCascadeChangedType,
+			project.GrantRemovedType,
+			project.RoleAddedType,
+			project.RoleRemovedType).
+		Builder()
+}
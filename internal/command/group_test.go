@@ -0,0 +1,1166 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/muhlemmer/gu"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommands_AddGroup(t *testing.T) {
+	type fields struct {
+		eventstore      func(t *testing.T) *eventstore.Eventstore
+		idGenerator     id.Generator
+		checkPermission domain.PermissionCheck
+	}
+	type args struct {
+		ctx context.Context
+		add *AddGroup
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no resource owner, error",
+			fields: fields{
+				eventstore:      expectEventstore(),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instanceID"),
+				add: &AddGroup{
+					Name: "group",
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "no name, error",
+			fields: fields{
+				eventstore:      expectEventstore(),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instanceID"),
+				add: &AddGroup{
+					ObjectRoot: models.ObjectRoot{ResourceOwner: "org1"},
+					Name:       " ",
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "org not found, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instanceID"),
+				add: &AddGroup{
+					ObjectRoot: models.ObjectRoot{ResourceOwner: "org1"},
+					Name:       "group",
+				},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "already exists, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "group", ""),
+						),
+					),
+				),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "group1"),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instanceID"),
+				add: &AddGroup{
+					ObjectRoot: models.ObjectRoot{ResourceOwner: "org1"},
+					Name:       "group",
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "no permission, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(),
+				),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "group1"),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instanceID"),
+				add: &AddGroup{
+					ObjectRoot: models.ObjectRoot{ResourceOwner: "org1"},
+					Name:       "group",
+				},
+			},
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
+		{
+			name: "ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						group.NewAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "group", "description"),
+					),
+				),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "group1"),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instanceID"),
+				add: &AddGroup{
+					ObjectRoot:  models.ObjectRoot{ResourceOwner: "org1"},
+					Name:        "group",
+					Description: "description",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "group1",
+				},
+			},
+		},
+		{
+			name: "member not found, no group added, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(),
+					expectFilter(),
+				),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "group1"),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instanceID"),
+				add: &AddGroup{
+					ObjectRoot: models.ObjectRoot{ResourceOwner: "org1"},
+					Name:       "group",
+					MemberIDs:  []string{"user1"},
+				},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "with members, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewMachineAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"machine", "machine", "", false, domain.OIDCTokenTypeBearer),
+						),
+					),
+					expectPush(
+						group.NewAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "group", ""),
+						group.NewMemberAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "user1"),
+					),
+				),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "group1"),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instanceID"),
+				add: &AddGroup{
+					ObjectRoot: models.ObjectRoot{ResourceOwner: "org1"},
+					Name:       "group",
+					MemberIDs:  []string{"user1", "user1"},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "group1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:      tt.fields.eventstore(t),
+				idGenerator:     tt.fields.idGenerator,
+				checkPermission: tt.fields.checkPermission,
+			}
+			got, err := c.AddGroup(tt.args.ctx, tt.args.add)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_ChangeGroup(t *testing.T) {
+	type fields struct {
+		eventstore      func(t *testing.T) *eventstore.Eventstore
+		checkPermission domain.PermissionCheck
+	}
+	type args struct {
+		ctx    context.Context
+		change *ChangeGroup
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no id, error",
+			fields: fields{
+				eventstore:      expectEventstore(),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instanceID"),
+				change: &ChangeGroup{},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "not found, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instanceID"),
+				change: &ChangeGroup{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1", ResourceOwner: "org1"},
+					Name:       gu.Ptr("new"),
+				},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "no changes, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "group", ""),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instanceID"),
+				change: &ChangeGroup{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1", ResourceOwner: "org1"},
+					Name:       gu.Ptr("group"),
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "group1",
+				},
+			},
+		},
+		{
+			name: "changed, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "group", ""),
+						),
+					),
+					expectPush(
+						group.NewChangedEvent(context.Background(), group.NewAggregate("group1", "org1"),
+							[]group.Changes{
+								group.ChangeName("group", "new"),
+								group.ChangeDescription("description"),
+							},
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instanceID"),
+				change: &ChangeGroup{
+					ObjectRoot:  models.ObjectRoot{AggregateID: "group1", ResourceOwner: "org1"},
+					Name:        gu.Ptr("new"),
+					Description: gu.Ptr("description"),
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "group1",
+				},
+			},
+		},
+		{
+			name: "name and members changed, single push",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "group", ""),
+						),
+						eventFromEventPusher(
+							group.NewMemberAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "user1"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewMachineAddedEvent(context.Background(), &user.NewAggregate("user2", "org1").Aggregate,
+								"machine", "machine", "", false, domain.OIDCTokenTypeBearer),
+						),
+					),
+					expectPush(
+						group.NewChangedEvent(context.Background(), group.NewAggregate("group1", "org1"),
+							[]group.Changes{
+								group.ChangeName("group", "new"),
+							},
+						),
+						group.NewMemberRemovedEvent(context.Background(), group.NewAggregate("group1", "org1"), "user1"),
+						group.NewMemberAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "user2"),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instanceID"),
+				change: &ChangeGroup{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1", ResourceOwner: "org1"},
+					Name:       gu.Ptr("new"),
+					MemberIDs:  &[]string{"user2"},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "group1",
+				},
+			},
+		},
+		{
+			name: "member not found, nothing pushed",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "group", ""),
+						),
+					),
+					expectFilter(),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instanceID"),
+				change: &ChangeGroup{
+					ObjectRoot: models.ObjectRoot{AggregateID: "group1", ResourceOwner: "org1"},
+					Name:       gu.Ptr("new"),
+					MemberIDs:  &[]string{"unknown"},
+				},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:      tt.fields.eventstore(t),
+				checkPermission: tt.fields.checkPermission,
+			}
+			got, err := c.ChangeGroup(tt.args.ctx, tt.args.change)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveGroup(t *testing.T) {
+	type fields struct {
+		eventstore      func(t *testing.T) *eventstore.Eventstore
+		checkPermission domain.PermissionCheck
+	}
+	type args struct {
+		ctx           context.Context
+		groupID       string
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no id, error",
+			fields: fields{
+				eventstore:      expectEventstore(),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instanceID"),
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "not found, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instanceID"),
+				groupID:       "group1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "group1",
+				},
+			},
+		},
+		{
+			name: "no permission, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "group", ""),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instanceID"),
+				groupID:       "group1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
+		{
+			name: "removed, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "group", ""),
+						),
+					),
+					expectPush(
+						group.NewRemovedEvent(context.Background(), group.NewAggregate("group1", "org1"), "group"),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instanceID"),
+				groupID:       "group1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "group1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:      tt.fields.eventstore(t),
+				checkPermission: tt.fields.checkPermission,
+			}
+			got, err := c.RemoveGroup(tt.args.ctx, tt.args.groupID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_AddGroupMembers(t *testing.T) {
+	type fields struct {
+		eventstore      func(t *testing.T) *eventstore.Eventstore
+		checkPermission domain.PermissionCheck
+	}
+	type args struct {
+		ctx           context.Context
+		groupID       string
+		resourceOwner string
+		userIDs       []string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no users, error",
+			fields: fields{
+				eventstore:      expectEventstore(),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instanceID"),
+				groupID:       "group1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "group not found, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instanceID"),
+				groupID:       "group1",
+				resourceOwner: "org1",
+				userIDs:       []string{"user1"},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "user not found, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "group", ""),
+						),
+					),
+					expectFilter(),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instanceID"),
+				groupID:       "group1",
+				resourceOwner: "org1",
+				userIDs:       []string{"user1"},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "user of other organization, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "group", ""),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewMachineAddedEvent(context.Background(), &user.NewAggregate("user1", "org2").Aggregate,
+								"machine", "machine", "", false, domain.OIDCTokenTypeBearer),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instanceID"),
+				groupID:       "group1",
+				resourceOwner: "org1",
+				userIDs:       []string{"user1"},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "already member, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "group", ""),
+						),
+						eventFromEventPusher(
+							group.NewMemberAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "user1"),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instanceID"),
+				groupID:       "group1",
+				resourceOwner: "org1",
+				userIDs:       []string{"user1"},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "group1",
+				},
+			},
+		},
+		{
+			name: "member added, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "group", ""),
+						),
+						eventFromEventPusher(
+							group.NewMemberAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "user1"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewMachineAddedEvent(context.Background(), &user.NewAggregate("user2", "org1").Aggregate,
+								"machine", "machine", "", false, domain.OIDCTokenTypeBearer),
+						),
+					),
+					expectPush(
+						group.NewMemberAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "user2"),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instanceID"),
+				groupID:       "group1",
+				resourceOwner: "org1",
+				userIDs:       []string{"user1", "user2", "user2"},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "group1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:      tt.fields.eventstore(t),
+				checkPermission: tt.fields.checkPermission,
+			}
+			got, err := c.AddGroupMembers(tt.args.ctx, tt.args.groupID, tt.args.resourceOwner, tt.args.userIDs...)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_SetGroupMembers(t *testing.T) {
+	type fields struct {
+		eventstore      func(t *testing.T) *eventstore.Eventstore
+		checkPermission domain.PermissionCheck
+	}
+	type args struct {
+		ctx           context.Context
+		groupID       string
+		resourceOwner string
+		userIDs       []string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "group not found, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instanceID"),
+				groupID:       "group1",
+				resourceOwner: "org1",
+				userIDs:       []string{"user1"},
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "no permission, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "group", ""),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instanceID"),
+				groupID:       "group1",
+				resourceOwner: "org1",
+				userIDs:       []string{"user1"},
+			},
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
+		{
+			name: "unchanged, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "group", ""),
+						),
+						eventFromEventPusher(
+							group.NewMemberAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "user1"),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instanceID"),
+				groupID:       "group1",
+				resourceOwner: "org1",
+				userIDs:       []string{"user1"},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "group1",
+				},
+			},
+		},
+		{
+			name: "members replaced, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "group", ""),
+						),
+						eventFromEventPusher(
+							group.NewMemberAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "user1"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewMachineAddedEvent(context.Background(), &user.NewAggregate("user2", "org1").Aggregate,
+								"machine", "machine", "", false, domain.OIDCTokenTypeBearer),
+						),
+					),
+					expectPush(
+						group.NewMemberRemovedEvent(context.Background(), group.NewAggregate("group1", "org1"), "user1"),
+						group.NewMemberAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "user2"),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instanceID"),
+				groupID:       "group1",
+				resourceOwner: "org1",
+				userIDs:       []string{"user2"},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "group1",
+				},
+			},
+		},
+		{
+			name: "all members removed, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "group", ""),
+						),
+						eventFromEventPusher(
+							group.NewMemberAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "user1"),
+						),
+					),
+					expectPush(
+						group.NewMemberRemovedEvent(context.Background(), group.NewAggregate("group1", "org1"), "user1"),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instanceID"),
+				groupID:       "group1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "group1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:      tt.fields.eventstore(t),
+				checkPermission: tt.fields.checkPermission,
+			}
+			got, err := c.SetGroupMembers(tt.args.ctx, tt.args.groupID, tt.args.resourceOwner, tt.args.userIDs...)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_AddGroupGrant(t *testing.T) {
+	type fields struct {
+		eventstore      func(t *testing.T) *eventstore.Eventstore
+		idGenerator     id.Generator
+		checkPermission domain.PermissionCheck
+	}
+	type args struct {
+		ctx context.Context
+		add *AddGroupGrant
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no project, error",
+			fields: fields{
+				eventstore:      expectEventstore(),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instanceID"),
+				add: &AddGroupGrant{
+					GroupID:       "group1",
+					ResourceOwner: "org1",
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "grant on project already exists, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "group", ""),
+						),
+						eventFromEventPusher(
+							group.NewGrantAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "grant1", "project1", "", []string{"role"}),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instanceID"),
+				add: &AddGroupGrant{
+					GroupID:       "group1",
+					ResourceOwner: "org1",
+					ProjectID:     "project1",
+					RoleKeys:      []string{"role"},
+				},
+			},
+			res: res{
+				err: zerrors.IsErrorAlreadyExists,
+			},
+		},
+		{
+			name: "role not found, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "group", ""),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(), &project.NewAggregate("project1", "org1").Aggregate,
+								"project", false, false, false, domain.PrivateLabelingSettingUnspecified),
+						),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(), &project.NewAggregate("project1", "org1").Aggregate,
+								"role", "role", ""),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instanceID"),
+				add: &AddGroupGrant{
+					GroupID:       "group1",
+					ResourceOwner: "org1",
+					ProjectID:     "project1",
+					RoleKeys:      []string{"unknown"},
+				},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "role removed from project grant, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), group.NewAggregate("group1", "org2"), "group", ""),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(), &project.NewAggregate("project1", "org1").Aggregate,
+								"project", false, false, false, domain.PrivateLabelingSettingUnspecified),
+						),
+						eventFromEventPusher(
+							project.NewGrantAddedEvent(context.Background(), &project.NewAggregate("project1", "org1").Aggregate,
+								"projectgrant1", "org2", []string{"role", "role2"}),
+						),
+						eventFromEventPusher(
+							project.NewGrantCascadeChangedEvent(context.Background(), &project.NewAggregate("project1", "org1").Aggregate,
+								"projectgrant1", []string{"role2"}),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instanceID"),
+				add: &AddGroupGrant{
+					GroupID:        "group1",
+					ResourceOwner:  "org2",
+					ProjectID:      "project1",
+					ProjectGrantID: "projectgrant1",
+					RoleKeys:       []string{"role"},
+				},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "grant added, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							group.NewAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "group", ""),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(), &project.NewAggregate("project1", "org1").Aggregate,
+								"project", false, false, false, domain.PrivateLabelingSettingUnspecified),
+						),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(), &project.NewAggregate("project1", "org1").Aggregate,
+								"role", "role", ""),
+						),
+					),
+					expectPush(
+						group.NewGrantAddedEvent(context.Background(), group.NewAggregate("group1", "org1"), "grant1", "project1", "", []string{"role"}),
+					),
+				),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "grant1"),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instanceID"),
+				add: &AddGroupGrant{
+					GroupID:       "group1",
+					ResourceOwner: "org1",
+					ProjectID:     "project1",
+					RoleKeys:      []string{"role"},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "group1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:      tt.fields.eventstore(t),
+				idGenerator:     tt.fields.idGenerator,
+				checkPermission: tt.fields.checkPermission,
+			}
+			got, err := c.AddGroupGrant(tt.args.ctx, tt.args.add)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
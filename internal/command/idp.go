@@ -6,6 +6,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/command/preparation"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp/mapping"
 	"github.com/zitadel/zitadel/internal/repository/idp"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -21,6 +22,7 @@ type GenericOAuthProvider struct {
 	Scopes                []string
 	IDAttribute           string
 	UsePKCE               bool
+	ClaimMapping          *mapping.Mapping
 	IDPOptions            idp.Options
 }
 
@@ -32,6 +34,7 @@ type GenericOIDCProvider struct {
 	Scopes           []string
 	IsIDTokenMapping bool
 	UsePKCE          bool
+	ClaimMapping     *mapping.Mapping
 	IDPOptions       idp.Options
 }
 
@@ -136,6 +139,49 @@ type AppleProvider struct {
 	IDPOptions idp.Options
 }
 
+type OktaProvider struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	IDPOptions   idp.Options
+}
+
+type KeycloakProvider struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	IDPOptions   idp.Options
+}
+
+type ADFSProvider struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	IDPOptions   idp.Options
+}
+
+type BitbucketProvider struct {
+	Name         string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	IDPOptions   idp.Options
+}
+
+type DiscordProvider struct {
+	Name         string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	IDPOptions   idp.Options
+}
+
 // ExistsIDPOnOrgOrInstance query first org level IDPs and then instance level IDPs, no check if the IDP is active
 func ExistsIDPOnOrgOrInstance(ctx context.Context, filter preparation.FilterToQueryReducer, instanceID, orgID, id string) (exists bool, err error) {
 	ctx, span := tracing.NewSpan(ctx)
@@ -17,6 +17,7 @@ import (
 	"github.com/zitadel/zitadel/internal/idp"
 	"github.com/zitadel/zitadel/internal/idp/providers/apple"
 	"github.com/zitadel/zitadel/internal/idp/providers/azuread"
+	"github.com/zitadel/zitadel/internal/idp/providers/bitbucket"
 	"github.com/zitadel/zitadel/internal/idp/providers/jwt"
 	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
 	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
@@ -164,7 +165,7 @@ func (c *Commands) SucceedIDPIntent(ctx context.Context, writeModel *IDPIntentWr
 	if err != nil {
 		return "", err
 	}
-	accessToken, idToken, err := tokensForSucceededIDPIntent(idpSession, c.idpConfigEncryption)
+	accessToken, refreshToken, idToken, err := tokensForSucceededIDPIntent(idpSession, c.idpConfigEncryption)
 	if err != nil {
 		return "", err
 	}
@@ -180,6 +181,7 @@ func (c *Commands) SucceedIDPIntent(ctx context.Context, writeModel *IDPIntentWr
 		idpUser.GetPreferredUsername(),
 		userID,
 		accessToken,
+		refreshToken,
 		idToken,
 		idpSession.ExpiresAt(),
 	)
@@ -289,8 +291,8 @@ func (c *Commands) GetIntentWriteModel(ctx context.Context, id, resourceOwner st
 	return writeModel, err
 }
 
-// tokensForSucceededIDPIntent extracts the oidc.Tokens if available (and encrypts the access_token) for the succeeded event payload
-func tokensForSucceededIDPIntent(session idp.Session, encryptionAlg crypto.EncryptionAlgorithm) (*crypto.CryptoValue, string, error) {
+// tokensForSucceededIDPIntent extracts the oidc.Tokens if available (and encrypts the access_token and refresh_token) for the succeeded event payload
+func tokensForSucceededIDPIntent(session idp.Session, encryptionAlg crypto.EncryptionAlgorithm) (accessToken, refreshToken *crypto.CryptoValue, idToken string, err error) {
 	var tokens *oidc.Tokens[*oidc.IDTokenClaims]
 	switch s := session.(type) {
 	case *oauth.Session:
@@ -303,12 +305,55 @@ func tokensForSucceededIDPIntent(session idp.Session, encryptionAlg crypto.Encry
 		tokens = s.Tokens()
 	case *apple.Session:
 		tokens = s.Tokens
+	case *bitbucket.Session:
+		tokens = s.Tokens
 	default:
-		return nil, "", nil
+		return nil, nil, "", nil
 	}
 	if tokens.Token == nil || tokens.AccessToken == "" {
-		return nil, tokens.IDToken, nil
+		return nil, nil, tokens.IDToken, nil
+	}
+	accessToken, err = crypto.Encrypt([]byte(tokens.AccessToken), encryptionAlg)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if tokens.RefreshToken != "" {
+		refreshToken, err = crypto.Encrypt([]byte(tokens.RefreshToken), encryptionAlg)
+		if err != nil {
+			return nil, nil, "", err
+		}
+	}
+	return accessToken, refreshToken, tokens.IDToken, nil
+}
+
+// idpIntentMappingCommands evaluates the claim mapping of the identity provider on the user of the succeeded intent
+// and returns the commands to set the mapped metadata and grants on the user.
+func (c *Commands) idpIntentMappingCommands(ctx context.Context, intent *IDPIntentWriteModel, userID, resourceOwner string) ([]eventstore.Command, error) {
+	if len(intent.IDPUser) == 0 {
+		return nil, nil
+	}
+	idpWriteModel, err := IDPProviderWriteModel(ctx, c.eventstore.Filter, intent.IDPID)
+	if err != nil {
+		return nil, err
+	}
+	claimMapping := idpWriteModel.GetClaimMapping()
+	if claimMapping == nil || (len(claimMapping.Metadata) == 0 && len(claimMapping.Grants) == 0) {
+		return nil, nil
+	}
+	payload := intent.IDPUser
+	// the user of generic OAuth providers is stored with the claims in the raw info
+	if idpWriteModel.IDPType == domain.IDPTypeOAuth {
+		var oauthUser struct {
+			RawInfo json.RawMessage
+		}
+		if err := json.Unmarshal(intent.IDPUser, &oauthUser); err != nil {
+			return nil, zerrors.ThrowInternal(err, "COMMAND-Oog5e", "Errors.Intent.ResponseInvalid")
+		}
+		payload = oauthUser.RawInfo
+	}
+	result, err := claimMapping.Evaluate(payload)
+	if err != nil {
+		return nil, err
 	}
-	accessToken, err := crypto.Encrypt([]byte(tokens.AccessToken), encryptionAlg)
-	return accessToken, tokens.IDToken, err
+	return c.mappedIDPUserCommands(ctx, userID, resourceOwner, result.Metadata, result.Grants)
 }
@@ -22,8 +22,9 @@ type IDPIntentWriteModel struct {
 	IDPUserName  string
 	UserID       string
 
-	IDPAccessToken *crypto.CryptoValue
-	IDPIDToken     string
+	IDPAccessToken  *crypto.CryptoValue
+	IDPRefreshToken *crypto.CryptoValue
+	IDPIDToken      string
 
 	IDPEntryAttributes map[string][]string
 
@@ -129,6 +130,7 @@ func (wm *IDPIntentWriteModel) reduceOAuthSucceededEvent(e *idpintent.SucceededE
 	wm.IDPUserID = e.IDPUserID
 	wm.IDPUserName = e.IDPUserName
 	wm.IDPAccessToken = e.IDPAccessToken
+	wm.IDPRefreshToken = e.IDPRefreshToken
 	wm.IDPIDToken = e.IDPIDToken
 	wm.State = domain.IDPIntentStateSucceeded
 	wm.succeededAt = e.CreationDate()
@@ -23,6 +23,7 @@ import (
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/id/mock"
 	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/mapping"
 	"github.com/zitadel/zitadel/internal/idp/providers/azuread"
 	"github.com/zitadel/zitadel/internal/idp/providers/jwt"
 	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
@@ -33,6 +34,7 @@ import (
 	"github.com/zitadel/zitadel/internal/repository/idpintent"
 	"github.com/zitadel/zitadel/internal/repository/instance"
 	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
@@ -188,6 +190,7 @@ func TestCommands_CreateIntent(t *testing.T) {
 								"idAttribute",
 								nil,
 								true,
+								nil,
 								rep_idp.Options{},
 							)),
 					),
@@ -243,6 +246,7 @@ func TestCommands_CreateIntent(t *testing.T) {
 								"idAttribute",
 								nil,
 								true,
+								nil,
 								rep_idp.Options{},
 							)),
 					),
@@ -303,6 +307,7 @@ func TestCommands_CreateIntent(t *testing.T) {
 								"idAttribute",
 								nil,
 								true,
+								nil,
 								rep_idp.Options{},
 							)),
 					),
@@ -363,6 +368,7 @@ func TestCommands_CreateIntent(t *testing.T) {
 								"idAttribute",
 								nil,
 								true,
+								nil,
 								rep_idp.Options{},
 							)),
 					),
@@ -500,6 +506,7 @@ func TestCommands_AuthFromProvider(t *testing.T) {
 								"idAttribute",
 								nil,
 								true,
+								nil,
 								rep_idp.Options{},
 							)),
 						eventFromEventPusherWithInstanceID(
@@ -545,6 +552,7 @@ func TestCommands_AuthFromProvider(t *testing.T) {
 								"idAttribute",
 								nil,
 								true,
+								nil,
 								rep_idp.Options{},
 							)),
 					),
@@ -567,6 +575,7 @@ func TestCommands_AuthFromProvider(t *testing.T) {
 								"idAttribute",
 								nil,
 								true,
+								nil,
 								rep_idp.Options{},
 							)),
 					),
@@ -605,6 +614,7 @@ func TestCommands_AuthFromProvider(t *testing.T) {
 								[]string{"openid", "profile", "User.Read"},
 								false,
 								true,
+								nil,
 								rep_idp.Options{},
 							)),
 						eventFromEventPusherWithInstanceID(
@@ -642,6 +652,7 @@ func TestCommands_AuthFromProvider(t *testing.T) {
 								[]string{"openid", "profile", "User.Read"},
 								false,
 								true,
+								nil,
 								rep_idp.Options{},
 							)),
 						eventFromEventPusherWithInstanceID(
@@ -926,6 +937,7 @@ func TestCommands_SucceedIDPIntent(t *testing.T) {
 									KeyID:      "id",
 									Crypted:    []byte("accessToken"),
 								},
+								nil,
 								"idToken",
 								time.Time{},
 							)
@@ -1322,9 +1334,10 @@ func Test_tokensForSucceededIDPIntent(t *testing.T) {
 		encryptionAlg crypto.EncryptionAlgorithm
 	}
 	type res struct {
-		accessToken *crypto.CryptoValue
-		idToken     string
-		err         error
+		accessToken  *crypto.CryptoValue
+		refreshToken *crypto.CryptoValue
+		idToken      string
+		err          error
 	}
 	tests := []struct {
 		name string
@@ -1412,6 +1425,37 @@ func Test_tokensForSucceededIDPIntent(t *testing.T) {
 				err:     nil,
 			},
 		},
+		{
+			"oidc tokens with refresh token",
+			args{
+				&openid.Session{
+					Tokens: &oidc.Tokens[*oidc.IDTokenClaims]{
+						Token: &oauth2.Token{
+							AccessToken:  "accessToken",
+							RefreshToken: "refreshToken",
+						},
+						IDToken: "idToken",
+					},
+				},
+				crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			res{
+				accessToken: &crypto.CryptoValue{
+					CryptoType: crypto.TypeEncryption,
+					Algorithm:  "enc",
+					KeyID:      "id",
+					Crypted:    []byte("accessToken"),
+				},
+				refreshToken: &crypto.CryptoValue{
+					CryptoType: crypto.TypeEncryption,
+					Algorithm:  "enc",
+					KeyID:      "id",
+					Crypted:    []byte("refreshToken"),
+				},
+				idToken: "idToken",
+				err:     nil,
+			},
+		},
 		{
 			"jwt tokens",
 			args{
@@ -1457,10 +1501,115 @@ func Test_tokensForSucceededIDPIntent(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotAccessToken, gotIDToken, err := tokensForSucceededIDPIntent(tt.args.session, tt.args.encryptionAlg)
+			gotAccessToken, gotRefreshToken, gotIDToken, err := tokensForSucceededIDPIntent(tt.args.session, tt.args.encryptionAlg)
 			require.ErrorIs(t, err, tt.res.err)
 			assert.Equal(t, tt.res.accessToken, gotAccessToken)
+			assert.Equal(t, tt.res.refreshToken, gotRefreshToken)
 			assert.Equal(t, tt.res.idToken, gotIDToken)
 		})
 	}
 }
+
+func TestCommands_idpIntentMappingCommands(t *testing.T) {
+	claimMapping := &mapping.Mapping{
+		Metadata: []*mapping.MetadataMapping{
+			{Key: "department", Expression: "department"},
+		},
+	}
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx    context.Context
+		intent *IDPIntentWriteModel
+	}
+	type res struct {
+		cmds []eventstore.Command
+		err  error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"no mapping",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewOAuthIDPAddedEvent(context.Background(), &instance.NewAggregate("instance").Aggregate,
+								"idp", "name", "clientID", nil, "auth", "token", "user", "idAttribute", nil, true,
+								nil,
+								rep_idp.Options{},
+							)),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewOAuthIDPAddedEvent(context.Background(), &instance.NewAggregate("instance").Aggregate,
+								"idp", "name", "clientID", nil, "auth", "token", "user", "idAttribute", nil, true,
+								nil,
+								rep_idp.Options{},
+							)),
+					),
+				),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance"),
+				intent: &IDPIntentWriteModel{
+					IDPID:   "idp",
+					IDPUser: []byte(`{"RawInfo":{"department":"engineering"}}`),
+				},
+			},
+			res{},
+		},
+		{
+			"oauth user, mapped metadata",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewOAuthIDPAddedEvent(context.Background(), &instance.NewAggregate("instance").Aggregate,
+								"idp", "name", "clientID", nil, "auth", "token", "user", "idAttribute", nil, true,
+								claimMapping,
+								rep_idp.Options{},
+							)),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewOAuthIDPAddedEvent(context.Background(), &instance.NewAggregate("instance").Aggregate,
+								"idp", "name", "clientID", nil, "auth", "token", "user", "idAttribute", nil, true,
+								claimMapping,
+								rep_idp.Options{},
+							)),
+					),
+					expectFilter(),
+				),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance"),
+				intent: &IDPIntentWriteModel{
+					IDPID:   "idp",
+					IDPUser: []byte(`{"RawInfo":{"department":"engineering"}}`),
+				},
+			},
+			res{
+				cmds: []eventstore.Command{
+					user.NewMetadataSetEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+						"department", []byte("engineering")),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			cmds, err := c.idpIntentMappingCommands(tt.args.ctx, tt.args.intent, "user1", "org1")
+			require.ErrorIs(t, err, tt.res.err)
+			assert.Equal(t, tt.res.cmds, cmds)
+		})
+	}
+}
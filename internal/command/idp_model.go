@@ -15,15 +15,21 @@ import (
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	providers "github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/mapping"
+	"github.com/zitadel/zitadel/internal/idp/providers/adfs"
 	"github.com/zitadel/zitadel/internal/idp/providers/apple"
 	"github.com/zitadel/zitadel/internal/idp/providers/azuread"
+	"github.com/zitadel/zitadel/internal/idp/providers/bitbucket"
+	"github.com/zitadel/zitadel/internal/idp/providers/discord"
 	"github.com/zitadel/zitadel/internal/idp/providers/github"
 	"github.com/zitadel/zitadel/internal/idp/providers/gitlab"
 	"github.com/zitadel/zitadel/internal/idp/providers/google"
 	"github.com/zitadel/zitadel/internal/idp/providers/jwt"
+	"github.com/zitadel/zitadel/internal/idp/providers/keycloak"
 	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
 	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
 	"github.com/zitadel/zitadel/internal/idp/providers/oidc"
+	"github.com/zitadel/zitadel/internal/idp/providers/okta"
 	saml2 "github.com/zitadel/zitadel/internal/idp/providers/saml"
 	"github.com/zitadel/zitadel/internal/idp/providers/saml/requesttracker"
 	"github.com/zitadel/zitadel/internal/repository/idp"
@@ -46,6 +52,7 @@ type OAuthIDPWriteModel struct {
 	Scopes                []string
 	IDAttribute           string
 	UsePKCE               bool
+	ClaimMapping          *mapping.Mapping
 	idp.Options
 
 	State domain.IDPState
@@ -75,6 +82,7 @@ func (wm *OAuthIDPWriteModel) reduceAddedEvent(e *idp.OAuthIDPAddedEvent) {
 	wm.Scopes = e.Scopes
 	wm.IDAttribute = e.IDAttribute
 	wm.UsePKCE = e.UsePKCE
+	wm.ClaimMapping = e.ClaimMapping
 	wm.Options = e.Options
 	wm.State = domain.IDPStateActive
 }
@@ -107,6 +115,9 @@ func (wm *OAuthIDPWriteModel) reduceChangedEvent(e *idp.OAuthIDPChangedEvent) {
 	if e.UsePKCE != nil {
 		wm.UsePKCE = *e.UsePKCE
 	}
+	if e.ClaimMapping != nil {
+		wm.ClaimMapping = e.ClaimMapping
+	}
 	wm.Options.ReduceChanges(e.OptionChanges)
 }
 
@@ -121,6 +132,7 @@ func (wm *OAuthIDPWriteModel) NewChanges(
 	idAttribute string,
 	scopes []string,
 	usePKCE bool,
+	claimMapping *mapping.Mapping,
 	options idp.Options,
 ) ([]idp.OAuthIDPChanges, error) {
 	changes := make([]idp.OAuthIDPChanges, 0)
@@ -157,6 +169,9 @@ func (wm *OAuthIDPWriteModel) NewChanges(
 	if wm.UsePKCE != usePKCE {
 		changes = append(changes, idp.ChangeOAuthUsePKCE(usePKCE))
 	}
+	if claimMappingChanged(wm.ClaimMapping, claimMapping) {
+		changes = append(changes, idp.ChangeOAuthClaimMapping(claimMappingOrEmpty(claimMapping)))
+	}
 	opts := wm.Options.Changes(options)
 	if !opts.IsZero() {
 		changes = append(changes, idp.ChangeOAuthOptions(opts))
@@ -192,6 +207,9 @@ func (wm *OAuthIDPWriteModel) ToProvider(callbackURL string, idpAlg crypto.Encry
 	if wm.IsAutoUpdate {
 		opts = append(opts, oauth.WithAutoUpdate())
 	}
+	if !wm.ClaimMapping.IsEmpty() {
+		opts = append(opts, oauth.WithClaimMapping(wm.ClaimMapping))
+	}
 	return oauth.New(
 		config,
 		wm.Name,
@@ -207,6 +225,10 @@ func (wm *OAuthIDPWriteModel) GetProviderOptions() idp.Options {
 	return wm.Options
 }
 
+func (wm *OAuthIDPWriteModel) GetClaimMapping() *mapping.Mapping {
+	return wm.ClaimMapping
+}
+
 type OIDCIDPWriteModel struct {
 	eventstore.WriteModel
 
@@ -218,6 +240,7 @@ type OIDCIDPWriteModel struct {
 	Scopes           []string
 	IsIDTokenMapping bool
 	UsePKCE          bool
+	ClaimMapping     *mapping.Mapping
 	idp.Options
 
 	State domain.IDPState
@@ -259,6 +282,7 @@ func (wm *OIDCIDPWriteModel) reduceAddedEvent(e *idp.OIDCIDPAddedEvent) {
 	wm.Scopes = e.Scopes
 	wm.IsIDTokenMapping = e.IsIDTokenMapping
 	wm.UsePKCE = e.UsePKCE
+	wm.ClaimMapping = e.ClaimMapping
 	wm.Options = e.Options
 	wm.State = domain.IDPStateActive
 }
@@ -285,6 +309,9 @@ func (wm *OIDCIDPWriteModel) reduceChangedEvent(e *idp.OIDCIDPChangedEvent) {
 	if e.UsePKCE != nil {
 		wm.UsePKCE = *e.UsePKCE
 	}
+	if e.ClaimMapping != nil {
+		wm.ClaimMapping = e.ClaimMapping
+	}
 	wm.Options.ReduceChanges(e.OptionChanges)
 }
 
@@ -296,6 +323,7 @@ func (wm *OIDCIDPWriteModel) NewChanges(
 	secretCrypto crypto.EncryptionAlgorithm,
 	scopes []string,
 	idTokenMapping, usePKCE bool,
+	claimMapping *mapping.Mapping,
 	options idp.Options,
 ) ([]idp.OIDCIDPChanges, error) {
 	changes := make([]idp.OIDCIDPChanges, 0)
@@ -326,6 +354,9 @@ func (wm *OIDCIDPWriteModel) NewChanges(
 	if wm.UsePKCE != usePKCE {
 		changes = append(changes, idp.ChangeOIDCUsePKCE(usePKCE))
 	}
+	if claimMappingChanged(wm.ClaimMapping, claimMapping) {
+		changes = append(changes, idp.ChangeOIDCClaimMapping(claimMappingOrEmpty(claimMapping)))
+	}
 	opts := wm.Options.Changes(options)
 	if !opts.IsZero() {
 		changes = append(changes, idp.ChangeOIDCOptions(opts))
@@ -333,6 +364,22 @@ func (wm *OIDCIDPWriteModel) NewChanges(
 	return changes, nil
 }
 
+// claimMappingChanged treats nil and empty mappings as equal
+func claimMappingChanged(current, claimMapping *mapping.Mapping) bool {
+	if current.IsEmpty() && claimMapping.IsEmpty() {
+		return false
+	}
+	return !reflect.DeepEqual(current, claimMapping)
+}
+
+// claimMappingOrEmpty ensures that a removed mapping is stored as an empty (not nil) mapping in the changed event
+func claimMappingOrEmpty(claimMapping *mapping.Mapping) *mapping.Mapping {
+	if claimMapping == nil {
+		return &mapping.Mapping{}
+	}
+	return claimMapping
+}
+
 // reduceIDPConfigAddedEvent handles old idpConfig events
 func (wm *OIDCIDPWriteModel) reduceIDPConfigAddedEvent(e *idpconfig.IDPConfigAddedEvent) {
 	wm.Name = e.Name
@@ -399,6 +446,9 @@ func (wm *OIDCIDPWriteModel) ToProvider(callbackURL string, idpAlg crypto.Encryp
 	if wm.IsAutoUpdate {
 		opts = append(opts, oidc.WithAutoUpdate())
 	}
+	if !wm.ClaimMapping.IsEmpty() {
+		opts = append(opts, oidc.WithClaimMapping(wm.ClaimMapping))
+	}
 	return oidc.New(
 		wm.Name,
 		wm.Issuer,
@@ -415,6 +465,10 @@ func (wm *OIDCIDPWriteModel) GetProviderOptions() idp.Options {
 	return wm.Options
 }
 
+func (wm *OIDCIDPWriteModel) GetClaimMapping() *mapping.Mapping {
+	return wm.ClaimMapping
+}
+
 type JWTIDPWriteModel struct {
 	eventstore.WriteModel
 
@@ -1929,168 +1983,818 @@ func (wm *SAMLIDPWriteModel) GetProviderOptions() idp.Options {
 	return wm.Options
 }
 
-type IDPRemoveWriteModel struct {
+type OktaIDPWriteModel struct {
 	eventstore.WriteModel
 
-	ID    string
+	ID           string
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret *crypto.CryptoValue
+	Scopes       []string
+	idp.Options
+
 	State domain.IDPState
 }
 
-func (wm *IDPRemoveWriteModel) Reduce() error {
+func (wm *OktaIDPWriteModel) Reduce() error {
 	for _, event := range wm.Events {
 		switch e := event.(type) {
-		case *idp.OAuthIDPAddedEvent:
-			wm.reduceAdded(e.ID)
-		case *idp.OIDCIDPAddedEvent:
-			wm.reduceAdded(e.ID)
-		case *idp.JWTIDPAddedEvent:
-			wm.reduceAdded(e.ID)
-		case *idp.AzureADIDPAddedEvent:
-			wm.reduceAdded(e.ID)
-		case *idp.GitHubIDPAddedEvent:
-			wm.reduceAdded(e.ID)
-		case *idp.GitHubEnterpriseIDPAddedEvent:
-			wm.reduceAdded(e.ID)
-		case *idp.GitLabIDPAddedEvent:
-			wm.reduceAdded(e.ID)
-		case *idp.GitLabSelfHostedIDPAddedEvent:
-			wm.reduceAdded(e.ID)
-		case *idp.GoogleIDPAddedEvent:
-			wm.reduceAdded(e.ID)
-		case *idp.LDAPIDPAddedEvent:
-			wm.reduceAdded(e.ID)
-		case *idp.AppleIDPAddedEvent:
-			wm.reduceAdded(e.ID)
-		case *idp.SAMLIDPAddedEvent:
-			wm.reduceAdded(e.ID)
+		case *idp.OktaIDPAddedEvent:
+			wm.reduceAddedEvent(e)
+		case *idp.OktaIDPChangedEvent:
+			wm.reduceChangedEvent(e)
 		case *idp.RemovedEvent:
-			wm.reduceRemoved(e.ID)
-		case *idpconfig.IDPConfigAddedEvent:
-			wm.reduceAdded(e.ConfigID)
-		case *idpconfig.IDPConfigRemovedEvent:
-			wm.reduceRemoved(e.ConfigID)
+			wm.State = domain.IDPStateRemoved
 		}
 	}
 	return wm.WriteModel.Reduce()
 }
 
-func (wm *IDPRemoveWriteModel) reduceAdded(id string) {
-	if wm.ID != id {
-		return
-	}
+func (wm *OktaIDPWriteModel) reduceAddedEvent(e *idp.OktaIDPAddedEvent) {
+	wm.Name = e.Name
+	wm.Issuer = e.Issuer
+	wm.ClientID = e.ClientID
+	wm.ClientSecret = e.ClientSecret
+	wm.Scopes = e.Scopes
+	wm.Options = e.Options
 	wm.State = domain.IDPStateActive
 }
 
-func (wm *IDPRemoveWriteModel) reduceRemoved(id string) {
-	if wm.ID != id {
-		return
+func (wm *OktaIDPWriteModel) reduceChangedEvent(e *idp.OktaIDPChangedEvent) {
+	if e.ClientID != nil {
+		wm.ClientID = *e.ClientID
 	}
-	wm.State = domain.IDPStateRemoved
+	if e.ClientSecret != nil {
+		wm.ClientSecret = e.ClientSecret
+	}
+	if e.Name != nil {
+		wm.Name = *e.Name
+	}
+	if e.Issuer != nil {
+		wm.Issuer = *e.Issuer
+	}
+	if e.Scopes != nil {
+		wm.Scopes = e.Scopes
+	}
+	wm.Options.ReduceChanges(e.OptionChanges)
 }
 
-type IDPTypeWriteModel struct {
-	eventstore.WriteModel
-
-	ID    string
-	Type  domain.IDPType
-	State domain.IDPState
+func (wm *OktaIDPWriteModel) NewChanges(
+	name string,
+	issuer string,
+	clientID string,
+	clientSecretString string,
+	secretCrypto crypto.EncryptionAlgorithm,
+	scopes []string,
+	options idp.Options,
+) ([]idp.OktaIDPChanges, error) {
+	changes := make([]idp.OktaIDPChanges, 0)
+	var clientSecret *crypto.CryptoValue
+	var err error
+	if clientSecretString != "" {
+		clientSecret, err = crypto.Crypt([]byte(clientSecretString), secretCrypto)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, idp.ChangeOktaClientSecret(clientSecret))
+	}
+	if wm.ClientID != clientID {
+		changes = append(changes, idp.ChangeOktaClientID(clientID))
+	}
+	if wm.Name != name {
+		changes = append(changes, idp.ChangeOktaName(name))
+	}
+	if wm.Issuer != issuer {
+		changes = append(changes, idp.ChangeOktaIssuer(issuer))
+	}
+	if !reflect.DeepEqual(wm.Scopes, scopes) {
+		changes = append(changes, idp.ChangeOktaScopes(scopes))
+	}
+	opts := wm.Options.Changes(options)
+	if !opts.IsZero() {
+		changes = append(changes, idp.ChangeOktaOptions(opts))
+	}
+	return changes, nil
 }
 
-func NewIDPTypeWriteModel(id string) *IDPTypeWriteModel {
-	return &IDPTypeWriteModel{
-		ID: id,
+func (wm *OktaIDPWriteModel) ToProvider(callbackURL string, idpAlg crypto.EncryptionAlgorithm) (providers.Provider, error) {
+	secret, err := crypto.DecryptString(wm.ClientSecret, idpAlg)
+	if err != nil {
+		return nil, err
+	}
+	opts := make([]oidc.ProviderOpts, 0, 4)
+	if wm.IsCreationAllowed {
+		opts = append(opts, oidc.WithCreationAllowed())
+	}
+	if wm.IsLinkingAllowed {
+		opts = append(opts, oidc.WithLinkingAllowed())
+	}
+	if wm.IsAutoCreation {
+		opts = append(opts, oidc.WithAutoCreation())
+	}
+	if wm.IsAutoUpdate {
+		opts = append(opts, oidc.WithAutoUpdate())
 	}
+	return okta.New(
+		wm.Name,
+		wm.Issuer,
+		wm.ClientID,
+		secret,
+		callbackURL,
+		wm.Scopes,
+		opts...,
+	)
 }
 
-func (wm *IDPTypeWriteModel) Reduce() error {
+func (wm *OktaIDPWriteModel) GetProviderOptions() idp.Options {
+	return wm.Options
+}
+
+type KeycloakIDPWriteModel struct {
+	eventstore.WriteModel
+
+	ID           string
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret *crypto.CryptoValue
+	Scopes       []string
+	idp.Options
+
+	State domain.IDPState
+}
+
+func (wm *KeycloakIDPWriteModel) Reduce() error {
 	for _, event := range wm.Events {
 		switch e := event.(type) {
-		case *instance.OAuthIDPAddedEvent:
-			wm.reduceAdded(e.ID, domain.IDPTypeOAuth, e.Aggregate())
-		case *org.OAuthIDPAddedEvent:
-			wm.reduceAdded(e.ID, domain.IDPTypeOAuth, e.Aggregate())
-		case *instance.OIDCIDPAddedEvent:
-			wm.reduceAdded(e.ID, domain.IDPTypeOIDC, e.Aggregate())
-		case *org.OIDCIDPAddedEvent:
-			wm.reduceAdded(e.ID, domain.IDPTypeOIDC, e.Aggregate())
-		case *instance.JWTIDPAddedEvent:
-			wm.reduceAdded(e.ID, domain.IDPTypeJWT, e.Aggregate())
-		case *org.JWTIDPAddedEvent:
-			wm.reduceAdded(e.ID, domain.IDPTypeJWT, e.Aggregate())
-		case *instance.AzureADIDPAddedEvent:
-			wm.reduceAdded(e.ID, domain.IDPTypeAzureAD, e.Aggregate())
-		case *org.AzureADIDPAddedEvent:
-			wm.reduceAdded(e.ID, domain.IDPTypeAzureAD, e.Aggregate())
-		case *instance.GitHubIDPAddedEvent:
-			wm.reduceAdded(e.ID, domain.IDPTypeGitHub, e.Aggregate())
-		case *org.GitHubIDPAddedEvent:
-			wm.reduceAdded(e.ID, domain.IDPTypeGitHub, e.Aggregate())
-		case *instance.GitHubEnterpriseIDPAddedEvent:
-			wm.reduceAdded(e.ID, domain.IDPTypeGitHubEnterprise, e.Aggregate())
-		case *org.GitHubEnterpriseIDPAddedEvent:
-			wm.reduceAdded(e.ID, domain.IDPTypeGitHubEnterprise, e.Aggregate())
-		case *instance.GitLabIDPAddedEvent:
-			wm.reduceAdded(e.ID, domain.IDPTypeGitLab, e.Aggregate())
-		case *org.GitLabIDPAddedEvent:
-			wm.reduceAdded(e.ID, domain.IDPTypeGitLab, e.Aggregate())
-		case *instance.GitLabSelfHostedIDPAddedEvent:
-			wm.reduceAdded(e.ID, domain.IDPTypeGitLabSelfHosted, e.Aggregate())
-		case *org.GitLabSelfHostedIDPAddedEvent:
-			wm.reduceAdded(e.ID, domain.IDPTypeGitLabSelfHosted, e.Aggregate())
-		case *instance.GoogleIDPAddedEvent:
-			wm.reduceAdded(e.ID, domain.IDPTypeGoogle, e.Aggregate())
-		case *org.GoogleIDPAddedEvent:
-			wm.reduceAdded(e.ID, domain.IDPTypeGoogle, e.Aggregate())
-		case *instance.LDAPIDPAddedEvent:
-			wm.reduceAdded(e.ID, domain.IDPTypeLDAP, e.Aggregate())
-		case *org.LDAPIDPAddedEvent:
-			wm.reduceAdded(e.ID, domain.IDPTypeLDAP, e.Aggregate())
-		case *instance.AppleIDPAddedEvent:
-			wm.reduceAdded(e.ID, domain.IDPTypeApple, e.Aggregate())
-		case *org.AppleIDPAddedEvent:
-			wm.reduceAdded(e.ID, domain.IDPTypeApple, e.Aggregate())
-		case *instance.SAMLIDPAddedEvent:
-			wm.reduceAdded(e.ID, domain.IDPTypeSAML, e.Aggregate())
-		case *org.SAMLIDPAddedEvent:
-			wm.reduceAdded(e.ID, domain.IDPTypeSAML, e.Aggregate())
-		case *instance.OIDCIDPMigratedAzureADEvent:
-			wm.reduceChanged(e.ID, domain.IDPTypeAzureAD)
-		case *org.OIDCIDPMigratedAzureADEvent:
-			wm.reduceChanged(e.ID, domain.IDPTypeAzureAD)
-		case *instance.OIDCIDPMigratedGoogleEvent:
-			wm.reduceChanged(e.ID, domain.IDPTypeGoogle)
-		case *org.OIDCIDPMigratedGoogleEvent:
-			wm.reduceChanged(e.ID, domain.IDPTypeGoogle)
-		case *instance.IDPRemovedEvent:
-			wm.reduceRemoved(e.ID)
-		case *org.IDPRemovedEvent:
-			wm.reduceRemoved(e.ID)
-		case *instance.IDPConfigAddedEvent:
-			if e.Typ == domain.IDPConfigTypeOIDC {
-				wm.reduceAdded(e.ConfigID, domain.IDPTypeOIDC, e.Aggregate())
-			} else if e.Typ == domain.IDPConfigTypeJWT {
-				wm.reduceAdded(e.ConfigID, domain.IDPTypeJWT, e.Aggregate())
-			}
-		case *org.IDPConfigAddedEvent:
-			if e.Typ == domain.IDPConfigTypeOIDC {
-				wm.reduceAdded(e.ConfigID, domain.IDPTypeOIDC, e.Aggregate())
-			} else if e.Typ == domain.IDPConfigTypeJWT {
-				wm.reduceAdded(e.ConfigID, domain.IDPTypeJWT, e.Aggregate())
-			}
-		case *instance.IDPConfigRemovedEvent:
-			wm.reduceRemoved(e.ConfigID)
-		case *org.IDPConfigRemovedEvent:
-			wm.reduceRemoved(e.ConfigID)
+		case *idp.KeycloakIDPAddedEvent:
+			wm.reduceAddedEvent(e)
+		case *idp.KeycloakIDPChangedEvent:
+			wm.reduceChangedEvent(e)
+		case *idp.RemovedEvent:
+			wm.State = domain.IDPStateRemoved
 		}
 	}
 	return wm.WriteModel.Reduce()
 }
 
-func (wm *IDPTypeWriteModel) reduceAdded(id string, t domain.IDPType, agg *eventstore.Aggregate) {
-	if wm.ID != id {
-		return
-	}
-	wm.Type = t
+func (wm *KeycloakIDPWriteModel) reduceAddedEvent(e *idp.KeycloakIDPAddedEvent) {
+	wm.Name = e.Name
+	wm.Issuer = e.Issuer
+	wm.ClientID = e.ClientID
+	wm.ClientSecret = e.ClientSecret
+	wm.Scopes = e.Scopes
+	wm.Options = e.Options
+	wm.State = domain.IDPStateActive
+}
+
+func (wm *KeycloakIDPWriteModel) reduceChangedEvent(e *idp.KeycloakIDPChangedEvent) {
+	if e.ClientID != nil {
+		wm.ClientID = *e.ClientID
+	}
+	if e.ClientSecret != nil {
+		wm.ClientSecret = e.ClientSecret
+	}
+	if e.Name != nil {
+		wm.Name = *e.Name
+	}
+	if e.Issuer != nil {
+		wm.Issuer = *e.Issuer
+	}
+	if e.Scopes != nil {
+		wm.Scopes = e.Scopes
+	}
+	wm.Options.ReduceChanges(e.OptionChanges)
+}
+
+func (wm *KeycloakIDPWriteModel) NewChanges(
+	name string,
+	issuer string,
+	clientID string,
+	clientSecretString string,
+	secretCrypto crypto.EncryptionAlgorithm,
+	scopes []string,
+	options idp.Options,
+) ([]idp.KeycloakIDPChanges, error) {
+	changes := make([]idp.KeycloakIDPChanges, 0)
+	var clientSecret *crypto.CryptoValue
+	var err error
+	if clientSecretString != "" {
+		clientSecret, err = crypto.Crypt([]byte(clientSecretString), secretCrypto)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, idp.ChangeKeycloakClientSecret(clientSecret))
+	}
+	if wm.ClientID != clientID {
+		changes = append(changes, idp.ChangeKeycloakClientID(clientID))
+	}
+	if wm.Name != name {
+		changes = append(changes, idp.ChangeKeycloakName(name))
+	}
+	if wm.Issuer != issuer {
+		changes = append(changes, idp.ChangeKeycloakIssuer(issuer))
+	}
+	if !reflect.DeepEqual(wm.Scopes, scopes) {
+		changes = append(changes, idp.ChangeKeycloakScopes(scopes))
+	}
+	opts := wm.Options.Changes(options)
+	if !opts.IsZero() {
+		changes = append(changes, idp.ChangeKeycloakOptions(opts))
+	}
+	return changes, nil
+}
+
+func (wm *KeycloakIDPWriteModel) ToProvider(callbackURL string, idpAlg crypto.EncryptionAlgorithm) (providers.Provider, error) {
+	secret, err := crypto.DecryptString(wm.ClientSecret, idpAlg)
+	if err != nil {
+		return nil, err
+	}
+	opts := make([]oidc.ProviderOpts, 0, 4)
+	if wm.IsCreationAllowed {
+		opts = append(opts, oidc.WithCreationAllowed())
+	}
+	if wm.IsLinkingAllowed {
+		opts = append(opts, oidc.WithLinkingAllowed())
+	}
+	if wm.IsAutoCreation {
+		opts = append(opts, oidc.WithAutoCreation())
+	}
+	if wm.IsAutoUpdate {
+		opts = append(opts, oidc.WithAutoUpdate())
+	}
+	return keycloak.New(
+		wm.Name,
+		wm.Issuer,
+		wm.ClientID,
+		secret,
+		callbackURL,
+		wm.Scopes,
+		opts...,
+	)
+}
+
+func (wm *KeycloakIDPWriteModel) GetProviderOptions() idp.Options {
+	return wm.Options
+}
+
+type ADFSIDPWriteModel struct {
+	eventstore.WriteModel
+
+	ID           string
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret *crypto.CryptoValue
+	Scopes       []string
+	idp.Options
+
+	State domain.IDPState
+}
+
+func (wm *ADFSIDPWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *idp.ADFSIDPAddedEvent:
+			wm.reduceAddedEvent(e)
+		case *idp.ADFSIDPChangedEvent:
+			wm.reduceChangedEvent(e)
+		case *idp.RemovedEvent:
+			wm.State = domain.IDPStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *ADFSIDPWriteModel) reduceAddedEvent(e *idp.ADFSIDPAddedEvent) {
+	wm.Name = e.Name
+	wm.Issuer = e.Issuer
+	wm.ClientID = e.ClientID
+	wm.ClientSecret = e.ClientSecret
+	wm.Scopes = e.Scopes
+	wm.Options = e.Options
+	wm.State = domain.IDPStateActive
+}
+
+func (wm *ADFSIDPWriteModel) reduceChangedEvent(e *idp.ADFSIDPChangedEvent) {
+	if e.ClientID != nil {
+		wm.ClientID = *e.ClientID
+	}
+	if e.ClientSecret != nil {
+		wm.ClientSecret = e.ClientSecret
+	}
+	if e.Name != nil {
+		wm.Name = *e.Name
+	}
+	if e.Issuer != nil {
+		wm.Issuer = *e.Issuer
+	}
+	if e.Scopes != nil {
+		wm.Scopes = e.Scopes
+	}
+	wm.Options.ReduceChanges(e.OptionChanges)
+}
+
+func (wm *ADFSIDPWriteModel) NewChanges(
+	name string,
+	issuer string,
+	clientID string,
+	clientSecretString string,
+	secretCrypto crypto.EncryptionAlgorithm,
+	scopes []string,
+	options idp.Options,
+) ([]idp.ADFSIDPChanges, error) {
+	changes := make([]idp.ADFSIDPChanges, 0)
+	var clientSecret *crypto.CryptoValue
+	var err error
+	if clientSecretString != "" {
+		clientSecret, err = crypto.Crypt([]byte(clientSecretString), secretCrypto)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, idp.ChangeADFSClientSecret(clientSecret))
+	}
+	if wm.ClientID != clientID {
+		changes = append(changes, idp.ChangeADFSClientID(clientID))
+	}
+	if wm.Name != name {
+		changes = append(changes, idp.ChangeADFSName(name))
+	}
+	if wm.Issuer != issuer {
+		changes = append(changes, idp.ChangeADFSIssuer(issuer))
+	}
+	if !reflect.DeepEqual(wm.Scopes, scopes) {
+		changes = append(changes, idp.ChangeADFSScopes(scopes))
+	}
+	opts := wm.Options.Changes(options)
+	if !opts.IsZero() {
+		changes = append(changes, idp.ChangeADFSOptions(opts))
+	}
+	return changes, nil
+}
+
+func (wm *ADFSIDPWriteModel) ToProvider(callbackURL string, idpAlg crypto.EncryptionAlgorithm) (providers.Provider, error) {
+	secret, err := crypto.DecryptString(wm.ClientSecret, idpAlg)
+	if err != nil {
+		return nil, err
+	}
+	opts := make([]oidc.ProviderOpts, 0, 4)
+	if wm.IsCreationAllowed {
+		opts = append(opts, oidc.WithCreationAllowed())
+	}
+	if wm.IsLinkingAllowed {
+		opts = append(opts, oidc.WithLinkingAllowed())
+	}
+	if wm.IsAutoCreation {
+		opts = append(opts, oidc.WithAutoCreation())
+	}
+	if wm.IsAutoUpdate {
+		opts = append(opts, oidc.WithAutoUpdate())
+	}
+	return adfs.New(
+		wm.Name,
+		wm.Issuer,
+		wm.ClientID,
+		secret,
+		callbackURL,
+		wm.Scopes,
+		opts...,
+	)
+}
+
+func (wm *ADFSIDPWriteModel) GetProviderOptions() idp.Options {
+	return wm.Options
+}
+
+type BitbucketIDPWriteModel struct {
+	eventstore.WriteModel
+
+	ID           string
+	Name         string
+	ClientID     string
+	ClientSecret *crypto.CryptoValue
+	Scopes       []string
+	idp.Options
+
+	State domain.IDPState
+}
+
+func (wm *BitbucketIDPWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *idp.BitbucketIDPAddedEvent:
+			wm.reduceAddedEvent(e)
+		case *idp.BitbucketIDPChangedEvent:
+			wm.reduceChangedEvent(e)
+		case *idp.RemovedEvent:
+			wm.State = domain.IDPStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *BitbucketIDPWriteModel) reduceAddedEvent(e *idp.BitbucketIDPAddedEvent) {
+	wm.Name = e.Name
+	wm.ClientID = e.ClientID
+	wm.ClientSecret = e.ClientSecret
+	wm.Scopes = e.Scopes
+	wm.Options = e.Options
+	wm.State = domain.IDPStateActive
+}
+
+func (wm *BitbucketIDPWriteModel) reduceChangedEvent(e *idp.BitbucketIDPChangedEvent) {
+	if e.Name != nil {
+		wm.Name = *e.Name
+	}
+	if e.ClientID != nil {
+		wm.ClientID = *e.ClientID
+	}
+	if e.ClientSecret != nil {
+		wm.ClientSecret = e.ClientSecret
+	}
+	if e.Scopes != nil {
+		wm.Scopes = e.Scopes
+	}
+	wm.Options.ReduceChanges(e.OptionChanges)
+}
+
+func (wm *BitbucketIDPWriteModel) NewChanges(
+	name,
+	clientID,
+	clientSecretString string,
+	secretCrypto crypto.EncryptionAlgorithm,
+	scopes []string,
+	options idp.Options,
+) ([]idp.BitbucketIDPChanges, error) {
+	changes := make([]idp.BitbucketIDPChanges, 0)
+	var clientSecret *crypto.CryptoValue
+	var err error
+	if clientSecretString != "" {
+		clientSecret, err = crypto.Crypt([]byte(clientSecretString), secretCrypto)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, idp.ChangeBitbucketClientSecret(clientSecret))
+	}
+	if wm.Name != name {
+		changes = append(changes, idp.ChangeBitbucketName(name))
+	}
+	if wm.ClientID != clientID {
+		changes = append(changes, idp.ChangeBitbucketClientID(clientID))
+	}
+	if !reflect.DeepEqual(wm.Scopes, scopes) {
+		changes = append(changes, idp.ChangeBitbucketScopes(scopes))
+	}
+
+	opts := wm.Options.Changes(options)
+	if !opts.IsZero() {
+		changes = append(changes, idp.ChangeBitbucketOptions(opts))
+	}
+	return changes, nil
+}
+
+func (wm *BitbucketIDPWriteModel) ToProvider(callbackURL string, idpAlg crypto.EncryptionAlgorithm) (providers.Provider, error) {
+	secret, err := crypto.DecryptString(wm.ClientSecret, idpAlg)
+	if err != nil {
+		return nil, err
+	}
+	oauthOpts := make([]oauth.ProviderOpts, 0, 4)
+	if wm.IsCreationAllowed {
+		oauthOpts = append(oauthOpts, oauth.WithCreationAllowed())
+	}
+	if wm.IsLinkingAllowed {
+		oauthOpts = append(oauthOpts, oauth.WithLinkingAllowed())
+	}
+	if wm.IsAutoCreation {
+		oauthOpts = append(oauthOpts, oauth.WithAutoCreation())
+	}
+	if wm.IsAutoUpdate {
+		oauthOpts = append(oauthOpts, oauth.WithAutoUpdate())
+	}
+	return bitbucket.New(
+		wm.ClientID,
+		secret,
+		callbackURL,
+		wm.Scopes,
+		oauthOpts...,
+	)
+}
+
+func (wm *BitbucketIDPWriteModel) GetProviderOptions() idp.Options {
+	return wm.Options
+}
+
+type DiscordIDPWriteModel struct {
+	eventstore.WriteModel
+
+	ID           string
+	Name         string
+	ClientID     string
+	ClientSecret *crypto.CryptoValue
+	Scopes       []string
+	idp.Options
+
+	State domain.IDPState
+}
+
+func (wm *DiscordIDPWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *idp.DiscordIDPAddedEvent:
+			wm.reduceAddedEvent(e)
+		case *idp.DiscordIDPChangedEvent:
+			wm.reduceChangedEvent(e)
+		case *idp.RemovedEvent:
+			wm.State = domain.IDPStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *DiscordIDPWriteModel) reduceAddedEvent(e *idp.DiscordIDPAddedEvent) {
+	wm.Name = e.Name
+	wm.ClientID = e.ClientID
+	wm.ClientSecret = e.ClientSecret
+	wm.Scopes = e.Scopes
+	wm.Options = e.Options
+	wm.State = domain.IDPStateActive
+}
+
+func (wm *DiscordIDPWriteModel) reduceChangedEvent(e *idp.DiscordIDPChangedEvent) {
+	if e.Name != nil {
+		wm.Name = *e.Name
+	}
+	if e.ClientID != nil {
+		wm.ClientID = *e.ClientID
+	}
+	if e.ClientSecret != nil {
+		wm.ClientSecret = e.ClientSecret
+	}
+	if e.Scopes != nil {
+		wm.Scopes = e.Scopes
+	}
+	wm.Options.ReduceChanges(e.OptionChanges)
+}
+
+func (wm *DiscordIDPWriteModel) NewChanges(
+	name,
+	clientID,
+	clientSecretString string,
+	secretCrypto crypto.EncryptionAlgorithm,
+	scopes []string,
+	options idp.Options,
+) ([]idp.DiscordIDPChanges, error) {
+	changes := make([]idp.DiscordIDPChanges, 0)
+	var clientSecret *crypto.CryptoValue
+	var err error
+	if clientSecretString != "" {
+		clientSecret, err = crypto.Crypt([]byte(clientSecretString), secretCrypto)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, idp.ChangeDiscordClientSecret(clientSecret))
+	}
+	if wm.Name != name {
+		changes = append(changes, idp.ChangeDiscordName(name))
+	}
+	if wm.ClientID != clientID {
+		changes = append(changes, idp.ChangeDiscordClientID(clientID))
+	}
+	if !reflect.DeepEqual(wm.Scopes, scopes) {
+		changes = append(changes, idp.ChangeDiscordScopes(scopes))
+	}
+
+	opts := wm.Options.Changes(options)
+	if !opts.IsZero() {
+		changes = append(changes, idp.ChangeDiscordOptions(opts))
+	}
+	return changes, nil
+}
+
+func (wm *DiscordIDPWriteModel) ToProvider(callbackURL string, idpAlg crypto.EncryptionAlgorithm) (providers.Provider, error) {
+	secret, err := crypto.DecryptString(wm.ClientSecret, idpAlg)
+	if err != nil {
+		return nil, err
+	}
+	oauthOpts := make([]oauth.ProviderOpts, 0, 4)
+	if wm.IsCreationAllowed {
+		oauthOpts = append(oauthOpts, oauth.WithCreationAllowed())
+	}
+	if wm.IsLinkingAllowed {
+		oauthOpts = append(oauthOpts, oauth.WithLinkingAllowed())
+	}
+	if wm.IsAutoCreation {
+		oauthOpts = append(oauthOpts, oauth.WithAutoCreation())
+	}
+	if wm.IsAutoUpdate {
+		oauthOpts = append(oauthOpts, oauth.WithAutoUpdate())
+	}
+	return discord.New(
+		wm.ClientID,
+		secret,
+		callbackURL,
+		wm.Scopes,
+		oauthOpts...,
+	)
+}
+
+func (wm *DiscordIDPWriteModel) GetProviderOptions() idp.Options {
+	return wm.Options
+}
+
+type IDPRemoveWriteModel struct {
+	eventstore.WriteModel
+
+	ID    string
+	State domain.IDPState
+}
+
+func (wm *IDPRemoveWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *idp.OAuthIDPAddedEvent:
+			wm.reduceAdded(e.ID)
+		case *idp.OIDCIDPAddedEvent:
+			wm.reduceAdded(e.ID)
+		case *idp.JWTIDPAddedEvent:
+			wm.reduceAdded(e.ID)
+		case *idp.AzureADIDPAddedEvent:
+			wm.reduceAdded(e.ID)
+		case *idp.GitHubIDPAddedEvent:
+			wm.reduceAdded(e.ID)
+		case *idp.GitHubEnterpriseIDPAddedEvent:
+			wm.reduceAdded(e.ID)
+		case *idp.GitLabIDPAddedEvent:
+			wm.reduceAdded(e.ID)
+		case *idp.GitLabSelfHostedIDPAddedEvent:
+			wm.reduceAdded(e.ID)
+		case *idp.OktaIDPAddedEvent:
+			wm.reduceAdded(e.ID)
+		case *idp.KeycloakIDPAddedEvent:
+			wm.reduceAdded(e.ID)
+		case *idp.ADFSIDPAddedEvent:
+			wm.reduceAdded(e.ID)
+		case *idp.BitbucketIDPAddedEvent:
+			wm.reduceAdded(e.ID)
+		case *idp.DiscordIDPAddedEvent:
+			wm.reduceAdded(e.ID)
+		case *idp.GoogleIDPAddedEvent:
+			wm.reduceAdded(e.ID)
+		case *idp.LDAPIDPAddedEvent:
+			wm.reduceAdded(e.ID)
+		case *idp.AppleIDPAddedEvent:
+			wm.reduceAdded(e.ID)
+		case *idp.SAMLIDPAddedEvent:
+			wm.reduceAdded(e.ID)
+		case *idp.RemovedEvent:
+			wm.reduceRemoved(e.ID)
+		case *idpconfig.IDPConfigAddedEvent:
+			wm.reduceAdded(e.ConfigID)
+		case *idpconfig.IDPConfigRemovedEvent:
+			wm.reduceRemoved(e.ConfigID)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *IDPRemoveWriteModel) reduceAdded(id string) {
+	if wm.ID != id {
+		return
+	}
+	wm.State = domain.IDPStateActive
+}
+
+func (wm *IDPRemoveWriteModel) reduceRemoved(id string) {
+	if wm.ID != id {
+		return
+	}
+	wm.State = domain.IDPStateRemoved
+}
+
+type IDPTypeWriteModel struct {
+	eventstore.WriteModel
+
+	ID    string
+	Type  domain.IDPType
+	State domain.IDPState
+}
+
+func NewIDPTypeWriteModel(id string) *IDPTypeWriteModel {
+	return &IDPTypeWriteModel{
+		ID: id,
+	}
+}
+
+func (wm *IDPTypeWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *instance.OAuthIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeOAuth, e.Aggregate())
+		case *org.OAuthIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeOAuth, e.Aggregate())
+		case *instance.OIDCIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeOIDC, e.Aggregate())
+		case *org.OIDCIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeOIDC, e.Aggregate())
+		case *instance.JWTIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeJWT, e.Aggregate())
+		case *org.JWTIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeJWT, e.Aggregate())
+		case *instance.AzureADIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeAzureAD, e.Aggregate())
+		case *org.AzureADIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeAzureAD, e.Aggregate())
+		case *instance.GitHubIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeGitHub, e.Aggregate())
+		case *org.GitHubIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeGitHub, e.Aggregate())
+		case *instance.GitHubEnterpriseIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeGitHubEnterprise, e.Aggregate())
+		case *org.GitHubEnterpriseIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeGitHubEnterprise, e.Aggregate())
+		case *instance.GitLabIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeGitLab, e.Aggregate())
+		case *org.GitLabIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeGitLab, e.Aggregate())
+		case *instance.GitLabSelfHostedIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeGitLabSelfHosted, e.Aggregate())
+		case *instance.OktaIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeOkta, e.Aggregate())
+		case *instance.KeycloakIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeKeycloak, e.Aggregate())
+		case *instance.ADFSIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeADFS, e.Aggregate())
+		case *instance.BitbucketIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeBitbucket, e.Aggregate())
+		case *instance.DiscordIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeDiscord, e.Aggregate())
+		case *org.GitLabSelfHostedIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeGitLabSelfHosted, e.Aggregate())
+		case *org.OktaIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeOkta, e.Aggregate())
+		case *org.KeycloakIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeKeycloak, e.Aggregate())
+		case *org.ADFSIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeADFS, e.Aggregate())
+		case *org.BitbucketIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeBitbucket, e.Aggregate())
+		case *org.DiscordIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeDiscord, e.Aggregate())
+		case *instance.GoogleIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeGoogle, e.Aggregate())
+		case *org.GoogleIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeGoogle, e.Aggregate())
+		case *instance.LDAPIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeLDAP, e.Aggregate())
+		case *org.LDAPIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeLDAP, e.Aggregate())
+		case *instance.AppleIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeApple, e.Aggregate())
+		case *org.AppleIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeApple, e.Aggregate())
+		case *instance.SAMLIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeSAML, e.Aggregate())
+		case *org.SAMLIDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeSAML, e.Aggregate())
+		case *instance.OIDCIDPMigratedAzureADEvent:
+			wm.reduceChanged(e.ID, domain.IDPTypeAzureAD)
+		case *org.OIDCIDPMigratedAzureADEvent:
+			wm.reduceChanged(e.ID, domain.IDPTypeAzureAD)
+		case *instance.OIDCIDPMigratedGoogleEvent:
+			wm.reduceChanged(e.ID, domain.IDPTypeGoogle)
+		case *org.OIDCIDPMigratedGoogleEvent:
+			wm.reduceChanged(e.ID, domain.IDPTypeGoogle)
+		case *instance.IDPRemovedEvent:
+			wm.reduceRemoved(e.ID)
+		case *org.IDPRemovedEvent:
+			wm.reduceRemoved(e.ID)
+		case *instance.IDPConfigAddedEvent:
+			if e.Typ == domain.IDPConfigTypeOIDC {
+				wm.reduceAdded(e.ConfigID, domain.IDPTypeOIDC, e.Aggregate())
+			} else if e.Typ == domain.IDPConfigTypeJWT {
+				wm.reduceAdded(e.ConfigID, domain.IDPTypeJWT, e.Aggregate())
+			}
+		case *org.IDPConfigAddedEvent:
+			if e.Typ == domain.IDPConfigTypeOIDC {
+				wm.reduceAdded(e.ConfigID, domain.IDPTypeOIDC, e.Aggregate())
+			} else if e.Typ == domain.IDPConfigTypeJWT {
+				wm.reduceAdded(e.ConfigID, domain.IDPTypeJWT, e.Aggregate())
+			}
+		case *instance.IDPConfigRemovedEvent:
+			wm.reduceRemoved(e.ConfigID)
+		case *org.IDPConfigRemovedEvent:
+			wm.reduceRemoved(e.ConfigID)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *IDPTypeWriteModel) reduceAdded(id string, t domain.IDPType, agg *eventstore.Aggregate) {
+	if wm.ID != id {
+		return
+	}
+	wm.Type = t
 	wm.State = domain.IDPStateActive
 	wm.ResourceOwner = agg.ResourceOwner
 	wm.InstanceID = agg.InstanceID
@@ -2126,6 +2830,11 @@ func (wm *IDPTypeWriteModel) Query() *eventstore.SearchQueryBuilder {
 			instance.GitHubEnterpriseIDPAddedEventType,
 			instance.GitLabIDPAddedEventType,
 			instance.GitLabSelfHostedIDPAddedEventType,
+			instance.OktaIDPAddedEventType,
+			instance.KeycloakIDPAddedEventType,
+			instance.ADFSIDPAddedEventType,
+			instance.BitbucketIDPAddedEventType,
+			instance.DiscordIDPAddedEventType,
 			instance.GoogleIDPAddedEventType,
 			instance.LDAPIDPAddedEventType,
 			instance.AppleIDPAddedEventType,
@@ -2146,6 +2855,11 @@ func (wm *IDPTypeWriteModel) Query() *eventstore.SearchQueryBuilder {
 			org.GitHubEnterpriseIDPAddedEventType,
 			org.GitLabIDPAddedEventType,
 			org.GitLabSelfHostedIDPAddedEventType,
+			org.OktaIDPAddedEventType,
+			org.KeycloakIDPAddedEventType,
+			org.ADFSIDPAddedEventType,
+			org.BitbucketIDPAddedEventType,
+			org.DiscordIDPAddedEventType,
 			org.GoogleIDPAddedEventType,
 			org.LDAPIDPAddedEventType,
 			org.AppleIDPAddedEventType,
@@ -2222,6 +2936,16 @@ func NewAllIDPWriteModel(resourceOwner string, instanceBool bool, id string, idp
 			writeModel.model = NewGitLabInstanceIDPWriteModel(resourceOwner, id)
 		case domain.IDPTypeGitLabSelfHosted:
 			writeModel.model = NewGitLabSelfHostedInstanceIDPWriteModel(resourceOwner, id)
+		case domain.IDPTypeOkta:
+			writeModel.model = NewOktaInstanceIDPWriteModel(resourceOwner, id)
+		case domain.IDPTypeKeycloak:
+			writeModel.model = NewKeycloakInstanceIDPWriteModel(resourceOwner, id)
+		case domain.IDPTypeADFS:
+			writeModel.model = NewADFSInstanceIDPWriteModel(resourceOwner, id)
+		case domain.IDPTypeBitbucket:
+			writeModel.model = NewBitbucketInstanceIDPWriteModel(resourceOwner, id)
+		case domain.IDPTypeDiscord:
+			writeModel.model = NewDiscordInstanceIDPWriteModel(resourceOwner, id)
 		case domain.IDPTypeGoogle:
 			writeModel.model = NewGoogleInstanceIDPWriteModel(resourceOwner, id)
 		case domain.IDPTypeApple:
@@ -2253,6 +2977,16 @@ func NewAllIDPWriteModel(resourceOwner string, instanceBool bool, id string, idp
 			writeModel.model = NewGitLabOrgIDPWriteModel(resourceOwner, id)
 		case domain.IDPTypeGitLabSelfHosted:
 			writeModel.model = NewGitLabSelfHostedOrgIDPWriteModel(resourceOwner, id)
+		case domain.IDPTypeOkta:
+			writeModel.model = NewOktaOrgIDPWriteModel(resourceOwner, id)
+		case domain.IDPTypeKeycloak:
+			writeModel.model = NewKeycloakOrgIDPWriteModel(resourceOwner, id)
+		case domain.IDPTypeADFS:
+			writeModel.model = NewADFSOrgIDPWriteModel(resourceOwner, id)
+		case domain.IDPTypeBitbucket:
+			writeModel.model = NewBitbucketOrgIDPWriteModel(resourceOwner, id)
+		case domain.IDPTypeDiscord:
+			writeModel.model = NewDiscordOrgIDPWriteModel(resourceOwner, id)
 		case domain.IDPTypeGoogle:
 			writeModel.model = NewGoogleOrgIDPWriteModel(resourceOwner, id)
 		case domain.IDPTypeApple:
@@ -2304,6 +3038,15 @@ func (wm *AllIDPWriteModel) GetProviderOptions() idp.Options {
 	return wm.samlModel.GetProviderOptions()
 }
 
+// GetClaimMapping returns the claim mapping of the identity provider,
+// which is only set on generic OAuth and OIDC providers.
+func (wm *AllIDPWriteModel) GetClaimMapping() *mapping.Mapping {
+	if m, ok := wm.model.(interface{ GetClaimMapping() *mapping.Mapping }); ok {
+		return m.GetClaimMapping()
+	}
+	return nil
+}
+
 func (wm *AllIDPWriteModel) ToSAMLProvider(callbackURL string, idpAlg crypto.EncryptionAlgorithm, getRequest requesttracker.GetRequest, addRequest requesttracker.AddRequest) (providers.Provider, error) {
 	if wm.samlModel == nil {
 		return nil, zerrors.ThrowInternal(nil, "COMMAND-csi30hdscv", "ErrorsIDPConfig.NotExisting")
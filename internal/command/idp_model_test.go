@@ -144,6 +144,71 @@ func TestCommands_AllIDPWriteModel(t *testing.T) {
 				err:            nil,
 			},
 		},
+		{
+			name: "writemodel instance okta",
+			args: args{
+				resourceOwner: "owner",
+				instanceBool:  true,
+				id:            "id",
+				idpType:       domain.IDPTypeOkta,
+			},
+			res: res{
+				writeModelType: &InstanceOktaIDPWriteModel{},
+				err:            nil,
+			},
+		},
+		{
+			name: "writemodel instance keycloak",
+			args: args{
+				resourceOwner: "owner",
+				instanceBool:  true,
+				id:            "id",
+				idpType:       domain.IDPTypeKeycloak,
+			},
+			res: res{
+				writeModelType: &InstanceKeycloakIDPWriteModel{},
+				err:            nil,
+			},
+		},
+		{
+			name: "writemodel instance adfs",
+			args: args{
+				resourceOwner: "owner",
+				instanceBool:  true,
+				id:            "id",
+				idpType:       domain.IDPTypeADFS,
+			},
+			res: res{
+				writeModelType: &InstanceADFSIDPWriteModel{},
+				err:            nil,
+			},
+		},
+		{
+			name: "writemodel instance bitbucket",
+			args: args{
+				resourceOwner: "owner",
+				instanceBool:  true,
+				id:            "id",
+				idpType:       domain.IDPTypeBitbucket,
+			},
+			res: res{
+				writeModelType: &InstanceBitbucketIDPWriteModel{},
+				err:            nil,
+			},
+		},
+		{
+			name: "writemodel instance discord",
+			args: args{
+				resourceOwner: "owner",
+				instanceBool:  true,
+				id:            "id",
+				idpType:       domain.IDPTypeDiscord,
+			},
+			res: res{
+				writeModelType: &InstanceDiscordIDPWriteModel{},
+				err:            nil,
+			},
+		},
 		{
 			name: "writemodel instance google",
 			args: args{
@@ -299,6 +364,71 @@ func TestCommands_AllIDPWriteModel(t *testing.T) {
 				err:            nil,
 			},
 		},
+		{
+			name: "writemodel org okta",
+			args: args{
+				resourceOwner: "owner",
+				instanceBool:  false,
+				id:            "id",
+				idpType:       domain.IDPTypeOkta,
+			},
+			res: res{
+				writeModelType: &OrgOktaIDPWriteModel{},
+				err:            nil,
+			},
+		},
+		{
+			name: "writemodel org keycloak",
+			args: args{
+				resourceOwner: "owner",
+				instanceBool:  false,
+				id:            "id",
+				idpType:       domain.IDPTypeKeycloak,
+			},
+			res: res{
+				writeModelType: &OrgKeycloakIDPWriteModel{},
+				err:            nil,
+			},
+		},
+		{
+			name: "writemodel org adfs",
+			args: args{
+				resourceOwner: "owner",
+				instanceBool:  false,
+				id:            "id",
+				idpType:       domain.IDPTypeADFS,
+			},
+			res: res{
+				writeModelType: &OrgADFSIDPWriteModel{},
+				err:            nil,
+			},
+		},
+		{
+			name: "writemodel org bitbucket",
+			args: args{
+				resourceOwner: "owner",
+				instanceBool:  false,
+				id:            "id",
+				idpType:       domain.IDPTypeBitbucket,
+			},
+			res: res{
+				writeModelType: &OrgBitbucketIDPWriteModel{},
+				err:            nil,
+			},
+		},
+		{
+			name: "writemodel org discord",
+			args: args{
+				resourceOwner: "owner",
+				instanceBool:  false,
+				id:            "id",
+				idpType:       domain.IDPTypeDiscord,
+			},
+			res: res{
+				writeModelType: &OrgDiscordIDPWriteModel{},
+				err:            nil,
+			},
+		},
 		{
 			name: "writemodel org google",
 			args: args{
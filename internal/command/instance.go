@@ -52,11 +52,12 @@ type InstanceSetup struct {
 		}
 	}
 	PasswordComplexityPolicy struct {
-		MinLength    uint64
-		HasLowercase bool
-		HasUppercase bool
-		HasNumber    bool
-		HasSymbol    bool
+		MinLength     uint64
+		HasLowercase  bool
+		HasUppercase  bool
+		HasNumber     bool
+		HasSymbol     bool
+		CheckBreached bool
 	}
 	PasswordAgePolicy struct {
 		ExpireWarnDays uint64
@@ -328,6 +329,7 @@ func setupInstanceElements(instanceAgg *instance.Aggregate, setup *InstanceSetup
 			setup.PasswordComplexityPolicy.HasUppercase,
 			setup.PasswordComplexityPolicy.HasNumber,
 			setup.PasswordComplexityPolicy.HasSymbol,
+			setup.PasswordComplexityPolicy.CheckBreached,
 		),
 		prepareAddDefaultPasswordAgePolicy(
 			instanceAgg,
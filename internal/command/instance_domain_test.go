@@ -158,6 +158,11 @@ func TestCommandSide_AddInstanceDomain(t *testing.T) {
 								"",
 								domain.LoginVersionUnspecified,
 								"",
+								false,
+								false,
+								domain.OIDCBackchannelTokenDeliveryModePoll,
+								"",
+								"",
 							),
 						),
 					),
@@ -0,0 +1,151 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// ldapSyncMinInterval prevents that the directory is queried permanently
+const ldapSyncMinInterval = time.Minute
+
+// LDAPSync configures the periodic synchronization of the users of an LDAP identity provider.
+type LDAPSync struct {
+	Interval time.Duration
+	// Filter is an optional LDAP search filter the users must match in addition to the user object classes of the provider
+	Filter   string
+	PageSize uint32
+	// OrganizationID is the organization new users are created in
+	OrganizationID string
+	// DeactivateMissing deactivates the linked users which are no longer returned by the directory
+	DeactivateMissing bool
+	// GroupAttribute is the attribute of the users containing the groups, e.g. memberOf
+	GroupAttribute string
+	GroupGrants    []*LDAPSyncGroupGrant
+}
+
+// LDAPSyncGroupGrant grants the roles of the project to the members of the group.
+type LDAPSyncGroupGrant struct {
+	Group     string
+	ProjectID string
+	RoleKeys  []string
+}
+
+func (s *LDAPSync) validate() error {
+	if s.Interval < ldapSyncMinInterval {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Iequ7", "Errors.Invalid.Argument")
+	}
+	if s.OrganizationID = strings.TrimSpace(s.OrganizationID); s.OrganizationID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Aeph4", "Errors.Invalid.Argument")
+	}
+	if s.GroupAttribute = strings.TrimSpace(s.GroupAttribute); len(s.GroupGrants) > 0 && s.GroupAttribute == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Yoo0u", "Errors.Invalid.Argument")
+	}
+	for _, grant := range s.GroupGrants {
+		if grant.Group = strings.TrimSpace(grant.Group); grant.Group == "" || grant.ProjectID == "" {
+			return zerrors.ThrowInvalidArgument(nil, "COMMAND-Ohj6e", "Errors.Invalid.Argument")
+		}
+	}
+	return nil
+}
+
+// SetInstanceLDAPSync sets the synchronization of the instance LDAP provider.
+// Setting the synchronization restarts the schedule of the sync job.
+func (c *Commands) SetInstanceLDAPSync(ctx context.Context, idpID string, sync *LDAPSync) (*domain.ObjectDetails, error) {
+	if idpID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-ee3Ah", "Errors.IDMissing")
+	}
+	if err := sync.validate(); err != nil {
+		return nil, err
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	provider := NewLDAPInstanceIDPWriteModel(instanceID, idpID)
+	if err := c.eventstore.FilterToQueryReducer(ctx, provider); err != nil {
+		return nil, err
+	}
+	if !provider.State.Exists() {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Pah5o", "Errors.IDPConfig.NotExisting")
+	}
+	if err := c.checkOrgExists(ctx, sync.OrganizationID); err != nil {
+		return nil, err
+	}
+	groupGrants := make([]*instance.LDAPSyncGroupGrant, len(sync.GroupGrants))
+	for i, grant := range sync.GroupGrants {
+		projectResourceOwner, err := c.checkProjectExists(ctx, grant.ProjectID, "")
+		if err != nil {
+			return nil, err
+		}
+		groupGrants[i] = &instance.LDAPSyncGroupGrant{
+			Group:                grant.Group,
+			ProjectID:            grant.ProjectID,
+			ProjectResourceOwner: projectResourceOwner,
+			RoleKeys:             grant.RoleKeys,
+		}
+	}
+
+	writeModel := NewInstanceLDAPSyncWriteModel(instanceID, idpID)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if !writeModel.hasChanged(sync) {
+		return writeModelToObjectDetails(&writeModel.WriteModel), nil
+	}
+	err := c.pushAppendAndReduce(ctx, writeModel, instance.NewLDAPSyncSetEvent(
+		ctx,
+		&instance.NewAggregate(instanceID).Aggregate,
+		idpID,
+		sync.Interval,
+		sync.Filter,
+		sync.PageSize,
+		sync.OrganizationID,
+		sync.DeactivateMissing,
+		sync.GroupAttribute,
+		groupGrants,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// RemoveInstanceLDAPSync stops the synchronization of the instance LDAP provider.
+// The users created by the synchronization are kept.
+func (c *Commands) RemoveInstanceLDAPSync(ctx context.Context, idpID string) (*domain.ObjectDetails, error) {
+	if idpID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-ieM3u", "Errors.IDMissing")
+	}
+	writeModel := NewInstanceLDAPSyncWriteModel(authz.GetInstance(ctx).InstanceID(), idpID)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if !writeModel.IsSet {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Eeh7a", "Errors.IDPConfig.NotExisting")
+	}
+	err := c.pushAppendAndReduce(ctx, writeModel, instance.NewLDAPSyncRemovedEvent(
+		ctx,
+		InstanceAggregateFromWriteModel(&writeModel.WriteModel),
+		idpID,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// ReportInstanceLDAPSync stores the report of a finished sync run.
+func (c *Commands) ReportInstanceLDAPSync(ctx context.Context, idpID string, report instance.LDAPSyncReport) (*domain.ObjectDetails, error) {
+	if idpID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-aiT7e", "Errors.IDMissing")
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	pushed, err := c.eventstore.Push(ctx, instance.NewLDAPSyncReportedEvent(ctx, &instance.NewAggregate(instanceID).Aggregate, idpID, report))
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushed), nil
+}
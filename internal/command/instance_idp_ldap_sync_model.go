@@ -0,0 +1,87 @@
+package command
+
+import (
+	"slices"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+type InstanceLDAPSyncWriteModel struct {
+	eventstore.WriteModel
+
+	ID                string
+	Interval          time.Duration
+	Filter            string
+	PageSize          uint32
+	OrganizationID    string
+	DeactivateMissing bool
+	GroupAttribute    string
+	GroupGrants       []*instance.LDAPSyncGroupGrant
+
+	IsSet bool
+}
+
+func NewInstanceLDAPSyncWriteModel(instanceID, id string) *InstanceLDAPSyncWriteModel {
+	return &InstanceLDAPSyncWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   instanceID,
+			ResourceOwner: instanceID,
+		},
+		ID: id,
+	}
+}
+
+func (wm *InstanceLDAPSyncWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *instance.LDAPSyncSetEvent:
+			wm.Interval = e.Interval
+			wm.Filter = e.Filter
+			wm.PageSize = e.PageSize
+			wm.OrganizationID = e.OrganizationID
+			wm.DeactivateMissing = e.DeactivateMissing
+			wm.GroupAttribute = e.GroupAttribute
+			wm.GroupGrants = e.GroupGrants
+			wm.IsSet = true
+		case *instance.LDAPSyncRemovedEvent:
+			wm.Interval = 0
+			wm.Filter = ""
+			wm.PageSize = 0
+			wm.OrganizationID = ""
+			wm.DeactivateMissing = false
+			wm.GroupAttribute = ""
+			wm.GroupGrants = nil
+			wm.IsSet = false
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *InstanceLDAPSyncWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.LDAPSyncSetEventType,
+			instance.LDAPSyncRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+func (wm *InstanceLDAPSyncWriteModel) hasChanged(sync *LDAPSync) bool {
+	return !wm.IsSet ||
+		wm.Interval != sync.Interval ||
+		wm.Filter != sync.Filter ||
+		wm.PageSize != sync.PageSize ||
+		wm.OrganizationID != sync.OrganizationID ||
+		wm.DeactivateMissing != sync.DeactivateMissing ||
+		wm.GroupAttribute != sync.GroupAttribute ||
+		!slices.EqualFunc(wm.GroupGrants, sync.GroupGrants, func(a *instance.LDAPSyncGroupGrant, b *LDAPSyncGroupGrant) bool {
+			return a.Group == b.Group && a.ProjectID == b.ProjectID && slices.Equal(a.RoleKeys, b.RoleKeys)
+		})
+}
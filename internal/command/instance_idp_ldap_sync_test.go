@@ -0,0 +1,361 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func ldapIDPAddedEvent() *instance.LDAPIDPAddedEvent {
+	return instance.NewLDAPIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+		"idp1",
+		"name",
+		[]string{"server"},
+		false,
+		"baseDN",
+		"dn",
+		&crypto.CryptoValue{
+			CryptoType: crypto.TypeEncryption,
+			Algorithm:  "enc",
+			KeyID:      "id",
+			Crypted:    []byte("password"),
+		},
+		"user",
+		[]string{"object"},
+		[]string{"filter"},
+		time.Second*30,
+		nil,
+		idp.LDAPAttributes{},
+		idp.Options{},
+	)
+}
+
+func TestCommands_SetInstanceLDAPSync(t *testing.T) {
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx   context.Context
+		idpID string
+		sync  *LDAPSync
+	}
+	type want struct {
+		details *domain.ObjectDetails
+		err     error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   want
+	}{
+		{
+			name: "missing id, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:  authz.WithInstanceID(context.Background(), "instance1"),
+				sync: &LDAPSync{Interval: time.Hour, OrganizationID: "org1"},
+			},
+			want: want{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-ee3Ah", "Errors.IDMissing"),
+			},
+		},
+		{
+			name: "interval too short, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				sync:  &LDAPSync{Interval: time.Second, OrganizationID: "org1"},
+			},
+			want: want{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Iequ7", "Errors.Invalid.Argument"),
+			},
+		},
+		{
+			name: "group grants without attribute, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				sync: &LDAPSync{
+					Interval:       time.Hour,
+					OrganizationID: "org1",
+					GroupGrants:    []*LDAPSyncGroupGrant{{Group: "cn=sales", ProjectID: "project1"}},
+				},
+			},
+			want: want{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Yoo0u", "Errors.Invalid.Argument"),
+			},
+		},
+		{
+			name: "provider not found, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				sync:  &LDAPSync{Interval: time.Hour, OrganizationID: "org1"},
+			},
+			want: want{
+				err: zerrors.ThrowNotFound(nil, "COMMAND-Pah5o", "Errors.IDPConfig.NotExisting"),
+			},
+		},
+		{
+			name: "organization not found, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(ldapIDPAddedEvent()),
+					),
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				sync:  &LDAPSync{Interval: time.Hour, OrganizationID: "org1"},
+			},
+			want: want{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-QXPGs", "Errors.Org.NotFound"),
+			},
+		},
+		{
+			name: "set ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(ldapIDPAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org2").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						instance.NewLDAPSyncSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"idp1",
+							time.Hour,
+							"(department=sales)",
+							100,
+							"org1",
+							true,
+							"memberOf",
+							[]*instance.LDAPSyncGroupGrant{
+								{Group: "cn=sales", ProjectID: "project1", ProjectResourceOwner: "org2", RoleKeys: []string{"role1"}},
+							},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				sync: &LDAPSync{
+					Interval:          time.Hour,
+					Filter:            "(department=sales)",
+					PageSize:          100,
+					OrganizationID:    "org1",
+					DeactivateMissing: true,
+					GroupAttribute:    " memberOf ",
+					GroupGrants:       []*LDAPSyncGroupGrant{{Group: "cn=sales", ProjectID: "project1", RoleKeys: []string{"role1"}}},
+				},
+			},
+			want: want{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+		{
+			name: "unchanged, no push",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(ldapIDPAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewLDAPSyncSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"idp1",
+								time.Hour,
+								"",
+								0,
+								"org1",
+								false,
+								"",
+								nil,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+				sync:  &LDAPSync{Interval: time.Hour, OrganizationID: "org1"},
+			},
+			want: want{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.SetInstanceLDAPSync(tt.args.ctx, tt.args.idpID, tt.args.sync)
+			assert.ErrorIs(t, err, tt.want.err)
+			assertObjectDetails(t, tt.want.details, got)
+		})
+	}
+}
+
+func TestCommands_RemoveInstanceLDAPSync(t *testing.T) {
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx   context.Context
+		idpID string
+	}
+	type want struct {
+		details *domain.ObjectDetails
+		err     error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   want
+	}{
+		{
+			name: "missing id, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+			},
+			want: want{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-ieM3u", "Errors.IDMissing"),
+			},
+		},
+		{
+			name: "not set, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+			},
+			want: want{
+				err: zerrors.ThrowNotFound(nil, "COMMAND-Eeh7a", "Errors.IDPConfig.NotExisting"),
+			},
+		},
+		{
+			name: "remove ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewLDAPSyncSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"idp1",
+								time.Hour,
+								"",
+								0,
+								"org1",
+								false,
+								"",
+								nil,
+							),
+						),
+					),
+					expectPush(
+						instance.NewLDAPSyncRemovedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate, "idp1"),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				idpID: "idp1",
+			},
+			want: want{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "instance1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.RemoveInstanceLDAPSync(tt.args.ctx, tt.args.idpID)
+			assert.ErrorIs(t, err, tt.want.err)
+			assertObjectDetails(t, tt.want.details, got)
+		})
+	}
+}
+
+func TestCommands_ReportInstanceLDAPSync(t *testing.T) {
+	report := instance.LDAPSyncReport{
+		StartedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndedAt:   time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC),
+		Created:   1,
+		Failed:    1,
+		Errors:    []string{"user1: Errors.User.Profile.FirstNameEmpty"},
+	}
+	c := &Commands{
+		eventstore: expectEventstore(
+			expectPush(
+				instance.NewLDAPSyncReportedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate, "idp1", report),
+			),
+		)(t),
+	}
+	got, err := c.ReportInstanceLDAPSync(authz.WithInstanceID(context.Background(), "instance1"), "idp1", report)
+	assert.NoError(t, err)
+	assertObjectDetails(t, &domain.ObjectDetails{ResourceOwner: "instance1"}, got)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/idp/mapping"
 	"github.com/zitadel/zitadel/internal/repository/idp"
 	"github.com/zitadel/zitadel/internal/repository/instance"
 )
@@ -69,6 +70,7 @@ func (wm *InstanceOAuthIDPWriteModel) NewChangedEvent(
 	idAttribute string,
 	scopes []string,
 	usePKCE bool,
+	claimMapping *mapping.Mapping,
 	options idp.Options,
 ) (*instance.OAuthIDPChangedEvent, error) {
 
@@ -83,6 +85,7 @@ func (wm *InstanceOAuthIDPWriteModel) NewChangedEvent(
 		idAttribute,
 		scopes,
 		usePKCE,
+		claimMapping,
 		options,
 	)
 	if err != nil || len(changes) == 0 {
@@ -177,6 +180,7 @@ func (wm *InstanceOIDCIDPWriteModel) NewChangedEvent(
 	secretCrypto crypto.EncryptionAlgorithm,
 	scopes []string,
 	idTokenMapping, usePKCE bool,
+	claimMapping *mapping.Mapping,
 	options idp.Options,
 ) (*instance.OIDCIDPChangedEvent, error) {
 
@@ -189,6 +193,7 @@ func (wm *InstanceOIDCIDPWriteModel) NewChangedEvent(
 		scopes,
 		idTokenMapping,
 		usePKCE,
+		claimMapping,
 		options,
 	)
 	if err != nil || len(changes) == 0 {
@@ -945,6 +950,334 @@ func (wm *InstanceSAMLIDPWriteModel) NewChangedEvent(
 	return instance.NewSAMLIDPChangedEvent(ctx, aggregate, id, changes)
 }
 
+type InstanceOktaIDPWriteModel struct {
+	OktaIDPWriteModel
+}
+
+func NewOktaInstanceIDPWriteModel(instanceID, id string) *InstanceOktaIDPWriteModel {
+	return &InstanceOktaIDPWriteModel{
+		OktaIDPWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   instanceID,
+				ResourceOwner: instanceID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *InstanceOktaIDPWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.OktaIDPAddedEvent:
+			wm.OktaIDPWriteModel.AppendEvents(&e.OktaIDPAddedEvent)
+		case *instance.OktaIDPChangedEvent:
+			wm.OktaIDPWriteModel.AppendEvents(&e.OktaIDPChangedEvent)
+		case *instance.IDPRemovedEvent:
+			wm.OktaIDPWriteModel.AppendEvents(&e.RemovedEvent)
+		default:
+			wm.OktaIDPWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *InstanceOktaIDPWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.OktaIDPAddedEventType,
+			instance.OktaIDPChangedEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+func (wm *InstanceOktaIDPWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	issuer,
+	clientID string,
+	clientSecretString string,
+	secretCrypto crypto.EncryptionAlgorithm,
+	scopes []string,
+	options idp.Options,
+) (*instance.OktaIDPChangedEvent, error) {
+
+	changes, err := wm.OktaIDPWriteModel.NewChanges(name, issuer, clientID, clientSecretString, secretCrypto, scopes, options)
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return instance.NewOktaIDPChangedEvent(ctx, aggregate, id, changes)
+}
+
+type InstanceKeycloakIDPWriteModel struct {
+	KeycloakIDPWriteModel
+}
+
+func NewKeycloakInstanceIDPWriteModel(instanceID, id string) *InstanceKeycloakIDPWriteModel {
+	return &InstanceKeycloakIDPWriteModel{
+		KeycloakIDPWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   instanceID,
+				ResourceOwner: instanceID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *InstanceKeycloakIDPWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.KeycloakIDPAddedEvent:
+			wm.KeycloakIDPWriteModel.AppendEvents(&e.KeycloakIDPAddedEvent)
+		case *instance.KeycloakIDPChangedEvent:
+			wm.KeycloakIDPWriteModel.AppendEvents(&e.KeycloakIDPChangedEvent)
+		case *instance.IDPRemovedEvent:
+			wm.KeycloakIDPWriteModel.AppendEvents(&e.RemovedEvent)
+		default:
+			wm.KeycloakIDPWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *InstanceKeycloakIDPWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.KeycloakIDPAddedEventType,
+			instance.KeycloakIDPChangedEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+func (wm *InstanceKeycloakIDPWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	issuer,
+	clientID string,
+	clientSecretString string,
+	secretCrypto crypto.EncryptionAlgorithm,
+	scopes []string,
+	options idp.Options,
+) (*instance.KeycloakIDPChangedEvent, error) {
+
+	changes, err := wm.KeycloakIDPWriteModel.NewChanges(name, issuer, clientID, clientSecretString, secretCrypto, scopes, options)
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return instance.NewKeycloakIDPChangedEvent(ctx, aggregate, id, changes)
+}
+
+type InstanceADFSIDPWriteModel struct {
+	ADFSIDPWriteModel
+}
+
+func NewADFSInstanceIDPWriteModel(instanceID, id string) *InstanceADFSIDPWriteModel {
+	return &InstanceADFSIDPWriteModel{
+		ADFSIDPWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   instanceID,
+				ResourceOwner: instanceID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *InstanceADFSIDPWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.ADFSIDPAddedEvent:
+			wm.ADFSIDPWriteModel.AppendEvents(&e.ADFSIDPAddedEvent)
+		case *instance.ADFSIDPChangedEvent:
+			wm.ADFSIDPWriteModel.AppendEvents(&e.ADFSIDPChangedEvent)
+		case *instance.IDPRemovedEvent:
+			wm.ADFSIDPWriteModel.AppendEvents(&e.RemovedEvent)
+		default:
+			wm.ADFSIDPWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *InstanceADFSIDPWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.ADFSIDPAddedEventType,
+			instance.ADFSIDPChangedEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+func (wm *InstanceADFSIDPWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	issuer,
+	clientID string,
+	clientSecretString string,
+	secretCrypto crypto.EncryptionAlgorithm,
+	scopes []string,
+	options idp.Options,
+) (*instance.ADFSIDPChangedEvent, error) {
+
+	changes, err := wm.ADFSIDPWriteModel.NewChanges(name, issuer, clientID, clientSecretString, secretCrypto, scopes, options)
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return instance.NewADFSIDPChangedEvent(ctx, aggregate, id, changes)
+}
+
+type InstanceBitbucketIDPWriteModel struct {
+	BitbucketIDPWriteModel
+}
+
+func NewBitbucketInstanceIDPWriteModel(instanceID, id string) *InstanceBitbucketIDPWriteModel {
+	return &InstanceBitbucketIDPWriteModel{
+		BitbucketIDPWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   instanceID,
+				ResourceOwner: instanceID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *InstanceBitbucketIDPWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.BitbucketIDPAddedEvent:
+			wm.BitbucketIDPWriteModel.AppendEvents(&e.BitbucketIDPAddedEvent)
+		case *instance.BitbucketIDPChangedEvent:
+			wm.BitbucketIDPWriteModel.AppendEvents(&e.BitbucketIDPChangedEvent)
+		case *instance.IDPRemovedEvent:
+			wm.BitbucketIDPWriteModel.AppendEvents(&e.RemovedEvent)
+		default:
+			wm.BitbucketIDPWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *InstanceBitbucketIDPWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.BitbucketIDPAddedEventType,
+			instance.BitbucketIDPChangedEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+func (wm *InstanceBitbucketIDPWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	clientID string,
+	clientSecretString string,
+	secretCrypto crypto.EncryptionAlgorithm,
+	scopes []string,
+	options idp.Options,
+) (*instance.BitbucketIDPChangedEvent, error) {
+
+	changes, err := wm.BitbucketIDPWriteModel.NewChanges(name, clientID, clientSecretString, secretCrypto, scopes, options)
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return instance.NewBitbucketIDPChangedEvent(ctx, aggregate, id, changes)
+}
+
+type InstanceDiscordIDPWriteModel struct {
+	DiscordIDPWriteModel
+}
+
+func NewDiscordInstanceIDPWriteModel(instanceID, id string) *InstanceDiscordIDPWriteModel {
+	return &InstanceDiscordIDPWriteModel{
+		DiscordIDPWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   instanceID,
+				ResourceOwner: instanceID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *InstanceDiscordIDPWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.DiscordIDPAddedEvent:
+			wm.DiscordIDPWriteModel.AppendEvents(&e.DiscordIDPAddedEvent)
+		case *instance.DiscordIDPChangedEvent:
+			wm.DiscordIDPWriteModel.AppendEvents(&e.DiscordIDPChangedEvent)
+		case *instance.IDPRemovedEvent:
+			wm.DiscordIDPWriteModel.AppendEvents(&e.RemovedEvent)
+		default:
+			wm.DiscordIDPWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *InstanceDiscordIDPWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.DiscordIDPAddedEventType,
+			instance.DiscordIDPChangedEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+func (wm *InstanceDiscordIDPWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	clientID string,
+	clientSecretString string,
+	secretCrypto crypto.EncryptionAlgorithm,
+	scopes []string,
+	options idp.Options,
+) (*instance.DiscordIDPChangedEvent, error) {
+
+	changes, err := wm.DiscordIDPWriteModel.NewChanges(name, clientID, clientSecretString, secretCrypto, scopes, options)
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return instance.NewDiscordIDPChangedEvent(ctx, aggregate, id, changes)
+}
+
 type InstanceIDPRemoveWriteModel struct {
 	IDPRemoveWriteModel
 }
@@ -980,6 +1313,16 @@ func (wm *InstanceIDPRemoveWriteModel) AppendEvents(events ...eventstore.Event)
 			wm.IDPRemoveWriteModel.AppendEvents(&e.GitLabIDPAddedEvent)
 		case *instance.GitLabSelfHostedIDPAddedEvent:
 			wm.IDPRemoveWriteModel.AppendEvents(&e.GitLabSelfHostedIDPAddedEvent)
+		case *instance.OktaIDPAddedEvent:
+			wm.IDPRemoveWriteModel.AppendEvents(&e.OktaIDPAddedEvent)
+		case *instance.KeycloakIDPAddedEvent:
+			wm.IDPRemoveWriteModel.AppendEvents(&e.KeycloakIDPAddedEvent)
+		case *instance.ADFSIDPAddedEvent:
+			wm.IDPRemoveWriteModel.AppendEvents(&e.ADFSIDPAddedEvent)
+		case *instance.BitbucketIDPAddedEvent:
+			wm.IDPRemoveWriteModel.AppendEvents(&e.BitbucketIDPAddedEvent)
+		case *instance.DiscordIDPAddedEvent:
+			wm.IDPRemoveWriteModel.AppendEvents(&e.DiscordIDPAddedEvent)
 		case *instance.GoogleIDPAddedEvent:
 			wm.IDPRemoveWriteModel.AppendEvents(&e.GoogleIDPAddedEvent)
 		case *instance.SAMLIDPAddedEvent:
@@ -1015,6 +1358,11 @@ func (wm *InstanceIDPRemoveWriteModel) Query() *eventstore.SearchQueryBuilder {
 			instance.GitHubEnterpriseIDPAddedEventType,
 			instance.GitLabIDPAddedEventType,
 			instance.GitLabSelfHostedIDPAddedEventType,
+			instance.OktaIDPAddedEventType,
+			instance.KeycloakIDPAddedEventType,
+			instance.ADFSIDPAddedEventType,
+			instance.BitbucketIDPAddedEventType,
+			instance.DiscordIDPAddedEventType,
 			instance.GoogleIDPAddedEventType,
 			instance.LDAPIDPAddedEventType,
 			instance.AppleIDPAddedEventType,
@@ -63,6 +63,12 @@ func (wm *InstanceWriteModel) Reduce() error {
 	return wm.WriteModel.Reduce()
 }
 
+// SnapshotType implements [eventstore.SnapshotWriteModel].
+// Increase the version if Reduce or the fields change.
+func (wm *InstanceWriteModel) SnapshotType() (string, uint16) {
+	return "instance", 1
+}
+
 func (wm *InstanceWriteModel) Query() *eventstore.SearchQueryBuilder {
 	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
 		ResourceOwner(wm.ResourceOwner).
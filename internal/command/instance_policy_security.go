@@ -8,12 +8,15 @@ import (
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/instance"
+	webauthn_helper "github.com/zitadel/zitadel/internal/webauthn"
 )
 
 type SecurityPolicy struct {
 	EnableIframeEmbedding bool
 	AllowedOrigins        []string
 	EnableImpersonation   bool
+	// WebAuthNAttestation restricts the authenticators users can register as passkey or U2F
+	WebAuthNAttestation domain.WebAuthNAttestationPolicy
 }
 
 func (c *Commands) SetSecurityPolicy(ctx context.Context, policy *SecurityPolicy) (*domain.ObjectDetails, error) {
@@ -36,6 +39,9 @@ func (c *Commands) SetSecurityPolicy(ctx context.Context, policy *SecurityPolicy
 
 func (c *Commands) prepareSetSecurityPolicy(a *instance.Aggregate, policy *SecurityPolicy) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
+		if err := webauthn_helper.ValidateAttestationPolicy(&policy.WebAuthNAttestation); err != nil {
+			return nil, err
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			writeModel, err := c.getSecurityPolicyWriteModel(ctx, filter)
 			if err != nil {
@@ -63,3 +69,11 @@ func (c *Commands) getSecurityPolicyWriteModel(ctx context.Context, filter prepa
 	err = writeModel.Reduce()
 	return writeModel, err
 }
+
+func (c *Commands) webAuthNAttestationPolicy(ctx context.Context) (*domain.WebAuthNAttestationPolicy, error) {
+	writeModel, err := c.getSecurityPolicyWriteModel(ctx, c.eventstore.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return &writeModel.WebAuthNAttestation, nil
+}
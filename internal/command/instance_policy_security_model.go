@@ -38,6 +38,18 @@ func (wm *InstanceSecurityPolicyWriteModel) Reduce() error {
 			if e.EnableImpersonation != nil {
 				wm.EnableImpersonation = *e.EnableImpersonation
 			}
+			if e.WebAuthNAttestationRequired != nil {
+				wm.WebAuthNAttestation.Required = *e.WebAuthNAttestationRequired
+			}
+			if e.WebAuthNAllowedAAGUIDs != nil {
+				wm.WebAuthNAttestation.AllowedAAGUIDs = *e.WebAuthNAllowedAAGUIDs
+			}
+			if e.WebAuthNDeniedAAGUIDs != nil {
+				wm.WebAuthNAttestation.DeniedAAGUIDs = *e.WebAuthNDeniedAAGUIDs
+			}
+			if e.WebAuthNCertificationLevels != nil {
+				wm.WebAuthNAttestation.CertificationLevels = *e.WebAuthNCertificationLevels
+			}
 		}
 	}
 	return wm.WriteModel.Reduce()
@@ -71,6 +83,18 @@ func (wm *InstanceSecurityPolicyWriteModel) NewSetEvent(
 	if wm.EnableImpersonation != policy.EnableImpersonation {
 		changes = append(changes, instance.ChangeSecurityPolicyEnableImpersonation(policy.EnableImpersonation))
 	}
+	if wm.WebAuthNAttestation.Required != policy.WebAuthNAttestation.Required {
+		changes = append(changes, instance.ChangeSecurityPolicyWebAuthNAttestationRequired(policy.WebAuthNAttestation.Required))
+	}
+	if !slices.Equal(wm.WebAuthNAttestation.AllowedAAGUIDs, policy.WebAuthNAttestation.AllowedAAGUIDs) {
+		changes = append(changes, instance.ChangeSecurityPolicyWebAuthNAllowedAAGUIDs(policy.WebAuthNAttestation.AllowedAAGUIDs))
+	}
+	if !slices.Equal(wm.WebAuthNAttestation.DeniedAAGUIDs, policy.WebAuthNAttestation.DeniedAAGUIDs) {
+		changes = append(changes, instance.ChangeSecurityPolicyWebAuthNDeniedAAGUIDs(policy.WebAuthNAttestation.DeniedAAGUIDs))
+	}
+	if !slices.Equal(wm.WebAuthNAttestation.CertificationLevels, policy.WebAuthNAttestation.CertificationLevels) {
+		changes = append(changes, instance.ChangeSecurityPolicyWebAuthNCertificationLevels(policy.WebAuthNAttestation.CertificationLevels))
+	}
 	changeEvent, err := instance.NewSecurityPolicySetEvent(ctx, aggregate, changes)
 	if err != nil {
 		return nil, err
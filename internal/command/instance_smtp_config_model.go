@@ -17,6 +17,7 @@ type IAMSMTPConfigWriteModel struct {
 
 	SMTPConfig *SMTPConfig
 	HTTPConfig *HTTPConfig
+	APIConfig  *EmailAPIConfig
 
 	State domain.SMTPConfigState
 
@@ -35,6 +36,18 @@ type SMTPConfig struct {
 	ReplyToAddress string
 }
 
+type EmailAPIConfig struct {
+	Provider       domain.EmailAPIProvider
+	SenderAddress  string
+	SenderName     string
+	ReplyToAddress string
+	Endpoint       string
+	Region         string
+	Domain         string
+	KeyID          string
+	APIKey         *crypto.CryptoValue
+}
+
 func NewIAMSMTPConfigWriteModel(instanceID, id, domain string) *IAMSMTPConfigWriteModel {
 	return &IAMSMTPConfigWriteModel{
 		WriteModel: eventstore.WriteModel{
@@ -97,6 +110,16 @@ func (wm *IAMSMTPConfigWriteModel) Reduce() error {
 				continue
 			}
 			wm.reduceSMTPConfigHTTPChangedEvent(e)
+		case *instance.SMTPConfigAPIAddedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.reduceSMTPConfigAPIAddedEvent(e)
+		case *instance.SMTPConfigAPIChangedEvent:
+			if wm.ID != e.ID {
+				continue
+			}
+			wm.reduceSMTPConfigAPIChangedEvent(e)
 		case *instance.SMTPConfigRemovedEvent:
 			if wm.ID != e.ID {
 				continue
@@ -147,6 +170,8 @@ func (wm *IAMSMTPConfigWriteModel) Query() *eventstore.SearchQueryBuilder {
 			instance.SMTPConfigPasswordChangedEventType,
 			instance.SMTPConfigHTTPAddedEventType,
 			instance.SMTPConfigHTTPChangedEventType,
+			instance.SMTPConfigAPIAddedEventType,
+			instance.SMTPConfigAPIChangedEventType,
 			instance.SMTPConfigActivatedEventType,
 			instance.SMTPConfigDeactivatedEventType,
 			instance.SMTPConfigRemovedEventType,
@@ -227,6 +252,53 @@ func (wm *IAMSMTPConfigWriteModel) NewHTTPChangedEvent(ctx context.Context, aggr
 	return changeEvent, true, nil
 }
 
+func (wm *IAMSMTPConfigWriteModel) NewAPIChangedEvent(ctx context.Context, aggregate *eventstore.Aggregate, id, description, senderAddress, senderName, replyToAddress, endpoint, region, sendingDomain, keyID string, apiKey *crypto.CryptoValue) (*instance.SMTPConfigAPIChangedEvent, bool, error) {
+	changes := make([]instance.SMTPConfigAPIChanges, 0)
+	var err error
+	if wm.APIConfig == nil {
+		return nil, false, nil
+	}
+
+	if wm.ID != id {
+		changes = append(changes, instance.ChangeSMTPConfigAPIID(id))
+	}
+	if wm.Description != description {
+		changes = append(changes, instance.ChangeSMTPConfigAPIDescription(description))
+	}
+	if wm.APIConfig.SenderAddress != senderAddress {
+		changes = append(changes, instance.ChangeSMTPConfigAPISenderAddress(senderAddress))
+	}
+	if wm.APIConfig.SenderName != senderName {
+		changes = append(changes, instance.ChangeSMTPConfigAPISenderName(senderName))
+	}
+	if wm.APIConfig.ReplyToAddress != replyToAddress {
+		changes = append(changes, instance.ChangeSMTPConfigAPIReplyToAddress(replyToAddress))
+	}
+	if wm.APIConfig.Endpoint != endpoint {
+		changes = append(changes, instance.ChangeSMTPConfigAPIEndpoint(endpoint))
+	}
+	if wm.APIConfig.Region != region {
+		changes = append(changes, instance.ChangeSMTPConfigAPIRegion(region))
+	}
+	if wm.APIConfig.Domain != sendingDomain {
+		changes = append(changes, instance.ChangeSMTPConfigAPIDomain(sendingDomain))
+	}
+	if wm.APIConfig.KeyID != keyID {
+		changes = append(changes, instance.ChangeSMTPConfigAPIKeyID(keyID))
+	}
+	if apiKey != nil {
+		changes = append(changes, instance.ChangeSMTPConfigAPIKey(apiKey))
+	}
+	if len(changes) == 0 {
+		return nil, false, nil
+	}
+	changeEvent, err := instance.NewSMTPConfigAPIChangeEvent(ctx, aggregate, id, changes)
+	if err != nil {
+		return nil, false, err
+	}
+	return changeEvent, true, nil
+}
+
 func (wm *IAMSMTPConfigWriteModel) reduceSMTPConfigAddedEvent(e *instance.SMTPConfigAddedEvent) {
 	wm.Description = e.Description
 	wm.SMTPConfig = &SMTPConfig{
@@ -263,6 +335,22 @@ func (wm *IAMSMTPConfigWriteModel) reduceSMTPConfigHTTPAddedEvent(e *instance.SM
 	}
 }
 
+func (wm *IAMSMTPConfigWriteModel) reduceSMTPConfigAPIAddedEvent(e *instance.SMTPConfigAPIAddedEvent) {
+	wm.Description = e.Description
+	wm.APIConfig = &EmailAPIConfig{
+		Provider:       e.Provider,
+		SenderAddress:  e.SenderAddress,
+		SenderName:     e.SenderName,
+		ReplyToAddress: e.ReplyToAddress,
+		Endpoint:       e.Endpoint,
+		Region:         e.Region,
+		Domain:         e.Domain,
+		KeyID:          e.KeyID,
+		APIKey:         e.APIKey,
+	}
+	wm.State = domain.SMTPConfigStateInactive
+}
+
 func (wm *IAMSMTPConfigWriteModel) reduceSMTPConfigChangedEvent(e *instance.SMTPConfigChangedEvent) {
 	if wm.SMTPConfig == nil {
 		return
@@ -323,10 +411,45 @@ func (wm *IAMSMTPConfigWriteModel) reduceSMTPConfigHTTPChangedEvent(e *instance.
 	}
 }
 
+func (wm *IAMSMTPConfigWriteModel) reduceSMTPConfigAPIChangedEvent(e *instance.SMTPConfigAPIChangedEvent) {
+	if wm.APIConfig == nil {
+		return
+	}
+
+	if e.Description != nil {
+		wm.Description = *e.Description
+	}
+	if e.SenderAddress != nil {
+		wm.APIConfig.SenderAddress = *e.SenderAddress
+	}
+	if e.SenderName != nil {
+		wm.APIConfig.SenderName = *e.SenderName
+	}
+	if e.ReplyToAddress != nil {
+		wm.APIConfig.ReplyToAddress = *e.ReplyToAddress
+	}
+	if e.Endpoint != nil {
+		wm.APIConfig.Endpoint = *e.Endpoint
+	}
+	if e.Region != nil {
+		wm.APIConfig.Region = *e.Region
+	}
+	if e.Domain != nil {
+		wm.APIConfig.Domain = *e.Domain
+	}
+	if e.KeyID != nil {
+		wm.APIConfig.KeyID = *e.KeyID
+	}
+	if e.APIKey != nil {
+		wm.APIConfig.APIKey = e.APIKey
+	}
+}
+
 func (wm *IAMSMTPConfigWriteModel) reduceSMTPConfigRemovedEvent(e *instance.SMTPConfigRemovedEvent) {
 	wm.Description = ""
 	wm.HTTPConfig = nil
 	wm.SMTPConfig = nil
+	wm.APIConfig = nil
 	wm.State = domain.SMTPConfigStateRemoved
 
 	// If ID has empty value we're dealing with the old and unique smtp settings
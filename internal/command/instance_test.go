@@ -125,6 +125,11 @@ func oidcAppEvents(ctx context.Context, orgID, projectID, id, name, clientID str
 			"",
 			domain.LoginVersionUnspecified,
 			"",
+			false,
+			false,
+			domain.OIDCBackchannelTokenDeliveryModePoll,
+			"",
+			"",
 		),
 	}
 }
@@ -201,7 +206,7 @@ func instancePoliciesFilters(instanceID string) []expect {
 func instancePoliciesEvents(ctx context.Context, instanceID string) []eventstore.Command {
 	instanceAgg := instance.NewAggregate(instanceID)
 	return []eventstore.Command{
-		instance.NewPasswordComplexityPolicyAddedEvent(ctx, &instanceAgg.Aggregate, 8, true, true, true, true),
+		instance.NewPasswordComplexityPolicyAddedEvent(ctx, &instanceAgg.Aggregate, 8, true, true, true, true, false),
 		instance.NewPasswordAgePolicyAddedEvent(ctx, &instanceAgg.Aggregate, 0, 0),
 		instance.NewDomainPolicyAddedEvent(ctx, &instanceAgg.Aggregate, false, false, false),
 		instance.NewLoginPolicyAddedEvent(ctx, &instanceAgg.Aggregate, true, true, true, false, false, false, false, true, false, false, domain.PasswordlessTypeAllowed, "", 240*time.Hour, 240*time.Hour, 720*time.Hour, 18*time.Hour, 12*time.Hour),
@@ -219,12 +224,13 @@ func instancePoliciesEvents(ctx context.Context, instanceID string) []eventstore
 func instanceSetupPoliciesConfig() *InstanceSetup {
 	return &InstanceSetup{
 		PasswordComplexityPolicy: struct {
-			MinLength    uint64
-			HasLowercase bool
-			HasUppercase bool
-			HasNumber    bool
-			HasSymbol    bool
-		}{8, true, true, true, true},
+			MinLength     uint64
+			HasLowercase  bool
+			HasUppercase  bool
+			HasNumber     bool
+			HasSymbol     bool
+			CheckBreached bool
+		}{8, true, true, true, true, false},
 		PasswordAgePolicy: struct {
 			ExpireWarnDays uint64
 			MaxAgeDays     uint64
@@ -441,6 +447,11 @@ func generatedDomainFilters(instanceID, orgID, projectID, appID, generatedDomain
 				"",
 				domain.LoginVersionUnspecified,
 				"",
+				false,
+				false,
+				domain.OIDCBackchannelTokenDeliveryModePoll,
+				"",
+				"",
 			),
 		),
 		expectFilter(
@@ -478,6 +489,7 @@ func humanFilters(orgID string) []expect {
 				false,
 				false,
 				false,
+				false,
 			),
 		),
 	}
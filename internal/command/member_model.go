@@ -30,7 +30,7 @@ func (wm *MemberWriteModel) Reduce() error {
 			wm.State = domain.MemberStateActive
 		case *member.MemberChangedEvent:
 			wm.Roles = e.Roles
-		case *member.MemberRemovedEvent:
+		case *member.MemberRemovedEvent, *member.MemberCascadeRemovedEvent:
 			wm.Roles = nil
 			wm.State = domain.MemberStateRemoved
 		}
@@ -48,6 +48,12 @@ type OIDCSession struct {
 	Reason            domain.TokenReason
 	Actor             *domain.TokenActor
 	RefreshToken      string
+	// DPoPJKT is the JWK thumbprint of the DPoP proof the access token is bound to.
+	DPoPJKT string
+	// CertThumbprint is the thumbprint of the client certificate the access token is bound to.
+	CertThumbprint string
+	// AuthorizationDetails are the authorization_details (RFC 9396) the access token was issued for.
+	AuthorizationDetails domain.AuthorizationDetails
 }
 
 type AuthRequestComplianceChecker func(context.Context, *AuthRequestWriteModel) error
@@ -55,12 +61,19 @@ type AuthRequestComplianceChecker func(context.Context, *AuthRequestWriteModel)
 // CreateOIDCSessionFromAuthRequest creates a new OIDC Session, creates an access token and refresh token.
 // It returns the access token id, expiration and the refresh token.
 // If the underlying [AuthRequest] is a OIDC Auth Code Flow, it will set the code as exchanged.
+// If a dpopJKT is passed, the tokens are bound to the key of the DPoP proof.
+// If a certThumbprint is passed, the access token is bound to the client certificate.
+// The authorization_details of the auth request are granted to the session,
+// the access token is issued for the requested authorizationDetails, which must be part of them, or all if none are requested.
 func (c *Commands) CreateOIDCSessionFromAuthRequest(
 	ctx context.Context,
 	authReqId string,
 	complianceCheck AuthRequestComplianceChecker,
 	needRefreshToken bool,
 	backChannelLogoutURI string,
+	dpopJKT string,
+	certThumbprint string,
+	authorizationDetails domain.AuthorizationDetails,
 ) (session *OIDCSession, state string, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -100,6 +113,10 @@ func (c *Commands) CreateOIDCSessionFromAuthRequest(
 	if err = complianceCheck(ctx, authReqModel); err != nil {
 		return nil, "", err
 	}
+	tokenAuthorizationDetails, err := authReqModel.AuthorizationDetails.Narrow(authorizationDetails)
+	if err != nil {
+		return nil, "", err
+	}
 
 	cmd.AddSession(ctx,
 		sessionModel.UserID,
@@ -113,16 +130,17 @@ func (c *Commands) CreateOIDCSessionFromAuthRequest(
 		authReqModel.Nonce,
 		sessionModel.PreferredLanguage,
 		sessionModel.UserAgent,
+		authReqModel.AuthorizationDetails,
 	)
 	cmd.RegisterLogout(ctx, sessionModel.AggregateID, sessionModel.UserID, authReqModel.ClientID, backChannelLogoutURI)
 
 	if authReqModel.ResponseType != domain.OIDCResponseTypeIDToken {
-		if err = cmd.AddAccessToken(ctx, authReqModel.Scope, sessionModel.UserID, sessionModel.UserResourceOwner, domain.TokenReasonAuthRequest, nil); err != nil {
+		if err = cmd.AddAccessToken(ctx, authReqModel.Scope, sessionModel.UserID, sessionModel.UserResourceOwner, domain.TokenReasonAuthRequest, nil, dpopJKT, certThumbprint, tokenAuthorizationDetails); err != nil {
 			return nil, "", err
 		}
 	}
 	if authReqModel.NeedRefreshToken && needRefreshToken {
-		if err = cmd.AddRefreshToken(ctx, sessionModel.UserID); err != nil {
+		if err = cmd.AddRefreshToken(ctx, sessionModel.UserID, dpopJKT); err != nil {
 			return nil, "", err
 		}
 	}
@@ -155,10 +173,18 @@ func (c *Commands) CreateOIDCSession(ctx context.Context,
 	needRefreshToken bool,
 	sessionID string,
 	responseType domain.OIDCResponseType,
+	dpopJKT string,
+	certThumbprint string,
+	authorizationDetails,
+	requestedAuthorizationDetails domain.AuthorizationDetails,
 ) (session *OIDCSession, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
+	tokenAuthorizationDetails, err := authorizationDetails.Narrow(requestedAuthorizationDetails)
+	if err != nil {
+		return nil, err
+	}
 	cmd, err := c.newOIDCSessionAddEvents(ctx, userID, resourceOwner)
 	if err != nil {
 		return nil, err
@@ -170,15 +196,15 @@ func (c *Commands) CreateOIDCSession(ctx context.Context,
 		cmd.UserImpersonated(ctx, userID, resourceOwner, clientID, actor)
 	}
 
-	cmd.AddSession(ctx, userID, resourceOwner, sessionID, clientID, audience, scope, authMethods, authTime, nonce, preferredLanguage, userAgent)
+	cmd.AddSession(ctx, userID, resourceOwner, sessionID, clientID, audience, scope, authMethods, authTime, nonce, preferredLanguage, userAgent, authorizationDetails)
 	cmd.RegisterLogout(ctx, sessionID, userID, clientID, backChannelLogoutURI)
 	if responseType != domain.OIDCResponseTypeIDToken {
-		if err = cmd.AddAccessToken(ctx, scope, userID, resourceOwner, reason, actor); err != nil {
+		if err = cmd.AddAccessToken(ctx, scope, userID, resourceOwner, reason, actor, dpopJKT, certThumbprint, tokenAuthorizationDetails); err != nil {
 			return nil, err
 		}
 	}
 	if needRefreshToken {
-		if err = cmd.AddRefreshToken(ctx, userID); err != nil {
+		if err = cmd.AddRefreshToken(ctx, userID, dpopJKT); err != nil {
 			return nil, err
 		}
 	}
@@ -197,7 +223,11 @@ type RefreshTokenComplianceChecker func(ctx context.Context, wm *OIDCSessionWrit
 
 // ExchangeOIDCSessionRefreshAndAccessToken updates an existing OIDC Session, creates a new access and refresh token.
 // It returns the access token id and expiration and the new refresh token.
-func (c *Commands) ExchangeOIDCSessionRefreshAndAccessToken(ctx context.Context, refreshToken string, scope []string, complianceCheck RefreshTokenComplianceChecker) (_ *OIDCSession, err error) {
+// A refresh token bound to a DPoP key can only be used with a proof of the same key (dpopJKT),
+// the new access token is bound to the key of the passed proof and the passed client certificate (certThumbprint).
+// The new access token is issued for the requested authorizationDetails, which must be part of the ones granted to the session,
+// or all granted ones if none are requested.
+func (c *Commands) ExchangeOIDCSessionRefreshAndAccessToken(ctx context.Context, refreshToken string, scope []string, complianceCheck RefreshTokenComplianceChecker, dpopJKT, certThumbprint string, authorizationDetails domain.AuthorizationDetails) (_ *OIDCSession, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
@@ -205,15 +235,25 @@ func (c *Commands) ExchangeOIDCSessionRefreshAndAccessToken(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	if err = cmd.oidcSessionWriteModel.CheckRefreshTokenDPoP(dpopJKT); err != nil {
+		return nil, err
+	}
 	scope, err = complianceCheck(ctx, cmd.oidcSessionWriteModel, scope)
 	if err != nil {
 		return nil, err
 	}
+	authorizationDetails, err = cmd.oidcSessionWriteModel.AuthorizationDetails.Narrow(authorizationDetails)
+	if err != nil {
+		return nil, err
+	}
 	err = cmd.AddAccessToken(ctx, scope,
 		cmd.oidcSessionWriteModel.UserID,
 		cmd.oidcSessionWriteModel.UserResourceOwner,
 		domain.TokenReasonRefresh,
 		cmd.oidcSessionWriteModel.AccessTokenActor,
+		dpopJKT,
+		certThumbprint,
+		authorizationDetails,
 	)
 	if err != nil {
 		return nil, err
@@ -413,6 +453,7 @@ func (c *OIDCSessionEvents) AddSession(
 	nonce string,
 	preferredLanguage *language.Tag,
 	userAgent *domain.UserAgent,
+	authorizationDetails domain.AuthorizationDetails,
 ) {
 	c.events = append(c.events, oidcsession.NewAddedEvent(
 		ctx,
@@ -428,6 +469,7 @@ func (c *OIDCSessionEvents) AddSession(
 		nonce,
 		preferredLanguage,
 		userAgent,
+		authorizationDetails,
 	))
 }
 
@@ -466,25 +508,25 @@ func (c *OIDCSessionEvents) RegisterLogout(ctx context.Context, sessionID, userI
 	))
 }
 
-func (c *OIDCSessionEvents) AddAccessToken(ctx context.Context, scope []string, userID, resourceOwner string, reason domain.TokenReason, actor *domain.TokenActor) error {
+func (c *OIDCSessionEvents) AddAccessToken(ctx context.Context, scope []string, userID, resourceOwner string, reason domain.TokenReason, actor *domain.TokenActor, dpopJKT, certThumbprint string, authorizationDetails domain.AuthorizationDetails) error {
 	accessTokenID, err := c.idGenerator.Next()
 	if err != nil {
 		return err
 	}
 	c.accessTokenID = AccessTokenPrefix + accessTokenID
-	c.events = append(c.events, oidcsession.NewAccessTokenAddedEvent(ctx, c.oidcSessionWriteModel.aggregate, c.accessTokenID, scope, c.accessTokenLifetime, reason, actor))
+	c.events = append(c.events, oidcsession.NewAccessTokenAddedEvent(ctx, c.oidcSessionWriteModel.aggregate, c.accessTokenID, scope, c.accessTokenLifetime, reason, actor, dpopJKT, certThumbprint, authorizationDetails))
 	if !authz.GetFeatures(ctx).DisableUserTokenEvent {
 		c.events = append(c.events, user.NewUserTokenV2AddedEvent(ctx, &user.NewAggregate(userID, resourceOwner).Aggregate, c.accessTokenID))
 	}
 	return nil
 }
 
-func (c *OIDCSessionEvents) AddRefreshToken(ctx context.Context, userID string) (err error) {
+func (c *OIDCSessionEvents) AddRefreshToken(ctx context.Context, userID, dpopJKT string) (err error) {
 	c.refreshTokenID, c.refreshToken, err = c.generateRefreshToken(userID)
 	if err != nil {
 		return err
 	}
-	c.events = append(c.events, oidcsession.NewRefreshTokenAddedEvent(ctx, c.oidcSessionWriteModel.aggregate, c.refreshTokenID, c.refreshTokenLifeTime, c.refreshTokenIdleLifetime))
+	c.events = append(c.events, oidcsession.NewRefreshTokenAddedEvent(ctx, c.oidcSessionWriteModel.aggregate, c.refreshTokenID, c.refreshTokenLifeTime, c.refreshTokenIdleLifetime, dpopJKT))
 	return nil
 }
 
@@ -525,20 +567,23 @@ func (c *OIDCSessionEvents) PushEvents(ctx context.Context) (*OIDCSession, error
 		return nil, err
 	}
 	session := &OIDCSession{
-		SessionID:         c.oidcSessionWriteModel.SessionID,
-		ClientID:          c.oidcSessionWriteModel.ClientID,
-		UserID:            c.oidcSessionWriteModel.UserID,
-		Audience:          c.oidcSessionWriteModel.Audience,
-		Expiration:        c.oidcSessionWriteModel.AccessTokenExpiration,
-		Scope:             c.oidcSessionWriteModel.Scope,
-		AuthMethods:       c.oidcSessionWriteModel.AuthMethods,
-		AuthTime:          c.oidcSessionWriteModel.AuthTime,
-		Nonce:             c.oidcSessionWriteModel.Nonce,
-		PreferredLanguage: c.oidcSessionWriteModel.PreferredLanguage,
-		UserAgent:         c.oidcSessionWriteModel.UserAgent,
-		Reason:            c.oidcSessionWriteModel.AccessTokenReason,
-		Actor:             c.oidcSessionWriteModel.AccessTokenActor,
-		RefreshToken:      c.refreshToken,
+		SessionID:            c.oidcSessionWriteModel.SessionID,
+		ClientID:             c.oidcSessionWriteModel.ClientID,
+		UserID:               c.oidcSessionWriteModel.UserID,
+		Audience:             c.oidcSessionWriteModel.Audience,
+		Expiration:           c.oidcSessionWriteModel.AccessTokenExpiration,
+		Scope:                c.oidcSessionWriteModel.Scope,
+		AuthMethods:          c.oidcSessionWriteModel.AuthMethods,
+		AuthTime:             c.oidcSessionWriteModel.AuthTime,
+		Nonce:                c.oidcSessionWriteModel.Nonce,
+		PreferredLanguage:    c.oidcSessionWriteModel.PreferredLanguage,
+		UserAgent:            c.oidcSessionWriteModel.UserAgent,
+		Reason:               c.oidcSessionWriteModel.AccessTokenReason,
+		Actor:                c.oidcSessionWriteModel.AccessTokenActor,
+		RefreshToken:         c.refreshToken,
+		DPoPJKT:              c.oidcSessionWriteModel.AccessTokenDPoPJKT,
+		CertThumbprint:       c.oidcSessionWriteModel.AccessTokenCertThumbprint,
+		AuthorizationDetails: c.oidcSessionWriteModel.AccessTokenAuthorizationDetails,
 	}
 	if c.accessTokenID != "" {
 		// prefix the returned id with the oidcSessionID so that we can retrieve it later on
@@ -31,10 +31,16 @@ type OIDCSessionWriteModel struct {
 	AccessTokenExpiration      time.Time
 	AccessTokenReason          domain.TokenReason
 	AccessTokenActor           *domain.TokenActor
+	AccessTokenDPoPJKT         string
+	AccessTokenCertThumbprint  string
 	RefreshTokenID             string
 	RefreshToken               string
 	RefreshTokenExpiration     time.Time
 	RefreshTokenIdleExpiration time.Time
+	RefreshTokenDPoPJKT        string
+
+	AuthorizationDetails            domain.AuthorizationDetails
+	AccessTokenAuthorizationDetails domain.AuthorizationDetails
 
 	aggregate *eventstore.Aggregate
 }
@@ -101,6 +107,7 @@ func (wm *OIDCSessionWriteModel) reduceAdded(e *oidcsession.AddedEvent) {
 	wm.Nonce = e.Nonce
 	wm.PreferredLanguage = e.PreferredLanguage
 	wm.UserAgent = e.UserAgent
+	wm.AuthorizationDetails = e.AuthorizationDetails
 	wm.State = domain.OIDCSessionStateActive
 	// the write model might be initialized without resource owner,
 	// so update the aggregate
@@ -114,6 +121,9 @@ func (wm *OIDCSessionWriteModel) reduceAccessTokenAdded(e *oidcsession.AccessTok
 	wm.AccessTokenExpiration = e.CreationDate().Add(e.Lifetime)
 	wm.AccessTokenReason = e.Reason
 	wm.AccessTokenActor = e.Actor
+	wm.AccessTokenDPoPJKT = e.DPoPJKT
+	wm.AccessTokenCertThumbprint = e.CertThumbprint
+	wm.AccessTokenAuthorizationDetails = e.AuthorizationDetails
 }
 
 func (wm *OIDCSessionWriteModel) reduceAccessTokenRevoked(e *oidcsession.AccessTokenRevokedEvent) {
@@ -125,6 +135,7 @@ func (wm *OIDCSessionWriteModel) reduceRefreshTokenAdded(e *oidcsession.RefreshT
 	wm.RefreshTokenID = e.ID
 	wm.RefreshTokenExpiration = e.CreationDate().Add(e.Lifetime)
 	wm.RefreshTokenIdleExpiration = e.CreationDate().Add(e.IdleLifetime)
+	wm.RefreshTokenDPoPJKT = e.DPoPJKT
 }
 
 func (wm *OIDCSessionWriteModel) reduceRefreshTokenRenewed(e *oidcsession.RefreshTokenRenewedEvent) {
@@ -154,6 +165,15 @@ func (wm *OIDCSessionWriteModel) CheckRefreshToken(refreshTokenID string) error
 	return nil
 }
 
+// CheckRefreshTokenDPoP checks that the refresh token is used with a DPoP proof of the key it is bound to.
+// Refresh tokens issued without a proof aren't bound and can be used with or without a proof.
+func (wm *OIDCSessionWriteModel) CheckRefreshTokenDPoP(dpopJKT string) error {
+	if wm.RefreshTokenDPoPJKT != "" && wm.RefreshTokenDPoPJKT != dpopJKT {
+		return zerrors.ThrowPreconditionFailed(nil, "OIDCS-eeV4o", "Errors.OIDCSession.RefreshTokenInvalid")
+	}
+	return nil
+}
+
 func (wm *OIDCSessionWriteModel) CheckAccessToken(accessTokenID string) error {
 	if wm.State != domain.OIDCSessionStateActive {
 		return zerrors.ThrowPreconditionFailed(nil, "OIDCS-KL2pk", "Errors.OIDCSession.Token.Invalid")
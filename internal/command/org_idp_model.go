@@ -7,6 +7,7 @@ import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/idp/mapping"
 	"github.com/zitadel/zitadel/internal/repository/idp"
 	"github.com/zitadel/zitadel/internal/repository/org"
 )
@@ -71,6 +72,7 @@ func (wm *OrgOAuthIDPWriteModel) NewChangedEvent(
 	idAttribute string,
 	scopes []string,
 	usePKCE bool,
+	claimMapping *mapping.Mapping,
 	options idp.Options,
 ) (*org.OAuthIDPChangedEvent, error) {
 
@@ -85,6 +87,7 @@ func (wm *OrgOAuthIDPWriteModel) NewChangedEvent(
 		idAttribute,
 		scopes,
 		usePKCE,
+		claimMapping,
 		options,
 	)
 	if err != nil || len(changes) == 0 {
@@ -179,6 +182,7 @@ func (wm *OrgOIDCIDPWriteModel) NewChangedEvent(
 	secretCrypto crypto.EncryptionAlgorithm,
 	scopes []string,
 	idTokenMapping, usePKCE bool,
+	claimMapping *mapping.Mapping,
 	options idp.Options,
 ) (*org.OIDCIDPChangedEvent, error) {
 
@@ -191,6 +195,7 @@ func (wm *OrgOIDCIDPWriteModel) NewChangedEvent(
 		scopes,
 		idTokenMapping,
 		usePKCE,
+		claimMapping,
 		options,
 	)
 	if err != nil || len(changes) == 0 {
@@ -957,6 +962,334 @@ func (wm *OrgSAMLIDPWriteModel) NewChangedEvent(
 	return org.NewSAMLIDPChangedEvent(ctx, aggregate, id, changes)
 }
 
+type OrgOktaIDPWriteModel struct {
+	OktaIDPWriteModel
+}
+
+func NewOktaOrgIDPWriteModel(orgID, id string) *OrgOktaIDPWriteModel {
+	return &OrgOktaIDPWriteModel{
+		OktaIDPWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *OrgOktaIDPWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.OktaIDPAddedEvent:
+			wm.OktaIDPWriteModel.AppendEvents(&e.OktaIDPAddedEvent)
+		case *org.OktaIDPChangedEvent:
+			wm.OktaIDPWriteModel.AppendEvents(&e.OktaIDPChangedEvent)
+		case *org.IDPRemovedEvent:
+			wm.OktaIDPWriteModel.AppendEvents(&e.RemovedEvent)
+		default:
+			wm.OktaIDPWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *OrgOktaIDPWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.OktaIDPAddedEventType,
+			org.OktaIDPChangedEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+func (wm *OrgOktaIDPWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	issuer,
+	clientID string,
+	clientSecretString string,
+	secretCrypto crypto.EncryptionAlgorithm,
+	scopes []string,
+	options idp.Options,
+) (*org.OktaIDPChangedEvent, error) {
+
+	changes, err := wm.OktaIDPWriteModel.NewChanges(name, issuer, clientID, clientSecretString, secretCrypto, scopes, options)
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return org.NewOktaIDPChangedEvent(ctx, aggregate, id, changes)
+}
+
+type OrgKeycloakIDPWriteModel struct {
+	KeycloakIDPWriteModel
+}
+
+func NewKeycloakOrgIDPWriteModel(orgID, id string) *OrgKeycloakIDPWriteModel {
+	return &OrgKeycloakIDPWriteModel{
+		KeycloakIDPWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *OrgKeycloakIDPWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.KeycloakIDPAddedEvent:
+			wm.KeycloakIDPWriteModel.AppendEvents(&e.KeycloakIDPAddedEvent)
+		case *org.KeycloakIDPChangedEvent:
+			wm.KeycloakIDPWriteModel.AppendEvents(&e.KeycloakIDPChangedEvent)
+		case *org.IDPRemovedEvent:
+			wm.KeycloakIDPWriteModel.AppendEvents(&e.RemovedEvent)
+		default:
+			wm.KeycloakIDPWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *OrgKeycloakIDPWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.KeycloakIDPAddedEventType,
+			org.KeycloakIDPChangedEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+func (wm *OrgKeycloakIDPWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	issuer,
+	clientID string,
+	clientSecretString string,
+	secretCrypto crypto.EncryptionAlgorithm,
+	scopes []string,
+	options idp.Options,
+) (*org.KeycloakIDPChangedEvent, error) {
+
+	changes, err := wm.KeycloakIDPWriteModel.NewChanges(name, issuer, clientID, clientSecretString, secretCrypto, scopes, options)
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return org.NewKeycloakIDPChangedEvent(ctx, aggregate, id, changes)
+}
+
+type OrgADFSIDPWriteModel struct {
+	ADFSIDPWriteModel
+}
+
+func NewADFSOrgIDPWriteModel(orgID, id string) *OrgADFSIDPWriteModel {
+	return &OrgADFSIDPWriteModel{
+		ADFSIDPWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *OrgADFSIDPWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.ADFSIDPAddedEvent:
+			wm.ADFSIDPWriteModel.AppendEvents(&e.ADFSIDPAddedEvent)
+		case *org.ADFSIDPChangedEvent:
+			wm.ADFSIDPWriteModel.AppendEvents(&e.ADFSIDPChangedEvent)
+		case *org.IDPRemovedEvent:
+			wm.ADFSIDPWriteModel.AppendEvents(&e.RemovedEvent)
+		default:
+			wm.ADFSIDPWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *OrgADFSIDPWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.ADFSIDPAddedEventType,
+			org.ADFSIDPChangedEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+func (wm *OrgADFSIDPWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	issuer,
+	clientID string,
+	clientSecretString string,
+	secretCrypto crypto.EncryptionAlgorithm,
+	scopes []string,
+	options idp.Options,
+) (*org.ADFSIDPChangedEvent, error) {
+
+	changes, err := wm.ADFSIDPWriteModel.NewChanges(name, issuer, clientID, clientSecretString, secretCrypto, scopes, options)
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return org.NewADFSIDPChangedEvent(ctx, aggregate, id, changes)
+}
+
+type OrgBitbucketIDPWriteModel struct {
+	BitbucketIDPWriteModel
+}
+
+func NewBitbucketOrgIDPWriteModel(orgID, id string) *OrgBitbucketIDPWriteModel {
+	return &OrgBitbucketIDPWriteModel{
+		BitbucketIDPWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *OrgBitbucketIDPWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.BitbucketIDPAddedEvent:
+			wm.BitbucketIDPWriteModel.AppendEvents(&e.BitbucketIDPAddedEvent)
+		case *org.BitbucketIDPChangedEvent:
+			wm.BitbucketIDPWriteModel.AppendEvents(&e.BitbucketIDPChangedEvent)
+		case *org.IDPRemovedEvent:
+			wm.BitbucketIDPWriteModel.AppendEvents(&e.RemovedEvent)
+		default:
+			wm.BitbucketIDPWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *OrgBitbucketIDPWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.BitbucketIDPAddedEventType,
+			org.BitbucketIDPChangedEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+func (wm *OrgBitbucketIDPWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	clientID,
+	clientSecretString string,
+	secretCrypto crypto.EncryptionAlgorithm,
+	scopes []string,
+	options idp.Options,
+) (*org.BitbucketIDPChangedEvent, error) {
+
+	changes, err := wm.BitbucketIDPWriteModel.NewChanges(name, clientID, clientSecretString, secretCrypto, scopes, options)
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return org.NewBitbucketIDPChangedEvent(ctx, aggregate, id, changes)
+}
+
+type OrgDiscordIDPWriteModel struct {
+	DiscordIDPWriteModel
+}
+
+func NewDiscordOrgIDPWriteModel(orgID, id string) *OrgDiscordIDPWriteModel {
+	return &OrgDiscordIDPWriteModel{
+		DiscordIDPWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *OrgDiscordIDPWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.DiscordIDPAddedEvent:
+			wm.DiscordIDPWriteModel.AppendEvents(&e.DiscordIDPAddedEvent)
+		case *org.DiscordIDPChangedEvent:
+			wm.DiscordIDPWriteModel.AppendEvents(&e.DiscordIDPChangedEvent)
+		case *org.IDPRemovedEvent:
+			wm.DiscordIDPWriteModel.AppendEvents(&e.RemovedEvent)
+		default:
+			wm.DiscordIDPWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *OrgDiscordIDPWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.DiscordIDPAddedEventType,
+			org.DiscordIDPChangedEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+func (wm *OrgDiscordIDPWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	clientID,
+	clientSecretString string,
+	secretCrypto crypto.EncryptionAlgorithm,
+	scopes []string,
+	options idp.Options,
+) (*org.DiscordIDPChangedEvent, error) {
+
+	changes, err := wm.DiscordIDPWriteModel.NewChanges(name, clientID, clientSecretString, secretCrypto, scopes, options)
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return org.NewDiscordIDPChangedEvent(ctx, aggregate, id, changes)
+}
+
 type OrgIDPRemoveWriteModel struct {
 	IDPRemoveWriteModel
 }
@@ -992,6 +1325,16 @@ func (wm *OrgIDPRemoveWriteModel) AppendEvents(events ...eventstore.Event) {
 			wm.IDPRemoveWriteModel.AppendEvents(&e.GitLabIDPAddedEvent)
 		case *org.GitLabSelfHostedIDPAddedEvent:
 			wm.IDPRemoveWriteModel.AppendEvents(&e.GitLabSelfHostedIDPAddedEvent)
+		case *org.OktaIDPAddedEvent:
+			wm.IDPRemoveWriteModel.AppendEvents(&e.OktaIDPAddedEvent)
+		case *org.KeycloakIDPAddedEvent:
+			wm.IDPRemoveWriteModel.AppendEvents(&e.KeycloakIDPAddedEvent)
+		case *org.ADFSIDPAddedEvent:
+			wm.IDPRemoveWriteModel.AppendEvents(&e.ADFSIDPAddedEvent)
+		case *org.BitbucketIDPAddedEvent:
+			wm.IDPRemoveWriteModel.AppendEvents(&e.BitbucketIDPAddedEvent)
+		case *org.DiscordIDPAddedEvent:
+			wm.IDPRemoveWriteModel.AppendEvents(&e.DiscordIDPAddedEvent)
 		case *org.GoogleIDPAddedEvent:
 			wm.IDPRemoveWriteModel.AppendEvents(&e.GoogleIDPAddedEvent)
 		case *org.LDAPIDPAddedEvent:
@@ -1027,6 +1370,11 @@ func (wm *OrgIDPRemoveWriteModel) Query() *eventstore.SearchQueryBuilder {
 			org.GitHubEnterpriseIDPAddedEventType,
 			org.GitLabIDPAddedEventType,
 			org.GitLabSelfHostedIDPAddedEventType,
+			org.OktaIDPAddedEventType,
+			org.KeycloakIDPAddedEventType,
+			org.ADFSIDPAddedEventType,
+			org.BitbucketIDPAddedEventType,
+			org.DiscordIDPAddedEventType,
 			org.GoogleIDPAddedEventType,
 			org.LDAPIDPAddedEventType,
 			org.AppleIDPAddedEventType,
@@ -36,6 +36,12 @@ func (wm *OrgPasswordComplexityPolicyWriteModel) AppendEvents(events ...eventsto
 	}
 }
 
+// SnapshotType implements [eventstore.SnapshotWriteModel].
+// Increase the version if Reduce or the fields change.
+func (wm *OrgPasswordComplexityPolicyWriteModel) SnapshotType() (string, uint16) {
+	return "org_password_complexity_policy", 1
+}
+
 func (wm *OrgPasswordComplexityPolicyWriteModel) Reduce() error {
 	return wm.PasswordComplexityPolicyWriteModel.Reduce()
 }
@@ -59,7 +65,8 @@ func (wm *OrgPasswordComplexityPolicyWriteModel) NewChangedEvent(
 	hasLowercase,
 	hasUppercase,
 	hasNumber,
-	hasSymbol bool,
+	hasSymbol,
+	checkBreached bool,
 ) (*org.PasswordComplexityPolicyChangedEvent, bool) {
 
 	changes := make([]policy.PasswordComplexityPolicyChanges, 0)
@@ -78,6 +85,9 @@ func (wm *OrgPasswordComplexityPolicyWriteModel) NewChangedEvent(
 	if wm.HasSymbol != hasSymbol {
 		changes = append(changes, policy.ChangeHasSymbol(hasSymbol))
 	}
+	if wm.CheckBreached != checkBreached {
+		changes = append(changes, policy.ChangeCheckBreached(checkBreached))
+	}
 	if len(changes) == 0 {
 		return nil, false
 	}
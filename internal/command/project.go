@@ -195,6 +195,8 @@ type ChangeProject struct {
 	ProjectRoleCheck       *bool
 	HasProjectCheck        *bool
 	PrivateLabelingSetting *domain.PrivateLabelingSetting
+
+	AuthorizationDetailsTypes *[]string
 }
 
 func (p *ChangeProject) IsValid() error {
@@ -204,6 +206,9 @@ func (p *ChangeProject) IsValid() error {
 	if p.Name != nil && *p.Name == "" {
 		return zerrors.ThrowInvalidArgument(nil, "COMMAND-4m9vS", "Errors.Project.Invalid")
 	}
+	if p.AuthorizationDetailsTypes != nil && !domain.ValidAuthorizationDetailsTypes(*p.AuthorizationDetailsTypes) {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-ooTh4", "Errors.Project.Invalid")
+	}
 	return nil
 }
 
@@ -230,7 +235,9 @@ func (c *Commands) ChangeProject(ctx context.Context, change *ChangeProject) (_
 		change.ProjectRoleAssertion,
 		change.ProjectRoleCheck,
 		change.HasProjectCheck,
-		change.PrivateLabelingSetting)
+		change.PrivateLabelingSetting,
+		change.AuthorizationDetailsTypes,
+	)
 	if changedEvent == nil {
 		return writeModelToObjectDetails(&existing.WriteModel), nil
 	}
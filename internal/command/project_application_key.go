@@ -53,6 +53,10 @@ func (c *Commands) addApplicationKey(ctx context.Context, key *domain.Applicatio
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
+	if len(key.PublicKey) > 0 && !key.PublicKeyValid() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Oog4e", "Errors.Project.App.Key.Invalid")
+	}
+
 	keyWriteModel := NewApplicationKeyWriteModel(key.AggregateID, key.ApplicationID, key.KeyID, resourceOwner)
 	err = c.eventstore.FilterToQueryReducer(ctx, keyWriteModel)
 	if err != nil {
@@ -74,6 +78,10 @@ func (c *Commands) addApplicationKey(ctx context.Context, key *domain.Applicatio
 		}
 		key.ClientID = keyWriteModel.ClientID
 	}
+	// a provided public key (e.g. of a self-signed client certificate) still needs to be assigned to the client
+	if key.ClientID == "" {
+		key.ClientID = keyWriteModel.ClientID
+	}
 
 	pushedEvents, err := c.eventstore.Push(ctx,
 		project.NewApplicationKeyAddedEvent(
@@ -16,24 +16,31 @@ import (
 
 type addOIDCApp struct {
 	AddApp
-	Version                     domain.OIDCVersion
-	RedirectUris                []string
-	ResponseTypes               []domain.OIDCResponseType
-	GrantTypes                  []domain.OIDCGrantType
-	ApplicationType             domain.OIDCApplicationType
-	AuthMethodType              domain.OIDCAuthMethodType
-	PostLogoutRedirectUris      []string
-	DevMode                     bool
-	AccessTokenType             domain.OIDCTokenType
-	AccessTokenRoleAssertion    bool
-	IDTokenRoleAssertion        bool
-	IDTokenUserinfoAssertion    bool
-	ClockSkew                   time.Duration
-	AdditionalOrigins           []string
-	SkipSuccessPageForNativeApp bool
-	BackChannelLogoutURI        string
-	LoginVersion                domain.LoginVersion
-	LoginBaseURI                string
+	Version                            domain.OIDCVersion
+	RedirectUris                       []string
+	ResponseTypes                      []domain.OIDCResponseType
+	GrantTypes                         []domain.OIDCGrantType
+	ApplicationType                    domain.OIDCApplicationType
+	AuthMethodType                     domain.OIDCAuthMethodType
+	PostLogoutRedirectUris             []string
+	DevMode                            bool
+	AccessTokenType                    domain.OIDCTokenType
+	AccessTokenRoleAssertion           bool
+	IDTokenRoleAssertion               bool
+	IDTokenUserinfoAssertion           bool
+	ClockSkew                          time.Duration
+	AdditionalOrigins                  []string
+	SkipSuccessPageForNativeApp        bool
+	BackChannelLogoutURI               string
+	LoginVersion                       domain.LoginVersion
+	LoginBaseURI                       string
+	RequirePushedAuthorizationRequests bool
+	RequireDPoP                        bool
+
+	BackchannelTokenDeliveryMode          domain.OIDCBackchannelTokenDeliveryMode
+	BackchannelClientNotificationEndpoint string
+
+	TLSClientAuthSubjectDN string
 
 	ClientID          string
 	ClientSecret      string
@@ -114,6 +121,11 @@ func (c *Commands) AddOIDCAppCommand(app *addOIDCApp) preparation.Validation {
 					app.BackChannelLogoutURI,
 					app.LoginVersion,
 					app.LoginBaseURI,
+					app.RequirePushedAuthorizationRequests,
+					app.RequireDPoP,
+					app.BackchannelTokenDeliveryMode,
+					strings.TrimSpace(app.BackchannelClientNotificationEndpoint),
+					strings.TrimSpace(app.TLSClientAuthSubjectDN),
 				),
 			}, nil
 		}, nil
@@ -204,6 +216,11 @@ func (c *Commands) addOIDCApplicationWithID(ctx context.Context, oidcApp *domain
 		strings.TrimSpace(oidcApp.BackChannelLogoutURI),
 		oidcApp.LoginVersion,
 		strings.TrimSpace(oidcApp.LoginBaseURI),
+		oidcApp.RequirePushedAuthorizationRequests,
+		oidcApp.RequireDPoP,
+		oidcApp.BackchannelTokenDeliveryMode,
+		strings.TrimSpace(oidcApp.BackchannelClientNotificationEndpoint),
+		strings.TrimSpace(oidcApp.TLSClientAuthSubjectDN),
 	))
 
 	addedApplication.AppID = oidcApp.AppID
@@ -264,6 +281,11 @@ func (c *Commands) ChangeOIDCApplication(ctx context.Context, oidc *domain.OIDCA
 		strings.TrimSpace(oidc.BackChannelLogoutURI),
 		oidc.LoginVersion,
 		strings.TrimSpace(oidc.LoginBaseURI),
+		oidc.RequirePushedAuthorizationRequests,
+		oidc.RequireDPoP,
+		oidc.BackchannelTokenDeliveryMode,
+		strings.TrimSpace(oidc.BackchannelClientNotificationEndpoint),
+		strings.TrimSpace(oidc.TLSClientAuthSubjectDN),
 	)
 	if err != nil {
 		return nil, err
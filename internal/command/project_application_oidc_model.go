@@ -14,32 +14,39 @@ import (
 type OIDCApplicationWriteModel struct {
 	eventstore.WriteModel
 
-	AppID                    string
-	AppName                  string
-	ClientID                 string
-	HashedSecret             string
-	ClientSecretString       string
-	RedirectUris             []string
-	ResponseTypes            []domain.OIDCResponseType
-	GrantTypes               []domain.OIDCGrantType
-	ApplicationType          domain.OIDCApplicationType
-	AuthMethodType           domain.OIDCAuthMethodType
-	PostLogoutRedirectUris   []string
-	OIDCVersion              domain.OIDCVersion
-	Compliance               *domain.Compliance
-	DevMode                  bool
-	AccessTokenType          domain.OIDCTokenType
-	AccessTokenRoleAssertion bool
-	IDTokenRoleAssertion     bool
-	IDTokenUserinfoAssertion bool
-	ClockSkew                time.Duration
-	State                    domain.AppState
-	AdditionalOrigins        []string
-	SkipNativeAppSuccessPage bool
-	BackChannelLogoutURI     string
-	LoginVersion             domain.LoginVersion
-	LoginBaseURI             string
-	oidc                     bool
+	AppID                              string
+	AppName                            string
+	ClientID                           string
+	HashedSecret                       string
+	ClientSecretString                 string
+	RedirectUris                       []string
+	ResponseTypes                      []domain.OIDCResponseType
+	GrantTypes                         []domain.OIDCGrantType
+	ApplicationType                    domain.OIDCApplicationType
+	AuthMethodType                     domain.OIDCAuthMethodType
+	PostLogoutRedirectUris             []string
+	OIDCVersion                        domain.OIDCVersion
+	Compliance                         *domain.Compliance
+	DevMode                            bool
+	AccessTokenType                    domain.OIDCTokenType
+	AccessTokenRoleAssertion           bool
+	IDTokenRoleAssertion               bool
+	IDTokenUserinfoAssertion           bool
+	ClockSkew                          time.Duration
+	State                              domain.AppState
+	AdditionalOrigins                  []string
+	SkipNativeAppSuccessPage           bool
+	BackChannelLogoutURI               string
+	LoginVersion                       domain.LoginVersion
+	LoginBaseURI                       string
+	RequirePushedAuthorizationRequests bool
+	RequireDPoP                        bool
+
+	BackchannelTokenDeliveryMode          domain.OIDCBackchannelTokenDeliveryMode
+	BackchannelClientNotificationEndpoint string
+
+	TLSClientAuthSubjectDN string
+	oidc                   bool
 }
 
 func NewOIDCApplicationWriteModelWithAppID(projectID, appID, resourceOwner string) *OIDCApplicationWriteModel {
@@ -171,6 +178,11 @@ func (wm *OIDCApplicationWriteModel) appendAddOIDCEvent(e *project.OIDCConfigAdd
 	wm.BackChannelLogoutURI = e.BackChannelLogoutURI
 	wm.LoginVersion = e.LoginVersion
 	wm.LoginBaseURI = e.LoginBaseURI
+	wm.RequirePushedAuthorizationRequests = e.RequirePushedAuthorizationRequests
+	wm.RequireDPoP = e.RequireDPoP
+	wm.BackchannelTokenDeliveryMode = e.BackchannelTokenDeliveryMode
+	wm.BackchannelClientNotificationEndpoint = e.BackchannelClientNotificationEndpoint
+	wm.TLSClientAuthSubjectDN = e.TLSClientAuthSubjectDN
 }
 
 func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfigChangedEvent) {
@@ -228,6 +240,21 @@ func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfig
 	if e.LoginBaseURI != nil {
 		wm.LoginBaseURI = *e.LoginBaseURI
 	}
+	if e.RequirePushedAuthorizationRequests != nil {
+		wm.RequirePushedAuthorizationRequests = *e.RequirePushedAuthorizationRequests
+	}
+	if e.RequireDPoP != nil {
+		wm.RequireDPoP = *e.RequireDPoP
+	}
+	if e.BackchannelTokenDeliveryMode != nil {
+		wm.BackchannelTokenDeliveryMode = *e.BackchannelTokenDeliveryMode
+	}
+	if e.BackchannelClientNotificationEndpoint != nil {
+		wm.BackchannelClientNotificationEndpoint = *e.BackchannelClientNotificationEndpoint
+	}
+	if e.TLSClientAuthSubjectDN != nil {
+		wm.TLSClientAuthSubjectDN = *e.TLSClientAuthSubjectDN
+	}
 }
 
 func (wm *OIDCApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
@@ -272,6 +299,11 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	backChannelLogoutURI string,
 	loginVersion domain.LoginVersion,
 	loginBaseURI string,
+	requirePushedAuthorizationRequests bool,
+	requireDPoP bool,
+	backchannelTokenDeliveryMode domain.OIDCBackchannelTokenDeliveryMode,
+	backchannelClientNotificationEndpoint string,
+	tlsClientAuthSubjectDN string,
 ) (*project.OIDCConfigChangedEvent, bool, error) {
 	changes := make([]project.OIDCConfigChanges, 0)
 	var err error
@@ -330,6 +362,21 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	if wm.LoginBaseURI != loginBaseURI {
 		changes = append(changes, project.ChangeOIDCLoginBaseURI(loginBaseURI))
 	}
+	if wm.RequirePushedAuthorizationRequests != requirePushedAuthorizationRequests {
+		changes = append(changes, project.ChangeRequirePushedAuthorizationRequests(requirePushedAuthorizationRequests))
+	}
+	if wm.RequireDPoP != requireDPoP {
+		changes = append(changes, project.ChangeRequireDPoP(requireDPoP))
+	}
+	if wm.BackchannelTokenDeliveryMode != backchannelTokenDeliveryMode {
+		changes = append(changes, project.ChangeBackchannelTokenDeliveryMode(backchannelTokenDeliveryMode))
+	}
+	if wm.BackchannelClientNotificationEndpoint != backchannelClientNotificationEndpoint {
+		changes = append(changes, project.ChangeBackchannelClientNotificationEndpoint(backchannelClientNotificationEndpoint))
+	}
+	if wm.TLSClientAuthSubjectDN != tlsClientAuthSubjectDN {
+		changes = append(changes, project.ChangeTLSClientAuthSubjectDN(tlsClientAuthSubjectDN))
+	}
 
 	if len(changes) == 0 {
 		return nil, false, nil
@@ -178,6 +178,11 @@ func TestAddOIDCApp(t *testing.T) {
 						"",
 						domain.LoginVersionUnspecified,
 						"",
+						false,
+						false,
+						domain.OIDCBackchannelTokenDeliveryModePoll,
+						"",
+						"",
 					),
 				},
 			},
@@ -246,6 +251,11 @@ func TestAddOIDCApp(t *testing.T) {
 						"",
 						domain.LoginVersionUnspecified,
 						"",
+						false,
+						false,
+						domain.OIDCBackchannelTokenDeliveryModePoll,
+						"",
+						"",
 					),
 				},
 			},
@@ -314,6 +324,11 @@ func TestAddOIDCApp(t *testing.T) {
 						"",
 						domain.LoginVersionUnspecified,
 						"",
+						false,
+						false,
+						domain.OIDCBackchannelTokenDeliveryModePoll,
+						"",
+						"",
 					),
 				},
 			},
@@ -382,6 +397,11 @@ func TestAddOIDCApp(t *testing.T) {
 						"",
 						domain.LoginVersionUnspecified,
 						"",
+						false,
+						false,
+						domain.OIDCBackchannelTokenDeliveryModePoll,
+						"",
+						"",
 					),
 				},
 			},
@@ -490,6 +510,70 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 				err: zerrors.IsErrorInvalidArgument,
 			},
 		},
+		{
+			name: "ciba ping without notification endpoint, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instanceID"),
+				oidcApp: &domain.OIDCApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AppID:                        "app1",
+					AppName:                      "app",
+					ResponseTypes:                []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:                   []domain.OIDCGrantType{domain.OIDCGrantTypeCIBA},
+					BackchannelTokenDeliveryMode: domain.OIDCBackchannelTokenDeliveryModePing,
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "tls client auth without subject dn, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instanceID"),
+				oidcApp: &domain.OIDCApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AppID:          "app1",
+					AppName:        "app",
+					ResponseTypes:  []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:     []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					AuthMethodType: domain.OIDCAuthMethodTypeTLSClientAuth,
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
 		{
 			name: "create oidc app basic using whitespaces in uris, ok",
 			fields: fields{
@@ -531,6 +615,11 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 							"https://test.ch/backchannel",
 							domain.LoginVersion2,
 							"https://login.test.ch",
+							false,
+							false,
+							domain.OIDCBackchannelTokenDeliveryModePoll,
+							"",
+							"",
 						),
 					),
 				),
@@ -638,6 +727,11 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 							"https://test.ch/backchannel",
 							domain.LoginVersion2,
 							"https://login.test.ch",
+							false,
+							false,
+							domain.OIDCBackchannelTokenDeliveryModePoll,
+							"",
+							"",
 						),
 					),
 				),
@@ -872,6 +966,11 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								"https://test.ch/backchannel",
 								domain.LoginVersion2,
 								"https://login.test.ch",
+								false,
+								false,
+								domain.OIDCBackchannelTokenDeliveryModePoll,
+								"",
+								"",
 							),
 						),
 					),
@@ -946,6 +1045,11 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								"https://test.ch/backchannel",
 								domain.LoginVersion2,
 								"https://login.test.ch",
+								false,
+								false,
+								domain.OIDCBackchannelTokenDeliveryModePoll,
+								"",
+								"",
 							),
 						),
 					),
@@ -1020,6 +1124,11 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								"https://test.ch/backchannel",
 								domain.LoginVersion1,
 								"",
+								false,
+								false,
+								domain.OIDCBackchannelTokenDeliveryModePoll,
+								"",
+								"",
 							),
 						),
 					),
@@ -1214,6 +1323,11 @@ func TestCommandSide_ChangeOIDCApplicationSecret(t *testing.T) {
 								"",
 								domain.LoginVersionUnspecified,
 								"",
+								false,
+								false,
+								domain.OIDCBackchannelTokenDeliveryModePoll,
+								"",
+								"",
 							),
 						),
 					),
@@ -1378,6 +1492,11 @@ func TestCommands_VerifyOIDCClientSecret(t *testing.T) {
 							"",
 							domain.LoginVersionUnspecified,
 							"",
+							false,
+							false,
+							domain.OIDCBackchannelTokenDeliveryModePoll,
+							"",
+							"",
 						),
 					),
 				),
@@ -1416,6 +1535,11 @@ func TestCommands_VerifyOIDCClientSecret(t *testing.T) {
 							"",
 							domain.LoginVersionUnspecified,
 							"",
+							false,
+							false,
+							domain.OIDCBackchannelTokenDeliveryModePoll,
+							"",
+							"",
 						),
 					),
 				),
@@ -1453,6 +1577,11 @@ func TestCommands_VerifyOIDCClientSecret(t *testing.T) {
 							"",
 							domain.LoginVersionUnspecified,
 							"",
+							false,
+							false,
+							domain.OIDCBackchannelTokenDeliveryModePoll,
+							"",
+							"",
 						),
 					),
 				),
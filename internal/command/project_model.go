@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"slices"
 
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -17,6 +18,8 @@ type ProjectWriteModel struct {
 	HasProjectCheck        bool
 	PrivateLabelingSetting domain.PrivateLabelingSetting
 	State                  domain.ProjectState
+
+	AuthorizationDetailsTypes []string
 }
 
 func NewProjectWriteModel(projectID string, resourceOwner string) *ProjectWriteModel {
@@ -54,6 +57,9 @@ func (wm *ProjectWriteModel) Reduce() error {
 			if e.PrivateLabelingSetting != nil {
 				wm.PrivateLabelingSetting = *e.PrivateLabelingSetting
 			}
+			if e.AuthorizationDetailsTypes != nil {
+				wm.AuthorizationDetailsTypes = *e.AuthorizationDetailsTypes
+			}
 		case *project.ProjectDeactivatedEvent:
 			if wm.State == domain.ProjectStateRemoved {
 				continue
@@ -93,6 +99,7 @@ func (wm *ProjectWriteModel) NewChangedEvent(
 	projectRoleCheck,
 	hasProjectCheck *bool,
 	privateLabelingSetting *domain.PrivateLabelingSetting,
+	authorizationDetailsTypes *[]string,
 ) *project.ProjectChangeEvent {
 	changes := make([]project.ProjectChanges, 0)
 
@@ -113,6 +120,9 @@ func (wm *ProjectWriteModel) NewChangedEvent(
 	if privateLabelingSetting != nil && wm.PrivateLabelingSetting != *privateLabelingSetting {
 		changes = append(changes, project.ChangePrivateLabelingSetting(*privateLabelingSetting))
 	}
+	if authorizationDetailsTypes != nil && !slices.Equal(wm.AuthorizationDetailsTypes, *authorizationDetailsTypes) {
+		changes = append(changes, project.ChangeAuthorizationDetailsTypes(*authorizationDetailsTypes))
+	}
 	if len(changes) == 0 {
 		return nil
 	}
@@ -276,6 +276,26 @@ func TestCommandSide_ChangeProject(t *testing.T) {
 				err: zerrors.IsErrorInvalidArgument,
 			},
 		},
+		{
+			name: "invalid authorization details types, invalid error",
+			fields: fields{
+				eventstore:      expectEventstore(),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: context.Background(),
+				project: &ChangeProject{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AuthorizationDetailsTypes: &[]string{"payment_initiation", "payment_initiation"},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
 		{
 			name: "project not existing, not found error",
 			fields: fields{
@@ -495,6 +515,46 @@ func TestCommandSide_ChangeProject(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "project change authorization details types, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingAllowLoginUserResourceOwnerPolicy),
+						),
+					),
+					expectPush(
+						project.NewProjectChangeEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"",
+							[]project.ProjectChanges{
+								project.ChangeAuthorizationDetailsTypes([]string{"payment_initiation"}),
+							},
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: context.Background(),
+				project: &ChangeProject{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AuthorizationDetailsTypes: &[]string{"payment_initiation"},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
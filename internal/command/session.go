@@ -34,6 +34,7 @@ type SessionCommands struct {
 	eventCommands     []eventstore.Command
 
 	hasher               *crypto.Hasher
+	recoveryCodeHasher   *crypto.Hasher
 	intentAlg            crypto.EncryptionAlgorithm
 	totpAlg              crypto.EncryptionAlgorithm
 	otpAlg               crypto.EncryptionAlgorithm
@@ -41,6 +42,7 @@ type SessionCommands struct {
 	createPhoneCode      encryptedCodeGeneratorWithDefaultFunc
 	createToken          func(sessionID string) (id string, token string, err error)
 	getCodeVerifier      func(ctx context.Context, id string) (senders.CodeGenerator, error)
+	intentMapping        func(ctx context.Context, intent *IDPIntentWriteModel, userID, resourceOwner string) ([]eventstore.Command, error)
 	now                  func() time.Time
 	maxIdPIntentLifetime time.Duration
 }
@@ -51,6 +53,7 @@ func (c *Commands) NewSessionCommands(cmds []SessionCommand, session *SessionWri
 		sessionWriteModel:    session,
 		eventstore:           c.eventstore,
 		hasher:               c.userPasswordHasher,
+		recoveryCodeHasher:   c.secretHasher,
 		intentAlg:            c.idpConfigEncryption,
 		totpAlg:              c.multifactors.OTP.CryptoMFA,
 		otpAlg:               c.userEncryption,
@@ -58,6 +61,7 @@ func (c *Commands) NewSessionCommands(cmds []SessionCommand, session *SessionWri
 		createPhoneCode:      c.newPhoneCode,
 		createToken:          c.sessionTokenCreator,
 		getCodeVerifier:      c.phoneCodeVerifierFromConfig,
+		intentMapping:        c.idpIntentMappingCommands,
 		now:                  time.Now,
 		maxIdPIntentLifetime: c.maxIdPIntentLifetime,
 	}
@@ -121,6 +125,16 @@ func CheckIntent(intentID, token string) SessionCommand {
 			}
 		}
 		cmd.IntentChecked(ctx, cmd.now())
+		if cmd.intentWriteModel.IDPAccessToken != nil {
+			cmd.IDPLinkTokensSet(ctx)
+		}
+		// the metadata and grants mapped from the user of the identity provider are applied on every login,
+		// the same way as the login UI updates existing users
+		mappingCommands, err := cmd.intentMapping(ctx, cmd.intentWriteModel, cmd.sessionWriteModel.UserID, cmd.sessionWriteModel.UserResourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		cmd.eventCommands = append(cmd.eventCommands, mappingCommands...)
 		return nil, nil
 	}
 }
@@ -145,6 +159,28 @@ func CheckTOTP(code string) SessionCommand {
 	}
 }
 
+// CheckRecoveryCode defines a recovery code check to be executed for a session update.
+// The code can't be used again afterwards.
+func CheckRecoveryCode(code string) SessionCommand {
+	return func(ctx context.Context, cmd *SessionCommands) (_ []eventstore.Command, err error) {
+		commands, err := checkRecoveryCode(
+			ctx,
+			cmd.sessionWriteModel.UserID,
+			"",
+			code,
+			cmd.eventstore.FilterToQueryReducer,
+			cmd.recoveryCodeHasher,
+			nil,
+		)
+		if err != nil {
+			return commands, err
+		}
+		cmd.eventCommands = append(cmd.eventCommands, commands...)
+		cmd.RecoveryCodeChecked(ctx, cmd.now())
+		return nil, nil
+	}
+}
+
 // Exec will execute the commands specified and returns an error on the first occurrence.
 // In case of an error there might be specific commands returned, e.g. a failed pw check will have to be stored.
 func (s *SessionCommands) Exec(ctx context.Context) ([]eventstore.Command, error) {
@@ -177,6 +213,19 @@ func (s *SessionCommands) IntentChecked(ctx context.Context, checkedAt time.Time
 	s.eventCommands = append(s.eventCommands, idpintent.NewConsumedEvent(ctx, IDPIntentAggregateFromWriteModel(&s.intentWriteModel.WriteModel)))
 }
 
+// IDPLinkTokensSet stores the tokens of the identity provider from the checked intent on the user's link.
+// They are already encrypted with the same algorithm as the tokens of the link.
+func (s *SessionCommands) IDPLinkTokensSet(ctx context.Context) {
+	s.eventCommands = append(s.eventCommands, user.NewUserIDPLinkTokensSetEvent(ctx,
+		&user.NewAggregate(s.sessionWriteModel.UserID, s.sessionWriteModel.UserResourceOwner).Aggregate,
+		s.intentWriteModel.IDPID,
+		s.intentWriteModel.IDPUserID,
+		s.intentWriteModel.IDPAccessToken,
+		s.intentWriteModel.IDPRefreshToken,
+		s.intentWriteModel.expiresAt,
+	))
+}
+
 func (s *SessionCommands) WebAuthNChallenged(ctx context.Context, challenge string, allowedCrentialIDs [][]byte, userVerification domain.UserVerificationRequirement, rpid string) {
 	s.eventCommands = append(s.eventCommands, session.NewWebAuthNChallengedEvent(ctx, s.sessionWriteModel.aggregate, challenge, allowedCrentialIDs, userVerification, rpid))
 }
@@ -200,6 +249,10 @@ func (s *SessionCommands) TOTPChecked(ctx context.Context, checkedAt time.Time)
 	s.eventCommands = append(s.eventCommands, session.NewTOTPCheckedEvent(ctx, s.sessionWriteModel.aggregate, checkedAt))
 }
 
+func (s *SessionCommands) RecoveryCodeChecked(ctx context.Context, checkedAt time.Time) {
+	s.eventCommands = append(s.eventCommands, session.NewRecoveryCodeCheckedEvent(ctx, s.sessionWriteModel.aggregate, checkedAt))
+}
+
 func (s *SessionCommands) OTPSMSChallenged(ctx context.Context, code *crypto.CryptoValue, expiry time.Duration, returnCode bool, generatorID string) {
 	s.eventCommands = append(s.eventCommands, session.NewOTPSMSChallengedEvent(ctx, s.sessionWriteModel.aggregate, code, expiry, returnCode, generatorID))
 }
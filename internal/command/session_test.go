@@ -385,6 +385,9 @@ func TestCommands_updateSession(t *testing.T) {
 			})
 		return mCrypto
 	}
+	noIntentMapping := func(context.Context, *IDPIntentWriteModel, string, string) ([]eventstore.Command, error) {
+		return nil, nil
+	}
 
 	testNow := time.Now()
 	type fields struct {
@@ -694,6 +697,7 @@ func TestCommands_updateSession(t *testing.T) {
 								"idpUserName",
 								"userID2",
 								nil,
+								nil,
 								"",
 								time.Now().Add(time.Hour),
 							),
@@ -775,6 +779,7 @@ func TestCommands_updateSession(t *testing.T) {
 								"idpUsername",
 								"userID",
 								nil,
+								nil,
 								"",
 								time.Now().Add(time.Hour),
 							),
@@ -830,6 +835,7 @@ func TestCommands_updateSession(t *testing.T) {
 								"idpUsername",
 								"userID",
 								nil,
+								nil,
 								"",
 								time.Now().Add(-time.Hour),
 							),
@@ -880,6 +886,7 @@ func TestCommands_updateSession(t *testing.T) {
 								"idpUsername",
 								"userID",
 								nil,
+								nil,
 								"",
 								time.Now().Add(time.Hour),
 							),
@@ -911,7 +918,8 @@ func TestCommands_updateSession(t *testing.T) {
 							"token",
 							nil
 					},
-					intentAlg: decryption(nil),
+					intentAlg:     decryption(nil),
+					intentMapping: noIntentMapping,
 					now: func() time.Time {
 						return testNow
 					},
@@ -930,6 +938,79 @@ func TestCommands_updateSession(t *testing.T) {
 				},
 			},
 		},
+		{
+			"set user, intent with mapped metadata",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(), &user.NewAggregate("userID", "org1").Aggregate,
+								"username", "", "", "", "", language.English, domain.GenderUnspecified, "", false),
+						),
+						eventFromEventPusher(
+							idpintent.NewSucceededEvent(context.Background(),
+								&idpintent.NewAggregate("intent", "instance1").Aggregate,
+								[]byte(`{"department":"engineering"}`),
+								"idpUserID",
+								"idpUsername",
+								"userID",
+								nil,
+								nil,
+								"",
+								time.Now().Add(time.Hour),
+							),
+						),
+					),
+					expectPush(
+						session.NewUserCheckedEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
+							"userID", "org1", testNow, &language.Afrikaans),
+						session.NewIntentCheckedEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
+							testNow),
+						idpintent.NewConsumedEvent(context.Background(), &idpintent.NewAggregate("intent", "org1").Aggregate),
+						user.NewMetadataSetEvent(context.Background(), &user.NewAggregate("userID", "org1").Aggregate,
+							"department", []byte("engineering")),
+						session.NewTokenSetEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
+							"tokenID"),
+					),
+				),
+			},
+			args{
+				ctx: authz.NewMockContext("instance1", "", ""),
+				checks: &SessionCommands{
+					sessionWriteModel: NewSessionWriteModel("sessionID", "instance1"),
+					sessionCommands: []SessionCommand{
+						CheckUser("userID", "org1", &language.Afrikaans),
+						CheckIntent("intent", "aW50ZW50"),
+					},
+					createToken: func(sessionID string) (string, string, error) {
+						return "tokenID",
+							"token",
+							nil
+					},
+					intentAlg: decryption(nil),
+					intentMapping: func(ctx context.Context, intent *IDPIntentWriteModel, userID, resourceOwner string) ([]eventstore.Command, error) {
+						if string(intent.IDPUser) != `{"department":"engineering"}` {
+							return nil, nil
+						}
+						return []eventstore.Command{
+							user.NewMetadataSetEvent(ctx, &user.NewAggregate(userID, resourceOwner).Aggregate, "department", []byte("engineering")),
+						}, nil
+					},
+					now: func() time.Time {
+						return testNow
+					},
+				},
+			},
+			res{
+				want: &SessionChanged{
+					ObjectDetails: &domain.ObjectDetails{
+						ResourceOwner: "instance1",
+					},
+					ID:       "sessionID",
+					NewToken: "token",
+				},
+			},
+		},
 		{
 			"set user, intent (user not linked yet)",
 			fields{
@@ -956,6 +1037,7 @@ func TestCommands_updateSession(t *testing.T) {
 								"idpUsername",
 								"",
 								nil,
+								nil,
 								"",
 								time.Now().Add(time.Hour),
 							),
@@ -994,7 +1076,117 @@ func TestCommands_updateSession(t *testing.T) {
 							"token",
 							nil
 					},
-					intentAlg: decryption(nil),
+					intentAlg:     decryption(nil),
+					intentMapping: noIntentMapping,
+					now: func() time.Time {
+						return testNow
+					},
+				},
+			},
+			res{
+				want: &SessionChanged{
+					ObjectDetails: &domain.ObjectDetails{
+						ResourceOwner: "instance1",
+					},
+					ID:       "sessionID",
+					NewToken: "token",
+				},
+			},
+		},
+		{
+			"set user, intent with idp tokens",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(), &user.NewAggregate("userID", "org1").Aggregate,
+								"username", "", "", "", "", language.English, domain.GenderUnspecified, "", false),
+						),
+						eventFromEventPusher(
+							idpintent.NewStartedEvent(context.Background(),
+								&idpintent.NewAggregate("id", "instance1").Aggregate,
+								nil,
+								nil,
+								"idpID",
+								nil,
+							),
+						),
+						eventFromEventPusher(
+							idpintent.NewSucceededEvent(context.Background(),
+								&idpintent.NewAggregate("intent", "instance1").Aggregate,
+								nil,
+								"idpUserID",
+								"idpUsername",
+								"",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte("accessToken"),
+								},
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte("refreshToken"),
+								},
+								"",
+								testNow.Add(time.Hour),
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("userID", "org1").Aggregate,
+								"idpID",
+								"idpUsername",
+								"idpUserID",
+							),
+						),
+					),
+					expectPush(
+						session.NewUserCheckedEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
+							"userID", "org1", testNow, &language.Afrikaans),
+						session.NewIntentCheckedEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
+							testNow),
+						idpintent.NewConsumedEvent(context.Background(), &idpintent.NewAggregate("intent", "org1").Aggregate),
+						user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("userID", "org1").Aggregate,
+							"idpID",
+							"idpUserID",
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("accessToken"),
+							},
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("refreshToken"),
+							},
+							testNow.Add(time.Hour),
+						),
+						session.NewTokenSetEvent(context.Background(), &session.NewAggregate("sessionID", "instance1").Aggregate,
+							"tokenID"),
+					),
+				),
+			},
+			args{
+				ctx: authz.NewMockContext("instance1", "", ""),
+				checks: &SessionCommands{
+					sessionWriteModel: NewSessionWriteModel("sessionID", "instance1"),
+					sessionCommands: []SessionCommand{
+						CheckUser("userID", "org1", &language.Afrikaans),
+						CheckIntent("intent", "aW50ZW50"),
+					},
+					createToken: func(sessionID string) (string, string, error) {
+						return "tokenID",
+							"token",
+							nil
+					},
+					intentAlg:     decryption(nil),
+					intentMapping: noIntentMapping,
 					now: func() time.Time {
 						return testNow
 					},
@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"net"
+	"net/url"
 	"strings"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
@@ -311,6 +312,215 @@ func (c *Commands) ChangeSMTPConfigHTTP(ctx context.Context, config *ChangeSMTPC
 	return nil
 }
 
+type AddSMTPConfigAPI struct {
+	Details       *domain.ObjectDetails
+	ResourceOwner string
+	ID            string
+
+	Description    string
+	Provider       domain.EmailAPIProvider
+	SenderAddress  string
+	SenderName     string
+	ReplyToAddress string
+	Endpoint       string
+	Region         string
+	Domain         string
+	KeyID          string
+	APIKey         string
+}
+
+func (c *Commands) AddSMTPConfigAPI(ctx context.Context, config *AddSMTPConfigAPI) (err error) {
+	if config.ResourceOwner == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-qR3vTz8KpL", "Errors.ResourceOwnerMissing")
+	}
+	if config.ID == "" {
+		config.ID, err = c.idGenerator.Next()
+		if err != nil {
+			return err
+		}
+	}
+
+	senderAddress := strings.TrimSpace(config.SenderAddress)
+	endpoint := strings.TrimSpace(config.Endpoint)
+	region := strings.TrimSpace(config.Region)
+	sendingDomain := strings.TrimSpace(config.Domain)
+	keyID := strings.TrimSpace(config.KeyID)
+	if err := validateEmailAPIConfig(config.Provider, senderAddress, endpoint, region, sendingDomain, keyID, config.APIKey != ""); err != nil {
+		return err
+	}
+
+	var apiKey *crypto.CryptoValue
+	if config.APIKey != "" {
+		apiKey, err = crypto.Encrypt([]byte(config.APIKey), c.smtpEncryption)
+		if err != nil {
+			return err
+		}
+	}
+
+	smtpConfigWriteModel, err := c.getSMTPConfig(ctx, config.ResourceOwner, config.ID, senderAddressDomain(senderAddress))
+	if err != nil {
+		return err
+	}
+
+	err = checkSenderAddress(smtpConfigWriteModel)
+	if err != nil {
+		return err
+	}
+
+	err = c.pushAppendAndReduce(ctx, smtpConfigWriteModel, instance.NewSMTPConfigAPIAddedEvent(
+		ctx,
+		InstanceAggregateFromWriteModel(&smtpConfigWriteModel.WriteModel),
+		config.ID,
+		strings.TrimSpace(config.Description),
+		config.Provider,
+		senderAddress,
+		config.SenderName,
+		strings.TrimSpace(config.ReplyToAddress),
+		endpoint,
+		region,
+		sendingDomain,
+		keyID,
+		apiKey,
+	))
+	if err != nil {
+		return err
+	}
+	config.Details = writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel)
+	return nil
+}
+
+type ChangeSMTPConfigAPI struct {
+	Details       *domain.ObjectDetails
+	ResourceOwner string
+	ID            string
+
+	Description    string
+	SenderAddress  string
+	SenderName     string
+	ReplyToAddress string
+	Endpoint       string
+	Region         string
+	Domain         string
+	KeyID          string
+	// APIKey is only changed if set
+	APIKey string
+}
+
+func (c *Commands) ChangeSMTPConfigAPI(ctx context.Context, config *ChangeSMTPConfigAPI) (err error) {
+	if config.ResourceOwner == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Wc7nHs2QdX", "Errors.ResourceOwnerMissing")
+	}
+	if config.ID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Ld9pGk4EaM", "Errors.IDMissing")
+	}
+
+	senderAddress := strings.TrimSpace(config.SenderAddress)
+	smtpConfigWriteModel, err := c.getSMTPConfig(ctx, config.ResourceOwner, config.ID, senderAddressDomain(senderAddress))
+	if err != nil {
+		return err
+	}
+
+	if !smtpConfigWriteModel.State.Exists() || smtpConfigWriteModel.APIConfig == nil {
+		return zerrors.ThrowNotFound(nil, "COMMAND-Yt5bRf8UoN", "Errors.SMTPConfig.NotFound")
+	}
+
+	endpoint := strings.TrimSpace(config.Endpoint)
+	region := strings.TrimSpace(config.Region)
+	sendingDomain := strings.TrimSpace(config.Domain)
+	keyID := strings.TrimSpace(config.KeyID)
+	hasAPIKey := config.APIKey != "" || smtpConfigWriteModel.APIConfig.APIKey != nil
+	if err := validateEmailAPIConfig(smtpConfigWriteModel.APIConfig.Provider, senderAddress, endpoint, region, sendingDomain, keyID, hasAPIKey); err != nil {
+		return err
+	}
+
+	err = checkSenderAddress(smtpConfigWriteModel)
+	if err != nil {
+		return err
+	}
+
+	var apiKey *crypto.CryptoValue
+	if config.APIKey != "" {
+		apiKey, err = crypto.Encrypt([]byte(config.APIKey), c.smtpEncryption)
+		if err != nil {
+			return err
+		}
+	}
+
+	changedEvent, hasChanged, err := smtpConfigWriteModel.NewAPIChangedEvent(
+		ctx,
+		InstanceAggregateFromWriteModel(&smtpConfigWriteModel.WriteModel),
+		config.ID,
+		strings.TrimSpace(config.Description),
+		senderAddress,
+		config.SenderName,
+		strings.TrimSpace(config.ReplyToAddress),
+		endpoint,
+		region,
+		sendingDomain,
+		keyID,
+		apiKey,
+	)
+	if err != nil {
+		return err
+	}
+	if !hasChanged {
+		config.Details = writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel)
+		return nil
+	}
+
+	err = c.pushAppendAndReduce(ctx, smtpConfigWriteModel, changedEvent)
+	if err != nil {
+		return err
+	}
+	config.Details = writeModelToObjectDetails(&smtpConfigWriteModel.WriteModel)
+	return nil
+}
+
+// validateEmailAPIConfig checks that all values required by the provider are set.
+// The endpoint is optional for all providers except the generic JSON provider,
+// where it's the only way to know where to send the emails to.
+func validateEmailAPIConfig(provider domain.EmailAPIProvider, senderAddress, endpoint, region, sendingDomain, keyID string, hasAPIKey bool) error {
+	if !provider.Valid() {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Hb6sVw3JxC", "Errors.Invalid.Argument")
+	}
+	if senderAddress == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Fp2yKq9TmZ", "Errors.Invalid.Argument")
+	}
+	if endpoint != "" {
+		if u, err := url.Parse(endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+			return zerrors.ThrowInvalidArgument(err, "COMMAND-Ue4gNz7RbW", "Errors.Invalid.Argument")
+		}
+	}
+	switch provider {
+	case domain.EmailAPIProviderSES:
+		if region == "" || keyID == "" || !hasAPIKey {
+			return zerrors.ThrowInvalidArgument(nil, "COMMAND-Sx8mDc5HvT", "Errors.Invalid.Argument")
+		}
+	case domain.EmailAPIProviderSendGrid:
+		if !hasAPIKey {
+			return zerrors.ThrowInvalidArgument(nil, "COMMAND-Gd3rLp6WyE", "Errors.Invalid.Argument")
+		}
+	case domain.EmailAPIProviderMailgun:
+		if sendingDomain == "" || !hasAPIKey {
+			return zerrors.ThrowInvalidArgument(nil, "COMMAND-Mk7tBj2NsQ", "Errors.Invalid.Argument")
+		}
+	case domain.EmailAPIProviderJSON:
+		if endpoint == "" {
+			return zerrors.ThrowInvalidArgument(nil, "COMMAND-Jv5qXe8AcP", "Errors.Invalid.Argument")
+		}
+	case domain.EmailAPIProviderUnspecified:
+	}
+	return nil
+}
+
+func senderAddressDomain(senderAddress string) string {
+	if senderAddress == "" {
+		return ""
+	}
+	senderSplitted := strings.Split(senderAddress, "@")
+	return senderSplitted[len(senderSplitted)-1]
+}
+
 func (c *Commands) ActivateSMTPConfig(ctx context.Context, resourceOwner, id string) (*domain.ObjectDetails, error) {
 	if resourceOwner == "" {
 		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-h5htMCebv3", "Errors.ResourceOwnerMissing")
@@ -1141,6 +1141,534 @@ func TestCommandSide_ChangeSMTPConfigHTTP(t *testing.T) {
 	}
 }
 
+func TestCommandSide_AddSMTPConfigAPI(t *testing.T) {
+	type fields struct {
+		eventstore  func(t *testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+		alg         crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		api *AddSMTPConfigAPI
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "add smtp config, resourceowner empty",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				api: &AddSMTPConfigAPI{},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-qR3vTz8KpL", "Errors.ResourceOwnerMissing"))
+				},
+			},
+		},
+		{
+			name: "provider unspecified, invalid argument error",
+			fields: fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configid"),
+			},
+			args: args{
+				api: &AddSMTPConfigAPI{
+					ResourceOwner: "INSTANCE",
+					SenderAddress: "from@domain.ch",
+					APIKey:        "key",
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Hb6sVw3JxC", "Errors.Invalid.Argument"))
+				},
+			},
+		},
+		{
+			name: "sender address empty, invalid argument error",
+			fields: fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configid"),
+			},
+			args: args{
+				api: &AddSMTPConfigAPI{
+					ResourceOwner: "INSTANCE",
+					Provider:      domain.EmailAPIProviderSendGrid,
+					APIKey:        "key",
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Fp2yKq9TmZ", "Errors.Invalid.Argument"))
+				},
+			},
+		},
+		{
+			name: "invalid endpoint, invalid argument error",
+			fields: fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configid"),
+			},
+			args: args{
+				api: &AddSMTPConfigAPI{
+					ResourceOwner: "INSTANCE",
+					Provider:      domain.EmailAPIProviderJSON,
+					SenderAddress: "from@domain.ch",
+					Endpoint:      "endpoint",
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ue4gNz7RbW", "Errors.Invalid.Argument"))
+				},
+			},
+		},
+		{
+			name: "ses without region, invalid argument error",
+			fields: fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configid"),
+			},
+			args: args{
+				api: &AddSMTPConfigAPI{
+					ResourceOwner: "INSTANCE",
+					Provider:      domain.EmailAPIProviderSES,
+					SenderAddress: "from@domain.ch",
+					KeyID:         "keyid",
+					APIKey:        "secret",
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Sx8mDc5HvT", "Errors.Invalid.Argument"))
+				},
+			},
+		},
+		{
+			name: "sendgrid without api key, invalid argument error",
+			fields: fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configid"),
+			},
+			args: args{
+				api: &AddSMTPConfigAPI{
+					ResourceOwner: "INSTANCE",
+					Provider:      domain.EmailAPIProviderSendGrid,
+					SenderAddress: "from@domain.ch",
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Gd3rLp6WyE", "Errors.Invalid.Argument"))
+				},
+			},
+		},
+		{
+			name: "mailgun without domain, invalid argument error",
+			fields: fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configid"),
+			},
+			args: args{
+				api: &AddSMTPConfigAPI{
+					ResourceOwner: "INSTANCE",
+					Provider:      domain.EmailAPIProviderMailgun,
+					SenderAddress: "from@domain.ch",
+					APIKey:        "key",
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Mk7tBj2NsQ", "Errors.Invalid.Argument"))
+				},
+			},
+		},
+		{
+			name: "json without endpoint, invalid argument error",
+			fields: fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configid"),
+			},
+			args: args{
+				api: &AddSMTPConfigAPI{
+					ResourceOwner: "INSTANCE",
+					Provider:      domain.EmailAPIProviderJSON,
+					SenderAddress: "from@domain.ch",
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Jv5qXe8AcP", "Errors.Invalid.Argument"))
+				},
+			},
+		},
+		{
+			name: "sender address not custom domain, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewDomainPolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								true, true, true,
+							),
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configid"),
+				alg:         crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				api: &AddSMTPConfigAPI{
+					ResourceOwner: "INSTANCE",
+					Provider:      domain.EmailAPIProviderSendGrid,
+					SenderAddress: "from@wrongdomain.ch",
+					APIKey:        "key",
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "INST-xtWIiR2ZbR", "Errors.SMTPConfig.SenderAdressNotCustomDomain"))
+				},
+			},
+		},
+		{
+			name: "add ses config, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						instance.NewSMTPConfigAPIAddedEvent(
+							context.Background(),
+							&instance.NewAggregate("INSTANCE").Aggregate,
+							"configid",
+							"test",
+							domain.EmailAPIProviderSES,
+							"from@domain.ch",
+							"name",
+							"replyto@domain.ch",
+							"",
+							"eu-central-1",
+							"",
+							"keyid",
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("secret"),
+							},
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configid"),
+				alg:         crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				api: &AddSMTPConfigAPI{
+					ResourceOwner:  "INSTANCE",
+					Description:    "test",
+					Provider:       domain.EmailAPIProviderSES,
+					SenderAddress:  "from@domain.ch",
+					SenderName:     "name",
+					ReplyToAddress: "replyto@domain.ch",
+					Region:         "eu-central-1",
+					KeyID:          "keyid",
+					APIKey:         "secret",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+		{
+			name: "add json config without signing key, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						instance.NewSMTPConfigAPIAddedEvent(
+							context.Background(),
+							&instance.NewAggregate("INSTANCE").Aggregate,
+							"configid",
+							"test",
+							domain.EmailAPIProviderJSON,
+							"from@domain.ch",
+							"",
+							"",
+							"https://relay.example.com/mail",
+							"",
+							"",
+							"",
+							nil,
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "configid"),
+			},
+			args: args{
+				api: &AddSMTPConfigAPI{
+					ResourceOwner: "INSTANCE",
+					Description:   "test",
+					Provider:      domain.EmailAPIProviderJSON,
+					SenderAddress: "from@domain.ch",
+					Endpoint:      "https://relay.example.com/mail",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:     tt.fields.eventstore(t),
+				idGenerator:    tt.fields.idGenerator,
+				smtpEncryption: tt.fields.alg,
+			}
+			err := r.AddSMTPConfigAPI(context.Background(), tt.args.api)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, tt.args.api.Details)
+				assert.NotEmpty(t, tt.args.api.ID)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ChangeSMTPConfigAPI(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+		alg        crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		api *ChangeSMTPConfigAPI
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "resourceowner empty, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				api: &ChangeSMTPConfigAPI{},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Wc7nHs2QdX", "Errors.ResourceOwnerMissing"))
+				},
+			},
+		},
+		{
+			name: "id empty, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				api: &ChangeSMTPConfigAPI{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ld9pGk4EaM", "Errors.IDMissing"))
+				},
+			},
+		},
+		{
+			name: "smtp not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				api: &ChangeSMTPConfigAPI{
+					ResourceOwner: "INSTANCE",
+					ID:            "ID",
+					SenderAddress: "from@domain.ch",
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowNotFound(nil, "COMMAND-Yt5bRf8UoN", "Errors.SMTPConfig.NotFound"))
+				},
+			},
+		},
+		{
+			name: "http config, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewSMTPConfigHTTPAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"ID",
+								"test",
+								"endpoint",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				api: &ChangeSMTPConfigAPI{
+					ResourceOwner: "INSTANCE",
+					ID:            "ID",
+					SenderAddress: "from@domain.ch",
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowNotFound(nil, "COMMAND-Yt5bRf8UoN", "Errors.SMTPConfig.NotFound"))
+				},
+			},
+		},
+		{
+			name: "mailgun domain removed, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							newSMTPConfigAPIAddedEvent(domain.EmailAPIProviderMailgun, "", "mg.domain.ch"),
+						),
+					),
+				),
+			},
+			args: args{
+				api: &ChangeSMTPConfigAPI{
+					ResourceOwner: "INSTANCE",
+					ID:            "ID",
+					Description:   "test",
+					SenderAddress: "from@domain.ch",
+				},
+			},
+			res: res{
+				err: func(err error) bool {
+					return errors.Is(err, zerrors.ThrowInvalidArgument(nil, "COMMAND-Mk7tBj2NsQ", "Errors.Invalid.Argument"))
+				},
+			},
+		},
+		{
+			name: "no changes, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							newSMTPConfigAPIAddedEvent(domain.EmailAPIProviderMailgun, "", "mg.domain.ch"),
+						),
+					),
+				),
+			},
+			args: args{
+				api: &ChangeSMTPConfigAPI{
+					ResourceOwner: "INSTANCE",
+					ID:            "ID",
+					Description:   "test",
+					SenderAddress: "from@domain.ch",
+					Domain:        "mg.domain.ch",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+		{
+			name: "smtp config change, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							newSMTPConfigAPIAddedEvent(domain.EmailAPIProviderMailgun, "", "mg.domain.ch"),
+						),
+					),
+					expectPush(
+						newSMTPConfigAPIChangedEvent(
+							context.Background(),
+							"ID",
+							instance.ChangeSMTPConfigAPIDescription("test2"),
+							instance.ChangeSMTPConfigAPISenderName("name"),
+							instance.ChangeSMTPConfigAPIEndpoint("https://api.eu.mailgun.net"),
+							instance.ChangeSMTPConfigAPIKey(&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("key2"),
+							}),
+						),
+					),
+				),
+				alg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				api: &ChangeSMTPConfigAPI{
+					ResourceOwner: "INSTANCE",
+					ID:            "ID",
+					Description:   "test2",
+					SenderAddress: "from@domain.ch",
+					SenderName:    "name",
+					Endpoint:      "https://api.eu.mailgun.net",
+					Domain:        "mg.domain.ch",
+					APIKey:        "key2",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:     tt.fields.eventstore(t),
+				smtpEncryption: tt.fields.alg,
+			}
+			err := r.ChangeSMTPConfigAPI(context.Background(), tt.args.api)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, tt.args.api.Details)
+			}
+		})
+	}
+}
+
 func TestCommandSide_ActivateSMTPConfig(t *testing.T) {
 	type fields struct {
 		eventstore func(t *testing.T) *eventstore.Eventstore
@@ -2063,3 +2591,35 @@ func newSMTPConfigHTTPChangedEvent(ctx context.Context, id, description, endpoin
 	)
 	return event
 }
+
+func newSMTPConfigAPIAddedEvent(provider domain.EmailAPIProvider, endpoint, sendingDomain string) *instance.SMTPConfigAPIAddedEvent {
+	return instance.NewSMTPConfigAPIAddedEvent(
+		context.Background(),
+		&instance.NewAggregate("INSTANCE").Aggregate,
+		"ID",
+		"test",
+		provider,
+		"from@domain.ch",
+		"",
+		"",
+		endpoint,
+		"",
+		sendingDomain,
+		"",
+		&crypto.CryptoValue{
+			CryptoType: crypto.TypeEncryption,
+			Algorithm:  "enc",
+			KeyID:      "id",
+			Crypted:    []byte("key"),
+		},
+	)
+}
+
+func newSMTPConfigAPIChangedEvent(ctx context.Context, id string, changes ...instance.SMTPConfigAPIChanges) *instance.SMTPConfigAPIChangedEvent {
+	event, _ := instance.NewSMTPConfigAPIChangeEvent(ctx,
+		&instance.NewAggregate("INSTANCE").Aggregate,
+		id,
+		changes,
+	)
+	return event
+}
@@ -108,6 +108,7 @@ func writeModelToWebAuthN(wm *HumanWebAuthNWriteModel) *domain.WebAuthNToken {
 		WebAuthNTokenName: wm.WebAuthNTokenName,
 		State:             wm.State,
 		RPID:              wm.RPID,
+		ModelName:         wm.ModelName,
 	}
 }
 
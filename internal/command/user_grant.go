@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"reflect"
+	"slices"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -61,6 +62,43 @@ func (c *Commands) addUserGrant(ctx context.Context, userGrant *domain.UserGrant
 	return command, addedUserGrant, nil
 }
 
+// addUserGrantRoles grants the roles to the user on the project (grant).
+// If the user already has a grant on it, the missing roles are added to it,
+// so roles granted otherwise are kept.
+func (c *Commands) addUserGrantRoles(ctx context.Context, userGrant *domain.UserGrant, resourceOwner string) (_ eventstore.Command, _ *UserGrantWriteModel, err error) {
+	if !userGrant.IsValid() {
+		return nil, nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-ooy4E", "Errors.UserGrant.Invalid")
+	}
+	existingUserGrant, err := c.userGrantWriteModelByProject(ctx, userGrant.UserID, userGrant.ProjectID, userGrant.ProjectGrantID, resourceOwner)
+	if err != nil {
+		return nil, nil, err
+	}
+	if existingUserGrant == nil {
+		return c.addUserGrant(ctx, userGrant, resourceOwner)
+	}
+	roleKeys := slices.Clone(existingUserGrant.RoleKeys)
+	for _, roleKey := range userGrant.RoleKeys {
+		if !slices.Contains(roleKeys, roleKey) {
+			roleKeys = append(roleKeys, roleKey)
+		}
+	}
+	if len(roleKeys) == len(existingUserGrant.RoleKeys) {
+		return nil, existingUserGrant, nil
+	}
+	userGrant.AggregateID = existingUserGrant.AggregateID
+	userGrant.RoleKeys = roleKeys
+	err = c.checkUserGrantPreCondition(ctx, userGrant, resourceOwner)
+	if err != nil {
+		return nil, nil, err
+	}
+	return usergrant.NewUserGrantChangedEvent(
+		ctx,
+		UserGrantAggregateFromWriteModel(&existingUserGrant.WriteModel),
+		existingUserGrant.UserID,
+		roleKeys,
+	), existingUserGrant, nil
+}
+
 func (c *Commands) ChangeUserGrant(ctx context.Context, userGrant *domain.UserGrant, resourceOwner string) (_ *domain.UserGrant, err error) {
 	event, changedUserGrant, err := c.changeUserGrant(ctx, userGrant, resourceOwner, false)
 	if err != nil {
@@ -291,6 +329,40 @@ func (c *Commands) userGrantWriteModelByID(ctx context.Context, userGrantID, res
 	return writeModel, nil
 }
 
+// userGrantWriteModelByProject returns the grant of the user on the project (grant)
+// or nil if the user has none.
+func (c *Commands) userGrantWriteModelByProject(ctx context.Context, userID, projectID, projectGrantID, resourceOwner string) (_ *UserGrantWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	// only the added events contain the user and project, so the ids of the grants are filtered first
+	added, err := c.eventstore.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(resourceOwner).
+		AddQuery().
+		AggregateTypes(usergrant.AggregateType).
+		EventTypes(usergrant.UserGrantAddedType).
+		EventData(map[string]interface{}{"userId": userID, "projectId": projectID}).
+		Builder())
+	if err != nil {
+		return nil, err
+	}
+	// the grant can only exist once, so the latest one is checked first
+	for i := len(added) - 1; i >= 0; i-- {
+		e, ok := added[i].(*usergrant.UserGrantAddedEvent)
+		if !ok || e.ProjectGrantID != projectGrantID {
+			continue
+		}
+		writeModel, err := c.userGrantWriteModelByID(ctx, e.Aggregate().ID, resourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		if writeModel.State == domain.UserGrantStateActive || writeModel.State == domain.UserGrantStateInactive {
+			return writeModel, nil
+		}
+	}
+	return nil, nil
+}
+
 func (c *Commands) checkUserGrantPreCondition(ctx context.Context, usergrant *domain.UserGrant, resourceOwner string) (err error) {
 	if !authz.GetFeatures(ctx).ShouldUseImprovedPerformance(feature.ImprovedPerformanceTypeUserGrant) {
 		return c.checkUserGrantPreConditionOld(ctx, usergrant, resourceOwner)
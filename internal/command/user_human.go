@@ -235,7 +235,7 @@ func (c *Commands) AddHumanCommand(human *AddHuman, orgID string, hasher *crypto
 				createCmd.AddPhoneData(human.Phone.Number)
 			}
 
-			if err := addHumanCommandPassword(ctx, filter, createCmd, human, hasher); err != nil {
+			if err := addHumanCommandPassword(ctx, filter, createCmd, human, hasher, c.passwordBreachChecker); err != nil {
 				return nil, err
 			}
 
@@ -347,9 +347,9 @@ func (c *Commands) addHumanCommandCheckID(ctx context.Context, filter preparatio
 	return nil
 }
 
-func addHumanCommandPassword(ctx context.Context, filter preparation.FilterToQueryReducer, createCmd humanCreationCommand, human *AddHuman, hasher *crypto.Hasher) (err error) {
+func addHumanCommandPassword(ctx context.Context, filter preparation.FilterToQueryReducer, createCmd humanCreationCommand, human *AddHuman, hasher *crypto.Hasher, breachChecker crypto.PasswordBreachChecker) (err error) {
 	if human.Password != "" {
-		if err = humanValidatePassword(ctx, filter, human.Password); err != nil {
+		if err = humanValidatePassword(ctx, filter, human.Password, breachChecker); err != nil {
 			return err
 		}
 
@@ -391,13 +391,16 @@ func (c *Commands) userValidateDomain(ctx context.Context, resourceOwner string,
 	return nil
 }
 
-func humanValidatePassword(ctx context.Context, filter preparation.FilterToQueryReducer, password string) error {
+func humanValidatePassword(ctx context.Context, filter preparation.FilterToQueryReducer, password string, breachChecker crypto.PasswordBreachChecker) error {
 	passwordComplexity, err := passwordComplexityPolicyWriteModel(ctx, filter)
 	if err != nil {
 		return err
 	}
 
-	return passwordComplexity.Validate(password)
+	if err = passwordComplexity.Validate(password); err != nil {
+		return err
+	}
+	return checkPasswordBreached(ctx, breachChecker, passwordComplexity.CheckBreached, password)
 }
 
 func (h *AddHuman) ensureDisplayName() {
@@ -549,6 +552,14 @@ func (c *Commands) createHuman(ctx context.Context, orgID string, human *domain.
 
 	human.EnsureDisplayName()
 	if human.Password != nil {
+		if pwPolicy != nil && human.Password.SecretString != "" {
+			if err := pwPolicy.Check(human.Password.SecretString); err != nil {
+				return nil, nil, nil, err
+			}
+			if err := checkPasswordBreached(ctx, c.passwordBreachChecker, pwPolicy.CheckBreached, human.Password.SecretString); err != nil {
+				return nil, nil, nil, err
+			}
+		}
 		if err := human.HashPasswordIfExisting(ctx, pwPolicy, c.userPasswordHasher, human.Password.ChangeRequired); err != nil {
 			return nil, nil, nil, err
 		}
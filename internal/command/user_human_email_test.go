@@ -633,6 +633,7 @@ func TestCommandSide_VerifyHumanEmail(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -9,6 +9,7 @@ import (
 	"github.com/zitadel/logging"
 	"github.com/zitadel/passwap"
 
+	"github.com/zitadel/zitadel/internal/api/authz"
 	commandErrors "github.com/zitadel/zitadel/internal/command/errors"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -248,6 +249,29 @@ func (c *Commands) checkPasswordComplexity(ctx context.Context, newPassword stri
 	if err := policy.Check(newPassword); err != nil {
 		return err
 	}
+	return checkPasswordBreached(ctx, c.passwordBreachChecker, policy.CheckBreached, newPassword)
+}
+
+// checkPasswordBreached rejects the password if the policy requires it not to be part of a known breach corpus.
+// If no breach corpus is configured, the check is skipped.
+func checkPasswordBreached(ctx context.Context, checker crypto.PasswordBreachChecker, checkBreached bool, password string) (err error) {
+	if !checkBreached || password == "" {
+		return nil
+	}
+	if checker == nil {
+		logging.WithFields("instance", authz.GetInstance(ctx).InstanceID()).Warn("password complexity policy requires a breach check, but no breach corpus is configured")
+		return nil
+	}
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	breached, err := checker.IsBreached(ctx, password)
+	if err != nil {
+		return err
+	}
+	if breached {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Dee4u", "Errors.User.PasswordComplexityPolicy.Breached")
+	}
 	return nil
 }
 
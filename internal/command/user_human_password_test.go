@@ -150,6 +150,7 @@ func TestCommandSide_SetOneTimePassword(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -212,6 +213,7 @@ func TestCommandSide_SetOneTimePassword(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -463,6 +465,7 @@ func TestCommandSide_SetPasswordWithVerifyCode(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -540,6 +543,7 @@ func TestCommandSide_SetPasswordWithVerifyCode(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -618,6 +622,7 @@ func TestCommandSide_SetPasswordWithVerifyCode(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -701,6 +706,7 @@ func TestCommandSide_SetPasswordWithVerifyCode(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -759,7 +765,8 @@ func TestCommandSide_SetPasswordWithVerifyCode(t *testing.T) {
 
 func TestCommandSide_ChangePassword(t *testing.T) {
 	type fields struct {
-		userPasswordHasher *crypto.Hasher
+		userPasswordHasher    *crypto.Hasher
+		passwordBreachChecker crypto.PasswordBreachChecker
 	}
 	type args struct {
 		ctx            context.Context
@@ -924,6 +931,7 @@ func TestCommandSide_ChangePassword(t *testing.T) {
 							true,
 							true,
 							true,
+							false,
 						),
 					),
 				),
@@ -1026,6 +1034,7 @@ func TestCommandSide_ChangePassword(t *testing.T) {
 							false,
 							false,
 							false,
+							false,
 						),
 					),
 				),
@@ -1094,6 +1103,7 @@ func TestCommandSide_ChangePassword(t *testing.T) {
 							false,
 							false,
 							false,
+							false,
 						),
 					),
 				),
@@ -1163,6 +1173,7 @@ func TestCommandSide_ChangePassword(t *testing.T) {
 							false,
 							false,
 							false,
+							false,
 						),
 					),
 				),
@@ -1181,12 +1192,72 @@ func TestCommandSide_ChangePassword(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "change password, breached",
+			fields: fields{
+				userPasswordHasher:    mockPasswordHasher("x"),
+				passwordBreachChecker: &mockPasswordBreachChecker{breached: true},
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				oldPassword:   "password",
+				newPassword:   "password1",
+			},
+			expect: []expect{
+				expectFilter(
+					eventFromEventPusher(
+						user.NewHumanAddedEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+							"username",
+							"firstname",
+							"lastname",
+							"nickname",
+							"displayname",
+							language.German,
+							domain.GenderUnspecified,
+							"email@test.ch",
+							true,
+						),
+					),
+					eventFromEventPusher(
+						user.NewHumanEmailVerifiedEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+						),
+					),
+					eventFromEventPusher(
+						user.NewHumanPasswordChangedEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+							"$plain$x$password",
+							false,
+							"")),
+				),
+				expectFilter(
+					eventFromEventPusher(
+						org.NewPasswordComplexityPolicyAddedEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+							1,
+							false,
+							false,
+							false,
+							false,
+							true,
+						),
+					),
+				),
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &Commands{
-				eventstore:         eventstoreExpect(t, tt.expect...),
-				userPasswordHasher: tt.fields.userPasswordHasher,
+				eventstore:            eventstoreExpect(t, tt.expect...),
+				userPasswordHasher:    tt.fields.userPasswordHasher,
+				passwordBreachChecker: tt.fields.passwordBreachChecker,
 			}
 			got, err := r.ChangePassword(tt.args.ctx, tt.args.resourceOwner, tt.args.userID, tt.args.oldPassword, tt.args.newPassword, tt.args.userAgentID, tt.args.changeRequired)
 			if tt.res.err == nil {
@@ -2434,3 +2505,74 @@ func Test_convertPasswapErr(t *testing.T) {
 		})
 	}
 }
+
+type mockPasswordBreachChecker struct {
+	breached bool
+	err      error
+}
+
+func (m *mockPasswordBreachChecker) IsBreached(context.Context, string) (bool, error) {
+	return m.breached, m.err
+}
+
+func Test_checkPasswordBreached(t *testing.T) {
+	type args struct {
+		checker       crypto.PasswordBreachChecker
+		checkBreached bool
+		password      string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "not required",
+			args: args{
+				checker:       &mockPasswordBreachChecker{breached: true},
+				checkBreached: false,
+				password:      "password",
+			},
+		},
+		{
+			name: "no checker configured",
+			args: args{
+				checker:       nil,
+				checkBreached: true,
+				password:      "password",
+			},
+		},
+		{
+			name: "not breached",
+			args: args{
+				checker:       &mockPasswordBreachChecker{breached: false},
+				checkBreached: true,
+				password:      "Sup3rS3cret!",
+			},
+		},
+		{
+			name: "breached",
+			args: args{
+				checker:       &mockPasswordBreachChecker{breached: true},
+				checkBreached: true,
+				password:      "password",
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "COMMAND-Dee4u", "Errors.User.PasswordComplexityPolicy.Breached"),
+		},
+		{
+			name: "checker error",
+			args: args{
+				checker:       &mockPasswordBreachChecker{err: io.ErrClosedPipe},
+				checkBreached: true,
+				password:      "password",
+			},
+			wantErr: io.ErrClosedPipe,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPasswordBreached(context.Background(), tt.args.checker, tt.args.checkBreached, tt.args.password)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// recoveryCodeChars omits characters which are easily confused with each other (0/o, 1/l/i)
+var recoveryCodeChars = []rune("abcdefghjkmnpqrstuvwxyz23456789")
+
+type recoveryCodesFunc func() (hashedCodes, plainCodes []string, err error)
+
+func newRecoveryCodesFunc(hasher *crypto.Hasher) recoveryCodesFunc {
+	return func() (hashedCodes, plainCodes []string, err error) {
+		hashedCodes = make([]string, domain.RecoveryCodesCount)
+		plainCodes = make([]string, domain.RecoveryCodesCount)
+		for i := range domain.RecoveryCodesCount {
+			plainCodes[i], err = crypto.GenerateRandomString(domain.RecoveryCodeLength, recoveryCodeChars)
+			if err != nil {
+				return nil, nil, err
+			}
+			hashedCodes[i], err = hasher.Hash(plainCodes[i])
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		return hashedCodes, plainCodes, nil
+	}
+}
+
+// GenerateHumanRecoveryCodes generates a new set of recovery codes for the user.
+// Previously generated codes will no longer be valid.
+// The plain codes are only returned once and can't be retrieved afterwards.
+func (c *Commands) GenerateHumanRecoveryCodes(ctx context.Context, userID, resourceOwner string) (_ *domain.RecoveryCodes, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Aeph4", "Errors.User.UserIDMissing")
+	}
+	human, err := c.getHuman(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.checkPermissionUpdateUserCredentials(ctx, human.ResourceOwner, userID); err != nil {
+		return nil, err
+	}
+	writeModel, err := c.recoveryCodesWriteModelByID(ctx, userID, human.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	hashedCodes, plainCodes, err := c.newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	userAgg := UserAggregateFromWriteModel(&writeModel.WriteModel)
+	if err = c.pushAppendAndReduce(ctx, writeModel, user.NewHumanRecoveryCodesAddedEvent(ctx, userAgg, hashedCodes)); err != nil {
+		return nil, err
+	}
+	for i, code := range plainCodes {
+		plainCodes[i] = domain.FormatRecoveryCode(code)
+	}
+	return &domain.RecoveryCodes{
+		ObjectDetails: writeModelToObjectDetails(&writeModel.WriteModel),
+		Codes:         plainCodes,
+	}, nil
+}
+
+// RemoveHumanRecoveryCodes removes all recovery codes of the user.
+func (c *Commands) RemoveHumanRecoveryCodes(ctx context.Context, userID, resourceOwner string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-ooH3u", "Errors.User.UserIDMissing")
+	}
+	writeModel, err := c.recoveryCodesWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State != domain.MFAStateReady {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Shie7", "Errors.User.MFA.RecoveryCodes.NotExisting")
+	}
+	if err = c.checkPermissionUpdateUserCredentials(ctx, writeModel.ResourceOwner, userID); err != nil {
+		return nil, err
+	}
+	userAgg := UserAggregateFromWriteModel(&writeModel.WriteModel)
+	if err = c.pushAppendAndReduce(ctx, writeModel, user.NewHumanRecoveryCodesRemovedEvent(ctx, userAgg)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// HumanCheckRecoveryCode checks the recovery code of the user in the login (v1)
+// and invalidates it on success.
+func (c *Commands) HumanCheckRecoveryCode(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest) error {
+	commands, err := checkRecoveryCode(
+		ctx,
+		userID,
+		resourceOwner,
+		code,
+		c.eventstore.FilterToQueryReducer,
+		c.secretHasher,
+		authRequestDomainToAuthRequestInfo(authRequest),
+	)
+
+	_, pushErr := c.eventstore.Push(ctx, commands...)
+	logging.OnError(pushErr).Error("error create recovery code check events")
+	return err
+}
+
+func checkRecoveryCode(
+	ctx context.Context,
+	userID, resourceOwner, code string,
+	queryReducer func(ctx context.Context, r eventstore.QueryReducer) error,
+	hasher *crypto.Hasher,
+	optionalAuthRequestInfo *user.AuthRequestInfo,
+) ([]eventstore.Command, error) {
+	if userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ceij5", "Errors.User.UserIDMissing")
+	}
+	writeModel := NewHumanRecoveryCodesWriteModel(userID, resourceOwner)
+	err := queryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State != domain.MFAStateReady {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ohl6a", "Errors.User.MFA.RecoveryCodes.NotExisting")
+	}
+	userAgg := UserAggregateFromWriteModel(&writeModel.WriteModel)
+	codeIndex := verifyRecoveryCode(writeModel, domain.NormalizeRecoveryCode(code), hasher)
+	var hashedCode string
+	if codeIndex >= 0 {
+		hashedCode = writeModel.HashedCodes[codeIndex]
+	}
+
+	// recheck for additional events (failed checks, used codes or locks)
+	recheckErr := queryReducer(ctx, writeModel)
+	if recheckErr != nil {
+		return nil, recheckErr
+	}
+	if writeModel.UserLocked {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-uu9Ai", "Errors.User.Locked")
+	}
+
+	// the check succeeded and neither was the code used (or regenerated) nor the user locked in the meantime
+	if codeIndex >= 0 && codeIndex < len(writeModel.HashedCodes) &&
+		writeModel.HashedCodes[codeIndex] == hashedCode && !writeModel.UsedCodes[codeIndex] {
+		return []eventstore.Command{user.NewHumanRecoveryCodeCheckSucceededEvent(ctx, userAgg, codeIndex, optionalAuthRequestInfo)}, nil
+	}
+
+	// the check failed, therefore check if the limit was reached and the user must additionally be locked
+	commands := make([]eventstore.Command, 0, 2)
+	commands = append(commands, user.NewHumanRecoveryCodeCheckFailedEvent(ctx, userAgg, optionalAuthRequestInfo))
+	lockoutPolicy, err := getLockoutPolicy(ctx, writeModel.ResourceOwner, queryReducer)
+	if err != nil {
+		return nil, err
+	}
+	if lockoutPolicy.MaxOTPAttempts > 0 && writeModel.CheckFailedCount+1 >= lockoutPolicy.MaxOTPAttempts {
+		commands = append(commands, user.NewUserLockedEvent(ctx, userAgg))
+	}
+	return commands, zerrors.ThrowInvalidArgument(nil, "COMMAND-Iej3o", "Errors.User.MFA.RecoveryCodes.Invalid")
+}
+
+// verifyRecoveryCode returns the index of the unused code matching the provided one or -1 if none matches.
+func verifyRecoveryCode(writeModel *HumanRecoveryCodesWriteModel, code string, hasher *crypto.Hasher) int {
+	if code == "" {
+		return -1
+	}
+	for i, hashedCode := range writeModel.HashedCodes {
+		if writeModel.UsedCodes[i] {
+			continue
+		}
+		if _, err := hasher.Verify(hashedCode, code); err == nil {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *Commands) recoveryCodesWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanRecoveryCodesWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanRecoveryCodesWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,92 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type HumanRecoveryCodesWriteModel struct {
+	eventstore.WriteModel
+
+	State            domain.MFAState
+	HashedCodes      []string
+	UsedCodes        []bool
+	CheckFailedCount uint64
+	UserLocked       bool
+}
+
+func NewHumanRecoveryCodesWriteModel(userID, resourceOwner string) *HumanRecoveryCodesWriteModel {
+	return &HumanRecoveryCodesWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanRecoveryCodesWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanRecoveryCodesAddedEvent:
+			wm.State = domain.MFAStateReady
+			wm.HashedCodes = e.HashedCodes
+			wm.UsedCodes = make([]bool, len(e.HashedCodes))
+			wm.CheckFailedCount = 0
+		case *user.HumanRecoveryCodeCheckSucceededEvent:
+			if e.CodeIndex >= 0 && e.CodeIndex < len(wm.UsedCodes) {
+				wm.UsedCodes[e.CodeIndex] = true
+			}
+			wm.CheckFailedCount = 0
+		case *user.HumanRecoveryCodeCheckFailedEvent:
+			wm.CheckFailedCount++
+		case *user.UserLockedEvent:
+			wm.UserLocked = true
+		case *user.UserUnlockedEvent:
+			wm.CheckFailedCount = 0
+			wm.UserLocked = false
+		case *user.HumanRecoveryCodesRemovedEvent:
+			wm.State = domain.MFAStateRemoved
+			wm.HashedCodes = nil
+			wm.UsedCodes = nil
+		case *user.UserRemovedEvent:
+			wm.State = domain.MFAStateRemoved
+			wm.HashedCodes = nil
+			wm.UsedCodes = nil
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanRecoveryCodesWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.HumanRecoveryCodesAddedType,
+			user.HumanRecoveryCodesRemovedType,
+			user.HumanRecoveryCodeCheckSucceededType,
+			user.HumanRecoveryCodeCheckFailedType,
+			user.UserLockedType,
+			user.UserUnlockedType,
+			user.UserRemovedType,
+		).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+// RemainingCodes returns the number of recovery codes, which were not used yet.
+func (wm *HumanRecoveryCodesWriteModel) RemainingCodes() int {
+	var remaining int
+	for _, used := range wm.UsedCodes {
+		if !used {
+			remaining++
+		}
+	}
+	return remaining
+}
@@ -446,6 +446,7 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -516,6 +517,7 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -589,6 +591,7 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -663,6 +666,7 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -723,6 +727,7 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -786,6 +791,7 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -849,6 +855,7 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -972,6 +979,7 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1051,6 +1059,7 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1225,6 +1234,7 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1425,6 +1435,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 		eventstore                  func(*testing.T) *eventstore.Eventstore
 		idGenerator                 id.Generator
 		userPasswordHasher          *crypto.Hasher
+		passwordBreachChecker       crypto.PasswordBreachChecker
 		newEncryptedCodeWithDefault encryptedCodeWithDefaultFunc
 		defaultSecretGenerators     *SecretGenerators
 	}
@@ -1566,21 +1577,80 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										false,
+									),
+								),
+							),
+						),
+					},
+					args{
+						ctx:   context.Background(),
+						orgID: "org1",
+						human: &domain.Human{
+							Username: "username",
+							Profile: &domain.Profile{
+								FirstName:         "firstname",
+								PreferredLanguage: AllowedLanguage,
+							},
+						},
+					}
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "password breached, invalid argument error",
+			given: func(t *testing.T) (fields, args) {
+				return fields{
+						eventstore: expectEventstore(
+							expectFilter(
+								eventFromEventPusher(
+									org.NewDomainPolicyAddedEvent(context.Background(),
+										&user.NewAggregate("user1", "org1").Aggregate,
+										true,
+										true,
+										true,
+									),
+								),
+							),
+							expectFilter(
+								eventFromEventPusher(
+									org.NewPasswordComplexityPolicyAddedEvent(context.Background(),
+										&user.NewAggregate("user1", "org1").Aggregate,
+										1,
+										false,
+										false,
+										false,
+										false,
+										true,
 									),
 								),
 							),
 						),
+						idGenerator:           id_mock.NewIDGeneratorExpectIDs(t, "user1"),
+						userPasswordHasher:    mockPasswordHasher("x"),
+						passwordBreachChecker: &mockPasswordBreachChecker{breached: true},
 					},
 					args{
 						ctx:   context.Background(),
 						orgID: "org1",
 						human: &domain.Human{
 							Username: "username",
+							Password: &domain.Password{
+								SecretString:   "password",
+								ChangeRequired: true,
+							},
 							Profile: &domain.Profile{
 								FirstName:         "firstname",
+								LastName:          "lastname",
 								PreferredLanguage: AllowedLanguage,
 							},
+							Email: &domain.Email{
+								EmailAddress: "email@test.ch",
+							},
 						},
+						secretGenerator: GetMockSecretGenerator(t),
 					}
 			},
 			res: res{
@@ -1611,6 +1681,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										false,
 									),
 								),
 							),
@@ -1697,6 +1768,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										false,
 									),
 								),
 							),
@@ -1777,6 +1849,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										false,
 									),
 								),
 							),
@@ -1876,6 +1949,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										false,
 									),
 								),
 							),
@@ -1979,6 +2053,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										false,
 									),
 								),
 							),
@@ -2114,6 +2189,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										false,
 									),
 								),
 							),
@@ -2209,6 +2285,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										false,
 									),
 								),
 							),
@@ -2294,6 +2371,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										false,
 									),
 								),
 							),
@@ -2380,6 +2458,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										false,
 									),
 								),
 							),
@@ -2525,6 +2604,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										false,
 									),
 								),
 							),
@@ -2668,6 +2748,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										false,
 									),
 								),
 							),
@@ -2840,6 +2921,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										false,
 									),
 								),
 							),
@@ -3012,6 +3094,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										false,
 									),
 								),
 							),
@@ -3191,6 +3274,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 										false,
 										false,
 										false,
+										false,
 									),
 								),
 							),
@@ -3354,6 +3438,7 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 				eventstore:                  f.eventstore(t),
 				idGenerator:                 f.idGenerator,
 				userPasswordHasher:          f.userPasswordHasher,
+				passwordBreachChecker:       f.passwordBreachChecker,
 				newEncryptedCodeWithDefault: f.newEncryptedCodeWithDefault,
 				defaultSecretGenerators:     f.defaultSecretGenerators,
 			}
@@ -3840,6 +3925,7 @@ func TestAddHumanCommand(t *testing.T) {
 									true,
 									true,
 									true,
+									false,
 								),
 							}, nil
 						}).
@@ -3893,6 +3979,7 @@ func TestAddHumanCommand(t *testing.T) {
 									false,
 									false,
 									false,
+									false,
 								),
 							}, nil
 						}).
@@ -3964,6 +4051,7 @@ func TestAddHumanCommand(t *testing.T) {
 									false,
 									false,
 									false,
+									false,
 								),
 							}, nil
 						}).
@@ -4036,6 +4124,7 @@ func TestAddHumanCommand(t *testing.T) {
 									false,
 									false,
 									false,
+									false,
 								),
 							}, nil
 						}).
@@ -4108,6 +4197,7 @@ func TestAddHumanCommand(t *testing.T) {
 									false,
 									false,
 									false,
+									false,
 								),
 							}, nil
 						}).
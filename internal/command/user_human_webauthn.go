@@ -160,7 +160,11 @@ func (c *Commands) addHumanWebAuthN(ctx context.Context, userID, resourceowner,
 	if accountName == "" {
 		accountName = string(user.EmailAddress)
 	}
-	webAuthN, err := c.webauthnConfig.BeginRegistration(ctx, user, accountName, authenticatorPlatform, userVerification, rpID, tokens...)
+	attestationPolicy, err := c.webAuthNAttestationPolicy(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	webAuthN, err := c.webauthnConfig.BeginRegistration(ctx, user, accountName, authenticatorPlatform, userVerification, rpID, attestationPolicy, tokens...)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -199,6 +203,7 @@ func (c *Commands) HumanVerifyU2FSetup(ctx context.Context, userID, resourceowne
 			webAuthN.AAGUID,
 			webAuthN.SignCount,
 			userAgentID,
+			webAuthN.ModelName,
 		),
 	)
 	if err != nil {
@@ -250,6 +255,7 @@ func (c *Commands) humanHumanPasswordlessSetup(ctx context.Context, userID, reso
 			webAuthN.AAGUID,
 			webAuthN.SignCount,
 			userAgentID,
+			webAuthN.ModelName,
 		),
 	}
 	if codeCheckEvent != nil {
@@ -275,7 +281,11 @@ func (c *Commands) verifyHumanWebAuthN(ctx context.Context, userID, resourceowne
 		return nil, nil, nil, err
 	}
 	_, token := domain.GetTokenToVerify(tokens)
-	webAuthN, err := c.webauthnConfig.FinishRegistration(ctx, user, token, tokenName, credentialData)
+	attestationPolicy, err := c.webAuthNAttestationPolicy(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	webAuthN, err := c.webauthnConfig.FinishRegistration(ctx, user, token, tokenName, credentialData, attestationPolicy)
 	if err != nil {
 		return nil, nil, nil, err
 	}
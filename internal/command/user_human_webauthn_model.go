@@ -22,6 +22,7 @@ type HumanWebAuthNWriteModel struct {
 	SignCount         uint32
 	WebAuthNTokenName string
 	RPID              string
+	ModelName         string
 
 	State domain.MFAState
 }
@@ -125,6 +126,7 @@ func (wm *HumanWebAuthNWriteModel) appendVerifiedEvent(e *user.HumanWebAuthNVeri
 	wm.AAGUID = e.AAGUID
 	wm.SignCount = e.SignCount
 	wm.WebAuthNTokenName = e.WebAuthNTokenName
+	wm.ModelName = e.ModelName
 	wm.State = domain.MFAStateReady
 }
 
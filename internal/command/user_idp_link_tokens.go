@@ -0,0 +1,247 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"golang.org/x/oauth2"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// idpLinkAccessTokenLeeway is the minimal remaining lifetime of an upstream access token returned by [Commands.GetUserIDPLinkAccessToken].
+// Tokens expiring sooner will be refreshed first.
+const idpLinkAccessTokenLeeway = time.Minute
+
+type IDPLinkAccessToken struct {
+	AccessToken string
+	// Expiry of the access token, a zero value means the token does not expire
+	Expiry time.Time
+}
+
+// SetUserIDPLinkTokens stores the tokens issued by the identity provider for the user link (encrypted),
+// so they can be refreshed and retrieved later on.
+// There's no permission check, as it's called after a successful authentication of the user at the identity provider.
+func (c *Commands) SetUserIDPLinkTokens(ctx context.Context, userID, resourceOwner, idpConfigID, externalUserID string, tokens *oauth2.Token) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" || idpConfigID == "" || externalUserID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Tq8vL", "Errors.IDMissing")
+	}
+	if tokens == nil || tokens.AccessToken == "" {
+		return nil
+	}
+	writeModel, err := c.userIDPLinkTokensWriteModelByID(ctx, userID, idpConfigID, externalUserID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if writeModel.State != domain.UserIDPLinkStateActive {
+		return zerrors.ThrowNotFound(nil, "COMMAND-Lw2nP", "Errors.User.ExternalIDP.NotFound")
+	}
+	cmd, err := c.userIDPLinkTokensSetEvent(ctx, writeModel, tokens, nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.eventstore.Push(ctx, cmd)
+	return err
+}
+
+// RefreshUserIDPLinkTokens uses the stored refresh token to request new tokens from the identity provider,
+// if the access token expires within the provided duration.
+// Tokens which were not used within unusedFor (if set) are not refreshed and a [zerrors.PreconditionFailed] error is returned,
+// they are refreshed again when they are requested.
+// If the identity provider rejects the refresh token, the stored tokens are removed and a [zerrors.PreconditionFailed] error is returned.
+func (c *Commands) RefreshUserIDPLinkTokens(ctx context.Context, userID, resourceOwner, idpConfigID, externalUserID string, expiresWithin, unusedFor time.Duration) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" || idpConfigID == "" || externalUserID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-m3Rfa", "Errors.IDMissing")
+	}
+	writeModel, err := c.userIDPLinkTokensWriteModelByID(ctx, userID, idpConfigID, externalUserID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if writeModel.State != domain.UserIDPLinkStateActive || writeModel.AccessToken == nil {
+		return zerrors.ThrowNotFound(nil, "COMMAND-Vd7hX", "Errors.User.ExternalIDP.NotFound")
+	}
+	if !writeModel.expiresWithin(expiresWithin) {
+		return nil
+	}
+	if unusedFor > 0 && writeModel.unusedFor(unusedFor) {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ahb4e", "Errors.User.ExternalIDP.TokensUnused")
+	}
+	_, err = c.refreshUserIDPLinkTokens(ctx, writeModel, false)
+	return err
+}
+
+// GetUserIDPLinkAccessToken returns a valid access token of the identity provider for the user link.
+// The stored tokens are refreshed first, if the access token is expired or about to expire.
+func (c *Commands) GetUserIDPLinkAccessToken(ctx context.Context, userID, resourceOwner, idpConfigID, externalUserID string) (_ *IDPLinkAccessToken, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" || idpConfigID == "" || externalUserID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Hk4sY", "Errors.IDMissing")
+	}
+	writeModel, err := c.userIDPLinkTokensWriteModelByID(ctx, userID, idpConfigID, externalUserID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	// the tokens grant access to the user's account at the identity provider,
+	// so the permission is also required for the user's own links
+	// and checked before the existence of the link is revealed
+	if err := c.newPermissionCheck(ctx, domain.PermissionUserIDPTokenRead, user.AggregateType)(writeModel.ResourceOwner, userID); err != nil {
+		return nil, err
+	}
+	if writeModel.State != domain.UserIDPLinkStateActive {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Rb9eN", "Errors.User.ExternalIDP.NotFound")
+	}
+	if writeModel.AccessToken == nil {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Pz6cW", "Errors.User.ExternalIDP.TokensNotFound")
+	}
+	if writeModel.expiresWithin(idpLinkAccessTokenLeeway) {
+		return c.refreshUserIDPLinkTokens(ctx, writeModel, true)
+	}
+	token, err := c.idpLinkAccessToken(writeModel)
+	if err != nil {
+		return nil, err
+	}
+	// the use is only recorded once per set of tokens
+	if !writeModel.Used {
+		userAgg := UserAggregateFromWriteModel(&writeModel.WriteModel)
+		if _, err = c.eventstore.Push(ctx, user.NewUserIDPLinkTokensUsedEvent(ctx, userAgg, writeModel.IDPConfigID, writeModel.ExternalUserID)); err != nil {
+			return nil, err
+		}
+	}
+	return token, nil
+}
+
+func (c *Commands) idpLinkAccessToken(writeModel *UserIDPLinkTokensWriteModel) (*IDPLinkAccessToken, error) {
+	accessToken, err := crypto.DecryptString(writeModel.AccessToken, c.idpConfigEncryption)
+	if err != nil {
+		return nil, err
+	}
+	return &IDPLinkAccessToken{
+		AccessToken: accessToken,
+		Expiry:      writeModel.Expiry,
+	}, nil
+}
+
+// refreshUserIDPLinkTokens refreshes the tokens at the identity provider.
+// If used is set, the new tokens are marked as used.
+// Errors returned by the identity provider are permanent, except server errors and temporary unavailability,
+// so the refresh is not retried.
+func (c *Commands) refreshUserIDPLinkTokens(ctx context.Context, writeModel *UserIDPLinkTokensWriteModel, used bool) (*IDPLinkAccessToken, error) {
+	if writeModel.RefreshToken == nil {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Jc5tM", "Errors.User.ExternalIDP.TokensExpired")
+	}
+	refreshToken, err := crypto.DecryptString(writeModel.RefreshToken, c.idpConfigEncryption)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := c.GetProvider(ctx, writeModel.IDPConfigID, "", "")
+	if err != nil {
+		return nil, err
+	}
+	refresher, ok := provider.(idp.TokenRefresher)
+	if !ok {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ne8qB", "Errors.IDPConfig.TokenRefreshNotSupported")
+	}
+	tokens, err := refresher.RefreshTokens(ctx, refreshToken)
+	if err != nil {
+		var oidcErr *oidc.Error
+		if !errors.As(err, &oidcErr) || oidcErr.ErrorType == oidc.ServerError || oidcErr.ErrorType == "temporarily_unavailable" {
+			return nil, zerrors.ThrowInternal(err, "COMMAND-Gu3xK", "Errors.User.ExternalIDP.TokensRefreshFailed")
+		}
+		if oidcErr.ErrorType != oidc.InvalidGrant {
+			return nil, zerrors.ThrowPreconditionFailed(err, "COMMAND-eeX0u", "Errors.User.ExternalIDP.TokensRefreshFailed")
+		}
+		return c.removeRejectedUserIDPLinkTokens(ctx, writeModel, err)
+	}
+	cmd, err := c.userIDPLinkTokensSetEvent(ctx, writeModel, tokens, writeModel.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	cmds := []eventstore.Command{cmd}
+	if used {
+		cmds = append(cmds, user.NewUserIDPLinkTokensUsedEvent(ctx, cmd.Aggregate(), writeModel.IDPConfigID, writeModel.ExternalUserID))
+	}
+	if _, err = c.eventstore.Push(ctx, cmds...); err != nil {
+		return nil, err
+	}
+	return &IDPLinkAccessToken{
+		AccessToken: tokens.AccessToken,
+		Expiry:      tokens.Expiry,
+	}, nil
+}
+
+// removeRejectedUserIDPLinkTokens removes the stored tokens after the identity provider rejected the refresh token,
+// as it has been revoked or is expired.
+// With rotating refresh tokens, the rejection might also be caused by a concurrent refresh,
+// in which case the tokens stored meanwhile are kept and their access token is returned instead.
+func (c *Commands) removeRejectedUserIDPLinkTokens(ctx context.Context, writeModel *UserIDPLinkTokensWriteModel, refreshErr error) (*IDPLinkAccessToken, error) {
+	current, err := c.userIDPLinkTokensWriteModelByID(ctx, writeModel.AggregateID, writeModel.IDPConfigID, writeModel.ExternalUserID, writeModel.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if current.State != domain.UserIDPLinkStateActive || current.AccessToken == nil {
+		return nil, zerrors.ThrowPreconditionFailed(refreshErr, "COMMAND-Qe5zR", "Errors.User.ExternalIDP.TokensExpired")
+	}
+	if !sameCryptoValue(current.RefreshToken, writeModel.RefreshToken) {
+		return c.idpLinkAccessToken(current)
+	}
+	userAgg := UserAggregateFromWriteModel(&current.WriteModel)
+	if _, err = c.eventstore.Push(ctx, user.NewUserIDPLinkTokensRemovedEvent(ctx, userAgg, current.IDPConfigID, current.ExternalUserID)); err != nil {
+		return nil, err
+	}
+	return nil, zerrors.ThrowPreconditionFailed(refreshErr, "COMMAND-Wy2dF", "Errors.User.ExternalIDP.TokensExpired")
+}
+
+func sameCryptoValue(a, b *crypto.CryptoValue) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.KeyID == b.KeyID && bytes.Equal(a.Crypted, b.Crypted)
+}
+
+// userIDPLinkTokensSetEvent encrypts the tokens and creates the event.
+// Identity providers might not issue a new refresh token on every refresh,
+// in which case the previous one (if provided) will be kept.
+func (c *Commands) userIDPLinkTokensSetEvent(ctx context.Context, writeModel *UserIDPLinkTokensWriteModel, tokens *oauth2.Token, previousRefreshToken *crypto.CryptoValue) (*user.UserIDPLinkTokensSetEvent, error) {
+	accessToken, err := crypto.Encrypt([]byte(tokens.AccessToken), c.idpConfigEncryption)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken := previousRefreshToken
+	if tokens.RefreshToken != "" {
+		refreshToken, err = crypto.Encrypt([]byte(tokens.RefreshToken), c.idpConfigEncryption)
+		if err != nil {
+			return nil, err
+		}
+	}
+	userAgg := UserAggregateFromWriteModel(&writeModel.WriteModel)
+	return user.NewUserIDPLinkTokensSetEvent(ctx, userAgg, writeModel.IDPConfigID, writeModel.ExternalUserID, accessToken, refreshToken, tokens.Expiry), nil
+}
+
+func (c *Commands) userIDPLinkTokensWriteModelByID(ctx context.Context, userID, idpConfigID, externalUserID, resourceOwner string) (writeModel *UserIDPLinkTokensWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewUserIDPLinkTokensWriteModel(userID, idpConfigID, externalUserID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
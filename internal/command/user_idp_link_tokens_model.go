@@ -0,0 +1,175 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type UserIDPLinkTokensWriteModel struct {
+	eventstore.WriteModel
+
+	IDPConfigID    string
+	ExternalUserID string
+	State          domain.UserIDPLinkState
+
+	AccessToken  *crypto.CryptoValue
+	RefreshToken *crypto.CryptoValue
+	Expiry       time.Time
+	// Used is set if the tokens were read since they were last set
+	Used bool
+	// LastUsed is the time the tokens were last read or initially stored
+	LastUsed time.Time
+}
+
+func NewUserIDPLinkTokensWriteModel(userID, idpConfigID, externalUserID, resourceOwner string) *UserIDPLinkTokensWriteModel {
+	return &UserIDPLinkTokensWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+		IDPConfigID:    idpConfigID,
+		ExternalUserID: externalUserID,
+	}
+}
+
+func (wm *UserIDPLinkTokensWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *user.UserIDPLinkAddedEvent:
+			if e.IDPConfigID != wm.IDPConfigID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *user.UserIDPExternalIDMigratedEvent:
+			if e.IDPConfigID != wm.IDPConfigID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *user.UserIDPLinkRemovedEvent:
+			if e.IDPConfigID != wm.IDPConfigID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *user.UserIDPLinkCascadeRemovedEvent:
+			if e.IDPConfigID != wm.IDPConfigID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *user.UserIDPLinkTokensSetEvent:
+			if e.IDPConfigID != wm.IDPConfigID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *user.UserIDPLinkTokensRemovedEvent:
+			if e.IDPConfigID != wm.IDPConfigID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *user.UserIDPLinkTokensUsedEvent:
+			if e.IDPConfigID != wm.IDPConfigID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *user.UserRemovedEvent:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+// Reduce only takes the events of the link with the ExternalUserID into account.
+// Since the ID of the external user might be migrated, it's followed through the migration events.
+func (wm *UserIDPLinkTokensWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.UserIDPLinkAddedEvent:
+			if e.ExternalUserID != wm.ExternalUserID {
+				continue
+			}
+			wm.State = domain.UserIDPLinkStateActive
+			wm.removeTokens()
+		case *user.UserIDPExternalIDMigratedEvent:
+			if e.PreviousID != wm.ExternalUserID {
+				continue
+			}
+			wm.ExternalUserID = e.NewID
+		case *user.UserIDPLinkRemovedEvent:
+			if e.ExternalUserID != wm.ExternalUserID {
+				continue
+			}
+			wm.State = domain.UserIDPLinkStateRemoved
+			wm.removeTokens()
+		case *user.UserIDPLinkCascadeRemovedEvent:
+			if e.ExternalUserID != wm.ExternalUserID {
+				continue
+			}
+			wm.State = domain.UserIDPLinkStateRemoved
+			wm.removeTokens()
+		case *user.UserIDPLinkTokensSetEvent:
+			if e.ExternalUserID != wm.ExternalUserID {
+				continue
+			}
+			if wm.AccessToken == nil {
+				wm.LastUsed = e.CreationDate()
+			}
+			wm.AccessToken = e.AccessToken
+			wm.RefreshToken = e.RefreshToken
+			wm.Expiry = e.Expiry
+			wm.Used = false
+		case *user.UserIDPLinkTokensRemovedEvent:
+			if e.ExternalUserID != wm.ExternalUserID {
+				continue
+			}
+			wm.removeTokens()
+		case *user.UserIDPLinkTokensUsedEvent:
+			if e.ExternalUserID != wm.ExternalUserID {
+				continue
+			}
+			wm.Used = true
+			wm.LastUsed = e.CreationDate()
+		case *user.UserRemovedEvent:
+			wm.State = domain.UserIDPLinkStateRemoved
+			wm.removeTokens()
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *UserIDPLinkTokensWriteModel) removeTokens() {
+	wm.AccessToken = nil
+	wm.RefreshToken = nil
+	wm.Expiry = time.Time{}
+	wm.Used = false
+	wm.LastUsed = time.Time{}
+}
+
+func (wm *UserIDPLinkTokensWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.UserIDPLinkAddedType,
+			user.UserIDPExternalIDMigratedType,
+			user.UserIDPLinkRemovedType,
+			user.UserIDPLinkCascadeRemovedType,
+			user.UserIDPLinkTokensSetType,
+			user.UserIDPLinkTokensRemovedType,
+			user.UserIDPLinkTokensUsedType,
+			user.UserRemovedType).
+		Builder()
+}
+
+// expiresWithin returns true if the access token is expired or will expire in the provided duration.
+// A zero expiry means the access token does not expire.
+func (wm *UserIDPLinkTokensWriteModel) expiresWithin(d time.Duration) bool {
+	return !wm.Expiry.IsZero() && time.Now().Add(d).After(wm.Expiry)
+}
+
+// unusedFor returns true if the tokens were neither read nor stored within the provided duration.
+func (wm *UserIDPLinkTokensWriteModel) unusedFor(d time.Duration) bool {
+	return time.Now().Add(-d).After(wm.LastUsed)
+}
@@ -0,0 +1,719 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/oauth2"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	rep_idp "github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func idpLinkTokensCryptoValue(value string) *crypto.CryptoValue {
+	return &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte(value),
+	}
+}
+
+func idpLinkTokensOAuthIDPAddedEvent() eventstore.Event {
+	return eventFromEventPusherWithInstanceID(
+		"instance",
+		instance.NewOAuthIDPAddedEvent(context.Background(), &instance.NewAggregate("instance").Aggregate,
+			"idp",
+			"name",
+			"clientID",
+			idpLinkTokensCryptoValue("clientSecret"),
+			"https://idp.example.com/authorize",
+			"https://idp.example.com/token",
+			"https://idp.example.com/user",
+			"idAttribute",
+			nil,
+			true,
+			nil,
+			rep_idp.Options{},
+		),
+	)
+}
+
+func TestCommands_SetUserIDPLinkTokens(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx            context.Context
+		userID         string
+		resourceOwner  string
+		idpConfigID    string
+		externalUserID string
+		tokens         *oauth2.Token
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "missing id, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:            context.Background(),
+				resourceOwner:  "org1",
+				idpConfigID:    "idp",
+				externalUserID: "externalUser",
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "COMMAND-Tq8vL", "Errors.IDMissing"),
+		},
+		{
+			name: "no tokens, ok",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:            context.Background(),
+				userID:         "user1",
+				resourceOwner:  "org1",
+				idpConfigID:    "idp",
+				externalUserID: "externalUser",
+			},
+		},
+		{
+			name: "link not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:            context.Background(),
+				userID:         "user1",
+				resourceOwner:  "org1",
+				idpConfigID:    "idp",
+				externalUserID: "externalUser",
+				tokens:         &oauth2.Token{AccessToken: "accessToken"},
+			},
+			wantErr: zerrors.ThrowNotFound(nil, "COMMAND-Lw2nP", "Errors.User.ExternalIDP.NotFound"),
+		},
+		{
+			name: "link removed, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp", "name", "externalUser"),
+						),
+						eventFromEventPusher(
+							user.NewUserIDPLinkRemovedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp", "externalUser"),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:            context.Background(),
+				userID:         "user1",
+				resourceOwner:  "org1",
+				idpConfigID:    "idp",
+				externalUserID: "externalUser",
+				tokens:         &oauth2.Token{AccessToken: "accessToken"},
+			},
+			wantErr: zerrors.ThrowNotFound(nil, "COMMAND-Lw2nP", "Errors.User.ExternalIDP.NotFound"),
+		},
+		{
+			name: "migrated link, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp", "name", "previousUser"),
+						),
+						eventFromEventPusher(
+							user.NewUserIDPExternalIDMigratedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp", "previousUser", "externalUser"),
+						),
+					),
+					expectPush(
+						user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+							"idp",
+							"externalUser",
+							idpLinkTokensCryptoValue("accessToken"),
+							idpLinkTokensCryptoValue("refreshToken"),
+							time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:            context.Background(),
+				userID:         "user1",
+				resourceOwner:  "org1",
+				idpConfigID:    "idp",
+				externalUserID: "previousUser",
+				tokens: &oauth2.Token{
+					AccessToken:  "accessToken",
+					RefreshToken: "refreshToken",
+					Expiry:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:          tt.fields.eventstore(t),
+				idpConfigEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			err := c.SetUserIDPLinkTokens(tt.args.ctx, tt.args.userID, tt.args.resourceOwner, tt.args.idpConfigID, tt.args.externalUserID, tt.args.tokens)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCommands_RefreshUserIDPLinkTokens(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		expiresWithin time.Duration
+		unusedFor     time.Duration
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		httpMock func()
+		wantErr  error
+	}{
+		{
+			name: "no tokens, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp", "name", "externalUser"),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				expiresWithin: time.Minute,
+			},
+			wantErr: zerrors.ThrowNotFound(nil, "COMMAND-Vd7hX", "Errors.User.ExternalIDP.NotFound"),
+		},
+		{
+			name: "not expiring, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp", "name", "externalUser"),
+						),
+						eventFromEventPusher(
+							user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"idp",
+								"externalUser",
+								idpLinkTokensCryptoValue("accessToken"),
+								idpLinkTokensCryptoValue("refreshToken"),
+								time.Now().Add(time.Hour),
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				expiresWithin: time.Minute,
+			},
+		},
+		{
+			name: "refresh token revoked, tokens removed",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp", "name", "externalUser"),
+						),
+						eventFromEventPusher(
+							user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"idp",
+								"externalUser",
+								idpLinkTokensCryptoValue("accessToken"),
+								idpLinkTokensCryptoValue("refreshToken"),
+								time.Now().Add(time.Minute),
+							),
+						),
+					),
+					expectFilter(
+						idpLinkTokensOAuthIDPAddedEvent(),
+					),
+					expectFilter(
+						idpLinkTokensOAuthIDPAddedEvent(),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp", "name", "externalUser"),
+						),
+						eventFromEventPusher(
+							user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"idp",
+								"externalUser",
+								idpLinkTokensCryptoValue("accessToken"),
+								idpLinkTokensCryptoValue("refreshToken"),
+								time.Now().Add(time.Minute),
+							),
+						),
+					),
+					expectPush(
+						user.NewUserIDPLinkTokensRemovedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp", "externalUser"),
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance"),
+				expiresWithin: time.Hour,
+			},
+			httpMock: func() {
+				gock.New("https://idp.example.com").
+					Post("/token").
+					Reply(400).
+					JSON(map[string]string{
+						"error": "invalid_grant",
+					})
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Wy2dF", "Errors.User.ExternalIDP.TokensExpired"),
+		},
+		{
+			name: "refresh token rotated concurrently, tokens kept",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp", "name", "externalUser"),
+						),
+						eventFromEventPusher(
+							user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"idp",
+								"externalUser",
+								idpLinkTokensCryptoValue("accessToken"),
+								idpLinkTokensCryptoValue("refreshToken"),
+								time.Now().Add(time.Minute),
+							),
+						),
+					),
+					expectFilter(
+						idpLinkTokensOAuthIDPAddedEvent(),
+					),
+					expectFilter(
+						idpLinkTokensOAuthIDPAddedEvent(),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp", "name", "externalUser"),
+						),
+						eventFromEventPusher(
+							user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"idp",
+								"externalUser",
+								idpLinkTokensCryptoValue("accessToken"),
+								idpLinkTokensCryptoValue("refreshToken"),
+								time.Now().Add(time.Minute),
+							),
+						),
+						eventFromEventPusher(
+							user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"idp",
+								"externalUser",
+								idpLinkTokensCryptoValue("newAccessToken"),
+								idpLinkTokensCryptoValue("newRefreshToken"),
+								time.Now().Add(time.Hour),
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance"),
+				expiresWithin: time.Hour,
+			},
+			httpMock: func() {
+				gock.New("https://idp.example.com").
+					Post("/token").
+					Reply(400).
+					JSON(map[string]string{
+						"error": "invalid_grant",
+					})
+			},
+		},
+		{
+			name: "not used, precondition failed error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp", "name", "externalUser"),
+						),
+						eventFromEventPusher(
+							user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"idp",
+								"externalUser",
+								idpLinkTokensCryptoValue("accessToken"),
+								idpLinkTokensCryptoValue("refreshToken"),
+								time.Now().Add(time.Minute),
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				expiresWithin: time.Hour,
+				unusedFor:     time.Hour,
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ahb4e", "Errors.User.ExternalIDP.TokensUnused"),
+		},
+		{
+			name: "refresh rejected permanently, precondition failed error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp", "name", "externalUser"),
+						),
+						eventFromEventPusher(
+							user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"idp",
+								"externalUser",
+								idpLinkTokensCryptoValue("accessToken"),
+								idpLinkTokensCryptoValue("refreshToken"),
+								time.Now().Add(time.Minute),
+							),
+						),
+					),
+					expectFilter(
+						idpLinkTokensOAuthIDPAddedEvent(),
+					),
+					expectFilter(
+						idpLinkTokensOAuthIDPAddedEvent(),
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance"),
+				expiresWithin: time.Hour,
+			},
+			httpMock: func() {
+				gock.New("https://idp.example.com").
+					Post("/token").
+					Reply(400).
+					JSON(map[string]string{
+						"error": "invalid_client",
+					})
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "COMMAND-eeX0u", "Errors.User.ExternalIDP.TokensRefreshFailed"),
+		},
+		{
+			name: "refresh, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp", "name", "externalUser"),
+						),
+						eventFromEventPusherWithCreationDateNow(
+							user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"idp",
+								"externalUser",
+								idpLinkTokensCryptoValue("accessToken"),
+								idpLinkTokensCryptoValue("refreshToken"),
+								time.Now().Add(time.Minute),
+							),
+						),
+					),
+					expectFilter(
+						idpLinkTokensOAuthIDPAddedEvent(),
+					),
+					expectFilter(
+						idpLinkTokensOAuthIDPAddedEvent(),
+					),
+					expectPush(
+						user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+							"idp",
+							"externalUser",
+							idpLinkTokensCryptoValue("newAccessToken"),
+							idpLinkTokensCryptoValue("refreshToken"),
+							time.Time{},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance"),
+				expiresWithin: time.Hour,
+				unusedFor:     time.Hour,
+			},
+			httpMock: func() {
+				gock.New("https://idp.example.com").
+					Post("/token").
+					Reply(200).
+					JSON(&oidc.AccessTokenResponse{
+						AccessToken: "newAccessToken",
+						TokenType:   oidc.BearerToken,
+					})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			if tt.httpMock != nil {
+				tt.httpMock()
+			}
+			c := &Commands{
+				eventstore:          tt.fields.eventstore(t),
+				idpConfigEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			err := c.RefreshUserIDPLinkTokens(tt.args.ctx, "user1", "org1", "idp", "externalUser", tt.args.expiresWithin, tt.args.unusedFor)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCommands_GetUserIDPLinkAccessToken(t *testing.T) {
+	expiry := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	type fields struct {
+		eventstore      func(t *testing.T) *eventstore.Eventstore
+		checkPermission domain.PermissionCheck
+	}
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *IDPLinkAccessToken
+		wantErr error
+	}{
+		{
+			name: "link not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: zerrors.ThrowNotFound(nil, "COMMAND-Rb9eN", "Errors.User.ExternalIDP.NotFound"),
+		},
+		{
+			name: "link not existing, missing permission, permission denied error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: zerrors.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied"),
+		},
+		{
+			name: "missing permission, permission denied error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp", "name", "externalUser"),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: zerrors.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied"),
+		},
+		{
+			name: "tokens removed, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp", "name", "externalUser"),
+						),
+						eventFromEventPusher(
+							user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"idp",
+								"externalUser",
+								idpLinkTokensCryptoValue("accessToken"),
+								idpLinkTokensCryptoValue("refreshToken"),
+								expiry,
+							),
+						),
+						eventFromEventPusher(
+							user.NewUserIDPLinkTokensRemovedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp", "externalUser"),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: zerrors.ThrowNotFound(nil, "COMMAND-Pz6cW", "Errors.User.ExternalIDP.TokensNotFound"),
+		},
+		{
+			name: "expired without refresh token, precondition failed error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp", "name", "externalUser"),
+						),
+						eventFromEventPusher(
+							user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"idp",
+								"externalUser",
+								idpLinkTokensCryptoValue("accessToken"),
+								nil,
+								time.Now().Add(-time.Minute),
+							),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Jc5tM", "Errors.User.ExternalIDP.TokensExpired"),
+		},
+		{
+			name: "own link, missing permission, permission denied error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp", "name", "externalUser"),
+						),
+						eventFromEventPusher(
+							user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"idp",
+								"externalUser",
+								idpLinkTokensCryptoValue("accessToken"),
+								idpLinkTokensCryptoValue("refreshToken"),
+								expiry,
+							),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				ctx: authz.SetCtxData(context.Background(), authz.CtxData{UserID: "user1"}),
+			},
+			wantErr: zerrors.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied"),
+		},
+		{
+			name: "ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp", "name", "externalUser"),
+						),
+						eventFromEventPusher(
+							user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"idp",
+								"externalUser",
+								idpLinkTokensCryptoValue("accessToken"),
+								idpLinkTokensCryptoValue("refreshToken"),
+								expiry,
+							),
+						),
+					),
+					expectPush(
+						user.NewUserIDPLinkTokensUsedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp", "externalUser"),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want: &IDPLinkAccessToken{
+				AccessToken: "accessToken",
+				Expiry:      expiry,
+			},
+		},
+		{
+			name: "already used, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPLinkAddedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp", "name", "externalUser"),
+						),
+						eventFromEventPusher(
+							user.NewUserIDPLinkTokensSetEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate,
+								"idp",
+								"externalUser",
+								idpLinkTokensCryptoValue("accessToken"),
+								idpLinkTokensCryptoValue("refreshToken"),
+								expiry,
+							),
+						),
+						eventFromEventPusher(
+							user.NewUserIDPLinkTokensUsedEvent(context.Background(), &user.NewAggregate("user1", "org1").Aggregate, "idp", "externalUser"),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want: &IDPLinkAccessToken{
+				AccessToken: "accessToken",
+				Expiry:      expiry,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:          tt.fields.eventstore(t),
+				checkPermission:     tt.fields.checkPermission,
+				idpConfigEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			got, err := c.GetUserIDPLinkAccessToken(tt.args.ctx, "user1", "org1", "idp", "externalUser")
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.want == nil {
+				return
+			}
+			assert.Equal(t, tt.want.AccessToken, got.AccessToken)
+			assert.True(t, tt.want.Expiry.Equal(got.Expiry))
+		})
+	}
+}
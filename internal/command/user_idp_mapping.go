@@ -0,0 +1,144 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"slices"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/idp/mapping"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// ApplyIDPUserMapping sets the metadata and grants mapped from the user of an identity provider on an existing user.
+// Unchanged metadata and already granted roles are ignored, roles are added to existing grants of the same project.
+// The grants are created in the organization of the project (or the granted organization of the project grant),
+// grants of projects, project grants or roles, which no longer exist, are skipped.
+func (c *Commands) ApplyIDPUserMapping(ctx context.Context, userID, resourceOwner string, metadata []*domain.Metadata, grants []*domain.UserGrant) (*domain.ObjectDetails, error) {
+	if err := c.checkUserExists(ctx, userID, resourceOwner); err != nil {
+		return nil, err
+	}
+	cmds, err := c.mappedIDPUserCommands(ctx, userID, resourceOwner, metadata, grants)
+	if err != nil {
+		return nil, err
+	}
+	if len(cmds) == 0 {
+		return &domain.ObjectDetails{ResourceOwner: resourceOwner}, nil
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+func (c *Commands) mappedIDPUserCommands(ctx context.Context, userID, resourceOwner string, metadata []*domain.Metadata, grants []*domain.UserGrant) (_ []eventstore.Command, err error) {
+	cmds := make([]eventstore.Command, 0, len(metadata)+len(grants))
+	if len(metadata) > 0 {
+		existingMetadata, err := c.getUserMetadataListModelByID(ctx, userID, resourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		userAgg := &user.NewAggregate(userID, resourceOwner).Aggregate
+		for _, m := range metadata {
+			if value, ok := existingMetadata.metadataList[m.Key]; ok && bytes.Equal(value, m.Value) {
+				continue
+			}
+			cmd, err := c.setUserMetadata(ctx, userAgg, m)
+			if err != nil {
+				return nil, err
+			}
+			cmds = append(cmds, cmd)
+		}
+	}
+	for _, grant := range grants {
+		grant.UserID = userID
+		grantResourceOwner, err := c.mappedGrantResourceOwner(ctx, grant)
+		if err != nil {
+			if zerrors.IsPreconditionFailed(err) {
+				logging.WithFields("user", userID, "project", grant.ProjectID, "projectGrant", grant.ProjectGrantID).WithError(err).Warn("mapped user grant skipped")
+				continue
+			}
+			return nil, err
+		}
+		cmd, _, err := c.addUserGrantRoles(ctx, grant, grantResourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	return cmds, nil
+}
+
+// mappedGrantResourceOwner returns the organization, the mapped grant is created in.
+// Grants of a project grant are created in the granted organization,
+// grants of a project in the organization of the project, which is resolved when the claim mapping is saved.
+func (c *Commands) mappedGrantResourceOwner(ctx context.Context, grant *domain.UserGrant) (string, error) {
+	if grant.ProjectGrantID == "" && grant.ResourceOwner != "" {
+		return grant.ResourceOwner, nil
+	}
+	preConditions, err := mappedGrantPreConditions(ctx, c.eventstore.Filter, grant.ProjectID, grant.ProjectGrantID, grant.RoleKeys)
+	if err != nil {
+		return "", err
+	}
+	if grant.ProjectGrantID != "" {
+		return preConditions.GrantedOrgID, nil
+	}
+	return preConditions.ProjectResourceOwner, nil
+}
+
+// checkMappedGrants checks that the projects (or project grants) and roles of the grants of the claim mapping exist,
+// so a stale mapping is rejected when it is saved instead of failing on the login of the users.
+// It sets the organization of the project, in which the grants are created.
+// If the resourceOwner is set (identity providers of an organization), only projects of the organization
+// or project grants granted to it can be mapped.
+func checkMappedGrants(ctx context.Context, filter preparation.FilterToQueryReducer, claimMapping *mapping.Mapping, resourceOwner string) error {
+	if claimMapping == nil {
+		return nil
+	}
+	for _, grant := range claimMapping.Grants {
+		preConditions, err := mappedGrantPreConditions(ctx, filter, grant.ProjectID, grant.ProjectGrantID, grant.RoleKeys)
+		if err != nil {
+			return err
+		}
+		if resourceOwner != "" && grant.ProjectGrantID == "" && preConditions.ProjectResourceOwner != resourceOwner {
+			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Oox1e", "Errors.Project.NotFound")
+		}
+		if resourceOwner != "" && grant.ProjectGrantID != "" && preConditions.GrantedOrgID != resourceOwner {
+			return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Rai5k", "Errors.Project.Grant.NotFound")
+		}
+		grant.ProjectResourceOwner = preConditions.ProjectResourceOwner
+	}
+	return nil
+}
+
+func mappedGrantPreConditions(ctx context.Context, filter preparation.FilterToQueryReducer, projectID, projectGrantID string, roleKeys []string) (*MappedGrantPreConditionReadModel, error) {
+	preConditions := NewMappedGrantPreConditionReadModel(projectID, projectGrantID)
+	events, err := filter(ctx, preConditions.Query())
+	if err != nil {
+		return nil, err
+	}
+	preConditions.AppendEvents(events...)
+	if err := preConditions.Reduce(); err != nil {
+		return nil, err
+	}
+	if !preConditions.ProjectExists {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ieg4a", "Errors.Project.NotFound")
+	}
+	if projectGrantID != "" && !preConditions.ProjectGrantExists {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-ahX7o", "Errors.Project.Grant.NotFound")
+	}
+	for _, roleKey := range roleKeys {
+		if !slices.Contains(preConditions.ExistingRoleKeys, roleKey) {
+			return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Phu3e", "Errors.Project.Role.NotFound")
+		}
+	}
+	return preConditions, nil
+}
@@ -0,0 +1,91 @@
+package command
+
+import (
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+// MappedGrantPreConditionReadModel checks if the project (or project grant) of a grant mapped from the user
+// of an identity provider exists and resolves the organizations and role keys of it.
+type MappedGrantPreConditionReadModel struct {
+	eventstore.WriteModel
+
+	ProjectID            string
+	ProjectGrantID       string
+	ProjectResourceOwner string
+	GrantedOrgID         string
+	ProjectExists        bool
+	ProjectGrantExists   bool
+	ExistingRoleKeys     []string
+}
+
+func NewMappedGrantPreConditionReadModel(projectID, projectGrantID string) *MappedGrantPreConditionReadModel {
+	return &MappedGrantPreConditionReadModel{
+		ProjectID:      projectID,
+		ProjectGrantID: projectGrantID,
+	}
+}
+
+func (wm *MappedGrantPreConditionReadModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.ProjectAddedEvent:
+			wm.ProjectExists = true
+			wm.ProjectResourceOwner = e.Aggregate().ResourceOwner
+		case *project.ProjectRemovedEvent:
+			wm.ProjectExists = false
+			wm.ProjectGrantExists = false
+		case *project.GrantAddedEvent:
+			if wm.ProjectGrantID == e.GrantID {
+				wm.ProjectGrantExists = true
+				wm.GrantedOrgID = e.GrantedOrgID
+				wm.ExistingRoleKeys = e.RoleKeys
+			}
+		case *project.GrantChangedEvent:
+			if wm.ProjectGrantID == e.GrantID {
+				wm.ExistingRoleKeys = e.RoleKeys
+			}
+		case *project.GrantCascadeChangedEvent:
+			if wm.ProjectGrantID == e.GrantID {
+				wm.ExistingRoleKeys = e.RoleKeys
+			}
+		case *project.GrantRemovedEvent:
+			if wm.ProjectGrantID == e.GrantID {
+				wm.ProjectGrantExists = false
+				wm.ExistingRoleKeys = []string{}
+			}
+		case *project.RoleAddedEvent:
+			if wm.ProjectGrantID != "" {
+				continue
+			}
+			wm.ExistingRoleKeys = append(wm.ExistingRoleKeys, e.Key)
+		case *project.RoleRemovedEvent:
+			if wm.ProjectGrantID != "" {
+				continue
+			}
+			wm.ExistingRoleKeys = slices.DeleteFunc(wm.ExistingRoleKeys, func(key string) bool {
+				return key == e.Key
+			})
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *MappedGrantPreConditionReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.ProjectID).
+		EventTypes(
+			project.ProjectAddedType,
+			project.ProjectRemovedType,
+			project.GrantAddedType,
+			project.GrantChangedType,
+			project.GrantCascadeChangedType,
+			project.GrantRemovedType,
+			project.RoleAddedType,
+			project.RoleRemovedType).
+		Builder()
+}
@@ -0,0 +1,374 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_ApplyIDPUserMapping(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx      context.Context
+		metadata []*domain.Metadata
+		grants   []*domain.UserGrant
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	userAddedEvent := func() eventstore.Event {
+		return eventFromEventPusher(
+			user.NewHumanAddedEvent(context.Background(),
+				&user.NewAggregate("user1", "org1").Aggregate,
+				"username1",
+				"firstname1",
+				"lastname1",
+				"nickname1",
+				"displayname1",
+				language.German,
+				domain.GenderMale,
+				"email1",
+				true,
+			),
+		)
+	}
+	projectEvents := func(resourceOwner string) []eventstore.Event {
+		return []eventstore.Event{
+			eventFromEventPusher(
+				project.NewProjectAddedEvent(context.Background(),
+					&project.NewAggregate("project1", resourceOwner).Aggregate,
+					"projectname1", true, true, true,
+					domain.PrivateLabelingSettingUnspecified,
+				),
+			),
+			eventFromEventPusher(
+				project.NewRoleAddedEvent(context.Background(),
+					&project.NewAggregate("project1", resourceOwner).Aggregate,
+					"rolekey1",
+					"rolekey",
+					"",
+				),
+			),
+			eventFromEventPusher(
+				project.NewRoleAddedEvent(context.Background(),
+					&project.NewAggregate("project1", resourceOwner).Aggregate,
+					"rolekey2",
+					"rolekey 2",
+					"",
+				),
+			),
+		}
+	}
+	projectGrantAddedEvent := func() eventstore.Event {
+		return eventFromEventPusher(
+			project.NewGrantAddedEvent(context.Background(),
+				&project.NewAggregate("project1", "org2").Aggregate,
+				"projectgrant1",
+				"org1",
+				[]string{"rolekey1"},
+			),
+		)
+	}
+	userGrantAddedEvent := func() eventstore.Event {
+		return eventFromEventPusher(
+			usergrant.NewUserGrantAddedEvent(context.Background(),
+				&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+				"user1",
+				"project1",
+				"",
+				[]string{"rolekey1"},
+			),
+		)
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "user not existing, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				metadata: []*domain.Metadata{
+					{Key: "key", Value: []byte("value")},
+				},
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "metadata and roles unchanged, no push",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						userAddedEvent(),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewMetadataSetEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"key",
+								[]byte("value"),
+							),
+						),
+					),
+					expectFilter(
+						userGrantAddedEvent(),
+					),
+					expectFilter(
+						userGrantAddedEvent(),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.NewMockContextWithPermissions("", "", "", []string{domain.RoleProjectOwner}),
+				metadata: []*domain.Metadata{
+					{Key: "key", Value: []byte("value")},
+				},
+				grants: []*domain.UserGrant{
+					{ObjectRoot: es_models.ObjectRoot{ResourceOwner: "org1"}, ProjectID: "project1", RoleKeys: []string{"rolekey1"}},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "changed metadata set and roles added to existing grant, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						userAddedEvent(),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewMetadataSetEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"key",
+								[]byte("value"),
+							),
+						),
+					),
+					expectFilter(
+						userGrantAddedEvent(),
+					),
+					expectFilter(
+						userGrantAddedEvent(),
+					),
+					expectFilter(
+						append([]eventstore.Event{userAddedEvent()}, projectEvents("org1")...)...,
+					),
+					expectPush(
+						user.NewMetadataSetEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+							"key",
+							[]byte("value2"),
+						),
+						usergrant.NewUserGrantChangedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+							"user1",
+							[]string{"rolekey1", "rolekey2"},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.NewMockContextWithPermissions("", "", "", []string{domain.RoleProjectOwner}),
+				metadata: []*domain.Metadata{
+					{Key: "key", Value: []byte("value2")},
+				},
+				grants: []*domain.UserGrant{
+					{ObjectRoot: es_models.ObjectRoot{ResourceOwner: "org1"}, ProjectID: "project1", RoleKeys: []string{"rolekey2"}},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "grant added, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						userAddedEvent(),
+					),
+					expectFilter(),
+					expectFilter(
+						append([]eventstore.Event{userAddedEvent()}, projectEvents("org1")...)...,
+					),
+					expectPush(
+						usergrant.NewUserGrantAddedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+							"user1",
+							"project1",
+							"",
+							[]string{"rolekey1", "rolekey2"},
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "usergrant1"),
+			},
+			args: args{
+				ctx: authz.NewMockContextWithPermissions("", "", "", []string{domain.RoleProjectOwner}),
+				grants: []*domain.UserGrant{
+					{ObjectRoot: es_models.ObjectRoot{ResourceOwner: "org1"}, ProjectID: "project1", RoleKeys: []string{"rolekey1", "rolekey2"}},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "grant in organization of project added, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						userAddedEvent(),
+					),
+					expectFilter(),
+					expectFilter(
+						append([]eventstore.Event{userAddedEvent()}, projectEvents("org2")...)...,
+					),
+					expectPush(
+						usergrant.NewUserGrantAddedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org2").Aggregate,
+							"user1",
+							"project1",
+							"",
+							[]string{"rolekey1"},
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "usergrant1"),
+			},
+			args: args{
+				ctx: authz.NewMockContextWithPermissions("", "", "", []string{domain.RoleProjectOwner}),
+				grants: []*domain.UserGrant{
+					{ObjectRoot: es_models.ObjectRoot{ResourceOwner: "org2"}, ProjectID: "project1", RoleKeys: []string{"rolekey1"}},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org2",
+				},
+			},
+		},
+		{
+			name: "grant of project grant added in granted organization, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						userAddedEvent(),
+					),
+					expectFilter(
+						append(projectEvents("org2"), projectGrantAddedEvent())...,
+					),
+					expectFilter(),
+					expectFilter(
+						append(append([]eventstore.Event{userAddedEvent()}, projectEvents("org2")...), projectGrantAddedEvent())...,
+					),
+					expectPush(
+						usergrant.NewUserGrantAddedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+							"user1",
+							"project1",
+							"projectgrant1",
+							[]string{"rolekey1"},
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "usergrant1"),
+			},
+			args: args{
+				ctx: authz.NewMockContextWithPermissions("", "", "", []string{domain.RoleProjectOwner}),
+				grants: []*domain.UserGrant{
+					{ObjectRoot: es_models.ObjectRoot{ResourceOwner: "org2"}, ProjectID: "project1", ProjectGrantID: "projectgrant1", RoleKeys: []string{"rolekey1"}},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "role of grant removed, grant skipped",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						userAddedEvent(),
+					),
+					expectFilter(
+						append(projectEvents("org2"),
+							eventFromEventPusher(
+								project.NewRoleRemovedEvent(context.Background(),
+									&project.NewAggregate("project1", "org2").Aggregate,
+									"rolekey2",
+								),
+							),
+						)...,
+					),
+				),
+			},
+			args: args{
+				ctx: authz.NewMockContextWithPermissions("", "", "", []string{domain.RoleProjectOwner}),
+				grants: []*domain.UserGrant{
+					{ProjectID: "project1", RoleKeys: []string{"rolekey2"}},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+			}
+			got, err := r.ApplyIDPUserMapping(tt.args.ctx, "user1", "org1", tt.args.metadata, tt.args.grants)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
@@ -203,7 +203,7 @@ func (c *Commands) AddUserHuman(ctx context.Context, resourceOwner string, human
 
 	// separated to change when old user logic is not used anymore
 	filter := c.eventstore.Filter //nolint:staticcheck
-	if err := addHumanCommandPassword(ctx, filter, createCmd, human, c.userPasswordHasher); err != nil {
+	if err := addHumanCommandPassword(ctx, filter, createCmd, human, c.userPasswordHasher, c.passwordBreachChecker); err != nil {
 		return err
 	}
 
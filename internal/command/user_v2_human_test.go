@@ -421,6 +421,7 @@ func TestCommandSide_AddUserHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -551,6 +552,7 @@ func TestCommandSide_AddUserHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -625,6 +627,7 @@ func TestCommandSide_AddUserHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -700,6 +703,7 @@ func TestCommandSide_AddUserHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -764,6 +768,7 @@ func TestCommandSide_AddUserHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -828,6 +833,7 @@ func TestCommandSide_AddUserHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -953,6 +959,7 @@ func TestCommandSide_AddUserHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1033,6 +1040,7 @@ func TestCommandSide_AddUserHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1143,6 +1151,7 @@ func TestCommandSide_AddUserHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1314,6 +1323,7 @@ func TestCommandSide_AddUserHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -3025,6 +3035,7 @@ func TestCommandSide_ChangeUserHuman(t *testing.T) {
 								true,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -3129,6 +3140,7 @@ func TestCommandSide_ChangeUserHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -3185,6 +3197,7 @@ func TestCommandSide_ChangeUserHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -3293,6 +3306,7 @@ func TestCommandSide_ChangeUserHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -3419,6 +3433,7 @@ func TestCommandSide_ChangeUserHuman(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 							),
 						),
 					),
@@ -3543,6 +3558,7 @@ func TestCommandSide_ChangeUserHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 							),
 						),
 					),
@@ -1032,6 +1032,7 @@ func TestCommands_VerifyInviteCodeSetPassword(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 							),
 						),
 					),
@@ -1117,6 +1118,7 @@ func TestCommands_VerifyInviteCodeSetPassword(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 							),
 						),
 					),
@@ -118,6 +118,7 @@ func TestCommands_RegisterUserU2F(t *testing.T) {
 							false, false, false,
 						),
 					)),
+					expectFilter(), // security policy
 				),
 				idGenerator: id_mock.NewIDGeneratorExpectError(t, io.ErrClosedPipe),
 			},
@@ -179,6 +180,7 @@ func TestCommands_pushUserU2F(t *testing.T) {
 				false, false, false,
 			),
 		)),
+		expectFilter(), // security policy
 		expectFilter(eventFromEventPusher(
 			user.NewHumanWebAuthNAddedEvent(eventstore.NewBaseEventForPush(
 				ctx, &org.NewAggregate("org1").Aggregate, user.HumanPasswordlessTokenAddedType,
@@ -10,6 +10,7 @@ type SystemDefaults struct {
 	SecretGenerators     SecretGenerators
 	PasswordHasher       crypto.HashConfig
 	SecretHasher         crypto.HashConfig
+	PasswordBreachCheck  crypto.PasswordBreachCheckConfig
 	Multifactors         MultifactorConfig
 	DomainVerification   DomainVerification
 	Notifications        Notifications
@@ -0,0 +1,213 @@
+package crypto
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	sha1HexLength         = 40
+	breachRangePrefixSize = 5
+)
+
+// PasswordBreachCheckConfig defines where passwords are looked up when a password complexity policy requires
+// passwords not to be part of a known breach corpus.
+// The Endpoint and the File can be used on their own or combined, in which case the File is checked first.
+type PasswordBreachCheckConfig struct {
+	// Endpoint of a k-anonymity range API, e.g. https://api.pwnedpasswords.com/range/.
+	// Only the first 5 characters of the hex encoded SHA-1 hash of the password are appended to it and sent.
+	// The response must contain a line per hash suffix in the form of `SUFFIX:COUNT`.
+	Endpoint string
+	// Timeout of a single request to the Endpoint.
+	Timeout time.Duration
+	// File is the path to a local file containing hex encoded SHA-1 hashes (optionally suffixed with `:COUNT`),
+	// one per line and sorted ascending, e.g. the "ordered by hash" download of Have I Been Pwned.
+	File string
+	// FailOnError rejects passwords if the breach corpus could not be queried.
+	// By default, errors are logged and the password is accepted.
+	FailOnError bool
+}
+
+// PasswordBreachChecker checks if a password is part of a known breach corpus.
+type PasswordBreachChecker interface {
+	IsBreached(ctx context.Context, password string) (bool, error)
+}
+
+// NewPasswordBreachChecker returns nil if neither an Endpoint nor a File is configured.
+func (c *PasswordBreachCheckConfig) NewPasswordBreachChecker(client *http.Client) (PasswordBreachChecker, error) {
+	if c == nil || (c.Endpoint == "" && c.File == "") {
+		return nil, nil
+	}
+	checker := &passwordBreachChecker{
+		failOnError: c.FailOnError,
+	}
+	if c.File != "" {
+		if _, err := os.Stat(c.File); err != nil {
+			return nil, fmt.Errorf("password breach file: %w", err)
+		}
+		checker.sources = append(checker.sources, &breachFile{path: c.File})
+	}
+	if c.Endpoint != "" {
+		if client == nil {
+			client = http.DefaultClient
+		}
+		checker.sources = append(checker.sources, &breachRangeEndpoint{
+			endpoint: strings.TrimSuffix(c.Endpoint, "/") + "/",
+			timeout:  c.Timeout,
+			client:   client,
+		})
+	}
+	return checker, nil
+}
+
+type breachSource interface {
+	contains(ctx context.Context, hash string) (bool, error)
+}
+
+type passwordBreachChecker struct {
+	sources     []breachSource
+	failOnError bool
+}
+
+func (c *passwordBreachChecker) IsBreached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	for _, source := range c.sources {
+		breached, err := source.contains(ctx, hash)
+		if err != nil {
+			if c.failOnError {
+				return false, zerrors.ThrowInternal(err, "CRYPT-Aiqu3", "Errors.User.PasswordComplexityPolicy.BreachCheckFailed")
+			}
+			logging.WithError(err).Warn("password breach check failed, password accepted")
+			continue
+		}
+		if breached {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// breachRangeEndpoint queries a k-anonymity range API, so only the prefix of the hash leaves the system.
+type breachRangeEndpoint struct {
+	endpoint string
+	timeout  time.Duration
+	client   *http.Client
+}
+
+func (e *breachRangeEndpoint) contains(ctx context.Context, hash string) (bool, error) {
+	if e.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.endpoint+hash[:breachRangePrefixSize], nil)
+	if err != nil {
+		return false, err
+	}
+	// padding hides the number of returned suffixes from an observer of the (encrypted) response size
+	req.Header.Set("Add-Padding", "true")
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected status code %d from password breach endpoint", resp.StatusCode)
+	}
+	return rangeContains(resp.Body, hash[breachRangePrefixSize:])
+}
+
+// rangeContains checks if the suffix is part of a range response.
+// Entries with a count of 0 are padding and ignored.
+func rangeContains(r io.Reader, suffix string) (bool, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		entry, count, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !strings.EqualFold(entry, suffix) {
+			continue
+		}
+		return count != "0", nil
+	}
+	return false, scanner.Err()
+}
+
+// breachFile searches a local file of sorted hashes using a binary search,
+// so the file (which might be tens of gigabytes) never has to be loaded into memory.
+type breachFile struct {
+	path string
+}
+
+func (f *breachFile) contains(_ context.Context, hash string) (bool, error) {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return false, err
+	}
+	// search the first offset whose next line is greater than or equal to the hash
+	low, high := int64(0), info.Size()
+	for low < high {
+		mid := low + (high-low)/2
+		line, err := lineAfter(file, mid, info.Size())
+		if err != nil {
+			return false, err
+		}
+		if line != "" && lineHash(line) < hash {
+			low = mid + 1
+			continue
+		}
+		high = mid
+	}
+	line, err := lineAfter(file, low, info.Size())
+	if err != nil {
+		return false, err
+	}
+	return line != "" && lineHash(line) == hash, nil
+}
+
+// lineAfter returns the first complete line starting at or after the offset.
+// An empty string is returned if the end of the file is reached.
+func lineAfter(file io.ReaderAt, offset, size int64) (string, error) {
+	if offset > 0 {
+		// start at the previous byte so a line starting exactly at the offset is not skipped
+		offset--
+	}
+	reader := bufio.NewReader(io.NewSectionReader(file, offset, size-offset))
+	if offset > 0 {
+		if _, err := reader.ReadString('\n'); err != nil {
+			if errors.Is(err, io.EOF) {
+				return "", nil
+			}
+			return "", err
+		}
+	}
+	line, err := reader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func lineHash(line string) string {
+	if len(line) > sha1HexLength {
+		line = line[:sha1HexLength]
+	}
+	return strings.ToUpper(line)
+}
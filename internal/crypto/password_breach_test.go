@@ -0,0 +1,163 @@
+package crypto
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func sha1Hex(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+func writeBreachFile(t *testing.T, passwords ...string) string {
+	hashes := make([]string, len(passwords))
+	for i, password := range passwords {
+		hashes[i] = fmt.Sprintf("%s:%d", sha1Hex(password), i+1)
+	}
+	slices.Sort(hashes)
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(hashes, "\r\n")+"\r\n"), 0o600))
+	return path
+}
+
+func TestPasswordBreachCheckConfig_NewPasswordBreachChecker(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *PasswordBreachCheckConfig
+		wantNil bool
+		wantErr bool
+	}{
+		{
+			name:    "nil config",
+			wantNil: true,
+		},
+		{
+			name:    "not configured",
+			config:  &PasswordBreachCheckConfig{},
+			wantNil: true,
+		},
+		{
+			name: "file not existing",
+			config: &PasswordBreachCheckConfig{
+				File: filepath.Join(t.TempDir(), "missing.txt"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "endpoint",
+			config: &PasswordBreachCheckConfig{
+				Endpoint: "https://api.pwnedpasswords.com/range/",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.NewPasswordBreachChecker(nil)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNil, got == nil)
+		})
+	}
+}
+
+func Test_breachFile_contains(t *testing.T) {
+	breached := []string{"password", "123456", "qwerty", "letmein", "Password1!", "dragon", "monkey", "abc123"}
+	file := &breachFile{path: writeBreachFile(t, breached...)}
+	for _, password := range breached {
+		t.Run(password, func(t *testing.T) {
+			got, err := file.contains(context.Background(), sha1Hex(password))
+			require.NoError(t, err)
+			assert.True(t, got)
+		})
+	}
+	for _, password := range []string{"correct horse battery staple", "Password1", "", "zzzzzzzz"} {
+		t.Run("not "+password, func(t *testing.T) {
+			got, err := file.contains(context.Background(), sha1Hex(password))
+			require.NoError(t, err)
+			assert.False(t, got)
+		})
+	}
+	t.Run("boundaries", func(t *testing.T) {
+		got, err := file.contains(context.Background(), strings.Repeat("0", sha1HexLength))
+		require.NoError(t, err)
+		assert.False(t, got)
+		got, err = file.contains(context.Background(), strings.Repeat("F", sha1HexLength))
+		require.NoError(t, err)
+		assert.False(t, got)
+	})
+}
+
+func Test_breachRangeEndpoint_contains(t *testing.T) {
+	hash := sha1Hex("password")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/range/" + hash[:5]:
+			assert.Equal(t, "true", r.Header.Get("Add-Padding"))
+			fmt.Fprintf(w, "0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n%s:3861493\r\n00D4F6E8FA6EECAD2A3AA415EEC418D38EC:0\r\n", hash[5:])
+		case "/range/" + sha1Hex("padded")[:5]:
+			fmt.Fprintf(w, "%s:0\r\n", sha1Hex("padded")[5:])
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	endpoint := &breachRangeEndpoint{endpoint: server.URL + "/range/", client: server.Client()}
+
+	got, err := endpoint.contains(context.Background(), hash)
+	require.NoError(t, err)
+	assert.True(t, got)
+
+	got, err = endpoint.contains(context.Background(), sha1Hex("padded"))
+	require.NoError(t, err)
+	assert.False(t, got)
+
+	_, err = endpoint.contains(context.Background(), sha1Hex("unavailable"))
+	require.Error(t, err)
+}
+
+func Test_passwordBreachChecker_IsBreached(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	file := writeBreachFile(t, "password")
+
+	t.Run("found in file", func(t *testing.T) {
+		checker, err := (&PasswordBreachCheckConfig{File: file, Endpoint: server.URL}).NewPasswordBreachChecker(server.Client())
+		require.NoError(t, err)
+		got, err := checker.IsBreached(context.Background(), "password")
+		require.NoError(t, err)
+		assert.True(t, got)
+	})
+	t.Run("endpoint error ignored", func(t *testing.T) {
+		checker, err := (&PasswordBreachCheckConfig{File: file, Endpoint: server.URL}).NewPasswordBreachChecker(server.Client())
+		require.NoError(t, err)
+		got, err := checker.IsBreached(context.Background(), "Sup3rS3cret!")
+		require.NoError(t, err)
+		assert.False(t, got)
+	})
+	t.Run("endpoint error fails", func(t *testing.T) {
+		checker, err := (&PasswordBreachCheckConfig{Endpoint: server.URL, FailOnError: true}).NewPasswordBreachChecker(server.Client())
+		require.NoError(t, err)
+		_, err = checker.IsBreached(context.Background(), "Sup3rS3cret!")
+		assert.True(t, zerrors.IsInternal(err))
+	})
+}
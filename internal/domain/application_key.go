@@ -1,7 +1,9 @@
 package domain
 
 import (
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
 	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
@@ -24,6 +26,16 @@ func (k *ApplicationKey) SetPublicKey(publicKey []byte) {
 	k.PublicKey = publicKey
 }
 
+// PublicKeyValid checks that a provided public key (e.g. of a self-signed client certificate) is a PEM encoded PKIX public key.
+func (k *ApplicationKey) PublicKeyValid() bool {
+	block, _ := pem.Decode(k.PublicKey)
+	if block == nil {
+		return false
+	}
+	_, err := x509.ParsePKIXPublicKey(block.Bytes)
+	return err == nil
+}
+
 func (k *ApplicationKey) SetPrivateKey(privateKey []byte) {
 	k.PrivateKey = privateKey
 }
@@ -48,6 +48,21 @@ type OIDCApp struct {
 	BackChannelLogoutURI     string
 	LoginVersion             LoginVersion
 	LoginBaseURI             string
+	// RequirePushedAuthorizationRequests rejects authorization requests
+	// which were not pushed to the par endpoint (RFC 9126) beforehand.
+	RequirePushedAuthorizationRequests bool
+	// RequireDPoP rejects token requests without a DPoP proof (RFC 9449),
+	// so all issued tokens are sender-constrained.
+	RequireDPoP bool
+	// BackchannelTokenDeliveryMode defines how the client learns about the result
+	// of a client initiated backchannel authentication (CIBA) request.
+	BackchannelTokenDeliveryMode OIDCBackchannelTokenDeliveryMode
+	// BackchannelClientNotificationEndpoint is called in the ping mode,
+	// as soon as the user approved or denied a CIBA request.
+	BackchannelClientNotificationEndpoint string
+	// TLSClientAuthSubjectDN is the expected subject distinguished name (RFC 4514) of the client certificate
+	// of a client using the tls_client_auth method (RFC 8705).
+	TLSClientAuthSubjectDN string
 
 	State AppState
 }
@@ -105,6 +120,14 @@ const (
 	OIDCGrantTypeRefreshToken
 	OIDCGrantTypeDeviceCode
 	OIDCGrantTypeTokenExchange
+	OIDCGrantTypeCIBA
+)
+
+type OIDCBackchannelTokenDeliveryMode int32
+
+const (
+	OIDCBackchannelTokenDeliveryModePoll OIDCBackchannelTokenDeliveryMode = iota
+	OIDCBackchannelTokenDeliveryModePing
 )
 
 type OIDCApplicationType int32
@@ -122,8 +145,23 @@ const (
 	OIDCAuthMethodTypePost
 	OIDCAuthMethodTypeNone
 	OIDCAuthMethodTypePrivateKeyJWT
+	OIDCAuthMethodTypeTLSClientAuth
+	OIDCAuthMethodTypeSelfSignedTLSClientAuth
 )
 
+// IsMutualTLS returns if the client authenticates with a certificate (RFC 8705),
+// in which case the issued access tokens are bound to the certificate.
+func (a OIDCAuthMethodType) IsMutualTLS() bool {
+	return a == OIDCAuthMethodTypeTLSClientAuth || a == OIDCAuthMethodTypeSelfSignedTLSClientAuth
+}
+
+// KeysAllowed returns if keys can be added to clients using the auth method:
+// for private_key_jwt to verify the client assertion and
+// for self_signed_tls_client_auth to verify the client certificate.
+func (a OIDCAuthMethodType) KeysAllowed() bool {
+	return a == OIDCAuthMethodTypePrivateKeyJWT || a == OIDCAuthMethodTypeSelfSignedTLSClientAuth
+}
+
 type Compliance struct {
 	NoneCompliant bool
 	Problems      []string
@@ -137,7 +175,7 @@ const (
 )
 
 func (a *OIDCApp) IsValid() bool {
-	if a.ClockSkew > time.Second*5 || a.ClockSkew < time.Second*0 || !a.OriginsValid() {
+	if a.ClockSkew > time.Second*5 || a.ClockSkew < time.Second*0 || !a.OriginsValid() || !a.BackchannelNotificationValid() || !a.TLSClientAuthValid() {
 		return false
 	}
 	grantTypes := a.getRequiredGrantTypes()
@@ -153,6 +191,22 @@ func (a *OIDCApp) IsValid() bool {
 	return true
 }
 
+// BackchannelNotificationValid checks that a client using the ping mode has a https notification endpoint.
+func (a *OIDCApp) BackchannelNotificationValid() bool {
+	if a.BackchannelTokenDeliveryMode != OIDCBackchannelTokenDeliveryModePing {
+		return true
+	}
+	return strings.HasPrefix(strings.TrimSpace(a.BackchannelClientNotificationEndpoint), https)
+}
+
+// TLSClientAuthValid checks that a client using the tls_client_auth method has the subject DN of its certificate configured.
+func (a *OIDCApp) TLSClientAuthValid() bool {
+	if a.AuthMethodType != OIDCAuthMethodTypeTLSClientAuth {
+		return true
+	}
+	return strings.TrimSpace(a.TLSClientAuthSubjectDN) != ""
+}
+
 func (a *OIDCApp) OriginsValid() bool {
 	for _, origin := range a.AdditionalOrigins {
 		if !http_util.IsOrigin(strings.TrimSpace(origin)) {
@@ -173,11 +227,15 @@ func RequiredOIDCGrantTypes(responseTypes []OIDCResponseType, grantTypesSet []OI
 	for _, r := range responseTypes {
 		switch r {
 		case OIDCResponseTypeCode:
-			// #5684 when "Device Code" is selected, "Authorization Code" is no longer a hard requirement
-			if !containsOIDCGrantType(grantTypesSet, OIDCGrantTypeDeviceCode) {
-				grantTypes = append(grantTypes, OIDCGrantTypeAuthorizationCode)
-			} else {
+			// #5684 when "Device Code" is selected, "Authorization Code" is no longer a hard requirement,
+			// the same applies to the decoupled "CIBA" flow
+			switch {
+			case containsOIDCGrantType(grantTypesSet, OIDCGrantTypeDeviceCode):
 				grantTypes = append(grantTypes, OIDCGrantTypeDeviceCode)
+			case containsOIDCGrantType(grantTypesSet, OIDCGrantTypeCIBA):
+				grantTypes = append(grantTypes, OIDCGrantTypeCIBA)
+			default:
+				grantTypes = append(grantTypes, OIDCGrantTypeAuthorizationCode)
 			}
 		case OIDCResponseTypeIDToken, OIDCResponseTypeIDTokenToken:
 			if !implicit {
@@ -212,6 +270,12 @@ func containsOIDCGrantType(grantTypes []OIDCGrantType, grantType OIDCGrantType)
 	return false
 }
 
+// containsDecoupledOIDCGrantType checks for grant types, where the user doesn't authenticate on the client's device
+// and therefore no redirect is needed.
+func containsDecoupledOIDCGrantType(grantTypes []OIDCGrantType) bool {
+	return containsOIDCGrantType(grantTypes, OIDCGrantTypeDeviceCode) || containsOIDCGrantType(grantTypes, OIDCGrantTypeCIBA)
+}
+
 func (a *OIDCApp) FillCompliance() {
 	a.Compliance = GetOIDCCompliance(a.OIDCVersion, a.ApplicationType, a.GrantTypes, a.ResponseTypes, a.AuthMethodType, a.RedirectUris)
 }
@@ -241,7 +305,7 @@ func GetOIDCV1Compliance(appType OIDCApplicationType, grantTypes []OIDCGrantType
 }
 
 func checkGrantTypesCombination(compliance *Compliance, grantTypes []OIDCGrantType) {
-	if !containsOIDCGrantType(grantTypes, OIDCGrantTypeDeviceCode) && containsOIDCGrantType(grantTypes, OIDCGrantTypeRefreshToken) && !containsOIDCGrantType(grantTypes, OIDCGrantTypeAuthorizationCode) {
+	if !containsDecoupledOIDCGrantType(grantTypes) && containsOIDCGrantType(grantTypes, OIDCGrantTypeRefreshToken) && !containsOIDCGrantType(grantTypes, OIDCGrantTypeAuthorizationCode) {
 		compliance.NoneCompliant = true
 		compliance.Problems = append(compliance.Problems, "Application.OIDC.V1.GrantType.Refresh.NoAuthCode")
 	}
@@ -249,7 +313,7 @@ func checkGrantTypesCombination(compliance *Compliance, grantTypes []OIDCGrantTy
 
 func checkRedirectURIs(compliance *Compliance, grantTypes []OIDCGrantType, appType OIDCApplicationType, redirectUris []string) {
 	// See #5684 for OIDCGrantTypeDeviceCode and redirectUris further explanation
-	if len(redirectUris) == 0 && (!containsOIDCGrantType(grantTypes, OIDCGrantTypeDeviceCode) || (containsOIDCGrantType(grantTypes, OIDCGrantTypeDeviceCode) && containsOIDCGrantType(grantTypes, OIDCGrantTypeAuthorizationCode))) {
+	if len(redirectUris) == 0 && (!containsDecoupledOIDCGrantType(grantTypes) || (containsDecoupledOIDCGrantType(grantTypes) && containsOIDCGrantType(grantTypes, OIDCGrantTypeAuthorizationCode))) {
 		compliance.NoneCompliant = true
 		compliance.Problems = append([]string{"Application.OIDC.V1.NoRedirectUris"}, compliance.Problems...)
 	}
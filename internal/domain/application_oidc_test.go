@@ -177,6 +177,106 @@ func TestApplicationValid(t *testing.T) {
 			},
 			result: false,
 		},
+		{
+			name: "valid oidc application: ciba",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:    models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:         "AppID",
+					AppName:       "Name",
+					ResponseTypes: []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:    []OIDCGrantType{OIDCGrantTypeCIBA},
+				},
+			},
+			result: true,
+		},
+		{
+			name: "invalid oidc application: ciba ping without notification endpoint",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:                   models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:                        "AppID",
+					AppName:                      "Name",
+					ResponseTypes:                []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:                   []OIDCGrantType{OIDCGrantTypeCIBA},
+					BackchannelTokenDeliveryMode: OIDCBackchannelTokenDeliveryModePing,
+				},
+			},
+			result: false,
+		},
+		{
+			name: "invalid oidc application: ciba ping with http notification endpoint",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:                            models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:                                 "AppID",
+					AppName:                               "Name",
+					ResponseTypes:                         []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:                            []OIDCGrantType{OIDCGrantTypeCIBA},
+					BackchannelTokenDeliveryMode:          OIDCBackchannelTokenDeliveryModePing,
+					BackchannelClientNotificationEndpoint: "http://client.com/ciba",
+				},
+			},
+			result: false,
+		},
+		{
+			name: "valid oidc application: ciba ping",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:                            models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:                                 "AppID",
+					AppName:                               "Name",
+					ResponseTypes:                         []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:                            []OIDCGrantType{OIDCGrantTypeCIBA},
+					BackchannelTokenDeliveryMode:          OIDCBackchannelTokenDeliveryModePing,
+					BackchannelClientNotificationEndpoint: "https://client.com/ciba",
+				},
+			},
+			result: true,
+		},
+		{
+			name: "invalid oidc application: tls client auth without subject dn",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:     models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:          "AppID",
+					AppName:        "Name",
+					ResponseTypes:  []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:     []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+					AuthMethodType: OIDCAuthMethodTypeTLSClientAuth,
+				},
+			},
+			result: false,
+		},
+		{
+			name: "valid oidc application: tls client auth",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:             models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:                  "AppID",
+					AppName:                "Name",
+					ResponseTypes:          []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:             []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+					AuthMethodType:         OIDCAuthMethodTypeTLSClientAuth,
+					TLSClientAuthSubjectDN: "CN=client,O=partner",
+				},
+			},
+			result: true,
+		},
+		{
+			name: "valid oidc application: self-signed tls client auth",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:     models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:          "AppID",
+					AppName:        "Name",
+					ResponseTypes:  []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:     []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+					AuthMethodType: OIDCAuthMethodTypeSelfSignedTLSClientAuth,
+				},
+			},
+			result: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -241,6 +341,11 @@ func Test_checkGrantTypesCombination(t *testing.T) {
 			want:       &Compliance{},
 			grantTypes: []OIDCGrantType{OIDCGrantTypeDeviceCode, OIDCGrantTypeRefreshToken},
 		},
+		{
+			name:       "ciba and refresh token doesnt require OIDCGrantTypeAuthorizationCode",
+			want:       &Compliance{},
+			grantTypes: []OIDCGrantType{OIDCGrantTypeCIBA, OIDCGrantTypeRefreshToken},
+		},
 		{
 			name:       "refresh token and authorization code",
 			want:       &Compliance{},
@@ -284,6 +389,13 @@ func Test_checkRedirectURIs(t *testing.T) {
 			},
 			args: args{},
 		},
+		{
+			name: "no redirect uris with ciba",
+			want: &Compliance{},
+			args: args{
+				grantTypes: []OIDCGrantType{OIDCGrantTypeCIBA},
+			},
+		},
 		{
 			name: "implicit and authorization code",
 			want: &Compliance{
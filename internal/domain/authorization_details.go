@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	authorizationDetailTypeField      = "type"
+	authorizationDetailsTypeMaxLength = 200
+
+	// AuthorizationDetailsInvalid is the error message of invalid authorization details,
+	// which is returned as invalid_authorization_details error by the OIDC endpoints.
+	AuthorizationDetailsInvalid = "Errors.AuthorizationDetails.Invalid"
+)
+
+// AuthorizationDetail is a single object of the authorization_details parameter of Rich Authorization Requests (RFC 9396).
+// Apart from the required type, the fields are defined by the type and are kept as they were requested.
+type AuthorizationDetail map[string]any
+
+// Type returns the type of the authorization detail, which identifies its fields.
+func (d AuthorizationDetail) Type() string {
+	t, _ := d[authorizationDetailTypeField].(string)
+	return t
+}
+
+// AuthorizationDetails are the authorization_details requested by a client (RFC 9396).
+type AuthorizationDetails []AuthorizationDetail
+
+// ParseAuthorizationDetails parses the JSON array of the authorization_details parameter.
+// An empty value results in no authorization details.
+func ParseAuthorizationDetails(value string) (AuthorizationDetails, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	var details AuthorizationDetails
+	if err := json.Unmarshal([]byte(value), &details); err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "DOMAIN-ahG4e", AuthorizationDetailsInvalid)
+	}
+	return details, nil
+}
+
+// Validate checks that every authorization detail has one of the allowed types (RFC 9396, section 5).
+func (d AuthorizationDetails) Validate(allowedTypes []string) error {
+	for _, detail := range d {
+		if detail == nil || detail.Type() == "" {
+			return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Eiz1u", AuthorizationDetailsInvalid)
+		}
+		if !slices.Contains(allowedTypes, detail.Type()) {
+			return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ohg7a", AuthorizationDetailsInvalid)
+		}
+	}
+	return nil
+}
+
+// Contains checks that each of the other authorization details is equal to one of d.
+func (d AuthorizationDetails) Contains(other AuthorizationDetails) bool {
+	for _, detail := range other {
+		if !slices.ContainsFunc(d, func(granted AuthorizationDetail) bool {
+			return reflect.DeepEqual(granted, detail)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// Narrow returns the requested authorization details if they are part of the granted authorization details d.
+// If none are requested, all granted authorization details are returned (RFC 9396, section 6).
+// Requested details are only considered part of the granted details if they are equal to one of them,
+// as the semantics of the fields are unknown.
+func (d AuthorizationDetails) Narrow(requested AuthorizationDetails) (AuthorizationDetails, error) {
+	if len(requested) == 0 {
+		return d, nil
+	}
+	if !d.Contains(requested) {
+		return nil, zerrors.ThrowInvalidArgument(nil, "DOMAIN-ooW3k", AuthorizationDetailsInvalid)
+	}
+	return requested, nil
+}
+
+// ValidAuthorizationDetailsTypes checks the authorization details types a project declares.
+func ValidAuthorizationDetailsTypes(types []string) bool {
+	for i, t := range types {
+		if t == "" || t != strings.TrimSpace(t) || len(t) > authorizationDetailsTypeMaxLength {
+			return false
+		}
+		if slices.Contains(types[:i], t) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAuthorizationDetails(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    AuthorizationDetails
+		wantErr bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:    "no array",
+			value:   `{"type":"payment_initiation"}`,
+			wantErr: true,
+		},
+		{
+			name:  "ok",
+			value: `[{"type":"payment_initiation","instructedAmount":{"currency":"EUR","amount":"123.50"}}]`,
+			want: AuthorizationDetails{
+				{
+					"type": "payment_initiation",
+					"instructedAmount": map[string]any{
+						"currency": "EUR",
+						"amount":   "123.50",
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAuthorizationDetails(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAuthorizationDetails_Validate(t *testing.T) {
+	allowed := []string{"payment_initiation", "account_information"}
+	tests := []struct {
+		name    string
+		details AuthorizationDetails
+		wantErr bool
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:    "missing type",
+			details: AuthorizationDetails{{"actions": []any{"read"}}},
+			wantErr: true,
+		},
+		{
+			name:    "type not allowed",
+			details: AuthorizationDetails{{"type": "payment_initiation"}, {"type": "tax_data"}},
+			wantErr: true,
+		},
+		{
+			name:    "ok",
+			details: AuthorizationDetails{{"type": "payment_initiation"}, {"type": "account_information"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.details.Validate(allowed)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAuthorizationDetails_Narrow(t *testing.T) {
+	payment := AuthorizationDetail{"type": "payment_initiation", "creditorAccount": map[string]any{"iban": "DE02100100109307118603"}}
+	account := AuthorizationDetail{"type": "account_information", "actions": []any{"list_accounts"}}
+	granted := AuthorizationDetails{payment, account}
+
+	tests := []struct {
+		name      string
+		requested AuthorizationDetails
+		want      AuthorizationDetails
+		wantErr   bool
+	}{
+		{
+			name: "none requested",
+			want: granted,
+		},
+		{
+			name:      "subset",
+			requested: AuthorizationDetails{{"type": "account_information", "actions": []any{"list_accounts"}}},
+			want:      AuthorizationDetails{account},
+		},
+		{
+			name:      "changed fields",
+			requested: AuthorizationDetails{{"type": "account_information", "actions": []any{"list_accounts", "read_balances"}}},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := granted.Narrow(tt.requested)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidAuthorizationDetailsTypes(t *testing.T) {
+	assert.True(t, ValidAuthorizationDetailsTypes(nil))
+	assert.True(t, ValidAuthorizationDetailsTypes([]string{"payment_initiation", "account_information"}))
+	assert.False(t, ValidAuthorizationDetailsTypes([]string{""}))
+	assert.False(t, ValidAuthorizationDetailsTypes([]string{" payment_initiation"}))
+	assert.False(t, ValidAuthorizationDetailsTypes([]string{"payment_initiation", "payment_initiation"}))
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"strconv"
+	"time"
+)
+
+// BackchannelAuthState describes the step the
+// client initiated backchannel authentication (CIBA) request is in.
+// We generate the Stringer implementation for prettier
+// log output.
+//
+//go:generate stringer -type=BackchannelAuthState -linecomment
+type BackchannelAuthState uint
+
+const (
+	BackchannelAuthStateUndefined BackchannelAuthState = iota // undefined
+	BackchannelAuthStateInitiated                             // initiated
+	BackchannelAuthStateApproved                              // approved
+	BackchannelAuthStateDenied                                // denied
+	BackchannelAuthStateExpired                               // expired
+	BackchannelAuthStateDone                                  // done
+
+	backchannelAuthStateCount // invalid
+)
+
+// Exists returns true when not Undefined and
+// any status lower than backchannelAuthStateCount.
+func (s BackchannelAuthState) Exists() bool {
+	return s > BackchannelAuthStateUndefined && s < backchannelAuthStateCount
+}
+
+func (s BackchannelAuthState) GoString() string {
+	return strconv.Itoa(int(s))
+}
+
+// BackchannelAuthCanceled is a subset of BackchannelAuthState, allowed to
+// be used in the backchannelauth.CanceledEvent.
+// The string type is used to make the eventstore more readable
+// on the reason of cancelation.
+type BackchannelAuthCanceled string
+
+const (
+	BackchannelAuthCanceledDenied  = "denied"
+	BackchannelAuthCanceledExpired = "expired"
+)
+
+func (c BackchannelAuthCanceled) State() BackchannelAuthState {
+	switch c {
+	case BackchannelAuthCanceledDenied:
+		return BackchannelAuthStateDenied
+	case BackchannelAuthCanceledExpired:
+		return BackchannelAuthStateExpired
+	default:
+		return BackchannelAuthStateUndefined
+	}
+}
+
+// AuthRequestBackchannel is a pending client initiated backchannel authentication (CIBA) request,
+// which the user can approve or deny in the login.
+type AuthRequestBackchannel struct {
+	ID             string
+	CreationDate   time.Time
+	ClientID       string
+	UserID         string
+	Scopes         []string
+	BindingMessage string
+	Expires        time.Time
+	AppName        string
+	ProjectName    string
+}
@@ -0,0 +1,30 @@
+package domain
+
+type GroupState int32
+
+const (
+	GroupStateUnspecified GroupState = iota
+	GroupStateActive
+	GroupStateRemoved
+	groupStateCount
+)
+
+func (s GroupState) Valid() bool {
+	return s >= 0 && s < groupStateCount
+}
+
+func (s GroupState) Exists() bool {
+	return s != GroupStateUnspecified && s != GroupStateRemoved
+}
+
+type GroupGrantState int32
+
+const (
+	GroupGrantStateUnspecified GroupGrantState = iota
+	GroupGrantStateActive
+	GroupGrantStateRemoved
+)
+
+func (s GroupGrantState) Exists() bool {
+	return s != GroupGrantStateUnspecified && s != GroupGrantStateRemoved
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"strings"
+)
+
+const (
+	// RecoveryCodesCount is the number of recovery codes generated for a user at once.
+	RecoveryCodesCount = 10
+	// RecoveryCodeLength is the length of a recovery code without formatting.
+	RecoveryCodeLength = 10
+)
+
+type RecoveryCodes struct {
+	*ObjectDetails
+
+	Codes []string
+}
+
+// FormatRecoveryCode splits the code in two halves for better readability, e.g. `h3k9d-m2x7q`.
+func FormatRecoveryCode(code string) string {
+	if len(code) != RecoveryCodeLength {
+		return code
+	}
+	return code[:RecoveryCodeLength/2] + "-" + code[RecoveryCodeLength/2:]
+}
+
+// NormalizeRecoveryCode removes the formatting of [FormatRecoveryCode] and whitespaces entered by the user.
+func NormalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatRecoveryCode(t *testing.T) {
+	assert.Equal(t, "h3k9d-m2x7q", FormatRecoveryCode("h3k9dm2x7q"))
+	assert.Equal(t, "short", FormatRecoveryCode("short"))
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	assert.Equal(t, "h3k9dm2x7q", NormalizeRecoveryCode("h3k9d-m2x7q"))
+	assert.Equal(t, "h3k9dm2x7q", NormalizeRecoveryCode(" H3K9D - M2X7Q "))
+}
@@ -24,6 +24,28 @@ type WebAuthNToken struct {
 	SignCount              uint32
 	WebAuthNTokenName      string
 	RPID                   string
+	// ModelName is the description of the authenticator in the FIDO metadata, if known
+	ModelName string
+}
+
+// WebAuthNAttestationPolicy restricts the authenticators which can be registered
+// based on their attestation and the FIDO Metadata Service (MDS).
+type WebAuthNAttestationPolicy struct {
+	// Required rejects authenticators without an attestation which chains to the roots of their metadata statement
+	Required bool
+	// AllowedAAGUIDs restricts the registration to the listed authenticator models
+	AllowedAAGUIDs []string
+	// DeniedAAGUIDs rejects the listed authenticator models
+	DeniedAAGUIDs []string
+	// CertificationLevels requires the authenticator to be FIDO certified in one of the listed levels (e.g. FIDO_CERTIFIED_L2)
+	CertificationLevels []string
+}
+
+// AttestationRequired returns true if the authenticator needs to be verified against the metadata.
+// Allowed AAGUIDs and certification levels can only be trusted with a verified attestation,
+// so they imply the requirement.
+func (p *WebAuthNAttestationPolicy) AttestationRequired() bool {
+	return p != nil && (p.Required || len(p.AllowedAAGUIDs) > 0 || len(p.CertificationLevels) > 0)
 }
 
 type WebAuthNLogin struct {
@@ -38,6 +38,11 @@ const (
 	IDPTypeGoogle
 	IDPTypeApple
 	IDPTypeSAML
+	IDPTypeOkta
+	IDPTypeKeycloak
+	IDPTypeADFS
+	IDPTypeBitbucket
+	IDPTypeDiscord
 )
 
 func (t IDPType) GetCSSClass() string {
@@ -59,7 +64,12 @@ func (t IDPType) GetCSSClass() string {
 		IDPTypeJWT,
 		IDPTypeOAuth,
 		IDPTypeLDAP,
-		IDPTypeSAML:
+		IDPTypeSAML,
+		IDPTypeOkta,
+		IDPTypeKeycloak,
+		IDPTypeADFS,
+		IDPTypeBitbucket,
+		IDPTypeDiscord:
 		fallthrough
 	default:
 		return ""
@@ -85,6 +95,10 @@ func (t IDPType) DisplayName() string {
 		return "Google"
 	case IDPTypeApple:
 		return "Apple"
+	case IDPTypeBitbucket:
+		return "Bitbucket"
+	case IDPTypeDiscord:
+		return "Discord"
 	case IDPTypeUnspecified,
 		IDPTypeOIDC,
 		IDPTypeJWT,
@@ -93,7 +107,10 @@ func (t IDPType) DisplayName() string {
 		IDPTypeAzureAD,
 		IDPTypeGitHubEnterprise,
 		IDPTypeGitLabSelfHosted,
-		IDPTypeSAML:
+		IDPTypeSAML,
+		IDPTypeOkta,
+		IDPTypeKeycloak,
+		IDPTypeADFS:
 		fallthrough
 	default:
 		// we should never get here, so log it
@@ -31,6 +31,7 @@ const (
 	PermissionUserRead            = "user.read"
 	PermissionUserDelete          = "user.delete"
 	PermissionUserCredentialWrite = "user.credential.write"
+	PermissionUserIDPTokenRead    = "user.idp.token.read"
 	PermissionSessionWrite        = "session.write"
 	PermissionSessionRead         = "session.read"
 	PermissionSessionLink         = "session.link"
@@ -47,6 +48,11 @@ const (
 	PermissionProjectRoleWrite    = "project.role.write"
 	PermissionProjectRoleRead     = "project.role.read"
 	PermissionProjectRoleDelete   = "project.role.delete"
+	PermissionGroupWrite          = "group.write"
+	PermissionGroupRead           = "group.read"
+	PermissionGroupDelete         = "group.delete"
+	PermissionGroupGrantWrite     = "group.grant.write"
+	PermissionGroupGrantDelete    = "group.grant.delete"
 )
 
 // ProjectPermissionCheck is used as a check for preconditions dependent on application, project, user resourceowner and usergrants.
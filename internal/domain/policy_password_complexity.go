@@ -22,6 +22,9 @@ type PasswordComplexityPolicy struct {
 	HasUppercase bool
 	HasNumber    bool
 	HasSymbol    bool
+	// CheckBreached rejects passwords which are part of a known breach corpus.
+	// The check itself can't be done by the policy and is therefore executed by the caller.
+	CheckBreached bool
 
 	Default bool
 }
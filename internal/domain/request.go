@@ -33,6 +33,8 @@ type AuthRequestOIDC struct {
 	ResponseMode  OIDCResponseMode
 	Nonce         string
 	CodeChallenge *OIDCCodeChallenge
+
+	AuthorizationDetails AuthorizationDetails
 }
 
 func (a *AuthRequestOIDC) Type() AuthRequestType {
@@ -12,3 +12,17 @@ const (
 func (s SMTPConfigState) Exists() bool {
 	return s != SMTPConfigStateUnspecified && s != SMTPConfigStateRemoved
 }
+
+type EmailAPIProvider int32
+
+const (
+	EmailAPIProviderUnspecified EmailAPIProvider = iota
+	EmailAPIProviderSES
+	EmailAPIProviderSendGrid
+	EmailAPIProviderMailgun
+	EmailAPIProviderJSON
+)
+
+func (p EmailAPIProvider) Valid() bool {
+	return p > EmailAPIProviderUnspecified && p <= EmailAPIProviderJSON
+}
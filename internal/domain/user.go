@@ -44,6 +44,7 @@ const (
 	UserAuthMethodTypeOTPEmail
 	UserAuthMethodTypeOTP // generic OTP when parsing AMR from OIDC
 	UserAuthMethodTypePrivateKey
+	UserAuthMethodTypeRecoveryCode
 	userAuthMethodTypeCount
 )
 
@@ -63,7 +64,8 @@ func HasMFA(methods []UserAuthMethodType) bool {
 			UserAuthMethodTypeOTPEmail,
 			UserAuthMethodTypeIDP,
 			UserAuthMethodTypeOTP,
-			UserAuthMethodTypePrivateKey:
+			UserAuthMethodTypePrivateKey,
+			UserAuthMethodTypeRecoveryCode:
 			factors++
 		case UserAuthMethodTypeUnspecified,
 			userAuthMethodTypeCount:
@@ -83,7 +85,8 @@ func Has2FA(methods []UserAuthMethodType) bool {
 			UserAuthMethodTypeTOTP,
 			UserAuthMethodTypeOTPSMS,
 			UserAuthMethodTypeOTPEmail,
-			UserAuthMethodTypeOTP:
+			UserAuthMethodTypeOTP,
+			UserAuthMethodTypeRecoveryCode:
 			factors++
 		case UserAuthMethodTypeUnspecified,
 			UserAuthMethodTypePassword,
@@ -0,0 +1,339 @@
+// Package eventsink delivers the events of all instances in batches to HTTP endpoints,
+// for example to feed a SIEM or a data warehouse.
+//
+// Each sink stores the position of the last delivered event,
+// which is updated after the endpoint acknowledged the batch.
+// A batch is redelivered if the acknowledgement could not be stored,
+// so endpoints must handle duplicates, for example by the instance, aggregate and sequence of an event.
+package eventsink
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+	"github.com/zitadel/zitadel/pkg/actions"
+)
+
+type Config struct {
+	Enabled bool
+	// Endpoint receives the batches as POST requests with a JSON body.
+	Endpoint string
+	// Headers are added to each request, for example to authenticate.
+	Headers map[string]string
+	// SigningKey signs the body of the requests like for action targets, if set.
+	SigningKey string
+	// InstanceIDs restricts the events to the instances, all instances are delivered if empty.
+	InstanceIDs []string
+	// AggregateTypes restricts the events to the aggregate types.
+	AggregateTypes []string
+	// EventTypes restricts the events to the event types.
+	EventTypes []string
+	// SkipHistory starts a new sink at the latest event instead of the first stored event.
+	SkipHistory bool
+	// BatchSize is the maximum amount of events per request.
+	BatchSize uint16
+	// Interval is the time to wait for new events after all events were delivered.
+	Interval time.Duration
+	// Timeout of a request to the endpoint.
+	Timeout time.Duration
+	// RetryInterval is the time to wait after a failed delivery.
+	RetryInterval time.Duration
+}
+
+// Batch is the body of the requests to the endpoint.
+type Batch struct {
+	Sink   string   `json:"sink"`
+	Events []*Event `json:"events"`
+	// Position and Offset identify the last event of the batch.
+	// Multiple events of a transaction share a position, the offset counts them.
+	Position decimal.Decimal `json:"position"`
+	Offset   uint32          `json:"offset"`
+}
+
+type Event struct {
+	InstanceID    string          `json:"instanceId"`
+	AggregateType string          `json:"aggregateType"`
+	AggregateID   string          `json:"aggregateId"`
+	ResourceOwner string          `json:"resourceOwner"`
+	Sequence      uint64          `json:"sequence"`
+	Type          string          `json:"eventType"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	Creator       string          `json:"creator"`
+	Position      decimal.Decimal `json:"position"`
+	Payload       json.RawMessage `json:"payload,omitempty"`
+}
+
+type cursor struct {
+	position decimal.Decimal
+	offset   uint32
+}
+
+// next moves the cursor to the event.
+func (c cursor) next(position decimal.Decimal) cursor {
+	if position.Equal(c.position) {
+		return cursor{position: position, offset: c.offset + 1}
+	}
+	return cursor{position: position, offset: 1}
+}
+
+type Eventstore interface {
+	Filter(ctx context.Context, searchQuery *eventstore.SearchQueryBuilder) ([]eventstore.Event, error)
+	InstanceIDs(ctx context.Context, searchQuery *eventstore.SearchQueryBuilder) ([]string, error)
+	LatestPosition(ctx context.Context, searchQuery *eventstore.SearchQueryBuilder) (decimal.Decimal, error)
+}
+
+type sink struct {
+	name   string
+	config *Config
+	es     Eventstore
+	client *database.DB
+	http   *http.Client
+}
+
+// Start delivers the events of the enabled sinks in the background.
+// All nodes can be configured with the same sinks,
+// the cursor of a sink is only advanced by the node which delivered the batch first,
+// but nodes delivering concurrently can send the same batch.
+func Start(ctx context.Context, configs map[string]*Config, es Eventstore, client *database.DB) {
+	for name, config := range configs {
+		if config == nil || !config.Enabled {
+			continue
+		}
+		logging.WithFields("sink", name, "endpoint", config.Endpoint).Info("start event sink")
+		go newSink(name, config, es, client).run(ctx)
+	}
+}
+
+func newSink(name string, config *Config, es Eventstore, client *database.DB) *sink {
+	if config.BatchSize == 0 {
+		config.BatchSize = 100
+	}
+	if config.Interval <= 0 {
+		config.Interval = time.Second
+	}
+	if config.RetryInterval <= 0 {
+		config.RetryInterval = 10 * time.Second
+	}
+	return &sink{
+		name:   name,
+		config: config,
+		es:     es,
+		client: client,
+		http:   &http.Client{Timeout: config.Timeout},
+	}
+}
+
+func (s *sink) run(ctx context.Context) {
+	for {
+		err := s.init(ctx)
+		if err == nil {
+			break
+		}
+		logging.WithFields("sink", s.name).WithError(err).Warn("unable to initialize event sink")
+		if !wait(ctx, s.config.RetryInterval) {
+			return
+		}
+	}
+	for {
+		delivered, err := s.deliver(ctx)
+		logging.WithFields("sink", s.name).OnError(err).Warn("event sink delivery failed")
+		switch {
+		case err != nil:
+			if !wait(ctx, s.config.RetryInterval) {
+				return
+			}
+		case delivered < int(s.config.BatchSize):
+			if !wait(ctx, s.config.Interval) {
+				return
+			}
+		case ctx.Err() != nil:
+			return
+		}
+	}
+}
+
+func wait(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+const (
+	initCursorStmt = `INSERT INTO eventstore.event_sink_cursors (sink_name, "position") VALUES ($1, $2) ON CONFLICT (sink_name) DO NOTHING`
+	cursorStmt     = `SELECT "position", "offset" FROM eventstore.event_sink_cursors WHERE sink_name = $1`
+	// setCursorStmt only advances the cursor if it was not moved by another node in the meantime.
+	setCursorStmt = `UPDATE eventstore.event_sink_cursors SET "position" = $2, "offset" = $3, change_date = now() WHERE sink_name = $1 AND "position" = $4 AND "offset" = $5`
+)
+
+// init creates the cursor of the sink if it does not exist yet.
+func (s *sink) init(ctx context.Context) error {
+	var position decimal.Decimal
+	if s.config.SkipHistory {
+		var err error
+		position, err = s.es.LatestPosition(ctx, s.query(cursor{}, s.config.InstanceIDs).Columns(eventstore.ColumnsMaxPosition))
+		if err != nil {
+			return err
+		}
+	}
+	_, err := s.client.ExecContext(ctx, initCursorStmt, s.name, position)
+	return err
+}
+
+// deliver sends the next batch and advances the cursor after the endpoint acknowledged it.
+// The cursor is not locked during the request, so a slow endpoint does not hold a transaction open.
+// If another node delivered the batch in the meantime, the cursor is left as it is and nothing is counted as delivered.
+func (s *sink) deliver(ctx context.Context) (delivered int, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	previous := cursor{}
+	err = s.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		return row.Scan(&previous.position, &previous.offset)
+	}, cursorStmt, s.name)
+	if err != nil {
+		return 0, zerrors.ThrowInternal(err, "SINK-ooR5a", "Errors.Internal")
+	}
+	events, err := s.events(ctx, previous)
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+
+	batch := &Batch{
+		Sink:   s.name,
+		Events: make([]*Event, len(events)),
+	}
+	current := previous
+	for i, event := range events {
+		current = current.next(event.Position())
+		batch.Events[i] = eventToSink(event)
+	}
+	batch.Position, batch.Offset = current.position, current.offset
+
+	if err = s.send(ctx, batch); err != nil {
+		return 0, err
+	}
+	result, err := s.client.ExecContext(ctx, setCursorStmt, s.name, current.position, current.offset, previous.position, previous.offset)
+	if err != nil {
+		return 0, zerrors.ThrowInternal(err, "SINK-Ie9ph", "Errors.Internal")
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		logging.WithFields("sink", s.name).Info("batch was delivered by another node")
+		return 0, nil
+	}
+	return len(events), nil
+}
+
+func (s *sink) events(ctx context.Context, current cursor) ([]eventstore.Event, error) {
+	instanceIDs := s.config.InstanceIDs
+	if len(instanceIDs) == 0 {
+		// open transactions can only be awaited per instance,
+		// so the events are filtered by the instances having new events.
+		// The instances are queried without the offset, which would skip instances instead of events.
+		var err error
+		instanceIDs, err = s.es.InstanceIDs(ctx, s.query(current, nil).Columns(eventstore.ColumnsInstanceIDs))
+		if err != nil || len(instanceIDs) == 0 {
+			return nil, err
+		}
+	}
+	builder := s.query(current, instanceIDs).
+		Columns(eventstore.ColumnsEvent).
+		AwaitOpenTransactions().
+		Limit(uint64(s.config.BatchSize))
+	// Events pushed in the same transaction share the position,
+	// the offset skips the events of the position already delivered.
+	// It stays stable across batches because every instance with events at the position of the cursor
+	// is part of the instances having new events.
+	if current.offset > 0 {
+		builder = builder.Offset(current.offset)
+	}
+	return s.es.Filter(ctx, builder)
+}
+
+// query returns the events at and after the position of the cursor.
+func (s *sink) query(current cursor, instanceIDs []string) *eventstore.SearchQueryBuilder {
+	builder := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		OrderAsc().
+		PositionAtLeast(current.position)
+	if len(instanceIDs) > 0 {
+		builder = builder.InstanceIDs(instanceIDs)
+	}
+	if len(s.config.AggregateTypes) == 0 && len(s.config.EventTypes) == 0 {
+		return builder
+	}
+	aggregateTypes := make([]eventstore.AggregateType, len(s.config.AggregateTypes))
+	for i, aggregateType := range s.config.AggregateTypes {
+		aggregateTypes[i] = eventstore.AggregateType(aggregateType)
+	}
+	eventTypes := make([]eventstore.EventType, len(s.config.EventTypes))
+	for i, eventType := range s.config.EventTypes {
+		eventTypes[i] = eventstore.EventType(eventType)
+	}
+	return builder.AddQuery().
+		AggregateTypes(aggregateTypes...).
+		EventTypes(eventTypes...).
+		Builder()
+}
+
+func (s *sink) send(ctx context.Context, batch *Batch) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "SINK-eiN0u", "Errors.Internal")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return zerrors.ThrowInternal(err, "SINK-Ahx3i", "Errors.Internal")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range s.config.Headers {
+		req.Header.Set(key, value)
+	}
+	if s.config.SigningKey != "" {
+		req.Header.Set(actions.SigningHeader, actions.ComputeSignatureHeader(time.Now(), body, s.config.SigningKey))
+	}
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return zerrors.ThrowUnavailable(err, "SINK-Lae6o", "Errors.Internal")
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return zerrors.ThrowUnavailablef(nil, "SINK-ue7Ai", "endpoint responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func eventToSink(event eventstore.Event) *Event {
+	sinkEvent := &Event{
+		InstanceID:    event.Aggregate().InstanceID,
+		AggregateType: string(event.Aggregate().Type),
+		AggregateID:   event.Aggregate().ID,
+		ResourceOwner: event.Aggregate().ResourceOwner,
+		Sequence:      event.Sequence(),
+		Type:          string(event.Type()),
+		CreatedAt:     event.CreatedAt(),
+		Creator:       event.Creator(),
+		Position:      event.Position(),
+	}
+	if payload := event.DataAsBytes(); len(payload) > 0 && json.Valid(payload) {
+		sinkEvent.Payload = payload
+	}
+	return sinkEvent
+}
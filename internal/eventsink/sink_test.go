@@ -0,0 +1,227 @@
+package eventsink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/pkg/actions"
+)
+
+type mockEventstore struct {
+	events      []eventstore.Event
+	instanceIDs []string
+	queries     []*eventstore.SearchQueryBuilder
+}
+
+func (m *mockEventstore) Filter(_ context.Context, query *eventstore.SearchQueryBuilder) ([]eventstore.Event, error) {
+	m.queries = append(m.queries, query)
+	return m.events, nil
+}
+
+func (m *mockEventstore) InstanceIDs(_ context.Context, query *eventstore.SearchQueryBuilder) ([]string, error) {
+	m.queries = append(m.queries, query)
+	return m.instanceIDs, nil
+}
+
+func (m *mockEventstore) LatestPosition(_ context.Context, query *eventstore.SearchQueryBuilder) (decimal.Decimal, error) {
+	m.queries = append(m.queries, query)
+	return decimal.NewFromInt(42), nil
+}
+
+func testEvent(instanceID string, sequence uint64, position decimal.Decimal) eventstore.Event {
+	return &eventstore.BaseEvent{
+		EventType: "user.added",
+		Agg: &eventstore.Aggregate{
+			ID:            "userID",
+			Type:          "user",
+			ResourceOwner: "orgID",
+			InstanceID:    instanceID,
+		},
+		Seq:      sequence,
+		Pos:      position,
+		Creation: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Data:     []byte(`{"userName":"gigi"}`),
+	}
+}
+
+func Test_cursor_next(t *testing.T) {
+	c := cursor{position: decimal.NewFromInt(1), offset: 2}
+	c = c.next(decimal.NewFromInt(1))
+	assert.Equal(t, uint32(3), c.offset)
+	c = c.next(decimal.NewFromInt(2))
+	assert.True(t, decimal.NewFromInt(2).Equal(c.position))
+	assert.Equal(t, uint32(1), c.offset)
+}
+
+func Test_sink_query(t *testing.T) {
+	s := newSink("sink", &Config{
+		AggregateTypes: []string{"user"},
+		EventTypes:     []string{"user.added"},
+	}, nil, nil)
+	query := s.query(cursor{position: decimal.NewFromInt(5), offset: 2}, []string{"instance1"})
+	assert.True(t, decimal.NewFromInt(5).Equal(query.GetPositionAtLeast()))
+	assert.Zero(t, query.GetOffset())
+	assert.Equal(t, []string{"instance1"}, query.GetInstanceIDs())
+	assert.False(t, query.GetDesc())
+	require.Len(t, query.GetQueries(), 1)
+	assert.Equal(t, []eventstore.AggregateType{"user"}, query.GetQueries()[0].GetAggregateTypes())
+	assert.Equal(t, []eventstore.EventType{"user.added"}, query.GetQueries()[0].GetEventTypes())
+
+	s = newSink("sink", &Config{}, nil, nil)
+	query = s.query(cursor{}, nil)
+	assert.Empty(t, query.GetQueries())
+	assert.Empty(t, query.GetInstanceIDs())
+}
+
+func Test_sink_deliver(t *testing.T) {
+	position := decimal.NewFromFloat(1712662457.365215)
+	events := []eventstore.Event{
+		testEvent("instance1", 1, position),
+		testEvent("instance1", 2, position),
+		testEvent("instance2", 1, position.Add(decimal.NewFromInt(1))),
+	}
+	tests := []struct {
+		name          string
+		status        int
+		concurrent    bool
+		events        []eventstore.Event
+		wantDelivered int
+		wantErr       bool
+		wantBatch     bool
+	}{
+		{
+			name:          "delivered",
+			status:        http.StatusOK,
+			events:        events,
+			wantDelivered: 3,
+			wantBatch:     true,
+		},
+		{
+			name:      "endpoint failed",
+			status:    http.StatusServiceUnavailable,
+			events:    events,
+			wantErr:   true,
+			wantBatch: true,
+		},
+		{
+			name:   "no events",
+			status: http.StatusOK,
+		},
+		{
+			name:       "delivered by other node",
+			status:     http.StatusOK,
+			concurrent: true,
+			events:     events,
+			wantBatch:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received *Batch
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "value", r.Header.Get("X-Custom"))
+				assert.NotEmpty(t, r.Header.Get(actions.SigningHeader))
+				received = new(Batch)
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(received))
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectQuery(regexp.QuoteMeta(cursorStmt)).
+				WithArgs("sink").
+				WillReturnRows(sqlmock.NewRows([]string{"position", "offset"}).AddRow("1712662457.365215", 1))
+			if tt.wantBatch && !tt.wantErr {
+				var affected int64 = 1
+				if tt.concurrent {
+					affected = 0
+				}
+				mock.ExpectExec(regexp.QuoteMeta(setCursorStmt)).
+					WithArgs("sink", sqlmock.AnyArg(), 1, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, affected))
+			}
+
+			es := &mockEventstore{events: tt.events, instanceIDs: []string{"instance1", "instance2"}}
+			s := newSink("sink", &Config{
+				Endpoint:   server.URL,
+				Headers:    map[string]string{"X-Custom": "value"},
+				SigningKey: "key",
+			}, es, &database.DB{DB: db})
+
+			delivered, err := s.deliver(context.Background())
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantDelivered, delivered)
+			require.NoError(t, mock.ExpectationsWereMet())
+
+			if !tt.wantBatch {
+				assert.Nil(t, received)
+				return
+			}
+			require.NotNil(t, received)
+			assert.Equal(t, "sink", received.Sink)
+			require.Len(t, received.Events, 3)
+			assert.True(t, position.Equal(received.Events[0].Position))
+			received.Events[0].Position = position
+			assert.Equal(t, &Event{
+				InstanceID:    "instance1",
+				AggregateType: "user",
+				AggregateID:   "userID",
+				ResourceOwner: "orgID",
+				Sequence:      1,
+				Type:          "user.added",
+				CreatedAt:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Position:      position,
+				Payload:       json.RawMessage(`{"userName":"gigi"}`),
+			}, received.Events[0])
+			assert.True(t, position.Add(decimal.NewFromInt(1)).Equal(received.Position))
+			assert.Equal(t, uint32(1), received.Offset)
+		})
+	}
+}
+
+func Test_sink_events_instances(t *testing.T) {
+	es := &mockEventstore{instanceIDs: []string{"instance1"}}
+	s := newSink("sink", &Config{BatchSize: 10}, es, nil)
+	_, err := s.events(context.Background(), cursor{position: decimal.NewFromInt(1)})
+	require.NoError(t, err)
+	require.Len(t, es.queries, 2)
+	assert.Equal(t, eventstore.Columns(eventstore.ColumnsInstanceIDs), es.queries[0].GetColumns())
+	assert.Equal(t, []string{"instance1"}, es.queries[1].GetInstanceIDs())
+	assert.True(t, es.queries[1].GetAwaitOpenTransactions())
+	assert.Equal(t, uint64(10), es.queries[1].GetLimit())
+
+	es = &mockEventstore{instanceIDs: []string{"instance1"}}
+	s = newSink("sink", &Config{BatchSize: 10}, es, nil)
+	_, err = s.events(context.Background(), cursor{position: decimal.NewFromInt(1), offset: 3})
+	require.NoError(t, err)
+	require.Len(t, es.queries, 2)
+	assert.Zero(t, es.queries[0].GetOffset(), "instances must not be skipped by the offset")
+	assert.True(t, decimal.NewFromInt(1).Equal(es.queries[0].GetPositionAtLeast()))
+	assert.Equal(t, uint32(3), es.queries[1].GetOffset())
+
+	es = &mockEventstore{}
+	s = newSink("sink", &Config{}, es, nil)
+	events, err := s.events(context.Background(), cursor{})
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Len(t, es.queries, 1, "no instances with new events")
+}
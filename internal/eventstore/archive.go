@@ -0,0 +1,254 @@
+package eventstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// ArchiveConfig configures the archival of closed aggregates.
+// The events of an archived aggregate, except its closing event, are moved from eventstore.events2 to eventstore.events2_archive
+// and are moved back as soon as the aggregate is filtered by its id.
+// As the closing event is kept, events pushed to an archived aggregate continue its sequence
+// and [Eventstore.Filter] only looks up the archive for filters returning a closing event as first event of an aggregate.
+// [Eventstore.FilterToReducer] looks up the archived aggregates filtered by id before it reduces the first event.
+type ArchiveConfig struct {
+	// Enabled rehydrates archived aggregates, it is required to archive aggregates.
+	Enabled bool
+	// Retention is the minimum age of the closing event before an aggregate is archived.
+	Retention time.Duration
+	// ClosingEventTypes close an aggregate if one of them is the latest event of the aggregate.
+	// They must be the same on all nodes, as they are used to detect archived aggregates.
+	ClosingEventTypes []string
+	// BatchSize is the maximum amount of aggregates archived per transaction.
+	BatchSize uint32
+}
+
+type ArchivedAggregate struct {
+	InstanceID    string
+	AggregateType AggregateType
+	AggregateID   string
+	// Sequence of the closing event
+	Sequence uint64
+}
+
+const (
+	// closedAggregatesStmt selects aggregates whose latest event closes them and which are not archived yet.
+	// Only events already processed by all projections of the instance are archived,
+	// projections which did not process any event yet (e.g. rebuilds) prevent archiving.
+	closedAggregatesStmt = `SELECT e.instance_id, e.aggregate_type, e.aggregate_id, e."sequence"` +
+		` FROM eventstore.events2 e` +
+		` WHERE e.event_type = ANY($1) AND e.created_at < $2 AND e."sequence" > 1` +
+		` AND e."position" < (SELECT MIN(COALESCE(s."position", 0)) FROM projections.current_states s WHERE s.instance_id = e.instance_id)` +
+		` AND NOT EXISTS (SELECT 1 FROM eventstore.events2 l WHERE l.instance_id = e.instance_id AND l.aggregate_type = e.aggregate_type AND l.aggregate_id = e.aggregate_id AND l."sequence" > e."sequence")` +
+		` AND NOT EXISTS (SELECT 1 FROM eventstore.archived_aggregates a WHERE a.instance_id = e.instance_id AND a.aggregate_type = e.aggregate_type AND a.aggregate_id = e.aggregate_id)` +
+		` LIMIT $3`
+	// archiveAggregateStmt moves the events of an aggregate before its closing event to the archive
+	// if it was not changed in the meantime.
+	archiveAggregateStmt = `WITH moved AS (` +
+		`DELETE FROM eventstore.events2 WHERE instance_id = $1 AND aggregate_type = $2 AND aggregate_id = $3 AND "sequence" < $4` +
+		` AND NOT EXISTS (SELECT 1 FROM eventstore.events2 WHERE instance_id = $1 AND aggregate_type = $2 AND aggregate_id = $3 AND "sequence" > $4)` +
+		` RETURNING *` +
+		`), archived AS (INSERT INTO eventstore.events2_archive SELECT * FROM moved RETURNING 1)` +
+		` INSERT INTO eventstore.archived_aggregates (instance_id, aggregate_type, aggregate_id, event_count)` +
+		` SELECT $1, $2, $3, COUNT(*) FROM archived HAVING COUNT(*) > 0` +
+		` RETURNING event_count`
+	// archivedAggregatesStmt selects the archived aggregates of the ids, the instances are optional.
+	archivedAggregatesStmt = `SELECT instance_id, aggregate_type, aggregate_id FROM eventstore.archived_aggregates` +
+		` WHERE aggregate_id = ANY($1) AND (cardinality($2::TEXT[]) = 0 OR instance_id = ANY($2))`
+	// rehydrateAggregateStmt moves the events of an aggregate back to eventstore.events2.
+	// Deleting the archived aggregate first locks it against concurrent rehydrations.
+	rehydrateAggregateStmt = `WITH restored AS (` +
+		`DELETE FROM eventstore.archived_aggregates WHERE instance_id = $1 AND aggregate_type = $2 AND aggregate_id = $3 RETURNING 1` +
+		`), moved AS (` +
+		`DELETE FROM eventstore.events2_archive WHERE instance_id = $1 AND aggregate_type = $2 AND aggregate_id = $3 AND EXISTS (SELECT 1 FROM restored) RETURNING *` +
+		`) INSERT INTO eventstore.events2 SELECT * FROM moved ON CONFLICT DO NOTHING`
+	// rehydrateInstanceStmt moves the events of all archived aggregates of an instance back to eventstore.events2.
+	rehydrateInstanceStmt = `WITH restored AS (` +
+		`DELETE FROM eventstore.archived_aggregates WHERE instance_id = $1 RETURNING aggregate_type, aggregate_id` +
+		`), moved AS (` +
+		`DELETE FROM eventstore.events2_archive a USING restored r WHERE a.instance_id = $1 AND a.aggregate_type = r.aggregate_type AND a.aggregate_id = r.aggregate_id RETURNING a.*` +
+		`) INSERT INTO eventstore.events2 SELECT * FROM moved ON CONFLICT DO NOTHING`
+)
+
+// ClosedAggregates returns the aggregates which can be archived.
+func ClosedAggregates(ctx context.Context, client *database.DB, config *ArchiveConfig, now time.Time, limit uint32) (_ []*ArchivedAggregate, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	aggregates := make([]*ArchivedAggregate, 0, limit)
+	err = client.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			aggregate := new(ArchivedAggregate)
+			if err := rows.Scan(&aggregate.InstanceID, &aggregate.AggregateType, &aggregate.AggregateID, &aggregate.Sequence); err != nil {
+				return err
+			}
+			aggregates = append(aggregates, aggregate)
+		}
+		return rows.Err()
+	}, closedAggregatesStmt, database.TextArray[string](config.ClosingEventTypes), now.Add(-config.Retention), limit)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "EVENT-ahy3O", "Errors.Internal")
+	}
+	return aggregates, nil
+}
+
+// ArchiveAggregates moves the events of the aggregates to the archive in a single transaction.
+// Aggregates which received events after the closing event are skipped.
+// It returns the amount of archived aggregates and events.
+func ArchiveAggregates(ctx context.Context, client *database.DB, aggregates []*ArchivedAggregate) (archivedAggregates, archivedEvents uint64, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	tx, err := client.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, zerrors.ThrowInternal(err, "EVENT-Oog6a", "Errors.Internal")
+	}
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback()
+			logging.OnError(rollbackErr).Debug("unable to rollback")
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	for _, aggregate := range aggregates {
+		var count uint64
+		err = tx.QueryRowContext(ctx, archiveAggregateStmt, aggregate.InstanceID, aggregate.AggregateType, aggregate.AggregateID, aggregate.Sequence).Scan(&count)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return 0, 0, zerrors.ThrowInternal(err, "EVENT-ieW4s", "Errors.Internal")
+		}
+		archivedAggregates++
+		archivedEvents += count
+	}
+	return archivedAggregates, archivedEvents, nil
+}
+
+// archivedCandidates returns the aggregates filtered by id, whose first filtered event is a closing event.
+// As archiving keeps the closing event, the previous events of these aggregates might be archived.
+// Other aggregates are not looked up in the archive, which saves a query per filter.
+func (es *Eventstore) archivedCandidates(searchQuery *SearchQueryBuilder, events []Event) []*ArchivedAggregate {
+	if !es.rehydrateArchived || len(es.closingEventTypes) == 0 || len(events) == 0 {
+		return nil
+	}
+	var aggregateIDs []string
+	for _, query := range searchQuery.GetQueries() {
+		aggregateIDs = append(aggregateIDs, query.GetAggregateIDs()...)
+	}
+	if len(aggregateIDs) == 0 {
+		return nil
+	}
+	first := make(map[ArchivedAggregate]Event)
+	for _, event := range events {
+		aggregate := event.Aggregate()
+		if !slices.Contains(aggregateIDs, aggregate.ID) {
+			continue
+		}
+		key := ArchivedAggregate{InstanceID: aggregate.InstanceID, AggregateType: aggregate.Type, AggregateID: aggregate.ID}
+		if previous, ok := first[key]; !ok || event.Sequence() < previous.Sequence() {
+			first[key] = event
+		}
+	}
+	var candidates []*ArchivedAggregate
+	for aggregate, event := range first {
+		if event.Sequence() > 1 && slices.Contains(es.closingEventTypes, event.Type()) {
+			candidates = append(candidates, &aggregate)
+		}
+	}
+	return candidates
+}
+
+// rehydrateQueried moves the archived aggregates filtered by id back to the events before they are filtered.
+// Unlike [Eventstore.archivedCandidates] it looks up the archive before the events are filtered,
+// so the events can be reduced one by one without keeping them in memory.
+func (es *Eventstore) rehydrateQueried(ctx context.Context, searchQuery *SearchQueryBuilder) (err error) {
+	if !es.rehydrateArchived || len(es.closingEventTypes) == 0 {
+		return nil
+	}
+	var aggregateIDs []string
+	for _, query := range searchQuery.GetQueries() {
+		aggregateIDs = append(aggregateIDs, query.GetAggregateIDs()...)
+	}
+	if len(aggregateIDs) == 0 {
+		return nil
+	}
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	instanceIDs := searchQuery.GetInstanceIDs()
+	if instanceID := searchQuery.GetInstanceID(); instanceID != nil {
+		instanceIDs = []string{*instanceID}
+	}
+	var aggregates []*ArchivedAggregate
+	err = es.querier.Client().QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			aggregate := new(ArchivedAggregate)
+			if err := rows.Scan(&aggregate.InstanceID, &aggregate.AggregateType, &aggregate.AggregateID); err != nil {
+				return err
+			}
+			aggregates = append(aggregates, aggregate)
+		}
+		return rows.Err()
+	}, archivedAggregatesStmt, database.TextArray[string](aggregateIDs), database.TextArray[string](instanceIDs))
+	if err != nil {
+		return zerrors.ThrowInternal(err, "EVENT-uP4ae", "Errors.Internal")
+	}
+	_, err = es.rehydrate(ctx, aggregates)
+	return err
+}
+
+// rehydrate moves the archived events of the aggregates back to the events.
+// It returns true if events were moved, so the filter must be repeated.
+func (es *Eventstore) rehydrate(ctx context.Context, aggregates []*ArchivedAggregate) (_ bool, err error) {
+	if len(aggregates) == 0 {
+		return false, nil
+	}
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	client := es.querier.Client()
+	var rehydrated bool
+	for _, aggregate := range aggregates {
+		result, err := client.ExecContext(ctx, rehydrateAggregateStmt, aggregate.InstanceID, aggregate.AggregateType, aggregate.AggregateID)
+		if err != nil {
+			return false, zerrors.ThrowInternal(err, "EVENT-ooPh4", "Errors.Internal")
+		}
+		if events, _ := result.RowsAffected(); events > 0 {
+			logging.WithFields("instance", aggregate.InstanceID, "aggregate_type", aggregate.AggregateType, "aggregate_id", aggregate.AggregateID, "events", events).Info("rehydrated archived aggregate")
+			rehydrated = true
+		}
+	}
+	return rehydrated, nil
+}
+
+// RehydrateInstance moves all archived aggregates of the instance back to the events.
+// Projections call it before they process the events of an instance from the beginning (new projections and rebuilds),
+// as they query events by type and would therefore miss archived events.
+func (es *Eventstore) RehydrateInstance(ctx context.Context, client database.ContextExecuter, instanceID string) (err error) {
+	if !es.rehydrateArchived {
+		return nil
+	}
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	result, err := client.ExecContext(ctx, rehydrateInstanceStmt, instanceID)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "EVENT-Ahch0", "Errors.Internal")
+	}
+	if rehydrated, _ := result.RowsAffected(); rehydrated > 0 {
+		logging.WithFields("instance", instanceID, "events", rehydrated).Info("rehydrated archived events of instance")
+	}
+	return nil
+}
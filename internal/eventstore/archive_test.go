@@ -0,0 +1,301 @@
+package eventstore
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+type archiveQuerier struct {
+	testQuerier
+	client   *database.DB
+	filtered int
+}
+
+func (q *archiveQuerier) Client() *database.DB {
+	return q.client
+}
+
+func (q *archiveQuerier) FilterToReducer(ctx context.Context, searchQuery *SearchQueryBuilder, reduce Reducer) error {
+	q.filtered++
+	return q.testQuerier.FilterToReducer(ctx, searchQuery, reduce)
+}
+
+func archiveTestEvent(eventType EventType, sequence uint64) Event {
+	return &BaseEvent{
+		EventType: eventType,
+		Agg:       &Aggregate{ID: "user1", Type: "user", InstanceID: "instance"},
+		Seq:       sequence,
+	}
+}
+
+func TestEventstore_rehydrate(t *testing.T) {
+	tests := []struct {
+		name         string
+		enabled      bool
+		query        *SearchQueryBuilder
+		events       []Event
+		rehydrate    bool
+		archived     bool
+		wantFiltered int
+	}{
+		{
+			name:    "disabled",
+			enabled: false,
+			query: NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance").
+				AddQuery().AggregateTypes("user").AggregateIDs("user1").Builder(),
+			events:       []Event{archiveTestEvent("user.removed", 5)},
+			wantFiltered: 1,
+		},
+		{
+			name:    "without aggregate ids",
+			enabled: true,
+			query: NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance").
+				AddQuery().AggregateTypes("user").Builder(),
+			events:       []Event{archiveTestEvent("user.removed", 5)},
+			wantFiltered: 1,
+		},
+		{
+			name:    "not closed",
+			enabled: true,
+			query: NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance").
+				AddQuery().AggregateTypes("user").AggregateIDs("user1").Builder(),
+			events:       []Event{archiveTestEvent("user.added", 1), archiveTestEvent("user.changed", 2)},
+			wantFiltered: 1,
+		},
+		{
+			name:    "closed, all events filtered",
+			enabled: true,
+			query: NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance").
+				AddQuery().AggregateTypes("user").AggregateIDs("user1").Builder(),
+			events:       []Event{archiveTestEvent("user.added", 1), archiveTestEvent("user.removed", 2)},
+			wantFiltered: 1,
+		},
+		{
+			name:    "closing event first, not archived",
+			enabled: true,
+			query: NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance").
+				AddQuery().AggregateTypes("user").AggregateIDs("user1").EventTypes("user.removed").Builder(),
+			events:       []Event{archiveTestEvent("user.removed", 5)},
+			rehydrate:    true,
+			wantFiltered: 1,
+		},
+		{
+			name:    "closing event first, archived",
+			enabled: true,
+			query: NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance").
+				AddQuery().AggregateTypes("user").AggregateIDs("user1").Builder(),
+			events:       []Event{archiveTestEvent("user.removed", 5)},
+			rehydrate:    true,
+			archived:     true,
+			wantFiltered: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			if tt.rehydrate {
+				var rehydrated int64
+				if tt.archived {
+					rehydrated = 4
+				}
+				mock.ExpectExec(regexp.QuoteMeta(rehydrateAggregateStmt)).
+					WithArgs("instance", "user", "user1").
+					WillReturnResult(sqlmock.NewResult(0, rehydrated))
+			}
+
+			querier := &archiveQuerier{
+				testQuerier: testQuerier{events: tt.events, t: t},
+				client:      &database.DB{DB: db},
+			}
+			es := NewEventstore(&Config{
+				Querier: querier,
+				Archive: ArchiveConfig{Enabled: tt.enabled, ClosingEventTypes: []string{"user.removed"}},
+			})
+			events, err := es.Filter(context.Background(), tt.query)
+			require.NoError(t, err)
+			assert.Len(t, events, len(tt.events))
+			assert.Equal(t, tt.wantFiltered, querier.filtered)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// batchReducer records the amount of events of each append.
+type batchReducer struct {
+	appends []int
+	reduced int
+}
+
+func (r *batchReducer) AppendEvents(events ...Event) {
+	r.appends = append(r.appends, len(events))
+}
+
+func (r *batchReducer) Reduce() error {
+	r.reduced++
+	return nil
+}
+
+func TestEventstore_FilterToReducer_archived(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    *SearchQueryBuilder
+		probe    bool
+		archived bool
+	}{
+		{
+			name: "without aggregate ids",
+			query: NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance").
+				AddQuery().AggregateTypes("user").Builder(),
+		},
+		{
+			name: "not archived",
+			query: NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance").
+				AddQuery().AggregateTypes("user").AggregateIDs("user1").Builder(),
+			probe: true,
+		},
+		{
+			name: "archived",
+			query: NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance").
+				AddQuery().AggregateTypes("user").AggregateIDs("user1").Builder(),
+			probe:    true,
+			archived: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			if tt.probe {
+				rows := sqlmock.NewRows([]string{"instance_id", "aggregate_type", "aggregate_id"})
+				if tt.archived {
+					rows.AddRow("instance", "user", "user1")
+				}
+				mock.ExpectQuery(regexp.QuoteMeta(archivedAggregatesStmt)).
+					WithArgs(database.TextArray[string]{"user1"}, database.TextArray[string]{"instance"}).
+					WillReturnRows(rows)
+			}
+			if tt.archived {
+				mock.ExpectExec(regexp.QuoteMeta(rehydrateAggregateStmt)).
+					WithArgs("instance", "user", "user1").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			}
+
+			querier := &archiveQuerier{
+				testQuerier: testQuerier{
+					events: []Event{
+						archiveTestEvent("user.added", 1),
+						archiveTestEvent("user.changed", 2),
+						archiveTestEvent("user.removed", 3),
+					},
+					t: t,
+				},
+				client: &database.DB{DB: db},
+			}
+			es := NewEventstore(&Config{
+				Querier: querier,
+				Archive: ArchiveConfig{Enabled: true, ClosingEventTypes: []string{"user.removed"}},
+			})
+			reducer := new(batchReducer)
+			require.NoError(t, es.FilterToReducer(context.Background(), tt.query, reducer))
+			assert.Equal(t, 1, querier.filtered)
+			assert.Equal(t, []int{1, 1, 1}, reducer.appends, "events must be reduced one by one")
+			assert.Equal(t, 3, reducer.reduced)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestEventstore_RehydrateInstance(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+	}{
+		{
+			name:    "disabled",
+			enabled: false,
+		},
+		{
+			name:    "enabled",
+			enabled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			if tt.enabled {
+				mock.ExpectExec(regexp.QuoteMeta(rehydrateInstanceStmt)).
+					WithArgs("instance").
+					WillReturnResult(sqlmock.NewResult(0, 3))
+			}
+
+			es := NewEventstore(&Config{
+				Archive: ArchiveConfig{Enabled: tt.enabled},
+			})
+			require.NoError(t, es.RehydrateInstance(context.Background(), db, "instance"))
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestClosedAggregates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(closedAggregatesStmt)).
+		WithArgs(database.TextArray[string]{"user.removed"}, now.Add(-24*time.Hour), 10).
+		WillReturnRows(sqlmock.NewRows([]string{"instance_id", "aggregate_type", "aggregate_id", "sequence"}).
+			AddRow("instance", "user", "user1", 5))
+
+	aggregates, err := ClosedAggregates(context.Background(), &database.DB{DB: db}, &ArchiveConfig{
+		Retention:         24 * time.Hour,
+		ClosingEventTypes: []string{"user.removed"},
+	}, now, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []*ArchivedAggregate{
+		{InstanceID: "instance", AggregateType: "user", AggregateID: "user1", Sequence: 5},
+	}, aggregates)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArchiveAggregates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(archiveAggregateStmt)).
+		WithArgs("instance", "user", "user1", 5).
+		WillReturnRows(sqlmock.NewRows([]string{"event_count"}).AddRow(5))
+	mock.ExpectQuery(regexp.QuoteMeta(archiveAggregateStmt)).
+		WithArgs("instance", "session", "session1", 3).
+		WillReturnRows(sqlmock.NewRows([]string{"event_count"}))
+	mock.ExpectCommit()
+
+	aggregates, events, err := ArchiveAggregates(context.Background(), &database.DB{DB: db}, []*ArchivedAggregate{
+		{InstanceID: "instance", AggregateType: "user", AggregateID: "user1", Sequence: 5},
+		// changed after it was closed
+		{InstanceID: "instance", AggregateType: "session", AggregateID: "session1", Sequence: 3},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), aggregates)
+	assert.Equal(t, uint64(5), events)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -7,6 +7,12 @@ import (
 type Config struct {
 	PushTimeout time.Duration
 	MaxRetries  uint32
+	// Notifications notify all nodes about pushed events
+	Notifications NotificationConfig
+	// Archive moves the events of closed aggregates out of the events table
+	Archive ArchiveConfig
+	// Snapshots restore write models from their latest snapshot
+	Snapshots SnapshotConfig
 
 	Pusher   Pusher
 	Querier  Querier
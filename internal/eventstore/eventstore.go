@@ -30,6 +30,13 @@ type Eventstore struct {
 	pusher   Pusher
 	querier  Querier
 	searcher Searcher
+
+	notifier *notifier
+	// rehydrateArchived moves archived aggregates back to the events after a filter returned their closing event
+	rehydrateArchived bool
+	closingEventTypes []EventType
+	// snapshots is set if write models are restored from snapshots
+	snapshots *SnapshotConfig
 }
 
 var (
@@ -63,14 +70,36 @@ type eventTypeInterceptors struct {
 }
 
 func NewEventstore(config *Config) *Eventstore {
-	return &Eventstore{
+	es := &Eventstore{
 		PushTimeout: config.PushTimeout,
 		maxRetries:  int(config.MaxRetries),
 
 		pusher:   config.Pusher,
 		querier:  config.Querier,
 		searcher: config.Searcher,
+
+		rehydrateArchived: config.Archive.Enabled,
+	}
+	for _, eventType := range config.Archive.ClosingEventTypes {
+		es.closingEventTypes = append(es.closingEventTypes, EventType(eventType))
+	}
+	if config.Snapshots.Enabled {
+		es.snapshots = &config.Snapshots
+	}
+	if config.Notifications.Enabled {
+		if pusher, ok := config.Pusher.(notificationPusher); ok {
+			pusher.EnablePushNotifications(config.Notifications.channel())
+			es.notifier = newNotifier(config.Notifications, es)
+		} else {
+			logging.Warn("eventstore notifications are not supported by the pusher")
+		}
 	}
+	return es
+}
+
+// notificationPusher is implemented by pushers which notify all nodes after the events are committed.
+type notificationPusher interface {
+	EnablePushNotifications(channel string)
 }
 
 // Health checks if the eventstore can properly work
@@ -168,8 +197,20 @@ func (es *Eventstore) Search(ctx context.Context, conditions ...map[FieldType]an
 //
 // Deprecated: Use [FilterToQueryReducer] instead to avoid allocations.
 func (es *Eventstore) Filter(ctx context.Context, searchQuery *SearchQueryBuilder) ([]Event, error) {
-	events := make([]Event, 0, searchQuery.GetLimit())
 	searchQuery.ensureInstanceID(ctx)
+	events, err := es.filter(ctx, searchQuery)
+	if err != nil {
+		return nil, err
+	}
+	rehydrated, err := es.rehydrate(ctx, es.archivedCandidates(searchQuery, events))
+	if err != nil || !rehydrated {
+		return events, err
+	}
+	return es.filter(ctx, searchQuery)
+}
+
+func (es *Eventstore) filter(ctx context.Context, searchQuery *SearchQueryBuilder) ([]Event, error) {
+	events := make([]Event, 0, searchQuery.GetLimit())
 	err := es.querier.FilterToReducer(ctx, searchQuery, func(event Event) error {
 		event, err := es.mapEvent(event)
 		if err != nil {
@@ -226,6 +267,10 @@ type reducer interface {
 // FilterToReducer filters the events based on the search query, appends all events to the reducer and calls it's reduce function
 func (es *Eventstore) FilterToReducer(ctx context.Context, searchQuery *SearchQueryBuilder, r reducer) error {
 	searchQuery.ensureInstanceID(ctx)
+	// archived events must be rehydrated before the first event is reduced
+	if err := es.rehydrateQueried(ctx, searchQuery); err != nil {
+		return err
+	}
 	return es.querier.FilterToReducer(ctx, searchQuery, func(event Event) error {
 		event, err := es.mapEvent(event)
 		if err != nil {
@@ -261,7 +306,11 @@ type QueryReducer interface {
 
 // FilterToQueryReducer filters the events based on the search query of the query function,
 // appends all events to the reducer and calls it's reduce function
+// Write models implementing [SnapshotWriteModel] are restored from their latest snapshot if snapshots are enabled.
 func (es *Eventstore) FilterToQueryReducer(ctx context.Context, r QueryReducer) error {
+	if model, ok := r.(SnapshotWriteModel); ok && es.snapshots != nil {
+		return es.filterWithSnapshot(ctx, model)
+	}
 	return es.FilterToReducer(ctx, r.Query(), r)
 }
 
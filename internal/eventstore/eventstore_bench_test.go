@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore"
 )
@@ -160,3 +161,87 @@ func Benchmark_Push_MultipleAggregate_Parallel(b *testing.B) {
 		}
 	}
 }
+
+type benchWriteModel struct {
+	eventstore.WriteModel
+
+	Count int
+}
+
+func (wm *benchWriteModel) Reduce() error {
+	wm.Count += len(wm.Events)
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *benchWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID("").
+		AddQuery().
+		AggregateTypes(eventstore.AggregateType(wm.AggregateID)).
+		AggregateIDs(wm.AggregateID).
+		Builder()
+}
+
+func (wm *benchWriteModel) SnapshotType() (string, uint16) {
+	return "bench", 1
+}
+
+const createSnapshotsTable = `CREATE TABLE IF NOT EXISTS eventstore.snapshots (
+	instance_id TEXT NOT NULL
+	, snapshot_type TEXT NOT NULL
+	, query_hash TEXT NOT NULL
+	, version INT2 NOT NULL
+	, "position" NUMERIC NOT NULL
+	, "offset" INT4 NOT NULL
+	, payload JSONB NOT NULL
+	, change_date TIMESTAMPTZ NOT NULL DEFAULT now()
+	, PRIMARY KEY (instance_id, snapshot_type, query_hash)
+)`
+
+func Benchmark_FilterToQueryReducer_Snapshot(b *testing.B) {
+	ctx := context.Background()
+	if _, err := testClient.Exec(createSnapshotsTable); err != nil {
+		b.Fatal(err)
+	}
+
+	for _, eventCount := range []int{100, 1000, 10000} {
+		aggregateID := "snapshot" + strconv.Itoa(eventCount)
+		cleanupEventstore(testClient)()
+		if _, err := testClient.Exec("TRUNCATE eventstore.snapshots"); err != nil {
+			b.Fatal(err)
+		}
+		for pushed := 0; pushed < eventCount; pushed += 100 {
+			cmds := make([]eventstore.Command, 100)
+			for i := range cmds {
+				cmds[i] = generateCommand(eventstore.AggregateType(aggregateID), aggregateID)
+			}
+			if _, err := pushers["v3(inmemory)"].Push(ctx, testClient.DB, cmds...); err != nil {
+				b.Fatal(err)
+			}
+		}
+
+		for _, snapshots := range []bool{false, true} {
+			es := eventstore.NewEventstore(&eventstore.Config{
+				Querier: queriers["v2(inmemory)"],
+				Pusher:  pushers["v3(inmemory)"],
+				Snapshots: eventstore.SnapshotConfig{
+					Enabled:   snapshots,
+					Threshold: 10,
+					// the events were just pushed
+					MinAge: time.Nanosecond,
+				},
+			})
+			b.Run(fmt.Sprintf("events-%d-snapshots-%t", eventCount, snapshots), func(b *testing.B) {
+				for n := 0; n < b.N; n++ {
+					wm := &benchWriteModel{WriteModel: eventstore.WriteModel{AggregateID: aggregateID}}
+					if err := es.FilterToQueryReducer(ctx, wm); err != nil {
+						b.Error(err)
+					}
+					if wm.Count != eventCount {
+						b.Errorf("expected %d events got %d", eventCount, wm.Count)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	//go:embed blocked_aggregate_get.sql
+	blockedAggregatesStmt string
+	//go:embed blocked_aggregate_set.sql
+	setBlockedAggregateStmt string
+	//go:embed blocked_aggregate_delete.sql
+	deleteBlockedAggregateStmt string
+	//go:embed blocked_aggregate_instances.sql
+	blockedInstancesStmt string
+)
+
+type blockedAggregateKey struct {
+	aggregateType eventstore.AggregateType
+	aggregateID   string
+}
+
+// blockedAggregate is an aggregate of a [BlockingProjection] with a failed statement.
+// The events of the aggregate are skipped until the failed statement succeeded,
+// the events of other aggregates are processed in the meantime.
+type blockedAggregate struct {
+	// sequence of the event of the failed statement
+	sequence uint64
+	// lastSequence of the events skipped because the aggregate is blocked
+	lastSequence uint64
+	retryAt      time.Time
+
+	changed  bool
+	resolved bool
+}
+
+// blockedAggregates of an instance, nil if the projection is not a [BlockingProjection].
+type blockedAggregates map[blockedAggregateKey]*blockedAggregate
+
+// skip returns if the statement is skipped because its aggregate is blocked.
+func (b blockedAggregates) skip(statement *Statement) bool {
+	blocked, ok := b[blockedAggregateKey{aggregateType: statement.Aggregate.Type, aggregateID: statement.Aggregate.ID}]
+	if !ok || blocked.resolved {
+		return false
+	}
+	if statement.Sequence > blocked.lastSequence {
+		blocked.lastSequence = statement.Sequence
+		blocked.changed = true
+	}
+	return true
+}
+
+// block blocks the aggregate of the failed statement until retryAt.
+func (b blockedAggregates) block(statement *Statement, retryAt time.Time) {
+	key := blockedAggregateKey{aggregateType: statement.Aggregate.Type, aggregateID: statement.Aggregate.ID}
+	blocked, ok := b[key]
+	if !ok || blocked.resolved {
+		blocked = &blockedAggregate{lastSequence: statement.Sequence}
+		b[key] = blocked
+	}
+	blocked.sequence = statement.Sequence
+	blocked.lastSequence = max(blocked.lastSequence, statement.Sequence)
+	blocked.retryAt = retryAt
+	blocked.changed = true
+}
+
+// blockedAggregates returns the blocked aggregates of the instance.
+func (h *Handler) blockedAggregates(ctx context.Context, tx *sql.Tx, instanceID string) (blockedAggregates, error) {
+	rows, err := tx.QueryContext(ctx, blockedAggregatesStmt, h.projection.Name(), instanceID)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Eib3u", "unable to query blocked aggregates")
+	}
+	defer rows.Close()
+	blocked := make(blockedAggregates)
+	for rows.Next() {
+		var (
+			key       blockedAggregateKey
+			aggregate = new(blockedAggregate)
+		)
+		if err = rows.Scan(&key.aggregateType, &key.aggregateID, &aggregate.sequence, &aggregate.lastSequence, &aggregate.retryAt); err != nil {
+			return nil, zerrors.ThrowInternal(err, "V2-ieT7a", "unable to scan blocked aggregate")
+		}
+		blocked[key] = aggregate
+	}
+	if err = rows.Err(); err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-ahY3o", "unable to query blocked aggregates")
+	}
+	return blocked, nil
+}
+
+// retryBlocked executes the statements of the skipped events of the blocked aggregates whose delay passed.
+// The aggregate is resolved if all statements succeeded, otherwise it is blocked by the failed statement.
+func (h *Handler) retryBlocked(ctx context.Context, tx *sql.Tx, instanceID string, blocked blockedAggregates) error {
+	for key, aggregate := range blocked {
+		if aggregate.retryAt.After(h.now()) {
+			continue
+		}
+		query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			InstanceID(instanceID).
+			OrderAsc().
+			SequenceGreater(aggregate.sequence - 1).
+			SetTx(tx).
+			AddQuery().
+			AggregateTypes(key.aggregateType).
+			AggregateIDs(key.aggregateID)
+		if eventTypes := h.eventTypes[key.aggregateType]; len(eventTypes) > 0 {
+			query = query.EventTypes(eventTypes...)
+		}
+		events, err := h.es.Filter(ctx, query.Builder())
+		if err != nil {
+			return err
+		}
+		// the aggregate is resolved unless a statement fails again
+		aggregate.resolved, aggregate.changed = true, true
+		for _, event := range events {
+			if event.Sequence() > aggregate.lastSequence {
+				break
+			}
+			if err = h.executeStatement(ctx, tx, h.blockingStatement(event), blocked); err != nil {
+				return err
+			}
+			if reblocked := blocked[key]; reblocked != aggregate {
+				// the skipped events after the failed statement stay blocked
+				reblocked.lastSequence = max(reblocked.lastSequence, aggregate.lastSequence)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// blockingStatement reduces the event, a failed reduce is handled like a failed statement.
+func (h *Handler) blockingStatement(event eventstore.Event) *Statement {
+	statement, err := h.reduce(event)
+	if err != nil {
+		h.logEvent(event).WithError(err).Error("reduce failed")
+		return failedReduceStatement(event, err)
+	}
+	return statement
+}
+
+// failedReduceStatement fails with the error of the reduce,
+// so it only blocks the aggregate of the event for a [BlockingProjection].
+func failedReduceStatement(event eventstore.Event, err error) *Statement {
+	return NewStatement(event, func(Executer, string) error {
+		return err
+	})
+}
+
+// storeBlocked stores the changes of the blocked aggregates of the instance.
+func (h *Handler) storeBlocked(ctx context.Context, tx *sql.Tx, instanceID string, blocked blockedAggregates) error {
+	for key, aggregate := range blocked {
+		if !aggregate.changed {
+			continue
+		}
+		var err error
+		if aggregate.resolved {
+			_, err = tx.ExecContext(ctx, deleteBlockedAggregateStmt, h.projection.Name(), instanceID, key.aggregateType, key.aggregateID)
+		} else {
+			_, err = tx.ExecContext(ctx, setBlockedAggregateStmt, h.projection.Name(), instanceID, key.aggregateType, key.aggregateID, aggregate.sequence, aggregate.lastSequence, aggregate.retryAt)
+		}
+		if err != nil {
+			return zerrors.ThrowInternal(err, "V2-Aim4o", "unable to store blocked aggregate")
+		}
+	}
+	return nil
+}
+
+// blockedInstances returns the instances with blocked aggregates which are due for a retry.
+func (h *Handler) blockedInstances(ctx context.Context) ([]string, error) {
+	var instances []string
+	err := h.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			var instance string
+			if err := rows.Scan(&instance); err != nil {
+				return err
+			}
+			instances = append(instances, instance)
+		}
+		return rows.Err()
+	}, blockedInstancesStmt, h.projection.Name())
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-Ohp8u", "unable to query blocked instances")
+	}
+	return instances, nil
+}
@@ -0,0 +1,8 @@
+DELETE FROM
+    projections.blocked_aggregates
+WHERE
+    projection_name = $1
+    AND instance_id = $2
+    AND aggregate_type = $3
+    AND aggregate_id = $4
+;
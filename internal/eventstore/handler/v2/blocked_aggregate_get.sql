@@ -0,0 +1,12 @@
+SELECT
+    aggregate_type
+    , aggregate_id
+    , "sequence"
+    , last_sequence
+    , retry_at
+FROM
+    projections.blocked_aggregates
+WHERE
+    projection_name = $1
+    AND instance_id = $2
+;
@@ -0,0 +1,8 @@
+SELECT DISTINCT
+    instance_id
+FROM
+    projections.blocked_aggregates
+WHERE
+    projection_name = $1
+    AND retry_at <= now()
+;
@@ -0,0 +1,26 @@
+INSERT INTO projections.blocked_aggregates (
+    projection_name
+    , instance_id
+    , aggregate_type
+    , aggregate_id
+    , "sequence"
+    , last_sequence
+    , retry_at
+) VALUES (
+    $1
+    , $2
+    , $3
+    , $4
+    , $5
+    , $6
+    , $7
+) ON CONFLICT (
+    projection_name
+    , instance_id
+    , aggregate_type
+    , aggregate_id
+) DO UPDATE SET
+    "sequence" = EXCLUDED."sequence"
+    , last_sequence = EXCLUDED.last_sequence
+    , retry_at = EXCLUDED.retry_at
+;
@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+type blockedTestEventstore struct {
+	EventStore
+	events  []eventstore.Event
+	queries []*eventstore.SearchQueryBuilder
+}
+
+func (es *blockedTestEventstore) Filter(_ context.Context, query *eventstore.SearchQueryBuilder) ([]eventstore.Event, error) {
+	es.queries = append(es.queries, query)
+	return es.events, nil
+}
+
+func blockedTestEvent(aggregateID string, sequence uint64) eventstore.Event {
+	return &eventstore.BaseEvent{
+		EventType: "test.event",
+		Agg:       &eventstore.Aggregate{ID: aggregateID, Type: "test", InstanceID: "instance"},
+		Seq:       sequence,
+	}
+}
+
+// blockedTestStatement fails if err is set and records its execution.
+func blockedTestStatement(aggregateID string, sequence uint64, err error, executed *[]string) *Statement {
+	return NewStatement(blockedTestEvent(aggregateID, sequence), func(Executer, string) error {
+		*executed = append(*executed, aggregateID)
+		return err
+	})
+}
+
+func expectFailedStatement(sequence uint64, aggregateID string, count uint8) []mock.Expectation {
+	return []mock.Expectation{
+		mock.ExcpectExec("SAVEPOINT exec_stmt", mock.WithExecNoRowsAffected()),
+		mock.ExcpectExec("ROLLBACK TO SAVEPOINT exec_stmt", mock.WithExecNoRowsAffected()),
+		mock.ExpectQuery(
+			failureCountStmt,
+			mock.WithQueryArgs("projection", "instance", eventstore.AggregateType("test"), aggregateID, sequence),
+			mock.WithQueryResult([]string{"failure_count"}, [][]driver.Value{{count - 1}}),
+		),
+		mock.ExcpectExec(setFailedEventStmt, mock.WithExecRowsAffected(1)),
+	}
+}
+
+func newBlockingTestHandler(es EventStore) *Handler {
+	blocking := &blockingProjection{
+		projection: projection{name: "projection"},
+		permanent:  errPermanent,
+	}
+	return &Handler{
+		projection:      blocking,
+		blocking:        blocking,
+		es:              es,
+		maxFailureCount: 3,
+		now: func() time.Time {
+			return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		},
+	}
+}
+
+var errPermanent = errors.New("permanent")
+
+func TestHandler_executeStatements_blocked(t *testing.T) {
+	var executed []string
+	expectations := expectFailedStatement(1, "a", 1)
+	expectations = append(expectations, mock.ExcpectExec("SAVEPOINT exec_stmt", mock.WithExecNoRowsAffected()))
+	sqlMock := mock.NewSQLMock(t, append([]mock.Expectation{mock.ExpectBegin(nil)}, expectations...)...)
+	tx, err := sqlMock.DB.BeginTx(context.Background(), nil)
+	require.NoError(t, err)
+
+	h := newBlockingTestHandler(nil)
+	blocked := make(blockedAggregates)
+	lastProcessedIndex, err := h.executeStatements(context.Background(), tx, []*Statement{
+		blockedTestStatement("a", 1, errors.New("unavailable"), &executed),
+		blockedTestStatement("b", 1, nil, &executed),
+		blockedTestStatement("a", 2, nil, &executed),
+	}, blocked)
+	require.NoError(t, err)
+	assert.Equal(t, 2, lastProcessedIndex, "the events of other aggregates are processed")
+	assert.Equal(t, []string{"a", "b"}, executed, "the following events of the failed aggregate are skipped")
+	assert.Equal(t, blockedAggregates{
+		{aggregateType: "test", aggregateID: "a"}: {
+			sequence:     1,
+			lastSequence: 2,
+			retryAt:      h.now().Add(time.Second),
+			changed:      true,
+		},
+	}, blocked)
+	sqlMock.Assert(t)
+}
+
+func TestHandler_executeStatements_permanent(t *testing.T) {
+	var executed []string
+	expectations := expectFailedStatement(1, "a", 3)
+	expectations = append(expectations, mock.ExcpectExec("SAVEPOINT exec_stmt", mock.WithExecNoRowsAffected()))
+	sqlMock := mock.NewSQLMock(t, append([]mock.Expectation{mock.ExpectBegin(nil)}, expectations...)...)
+	tx, err := sqlMock.DB.BeginTx(context.Background(), nil)
+	require.NoError(t, err)
+
+	h := newBlockingTestHandler(nil)
+	blocked := make(blockedAggregates)
+	_, err = h.executeStatements(context.Background(), tx, []*Statement{
+		blockedTestStatement("a", 1, errPermanent, &executed),
+		blockedTestStatement("a", 2, nil, &executed),
+	}, blocked)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "a"}, executed, "the permanently failed event is skipped after the max failure count")
+	assert.Empty(t, blocked)
+	sqlMock.Assert(t)
+}
+
+func TestHandler_retryBlocked(t *testing.T) {
+	tests := []struct {
+		name         string
+		aggregate    *blockedAggregate
+		events       []eventstore.Event
+		failSequence uint64
+		expect       []mock.Expectation
+		wantExecuted []uint64
+		want         *blockedAggregate
+	}{
+		{
+			name: "not due",
+			aggregate: &blockedAggregate{
+				sequence:     2,
+				lastSequence: 3,
+				retryAt:      time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC),
+			},
+			want: &blockedAggregate{
+				sequence:     2,
+				lastSequence: 3,
+				retryAt:      time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "resolved",
+			aggregate: &blockedAggregate{
+				sequence:     2,
+				lastSequence: 3,
+			},
+			events: []eventstore.Event{
+				blockedTestEvent("a", 2),
+				blockedTestEvent("a", 3),
+				// not skipped yet, processed by the following statements
+				blockedTestEvent("a", 4),
+			},
+			expect: []mock.Expectation{
+				mock.ExcpectExec("SAVEPOINT exec_stmt", mock.WithExecNoRowsAffected()),
+				mock.ExcpectExec("SAVEPOINT exec_stmt", mock.WithExecNoRowsAffected()),
+			},
+			wantExecuted: []uint64{2, 3},
+			want: &blockedAggregate{
+				sequence:     2,
+				lastSequence: 3,
+				changed:      true,
+				resolved:     true,
+			},
+		},
+		{
+			name: "failed again",
+			aggregate: &blockedAggregate{
+				sequence:     2,
+				lastSequence: 4,
+			},
+			events: []eventstore.Event{
+				blockedTestEvent("a", 2),
+				blockedTestEvent("a", 3),
+				blockedTestEvent("a", 4),
+			},
+			failSequence: 3,
+			expect: append(
+				[]mock.Expectation{mock.ExcpectExec("SAVEPOINT exec_stmt", mock.WithExecNoRowsAffected())},
+				expectFailedStatement(3, "a", 2)...,
+			),
+			wantExecuted: []uint64{2, 3},
+			want: &blockedAggregate{
+				sequence:     3,
+				lastSequence: 4,
+				retryAt:      time.Date(2024, 1, 1, 0, 0, 2, 0, time.UTC),
+				changed:      true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlMock := mock.NewSQLMock(t, append([]mock.Expectation{mock.ExpectBegin(nil)}, tt.expect...)...)
+			tx, err := sqlMock.DB.BeginTx(context.Background(), nil)
+			require.NoError(t, err)
+
+			es := &blockedTestEventstore{events: tt.events}
+			h := newBlockingTestHandler(es)
+			var executed []uint64
+			h.projection = &blockingProjection{
+				projection: projection{
+					name: "projection",
+					reducers: []AggregateReducer{{
+						Aggregate: "test",
+						EventReducers: []EventReducer{{
+							Event: "test.event",
+							Reduce: func(event eventstore.Event) (*Statement, error) {
+								return NewStatement(event, func(Executer, string) error {
+									executed = append(executed, event.Sequence())
+									if event.Sequence() == tt.failSequence {
+										return errors.New("unavailable")
+									}
+									return nil
+								}), nil
+							},
+						}},
+					}},
+				},
+			}
+			key := blockedAggregateKey{aggregateType: "test", aggregateID: "a"}
+			blocked := blockedAggregates{key: tt.aggregate}
+			require.NoError(t, h.retryBlocked(context.Background(), tx, "instance", blocked))
+			assert.Equal(t, tt.wantExecuted, executed)
+			assert.Equal(t, tt.want, blocked[key])
+			if len(tt.events) > 0 {
+				require.Len(t, es.queries, 1)
+				assert.Equal(t, uint64(1), es.queries[0].GetEventSequenceGreater())
+			}
+			sqlMock.Assert(t)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"database/sql"
 	_ "embed"
+	"math"
 	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -47,17 +48,30 @@ func failureFromStatement(statement *Statement, err error) *failure {
 	}
 }
 
-func (h *Handler) handleFailedStmt(tx *sql.Tx, f *failure) (shouldContinue bool) {
+// handleFailedStmt increments the failure count of the failed statement.
+// The statement is skipped as soon as it reached the max failure count.
+// Statements of a [BlockingProjection] are only skipped for permanent errors,
+// otherwise the statement is retried after the returned delay.
+func (h *Handler) handleFailedStmt(tx *sql.Tx, f *failure) (shouldContinue bool, retryAfter time.Duration) {
 	failureCount, err := h.failureCount(tx, f)
 	if err != nil {
 		h.logFailure(f).WithError(err).Warn("unable to get failure count")
-		return false
+		return false, 0
+	}
+	if failureCount < math.MaxUint8 {
+		failureCount += 1
 	}
-	failureCount += 1
 	err = h.setFailureCount(tx, failureCount, f)
 	h.logFailure(f).OnError(err).Warn("unable to update failure count")
 
-	return failureCount >= h.maxFailureCount
+	if h.blocking == nil {
+		return failureCount >= h.maxFailureCount, 0
+	}
+	if h.blocking.IsPermanent(f.err) && failureCount >= h.maxFailureCount {
+		h.logFailure(f).WithError(f.err).Error("event failed permanently and is skipped")
+		return true, 0
+	}
+	return false, h.blocking.RetryDelay(failureCount)
 }
 
 func (h *Handler) failureCount(tx *sql.Tx, f *failure) (count uint8, err error) {
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func TestHandler_handleFailedStmt(t *testing.T) {
+	failedAt := time.Now()
+	f := &failure{
+		sequence:      5,
+		instance:      "instance",
+		aggregateID:   "aggregate",
+		aggregateType: "type",
+		eventDate:     failedAt,
+		err:           errors.New("failed"),
+	}
+	expectFailure := func(previousCount, count uint8) *mock.SQLMock {
+		return mock.NewSQLMock(t,
+			mock.ExpectBegin(nil),
+			mock.ExpectQuery(
+				failureCountStmt,
+				mock.WithQueryArgs("projection", "instance", eventstore.AggregateType("type"), "aggregate", uint64(5)),
+				mock.WithQueryResult([]string{"failure_count"}, [][]driver.Value{{previousCount}}),
+			),
+			mock.ExcpectExec(
+				setFailedEventStmt,
+				mock.WithExecArgs("projection", "instance", eventstore.AggregateType("type"), "aggregate", failedAt, uint64(5), count, "failed"),
+				mock.WithExecRowsAffected(1),
+			),
+		)
+	}
+	permanentErr := errors.New("failed")
+	tests := []struct {
+		name               string
+		mock               *mock.SQLMock
+		err                error
+		blocking           bool
+		wantShouldContinue bool
+		wantRetryAfter     time.Duration
+	}{
+		{
+			name: "below max failure count, retry",
+			mock: expectFailure(1, 2),
+		},
+		{
+			name:               "max failure count reached, skip",
+			mock:               expectFailure(2, 3),
+			wantShouldContinue: true,
+		},
+		{
+			name:           "blocking projection, max failure count reached, retry after delay",
+			mock:           expectFailure(2, 3),
+			blocking:       true,
+			wantRetryAfter: 3 * time.Second,
+		},
+		{
+			name:           "blocking projection, failure count does not overflow",
+			mock:           expectFailure(math.MaxUint8, math.MaxUint8),
+			blocking:       true,
+			wantRetryAfter: math.MaxUint8 * time.Second,
+		},
+		{
+			name:           "blocking projection, permanent error below max failure count, retry after delay",
+			mock:           expectFailure(1, 2),
+			err:            permanentErr,
+			blocking:       true,
+			wantRetryAfter: 2 * time.Second,
+		},
+		{
+			name:               "blocking projection, permanent error reached max failure count, skip",
+			mock:               expectFailure(2, 3),
+			err:                permanentErr,
+			blocking:           true,
+			wantShouldContinue: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				projection:      &projection{name: "projection"},
+				maxFailureCount: 3,
+			}
+			if tt.blocking {
+				h.blocking = &blockingProjection{projection: projection{name: "projection"}, permanent: permanentErr}
+			}
+			f := *f
+			if tt.err != nil {
+				f.err = tt.err
+			}
+			tx, err := tt.mock.DB.BeginTx(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("unable to begin transaction: %v", err)
+			}
+
+			shouldContinue, retryAfter := h.handleFailedStmt(tx, &f)
+			if shouldContinue != tt.wantShouldContinue {
+				t.Errorf("unexpected shouldContinue, want: %v got: %v", tt.wantShouldContinue, shouldContinue)
+			}
+			if retryAfter != tt.wantRetryAfter {
+				t.Errorf("unexpected retryAfter, want: %v got: %v", tt.wantRetryAfter, retryAfter)
+			}
+
+			tt.mock.Assert(t)
+		})
+	}
+}
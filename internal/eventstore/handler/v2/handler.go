@@ -45,6 +45,11 @@ type Config struct {
 	ActiveInstancer interface {
 		ActiveInstances() []string
 	}
+
+	// ConcurrentInstances is the amount of instances triggered in parallel, defaults to 1
+	ConcurrentInstances uint
+	// Sharding restricts the scheduled instances to the shards leased by the node
+	Sharding Sharding
 }
 
 type Handler struct {
@@ -57,6 +62,7 @@ type Handler struct {
 
 	maxFailureCount  uint8
 	retryFailedAfter time.Duration
+	blocking         BlockingProjection
 	requeueEvery     time.Duration
 	txDuration       time.Duration
 	now              nowFunc
@@ -69,6 +75,10 @@ type Handler struct {
 
 	queryInstances func() ([]string, error)
 
+	concurrentInstances uint
+	shards              *shardLeases
+	queue               *instanceQueue
+
 	metrics *ProjectionMetrics
 }
 
@@ -149,6 +159,18 @@ type GlobalProjection interface {
 	FilterGlobalEvents()
 }
 
+// BlockingProjection keeps the order of the events per aggregate.
+// A failed statement blocks the following events of its aggregate and is retried
+// after the delay returned by RetryDelay for the count of its failures,
+// the events of other aggregates are processed in the meantime.
+// Only statements failing with a permanent error are skipped after [Config.MaxFailureCount].
+type BlockingProjection interface {
+	Projection
+	RetryDelay(failureCount uint8) time.Duration
+	// IsPermanent returns if the error of a statement is not resolved by retries.
+	IsPermanent(err error) bool
+}
+
 func NewHandler(
 	ctx context.Context,
 	config *Config,
@@ -169,6 +191,11 @@ func NewHandler(
 
 	metrics := NewProjectionMetrics()
 
+	concurrentInstances := config.ConcurrentInstances
+	if concurrentInstances == 0 {
+		concurrentInstances = 1
+	}
+
 	handler := &Handler{
 		projection:             projection,
 		client:                 config.Client,
@@ -188,24 +215,47 @@ func NewHandler(
 			}
 			return nil, nil
 		},
-		metrics: metrics,
+		concurrentInstances: concurrentInstances,
+		shards:              newShardLeases(config.Sharding, config.RequeueEvery),
+		metrics:             metrics,
 	}
 
 	if _, ok := projection.(GlobalProjection); ok {
 		handler.queryGlobal = true
 	}
+	if blocking, ok := projection.(BlockingProjection); ok {
+		handler.blocking = blocking
+	}
 
 	return handler
 }
 
 func (h *Handler) Start(ctx context.Context) {
+	h.queue = newInstanceQueue(h.concurrentInstances)
+	for range h.concurrentInstances {
+		go h.work(ctx)
+	}
 	go h.schedule(ctx)
 	if h.triggerWithoutEvents != nil {
 		return
 	}
+	if subscriber, ok := h.es.(notificationSubscriber); ok {
+		// events pushed after the current position are caught up if the listener reconnects
+		position, err := h.currentPosition(ctx)
+		h.log().OnError(err).Warn("unable to query current position for the notification subscription")
+		if subscription := subscriber.SubscribeNotifications(ctx, h.eventTypes, position); subscription != nil {
+			go h.subscribeNotifications(ctx, subscription)
+			return
+		}
+	}
 	go h.subscribe(ctx)
 }
 
+// notificationSubscriber is implemented by an [EventStore] which notifies about events pushed by all nodes.
+type notificationSubscriber interface {
+	SubscribeNotifications(ctx context.Context, types map[eventstore.AggregateType][]eventstore.EventType, position decimal.Decimal) *eventstore.NotificationSubscription
+}
+
 type checkInit struct {
 	didInit        bool
 	projectionName string
@@ -265,8 +315,18 @@ func (h *Handler) schedule(ctx context.Context) {
 		case <-t.C:
 			instances, err := h.queryInstances()
 			h.log().OnError(err).Debug("unable to query instances")
+			if h.blocking != nil {
+				// blocked aggregates are retried even if the instance is not active
+				blocked, err := h.blockedInstances(ctx)
+				h.log().OnError(err).Debug("unable to query instances with blocked aggregates")
+				instances = append(instances, blocked...)
+			}
 
-			h.triggerInstances(call.WithTimestamp(ctx), instances)
+			err = h.shards.renew(ctx, h.client, h.ProjectionName())
+			h.log().OnError(err).Warn("unable to renew shard leases")
+			for _, instance := range h.shards.filter(instances) {
+				h.enqueue(ctx, instance, laneRegular)
+			}
 			t.Reset(h.requeueEvery)
 		}
 	}
@@ -309,22 +369,33 @@ func (h *Handler) subscribe(ctx context.Context) {
 		case event := <-queue:
 			events := checkAdditionalEvents(queue, event)
 			solvedInstances := make([]string, 0, len(events))
-			queueCtx := call.WithTimestamp(ctx)
 			for _, e := range events {
 				if instanceSolved(solvedInstances, e.Aggregate().InstanceID) {
 					continue
 				}
-				queueCtx = authz.WithInstanceID(queueCtx, e.Aggregate().InstanceID)
-				_, err := h.Trigger(queueCtx)
-				h.log().OnError(err).Debug("trigger of queued event failed")
-				if err == nil {
-					solvedInstances = append(solvedInstances, e.Aggregate().InstanceID)
-				}
+				// the events were pushed by this node, so the instance is triggered regardless of its shard
+				h.enqueue(ctx, e.Aggregate().InstanceID, lanePriority)
+				solvedInstances = append(solvedInstances, e.Aggregate().InstanceID)
 			}
 		}
 	}
 }
 
+// subscribeNotifications queues the instances of the shard with events pushed by any node in the priority lane.
+func (h *Handler) subscribeNotifications(ctx context.Context, subscription *eventstore.NotificationSubscription) {
+	defer subscription.Unsubscribe()
+	for {
+		instances, err := subscription.Next(ctx)
+		if err != nil {
+			h.log().Debug("shutdown")
+			return
+		}
+		for _, instance := range h.shards.filter(instances) {
+			h.enqueue(ctx, instance, lanePriority)
+		}
+	}
+}
+
 func instanceSolved(solvedInstances []string, instanceID string) bool {
 	for _, solvedInstance := range solvedInstances {
 		if solvedInstance == instanceID {
@@ -536,6 +607,16 @@ func (h *Handler) processEvents(ctx context.Context, config *triggerConfig) (add
 		currentState.offset = 0
 	}
 
+	var blocked blockedAggregates
+	if h.blocking != nil {
+		if blocked, err = h.blockedAggregates(ctx, tx, currentState.instanceID); err != nil {
+			return false, err
+		}
+		if err = h.retryBlocked(ctx, tx, currentState.instanceID, blocked); err != nil {
+			return false, err
+		}
+	}
+
 	var statements []*Statement
 	statements, additionalIteration, err = h.generateStatements(ctx, tx, currentState)
 	if err != nil {
@@ -553,22 +634,28 @@ func (h *Handler) processEvents(ctx context.Context, config *triggerConfig) (add
 		if err == nil && currentState.aggregateID != "" && len(statements) > 0 {
 			// Don't update projection timing or latency unless we successfully processed events
 			h.metrics.ProjectionUpdateTiming(ctx, h.ProjectionName(), float64(time.Since(start).Seconds()))
-			h.metrics.ProjectionStateLatency(ctx, h.ProjectionName(), time.Since(currentState.eventTimestamp).Seconds())
+			h.metrics.ProjectionStateLatency(ctx, h.ProjectionName(), currentState.instanceID, time.Since(currentState.eventTimestamp).Seconds())
 
 			h.invalidateCaches(ctx, aggregatesFromStatements(statements))
 		}
 	}()
 
 	if len(statements) == 0 {
+		if err = h.storeBlocked(ctx, tx, currentState.instanceID, blocked); err != nil {
+			return false, err
+		}
 		err = h.setState(tx, currentState)
 		return additionalIteration, err
 	}
 
-	lastProcessedIndex, err := h.executeStatements(ctx, tx, statements)
+	lastProcessedIndex, err := h.executeStatements(ctx, tx, statements, blocked)
 	h.log().OnError(err).WithField("lastProcessedIndex", lastProcessedIndex).Debug("execution of statements failed")
 	if lastProcessedIndex < 0 {
 		return false, err
 	}
+	if storeErr := h.storeBlocked(ctx, tx, currentState.instanceID, blocked); storeErr != nil {
+		return false, storeErr
+	}
 
 	currentState.position = statements[lastProcessedIndex].Position
 	currentState.offset = statements[lastProcessedIndex].offset
@@ -640,7 +727,7 @@ func skipPreviouslyReducedStatements(statements []*Statement, currentState *stat
 	return -1
 }
 
-func (h *Handler) executeStatements(ctx context.Context, tx *sql.Tx, statements []*Statement) (lastProcessedIndex int, err error) {
+func (h *Handler) executeStatements(ctx context.Context, tx *sql.Tx, statements []*Statement, blocked blockedAggregates) (lastProcessedIndex int, err error) {
 	lastProcessedIndex = -1
 
 	for i, statement := range statements {
@@ -648,7 +735,11 @@ func (h *Handler) executeStatements(ctx context.Context, tx *sql.Tx, statements
 		case <-ctx.Done():
 			break
 		default:
-			err := h.executeStatement(ctx, tx, statement)
+			if blocked.skip(statement) {
+				lastProcessedIndex = i
+				continue
+			}
+			err := h.executeStatement(ctx, tx, statement, blocked)
 			if err != nil {
 				return lastProcessedIndex, err
 			}
@@ -658,7 +749,9 @@ func (h *Handler) executeStatements(ctx context.Context, tx *sql.Tx, statements
 	return lastProcessedIndex, nil
 }
 
-func (h *Handler) executeStatement(ctx context.Context, tx *sql.Tx, statement *Statement) (err error) {
+// executeStatement executes the statement in a savepoint.
+// A failed statement of a [BlockingProjection] blocks its aggregate instead of returning the error.
+func (h *Handler) executeStatement(ctx context.Context, tx *sql.Tx, statement *Statement, blocked blockedAggregates) (err error) {
 	if statement.Execute == nil {
 		return nil
 	}
@@ -675,10 +768,14 @@ func (h *Handler) executeStatement(ctx context.Context, tx *sql.Tx, statement *S
 		_, rollbackErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT exec_stmt")
 		h.log().OnError(rollbackErr).Error("rollback to savepoint failed")
 
-		shouldContinue := h.handleFailedStmt(tx, failureFromStatement(statement, err))
+		shouldContinue, retryAfter := h.handleFailedStmt(tx, failureFromStatement(statement, err))
 		if shouldContinue {
 			return nil
 		}
+		if blocked != nil {
+			blocked.block(statement, h.now().Add(retryAfter))
+			return nil
+		}
 
 		return &executionError{parent: err}
 	}
@@ -12,10 +12,14 @@ import (
 const (
 	ProjectionLabel = "projection"
 	SuccessLabel    = "success"
+	InstanceLabel   = "instance"
+	LaneLabel       = "lane"
 
 	ProjectionEventsProcessed    = "projection_events_processed"
 	ProjectionHandleTimerMetric  = "projection_handle_timer"
 	ProjectionStateLatencyMetric = "projection_state_latency"
+	ProjectionQueueWaitMetric    = "projection_queue_wait"
+	ProjectionQueueFullMetric    = "projection_queue_full"
 )
 
 type ProjectionMetrics struct {
@@ -44,6 +48,18 @@ func NewProjectionMetrics() *ProjectionMetrics {
 		[]float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 600, 1800},
 	)
 	logging.OnError(err).Error("failed to register projection state latency metric")
+	err = projectionMetrics.provider.RegisterHistogram(
+		ProjectionQueueWaitMetric,
+		"Time an instance waited in the queue until its projection was triggered",
+		"s",
+		[]float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60, 300},
+	)
+	logging.OnError(err).Error("failed to register projection queue wait metric")
+	err = projectionMetrics.provider.RegisterCounter(
+		ProjectionQueueFullMetric,
+		"Number of instances which had to wait for a free place in the queue",
+	)
+	logging.OnError(err).Error("failed to register projection queue full counter")
 	return projectionMetrics
 }
 
@@ -62,9 +78,26 @@ func (m *ProjectionMetrics) ProjectionEventsProcessed(ctx context.Context, proje
 	logging.OnError(err).Error("failed to add projection events processed metric")
 }
 
-func (m *ProjectionMetrics) ProjectionStateLatency(ctx context.Context, projection string, latency float64) {
+func (m *ProjectionMetrics) ProjectionStateLatency(ctx context.Context, projection, instance string, latency float64) {
 	err := m.provider.AddHistogramMeasurement(ctx, ProjectionStateLatencyMetric, latency, map[string]attribute.Value{
 		ProjectionLabel: attribute.StringValue(projection),
+		InstanceLabel:   attribute.StringValue(instance),
 	})
 	logging.OnError(err).Error("failed to add projection state latency metric")
 }
+
+func (m *ProjectionMetrics) ProjectionQueueWait(ctx context.Context, projection string, lane queueLane, wait float64) {
+	err := m.provider.AddHistogramMeasurement(ctx, ProjectionQueueWaitMetric, wait, map[string]attribute.Value{
+		ProjectionLabel: attribute.StringValue(projection),
+		LaneLabel:       attribute.StringValue(string(lane)),
+	})
+	logging.OnError(err).Error("failed to add projection queue wait metric")
+}
+
+func (m *ProjectionMetrics) ProjectionQueueFull(ctx context.Context, projection string, lane queueLane) {
+	err := m.provider.AddCount(ctx, ProjectionQueueFullMetric, 1, map[string]attribute.Value{
+		ProjectionLabel: attribute.StringValue(projection),
+		LaneLabel:       attribute.StringValue(string(lane)),
+	})
+	logging.OnError(err).Error("failed to add projection queue full metric")
+}
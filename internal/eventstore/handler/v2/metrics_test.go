@@ -73,7 +73,7 @@ func TestProjectionMetrics_ProjectionStateLatency(t *testing.T) {
 	projection := "test_projection"
 	latency := 10.0
 
-	projectionMetrics.ProjectionStateLatency(ctx, projection, latency)
+	projectionMetrics.ProjectionStateLatency(ctx, projection, "instance", latency)
 
 	values := mockMetrics.GetHistogramValues(ProjectionStateLatencyMetric)
 	require.Len(t, values, 1)
@@ -82,6 +82,49 @@ func TestProjectionMetrics_ProjectionStateLatency(t *testing.T) {
 	labels := mockMetrics.GetHistogramLabels(ProjectionStateLatencyMetric)
 	require.Len(t, labels, 1)
 	assert.Equal(t, projection, labels[0][ProjectionLabel].AsString())
+	assert.Equal(t, "instance", labels[0][InstanceLabel].AsString())
+}
+
+func TestProjectionMetrics_ProjectionQueueWait(t *testing.T) {
+
+	mockMetrics := metrics.NewMockMetrics()
+	metrics.M = mockMetrics
+	projectionMetrics := NewProjectionMetrics()
+
+	ctx := context.Background()
+	projection := "test_projection"
+	wait := 2.0
+
+	projectionMetrics.ProjectionQueueWait(ctx, projection, lanePriority, wait)
+
+	values := mockMetrics.GetHistogramValues(ProjectionQueueWaitMetric)
+	require.Len(t, values, 1)
+	assert.Equal(t, wait, values[0])
+
+	labels := mockMetrics.GetHistogramLabels(ProjectionQueueWaitMetric)
+	require.Len(t, labels, 1)
+	assert.Equal(t, projection, labels[0][ProjectionLabel].AsString())
+	assert.Equal(t, string(lanePriority), labels[0][LaneLabel].AsString())
+}
+
+func TestProjectionMetrics_ProjectionQueueFull(t *testing.T) {
+
+	mockMetrics := metrics.NewMockMetrics()
+	metrics.M = mockMetrics
+	projectionMetrics := NewProjectionMetrics()
+
+	ctx := context.Background()
+	projection := "test_projection"
+
+	projectionMetrics.ProjectionQueueFull(ctx, projection, laneRegular)
+	projectionMetrics.ProjectionQueueFull(ctx, projection, laneRegular)
+
+	assert.Equal(t, int64(2), mockMetrics.GetCounterValue(ProjectionQueueFullMetric))
+
+	labels := mockMetrics.GetCounterLabels(ProjectionQueueFullMetric)
+	require.Len(t, labels, 2)
+	assert.Equal(t, projection, labels[0][ProjectionLabel].AsString())
+	assert.Equal(t, string(laneRegular), labels[0][LaneLabel].AsString())
 }
 
 func TestProjectionMetrics_Integration(t *testing.T) {
@@ -102,7 +145,7 @@ func TestProjectionMetrics_Integration(t *testing.T) {
 	projectionMetrics.ProjectionUpdateTiming(ctx, projection, duration)
 
 	latency := 5.0
-	projectionMetrics.ProjectionStateLatency(ctx, projection, latency)
+	projectionMetrics.ProjectionStateLatency(ctx, projection, "instance", latency)
 
 	value := mockMetrics.GetCounterValue(ProjectionEventsProcessed)
 	assert.Equal(t, int64(4), value)
@@ -1,5 +1,10 @@
 package handler
 
+import (
+	"errors"
+	"time"
+)
+
 var _ Projection = (*projection)(nil)
 
 type projection struct {
@@ -21,3 +26,20 @@ func (p *projection) Name() string {
 func (p *projection) Reducers() []AggregateReducer {
 	return p.reducers
 }
+
+var _ BlockingProjection = (*blockingProjection)(nil)
+
+type blockingProjection struct {
+	projection
+	permanent error
+}
+
+// RetryDelay implements [BlockingProjection]
+func (p *blockingProjection) RetryDelay(failureCount uint8) time.Duration {
+	return time.Duration(failureCount) * time.Second
+}
+
+// IsPermanent implements [BlockingProjection]
+func (p *blockingProjection) IsPermanent(err error) bool {
+	return errors.Is(err, p.permanent)
+}
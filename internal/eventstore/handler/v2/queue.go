@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/call"
+)
+
+// Sharding distributes the scheduled instances across the nodes by the hash of the instance id.
+// Each node leases its shard, shards without live owner are taken over by the other nodes.
+type Sharding struct {
+	// Count is the amount of shards, sharding is disabled if it's lower than 2
+	Count uint32
+	// Index is the shard of the node, from 0 to Count-1
+	Index uint32
+	// LeaseDuration is the time after which the shard of a node which stopped renewing its lease is taken over.
+	// The leases are renewed on each schedule, if it's not longer than RequeueEvery three times RequeueEvery is used.
+	LeaseDuration time.Duration
+}
+
+// shard returns the shard of the instance.
+func (s Sharding) shard(instanceID string) uint32 {
+	hash := fnv.New32a()
+	// hash.Write never returns an error
+	_, _ = hash.Write([]byte(instanceID))
+	return hash.Sum32() % s.Count
+}
+
+type queueLane string
+
+const (
+	// lanePriority contains the instances which just received events
+	lanePriority queueLane = "priority"
+	// laneRegular contains the instances queued by the scheduler
+	laneRegular queueLane = "regular"
+)
+
+type queuedInstance struct {
+	id       string
+	lane     queueLane
+	queuedAt time.Time
+}
+
+// instanceQueue queues the instances to trigger for the workers of a handler.
+// The instances of the priority lane are dequeued before the ones of the regular lane.
+// Enqueueing blocks as long as the lane is full, so the scheduler and the subscriptions
+// slow down if the workers can't keep up.
+type instanceQueue struct {
+	lanes map[queueLane]chan *queuedInstance
+	// queued contains the instances waiting in a lane, an instance is queued at most once per lane
+	queued sync.Map
+}
+
+func newInstanceQueue(size uint) *instanceQueue {
+	return &instanceQueue{
+		lanes: map[queueLane]chan *queuedInstance{
+			lanePriority: make(chan *queuedInstance, size),
+			laneRegular:  make(chan *queuedInstance, size),
+		},
+	}
+}
+
+// enqueue adds the instance to the lane if it's not already waiting in it.
+// full is called if the lane is full before it waits for a free place.
+func (q *instanceQueue) enqueue(ctx context.Context, instanceID string, lane queueLane, full func()) {
+	key := string(lane) + ":" + instanceID
+	if _, queued := q.queued.LoadOrStore(key, struct{}{}); queued {
+		return
+	}
+	instance := &queuedInstance{id: instanceID, lane: lane, queuedAt: time.Now()}
+	select {
+	case q.lanes[lane] <- instance:
+		return
+	default:
+	}
+	full()
+	select {
+	case q.lanes[lane] <- instance:
+	case <-ctx.Done():
+		q.queued.Delete(key)
+	}
+}
+
+// dequeue returns the next instance, preferring the priority lane.
+// It returns nil if ctx is done.
+func (q *instanceQueue) dequeue(ctx context.Context) (instance *queuedInstance) {
+	defer func() {
+		if instance != nil {
+			q.queued.Delete(string(instance.lane) + ":" + instance.id)
+		}
+	}()
+	select {
+	case instance = <-q.lanes[lanePriority]:
+		return instance
+	default:
+	}
+	select {
+	case instance = <-q.lanes[lanePriority]:
+	case instance = <-q.lanes[laneRegular]:
+	case <-ctx.Done():
+	}
+	return instance
+}
+
+func (h *Handler) enqueue(ctx context.Context, instanceID string, lane queueLane) {
+	h.queue.enqueue(ctx, instanceID, lane, func() {
+		h.metrics.ProjectionQueueFull(ctx, h.ProjectionName(), lane)
+		h.log().WithField("instance", instanceID).WithField("lane", lane).Debug("queue full")
+	})
+}
+
+// work triggers the queued instances until ctx is done.
+func (h *Handler) work(ctx context.Context) {
+	for {
+		instance := h.queue.dequeue(ctx)
+		if instance == nil {
+			return
+		}
+		h.metrics.ProjectionQueueWait(ctx, h.ProjectionName(), instance.lane, time.Since(instance.queuedAt).Seconds())
+		var opts []TriggerOpt
+		if instance.lane == lanePriority {
+			// the events must not be skipped if another worker is triggering the instance
+			opts = append(opts, WithAwaitRunning())
+		}
+		h.triggerInstances(call.WithTimestamp(ctx), []string{instance.id}, opts...)
+	}
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_instanceQueue_priority(t *testing.T) {
+	ctx := context.Background()
+	queue := newInstanceQueue(2)
+	noFull := func() { t.Error("queue must not be full") }
+
+	queue.enqueue(ctx, "regular", laneRegular, noFull)
+	queue.enqueue(ctx, "priority", lanePriority, noFull)
+
+	instance := queue.dequeue(ctx)
+	require.NotNil(t, instance)
+	assert.Equal(t, "priority", instance.id)
+	assert.Equal(t, lanePriority, instance.lane)
+
+	instance = queue.dequeue(ctx)
+	require.NotNil(t, instance)
+	assert.Equal(t, "regular", instance.id)
+	assert.Equal(t, laneRegular, instance.lane)
+}
+
+func Test_instanceQueue_deduplicate(t *testing.T) {
+	ctx := context.Background()
+	queue := newInstanceQueue(1)
+	noFull := func() { t.Error("queue must not be full") }
+
+	queue.enqueue(ctx, "instance", laneRegular, noFull)
+	queue.enqueue(ctx, "instance", laneRegular, noFull)
+	require.Equal(t, "instance", queue.dequeue(ctx).id)
+
+	// the instance can be queued again as soon as it left the queue
+	queue.enqueue(ctx, "instance", laneRegular, noFull)
+	require.Equal(t, "instance", queue.dequeue(ctx).id)
+}
+
+func Test_instanceQueue_backpressure(t *testing.T) {
+	queue := newInstanceQueue(1)
+	queue.enqueue(context.Background(), "instance1", laneRegular, func() { t.Error("queue must not be full") })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	var full bool
+	queue.enqueue(ctx, "instance2", laneRegular, func() { full = true })
+	assert.True(t, full)
+
+	// instance2 was not queued because ctx was done
+	require.Equal(t, "instance1", queue.dequeue(context.Background()).id)
+	queue.enqueue(context.Background(), "instance2", laneRegular, func() { t.Error("queue must not be full") })
+	require.Equal(t, "instance2", queue.dequeue(context.Background()).id)
+}
+
+func Test_instanceQueue_dequeueDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Nil(t, newInstanceQueue(1).dequeue(ctx))
+}
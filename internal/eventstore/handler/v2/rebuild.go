@@ -0,0 +1,298 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const shadowSuffix = "_shadow"
+
+var (
+	//go:embed rebuild_status.sql
+	rebuildStatusStmt string
+	//go:embed rebuild_tables.sql
+	rebuildTablesStmt string
+	//go:embed rebuild_relations.sql
+	rebuildRelationsStmt string
+)
+
+// ShadowName returns the name of the projection a rebuild of projectionName writes to.
+func ShadowName(projectionName string) string {
+	return projectionName + shadowSuffix
+}
+
+// shadowProjection reduces the events of the wrapped projection into the shadow tables.
+// As statements are executed with the name of the projection, the same reducers write to other tables.
+type shadowProjection struct {
+	Projection
+}
+
+func (p *shadowProjection) Name() string {
+	return ShadowName(p.Projection.Name())
+}
+
+func (p *shadowProjection) Init() *handler.Check {
+	check, ok := p.Projection.(initializer)
+	if !ok {
+		return new(handler.Check)
+	}
+	return check.Init()
+}
+
+// RebuildProgress is the progress of a rebuild of a projection for one instance.
+type RebuildProgress struct {
+	ProjectionName    string
+	InstanceID        string
+	LivePosition      decimal.Decimal
+	ShadowPosition    decimal.Decimal
+	ShadowEventDate   time.Time
+	ShadowLastUpdated time.Time
+	CaughtUp          bool
+}
+
+// RebuildStatus returns the progress of the running rebuilds per instance.
+// If projectionName is empty, the progress of all rebuilds is returned.
+func RebuildStatus(ctx context.Context, client *database.DB, projectionName string) (progress []*RebuildProgress, err error) {
+	err = client.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			var (
+				p                 = new(RebuildProgress)
+				livePosition      decimal.NullDecimal
+				shadowPosition    decimal.NullDecimal
+				shadowEventDate   sql.NullTime
+				shadowLastUpdated sql.NullTime
+			)
+			if err := rows.Scan(
+				&p.ProjectionName,
+				&p.InstanceID,
+				&livePosition,
+				&shadowPosition,
+				&shadowEventDate,
+				&shadowLastUpdated,
+				&p.CaughtUp,
+			); err != nil {
+				return err
+			}
+			p.LivePosition = livePosition.Decimal
+			p.ShadowPosition = shadowPosition.Decimal
+			p.ShadowEventDate = shadowEventDate.Time
+			p.ShadowLastUpdated = shadowLastUpdated.Time
+			progress = append(progress, p)
+		}
+		return rows.Err()
+	}, rebuildStatusStmt, projectionName)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V2-aeD4o", "unable to query rebuild status")
+	}
+	return progress, nil
+}
+
+// Rebuild reduces all events of the projection into shadow tables while the live tables keep serving.
+// The progress is tracked in the current states under the [ShadowName] of the projection,
+// an interrupted rebuild therefore continues where it stopped.
+// As soon as the shadow tables caught up with the live tables for all instances
+// the tables and the states are swapped in a single transaction.
+//
+// Statements which do not write to the table named by the projection name are executed against the live tables.
+func (h *Handler) Rebuild(ctx context.Context) error {
+	schema, table := splitTableName(h.ProjectionName())
+	var tableType string
+	err := h.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		return row.Scan(&tableType)
+	}, "SELECT table_type FROM information_schema.tables WHERE table_schema = $1 AND table_name = $2", schema, table)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return zerrors.ThrowInternal(err, "V2-Oe4ah", "unable to query projection table")
+	}
+	if tableType != "" && tableType != "BASE TABLE" {
+		return zerrors.ThrowPreconditionFailed(nil, "V2-ahR0a", "only projections stored in tables can be rebuilt")
+	}
+
+	shadow := h.shadow()
+	if err = shadow.Init(ctx); err != nil {
+		return err
+	}
+	for i := 0; ; i++ {
+		instances, err := shadow.existingInstances(ctx)
+		if err != nil {
+			return err
+		}
+		shadow.triggerInstances(ctx, instances)
+
+		progress, err := RebuildStatus(ctx, h.client, h.ProjectionName())
+		if err != nil {
+			return err
+		}
+		pending := 0
+		for _, p := range progress {
+			if !p.CaughtUp {
+				pending++
+			}
+		}
+		h.log().WithField("iteration", i).WithField("pending", pending).Info("shadow projection triggered")
+		if pending == 0 {
+			break
+		}
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+	}
+	return h.swapShadow(ctx)
+}
+
+// shadow returns a handler which writes the projection into the shadow tables.
+func (h *Handler) shadow() *Handler {
+	return &Handler{
+		client:               h.client,
+		projection:           &shadowProjection{Projection: h.projection},
+		es:                   h.es,
+		bulkLimit:            h.bulkLimit,
+		eventTypes:           h.eventTypes,
+		maxFailureCount:      h.maxFailureCount,
+		retryFailedAfter:     h.retryFailedAfter,
+		blocking:             h.blocking,
+		requeueEvery:         h.requeueEvery,
+		txDuration:           h.txDuration,
+		now:                  h.now,
+		queryGlobal:          h.queryGlobal,
+		triggerWithoutEvents: h.triggerWithoutEvents,
+		queryInstances:       h.queryInstances,
+		metrics:              NewProjectionMetrics(),
+	}
+}
+
+func (h *Handler) swapShadow(ctx context.Context) (err error) {
+	tx, err := h.client.BeginTx(ctx, nil)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "V2-ohB5u", "begin failed")
+	}
+	defer func() {
+		if err != nil {
+			h.log().OnError(tx.Rollback()).Debug("unable to rollback")
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	// the live handlers skip the instances as long as their states are locked
+	if _, err = tx.ExecContext(ctx, "SELECT 1 FROM projections.current_states WHERE projection_name = $1 FOR UPDATE", h.ProjectionName()); err != nil {
+		return zerrors.ThrowInternal(err, "V2-Zae9o", "unable to lock states")
+	}
+	schema, shadowTable := splitTableName(ShadowName(h.ProjectionName()))
+	tables, err := queryStrings(ctx, tx, rebuildTablesStmt, schema, shadowTable)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "V2-ieX6u", "unable to query shadow tables")
+	}
+	if len(tables) == 0 {
+		return zerrors.ThrowPreconditionFailed(nil, "V2-Eph3i", "shadow tables not found")
+	}
+	relations, err := queryRelations(ctx, tx, schema, tables)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "V2-bah3U", "unable to query shadow indexes")
+	}
+	for _, stmt := range swapStatements(h.ProjectionName(), tables, relations) {
+		if _, err = tx.ExecContext(ctx, stmt); err != nil {
+			return zerrors.ThrowInternal(err, "V2-shoo3", "unable to swap shadow tables")
+		}
+	}
+	for _, table := range []string{"projections.current_states", "projections.failed_events2", "projections.blocked_aggregates"} {
+		if _, err = tx.ExecContext(ctx, "DELETE FROM "+table+" WHERE projection_name = $1", h.ProjectionName()); err != nil {
+			return zerrors.ThrowInternal(err, "V2-Ku0ie", "unable to move states")
+		}
+		if _, err = tx.ExecContext(ctx, "UPDATE "+table+" SET projection_name = $1 WHERE projection_name = $2", h.ProjectionName(), ShadowName(h.ProjectionName())); err != nil {
+			return zerrors.ThrowInternal(err, "V2-eiy3E", "unable to move states")
+		}
+	}
+	h.log().WithField("tables", len(tables)).Info("shadow tables swapped")
+	return nil
+}
+
+// shadowRelation is an index or a foreign key of a shadow table.
+type shadowRelation struct {
+	isIndex bool
+	table   string
+	name    string
+}
+
+// swapStatements drops the live tables and renames the shadow tables, their indexes and foreign keys
+// to the names the live tables would be created with.
+func swapStatements(projectionName string, shadowTables []string, relations []*shadowRelation) []string {
+	schema, liveTable := splitTableName(projectionName)
+	_, shadowTable := splitTableName(ShadowName(projectionName))
+	liveName := func(name string) string {
+		return strings.Replace(name, shadowTable, liveTable, 1)
+	}
+
+	liveTables := make([]string, len(shadowTables))
+	for i, table := range shadowTables {
+		liveTables[i] = schema + "." + liveName(table)
+	}
+	stmts := make([]string, 0, len(shadowTables)+len(relations)+1)
+	stmts = append(stmts, "DROP TABLE IF EXISTS "+strings.Join(liveTables, ", "))
+	for _, table := range shadowTables {
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s.%s RENAME TO %s", schema, table, liveName(table)))
+	}
+	for _, relation := range relations {
+		if !strings.Contains(relation.name, shadowTable) {
+			continue
+		}
+		if relation.isIndex {
+			stmts = append(stmts, fmt.Sprintf("ALTER INDEX %s.%s RENAME TO %s", schema, relation.name, liveName(relation.name)))
+			continue
+		}
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s.%s RENAME CONSTRAINT %s TO %s", schema, liveName(relation.table), relation.name, liveName(relation.name)))
+	}
+	return stmts
+}
+
+func queryStrings(ctx context.Context, tx *sql.Tx, query string, args ...any) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var values []string
+	for rows.Next() {
+		var value string
+		if err = rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
+
+func queryRelations(ctx context.Context, tx *sql.Tx, schema string, tables []string) ([]*shadowRelation, error) {
+	rows, err := tx.QueryContext(ctx, rebuildRelationsStmt, schema, database.TextArray[string](tables))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var relations []*shadowRelation
+	for rows.Next() {
+		relation := new(shadowRelation)
+		if err = rows.Scan(&relation.isIndex, &relation.table, &relation.name); err != nil {
+			return nil, err
+		}
+		relations = append(relations, relation)
+	}
+	return relations, rows.Err()
+}
+
+func splitTableName(name string) (schema, table string) {
+	schema, table, ok := strings.Cut(name, ".")
+	if !ok {
+		return "public", name
+	}
+	return schema, table
+}
@@ -0,0 +1,26 @@
+SELECT
+    TRUE
+    , t.relname
+    , c.relname
+FROM
+    pg_index i
+JOIN pg_class c ON c.oid = i.indexrelid
+JOIN pg_class t ON t.oid = i.indrelid
+JOIN pg_namespace n ON n.oid = t.relnamespace
+WHERE
+    n.nspname = $1
+    AND t.relname = ANY($2)
+UNION ALL
+SELECT
+    FALSE
+    , t.relname
+    , con.conname
+FROM
+    pg_constraint con
+JOIN pg_class t ON t.oid = con.conrelid
+JOIN pg_namespace n ON n.oid = t.relnamespace
+WHERE
+    con.contype = 'f'
+    AND n.nspname = $1
+    AND t.relname = ANY($2)
+;
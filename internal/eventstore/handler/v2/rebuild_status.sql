@@ -0,0 +1,17 @@
+SELECT
+    projection_name
+    , instance_id
+    , live_position
+    , shadow_position
+    , shadow_event_date
+    , shadow_last_updated
+    , caught_up
+FROM
+    projections.rebuild_status
+WHERE
+    $1 = ''
+    OR projection_name = $1
+ORDER BY
+    projection_name
+    , instance_id
+;
@@ -0,0 +1,15 @@
+SELECT
+    table_name
+FROM
+    information_schema.tables
+WHERE
+    table_schema = $1
+    AND table_type = 'BASE TABLE'
+    AND (
+        table_name = $2
+        OR starts_with(table_name, $2 || '_')
+    )
+-- the primary table is renamed first
+ORDER BY
+    length(table_name)
+;
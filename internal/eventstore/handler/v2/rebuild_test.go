@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+)
+
+type initProjection struct {
+	projection
+}
+
+func (*initProjection) Init() *handler.Check {
+	return NewMultiTableCheck(
+		NewTable([]*InitColumn{NewColumn("id", ColumnTypeText)}, NewPrimaryKey("id")),
+	)
+}
+
+func Test_shadowProjection(t *testing.T) {
+	shadow := &shadowProjection{Projection: &projection{name: "projections.users"}}
+	assert.Equal(t, "projections.users_shadow", shadow.Name())
+	assert.True(t, shadow.Init().IsNoop())
+
+	shadow = &shadowProjection{Projection: &initProjection{projection{name: "projections.users"}}}
+	assert.Len(t, shadow.Init().Executes, 1)
+}
+
+func Test_swapStatements(t *testing.T) {
+	tests := []struct {
+		name      string
+		tables    []string
+		relations []*shadowRelation
+		want      []string
+	}{
+		{
+			name:   "single table",
+			tables: []string{"users_shadow"},
+			relations: []*shadowRelation{
+				{isIndex: true, table: "users_shadow", name: "users_shadow_pkey"},
+				{isIndex: true, table: "users_shadow", name: "users_shadow_username_idx"},
+			},
+			want: []string{
+				"DROP TABLE IF EXISTS projections.users",
+				"ALTER TABLE projections.users_shadow RENAME TO users",
+				"ALTER INDEX projections.users_shadow_pkey RENAME TO users_pkey",
+				"ALTER INDEX projections.users_shadow_username_idx RENAME TO users_username_idx",
+			},
+		},
+		{
+			name:   "suffixed tables",
+			tables: []string{"users_shadow", "users_shadow_humans"},
+			relations: []*shadowRelation{
+				{isIndex: true, table: "users_shadow_humans", name: "users_shadow_humans_pkey"},
+				{table: "users_shadow_humans", name: "fk_humans_ref_users_shadow"},
+				{isIndex: true, table: "users_shadow_humans", name: "truncated_idx"},
+			},
+			want: []string{
+				"DROP TABLE IF EXISTS projections.users, projections.users_humans",
+				"ALTER TABLE projections.users_shadow RENAME TO users",
+				"ALTER TABLE projections.users_shadow_humans RENAME TO users_humans",
+				"ALTER INDEX projections.users_shadow_humans_pkey RENAME TO users_humans_pkey",
+				"ALTER TABLE projections.users_humans RENAME CONSTRAINT fk_humans_ref_users_shadow TO fk_humans_ref_users",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, swapStatements("projections.users", tt.tables, tt.relations))
+		})
+	}
+}
+
+func Test_splitTableName(t *testing.T) {
+	schema, table := splitTableName("projections.users")
+	assert.Equal(t, "projections", schema)
+	assert.Equal(t, "users", table)
+
+	schema, table = splitTableName("users")
+	assert.Equal(t, "public", schema)
+	assert.Equal(t, "users", table)
+}
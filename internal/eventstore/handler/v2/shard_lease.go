@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+//go:embed shard_lease.sql
+var renewShardLeasesStmt string
+
+// shardLeases are the shards owned by the handler of a node.
+// The handler leases the shard of its [Sharding.Index] and takes over the shards
+// of nodes which stopped renewing their lease, so the instances of a failed node are still processed.
+// As soon as the node is back, it reclaims its shard.
+// Two nodes processing the same instance during a takeover is safe,
+// as the current state of the instance is locked while the events are processed.
+type shardLeases struct {
+	sharding      Sharding
+	leaseDuration time.Duration
+	// owner identifies the handler in the leases, it's generated on the first renewal
+	owner string
+
+	mu    sync.RWMutex
+	owned []uint32
+}
+
+// newShardLeases returns the leases of a handler scheduled every requeueEvery.
+// The leases must outlast the schedule, so they are renewed before they expire.
+func newShardLeases(sharding Sharding, requeueEvery time.Duration) *shardLeases {
+	leases := &shardLeases{
+		sharding:      sharding,
+		leaseDuration: sharding.LeaseDuration,
+		// until the first renewal the node owns its configured shard
+		owned: []uint32{sharding.Index},
+	}
+	if leases.leaseDuration <= requeueEvery {
+		leases.leaseDuration = 3 * requeueEvery
+	}
+	return leases
+}
+
+// renew extends the lease of the owned shards and acquires the shards without live owner.
+// The owned shards are kept if the leases cannot be renewed.
+// It's only called by the scheduler of the handler.
+func (l *shardLeases) renew(ctx context.Context, client *database.DB, projectionName string) error {
+	if l.sharding.Count < 2 {
+		return nil
+	}
+	if l.owner == "" {
+		owner, err := id.SonyFlakeGenerator().Next()
+		if err != nil {
+			return zerrors.ThrowInternal(err, "V2-ieT0a", "unable to generate lease owner")
+		}
+		l.owner = owner
+	}
+	owned := make([]uint32, 0, 1)
+	err := client.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			var shard uint32
+			if err := rows.Scan(&shard); err != nil {
+				return err
+			}
+			owned = append(owned, shard)
+		}
+		return rows.Err()
+	}, renewShardLeasesStmt, projectionName, l.owner, l.sharding.Index, l.leaseDuration, l.sharding.Count)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "V2-Ohj4u", "unable to renew shard leases")
+	}
+	l.mu.Lock()
+	l.owned = owned
+	l.mu.Unlock()
+	return nil
+}
+
+// owns returns true if the instance belongs to a shard owned by the node.
+func (l *shardLeases) owns(instanceID string) bool {
+	if l.sharding.Count < 2 {
+		return true
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return slices.Contains(l.owned, l.sharding.shard(instanceID))
+}
+
+func (l *shardLeases) filter(instances []string) []string {
+	if l.sharding.Count < 2 {
+		return instances
+	}
+	owned := make([]string, 0, len(instances)/int(l.sharding.Count)+1)
+	for _, instance := range instances {
+		if l.owns(instance) {
+			owned = append(owned, instance)
+		}
+	}
+	return owned
+}
@@ -0,0 +1,14 @@
+-- leases the configured shard of the node and the shards without live owner.
+-- the configured node reclaims its shard from the node which took it over.
+INSERT INTO projections.shard_leases (projection_name, shard, "owner", taken_over, expires_at)
+SELECT $1, shard, $2, shard <> $3, now() + $4::INTERVAL
+FROM generate_series(0, $5 - 1) AS shard
+ON CONFLICT (projection_name, shard) DO UPDATE SET
+    "owner" = EXCLUDED."owner"
+    , taken_over = EXCLUDED.taken_over
+    , expires_at = EXCLUDED.expires_at
+WHERE
+    projections.shard_leases."owner" = EXCLUDED."owner"
+    OR projections.shard_leases.expires_at < now()
+    OR (projections.shard_leases.taken_over AND NOT EXCLUDED.taken_over)
+RETURNING shard;
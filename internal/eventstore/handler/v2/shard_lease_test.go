@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/mock"
+)
+
+func testInstances() []string {
+	instances := make([]string, 100)
+	for i := range instances {
+		instances[i] = fmt.Sprintf("instance%d", i)
+	}
+	return instances
+}
+
+func Test_shardLeases_filter(t *testing.T) {
+	instances := testInstances()
+
+	assert.Equal(t, instances, newShardLeases(Sharding{}, time.Minute).filter(instances))
+	assert.Equal(t, instances, newShardLeases(Sharding{Count: 1}, time.Minute).filter(instances))
+
+	var owned int
+	for i := range uint32(3) {
+		filtered := newShardLeases(Sharding{Count: 3, Index: i}, time.Minute).filter(instances)
+		assert.NotEmpty(t, filtered)
+		owned += len(filtered)
+	}
+	assert.Equal(t, len(instances), owned, "each instance must be owned by exactly one shard")
+
+	for _, instance := range instances {
+		assert.Equal(t, Sharding{Count: 3}.shard(instance), Sharding{Count: 3}.shard(instance), "hash must be stable")
+	}
+}
+
+func Test_newShardLeases_leaseDuration(t *testing.T) {
+	assert.Equal(t, 3*time.Minute, newShardLeases(Sharding{Count: 2}, time.Minute).leaseDuration)
+	assert.Equal(t, 3*time.Minute, newShardLeases(Sharding{Count: 2, LeaseDuration: time.Minute}, time.Minute).leaseDuration)
+	assert.Equal(t, 5*time.Minute, newShardLeases(Sharding{Count: 2, LeaseDuration: 5 * time.Minute}, time.Minute).leaseDuration)
+}
+
+func Test_shardLeases_renew(t *testing.T) {
+	instances := testInstances()
+	tests := []struct {
+		name   string
+		expect mock.Expectation
+		// owned are the shards after the renewal
+		owned   []uint32
+		wantErr bool
+	}{
+		{
+			name: "own shard",
+			expect: mock.ExpectQuery(renewShardLeasesStmt,
+				mock.WithQueryArgs("projection", "owner", uint32(1), 3*time.Minute, uint32(3)),
+				mock.WithQueryResult([]string{"shard"}, [][]driver.Value{{1}}),
+			),
+			owned: []uint32{1},
+		},
+		{
+			name: "shard without live owner taken over",
+			expect: mock.ExpectQuery(renewShardLeasesStmt,
+				mock.WithQueryArgs("projection", "owner", uint32(1), 3*time.Minute, uint32(3)),
+				mock.WithQueryResult([]string{"shard"}, [][]driver.Value{{0}, {1}}),
+			),
+			owned: []uint32{0, 1},
+		},
+		{
+			name: "own shard taken by another node",
+			expect: mock.ExpectQuery(renewShardLeasesStmt,
+				mock.WithQueryArgs("projection", "owner", uint32(1), 3*time.Minute, uint32(3)),
+				mock.WithQueryResult([]string{"shard"}, [][]driver.Value{}),
+			),
+			owned: []uint32{},
+		},
+		{
+			name: "renewal failed, owned shards kept",
+			expect: mock.ExpectQuery(renewShardLeasesStmt,
+				mock.WithQueryArgs("projection", "owner", uint32(1), 3*time.Minute, uint32(3)),
+				mock.WithQueryErr(errors.New("unavailable")),
+			),
+			owned:   []uint32{1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlMock := mock.NewSQLMock(t, tt.expect)
+			leases := newShardLeases(Sharding{Count: 3, Index: 1}, time.Minute)
+			leases.owner = "owner"
+
+			err := leases.renew(context.Background(), &database.DB{DB: sqlMock.DB}, "projection")
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.owned, leases.owned)
+			for _, instance := range leases.filter(instances) {
+				assert.Contains(t, tt.owned, Sharding{Count: 3}.shard(instance))
+			}
+			sqlMock.Assert(t)
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/shopspring/decimal"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -33,6 +34,8 @@ var (
 	updateStateStmt string
 	//go:embed state_lock.sql
 	lockStateStmt string
+	//go:embed state_position.sql
+	currentPositionStmt string
 
 	errJustUpdated = errors.New("projection was just updated")
 )
@@ -67,6 +70,9 @@ func (h *Handler) currentState(ctx context.Context, tx *sql.Tx, config *triggerC
 	)
 	if errors.Is(err, sql.ErrNoRows) {
 		err = h.lockState(tx, currentState.instanceID)
+		if err == nil {
+			err = h.rehydrateArchived(ctx, tx, currentState.instanceID)
+		}
 	}
 	if err != nil {
 		h.log().WithError(err).Debug("unable to query current state")
@@ -83,6 +89,34 @@ func (h *Handler) currentState(ctx context.Context, tx *sql.Tx, config *triggerC
 	return currentState, nil
 }
 
+// archiveRehydrater is implemented by eventstores which archive the events of closed aggregates.
+type archiveRehydrater interface {
+	RehydrateInstance(ctx context.Context, client database.ContextExecuter, instanceID string) error
+}
+
+// rehydrateArchived restores the archived events of the instance
+// before the projection processes its events from the beginning.
+// currentPosition returns the latest position processed by the projection over all instances.
+// Notification subscriptions catch up on events pushed after it.
+func (h *Handler) currentPosition(ctx context.Context) (position decimal.Decimal, err error) {
+	var latest decimal.NullDecimal
+	err = h.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		return row.Scan(&latest)
+	}, currentPositionStmt, h.projection.Name())
+	if err != nil {
+		return position, zerrors.ThrowInternal(err, "V2-Aeh5o", "unable to query current position")
+	}
+	return latest.Decimal, nil
+}
+
+func (h *Handler) rehydrateArchived(ctx context.Context, tx *sql.Tx, instanceID string) error {
+	rehydrater, ok := h.es.(archiveRehydrater)
+	if !ok {
+		return nil
+	}
+	return rehydrater.RehydrateInstance(ctx, tx, instanceID)
+}
+
 func (h *Handler) setState(tx *sql.Tx, updatedState *state) error {
 	res, err := tx.Exec(updateStateStmt,
 		h.projection.Name(),
@@ -0,0 +1,6 @@
+SELECT
+    MAX("position")
+FROM
+    projections.current_states
+WHERE
+    projection_name = $1;
@@ -14,6 +14,7 @@ import (
 	"github.com/shopspring/decimal"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/database/mock"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -267,12 +268,14 @@ func TestHandler_currentState(t *testing.T) {
 	type fields struct {
 		projection Projection
 		mock       *mock.SQLMock
+		es         *rehydratingEventstore
 	}
 	type args struct {
 		ctx context.Context
 	}
 	type want struct {
 		currentState *state
+		rehydrated   []string
 		isErr        func(t *testing.T, err error)
 	}
 	tests := []struct {
@@ -344,6 +347,41 @@ func TestHandler_currentState(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "no row, archived events rehydrated",
+			fields: fields{
+				projection: &projection{
+					name: "projection",
+				},
+				mock: mock.NewSQLMock(t,
+					mock.ExpectBegin(nil),
+					mock.ExpectQuery(currentStateStmt,
+						mock.WithQueryArgs(
+							"instance",
+							"projection",
+						),
+						mock.WithQueryErr(sql.ErrNoRows),
+					),
+					mock.ExcpectExec(lockStateStmt,
+						mock.WithExecArgs(
+							"projection",
+							"instance",
+						),
+						mock.WithExecRowsAffected(1),
+					),
+				),
+				es: new(rehydratingEventstore),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance"),
+			},
+			want: want{
+				currentState: &state{
+					instanceID: "instance",
+				},
+				rehydrated: []string{"instance"},
+			},
+		},
 		{
 			name: "state locked",
 			fields: fields{
@@ -434,6 +472,9 @@ func TestHandler_currentState(t *testing.T) {
 			h := &Handler{
 				projection: tt.fields.projection,
 			}
+			if tt.fields.es != nil {
+				h.es = tt.fields.es
+			}
 
 			tx, err := tt.fields.mock.DB.BeginTx(context.Background(), nil)
 			if err != nil {
@@ -446,7 +487,80 @@ func TestHandler_currentState(t *testing.T) {
 			if !reflect.DeepEqual(gotCurrentState, tt.want.currentState) {
 				t.Errorf("Handler.currentState() gotCurrentState = %v, want %v", gotCurrentState, tt.want.currentState)
 			}
+			if tt.fields.es != nil && !reflect.DeepEqual(tt.fields.es.instanceIDs, tt.want.rehydrated) {
+				t.Errorf("Handler.currentState() rehydrated = %v, want %v", tt.fields.es.instanceIDs, tt.want.rehydrated)
+			}
 			tt.fields.mock.Assert(t)
 		})
 	}
 }
+
+func TestHandler_currentPosition(t *testing.T) {
+	tests := []struct {
+		name     string
+		mock     *mock.SQLMock
+		position decimal.Decimal
+		wantErr  bool
+	}{
+		{
+			name: "query fails",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectQuery(currentPositionStmt,
+					mock.WithQueryArgs("projection"),
+					mock.WithQueryErr(sql.ErrConnDone),
+				),
+			),
+			wantErr: true,
+		},
+		{
+			name: "no state",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectQuery(currentPositionStmt,
+					mock.WithQueryArgs("projection"),
+					mock.WithQueryResult([]string{"position"}, [][]driver.Value{{nil}}),
+				),
+			),
+			position: decimal.Decimal{},
+		},
+		{
+			name: "latest position",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectQuery(currentPositionStmt,
+					mock.WithQueryArgs("projection"),
+					mock.WithQueryResult([]string{"position"}, [][]driver.Value{{"42.5"}}),
+				),
+			),
+			position: decimal.RequireFromString("42.5"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				client:     &database.DB{DB: tt.mock.DB},
+				projection: &projection{name: "projection"},
+			}
+
+			position, err := h.currentPosition(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Handler.currentPosition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !position.Equal(tt.position) {
+				t.Errorf("Handler.currentPosition() = %v, want %v", position, tt.position)
+			}
+			tt.mock.Assert(t)
+		})
+	}
+}
+
+var _ archiveRehydrater = (*eventstore.Eventstore)(nil)
+
+// rehydratingEventstore records the instances whose archived events are rehydrated.
+type rehydratingEventstore struct {
+	EventStore
+	instanceIDs []string
+}
+
+func (es *rehydratingEventstore) RehydrateInstance(_ context.Context, _ database.ContextExecuter, instanceID string) error {
+	es.instanceIDs = append(es.instanceIDs, instanceID)
+	return nil
+}
@@ -45,9 +45,13 @@ func (h *Handler) eventsToStatements(tx *sql.Tx, events []eventstore.Event, curr
 	offset := currentState.offset
 	for _, event := range events {
 		statement, err := h.reduce(event)
+		if err != nil && h.blocking != nil {
+			h.logEvent(event).WithError(err).Error("reduce failed")
+			statement, err = failedReduceStatement(event, err), nil
+		}
 		if err != nil {
 			h.logEvent(event).WithError(err).Error("reduce failed")
-			if shouldContinue := h.handleFailedStmt(tx, failureFromEvent(event, err)); shouldContinue {
+			if shouldContinue, _ := h.handleFailedStmt(tx, failureFromEvent(event, err)); shouldContinue {
 				continue
 			}
 			return statements, err
@@ -0,0 +1,348 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/zitadel/logging"
+)
+
+const DefaultNotificationChannel = "zitadel_events"
+
+// NotificationConfig enables notifications of all ZITADEL nodes after events were pushed,
+// using Postgres LISTEN/NOTIFY.
+type NotificationConfig struct {
+	Enabled bool
+	// Channel used for LISTEN/NOTIFY, defaults to [DefaultNotificationChannel].
+	Channel string
+	// ReconnectInterval is the time to wait before a broken listener connection is restarted.
+	ReconnectInterval time.Duration
+}
+
+func (c *NotificationConfig) channel() string {
+	if c.Channel == "" {
+		return DefaultNotificationChannel
+	}
+	return c.Channel
+}
+
+// maxNotificationPayload is the maximum payload size of NOTIFY in the default Postgres configuration.
+const maxNotificationPayload = 8000
+
+// PushNotification is sent to all nodes after events of an instance were committed.
+type PushNotification struct {
+	InstanceID string          `json:"instance"`
+	Position   decimal.Decimal `json:"position"`
+	// AggregateTypes of the pushed events.
+	// Empty if the types did not fit into the payload, which matches all subscriptions.
+	AggregateTypes []AggregateType `json:"aggregates,omitempty"`
+	// EventTypes of the pushed events.
+	// Empty if the types did not fit into the payload, which matches all event types of the aggregate types.
+	EventTypes []EventType `json:"events,omitempty"`
+}
+
+// NewPushNotifications returns a notification per instance of the events.
+func NewPushNotifications(events []Event) []*PushNotification {
+	notifications := make([]*PushNotification, 0, 1)
+	for _, event := range events {
+		i := slices.IndexFunc(notifications, func(notification *PushNotification) bool {
+			return notification.InstanceID == event.Aggregate().InstanceID
+		})
+		if i < 0 {
+			notifications = append(notifications, &PushNotification{InstanceID: event.Aggregate().InstanceID})
+			i = len(notifications) - 1
+		}
+		notification := notifications[i]
+		if event.Position().GreaterThan(notification.Position) {
+			notification.Position = event.Position()
+		}
+		if !slices.Contains(notification.AggregateTypes, event.Aggregate().Type) {
+			notification.AggregateTypes = append(notification.AggregateTypes, event.Aggregate().Type)
+		}
+		if !slices.Contains(notification.EventTypes, event.Type()) {
+			notification.EventTypes = append(notification.EventTypes, event.Type())
+		}
+	}
+	return notifications
+}
+
+// Payload marshals the notification for NOTIFY.
+// The types are omitted if the payload would exceed the limit of NOTIFY.
+func (n *PushNotification) Payload() ([]byte, error) {
+	reduced := *n
+	for {
+		payload, err := json.Marshal(&reduced)
+		if err != nil || len(payload) < maxNotificationPayload {
+			return payload, err
+		}
+		if len(reduced.EventTypes) > 0 {
+			reduced.EventTypes = nil
+			continue
+		}
+		if len(reduced.AggregateTypes) > 0 {
+			reduced.AggregateTypes = nil
+			continue
+		}
+		return nil, fmt.Errorf("push notification of instance %q exceeds the payload limit", n.InstanceID)
+	}
+}
+
+// NotificationSubscription receives the instances with new events from all nodes.
+// Notifications are never dropped: pending notifications are merged per instance until [NotificationSubscription.Next] is called.
+type NotificationSubscription struct {
+	notifier *notifier
+	types    map[AggregateType][]EventType
+
+	mu       sync.Mutex
+	position decimal.Decimal
+	pending  map[string]decimal.Decimal
+	signal   chan struct{}
+}
+
+// SubscribeNotifications subscribes to events of the given types pushed by any ZITADEL node.
+// If no event types are provided for an aggregate type, all events of the aggregate type are subscribed.
+// The position is used to catch up on missed events after a lost connection.
+// If it is zero, the latest position received by this node is used.
+//
+// The context must live as long as the application,
+// as the first call starts listening for notifications.
+// nil is returned if notifications are disabled.
+func (es *Eventstore) SubscribeNotifications(ctx context.Context, types map[AggregateType][]EventType, position decimal.Decimal) *NotificationSubscription {
+	if es.notifier == nil {
+		return nil
+	}
+	subscription := &NotificationSubscription{
+		notifier: es.notifier,
+		types:    types,
+		position: position,
+		pending:  make(map[string]decimal.Decimal),
+		signal:   make(chan struct{}, 1),
+	}
+	es.notifier.subscribe(ctx, subscription)
+	return subscription
+}
+
+// Next blocks until new events were pushed and returns the affected instances.
+// The position of the subscription is moved to the latest position of the returned notifications.
+func (s *NotificationSubscription) Next(ctx context.Context) ([]string, error) {
+	for {
+		s.mu.Lock()
+		if len(s.pending) > 0 {
+			instances := make([]string, 0, len(s.pending))
+			for instance, position := range s.pending {
+				instances = append(instances, instance)
+				if position.GreaterThan(s.position) {
+					s.position = position
+				}
+			}
+			clear(s.pending)
+			s.mu.Unlock()
+			slices.Sort(instances)
+			return instances, nil
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-s.signal:
+		}
+	}
+}
+
+// Position returns the latest position returned by [NotificationSubscription.Next].
+func (s *NotificationSubscription) Position() decimal.Decimal {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.position
+}
+
+// Unsubscribe stops receiving notifications.
+func (s *NotificationSubscription) Unsubscribe() {
+	s.notifier.unsubscribe(s)
+}
+
+func (s *NotificationSubscription) matches(notification *PushNotification) bool {
+	if len(notification.AggregateTypes) == 0 {
+		return true
+	}
+	for _, aggregateType := range notification.AggregateTypes {
+		eventTypes, ok := s.types[aggregateType]
+		if !ok {
+			continue
+		}
+		if len(eventTypes) == 0 || len(notification.EventTypes) == 0 {
+			return true
+		}
+		for _, eventType := range notification.EventTypes {
+			if slices.Contains(eventTypes, eventType) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *NotificationSubscription) add(instanceID string, position decimal.Decimal) {
+	s.mu.Lock()
+	if pending, ok := s.pending[instanceID]; !ok || position.GreaterThan(pending) {
+		s.pending[instanceID] = position
+	}
+	s.mu.Unlock()
+
+	select {
+	case s.signal <- struct{}{}:
+	default:
+	}
+}
+
+// catchUpQuery returns the query for instances with events of the subscription since its position.
+func (s *NotificationSubscription) catchUpQuery(position decimal.Decimal) *SearchQueryBuilder {
+	if s.Position().GreaterThan(position) {
+		position = s.Position()
+	}
+	builder := NewSearchQueryBuilder(ColumnsInstanceIDs).
+		OrderAsc().
+		PositionAtLeast(position)
+	for aggregateType, eventTypes := range s.types {
+		builder = builder.AddQuery().
+			AggregateTypes(aggregateType).
+			EventTypes(eventTypes...).
+			Builder()
+	}
+	return builder
+}
+
+type notifier struct {
+	config NotificationConfig
+	es     *Eventstore
+
+	mu            sync.RWMutex
+	subscriptions map[*NotificationSubscription]struct{}
+	position      decimal.Decimal
+	listen        sync.Once
+}
+
+func newNotifier(config NotificationConfig, es *Eventstore) *notifier {
+	if config.ReconnectInterval <= 0 {
+		config.ReconnectInterval = time.Second
+	}
+	return &notifier{
+		config:        config,
+		es:            es,
+		subscriptions: make(map[*NotificationSubscription]struct{}),
+	}
+}
+
+func (n *notifier) subscribe(ctx context.Context, subscription *NotificationSubscription) {
+	n.mu.Lock()
+	n.subscriptions[subscription] = struct{}{}
+	n.mu.Unlock()
+
+	n.listen.Do(func() {
+		go n.run(ctx)
+	})
+}
+
+func (n *notifier) unsubscribe(subscription *NotificationSubscription) {
+	n.mu.Lock()
+	delete(n.subscriptions, subscription)
+	n.mu.Unlock()
+}
+
+func (n *notifier) run(ctx context.Context) {
+	var reconnected bool
+	for {
+		err := n.listenAndHandle(ctx, reconnected)
+		if ctx.Err() != nil {
+			return
+		}
+		logging.WithError(err).WithField("channel", n.config.channel()).Warn("eventstore notification listener failed")
+		reconnected = true
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(n.config.ReconnectInterval):
+		}
+	}
+}
+
+func (n *notifier) listenAndHandle(ctx context.Context, reconnected bool) error {
+	poolConn, err := n.es.Client().Pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire listener connection: %w", err)
+	}
+	// the connection is removed from the pool,
+	// so the LISTEN state never leaks to other users of the pool.
+	conn := poolConn.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err = conn.Exec(ctx, "LISTEN "+pgx.Identifier{n.config.channel()}.Sanitize()); err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	if reconnected {
+		n.catchUp(ctx)
+	}
+	for {
+		pgNotification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		notification := new(PushNotification)
+		if err = json.Unmarshal([]byte(pgNotification.Payload), notification); err != nil {
+			logging.WithError(err).Warn("unable to decode eventstore notification")
+			continue
+		}
+		n.handle(notification)
+	}
+}
+
+func (n *notifier) handle(notification *PushNotification) {
+	n.mu.Lock()
+	if notification.Position.GreaterThan(n.position) {
+		n.position = notification.Position
+	}
+	n.mu.Unlock()
+
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	for subscription := range n.subscriptions {
+		if subscription.matches(notification) {
+			subscription.add(notification.InstanceID, notification.Position)
+		}
+	}
+}
+
+// catchUp notifies the subscriptions about the instances with events
+// pushed while the listener was disconnected.
+func (n *notifier) catchUp(ctx context.Context) {
+	n.mu.RLock()
+	position := n.position
+	subscriptions := make([]*NotificationSubscription, 0, len(n.subscriptions))
+	for subscription := range n.subscriptions {
+		subscriptions = append(subscriptions, subscription)
+	}
+	n.mu.RUnlock()
+
+	for _, subscription := range subscriptions {
+		query := subscription.catchUpQuery(position)
+		if query.GetPositionAtLeast().IsZero() {
+			logging.Info("eventstore notification subscription without position, skip catch up")
+			continue
+		}
+		instances, err := n.es.InstanceIDs(ctx, query)
+		if err != nil {
+			logging.WithError(err).Warn("eventstore notification catch up failed")
+			continue
+		}
+		for _, instance := range instances {
+			subscription.add(instance, query.GetPositionAtLeast())
+		}
+	}
+}
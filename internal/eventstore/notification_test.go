@@ -0,0 +1,234 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func notificationTestEvent(instanceID string, aggregateType AggregateType, eventType EventType, position float64) Event {
+	return &BaseEvent{
+		EventType: eventType,
+		Agg: &Aggregate{
+			InstanceID: instanceID,
+			Type:       aggregateType,
+		},
+		Pos: decimal.NewFromFloat(position),
+	}
+}
+
+func TestNewPushNotifications(t *testing.T) {
+	got := NewPushNotifications([]Event{
+		notificationTestEvent("instance1", "user", "user.added", 1.1),
+		notificationTestEvent("instance2", "org", "org.added", 1.2),
+		notificationTestEvent("instance1", "user", "user.changed", 1.3),
+		notificationTestEvent("instance1", "org", "user.changed", 1.4),
+	})
+	assert.Equal(t, []*PushNotification{
+		{
+			InstanceID:     "instance1",
+			Position:       decimal.NewFromFloat(1.4),
+			AggregateTypes: []AggregateType{"user", "org"},
+			EventTypes:     []EventType{"user.added", "user.changed"},
+		},
+		{
+			InstanceID:     "instance2",
+			Position:       decimal.NewFromFloat(1.2),
+			AggregateTypes: []AggregateType{"org"},
+			EventTypes:     []EventType{"org.added"},
+		},
+	}, got)
+}
+
+func TestPushNotification_Payload(t *testing.T) {
+	manyEventTypes := make([]EventType, 1000)
+	for i := range manyEventTypes {
+		manyEventTypes[i] = EventType(strings.Repeat("e", 10))
+	}
+	manyAggregateTypes := make([]AggregateType, 1000)
+	for i := range manyAggregateTypes {
+		manyAggregateTypes[i] = AggregateType(strings.Repeat("a", 10))
+	}
+	tests := []struct {
+		name         string
+		notification *PushNotification
+		want         string
+		wantErr      bool
+	}{
+		{
+			name: "all types",
+			notification: &PushNotification{
+				InstanceID:     "instance",
+				Position:       decimal.NewFromFloat(1.5),
+				AggregateTypes: []AggregateType{"user"},
+				EventTypes:     []EventType{"user.added"},
+			},
+			want: `{"instance":"instance","position":1.5,"aggregates":["user"],"events":["user.added"]}`,
+		},
+		{
+			name: "event types too large",
+			notification: &PushNotification{
+				InstanceID:     "instance",
+				Position:       decimal.NewFromFloat(1.5),
+				AggregateTypes: []AggregateType{"user"},
+				EventTypes:     manyEventTypes,
+			},
+			want: `{"instance":"instance","position":1.5,"aggregates":["user"]}`,
+		},
+		{
+			name: "aggregate types too large",
+			notification: &PushNotification{
+				InstanceID:     "instance",
+				Position:       decimal.NewFromFloat(1.5),
+				AggregateTypes: manyAggregateTypes,
+				EventTypes:     []EventType{"user.added"},
+			},
+			want: `{"instance":"instance","position":1.5}`,
+		},
+		{
+			name: "instance too large",
+			notification: &PushNotification{
+				InstanceID: strings.Repeat("i", maxNotificationPayload),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.notification.Payload()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+
+			decoded := new(PushNotification)
+			require.NoError(t, json.Unmarshal(got, decoded))
+			assert.Equal(t, tt.notification.InstanceID, decoded.InstanceID)
+			assert.True(t, tt.notification.Position.Equal(decoded.Position))
+		})
+	}
+}
+
+func TestNotificationSubscription_matches(t *testing.T) {
+	subscription := &NotificationSubscription{
+		types: map[AggregateType][]EventType{
+			"user": {"user.added"},
+			"org":  nil,
+		},
+	}
+	tests := []struct {
+		name         string
+		notification *PushNotification
+		want         bool
+	}{
+		{
+			name:         "without types",
+			notification: &PushNotification{},
+			want:         true,
+		},
+		{
+			name: "event type matches",
+			notification: &PushNotification{
+				AggregateTypes: []AggregateType{"user"},
+				EventTypes:     []EventType{"user.changed", "user.added"},
+			},
+			want: true,
+		},
+		{
+			name: "event type does not match",
+			notification: &PushNotification{
+				AggregateTypes: []AggregateType{"user"},
+				EventTypes:     []EventType{"user.changed"},
+			},
+			want: false,
+		},
+		{
+			name: "without event types",
+			notification: &PushNotification{
+				AggregateTypes: []AggregateType{"user"},
+			},
+			want: true,
+		},
+		{
+			name: "all events of aggregate type",
+			notification: &PushNotification{
+				AggregateTypes: []AggregateType{"org"},
+				EventTypes:     []EventType{"org.changed"},
+			},
+			want: true,
+		},
+		{
+			name: "aggregate type does not match",
+			notification: &PushNotification{
+				AggregateTypes: []AggregateType{"project"},
+				EventTypes:     []EventType{"user.added"},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, subscription.matches(tt.notification))
+		})
+	}
+}
+
+func TestNotificationSubscription_Next(t *testing.T) {
+	n := newNotifier(NotificationConfig{}, nil)
+	// prevent the listener from starting
+	n.listen.Do(func() {})
+	es := &Eventstore{notifier: n}
+
+	subscription := es.SubscribeNotifications(context.Background(), map[AggregateType][]EventType{"user": nil}, decimal.Decimal{})
+	require.NotNil(t, subscription)
+
+	n.handle(&PushNotification{InstanceID: "instance2", Position: decimal.NewFromInt(2), AggregateTypes: []AggregateType{"user"}})
+	n.handle(&PushNotification{InstanceID: "instance1", Position: decimal.NewFromInt(3), AggregateTypes: []AggregateType{"user"}})
+	n.handle(&PushNotification{InstanceID: "instance2", Position: decimal.NewFromInt(4), AggregateTypes: []AggregateType{"user"}})
+	n.handle(&PushNotification{InstanceID: "instance3", Position: decimal.NewFromInt(5), AggregateTypes: []AggregateType{"org"}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	instances, err := subscription.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"instance1", "instance2"}, instances, "notifications are merged per instance")
+	assert.True(t, decimal.NewFromInt(4).Equal(subscription.Position()))
+	assert.True(t, decimal.NewFromInt(5).Equal(n.position), "notifier tracks all positions")
+
+	subscription.Unsubscribe()
+	n.handle(&PushNotification{InstanceID: "instance1", Position: decimal.NewFromInt(6)})
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = subscription.Next(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestEventstore_SubscribeNotifications_disabled(t *testing.T) {
+	es := NewEventstore(&Config{})
+	assert.Nil(t, es.SubscribeNotifications(context.Background(), nil, decimal.Decimal{}))
+}
+
+func TestNotificationSubscription_catchUpQuery(t *testing.T) {
+	subscription := &NotificationSubscription{
+		types:    map[AggregateType][]EventType{"user": {"user.added"}},
+		position: decimal.NewFromInt(5),
+	}
+	query := subscription.catchUpQuery(decimal.NewFromInt(3))
+	assert.True(t, decimal.NewFromInt(5).Equal(query.GetPositionAtLeast()), "position of subscription is newer")
+
+	query = subscription.catchUpQuery(decimal.NewFromInt(7))
+	assert.True(t, decimal.NewFromInt(7).Equal(query.GetPositionAtLeast()), "position of notifier is newer")
+	assert.Equal(t, Columns(ColumnsInstanceIDs), query.GetColumns())
+	require.Len(t, query.GetQueries(), 1)
+	assert.Equal(t, []AggregateType{"user"}, query.GetQueries()[0].GetAggregateTypes())
+	assert.Equal(t, []EventType{"user.added"}, query.GetQueries()[0].GetEventTypes())
+}
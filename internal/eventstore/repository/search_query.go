@@ -29,6 +29,7 @@ type SearchQuery struct {
 	Creator             *Filter
 	Owner               *Filter
 	Position            *Filter
+	PositionAtMost      *Filter
 	Sequence            *Filter
 	CreatedAfter        *Filter
 	CreatedBefore       *Filter
@@ -60,6 +61,8 @@ const (
 	OperationNotIn
 
 	OperationGreaterOrEquals
+	// OperationLessOrEquals compares if the given value is less than or equal to the stored one
+	OperationLessOrEquals
 
 	operationCount
 )
@@ -143,6 +146,7 @@ func QueryFromBuilder(builder *eventstore.SearchQueryBuilder) (*SearchQuery, err
 		editorUserFilter,
 		resourceOwnerFilter,
 		positionAfterFilter,
+		positionAtMostFilter,
 		eventSequenceGreaterFilter,
 		creationDateAfterFilter,
 		creationDateBeforeFilter,
@@ -261,6 +265,14 @@ func positionAfterFilter(builder *eventstore.SearchQueryBuilder, query *SearchQu
 	return query.Position
 }
 
+func positionAtMostFilter(builder *eventstore.SearchQueryBuilder, query *SearchQuery) *Filter {
+	if builder.GetPositionAtMost().IsZero() {
+		return nil
+	}
+	query.PositionAtMost = NewFilter(FieldPosition, builder.GetPositionAtMost(), OperationLessOrEquals)
+	return query.PositionAtMost
+}
+
 func aggregateIDFilter(query *eventstore.SearchQuery) *Filter {
 	if len(query.GetAggregateIDs()) < 1 {
 		return nil
@@ -28,6 +28,7 @@ type SearchQueryBuilder struct {
 	lockRows              bool
 	lockOption            LockOption
 	positionAtLeast       decimal.Decimal
+	positionAtMost        decimal.Decimal
 	awaitOpenTransactions bool
 	creationDateAfter     time.Time
 	creationDateBefore    time.Time
@@ -82,6 +83,10 @@ func (b SearchQueryBuilder) GetPositionAtLeast() decimal.Decimal {
 	return b.positionAtLeast
 }
 
+func (b SearchQueryBuilder) GetPositionAtMost() decimal.Decimal {
+	return b.positionAtMost
+}
+
 func (b SearchQueryBuilder) GetAwaitOpenTransactions() bool {
 	return b.awaitOpenTransactions
 }
@@ -292,6 +297,12 @@ func (builder *SearchQueryBuilder) PositionAtLeast(position decimal.Decimal) *Se
 	return builder
 }
 
+// PositionAtMost filters for events which happened at or before the specified position
+func (builder *SearchQueryBuilder) PositionAtMost(position decimal.Decimal) *SearchQueryBuilder {
+	builder.positionAtMost = position
+	return builder
+}
+
 // AwaitOpenTransactions filters for events which are older than the oldest transaction of the database
 func (builder *SearchQueryBuilder) AwaitOpenTransactions() *SearchQueryBuilder {
 	builder.awaitOpenTransactions = true
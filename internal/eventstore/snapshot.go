@@ -0,0 +1,299 @@
+package eventstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SnapshotConfig configures the snapshots of write models implementing [SnapshotWriteModel].
+type SnapshotConfig struct {
+	Enabled bool
+	// Threshold is the minimum amount of events reduced after the latest snapshot before a new snapshot is stored.
+	Threshold uint32
+	// MinAge of the events included in a snapshot.
+	// The position of an event is the start of its transaction,
+	// so events of transactions running longer than MinAge could be missed by a snapshot.
+	MinAge time.Duration
+}
+
+// SnapshotWriteModel is implemented by write models which can be restored from a snapshot,
+// so only the events after the snapshot are filtered.
+// The write model is marshalled to JSON, all fields changed by Reduce must be exported
+// and the state must only depend on the events of the query.
+type SnapshotWriteModel interface {
+	QueryReducer
+	// SnapshotType identifies the write model.
+	// The version must be increased as soon as the reduce logic or the fields change,
+	// snapshots of other versions are ignored.
+	SnapshotType() (name string, version uint16)
+	writeModel() *WriteModel
+}
+
+// snapshot is the stored state of a write model after the event at position and offset.
+type snapshot struct {
+	position decimal.Decimal
+	// offset is the amount of events at the position included in the snapshot.
+	offset  uint32
+	payload *snapshotPayload
+}
+
+// snapshotPayload contains the fields of [WriteModel] which are not marshalled.
+type snapshotPayload struct {
+	AggregateID       string          `json:"aggregateId"`
+	ProcessedSequence uint64          `json:"processedSequence"`
+	ResourceOwner     string          `json:"resourceOwner"`
+	InstanceID        string          `json:"instanceId"`
+	ChangeDate        time.Time       `json:"changeDate"`
+	State             json.RawMessage `json:"state"`
+}
+
+const (
+	snapshotGetStmt = `SELECT "position", "offset", payload FROM eventstore.snapshots` +
+		` WHERE instance_id = $1 AND snapshot_type = $2 AND query_hash = $3 AND version = $4`
+	snapshotSetStmt = `INSERT INTO eventstore.snapshots (instance_id, snapshot_type, query_hash, version, "position", "offset", payload)` +
+		` VALUES ($1, $2, $3, $4, $5, $6, $7)` +
+		` ON CONFLICT (instance_id, snapshot_type, query_hash) DO UPDATE SET` +
+		` version = EXCLUDED.version, "position" = EXCLUDED."position", "offset" = EXCLUDED."offset", payload = EXCLUDED.payload, change_date = now()` +
+		` WHERE eventstore.snapshots.version <> EXCLUDED.version OR eventstore.snapshots."position" <= EXCLUDED."position"`
+)
+
+// filterWithSnapshot restores the write model from the latest snapshot and reduces the events after it.
+// A new snapshot is stored if at least [SnapshotConfig.Threshold] events were reduced.
+// Queries which cannot be snapshotted are filtered without snapshot.
+func (es *Eventstore) filterWithSnapshot(ctx context.Context, model SnapshotWriteModel) (err error) {
+	searchQuery := model.Query()
+	searchQuery.ensureInstanceID(ctx)
+	if !searchQuery.snapshotable() {
+		return es.FilterToReducer(ctx, searchQuery, model)
+	}
+
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	name, version := model.SnapshotType()
+	instanceID := *searchQuery.GetInstanceID()
+	queryHash := searchQuery.snapshotHash()
+	client := es.querier.Client()
+
+	current, err := getSnapshot(ctx, client, instanceID, name, queryHash, version)
+	logging.WithFields("snapshot", name).OnError(err).Warn("unable to load snapshot")
+	if current != nil {
+		if err = current.restore(model); err != nil {
+			return err
+		}
+		searchQuery.PositionAtLeast(current.position)
+		if current.offset > 0 {
+			searchQuery.Offset(current.offset)
+		}
+	} else {
+		current = new(snapshot)
+	}
+
+	reducer := &snapshotReducer{
+		SnapshotWriteModel: model,
+		cutoff:             positionOf(time.Now().Add(-es.snapshots.MinAge)),
+		threshold:          es.snapshots.Threshold,
+		current:            current,
+	}
+	if err = es.FilterToReducer(ctx, searchQuery, reducer); err != nil {
+		return err
+	}
+	reducer.takeSnapshot()
+	if reducer.reduceErr != nil {
+		return reducer.reduceErr
+	}
+	if reducer.err != nil {
+		logging.WithFields("snapshot", name).WithError(reducer.err).Warn("unable to take snapshot")
+		return nil
+	}
+	if reducer.next == nil {
+		return nil
+	}
+	err = setSnapshot(ctx, client, instanceID, name, queryHash, version, reducer.next)
+	logging.WithFields("snapshot", name).OnError(err).Warn("unable to store snapshot")
+	return nil
+}
+
+// snapshotReducer takes a snapshot of the write model before the first event newer than the cutoff is appended.
+type snapshotReducer struct {
+	SnapshotWriteModel
+	cutoff    decimal.Decimal
+	threshold uint32
+	// current is the position of the latest event older than the cutoff
+	current *snapshot
+	reduced uint32
+	taken   bool
+	next    *snapshot
+	err     error
+	// reduceErr is the error of reducing the events before the snapshot
+	reduceErr error
+}
+
+func (r *snapshotReducer) AppendEvents(events ...Event) {
+	for _, event := range events {
+		if event.Position().GreaterThanOrEqual(r.cutoff) {
+			r.takeSnapshot()
+		}
+		if !r.taken {
+			r.current = r.current.next(event.Position())
+			r.reduced++
+		}
+		r.SnapshotWriteModel.AppendEvents(event)
+	}
+}
+
+func (r *snapshotReducer) Reduce() error {
+	if r.reduceErr != nil {
+		return r.reduceErr
+	}
+	return r.SnapshotWriteModel.Reduce()
+}
+
+// takeSnapshot reduces the appended events and marshals the state if the threshold is reached.
+// Only the first call takes the snapshot.
+func (r *snapshotReducer) takeSnapshot() {
+	if r.taken {
+		return
+	}
+	r.taken = true
+	if r.reduced == 0 || r.reduced < r.threshold {
+		return
+	}
+	// events older and newer than the cutoff can be appended in the same call,
+	// so the older events are reduced before the state is marshalled
+	if r.reduceErr = r.SnapshotWriteModel.Reduce(); r.reduceErr != nil {
+		return
+	}
+	r.next, r.err = newSnapshot(r.SnapshotWriteModel, r.current)
+}
+
+// next moves the position of the snapshot to the event.
+func (s *snapshot) next(position decimal.Decimal) *snapshot {
+	if position.Equal(s.position) {
+		return &snapshot{position: position, offset: s.offset + 1}
+	}
+	return &snapshot{position: position, offset: 1}
+}
+
+func newSnapshot(model SnapshotWriteModel, at *snapshot) (*snapshot, error) {
+	state, err := json.Marshal(model)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "EVENT-Ohx1u", "unable to marshal snapshot")
+	}
+	writeModel := model.writeModel()
+	return &snapshot{
+		position: at.position,
+		offset:   at.offset,
+		payload: &snapshotPayload{
+			AggregateID:       writeModel.AggregateID,
+			ProcessedSequence: writeModel.ProcessedSequence,
+			ResourceOwner:     writeModel.ResourceOwner,
+			InstanceID:        writeModel.InstanceID,
+			ChangeDate:        writeModel.ChangeDate,
+			State:             state,
+		},
+	}, nil
+}
+
+func (s *snapshot) restore(model SnapshotWriteModel) error {
+	if err := json.Unmarshal(s.payload.State, model); err != nil {
+		return zerrors.ThrowInternal(err, "EVENT-ahT5e", "unable to unmarshal snapshot")
+	}
+	writeModel := model.writeModel()
+	writeModel.AggregateID = s.payload.AggregateID
+	writeModel.ProcessedSequence = s.payload.ProcessedSequence
+	writeModel.ResourceOwner = s.payload.ResourceOwner
+	writeModel.InstanceID = s.payload.InstanceID
+	writeModel.ChangeDate = s.payload.ChangeDate
+	return nil
+}
+
+func getSnapshot(ctx context.Context, client *database.DB, instanceID, name, queryHash string, version uint16) (_ *snapshot, err error) {
+	current := &snapshot{payload: new(snapshotPayload)}
+	var payload []byte
+	err = client.QueryRowContext(ctx, func(row *sql.Row) error {
+		return row.Scan(&current.position, &current.offset, &payload)
+	}, snapshotGetStmt, instanceID, name, queryHash, version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "EVENT-sei3O", "Errors.Internal")
+	}
+	if err = json.Unmarshal(payload, current.payload); err != nil {
+		return nil, zerrors.ThrowInternal(err, "EVENT-Eim6i", "unable to unmarshal snapshot")
+	}
+	return current, nil
+}
+
+func setSnapshot(ctx context.Context, client *database.DB, instanceID, name, queryHash string, version uint16, next *snapshot) error {
+	payload, err := json.Marshal(next.payload)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "EVENT-ieR3a", "unable to marshal snapshot")
+	}
+	_, err = client.ExecContext(ctx, snapshotSetStmt, instanceID, name, queryHash, version, next.position, next.offset, payload)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "EVENT-Cah7o", "Errors.Internal")
+	}
+	return nil
+}
+
+// positionOf returns the position of events created at t.
+func positionOf(t time.Time) decimal.Decimal {
+	return decimal.NewFromInt(t.UnixMicro()).Shift(-6)
+}
+
+// snapshotable returns if the query filters all events of a single instance in ascending order.
+func (b *SearchQueryBuilder) snapshotable() bool {
+	return b.columns == ColumnsEvent &&
+		!b.desc &&
+		b.limit == 0 &&
+		b.offset == 0 &&
+		b.positionAtLeast.IsZero() &&
+		b.positionAtMost.IsZero() &&
+		!b.lockRows &&
+		b.instanceID != nil &&
+		len(b.instanceIDs) == 0
+}
+
+// snapshotHash identifies the filtered events of the query.
+func (b *SearchQueryBuilder) snapshotHash() string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "owner=%s;editor=%s;created_after=%d;created_before=%d;sequence_greater=%d;",
+		b.resourceOwner,
+		b.editorUser,
+		b.creationDateAfter.UnixNano(),
+		b.creationDateBefore.UnixNano(),
+		b.eventSequenceGreater,
+	)
+	for _, query := range b.queries {
+		data, _ := json.Marshal(query.eventData)
+		fmt.Fprintf(hash, "query:aggregate_types=%v;aggregate_ids=%v;event_types=%v;data=%s;position_after=%s;",
+			query.aggregateTypes,
+			query.aggregateIDs,
+			query.eventTypes,
+			data,
+			query.positionAfter,
+		)
+	}
+	if b.excludeAggregateIDs != nil {
+		fmt.Fprintf(hash, "exclude:aggregate_types=%v;event_types=%v;",
+			b.excludeAggregateIDs.aggregateTypes,
+			b.excludeAggregateIDs.eventTypes,
+		)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
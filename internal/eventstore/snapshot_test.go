@@ -0,0 +1,235 @@
+package eventstore
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+type snapshotTestModel struct {
+	WriteModel
+
+	Count int
+}
+
+func (m *snapshotTestModel) Query() *SearchQueryBuilder {
+	return NewSearchQueryBuilder(ColumnsEvent).
+		InstanceID("instance").
+		AddQuery().
+		AggregateTypes("test.aggregate").
+		AggregateIDs("id").
+		Builder()
+}
+
+func (m *snapshotTestModel) Reduce() error {
+	m.Count += len(m.Events)
+	return m.WriteModel.Reduce()
+}
+
+func (m *snapshotTestModel) SnapshotType() (string, uint16) {
+	return "test", 1
+}
+
+func snapshotTestEvent(seq uint64, position decimal.Decimal) Event {
+	return &BaseEvent{
+		Agg:      &Aggregate{ID: "id", Type: "test.aggregate", ResourceOwner: "ro", InstanceID: "instance"},
+		Seq:      seq,
+		Pos:      position,
+		Creation: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// snapshotStateArg matches the payload of a stored snapshot by the count of the reduced events.
+type snapshotStateArg int
+
+func (a snapshotStateArg) Match(v driver.Value) bool {
+	data, ok := v.([]byte)
+	if !ok {
+		return false
+	}
+	payload := new(snapshotPayload)
+	if err := json.Unmarshal(data, payload); err != nil {
+		return false
+	}
+	model := new(snapshotTestModel)
+	if err := json.Unmarshal(payload.State, model); err != nil {
+		return false
+	}
+	return model.Count == int(a) && payload.AggregateID == "id"
+}
+
+func TestEventstore_filterWithSnapshot(t *testing.T) {
+	queryHash := (&snapshotTestModel{}).Query().snapshotHash()
+	recent := positionOf(time.Now())
+	tests := []struct {
+		name      string
+		snapshot  *snapshotPayload
+		events    []Event
+		expectSet func(sqlmock.Sqlmock)
+		wantCount int
+	}{
+		{
+			name: "no snapshot, below threshold",
+			events: []Event{
+				snapshotTestEvent(1, decimal.NewFromInt(1)),
+			},
+			wantCount: 1,
+		},
+		{
+			name: "no snapshot, take snapshot",
+			events: []Event{
+				snapshotTestEvent(1, decimal.NewFromInt(1)),
+				snapshotTestEvent(2, decimal.NewFromInt(2)),
+				snapshotTestEvent(3, decimal.NewFromInt(2)),
+			},
+			expectSet: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(snapshotSetStmt)).
+					WithArgs("instance", "test", queryHash, uint16(1), decimal.NewFromInt(2), uint32(2), snapshotStateArg(3)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantCount: 3,
+		},
+		{
+			name: "no snapshot, recent events not included",
+			events: []Event{
+				snapshotTestEvent(1, decimal.NewFromInt(1)),
+				snapshotTestEvent(2, decimal.NewFromInt(2)),
+				snapshotTestEvent(3, recent),
+			},
+			expectSet: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(snapshotSetStmt)).
+					WithArgs("instance", "test", queryHash, uint16(1), decimal.NewFromInt(2), uint32(1), snapshotStateArg(2)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantCount: 3,
+		},
+		{
+			name: "restore snapshot",
+			snapshot: &snapshotPayload{
+				AggregateID: "id",
+				State:       json.RawMessage(`{"Count":5}`),
+			},
+			events: []Event{
+				snapshotTestEvent(6, decimal.NewFromInt(3)),
+			},
+			wantCount: 6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			rows := sqlmock.NewRows([]string{"position", "offset", "payload"})
+			if tt.snapshot != nil {
+				payload, err := json.Marshal(tt.snapshot)
+				require.NoError(t, err)
+				rows.AddRow(decimal.NewFromInt(2), 1, payload)
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(snapshotGetStmt)).
+				WithArgs("instance", "test", queryHash, uint16(1)).
+				WillReturnRows(rows)
+			if tt.expectSet != nil {
+				tt.expectSet(mock)
+			}
+
+			es := NewEventstore(&Config{
+				Querier: &archiveQuerier{
+					testQuerier: testQuerier{events: tt.events},
+					client:      &database.DB{DB: db},
+				},
+				Snapshots: SnapshotConfig{
+					Enabled:   true,
+					Threshold: 2,
+					MinAge:    time.Minute,
+				},
+			})
+			model := new(snapshotTestModel)
+			require.NoError(t, es.FilterToQueryReducer(context.Background(), model))
+			assert.Equal(t, tt.wantCount, model.Count)
+			assert.Equal(t, "id", model.AggregateID)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_snapshotReducer_appendBatch(t *testing.T) {
+	reducer := &snapshotReducer{
+		SnapshotWriteModel: new(snapshotTestModel),
+		cutoff:             decimal.NewFromInt(10),
+		threshold:          2,
+		current:            new(snapshot),
+	}
+	reducer.AppendEvents(
+		snapshotTestEvent(1, decimal.NewFromInt(1)),
+		snapshotTestEvent(2, decimal.NewFromInt(2)),
+		snapshotTestEvent(3, decimal.NewFromInt(2)),
+		snapshotTestEvent(4, decimal.NewFromInt(10)),
+	)
+	require.NoError(t, reducer.Reduce())
+	reducer.takeSnapshot()
+	require.NoError(t, reducer.err)
+	require.NotNil(t, reducer.next)
+	assert.True(t, decimal.NewFromInt(2).Equal(reducer.next.position))
+	assert.Equal(t, uint32(2), reducer.next.offset)
+
+	restored := new(snapshotTestModel)
+	require.NoError(t, reducer.next.restore(restored))
+	assert.Equal(t, 3, restored.Count, "events appended with newer events must be part of the snapshot")
+	assert.Equal(t, uint64(3), restored.ProcessedSequence)
+	assert.Equal(t, 4, reducer.SnapshotWriteModel.(*snapshotTestModel).Count)
+}
+
+func TestSearchQueryBuilder_snapshotable(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *SearchQueryBuilder
+		want  bool
+	}{
+		{
+			name:  "snapshotable",
+			query: NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance").AddQuery().AggregateTypes("user").Builder(),
+			want:  true,
+		},
+		{
+			name:  "without instance",
+			query: NewSearchQueryBuilder(ColumnsEvent).AddQuery().AggregateTypes("user").Builder(),
+		},
+		{
+			name:  "descending",
+			query: NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance").OrderDesc().AddQuery().AggregateTypes("user").Builder(),
+		},
+		{
+			name:  "limit",
+			query: NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance").Limit(1).AddQuery().AggregateTypes("user").Builder(),
+		},
+		{
+			name:  "max sequence",
+			query: NewSearchQueryBuilder(ColumnsMaxPosition).InstanceID("instance").AddQuery().AggregateTypes("user").Builder(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.query.snapshotable())
+		})
+	}
+}
+
+func TestSearchQueryBuilder_snapshotHash(t *testing.T) {
+	query := func(aggregateID string) *SearchQueryBuilder {
+		return NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance").AddQuery().AggregateTypes("user").AggregateIDs(aggregateID).Builder()
+	}
+	assert.Equal(t, query("user1").snapshotHash(), query("user1").snapshotHash())
+	assert.NotEqual(t, query("user1").snapshotHash(), query("user2").snapshotHash())
+}
@@ -33,6 +33,8 @@ var (
 
 type Eventstore struct {
 	client *database.DB
+	// notificationChannel is notified about pushed events if set.
+	notificationChannel string
 }
 
 var (
@@ -161,6 +163,11 @@ func NewEventstore(client *database.DB) *Eventstore {
 	return &Eventstore{client: client}
 }
 
+// EnablePushNotifications notifies the channel about the pushed events after the push transaction committed.
+func (es *Eventstore) EnablePushNotifications(channel string) {
+	es.notificationChannel = channel
+}
+
 func (es *Eventstore) Health(ctx context.Context) error {
 	return es.client.PingContext(ctx)
 }
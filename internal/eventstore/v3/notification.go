@@ -0,0 +1,38 @@
+package eventstore
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const notifyPushStmt = "SELECT pg_notify($1, payload) FROM unnest($2::TEXT[]) AS payload"
+
+// notifyPush notifies the other nodes about the pushed events
+// with a single statement for all instances of the events.
+// Postgres delivers the notifications when the transaction of the client commits,
+// so listeners never observe events which are not visible yet.
+func (es *Eventstore) notifyPush(ctx context.Context, client database.ContextExecuter, events []eventstore.Event) (err error) {
+	if es.notificationChannel == "" || len(events) == 0 {
+		return nil
+	}
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	notifications := eventstore.NewPushNotifications(events)
+	payloads := make([]string, len(notifications))
+	for i, notification := range notifications {
+		payload, err := notification.Payload()
+		if err != nil {
+			return zerrors.ThrowInternal(err, "V3-Ohb4u", "Errors.Internal")
+		}
+		payloads[i] = string(payload)
+	}
+	if _, err = client.ExecContext(ctx, notifyPushStmt, es.notificationChannel, payloads); err != nil {
+		return zerrors.ThrowInternal(err, "V3-ieC2a", "Errors.Internal")
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package eventstore
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func TestEventstore_notifyPush(t *testing.T) {
+	events := []eventstore.Event{
+		&event{command: &command{InstanceID: "instance1", AggregateType: "user", CommandType: "user.added"}, position: decimal.NewFromInt(1)},
+		&event{command: &command{InstanceID: "instance2", AggregateType: "org", CommandType: "org.added"}, position: decimal.NewFromInt(2)},
+		&event{command: &command{InstanceID: "instance1", AggregateType: "user", CommandType: "user.changed"}, position: decimal.NewFromInt(3)},
+	}
+	tests := []struct {
+		name    string
+		channel string
+		events  []eventstore.Event
+		mock    *mock.SQLMock
+		wantErr bool
+	}{
+		{
+			name:   "notifications disabled",
+			events: events,
+			mock:   mock.NewSQLMock(t),
+		},
+		{
+			name:    "no events",
+			channel: "channel",
+			mock:    mock.NewSQLMock(t),
+		},
+		{
+			name:    "one statement for all instances",
+			channel: "channel",
+			events:  events,
+			mock: mock.NewSQLMock(t,
+				mock.ExcpectExec(notifyPushStmt,
+					mock.WithExecArgs(
+						"channel",
+						[]string{
+							`{"instance":"instance1","position":3,"aggregates":["user"],"events":["user.added","user.changed"]}`,
+							`{"instance":"instance2","position":2,"aggregates":["org"],"events":["org.added"]}`,
+						},
+					),
+					mock.WithExecRowsAffected(1),
+				),
+			),
+		},
+		{
+			name:    "notify fails",
+			channel: "channel",
+			events:  events,
+			mock: mock.NewSQLMock(t,
+				mock.ExcpectExec(notifyPushStmt,
+					mock.WithExecErr(sql.ErrConnDone),
+				),
+			),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := &Eventstore{notificationChannel: tt.channel}
+
+			err := es.notifyPush(context.Background(), &database.DB{DB: tt.mock.DB}, tt.events)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Eventstore.notifyPush() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			tt.mock.Assert(t)
+		})
+	}
+}
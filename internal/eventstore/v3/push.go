@@ -25,10 +25,19 @@ func (es *Eventstore) Push(ctx context.Context, client database.ContextQueryExec
 
 	events, err = es.writeCommands(ctx, client, commands)
 	if isSetupNotExecutedError(err) {
-		return es.pushWithoutFunc(ctx, client, commands...)
+		events, err = es.pushWithoutFunc(ctx, client, commands...)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return events, err
+	if _, ok := client.(database.Tx); ok {
+		// the caller commits the transaction, the notifications are delivered on commit
+		return events, es.notifyPush(ctx, client, events)
+	}
+	// the events are committed, instances missed by a failed notification are processed on the next trigger of the handlers
+	logging.OnError(es.notifyPush(ctx, es.client, events)).Warn("unable to notify about pushed events")
+	return events, nil
 }
 
 func (es *Eventstore) writeCommands(ctx context.Context, client database.ContextQueryExecuter, commands []eventstore.Command) (_ []eventstore.Event, err error) {
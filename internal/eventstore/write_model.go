@@ -16,6 +16,11 @@ type WriteModel struct {
 	ChangeDate        time.Time `json:"-"`
 }
 
+// writeModel implements [SnapshotWriteModel]
+func (wm *WriteModel) writeModel() *WriteModel {
+	return wm
+}
+
 // AppendEvents adds all the events to the read model.
 // The function doesn't compute the new state of the read model
 func (rm *WriteModel) AppendEvents(events ...Event) {
@@ -0,0 +1,70 @@
+package exporter
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	cloudEventSpecVersion = "1.0"
+	cloudEventContentType = "application/cloudevents+json"
+	cloudEventTypePrefix  = "com.zitadel."
+)
+
+// CloudEvent is the structured JSON format of the CloudEvents specification.
+// The attributes of the aggregate are added as extension attributes,
+// integers are represented as strings because extension integers are limited to 32 bits.
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+
+	InstanceID    string `json:"instanceid"`
+	AggregateType string `json:"aggregatetype"`
+	AggregateID   string `json:"aggregateid"`
+	ResourceOwner string `json:"resourceowner"`
+	Sequence      string `json:"sequence"`
+	Position      string `json:"position"`
+	Creator       string `json:"creator"`
+}
+
+func newCloudEvent(source string, event eventstore.Event) *CloudEvent {
+	sequence := strconv.FormatUint(event.Sequence(), 10)
+	cloudEvent := &CloudEvent{
+		SpecVersion:   cloudEventSpecVersion,
+		ID:            string(event.Aggregate().Type) + ":" + event.Aggregate().ID + ":" + sequence,
+		Source:        source + "/instances/" + event.Aggregate().InstanceID,
+		Type:          cloudEventTypePrefix + string(event.Type()),
+		Subject:       string(event.Aggregate().Type) + "/" + event.Aggregate().ID,
+		Time:          event.CreatedAt(),
+		InstanceID:    event.Aggregate().InstanceID,
+		AggregateType: string(event.Aggregate().Type),
+		AggregateID:   event.Aggregate().ID,
+		ResourceOwner: event.Aggregate().ResourceOwner,
+		Sequence:      sequence,
+		Position:      event.Position().String(),
+		Creator:       event.Creator(),
+	}
+	if payload := event.DataAsBytes(); len(payload) > 0 && json.Valid(payload) {
+		cloudEvent.DataContentType = "application/json"
+		cloudEvent.Data = payload
+	}
+	return cloudEvent
+}
+
+func (e *CloudEvent) marshal() ([]byte, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "EXPOR-Aeb3o", "unable to marshal cloud event")
+	}
+	return data, nil
+}
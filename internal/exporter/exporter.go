@@ -0,0 +1,293 @@
+// Package exporter publishes the events of all instances as CloudEvents to a message bus.
+//
+// Each exporter is a [handler.Handler] without tables:
+// its position is stored per instance in the current states of the projections
+// and failed publications are counted in the failed events of the projections.
+// Events are published one after another in the order of the eventstore,
+// a failed event blocks the following events of its aggregate until it was published,
+// so the order per aggregate is kept ([handler.BlockingProjection]).
+// Events rejected permanently by the message bus are skipped after the max failure count of the projection.
+// Events are published at least once, consumers must handle duplicates, for example by the id of the CloudEvent.
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	projectionPrefix = "projections.exporter_"
+
+	defaultTopic  = "zitadel.{{.InstanceID}}.{{.AggregateType}}"
+	defaultSource = "zitadel"
+)
+
+type Config struct {
+	Enabled bool
+	// Kafka publishes the events to Kafka, either Kafka or NATS must be configured.
+	Kafka *KafkaConfig
+	// NATS publishes the events to NATS JetStream, either Kafka or NATS must be configured.
+	NATS *NATSConfig
+	// Topic is a [text/template] of the Kafka topic or NATS subject of an event.
+	// The fields InstanceID, AggregateType, AggregateID, ResourceOwner and EventType can be used.
+	Topic string
+	// Source is the prefix of the source attribute of the CloudEvents, the instance is appended.
+	Source string
+	// AggregateTypes restricts the events to the aggregate types.
+	AggregateTypes []string
+	// EventTypes restricts the events to the event types.
+	EventTypes []string
+	// PublishTimeout is the maximum time to wait for the acknowledgement of the message bus.
+	PublishTimeout time.Duration
+	// Backoff delays the next publication of a failed event based on its failure count.
+	Backoff BackoffConfig
+}
+
+type BackoffConfig struct {
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+}
+
+// delay doubles the initial interval for each failure up to the max interval.
+func (c BackoffConfig) delay(failureCount uint8) time.Duration {
+	if failureCount == 0 || c.InitialInterval <= 0 {
+		return 0
+	}
+	delay := c.InitialInterval
+	for i := uint8(1); i < failureCount; i++ {
+		delay *= 2
+		if c.MaxInterval > 0 && delay >= c.MaxInterval {
+			return c.MaxInterval
+		}
+	}
+	if c.MaxInterval > 0 && delay > c.MaxInterval {
+		return c.MaxInterval
+	}
+	return delay
+}
+
+// Publisher sends messages to a message bus.
+type Publisher interface {
+	// Publish returns after the message bus acknowledged the message.
+	// Errors which are resolved by retries must be unavailable errors ([zerrors.ThrowUnavailable]).
+	Publish(ctx context.Context, message *Message) error
+	Close() error
+}
+
+type Message struct {
+	Topic string
+	// Key is the same for all events of an aggregate.
+	Key string
+	// ID is unique for each event.
+	ID          string
+	ContentType string
+	Data        []byte
+}
+
+var (
+	exporters  []*handler.Handler
+	publishers []Publisher
+)
+
+// Register creates the handlers of the enabled exporters.
+// All exporters share the handler config, it is customized by the "exporters" projection customizations.
+func Register(ctx context.Context, config handler.Config, configs map[string]*Config, eventTypes []string) error {
+	for name, exporterConfig := range configs {
+		if exporterConfig == nil || !exporterConfig.Enabled {
+			continue
+		}
+		publisher, err := newPublisher(exporterConfig)
+		if err != nil {
+			return err
+		}
+		e, err := newExporter(name, exporterConfig, eventTypes, eventstore.AggregateTypeFromEventType, publisher)
+		if err != nil {
+			return err
+		}
+		logging.WithFields("exporter", name).Info("register event exporter")
+		publishers = append(publishers, publisher)
+		exporters = append(exporters, handler.NewHandler(ctx, &config, e))
+	}
+	return nil
+}
+
+// Start publishes the events of the registered exporters in the background
+// and closes the publishers as soon as the context is done.
+func Start(ctx context.Context) {
+	if len(exporters) == 0 {
+		return
+	}
+	for _, h := range exporters {
+		h.Start(ctx)
+	}
+	go func() {
+		<-ctx.Done()
+		for _, publisher := range publishers {
+			logging.OnError(publisher.Close()).Warn("unable to close event exporter")
+		}
+	}()
+}
+
+func newPublisher(config *Config) (Publisher, error) {
+	switch {
+	case config.Kafka != nil && config.NATS != nil:
+		return nil, zerrors.ThrowInvalidArgument(nil, "EXPOR-Ohd4u", "either Kafka or NATS must be configured")
+	case config.Kafka != nil:
+		return newKafkaPublisher(config.Kafka)
+	case config.NATS != nil:
+		return newNATSPublisher(config.NATS)
+	default:
+		return nil, zerrors.ThrowInvalidArgument(nil, "EXPOR-Xie5a", "either Kafka or NATS must be configured")
+	}
+}
+
+type exporter struct {
+	name      string
+	config    *Config
+	topic     *template.Template
+	reducers  []handler.AggregateReducer
+	publisher Publisher
+}
+
+func newExporter(
+	name string,
+	config *Config,
+	eventTypes []string,
+	aggregateTypeFromEventType func(typ eventstore.EventType) eventstore.AggregateType,
+	publisher Publisher,
+) (*exporter, error) {
+	if config.Topic == "" {
+		config.Topic = defaultTopic
+	}
+	if config.Source == "" {
+		config.Source = defaultSource
+	}
+	if config.PublishTimeout <= 0 {
+		config.PublishTimeout = 10 * time.Second
+	}
+	topic, err := template.New(name).Option("missingkey=error").Parse(config.Topic)
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "EXPOR-eeT8u", "invalid topic template")
+	}
+	e := &exporter{
+		name:      name,
+		config:    config,
+		topic:     topic,
+		publisher: publisher,
+	}
+	e.reducers = e.aggregateReducers(eventTypes, aggregateTypeFromEventType)
+	return e, nil
+}
+
+// Name implements [handler.Projection].
+func (e *exporter) Name() string {
+	return projectionPrefix + e.name
+}
+
+// Reducers implements [handler.Projection].
+func (e *exporter) Reducers() []handler.AggregateReducer {
+	return e.reducers
+}
+
+// FilterGlobalEvents implements [handler.GlobalProjection]
+func (e *exporter) FilterGlobalEvents() {}
+
+// RetryDelay implements [handler.BlockingProjection]
+func (e *exporter) RetryDelay(failureCount uint8) time.Duration {
+	return e.config.Backoff.delay(failureCount)
+}
+
+// IsPermanent implements [handler.BlockingProjection]
+// Only unavailable errors of the publisher are resolved by retries,
+// for example messages rejected by the message bus or failed reduces are not.
+func (e *exporter) IsPermanent(err error) bool {
+	return !zerrors.IsUnavailable(err)
+}
+
+func (e *exporter) aggregateReducers(eventTypes []string, aggregateTypeFromEventType func(typ eventstore.EventType) eventstore.AggregateType) []handler.AggregateReducer {
+	if len(e.config.EventTypes) > 0 {
+		eventTypes = e.config.EventTypes
+	}
+	aggList := make(map[eventstore.AggregateType][]eventstore.EventType)
+	for _, eventType := range eventTypes {
+		aggType := aggregateTypeFromEventType(eventstore.EventType(eventType))
+		if len(e.config.AggregateTypes) > 0 && !slices.Contains(e.config.AggregateTypes, string(aggType)) {
+			continue
+		}
+		if !slices.Contains(aggList[aggType], eventstore.EventType(eventType)) {
+			aggList[aggType] = append(aggList[aggType], eventstore.EventType(eventType))
+		}
+	}
+
+	aggReducers := make([]handler.AggregateReducer, 0, len(aggList))
+	for aggType, aggEventTypes := range aggList {
+		eventReducers := make([]handler.EventReducer, len(aggEventTypes))
+		for i, eventType := range aggEventTypes {
+			eventReducers[i] = handler.EventReducer{
+				Event:  eventType,
+				Reduce: e.reduce,
+			}
+		}
+		aggReducers = append(aggReducers, handler.AggregateReducer{
+			Aggregate:     aggType,
+			EventReducers: eventReducers,
+		})
+	}
+	return aggReducers
+}
+
+func (e *exporter) reduce(event eventstore.Event) (*handler.Statement, error) {
+	message, err := e.message(event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewStatement(event, func(handler.Executer, string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), e.config.PublishTimeout)
+		defer cancel()
+		return e.publisher.Publish(ctx, message)
+	}), nil
+}
+
+type topicData struct {
+	InstanceID    string
+	AggregateType string
+	AggregateID   string
+	ResourceOwner string
+	EventType     string
+}
+
+func (e *exporter) message(event eventstore.Event) (*Message, error) {
+	var topic bytes.Buffer
+	err := e.topic.Execute(&topic, &topicData{
+		InstanceID:    event.Aggregate().InstanceID,
+		AggregateType: string(event.Aggregate().Type),
+		AggregateID:   event.Aggregate().ID,
+		ResourceOwner: event.Aggregate().ResourceOwner,
+		EventType:     string(event.Type()),
+	})
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "EXPOR-ua6Ai", "unable to execute topic template")
+	}
+	cloudEvent := newCloudEvent(e.config.Source, event)
+	data, err := cloudEvent.marshal()
+	if err != nil {
+		return nil, err
+	}
+	return &Message{
+		Topic:       topic.String(),
+		Key:         strings.Join([]string{event.Aggregate().InstanceID, string(event.Aggregate().Type), event.Aggregate().ID}, "/"),
+		ID:          cloudEvent.Source + "/" + cloudEvent.ID,
+		ContentType: cloudEventContentType,
+		Data:        data,
+	}, nil
+}
@@ -0,0 +1,245 @@
+package exporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	natsserver "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kerr"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func testEvent(eventType eventstore.EventType, sequence uint64) eventstore.Event {
+	return &eventstore.BaseEvent{
+		EventType: eventType,
+		Agg: &eventstore.Aggregate{
+			ID:            "userID",
+			Type:          "user",
+			ResourceOwner: "orgID",
+			InstanceID:    "instanceID",
+		},
+		Seq:      sequence,
+		Pos:      decimal.NewFromFloat(1712662457.365215),
+		Creation: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		User:     "creatorID",
+		Data:     []byte(`{"userName":"gigi"}`),
+	}
+}
+
+func aggregateTypeFromEventType(typ eventstore.EventType) eventstore.AggregateType {
+	return map[eventstore.EventType]eventstore.AggregateType{
+		"user.added":   "user",
+		"user.removed": "user",
+		"org.added":    "org",
+	}[typ]
+}
+
+func runJetStream(t *testing.T) (url string, js jetstream.JetStream) {
+	opts := natsserver.DefaultTestOptions
+	opts.Port = server.RANDOM_PORT
+	opts.JetStream = true
+	opts.StoreDir = t.TempDir()
+	srv := natsserver.RunServer(&opts)
+	t.Cleanup(srv.Shutdown)
+
+	publisher, err := newNATSPublisher(&NATSConfig{URL: srv.ClientURL()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = publisher.Close() })
+	_, err = publisher.js.CreateStream(context.Background(), jetstream.StreamConfig{
+		Name:     "zitadel",
+		Subjects: []string{"zitadel.>"},
+	})
+	require.NoError(t, err)
+	return srv.ClientURL(), publisher.js
+}
+
+func TestBackoffConfig_delay(t *testing.T) {
+	config := BackoffConfig{InitialInterval: time.Second, MaxInterval: 5 * time.Second}
+	assert.Equal(t, time.Duration(0), config.delay(0))
+	assert.Equal(t, time.Second, config.delay(1))
+	assert.Equal(t, 2*time.Second, config.delay(2))
+	assert.Equal(t, 4*time.Second, config.delay(3))
+	assert.Equal(t, 5*time.Second, config.delay(4))
+	assert.Equal(t, 5*time.Second, config.delay(255))
+	assert.Equal(t, time.Duration(0), BackoffConfig{}.delay(3))
+}
+
+func Test_exporter_Reducers(t *testing.T) {
+	eventTypes := []string{"user.added", "user.removed", "org.added"}
+	tests := []struct {
+		name   string
+		config *Config
+		want   map[eventstore.AggregateType][]eventstore.EventType
+	}{
+		{
+			name:   "all events",
+			config: &Config{},
+			want: map[eventstore.AggregateType][]eventstore.EventType{
+				"user": {"user.added", "user.removed"},
+				"org":  {"org.added"},
+			},
+		},
+		{
+			name:   "aggregate types",
+			config: &Config{AggregateTypes: []string{"org"}},
+			want: map[eventstore.AggregateType][]eventstore.EventType{
+				"org": {"org.added"},
+			},
+		},
+		{
+			name:   "event types",
+			config: &Config{EventTypes: []string{"user.removed"}},
+			want: map[eventstore.AggregateType][]eventstore.EventType{
+				"user": {"user.removed"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := newExporter("test", tt.config, eventTypes, aggregateTypeFromEventType, nil)
+			require.NoError(t, err)
+			assert.Equal(t, "projections.exporter_test", e.Name())
+			got := make(map[eventstore.AggregateType][]eventstore.EventType)
+			for _, reducer := range e.Reducers() {
+				for _, eventReducer := range reducer.EventReducers {
+					got[reducer.Aggregate] = append(got[reducer.Aggregate], eventReducer.Event)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_exporter_message(t *testing.T) {
+	e, err := newExporter("test", &Config{
+		Topic:  "zitadel.{{.InstanceID}}.{{.ResourceOwner}}.{{.EventType}}",
+		Source: "https://zitadel.example.com",
+	}, nil, aggregateTypeFromEventType, nil)
+	require.NoError(t, err)
+
+	message, err := e.message(testEvent("user.added", 3))
+	require.NoError(t, err)
+	assert.Equal(t, "zitadel.instanceID.orgID.user.added", message.Topic)
+	assert.Equal(t, "instanceID/user/userID", message.Key)
+	assert.Equal(t, "https://zitadel.example.com/instances/instanceID/user:userID:3", message.ID)
+	assert.Equal(t, "application/cloudevents+json", message.ContentType)
+	assert.JSONEq(t, `{
+		"specversion": "1.0",
+		"id": "user:userID:3",
+		"source": "https://zitadel.example.com/instances/instanceID",
+		"type": "com.zitadel.user.added",
+		"subject": "user/userID",
+		"time": "2024-01-01T00:00:00Z",
+		"datacontenttype": "application/json",
+		"data": {"userName": "gigi"},
+		"instanceid": "instanceID",
+		"aggregatetype": "user",
+		"aggregateid": "userID",
+		"resourceowner": "orgID",
+		"sequence": "3",
+		"position": "1712662457.365215",
+		"creator": "creatorID"
+	}`, string(message.Data))
+
+	_, err = newExporter("test", &Config{Topic: "{{.Invalid"}, nil, aggregateTypeFromEventType, nil)
+	require.Error(t, err)
+	e, err = newExporter("test", &Config{Topic: "{{.Unknown}}"}, nil, aggregateTypeFromEventType, nil)
+	require.NoError(t, err)
+	_, err = e.message(testEvent("user.added", 3))
+	require.Error(t, err)
+}
+
+func Test_exporter_publishNATS(t *testing.T) {
+	url, js := runJetStream(t)
+	publisher, err := newNATSPublisher(&NATSConfig{URL: url})
+	require.NoError(t, err)
+	defer publisher.Close()
+
+	e, err := newExporter("test", &Config{}, []string{"user.added", "user.removed"}, aggregateTypeFromEventType, publisher)
+	require.NoError(t, err)
+
+	events := []eventstore.Event{
+		testEvent("user.added", 1),
+		testEvent("user.removed", 2),
+		// redelivery of a published event
+		testEvent("user.removed", 2),
+	}
+	for _, event := range events {
+		statement, err := e.reduce(event)
+		require.NoError(t, err)
+		require.NoError(t, statement.Execute(nil, e.Name()))
+	}
+
+	stream, err := js.Stream(context.Background(), "zitadel")
+	require.NoError(t, err)
+	info, err := stream.Info(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), info.State.Msgs, "duplicate is detected by the message id")
+
+	consumer, err := stream.OrderedConsumer(context.Background(), jetstream.OrderedConsumerConfig{})
+	require.NoError(t, err)
+	for _, wantType := range []string{"com.zitadel.user.added", "com.zitadel.user.removed"} {
+		msg, err := consumer.Next(jetstream.FetchMaxWait(time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, "zitadel.instanceID.user", msg.Subject())
+		assert.Equal(t, "application/cloudevents+json", msg.Headers().Get("Content-Type"))
+		cloudEvent := new(CloudEvent)
+		require.NoError(t, json.Unmarshal(msg.Data(), cloudEvent))
+		assert.Equal(t, wantType, cloudEvent.Type)
+	}
+}
+
+func Test_exporter_publishFailed(t *testing.T) {
+	_, js := runJetStream(t)
+	e, err := newExporter("test", &Config{
+		Topic:          "unbound.{{.InstanceID}}",
+		PublishTimeout: 100 * time.Millisecond,
+	}, []string{"user.added"}, aggregateTypeFromEventType, &natsPublisher{js: js})
+	require.NoError(t, err)
+
+	statement, err := e.reduce(testEvent("user.added", 1))
+	require.NoError(t, err)
+	err = statement.Execute(nil, e.Name())
+	require.Error(t, err, "subject is not bound to a stream")
+	assert.False(t, e.IsPermanent(err), "the stream can be created by the operator")
+}
+
+func Test_exporter_RetryDelay(t *testing.T) {
+	e, err := newExporter("test", &Config{
+		Backoff: BackoffConfig{InitialInterval: time.Second, MaxInterval: time.Minute},
+	}, nil, aggregateTypeFromEventType, nil)
+	require.NoError(t, err)
+
+	var projection handler.Projection = e
+	blocking, ok := projection.(handler.BlockingProjection)
+	require.True(t, ok, "failed events must block their aggregate")
+	assert.Equal(t, time.Second, blocking.RetryDelay(1))
+	assert.Equal(t, 2*time.Second, blocking.RetryDelay(2))
+}
+
+func Test_exporter_IsPermanent(t *testing.T) {
+	e, err := newExporter("test", &Config{}, nil, aggregateTypeFromEventType, nil)
+	require.NoError(t, err)
+
+	assert.False(t, e.IsPermanent(zerrors.ThrowUnavailable(nil, "TEST-Ahs3o", "unavailable")))
+	assert.True(t, e.IsPermanent(zerrors.ThrowInvalidArgument(nil, "TEST-Bie5u", "rejected")))
+	assert.True(t, e.IsPermanent(zerrors.ThrowInternal(nil, "TEST-Koo3e", "reduce failed")))
+}
+
+func Test_isRejectedByKafka(t *testing.T) {
+	assert.True(t, isRejectedByKafka(kerr.MessageTooLarge))
+	assert.True(t, isRejectedByKafka(fmt.Errorf("produce: %w", kerr.InvalidRecord)))
+	assert.False(t, isRejectedByKafka(kerr.NotLeaderForPartition))
+	assert.False(t, isRejectedByKafka(context.DeadlineExceeded))
+}
@@ -0,0 +1,84 @@
+package exporter
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type KafkaConfig struct {
+	Brokers  []string
+	ClientID string
+	// TLS enables TLS with the system root certificates.
+	TLS bool
+	// Username and Password authenticate using SASL/PLAIN if set.
+	Username string
+	Password string
+}
+
+// kafkaPublisher produces the messages with the key of the aggregate,
+// so all events of an aggregate are written to the same partition.
+type kafkaPublisher struct {
+	client *kgo.Client
+}
+
+func newKafkaPublisher(config *KafkaConfig) (*kafkaPublisher, error) {
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(config.Brokers...),
+		// the events are published one after another, retries of the client must not reorder them
+		kgo.MaxProduceRequestsInflightPerBroker(1),
+	}
+	if config.ClientID != "" {
+		opts = append(opts, kgo.ClientID(config.ClientID))
+	}
+	if config.TLS {
+		opts = append(opts, kgo.DialTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12}))
+	}
+	if config.Username != "" {
+		opts = append(opts, kgo.SASL(plain.Auth{User: config.Username, Pass: config.Password}.AsMechanism()))
+	}
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "EXPOR-ahP5i", "invalid kafka config")
+	}
+	return &kafkaPublisher{client: client}, nil
+}
+
+func (p *kafkaPublisher) Publish(ctx context.Context, message *Message) error {
+	record := &kgo.Record{
+		Topic: message.Topic,
+		Key:   []byte(message.Key),
+		Value: message.Data,
+		Headers: []kgo.RecordHeader{
+			{Key: "content-type", Value: []byte(message.ContentType)},
+		},
+	}
+	err := p.client.ProduceSync(ctx, record).FirstErr()
+	if err == nil {
+		return nil
+	}
+	if isRejectedByKafka(err) {
+		return zerrors.ThrowInvalidArgument(err, "EXPOR-Uo4ka", "message rejected by kafka")
+	}
+	return zerrors.ThrowUnavailable(err, "EXPOR-Tho6e", "unable to publish to kafka")
+}
+
+// isRejectedByKafka returns if the record itself is invalid, so it will never be accepted.
+func isRejectedByKafka(err error) bool {
+	return errors.Is(err, kerr.MessageTooLarge) ||
+		errors.Is(err, kerr.RecordListTooLarge) ||
+		errors.Is(err, kerr.InvalidRecord) ||
+		errors.Is(err, kerr.CorruptMessage) ||
+		errors.Is(err, kerr.InvalidTimestamp)
+}
+
+func (p *kafkaPublisher) Close() error {
+	p.client.Close()
+	return nil
+}
@@ -0,0 +1,64 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type NATSConfig struct {
+	// URL of the NATS servers, multiple servers are separated by commas.
+	URL string
+	// CredentialsFile is the path to a file containing the user JWT and NKey seed.
+	CredentialsFile string
+	// Token authenticates the connection if set.
+	Token string
+}
+
+// natsPublisher publishes the messages to JetStream.
+// The subjects must be bound to a stream, the message id is used for the duplicate detection of the stream.
+type natsPublisher struct {
+	conn *nats.Conn
+	js   jetstream.JetStream
+}
+
+func newNATSPublisher(config *NATSConfig) (*natsPublisher, error) {
+	opts := []nats.Option{nats.Name("zitadel-exporter")}
+	if config.CredentialsFile != "" {
+		opts = append(opts, nats.UserCredentials(config.CredentialsFile))
+	}
+	if config.Token != "" {
+		opts = append(opts, nats.Token(config.Token))
+	}
+	conn, err := nats.Connect(config.URL, opts...)
+	if err != nil {
+		return nil, zerrors.ThrowUnavailable(err, "EXPOR-Ieg3a", "unable to connect to nats")
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, zerrors.ThrowInternal(err, "EXPOR-oog0E", "unable to create jetstream context")
+	}
+	return &natsPublisher{conn: conn, js: js}, nil
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, message *Message) error {
+	msg := nats.NewMsg(message.Topic)
+	msg.Header.Set("Content-Type", message.ContentType)
+	msg.Data = message.Data
+	if _, err := p.js.PublishMsg(ctx, msg, jetstream.WithMsgID(message.ID)); err != nil {
+		if errors.Is(err, nats.ErrMaxPayload) || errors.Is(err, nats.ErrBadSubject) {
+			return zerrors.ThrowInvalidArgument(err, "EXPOR-ahG8e", "message rejected by nats")
+		}
+		return zerrors.ThrowUnavailable(err, "EXPOR-wai4E", "unable to publish to nats")
+	}
+	return nil
+}
+
+func (p *natsPublisher) Close() error {
+	return p.conn.Drain()
+}
@@ -0,0 +1,317 @@
+package mapping
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var registerModifiers sync.Once
+
+// RegisterModifiers adds the modifiers used in the expressions of a [Mapping] to the [gjson] syntax.
+// As gjson keeps them in a global registry, which is not safe for concurrent use,
+// it must be called once on startup, before any mapping is evaluated.
+func RegisterModifiers() {
+	registerModifiers.Do(func() {
+		gjson.AddModifier("lower", func(json, _ string) string {
+			return stringModifier(json, strings.ToLower)
+		})
+		gjson.AddModifier("upper", func(json, _ string) string {
+			return stringModifier(json, strings.ToUpper)
+		})
+		gjson.AddModifier("trim", func(json, _ string) string {
+			return stringModifier(json, strings.TrimSpace)
+		})
+		gjson.AddModifier("first", firstModifier)
+		gjson.AddModifier("concat", concatModifier)
+	})
+}
+
+// Mapping defines how the claims of a federated user (id_token, userinfo or any other JSON payload)
+// are mapped into the fields of an [idp.User], metadata and user grants.
+//
+// Each expression is a [gjson] path (e.g. `email`, `address.country`, `emails.0`
+// or `[given_name,family_name]|@concat:" "`), extended by the following modifiers (see [RegisterModifiers]):
+//   - @lower / @upper / @trim: transforms a string
+//   - @first: returns the first element of an array
+//   - @concat: joins the non-empty elements of an array with the optional separator argument (e.g. `@concat:" "`)
+//
+// An empty expression will keep the value of the default mapping of the provider.
+//
+// [gjson]: https://github.com/tidwall/gjson/blob/master/SYNTAX.md
+type Mapping struct {
+	ID                string             `json:"id,omitempty"`
+	FirstName         string             `json:"firstName,omitempty"`
+	LastName          string             `json:"lastName,omitempty"`
+	DisplayName       string             `json:"displayName,omitempty"`
+	NickName          string             `json:"nickName,omitempty"`
+	PreferredUsername string             `json:"preferredUsername,omitempty"`
+	Email             string             `json:"email,omitempty"`
+	EmailVerified     string             `json:"emailVerified,omitempty"`
+	Phone             string             `json:"phone,omitempty"`
+	PhoneVerified     string             `json:"phoneVerified,omitempty"`
+	PreferredLanguage string             `json:"preferredLanguage,omitempty"`
+	AvatarURL         string             `json:"avatarUrl,omitempty"`
+	Profile           string             `json:"profile,omitempty"`
+	Metadata          []*MetadataMapping `json:"metadata,omitempty"`
+	Grants            []*GrantMapping    `json:"grants,omitempty"`
+}
+
+// MetadataMapping sets the result of the Expression as metadata with the specified Key.
+// Strings are stored as is, any other JSON value in its raw form.
+type MetadataMapping struct {
+	Key        string `json:"key"`
+	Expression string `json:"expression"`
+}
+
+// GrantMapping grants the RoleKeys of the project (or the project grant) to the user,
+// if the Condition evaluates to a truthy value (e.g. `groups.#(=="admins")` or `is_admin`).
+type GrantMapping struct {
+	Condition      string   `json:"condition"`
+	ProjectID      string   `json:"projectId"`
+	ProjectGrantID string   `json:"projectGrantId,omitempty"`
+	RoleKeys       []string `json:"roleKeys"`
+	// ProjectResourceOwner is the organization of the project, the grants are created in.
+	// It is set when the mapping is saved.
+	ProjectResourceOwner string `json:"projectResourceOwner,omitempty"`
+}
+
+// IsEmpty returns true if neither a field, metadata nor grant is mapped.
+func (m *Mapping) IsEmpty() bool {
+	if m == nil {
+		return true
+	}
+	for _, expression := range m.fields() {
+		if expression != "" {
+			return false
+		}
+	}
+	return len(m.Metadata) == 0 && len(m.Grants) == 0
+}
+
+// Validate checks that all metadata keys are set and unique and all grants are complete.
+func (m *Mapping) Validate() error {
+	if m == nil {
+		return nil
+	}
+	keys := make(map[string]struct{}, len(m.Metadata))
+	for _, metadata := range m.Metadata {
+		metadata.Key = strings.TrimSpace(metadata.Key)
+		metadata.Expression = strings.TrimSpace(metadata.Expression)
+		if metadata.Key == "" || metadata.Expression == "" {
+			return zerrors.ThrowInvalidArgument(nil, "MAPPING-Ahx3o", "Errors.IDP.ClaimMapping.MetadataInvalid")
+		}
+		if _, ok := keys[metadata.Key]; ok {
+			return zerrors.ThrowInvalidArgument(nil, "MAPPING-ooC8e", "Errors.IDP.ClaimMapping.MetadataKeyDuplicate")
+		}
+		keys[metadata.Key] = struct{}{}
+	}
+	for _, grant := range m.Grants {
+		grant.Condition = strings.TrimSpace(grant.Condition)
+		grant.ProjectID = strings.TrimSpace(grant.ProjectID)
+		grant.ProjectGrantID = strings.TrimSpace(grant.ProjectGrantID)
+		if grant.Condition == "" || grant.ProjectID == "" || len(grant.RoleKeys) == 0 {
+			return zerrors.ThrowInvalidArgument(nil, "MAPPING-Eeth4", "Errors.IDP.ClaimMapping.GrantInvalid")
+		}
+	}
+	return nil
+}
+
+func (m *Mapping) fields() []string {
+	return []string{
+		m.ID,
+		m.FirstName,
+		m.LastName,
+		m.DisplayName,
+		m.NickName,
+		m.PreferredUsername,
+		m.Email,
+		m.EmailVerified,
+		m.Phone,
+		m.PhoneVerified,
+		m.PreferredLanguage,
+		m.AvatarURL,
+		m.Profile,
+	}
+}
+
+// Result is the outcome of an evaluated [Mapping].
+// Fields without an expression or a matching claim are nil.
+type Result struct {
+	ID                *string
+	FirstName         *string
+	LastName          *string
+	DisplayName       *string
+	NickName          *string
+	PreferredUsername *string
+	Email             *string
+	EmailVerified     *bool
+	Phone             *string
+	PhoneVerified     *bool
+	PreferredLanguage *string
+	AvatarURL         *string
+	Profile           *string
+	Metadata          []*domain.Metadata
+	Grants            []*domain.UserGrant
+}
+
+// Evaluate applies the mapping on the JSON payload.
+func (m *Mapping) Evaluate(payload []byte) (*Result, error) {
+	if !gjson.ValidBytes(payload) {
+		return nil, zerrors.ThrowInvalidArgument(nil, "MAPPING-Iek5u", "Errors.IDP.ClaimMapping.PayloadInvalid")
+	}
+	result := new(Result)
+	if m == nil {
+		return result, nil
+	}
+	result.ID = evaluateString(payload, m.ID)
+	result.FirstName = evaluateString(payload, m.FirstName)
+	result.LastName = evaluateString(payload, m.LastName)
+	result.DisplayName = evaluateString(payload, m.DisplayName)
+	result.NickName = evaluateString(payload, m.NickName)
+	result.PreferredUsername = evaluateString(payload, m.PreferredUsername)
+	result.Email = evaluateString(payload, m.Email)
+	result.EmailVerified = evaluateBool(payload, m.EmailVerified)
+	result.Phone = evaluateString(payload, m.Phone)
+	result.PhoneVerified = evaluateBool(payload, m.PhoneVerified)
+	result.PreferredLanguage = evaluateString(payload, m.PreferredLanguage)
+	result.AvatarURL = evaluateString(payload, m.AvatarURL)
+	result.Profile = evaluateString(payload, m.Profile)
+
+	for _, metadata := range m.Metadata {
+		value := gjson.GetBytes(payload, metadata.Expression)
+		if !value.Exists() || value.Type == gjson.Null {
+			continue
+		}
+		raw := []byte(value.Raw)
+		if value.Type == gjson.String {
+			raw = []byte(value.Str)
+		}
+		if len(raw) == 0 {
+			continue
+		}
+		result.Metadata = append(result.Metadata, &domain.Metadata{Key: metadata.Key, Value: raw})
+	}
+	for _, grant := range m.Grants {
+		if !isTruthy(gjson.GetBytes(payload, grant.Condition)) {
+			continue
+		}
+		result.Grants = append(result.Grants, &domain.UserGrant{
+			ObjectRoot:     es_models.ObjectRoot{ResourceOwner: grant.ProjectResourceOwner},
+			ProjectID:      grant.ProjectID,
+			ProjectGrantID: grant.ProjectGrantID,
+			RoleKeys:       grant.RoleKeys,
+		})
+	}
+	return result, nil
+}
+
+// IDTokenPayload returns the claims of the id_token to be evaluated by a [Mapping].
+// The signature of the token is not verified, it must only be used for already verified tokens or for testing a mapping.
+func IDTokenPayload(idToken string) ([]byte, error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return nil, zerrors.ThrowInvalidArgument(nil, "MAPPING-Ohd7a", "Errors.IDP.ClaimMapping.IDTokenInvalid")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "MAPPING-Ro4ie", "Errors.IDP.ClaimMapping.IDTokenInvalid")
+	}
+	return payload, nil
+}
+
+func evaluateString(payload []byte, expression string) *string {
+	if expression == "" {
+		return nil
+	}
+	value := gjson.GetBytes(payload, expression)
+	if !value.Exists() || value.Type == gjson.Null {
+		return nil
+	}
+	s := value.String()
+	return &s
+}
+
+func evaluateBool(payload []byte, expression string) *bool {
+	if expression == "" {
+		return nil
+	}
+	value := gjson.GetBytes(payload, expression)
+	if !value.Exists() || value.Type == gjson.Null {
+		return nil
+	}
+	b := value.Bool()
+	return &b
+}
+
+// isTruthy returns true for existing values, which are neither false, zero, empty strings nor empty arrays and objects.
+func isTruthy(value gjson.Result) bool {
+	switch value.Type {
+	case gjson.Null:
+		return false
+	case gjson.False:
+		return false
+	case gjson.True:
+		return true
+	case gjson.Number:
+		return value.Num != 0
+	case gjson.String:
+		return value.Str != ""
+	case gjson.JSON:
+		if value.IsArray() {
+			return len(value.Array()) > 0
+		}
+		return len(value.Map()) > 0
+	}
+	return false
+}
+
+func stringModifier(json string, fn func(string) string) string {
+	value := gjson.Parse(json)
+	if value.Type != gjson.String {
+		return json
+	}
+	return marshalString(fn(value.Str))
+}
+
+func firstModifier(json, _ string) string {
+	value := gjson.Parse(json)
+	if !value.IsArray() {
+		return json
+	}
+	elements := value.Array()
+	if len(elements) == 0 {
+		return ""
+	}
+	return elements[0].Raw
+}
+
+func concatModifier(json, arg string) string {
+	value := gjson.Parse(json)
+	if !value.IsArray() {
+		return json
+	}
+	separator := arg
+	if strings.HasPrefix(arg, `"`) {
+		separator = gjson.Parse(arg).String()
+	}
+	parts := make([]string, 0, len(value.Array()))
+	for _, element := range value.Array() {
+		if s := element.String(); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return marshalString(strings.Join(parts, separator))
+}
+
+func marshalString(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
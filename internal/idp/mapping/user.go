@@ -0,0 +1,145 @@
+package mapping
+
+import (
+	"encoding/json"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp"
+)
+
+var _ idp.User = (*User)(nil)
+
+// User wraps the [idp.User] of a provider and overwrites its information
+// with the result of the [Mapping] evaluated on the raw payload of the provider.
+type User struct {
+	idp.User
+	mapping *Mapping
+	result  *Result
+}
+
+// NewUser creates a [User], which will be mapped on [User.UnmarshalJSON] or [User.Evaluate].
+func NewUser(user idp.User, mapping *Mapping) *User {
+	return &User{
+		User:    user,
+		mapping: mapping,
+		result:  new(Result),
+	}
+}
+
+// UnmarshalJSON unmarshalls the payload into the wrapped [idp.User] and evaluates the [Mapping] on it.
+func (u *User) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, u.User); err != nil {
+		return err
+	}
+	return u.Evaluate(data)
+}
+
+// MarshalJSON marshals the wrapped [idp.User], so the stored information stays the one of the provider.
+func (u *User) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.User)
+}
+
+// Evaluate evaluates the [Mapping] on the payload without changing the wrapped [idp.User].
+func (u *User) Evaluate(payload []byte) (err error) {
+	u.result, err = u.mapping.Evaluate(payload)
+	return err
+}
+
+// Metadata returns the mapped metadata.
+func (u *User) Metadata() []*domain.Metadata {
+	return u.result.Metadata
+}
+
+// UserGrants returns the mapped user grants.
+func (u *User) UserGrants() []*domain.UserGrant {
+	return u.result.Grants
+}
+
+// GetID is an implementation of the [idp.User] interface.
+func (u *User) GetID() string {
+	return stringOr(u.result.ID, u.User.GetID)
+}
+
+// GetFirstName is an implementation of the [idp.User] interface.
+func (u *User) GetFirstName() string {
+	return stringOr(u.result.FirstName, u.User.GetFirstName)
+}
+
+// GetLastName is an implementation of the [idp.User] interface.
+func (u *User) GetLastName() string {
+	return stringOr(u.result.LastName, u.User.GetLastName)
+}
+
+// GetDisplayName is an implementation of the [idp.User] interface.
+func (u *User) GetDisplayName() string {
+	return stringOr(u.result.DisplayName, u.User.GetDisplayName)
+}
+
+// GetNickname is an implementation of the [idp.User] interface.
+func (u *User) GetNickname() string {
+	return stringOr(u.result.NickName, u.User.GetNickname)
+}
+
+// GetPreferredUsername is an implementation of the [idp.User] interface.
+func (u *User) GetPreferredUsername() string {
+	return stringOr(u.result.PreferredUsername, u.User.GetPreferredUsername)
+}
+
+// GetEmail is an implementation of the [idp.User] interface.
+func (u *User) GetEmail() domain.EmailAddress {
+	if u.result.Email != nil {
+		return domain.EmailAddress(*u.result.Email)
+	}
+	return u.User.GetEmail()
+}
+
+// IsEmailVerified is an implementation of the [idp.User] interface.
+func (u *User) IsEmailVerified() bool {
+	if u.result.EmailVerified != nil {
+		return *u.result.EmailVerified
+	}
+	return u.User.IsEmailVerified()
+}
+
+// GetPhone is an implementation of the [idp.User] interface.
+func (u *User) GetPhone() domain.PhoneNumber {
+	if u.result.Phone != nil {
+		return domain.PhoneNumber(*u.result.Phone)
+	}
+	return u.User.GetPhone()
+}
+
+// IsPhoneVerified is an implementation of the [idp.User] interface.
+func (u *User) IsPhoneVerified() bool {
+	if u.result.PhoneVerified != nil {
+		return *u.result.PhoneVerified
+	}
+	return u.User.IsPhoneVerified()
+}
+
+// GetPreferredLanguage is an implementation of the [idp.User] interface.
+func (u *User) GetPreferredLanguage() language.Tag {
+	if u.result.PreferredLanguage != nil {
+		return language.Make(*u.result.PreferredLanguage)
+	}
+	return u.User.GetPreferredLanguage()
+}
+
+// GetAvatarURL is an implementation of the [idp.User] interface.
+func (u *User) GetAvatarURL() string {
+	return stringOr(u.result.AvatarURL, u.User.GetAvatarURL)
+}
+
+// GetProfile is an implementation of the [idp.User] interface.
+func (u *User) GetProfile() string {
+	return stringOr(u.result.Profile, u.User.GetProfile)
+}
+
+func stringOr(value *string, fallback func() string) string {
+	if value != nil {
+		return *value
+	}
+	return fallback()
+}
@@ -0,0 +1,56 @@
+package mapping
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+// testUser is a minimal provider user with a hardcoded mapping of the `sub` and `email` claims
+type testUser struct {
+	Sub   string `json:"sub"`
+	Email string `json:"email"`
+}
+
+func (u *testUser) GetID() string                      { return u.Sub }
+func (u *testUser) GetFirstName() string               { return "" }
+func (u *testUser) GetLastName() string                { return "" }
+func (u *testUser) GetDisplayName() string             { return "" }
+func (u *testUser) GetNickname() string                { return "" }
+func (u *testUser) GetPreferredUsername() string       { return u.Email }
+func (u *testUser) GetEmail() domain.EmailAddress      { return domain.EmailAddress(u.Email) }
+func (u *testUser) IsEmailVerified() bool              { return false }
+func (u *testUser) GetPhone() domain.PhoneNumber       { return "" }
+func (u *testUser) IsPhoneVerified() bool              { return false }
+func (u *testUser) GetPreferredLanguage() language.Tag { return language.Und }
+func (u *testUser) GetAvatarURL() string               { return "" }
+func (u *testUser) GetProfile() string                 { return "" }
+
+func TestUser_UnmarshalJSON(t *testing.T) {
+	payload := `{"sub":"123","email":"jane@example.com","upn":"Jane.Doe@Example.com","locale":"de-CH","verified":true,"department":"sales"}`
+	user := NewUser(&testUser{}, &Mapping{
+		Email:             "upn|@lower",
+		EmailVerified:     "verified",
+		PreferredLanguage: "locale",
+		Metadata:          []*MetadataMapping{{Key: "department", Expression: "department"}},
+	})
+
+	require.NoError(t, json.Unmarshal([]byte(payload), user))
+
+	assert.Equal(t, "123", user.GetID())
+	assert.Equal(t, "jane@example.com", user.GetPreferredUsername())
+	assert.Equal(t, domain.EmailAddress("jane.doe@example.com"), user.GetEmail())
+	assert.True(t, user.IsEmailVerified())
+	assert.Equal(t, language.Make("de-CH"), user.GetPreferredLanguage())
+	assert.Equal(t, []*domain.Metadata{{Key: "department", Value: []byte("sales")}}, user.Metadata())
+	assert.Empty(t, user.UserGrants())
+
+	stored, err := json.Marshal(user)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"sub":"123","email":"jane@example.com"}`, string(stored))
+}
@@ -3,6 +3,7 @@ package idp
 import (
 	"context"
 
+	"golang.org/x/oauth2"
 	"golang.org/x/text/language"
 
 	"github.com/zitadel/zitadel/internal/domain"
@@ -18,6 +19,13 @@ type Provider interface {
 	IsAutoUpdate() bool
 }
 
+// TokenRefresher is an optional extension to the [Provider] interface.
+// It can be implemented by providers which are able to issue new tokens for a federated user
+// using a refresh token, so the tokens of the user can be kept valid without a new authentication.
+type TokenRefresher interface {
+	RefreshTokens(ctx context.Context, refreshToken string) (*oauth2.Token, error)
+}
+
 // User contains the information of a federated user.
 type User interface {
 	GetID() string
@@ -0,0 +1,89 @@
+package adfs
+
+import (
+	openid "github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/oidc"
+)
+
+const (
+	upnClaim        = "upn"
+	uniqueNameClaim = "unique_name"
+)
+
+var _ idp.Provider = (*Provider)(nil)
+
+// Provider is the [idp.Provider] implementation for Microsoft Active Directory Federation Services (AD FS)
+type Provider struct {
+	*oidc.Provider
+}
+
+// New creates an AD FS provider using the [oidc.Provider] (OIDC generic provider).
+// The issuer is the URL of the federation service, e.g. `https://adfs.example.com/adfs`.
+//
+// Since the userinfo endpoint of AD FS only returns the `sub` claim,
+// the user information is always mapped from the id_token.
+func New(name, issuer, clientID, clientSecret, redirectURI string, scopes []string, options ...oidc.ProviderOpts) (*Provider, error) {
+	if len(scopes) == 0 {
+		// the OIDC provider would set `openid profile email phone` as default scope,
+		// but AD FS rejects the unknown `phone` scope, so we will just set a separate default list
+		scopes = []string{openid.ScopeOpenID, openid.ScopeProfile, openid.ScopeEmail}
+	}
+	rp, err := oidc.New(name, issuer, clientID, clientSecret, redirectURI, scopes, userMapper, append(options, oidc.WithIDTokenMapping())...)
+	if err != nil {
+		return nil, err
+	}
+	return &Provider{
+		Provider: rp,
+	}, nil
+}
+
+var userMapper = func(info *openid.UserInfo) idp.User {
+	return &User{oidc.NewUser(info)}
+}
+
+func InitUser() idp.User {
+	return &User{oidc.InitUser()}
+}
+
+// User is a representation of the authenticated AD FS user and implements the [idp.User] interface
+// by wrapping an [oidc.User]. It overwrites the [GetPreferredUsername], [GetEmail] and [GetDisplayName]
+// to use the Active Directory specific `upn` and `unique_name` claims.
+type User struct {
+	*oidc.User
+}
+
+// GetPreferredUsername implements the [idp.User] interface.
+// It returns the user principal name (`upn` claim) if no `preferred_username` is provided.
+func (u *User) GetPreferredUsername() string {
+	if u.PreferredUsername != "" {
+		return u.PreferredUsername
+	}
+	return u.claim(upnClaim)
+}
+
+// GetEmail implements the [idp.User] interface.
+// It returns the user principal name (`upn` claim) if no `email` is provided,
+// since it is usually equal to the email address of the user.
+func (u *User) GetEmail() domain.EmailAddress {
+	if u.Email != "" {
+		return domain.EmailAddress(u.Email)
+	}
+	return domain.EmailAddress(u.claim(upnClaim))
+}
+
+// GetDisplayName implements the [idp.User] interface.
+// It returns the `unique_name` claim (e.g. `EXAMPLE\jane`) if no `name` is provided.
+func (u *User) GetDisplayName() string {
+	if u.Name != "" {
+		return u.Name
+	}
+	return u.claim(uniqueNameClaim)
+}
+
+func (u *User) claim(key string) string {
+	value, _ := u.Claims[key].(string)
+	return value
+}
@@ -0,0 +1,170 @@
+package bitbucket
+
+import (
+	"context"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
+)
+
+const (
+	authURL    = "https://bitbucket.org/site/oauth2/authorize"
+	tokenURL   = "https://bitbucket.org/site/oauth2/access_token"
+	profileURL = "https://api.bitbucket.org/2.0/user"
+	emailsURL  = "https://api.bitbucket.org/2.0/user/emails"
+	name       = "Bitbucket"
+)
+
+var _ idp.Provider = (*Provider)(nil)
+
+// New creates a Bitbucket Cloud provider using the [oauth.Provider] (OAuth 2.0 generic provider).
+// The scopes of a Bitbucket OAuth consumer are defined on the consumer itself,
+// the `account` and `email` permissions are required to retrieve the user information.
+func New(clientID, secret, callbackURL string, scopes []string, options ...oauth.ProviderOpts) (*Provider, error) {
+	rp, err := oauth.New(
+		newConfig(clientID, secret, callbackURL, scopes),
+		name,
+		profileURL,
+		func() idp.User {
+			return new(User)
+		},
+		options...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Provider{
+		Provider: rp,
+	}, nil
+}
+
+// Provider is the [idp.Provider] implementation for Bitbucket
+type Provider struct {
+	*oauth.Provider
+}
+
+// BeginAuth implements the [idp.Provider] interface.
+// It wraps the [oauth.Session] into a [Session] to be able to retrieve the email of the user.
+func (p *Provider) BeginAuth(ctx context.Context, state string, params ...idp.Parameter) (idp.Session, error) {
+	session, err := p.Provider.BeginAuth(ctx, state, params...)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{Session: session.(*oauth.Session)}, nil
+}
+
+func newConfig(clientID, secret, callbackURL string, scopes []string) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     clientID,
+		ClientSecret: secret,
+		RedirectURL:  callbackURL,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  authURL,
+			TokenURL: tokenURL,
+		},
+		Scopes: scopes,
+	}
+}
+
+// User is a representation of the authenticated Bitbucket user and implements the [idp.User] interface.
+// Since the email is not part of the user resource, it is set by the [Session] from the emails endpoint
+// and only serialized to be able to restore the user (e.g. from a succeeded intent).
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-users/#api-user-get
+type User struct {
+	UUID        string `json:"uuid"`
+	AccountID   string `json:"account_id"`
+	Nickname    string `json:"nickname"`
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name"`
+	Links       struct {
+		Avatar struct {
+			Href string `json:"href"`
+		} `json:"avatar"`
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+
+	Email         domain.EmailAddress `json:"email,omitempty"`
+	EmailVerified bool                `json:"email_verified,omitempty"`
+}
+
+// GetID is an implementation of the [idp.User] interface.
+// It returns the UUID (including the curly braces), which will never change for the user.
+func (u *User) GetID() string {
+	return u.UUID
+}
+
+// GetFirstName is an implementation of the [idp.User] interface.
+// It returns an empty string because Bitbucket does not provide the user's firstname.
+func (u *User) GetFirstName() string {
+	return ""
+}
+
+// GetLastName is an implementation of the [idp.User] interface.
+// It returns an empty string because Bitbucket does not provide the user's lastname.
+func (u *User) GetLastName() string {
+	return ""
+}
+
+// GetDisplayName is an implementation of the [idp.User] interface.
+func (u *User) GetDisplayName() string {
+	return u.DisplayName
+}
+
+// GetNickname is an implementation of the [idp.User] interface.
+func (u *User) GetNickname() string {
+	return u.Nickname
+}
+
+// GetPreferredUsername is an implementation of the [idp.User] interface.
+// It returns the username of the Bitbucket user and falls back to the nickname,
+// since the username is only returned for accounts which have set one.
+func (u *User) GetPreferredUsername() string {
+	if u.Username != "" {
+		return u.Username
+	}
+	return u.Nickname
+}
+
+// GetEmail is an implementation of the [idp.User] interface.
+func (u *User) GetEmail() domain.EmailAddress {
+	return u.Email
+}
+
+// IsEmailVerified is an implementation of the [idp.User] interface.
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerified
+}
+
+// GetPhone is an implementation of the [idp.User] interface.
+// It returns an empty string because Bitbucket does not provide the user's phone.
+func (u *User) GetPhone() domain.PhoneNumber {
+	return ""
+}
+
+// IsPhoneVerified is an implementation of the [idp.User] interface
+// it returns false because Bitbucket does not provide the user's phone
+func (u *User) IsPhoneVerified() bool {
+	return false
+}
+
+// GetPreferredLanguage is an implementation of the [idp.User] interface.
+// It returns [language.Und] because Bitbucket does not provide the user's language.
+func (u *User) GetPreferredLanguage() language.Tag {
+	return language.Und
+}
+
+// GetProfile is an implementation of the [idp.User] interface.
+func (u *User) GetProfile() string {
+	return u.Links.HTML.Href
+}
+
+// GetAvatarURL is an implementation of the [idp.User] interface.
+func (u *User) GetAvatarURL() string {
+	return u.Links.Avatar.Href
+}
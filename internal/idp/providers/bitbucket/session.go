@@ -0,0 +1,62 @@
+package bitbucket
+
+import (
+	"context"
+	"net/http"
+
+	httphelper "github.com/zitadel/oidc/v3/pkg/http"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
+)
+
+var _ idp.Session = (*Session)(nil)
+
+// Session extends the [oauth.Session] to be able to retrieve the primary email of the user,
+// which is not part of the user resource of Bitbucket.
+type Session struct {
+	*oauth.Session
+}
+
+func NewSession(provider *Provider, code string, idpArguments map[string]any) *Session {
+	return &Session{Session: oauth.NewSession(provider.Provider, code, idpArguments)}
+}
+
+// emails is the paginated response of the emails endpoint.
+// https://developer.atlassian.com/cloud/bitbucket/rest/api-group-users/#api-user-emails-get
+type emails struct {
+	Values []struct {
+		Email       domain.EmailAddress `json:"email"`
+		IsPrimary   bool                `json:"is_primary"`
+		IsConfirmed bool                `json:"is_confirmed"`
+	} `json:"values"`
+}
+
+// FetchUser implements the [idp.Session] interface.
+// It will execute an OAuth 2.0 code exchange if needed to retrieve the access token,
+// call the user endpoint and additionally the emails endpoint to set the primary email of the [User].
+func (s *Session) FetchUser(ctx context.Context) (idp.User, error) {
+	user, err := s.Session.FetchUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, emailsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("authorization", s.Tokens.TokenType+" "+s.Tokens.AccessToken)
+	addresses := new(emails)
+	if err := httphelper.HttpRequest(s.Provider.RelyingParty.HttpClient(), req, addresses); err != nil {
+		return nil, err
+	}
+	bitbucketUser := user.(*User)
+	for _, address := range addresses.Values {
+		if !address.IsPrimary {
+			continue
+		}
+		bitbucketUser.Email = address.Email
+		bitbucketUser.EmailVerified = address.IsConfirmed
+	}
+	return bitbucketUser, nil
+}
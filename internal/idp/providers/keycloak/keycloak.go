@@ -0,0 +1,56 @@
+package keycloak
+
+import (
+	openid "github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/oidc"
+)
+
+var _ idp.Provider = (*Provider)(nil)
+
+// Provider is the [idp.Provider] implementation for Keycloak
+type Provider struct {
+	*oidc.Provider
+}
+
+// New creates a Keycloak provider using the [oidc.Provider] (OIDC generic provider).
+// The issuer is the URL of the realm, e.g. `https://keycloak.example.com/realms/example`.
+func New(name, issuer, clientID, clientSecret, redirectURI string, scopes []string, options ...oidc.ProviderOpts) (*Provider, error) {
+	if len(scopes) == 0 {
+		// the OIDC provider would set `openid profile email phone` as default scope,
+		// but since the `phone` client scope is only optional in Keycloak and might not be assigned to the client,
+		// which results in an `invalid_scope` error, we will just set a separate default list
+		scopes = []string{openid.ScopeOpenID, openid.ScopeProfile, openid.ScopeEmail}
+	}
+	rp, err := oidc.New(name, issuer, clientID, clientSecret, redirectURI, scopes, userMapper, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &Provider{
+		Provider: rp,
+	}, nil
+}
+
+var userMapper = func(info *openid.UserInfo) idp.User {
+	return &User{oidc.NewUser(info)}
+}
+
+func InitUser() idp.User {
+	return &User{oidc.InitUser()}
+}
+
+// User is a representation of the authenticated Keycloak user and implements the [idp.User] interface
+// by wrapping an [oidc.User]. It overwrites the [GetNickname] to use the `preferred_username` claim.
+type User struct {
+	*oidc.User
+}
+
+// GetNickname implements the [idp.User] interface.
+// It returns the username, because Keycloak does not provide a nickname by default.
+func (u *User) GetNickname() string {
+	if u.Nickname != "" {
+		return u.Nickname
+	}
+	return u.PreferredUsername
+}
@@ -0,0 +1,150 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// DefaultPageSize is the amount of entries requested per page if no page size is provided.
+const DefaultPageSize uint32 = 500
+
+var ErrNoServer = errors.New("no ldap server configured")
+
+// DirectoryUser is a user returned by a directory search.
+type DirectoryUser struct {
+	*User
+	// Groups contains the values of the group attribute, e.g. the DNs of the groups the user is member of
+	Groups []string
+}
+
+// directoryConn is the part of [ldap.Conn] used to search the directory.
+type directoryConn interface {
+	Bind(username, password string) error
+	Search(searchRequest *ldap.SearchRequest) (*ldap.SearchResult, error)
+	Close() error
+}
+
+type directoryConnector func(server string, startTLS bool, timeout time.Duration, rootCA []byte) (directoryConn, error)
+
+func connectDirectory(server string, startTLS bool, timeout time.Duration, rootCA []byte) (directoryConn, error) {
+	return getConnection(server, startTLS, timeout, rootCA)
+}
+
+// SearchUsers pages through all users of the directory which match the user object classes of the provider and the filter.
+// The filter is an LDAP search filter like `(department=sales)` and is optional.
+// If groupAttribute is set, its values are returned as groups of the user.
+//
+// fn is called once per page, the search stops as soon as fn returns an error.
+// The servers are tried in order until a connection and bind succeeds,
+// a failure after the first page is returned without trying the other servers.
+func (p *Provider) SearchUsers(ctx context.Context, filter string, pageSize uint32, groupAttribute string, fn func(users []*DirectoryUser) error) (err error) {
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+	connect := p.connectDirectory
+	if connect == nil {
+		connect = connectDirectory
+	}
+	var conn directoryConn
+	err = ErrNoServer
+	for _, server := range p.servers {
+		conn, err = connect(server, p.startTLS, p.timeout, p.rootCA)
+		if err != nil {
+			continue
+		}
+		if err = conn.Bind(p.bindDN, p.bindPassword); err != nil {
+			conn.Close()
+			continue
+		}
+		break
+	}
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	attributes := p.getNecessaryAttributes()
+	if groupAttribute != "" {
+		attributes = append(attributes, groupAttribute)
+	}
+	queries := make([]string, 0, len(p.userObjectClasses)+1)
+	for _, class := range p.userObjectClasses {
+		queries = append(queries, objectClassesToSearchQuery([]string{class}))
+	}
+	if filter != "" {
+		queries = append(queries, filter)
+	}
+	paging := ldap.NewControlPaging(pageSize)
+	searchRequest := ldap.NewSearchRequest(
+		p.baseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, int(p.timeout.Seconds()), false,
+		queriesAndToSearchQuery(queries...),
+		attributes,
+		[]ldap.Control{paging},
+	)
+	for {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		result, err := conn.Search(searchRequest)
+		if err != nil {
+			return err
+		}
+		users := make([]*DirectoryUser, len(result.Entries))
+		for i, entry := range result.Entries {
+			if users[i], err = p.mapDirectoryEntry(entry, groupAttribute); err != nil {
+				return err
+			}
+		}
+		if err = fn(users); err != nil {
+			return err
+		}
+
+		control, ok := ldap.FindControl(result.Controls, ldap.ControlTypePaging).(*ldap.ControlPaging)
+		if !ok || len(control.Cookie) == 0 {
+			return nil
+		}
+		paging.SetCookie(control.Cookie)
+	}
+}
+
+func (p *Provider) mapDirectoryEntry(entry *ldap.Entry, groupAttribute string) (*DirectoryUser, error) {
+	user, err := mapLDAPEntryToUser(
+		entry,
+		p.idAttribute,
+		p.firstNameAttribute,
+		p.lastNameAttribute,
+		p.displayNameAttribute,
+		p.nickNameAttribute,
+		p.preferredUsernameAttribute,
+		p.emailAttribute,
+		p.emailVerifiedAttribute,
+		p.phoneAttribute,
+		p.phoneVerifiedAttribute,
+		p.preferredLanguageAttribute,
+		p.avatarURLAttribute,
+		p.profileAttribute,
+	)
+	if err != nil {
+		return nil, err
+	}
+	directoryUser := &DirectoryUser{User: user}
+	if groupAttribute != "" {
+		directoryUser.Groups = entry.GetAttributeValues(groupAttribute)
+	}
+	return directoryUser, nil
+}
+
+// HasGroup returns true if the user is member of the group, DNs are compared case-insensitive.
+func (u *DirectoryUser) HasGroup(group string) bool {
+	for _, g := range u.Groups {
+		if strings.EqualFold(g, group) {
+			return true
+		}
+	}
+	return false
+}
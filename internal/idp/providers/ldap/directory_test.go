@@ -0,0 +1,170 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// localDirectory is an in memory stand-in for an LDAP server which supports the paged results control.
+type localDirectory struct {
+	entries      []*ldap.Entry
+	bindPassword string
+
+	requests []*ldap.SearchRequest
+	closed   bool
+}
+
+func localConnector(servers map[string]*localDirectory) directoryConnector {
+	return func(server string, _ bool, _ time.Duration, _ []byte) (directoryConn, error) {
+		directory, ok := servers[server]
+		if !ok {
+			return nil, errors.New("connection refused")
+		}
+		return directory, nil
+	}
+}
+
+func (d *localDirectory) Bind(_, password string) error {
+	if password != d.bindPassword {
+		return ErrFailedLogin
+	}
+	return nil
+}
+
+func (d *localDirectory) Search(request *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	d.requests = append(d.requests, request)
+	paging, ok := ldap.FindControl(request.Controls, ldap.ControlTypePaging).(*ldap.ControlPaging)
+	if !ok {
+		return &ldap.SearchResult{Entries: d.entries}, nil
+	}
+	var offset int
+	if len(paging.Cookie) > 0 {
+		offset, _ = strconv.Atoi(string(paging.Cookie))
+	}
+	end := min(offset+int(paging.PagingSize), len(d.entries))
+	response := ldap.NewControlPaging(paging.PagingSize)
+	if end < len(d.entries) {
+		response.SetCookie([]byte(strconv.Itoa(end)))
+	}
+	return &ldap.SearchResult{
+		Entries:  d.entries[offset:end],
+		Controls: []ldap.Control{response},
+	}, nil
+}
+
+func (d *localDirectory) Close() error {
+	d.closed = true
+	return nil
+}
+
+func newLocalDirectory(users int) *localDirectory {
+	directory := &localDirectory{bindPassword: "password"}
+	for i := range users {
+		id := strconv.Itoa(i)
+		directory.entries = append(directory.entries, ldap.NewEntry("uid=user"+id+",ou=people,dc=example,dc=com", map[string][]string{
+			"uid":       {"user" + id},
+			"givenName": {"first" + id},
+			"sn":        {"last" + id},
+			"mail":      {"user" + id + "@example.com"},
+			"memberOf":  {"CN=Sales,OU=Groups,DC=example,DC=com"},
+		}))
+	}
+	return directory
+}
+
+func newDirectoryProvider(servers []string) *Provider {
+	return New(
+		"ldap",
+		servers,
+		"dc=example,dc=com",
+		"cn=admin,dc=example,dc=com",
+		"password",
+		"dn",
+		[]string{"inetOrgPerson"},
+		[]string{"uid"},
+		10*time.Second,
+		nil,
+		"",
+		WithCustomIDAttribute("uid"),
+		WithFirstNameAttribute("givenName"),
+		WithLastNameAttribute("sn"),
+		WithEmailAttribute("mail"),
+	)
+}
+
+func TestProvider_SearchUsers(t *testing.T) {
+	directory := newLocalDirectory(5)
+	provider := newDirectoryProvider([]string{"ldap://unavailable", "ldap://local"})
+	provider.connectDirectory = localConnector(map[string]*localDirectory{"ldap://local": directory})
+
+	var pages [][]*DirectoryUser
+	err := provider.SearchUsers(context.Background(), "(department=sales)", 2, "memberOf", func(users []*DirectoryUser) error {
+		pages = append(pages, users)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, directory.closed)
+
+	require.Len(t, pages, 3)
+	assert.Len(t, pages[0], 2)
+	assert.Len(t, pages[1], 2)
+	assert.Len(t, pages[2], 1)
+	user := pages[2][0]
+	assert.Equal(t, "user4", user.ID)
+	assert.Equal(t, "first4", user.FirstName)
+	assert.Equal(t, "last4", user.LastName)
+	assert.Equal(t, "user4@example.com", string(user.Email))
+	assert.True(t, user.HasGroup("cn=sales,ou=groups,dc=example,dc=com"))
+	assert.False(t, user.HasGroup("cn=marketing,ou=groups,dc=example,dc=com"))
+
+	require.Len(t, directory.requests, 3)
+	assert.Equal(t, "dc=example,dc=com", directory.requests[0].BaseDN)
+	assert.Equal(t, "(&(objectClass=inetOrgPerson)(department=sales))", directory.requests[0].Filter)
+	assert.Contains(t, directory.requests[0].Attributes, "memberOf")
+}
+
+func TestProvider_SearchUsers_stop(t *testing.T) {
+	directory := newLocalDirectory(5)
+	provider := newDirectoryProvider([]string{"ldap://local"})
+	provider.connectDirectory = localConnector(map[string]*localDirectory{"ldap://local": directory})
+
+	stop := errors.New("stop")
+	var calls int
+	err := provider.SearchUsers(context.Background(), "", 2, "", func(users []*DirectoryUser) error {
+		calls++
+		assert.Nil(t, users[0].Groups)
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, "(objectClass=inetOrgPerson)", directory.requests[0].Filter)
+}
+
+func TestProvider_SearchUsers_bindFailed(t *testing.T) {
+	directory := newLocalDirectory(1)
+	directory.bindPassword = "other"
+	provider := newDirectoryProvider([]string{"ldap://local"})
+	provider.connectDirectory = localConnector(map[string]*localDirectory{"ldap://local": directory})
+
+	err := provider.SearchUsers(context.Background(), "", 0, "", func([]*DirectoryUser) error {
+		t.Error("no users must be returned")
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrFailedLogin)
+	assert.True(t, directory.closed)
+	assert.Empty(t, directory.requests)
+}
+
+func TestProvider_SearchUsers_noServer(t *testing.T) {
+	err := newDirectoryProvider(nil).SearchUsers(context.Background(), "", 0, "", func([]*DirectoryUser) error {
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrNoServer)
+}
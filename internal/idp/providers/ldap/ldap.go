@@ -27,6 +27,9 @@ type Provider struct {
 
 	loginUrl string
 
+	// connectDirectory is used by [Provider.SearchUsers], it's only overwritten in tests
+	connectDirectory directoryConnector
+
 	isLinkingAllowed  bool
 	isCreationAllowed bool
 	isAutoCreation    bool
@@ -2,15 +2,20 @@ package oauth
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/oidc/v3/pkg/client/rp"
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 	"golang.org/x/oauth2"
 
 	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/mapping"
 )
 
-var _ idp.Provider = (*Provider)(nil)
+var (
+	_ idp.Provider       = (*Provider)(nil)
+	_ idp.TokenRefresher = (*Provider)(nil)
+)
 
 // Provider is the [idp.Provider] implementation for a generic OAuth 2.0 provider
 type Provider struct {
@@ -19,6 +24,7 @@ type Provider struct {
 	name              string
 	userEndpoint      string
 	user              func() idp.User
+	claimMapping      *mapping.Mapping
 	isLinkingAllowed  bool
 	isCreationAllowed bool
 	isAutoCreation    bool
@@ -64,6 +70,14 @@ func WithRelyingPartyOption(option rp.Option) ProviderOpts {
 	}
 }
 
+// WithClaimMapping maps the information of the user endpoint using the provided [mapping.Mapping]
+// additionally to the user mapper.
+func WithClaimMapping(claimMapping *mapping.Mapping) ProviderOpts {
+	return func(p *Provider) {
+		p.claimMapping = claimMapping
+	}
+}
+
 // New creates a generic OAuth 2.0 provider
 func New(config *oauth2.Config, name, userEndpoint string, user func() idp.User, options ...ProviderOpts) (provider *Provider, err error) {
 	provider = &Provider{
@@ -118,6 +132,21 @@ func loginHint(hint string) rp.AuthURLOpt {
 	}
 }
 
+// RefreshTokens implements the [idp.TokenRefresher] interface.
+// It requests new tokens from the token endpoint of the provider using the refresh token.
+func (p *Provider) RefreshTokens(ctx context.Context, refreshToken string) (*oauth2.Token, error) {
+	tokens, err := rp.RefreshTokens[*oidc.IDTokenClaims](ctx, p.RelyingParty, refreshToken, "", "")
+	if err != nil {
+		return nil, err
+	}
+	// the expiry is always set based on the (optional) `expires_in` of the response,
+	// without it the access token does not expire
+	if !tokens.Expiry.After(time.Now()) {
+		tokens.Expiry = time.Time{}
+	}
+	return tokens.Token, nil
+}
+
 // IsLinkingAllowed implements the [idp.Provider] interface.
 func (p *Provider) IsLinkingAllowed() bool {
 	return p.isLinkingAllowed
@@ -139,5 +168,8 @@ func (p *Provider) IsAutoUpdate() bool {
 }
 
 func (p *Provider) User() idp.User {
-	return p.user()
+	if p.claimMapping.IsEmpty() {
+		return p.user()
+	}
+	return mapping.NewUser(p.user(), p.claimMapping)
 }
@@ -2,11 +2,14 @@ package oauth
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"github.com/h2non/gock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/zitadel/oidc/v3/pkg/client/rp"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
 	"golang.org/x/oauth2"
 
 	"github.com/zitadel/zitadel/internal/idp"
@@ -182,3 +185,100 @@ func TestProvider_Options(t *testing.T) {
 		})
 	}
 }
+
+func TestProvider_RefreshTokens(t *testing.T) {
+	type want struct {
+		err          func(error) bool
+		accessToken  string
+		refreshToken string
+		expiring     bool
+	}
+	tests := []struct {
+		name     string
+		httpMock func(issuer string)
+		want     want
+	}{
+		{
+			name: "invalid grant, error",
+			httpMock: func(issuer string) {
+				gock.New(issuer).
+					Post("/token").
+					BodyString("client_id=clientID&client_secret=clientSecret&grant_type=refresh_token&refresh_token=refreshToken&scope=user").
+					Reply(400).
+					JSON(map[string]string{
+						"error": "invalid_grant",
+					})
+			},
+			want: want{
+				err: func(err error) bool {
+					var oidcErr *oidc.Error
+					return errors.As(err, &oidcErr) && oidcErr.ErrorType == oidc.InvalidGrant
+				},
+			},
+		},
+		{
+			name: "refreshed",
+			httpMock: func(issuer string) {
+				gock.New(issuer).
+					Post("/token").
+					BodyString("client_id=clientID&client_secret=clientSecret&grant_type=refresh_token&refresh_token=refreshToken&scope=user").
+					Reply(200).
+					JSON(&oidc.AccessTokenResponse{
+						AccessToken:  "accessToken",
+						TokenType:    oidc.BearerToken,
+						RefreshToken: "newRefreshToken",
+						ExpiresIn:    3600,
+					})
+			},
+			want: want{
+				accessToken:  "accessToken",
+				refreshToken: "newRefreshToken",
+				expiring:     true,
+			},
+		},
+		{
+			name: "refreshed without expiration",
+			httpMock: func(issuer string) {
+				gock.New(issuer).
+					Post("/token").
+					BodyString("client_id=clientID&client_secret=clientSecret&grant_type=refresh_token&refresh_token=refreshToken&scope=user").
+					Reply(200).
+					JSON(&oidc.AccessTokenResponse{
+						AccessToken: "accessToken",
+						TokenType:   oidc.BearerToken,
+					})
+			},
+			want: want{
+				accessToken: "accessToken",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer gock.Off()
+			tt.httpMock("https://oauth2.com")
+
+			provider, err := New(&oauth2.Config{
+				ClientID:     "clientID",
+				ClientSecret: "clientSecret",
+				Endpoint: oauth2.Endpoint{
+					AuthURL:  "https://oauth2.com/authorize",
+					TokenURL: "https://oauth2.com/token",
+				},
+				RedirectURL: "redirectURI",
+				Scopes:      []string{"user"},
+			}, "oauth", "https://oauth2.com/user", nil)
+			require.NoError(t, err)
+
+			tokens, err := provider.RefreshTokens(context.Background(), "refreshToken")
+			if tt.want.err != nil {
+				assert.True(t, tt.want.err(err), "invalid error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.accessToken, tokens.AccessToken)
+			assert.Equal(t, tt.want.refreshToken, tokens.RefreshToken)
+			assert.Equal(t, tt.want.expiring, !tokens.Expiry.IsZero())
+		})
+	}
+}
@@ -2,15 +2,20 @@ package oidc
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/oidc/v3/pkg/client/rp"
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 	"golang.org/x/oauth2"
 
 	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/mapping"
 )
 
-var _ idp.Provider = (*Provider)(nil)
+var (
+	_ idp.Provider       = (*Provider)(nil)
+	_ idp.TokenRefresher = (*Provider)(nil)
+)
 
 // Provider is the [idp.Provider] implementation for a generic OIDC provider
 type Provider struct {
@@ -23,6 +28,7 @@ type Provider struct {
 	isAutoUpdate      bool
 	useIDToken        bool
 	userInfoMapper    func(info *oidc.UserInfo) idp.User
+	claimMapping      *mapping.Mapping
 	authOptions       []func(bool) rp.AuthURLOpt
 	generateVerifier  func() string
 }
@@ -94,6 +100,14 @@ func WithResponseMode(mode oidc.ResponseMode) ProviderOpts {
 	}
 }
 
+// WithClaimMapping maps the claims of the userinfo (or id_token) using the provided [mapping.Mapping]
+// additionally to the [UserInfoMapper].
+func WithClaimMapping(claimMapping *mapping.Mapping) ProviderOpts {
+	return func(p *Provider) {
+		p.claimMapping = claimMapping
+	}
+}
+
 type UserInfoMapper func(info *oidc.UserInfo) idp.User
 
 var DefaultMapper UserInfoMapper = func(info *oidc.UserInfo) idp.User {
@@ -169,6 +183,30 @@ func loginHint(hint string) rp.AuthURLOpt {
 	}
 }
 
+// RefreshTokens implements the [idp.TokenRefresher] interface.
+// It requests new tokens from the token endpoint of the provider using the refresh token.
+func (p *Provider) RefreshTokens(ctx context.Context, refreshToken string) (*oauth2.Token, error) {
+	tokens, err := rp.RefreshTokens[*oidc.IDTokenClaims](ctx, p.RelyingParty, refreshToken, "", "")
+	if err != nil {
+		return nil, err
+	}
+	// the expiry is always set based on the (optional) `expires_in` of the response,
+	// without it the access token does not expire
+	if !tokens.Expiry.After(time.Now()) {
+		tokens.Expiry = time.Time{}
+	}
+	return tokens.Token, nil
+}
+
+// User returns an [idp.User] to unmarshal the stored information of a federated user into,
+// including the [mapping.Mapping] if one is set.
+func (p *Provider) User() idp.User {
+	if p.claimMapping.IsEmpty() {
+		return InitUser()
+	}
+	return mapping.NewUser(InitUser(), p.claimMapping)
+}
+
 // IsLinkingAllowed implements the [idp.Provider] interface.
 func (p *Provider) IsLinkingAllowed() bool {
 	return p.isLinkingAllowed
@@ -2,6 +2,7 @@ package oidc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/mapping"
 	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
 )
 
@@ -70,7 +72,18 @@ func (s *Session) FetchUser(ctx context.Context) (user idp.User, err error) {
 		}
 	}
 	u := s.Provider.userInfoMapper(info)
-	return u, nil
+	if s.Provider.claimMapping.IsEmpty() {
+		return u, nil
+	}
+	payload, err := json.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+	mapped := mapping.NewUser(u, s.Provider.claimMapping)
+	if err = mapped.Evaluate(payload); err != nil {
+		return nil, err
+	}
+	return mapped, nil
 }
 
 func (s *Session) ExpiresAt() time.Time {
@@ -0,0 +1,61 @@
+package okta
+
+import (
+	openid "github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/oidc"
+)
+
+var _ idp.Provider = (*Provider)(nil)
+
+// Provider is the [idp.Provider] implementation for Okta
+type Provider struct {
+	*oidc.Provider
+}
+
+// New creates an Okta provider using the [oidc.Provider] (OIDC generic provider).
+// The issuer is either the Okta domain (org authorization server, e.g. `https://example.okta.com`)
+// or the URL of a custom authorization server (e.g. `https://example.okta.com/oauth2/default`).
+func New(name, issuer, clientID, clientSecret, redirectURI string, scopes []string, options ...oidc.ProviderOpts) (*Provider, error) {
+	rp, err := oidc.New(name, issuer, clientID, clientSecret, redirectURI, scopes, userMapper, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &Provider{
+		Provider: rp,
+	}, nil
+}
+
+var userMapper = func(info *openid.UserInfo) idp.User {
+	return &User{oidc.NewUser(info)}
+}
+
+func InitUser() idp.User {
+	return &User{oidc.InitUser()}
+}
+
+// User is a representation of the authenticated Okta user and implements the [idp.User] interface
+// by wrapping an [oidc.User]. It overwrites the [GetPreferredUsername] and [GetDisplayName]
+// to handle profiles where the `preferred_username` or `name` claims are not released.
+type User struct {
+	*oidc.User
+}
+
+// GetPreferredUsername implements the [idp.User] interface.
+// It returns the Okta login (`preferred_username`) and falls back to the email.
+func (u *User) GetPreferredUsername() string {
+	if u.PreferredUsername != "" {
+		return u.PreferredUsername
+	}
+	return string(u.GetEmail())
+}
+
+// GetDisplayName implements the [idp.User] interface.
+// It returns the `name` claim and falls back to the combination of first- and lastname.
+func (u *User) GetDisplayName() string {
+	if u.Name != "" || u.GivenName == "" || u.FamilyName == "" {
+		return u.Name
+	}
+	return u.GivenName + " " + u.FamilyName
+}
@@ -0,0 +1,24 @@
+package idptokens
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	RefresherUserID = "IDP-TOKEN-REFRESH"
+)
+
+func HandlerContext(event *eventstore.Aggregate) context.Context {
+	ctx := authz.WithInstanceID(context.Background(), event.InstanceID)
+	return authz.SetCtxData(ctx, authz.CtxData{UserID: RefresherUserID, OrgID: event.ResourceOwner})
+}
+
+// ContextWithRefresher sets the instance and the refresher as editor of the refreshed tokens.
+// No permissions are needed, since the refresh only renews the tokens already stored on the link.
+func ContextWithRefresher(ctx context.Context, instance authz.Instance, resourceOwner string) context.Context {
+	ctx = authz.WithInstance(ctx, instance)
+	return authz.SetCtxData(ctx, authz.CtxData{UserID: RefresherUserID, OrgID: resourceOwner})
+}
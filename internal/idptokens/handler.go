@@ -0,0 +1,91 @@
+package idptokens
+
+import (
+	"context"
+	"time"
+
+	"github.com/riverqueue/river"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/queue"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	HandlerTable = "projections.idp_tokens_handler"
+)
+
+type Queue interface {
+	Insert(ctx context.Context, args river.JobArgs, opts ...queue.InsertOpt) error
+}
+
+// eventHandler schedules the refresh of the tokens of a user link shortly before the access token expires.
+// Every refresh sets the tokens again, which schedules the next refresh,
+// until the refresh fails permanently or the tokens are not used anymore.
+type eventHandler struct {
+	queue         Queue
+	refreshBefore time.Duration
+	maxAttempts   uint8
+}
+
+func NewEventHandler(
+	ctx context.Context,
+	config handler.Config,
+	queue Queue,
+	refreshBefore time.Duration,
+	maxAttempts uint8,
+) *handler.Handler {
+	return handler.NewHandler(ctx, &config, &eventHandler{
+		queue:         queue,
+		refreshBefore: refreshBefore,
+		maxAttempts:   maxAttempts,
+	})
+}
+
+func (u *eventHandler) Name() string {
+	return HandlerTable
+}
+
+func (u *eventHandler) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  user.UserIDPLinkTokensSetType,
+					Reduce: u.reduceTokensSet,
+				},
+			},
+		},
+	}
+}
+
+func (u *eventHandler) reduceTokensSet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserIDPLinkTokensSetEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Quo4e", "reduce.wrong.event.type %s", user.UserIDPLinkTokensSetType)
+	}
+	// tokens can only be refreshed with a refresh token and only need to be if they expire
+	if e.RefreshToken == nil || e.Expiry.IsZero() {
+		return handler.NewNoOpStatement(e), nil
+	}
+	return handler.NewStatement(e, func(ex handler.Executer, projectionName string) error {
+		return u.queue.Insert(HandlerContext(e.Aggregate()),
+			&user.IDPLinkTokensRefreshRequest{
+				InstanceID:     e.Aggregate().InstanceID,
+				UserID:         e.Aggregate().ID,
+				ResourceOwner:  e.Aggregate().ResourceOwner,
+				IDPConfigID:    e.IDPConfigID,
+				ExternalUserID: e.ExternalUserID,
+				Sequence:       e.Sequence(),
+			},
+			queue.WithQueueName(user.IDPLinkTokensRefreshQueueName),
+			queue.WithScheduledAt(e.Expiry.Add(-u.refreshBefore)),
+			queue.WithMaxAttempts(u.maxAttempts),
+			// the events are reduced again if the projection is reset
+			queue.WithUniqueArgs(),
+		)
+	}), nil
+}
@@ -0,0 +1,93 @@
+package idptokens
+
+import (
+	"context"
+	"time"
+
+	"github.com/riverqueue/river"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type Queries interface {
+	InstanceByID(ctx context.Context, id string) (instance authz.Instance, err error)
+}
+
+type Commands interface {
+	RefreshUserIDPLinkTokens(ctx context.Context, userID, resourceOwner, idpConfigID, externalUserID string, expiresWithin, unusedFor time.Duration) error
+}
+
+type Worker struct {
+	river.WorkerDefaults[*user.IDPLinkTokensRefreshRequest]
+
+	config   WorkerConfig
+	commands Commands
+	queries  Queries
+}
+
+// Timeout implements the Timeout-function of [river.Worker].
+// Maximum time the refresh (incl. the call to the identity provider) can take.
+func (w *Worker) Timeout(*river.Job[*user.IDPLinkTokensRefreshRequest]) time.Duration {
+	return w.config.TransactionDuration
+}
+
+// Work implements [river.Worker].
+// It refreshes the tokens of the link if they are about to expire.
+// If they were already refreshed or set in the meantime, nothing is done, as the newer tokens scheduled their own refresh.
+// The job is cancelled if the instance, the link or the tokens do not exist anymore,
+// if the tokens were not used for [WorkerConfig.StopUnusedAfter]
+// or if the identity provider rejects the refresh permanently.
+func (w *Worker) Work(ctx context.Context, job *river.Job[*user.IDPLinkTokensRefreshRequest]) error {
+	instance, err := w.queries.InstanceByID(ctx, job.Args.InstanceID)
+	if err != nil {
+		return cancelIfNotFound(err)
+	}
+	ctx = ContextWithRefresher(ctx, instance, job.Args.ResourceOwner)
+
+	err = w.commands.RefreshUserIDPLinkTokens(ctx, job.Args.UserID, job.Args.ResourceOwner, job.Args.IDPConfigID, job.Args.ExternalUserID, w.config.RefreshBefore, w.config.StopUnusedAfter)
+	if zerrors.IsNotFound(err) || zerrors.IsPreconditionFailed(err) {
+		return river.JobCancel(err)
+	}
+	return err
+}
+
+func cancelIfNotFound(err error) error {
+	if zerrors.IsNotFound(err) {
+		return river.JobCancel(err)
+	}
+	return err
+}
+
+type WorkerConfig struct {
+	Workers             uint8
+	TransactionDuration time.Duration
+	// RefreshBefore is the duration before the expiry of an access token, when it is refreshed
+	RefreshBefore time.Duration
+	// StopUnusedAfter is the duration after which tokens which were not requested anymore are no longer refreshed
+	StopUnusedAfter time.Duration
+	// MaxAttempts is the maximum number of attempts of a refresh failing temporarily
+	MaxAttempts uint8
+}
+
+func NewWorker(
+	config WorkerConfig,
+	commands Commands,
+	queries Queries,
+) *Worker {
+	return &Worker{
+		config:   config,
+		commands: commands,
+		queries:  queries,
+	}
+}
+
+var _ river.Worker[*user.IDPLinkTokensRefreshRequest] = (*Worker)(nil)
+
+func (w *Worker) Register(workers *river.Workers, queues map[string]river.QueueConfig) {
+	river.AddWorker(workers, w)
+	queues[user.IDPLinkTokensRefreshQueueName] = river.QueueConfig{
+		MaxWorkers: int(w.config.Workers),
+	}
+}
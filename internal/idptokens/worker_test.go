@@ -0,0 +1,156 @@
+package idptokens
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type testInstance struct {
+	authz.Instance
+}
+
+func (i *testInstance) InstanceID() string {
+	return "instance1"
+}
+
+type testQueries struct {
+	instance    authz.Instance
+	instanceErr error
+}
+
+func (q *testQueries) InstanceByID(context.Context, string) (authz.Instance, error) {
+	return q.instance, q.instanceErr
+}
+
+type testCommands struct {
+	err           error
+	refreshed     int
+	expiresWithin time.Duration
+	unusedFor     time.Duration
+	editor        string
+}
+
+func (c *testCommands) RefreshUserIDPLinkTokens(ctx context.Context, _, _, _, _ string, expiresWithin, unusedFor time.Duration) error {
+	c.refreshed++
+	c.expiresWithin = expiresWithin
+	c.unusedFor = unusedFor
+	c.editor = authz.GetCtxData(ctx).UserID
+	return c.err
+}
+
+func TestWorker_Work(t *testing.T) {
+	tests := []struct {
+		name          string
+		queries       *testQueries
+		commands      *testCommands
+		wantCancel    bool
+		wantErr       bool
+		wantRefreshed int
+	}{
+		{
+			name: "instance removed, cancel",
+			queries: &testQueries{
+				instanceErr: zerrors.ThrowNotFound(nil, "QUERY-n0wng", "Errors.IAM.NotFound"),
+			},
+			commands:   &testCommands{},
+			wantCancel: true,
+		},
+		{
+			name: "link removed, cancel",
+			queries: &testQueries{
+				instance: &testInstance{},
+			},
+			commands: &testCommands{
+				err: zerrors.ThrowNotFound(nil, "COMMAND-Vd7hX", "Errors.User.ExternalIDP.NotFound"),
+			},
+			wantCancel:    true,
+			wantRefreshed: 1,
+		},
+		{
+			name: "refresh token revoked, cancel",
+			queries: &testQueries{
+				instance: &testInstance{},
+			},
+			commands: &testCommands{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Wy2dF", "Errors.User.ExternalIDP.TokensExpired"),
+			},
+			wantCancel:    true,
+			wantRefreshed: 1,
+		},
+		{
+			name: "tokens not used, cancel",
+			queries: &testQueries{
+				instance: &testInstance{},
+			},
+			commands: &testCommands{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ahb4e", "Errors.User.ExternalIDP.TokensUnused"),
+			},
+			wantCancel:    true,
+			wantRefreshed: 1,
+		},
+		{
+			name: "provider unavailable, retry",
+			queries: &testQueries{
+				instance: &testInstance{},
+			},
+			commands: &testCommands{
+				err: zerrors.ThrowInternal(nil, "COMMAND-Gu3xK", "Errors.User.ExternalIDP.TokensRefreshFailed"),
+			},
+			wantErr:       true,
+			wantRefreshed: 1,
+		},
+		{
+			name: "refreshed",
+			queries: &testQueries{
+				instance: &testInstance{},
+			},
+			commands:      &testCommands{},
+			wantRefreshed: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWorker(WorkerConfig{Workers: 1, TransactionDuration: time.Minute, RefreshBefore: 5 * time.Minute, StopUnusedAfter: time.Hour}, tt.commands, tt.queries)
+
+			err := w.Work(context.Background(), &river.Job[*user.IDPLinkTokensRefreshRequest]{
+				JobRow: &rivertype.JobRow{},
+				Args: &user.IDPLinkTokensRefreshRequest{
+					InstanceID:     "instance1",
+					UserID:         "user1",
+					ResourceOwner:  "org1",
+					IDPConfigID:    "idp1",
+					ExternalUserID: "ext1",
+					Sequence:       1,
+				},
+			})
+
+			assert.Equal(t, tt.wantRefreshed, tt.commands.refreshed)
+			if tt.wantCancel {
+				var cancelErr *river.JobCancelError
+				assert.ErrorAs(t, err, &cancelErr)
+				return
+			}
+			if tt.wantErr {
+				var cancelErr *river.JobCancelError
+				require.Error(t, err)
+				assert.False(t, errors.As(err, &cancelErr))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 5*time.Minute, tt.commands.expiresWithin)
+			assert.Equal(t, time.Hour, tt.commands.unusedFor)
+			assert.Equal(t, RefresherUserID, tt.commands.editor)
+		})
+	}
+}
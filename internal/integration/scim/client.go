@@ -24,6 +24,7 @@ type Client struct {
 	client  *http.Client
 	baseURL string
 	Users   *ResourceClient[resources.ScimUser]
+	Groups  *ResourceClient[resources.ScimGroup]
 }
 
 type ResourceClient[T any] struct {
@@ -122,6 +123,11 @@ func NewScimClient(target string) *Client {
 			baseURL:      target,
 			resourceName: "Users",
 		},
+		Groups: &ResourceClient[resources.ScimGroup]{
+			client:       client,
+			baseURL:      target,
+			resourceName: "Groups",
+		},
 	}
 }
 
@@ -0,0 +1,41 @@
+package ldapsync
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	SyncUserID = "LDAP-SYNC"
+	// syncRole is the role of the sync on the instance, it's mapped to permissions by the role mappings of the system users.
+	// It must only grant the permissions to write users and user grants.
+	syncRole = "IAM_LDAP_SYNC"
+)
+
+func HandlerContext(event *eventstore.Aggregate) context.Context {
+	ctx := authz.WithInstanceID(context.Background(), event.InstanceID)
+	return authz.SetCtxData(ctx, authz.CtxData{UserID: SyncUserID, OrgID: event.ResourceOwner})
+}
+
+// ContextWithSyncer sets the instance and the permissions to create and change the users and grants of the instance.
+// An error is returned if the role mappings of the system users do not grant the permissions.
+func ContextWithSyncer(ctx context.Context, instance authz.Instance, systemRoleMappings []authz.RoleMapping) (context.Context, error) {
+	ctx = authz.WithInstance(ctx, instance)
+	return authz.SetSystemCtxData(
+		ctx,
+		SyncUserID,
+		authz.Memberships{
+			{
+				MemberType:  authz.MemberTypeIAM,
+				AggregateID: instance.InstanceID(),
+				InstanceID:  instance.InstanceID(),
+				Roles:       []string{syncRole},
+			},
+		},
+		systemRoleMappings,
+		domain.PermissionUserWrite,
+	)
+}
@@ -0,0 +1,74 @@
+package ldapsync
+
+import (
+	"context"
+
+	"github.com/riverqueue/river"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/queue"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	HandlerTable = "projections.ldap_sync_handler"
+)
+
+type Queue interface {
+	Insert(ctx context.Context, args river.JobArgs, opts ...queue.InsertOpt) error
+}
+
+// eventHandler starts the sync job as soon as the synchronization of a provider is set.
+// The job reschedules itself until the synchronization is changed or removed.
+type eventHandler struct {
+	queue Queue
+}
+
+func NewEventHandler(
+	ctx context.Context,
+	config handler.Config,
+	queue Queue,
+) *handler.Handler {
+	return handler.NewHandler(ctx, &config, &eventHandler{
+		queue: queue,
+	})
+}
+
+func (u *eventHandler) Name() string {
+	return HandlerTable
+}
+
+func (u *eventHandler) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.LDAPSyncSetEventType,
+					Reduce: u.reduceSyncSet,
+				},
+			},
+		},
+	}
+}
+
+func (u *eventHandler) reduceSyncSet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.LDAPSyncSetEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-ahX4o", "reduce.wrong.event.type %s", instance.LDAPSyncSetEventType)
+	}
+	return handler.NewStatement(e, func(ex handler.Executer, projectionName string) error {
+		return u.queue.Insert(HandlerContext(e.Aggregate()),
+			&instance.LDAPSyncRequest{
+				InstanceID: e.Aggregate().InstanceID,
+				IDPID:      e.ID,
+				Sequence:   e.Sequence(),
+			},
+			queue.WithQueueName(instance.LDAPSyncQueueName),
+			// the events are reduced again if the projection is reset
+			queue.WithUniqueArgs(),
+		)
+	}), nil
+}
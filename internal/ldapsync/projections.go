@@ -0,0 +1,43 @@
+package ldapsync
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/queue"
+)
+
+var (
+	projections []*handler.Handler
+)
+
+func Register(
+	ctx context.Context,
+	handlerCustomConfig projection.CustomConfig,
+	workerConfig WorkerConfig,
+	commands *command.Commands,
+	queries *query.Queries,
+	userEncryption crypto.EncryptionAlgorithm,
+	systemRoleMappings []authz.RoleMapping,
+	queue *queue.Queue,
+) {
+	if workerConfig.Workers == 0 {
+		return
+	}
+	queue.ShouldStart()
+	projections = []*handler.Handler{
+		NewEventHandler(ctx, projection.ApplyCustomConfig(handlerCustomConfig), queue),
+	}
+	queue.AddWorkers(NewWorker(workerConfig, commands, queries, userEncryption, systemRoleMappings))
+}
+
+func Start(ctx context.Context) {
+	for _, projection := range projections {
+		projection.Start(ctx)
+	}
+}
@@ -0,0 +1,437 @@
+package ldapsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+var errMissingID = errors.New("user without id")
+
+// Directory returns the users of an LDAP server page by page, it's implemented by [ldap.Provider].
+type Directory interface {
+	SearchUsers(ctx context.Context, filter string, pageSize uint32, groupAttribute string, fn func(users []*ldap.DirectoryUser) error) error
+}
+
+var _ Directory = (*ldap.Provider)(nil)
+
+type Queries interface {
+	InstanceByID(ctx context.Context, id string) (instance authz.Instance, err error)
+	LDAPSyncByIDPID(ctx context.Context, idpID string) (*query.LDAPSync, error)
+	IDPUserLinks(ctx context.Context, queries *query.IDPUserLinksSearchQuery, permissionCheck domain.PermissionCheck) (*query.IDPUserLinks, error)
+	SearchUsers(ctx context.Context, queries *query.UserSearchQueries, permissionCheck domain.PermissionCheck) (*query.Users, error)
+	UserGrants(ctx context.Context, queries *query.UserGrantsQueries, shouldTriggerBulk bool) (*query.UserGrants, error)
+}
+
+type Commands interface {
+	GetProvider(ctx context.Context, idpID string, idpCallback string, samlRootURL string) (idp.Provider, error)
+	AddUserHuman(ctx context.Context, resourceOwner string, human *command.AddHuman, allowInitMail bool, alg crypto.EncryptionAlgorithm) error
+	ChangeUserHuman(ctx context.Context, human *command.ChangeHuman, alg crypto.EncryptionAlgorithm) error
+	AddUserGrant(ctx context.Context, userGrant *domain.UserGrant, resourceOwner string) (*domain.UserGrant, error)
+	ChangeUserGrant(ctx context.Context, userGrant *domain.UserGrant, resourceOwner string) (*domain.UserGrant, error)
+	RemoveUserGrant(ctx context.Context, grantID, resourceOwner string) (*domain.ObjectDetails, error)
+	ReportInstanceLDAPSync(ctx context.Context, idpID string, report instance.LDAPSyncReport) (*domain.ObjectDetails, error)
+}
+
+// synchronizer runs a single synchronization of the users of a directory.
+type synchronizer struct {
+	commands Commands
+	queries  Queries
+	userAlg  crypto.EncryptionAlgorithm
+	now      func() time.Time
+
+	config *query.LDAPSync
+	report instance.LDAPSyncReport
+	// links are the users linked to the provider by the id of the directory user
+	links map[string]*query.IDPUserLink
+	// seen are the ids of the directory users returned by the directory
+	seen map[string]struct{}
+}
+
+func newSynchronizer(commands Commands, queries Queries, userAlg crypto.EncryptionAlgorithm, now func() time.Time, config *query.LDAPSync) *synchronizer {
+	return &synchronizer{
+		commands: commands,
+		queries:  queries,
+		userAlg:  userAlg,
+		now:      now,
+		config:   config,
+		seen:     make(map[string]struct{}),
+	}
+}
+
+// run synchronizes the users of the directory and returns the report.
+// Failures of single users are only reported, the missing users are not deactivated if the directory search failed.
+func (s *synchronizer) run(ctx context.Context, directory Directory) instance.LDAPSyncReport {
+	s.report.StartedAt = s.now()
+	s.sync(ctx, directory)
+	s.report.EndedAt = s.now()
+	return s.report
+}
+
+func (s *synchronizer) sync(ctx context.Context, directory Directory) {
+	if err := s.loadLinks(ctx); err != nil {
+		s.fail("", err)
+		return
+	}
+	err := directory.SearchUsers(ctx, s.config.Filter, s.config.PageSize, s.config.GroupAttribute, func(users []*ldap.DirectoryUser) error {
+		return s.syncPage(ctx, users)
+	})
+	if err != nil {
+		s.fail("", err)
+		return
+	}
+	if s.config.DeactivateMissing {
+		s.deactivateMissing(ctx)
+	}
+}
+
+func (s *synchronizer) loadLinks(ctx context.Context) error {
+	idpQuery, err := query.NewIDPUserLinkIDPIDSearchQuery(s.config.IDPID)
+	if err != nil {
+		return err
+	}
+	links, err := s.queries.IDPUserLinks(ctx, &query.IDPUserLinksSearchQuery{Queries: []query.SearchQuery{idpQuery}}, nil)
+	if err != nil {
+		return err
+	}
+	s.links = make(map[string]*query.IDPUserLink, len(links.Links))
+	for _, link := range links.Links {
+		s.links[link.ProvidedUserID] = link
+	}
+	return nil
+}
+
+func (s *synchronizer) syncPage(ctx context.Context, directoryUsers []*ldap.DirectoryUser) error {
+	userIDs := make([]string, 0, len(directoryUsers))
+	for _, directoryUser := range directoryUsers {
+		if link, ok := s.links[directoryUser.ID]; ok {
+			userIDs = append(userIDs, link.UserID)
+		}
+	}
+	users, err := s.users(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+	for _, directoryUser := range directoryUsers {
+		if directoryUser.ID == "" {
+			s.fail("", errMissingID)
+			continue
+		}
+		if _, ok := s.seen[directoryUser.ID]; ok {
+			continue
+		}
+		s.seen[directoryUser.ID] = struct{}{}
+
+		userID, err := s.syncUser(ctx, directoryUser, users)
+		if err != nil {
+			s.fail(directoryUser.ID, err)
+			continue
+		}
+		if err = s.syncGrants(ctx, userID, directoryUser); err != nil {
+			s.fail(directoryUser.ID, err)
+		}
+	}
+	return ctx.Err()
+}
+
+// syncUser creates the user if it's not linked yet, otherwise the linked user is updated.
+func (s *synchronizer) syncUser(ctx context.Context, directoryUser *ldap.DirectoryUser, users map[string]*query.User) (userID string, err error) {
+	link, ok := s.links[directoryUser.ID]
+	if !ok {
+		return s.createUser(ctx, directoryUser)
+	}
+	user, ok := users[link.UserID]
+	if !ok || user.Human == nil {
+		return "", fmt.Errorf("linked user %s not found", link.UserID)
+	}
+	changes := userChanges(user, directoryUser)
+	if changes == nil {
+		s.report.Unchanged++
+		return user.ID, nil
+	}
+	if err = s.commands.ChangeUserHuman(ctx, changes, s.userAlg); err != nil {
+		return "", err
+	}
+	if changes.State != nil {
+		s.report.Reactivated++
+	}
+	if changes.Profile != nil || changes.Email != nil || changes.Phone != nil {
+		s.report.Updated++
+	}
+	return user.ID, nil
+}
+
+func (s *synchronizer) createUser(ctx context.Context, directoryUser *ldap.DirectoryUser) (string, error) {
+	username := directoryUser.PreferredUsername
+	if username == "" {
+		username = string(directoryUser.Email)
+	}
+	human := &command.AddHuman{
+		Username:    username,
+		FirstName:   directoryUser.FirstName,
+		LastName:    directoryUser.LastName,
+		NickName:    directoryUser.NickName,
+		DisplayName: directoryUser.DisplayName,
+		Email: command.Email{
+			Address:  directoryUser.Email,
+			Verified: directoryUser.EmailVerified,
+			// the users are not created by themselves, so they must not be asked to verify their email
+			NoEmailVerification: true,
+		},
+		PreferredLanguage: directoryUser.PreferredLanguage,
+		Phone: command.Phone{
+			Number:   directoryUser.Phone,
+			Verified: directoryUser.PhoneVerified,
+		},
+		Links: []*command.AddLink{
+			{
+				IDPID:         s.config.IDPID,
+				DisplayName:   username,
+				IDPExternalID: directoryUser.ID,
+			},
+		},
+	}
+	if err := s.commands.AddUserHuman(ctx, s.config.OrganizationID, human, false, s.userAlg); err != nil {
+		return "", err
+	}
+	s.links[directoryUser.ID] = &query.IDPUserLink{
+		IDPID:          s.config.IDPID,
+		UserID:         human.ID,
+		ProvidedUserID: directoryUser.ID,
+		ResourceOwner:  s.config.OrganizationID,
+	}
+	s.report.Created++
+	return human.ID, nil
+}
+
+// userChanges returns the changes to apply to the user, nil if nothing changed.
+// Attributes which are empty in the directory are not removed from the user.
+func userChanges(user *query.User, directoryUser *ldap.DirectoryUser) *command.ChangeHuman {
+	changes := &command.ChangeHuman{
+		ID:            user.ID,
+		ResourceOwner: user.ResourceOwner,
+	}
+	profile := new(command.Profile)
+	var profileChanged bool
+	setIfChanged := func(target **string, current, value string) {
+		if value != "" && value != current {
+			*target = &value
+			profileChanged = true
+		}
+	}
+	setIfChanged(&profile.FirstName, user.Human.FirstName, directoryUser.FirstName)
+	setIfChanged(&profile.LastName, user.Human.LastName, directoryUser.LastName)
+	setIfChanged(&profile.NickName, user.Human.NickName, directoryUser.NickName)
+	setIfChanged(&profile.DisplayName, user.Human.DisplayName, directoryUser.DisplayName)
+	if !directoryUser.PreferredLanguage.IsRoot() && directoryUser.PreferredLanguage != user.Human.PreferredLanguage {
+		profile.PreferredLanguage = &directoryUser.PreferredLanguage
+		profileChanged = true
+	}
+	if profileChanged {
+		changes.Profile = profile
+	}
+	if directoryUser.Email != "" && (directoryUser.Email != user.Human.Email || directoryUser.EmailVerified && !user.Human.IsEmailVerified) {
+		changes.Email = &command.Email{
+			Address:             directoryUser.Email,
+			Verified:            directoryUser.EmailVerified,
+			NoEmailVerification: true,
+		}
+	}
+	if directoryUser.Phone != "" && (directoryUser.Phone != user.Human.Phone || directoryUser.PhoneVerified && !user.Human.IsPhoneVerified) {
+		changes.Phone = &command.Phone{
+			Number:   directoryUser.Phone,
+			Verified: directoryUser.PhoneVerified,
+		}
+	}
+	if user.State == domain.UserStateInactive {
+		active := domain.UserStateActive
+		changes.State = &active
+	}
+	if changes.Profile == nil && changes.Email == nil && changes.Phone == nil && changes.State == nil {
+		return nil
+	}
+	return changes
+}
+
+// syncGrants grants the roles of the groups of the user.
+// Only the role keys of the group grants are managed by the synchronization:
+// they are added to and removed from the grant of the user on the project according to the groups of the user,
+// other roles of the grant are kept and the grant is only removed if no role is left.
+func (s *synchronizer) syncGrants(ctx context.Context, userID string, directoryUser *ldap.DirectoryUser) error {
+	if len(s.config.GroupGrants) == 0 {
+		return nil
+	}
+	projectIDs := make([]string, 0, len(s.config.GroupGrants))
+	managed := make(map[string][]string, len(s.config.GroupGrants))
+	desired := make(map[string][]string, len(s.config.GroupGrants))
+	resourceOwners := make(map[string]string, len(s.config.GroupGrants))
+	for _, groupGrant := range s.config.GroupGrants {
+		if !slices.Contains(projectIDs, groupGrant.ProjectID) {
+			projectIDs = append(projectIDs, groupGrant.ProjectID)
+		}
+		resourceOwners[groupGrant.ProjectID] = groupGrant.ProjectResourceOwner
+		managed[groupGrant.ProjectID] = appendMissing(managed[groupGrant.ProjectID], groupGrant.RoleKeys...)
+		if directoryUser.HasGroup(groupGrant.Group) {
+			desired[groupGrant.ProjectID] = appendMissing(desired[groupGrant.ProjectID], groupGrant.RoleKeys...)
+		}
+	}
+
+	existing, err := s.grants(ctx, userID, projectIDs)
+	if err != nil {
+		return err
+	}
+	for _, projectID := range projectIDs {
+		grant, isExisting := existing[projectID]
+		if !isExisting {
+			if len(desired[projectID]) == 0 {
+				continue
+			}
+			want := &domain.UserGrant{UserID: userID, ProjectID: projectID, RoleKeys: desired[projectID]}
+			if _, err = s.commands.AddUserGrant(ctx, want, resourceOwners[projectID]); err != nil {
+				return err
+			}
+			s.report.GrantsAdded++
+			continue
+		}
+		// roles not managed by the synchronization (e.g. granted manually) are kept
+		roles := slices.DeleteFunc(slices.Clone(grant.Roles), func(role string) bool {
+			return slices.Contains(managed[projectID], role)
+		})
+		roles = appendMissing(roles, desired[projectID]...)
+		switch {
+		case equalRoles(grant.Roles, roles):
+			continue
+		case len(roles) == 0:
+			if _, err = s.commands.RemoveUserGrant(ctx, grant.ID, grant.ResourceOwner); err != nil {
+				return err
+			}
+			s.report.GrantsRemoved++
+		default:
+			want := &domain.UserGrant{
+				ObjectRoot: es_models.ObjectRoot{AggregateID: grant.ID, ResourceOwner: grant.ResourceOwner},
+				UserID:     userID,
+				ProjectID:  projectID,
+				RoleKeys:   roles,
+			}
+			if _, err = s.commands.ChangeUserGrant(ctx, want, grant.ResourceOwner); err != nil {
+				return err
+			}
+			s.report.GrantsChanged++
+		}
+	}
+	return nil
+}
+
+// grants returns the direct grants of the user on the projects by project id,
+// grants of granted projects and grants inherited through groups are ignored.
+func (s *synchronizer) grants(ctx context.Context, userID string, projectIDs []string) (map[string]*query.UserGrant, error) {
+	userQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	projectQuery, err := query.NewUserGrantProjectIDsSearchQuery(projectIDs)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := s.queries.UserGrants(ctx, &query.UserGrantsQueries{Queries: []query.SearchQuery{userQuery, projectQuery}}, true)
+	if err != nil {
+		return nil, err
+	}
+	byProject := make(map[string]*query.UserGrant, len(grants.UserGrants))
+	for _, grant := range grants.UserGrants {
+		if grant.GrantID != "" || grant.GroupID != "" {
+			continue
+		}
+		byProject[grant.ProjectID] = grant
+	}
+	return byProject, nil
+}
+
+// deactivateMissing deactivates the active linked users which were not returned by the directory.
+func (s *synchronizer) deactivateMissing(ctx context.Context) {
+	userIDs := make([]string, 0, len(s.links))
+	for externalID, link := range s.links {
+		if _, ok := s.seen[externalID]; !ok {
+			userIDs = append(userIDs, link.UserID)
+		}
+	}
+	for chunk := range slices.Chunk(userIDs, int(max(s.config.PageSize, ldap.DefaultPageSize))) {
+		users, err := s.users(ctx, chunk)
+		if err != nil {
+			s.fail("", err)
+			return
+		}
+		for _, userID := range chunk {
+			user, ok := users[userID]
+			if !ok || user.State != domain.UserStateActive {
+				continue
+			}
+			inactive := domain.UserStateInactive
+			err = s.commands.ChangeUserHuman(ctx, &command.ChangeHuman{
+				ID:            user.ID,
+				ResourceOwner: user.ResourceOwner,
+				State:         &inactive,
+			}, s.userAlg)
+			if err != nil {
+				s.fail(userID, err)
+				continue
+			}
+			s.report.Deactivated++
+		}
+	}
+}
+
+func (s *synchronizer) users(ctx context.Context, userIDs []string) (map[string]*query.User, error) {
+	if len(userIDs) == 0 {
+		return map[string]*query.User{}, nil
+	}
+	idQuery, err := query.NewUserInUserIdsSearchQuery(userIDs)
+	if err != nil {
+		return nil, err
+	}
+	users, err := s.queries.SearchUsers(ctx, &query.UserSearchQueries{Queries: []query.SearchQuery{idQuery}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*query.User, len(users.Users))
+	for _, user := range users.Users {
+		byID[user.ID] = user
+	}
+	return byID, nil
+}
+
+func (s *synchronizer) fail(externalID string, err error) {
+	s.report.Failed++
+	if externalID == "" {
+		s.report.Errors = append(s.report.Errors, err.Error())
+		return
+	}
+	s.report.Errors = append(s.report.Errors, externalID+": "+err.Error())
+}
+
+func appendMissing(roles []string, add ...string) []string {
+	for _, role := range add {
+		if !slices.Contains(roles, role) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+func equalRoles(current, desired []string) bool {
+	return len(current) == len(desired) && !slices.ContainsFunc(desired, func(role string) bool {
+		return !slices.Contains(current, role)
+	})
+}
@@ -0,0 +1,385 @@
+package ldapsync
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+var testNow = time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC)
+
+// localDirectory is a stand-in for an LDAP server returning the pages in order.
+type localDirectory struct {
+	pages [][]*ldap.DirectoryUser
+	err   error
+}
+
+func (d *localDirectory) SearchUsers(_ context.Context, _ string, _ uint32, _ string, fn func(users []*ldap.DirectoryUser) error) error {
+	for _, page := range d.pages {
+		if err := fn(page); err != nil {
+			return err
+		}
+	}
+	return d.err
+}
+
+type testQueries struct {
+	instance authz.Instance
+	sync     *query.LDAPSync
+	syncErr  error
+	links    []*query.IDPUserLink
+	users    []*query.User
+	grants   []*query.UserGrant
+}
+
+func (q *testQueries) InstanceByID(context.Context, string) (authz.Instance, error) {
+	return q.instance, nil
+}
+
+func (q *testQueries) LDAPSyncByIDPID(context.Context, string) (*query.LDAPSync, error) {
+	return q.sync, q.syncErr
+}
+
+func (q *testQueries) IDPUserLinks(context.Context, *query.IDPUserLinksSearchQuery, domain.PermissionCheck) (*query.IDPUserLinks, error) {
+	return &query.IDPUserLinks{Links: q.links}, nil
+}
+
+func (q *testQueries) SearchUsers(context.Context, *query.UserSearchQueries, domain.PermissionCheck) (*query.Users, error) {
+	return &query.Users{Users: q.users}, nil
+}
+
+func (q *testQueries) UserGrants(context.Context, *query.UserGrantsQueries, bool) (*query.UserGrants, error) {
+	return &query.UserGrants{UserGrants: q.grants}, nil
+}
+
+type testCommands struct {
+	provider idp.Provider
+	// failUsers are the usernames for which the creation fails
+	failUsers []string
+
+	added         []*command.AddHuman
+	changed       []*command.ChangeHuman
+	addedGrants   []*domain.UserGrant
+	changedGrants []*domain.UserGrant
+	removedGrants []string
+	reports       []instance.LDAPSyncReport
+}
+
+func (c *testCommands) GetProvider(context.Context, string, string, string) (idp.Provider, error) {
+	return c.provider, nil
+}
+
+func (c *testCommands) AddUserHuman(_ context.Context, _ string, human *command.AddHuman, _ bool, _ crypto.EncryptionAlgorithm) error {
+	for _, username := range c.failUsers {
+		if human.Username == username {
+			return errors.New("creation failed")
+		}
+	}
+	human.ID = "user-" + human.Username
+	c.added = append(c.added, human)
+	return nil
+}
+
+func (c *testCommands) ChangeUserHuman(_ context.Context, human *command.ChangeHuman, _ crypto.EncryptionAlgorithm) error {
+	c.changed = append(c.changed, human)
+	return nil
+}
+
+func (c *testCommands) AddUserGrant(_ context.Context, userGrant *domain.UserGrant, _ string) (*domain.UserGrant, error) {
+	c.addedGrants = append(c.addedGrants, userGrant)
+	return userGrant, nil
+}
+
+func (c *testCommands) ChangeUserGrant(_ context.Context, userGrant *domain.UserGrant, _ string) (*domain.UserGrant, error) {
+	c.changedGrants = append(c.changedGrants, userGrant)
+	return userGrant, nil
+}
+
+func (c *testCommands) RemoveUserGrant(_ context.Context, grantID, _ string) (*domain.ObjectDetails, error) {
+	c.removedGrants = append(c.removedGrants, grantID)
+	return &domain.ObjectDetails{}, nil
+}
+
+func (c *testCommands) ReportInstanceLDAPSync(_ context.Context, _ string, report instance.LDAPSyncReport) (*domain.ObjectDetails, error) {
+	c.reports = append(c.reports, report)
+	return &domain.ObjectDetails{}, nil
+}
+
+func directoryUser(id, username, firstName string, groups ...string) *ldap.DirectoryUser {
+	return &ldap.DirectoryUser{
+		User:   ldap.NewUser(id, firstName, "last", "", "", username, domain.EmailAddress(username+"@example.com"), true, "", false, language.Und, "", ""),
+		Groups: groups,
+	}
+}
+
+func linkedUser(userID, firstName string, state domain.UserState) *query.User {
+	return &query.User{
+		ID:            userID,
+		ResourceOwner: "org1",
+		State:         state,
+		Human: &query.Human{
+			FirstName:       firstName,
+			LastName:        "last",
+			Email:           domain.EmailAddress(userID + "@example.com"),
+			IsEmailVerified: true,
+		},
+	}
+}
+
+func link(externalID, userID string) *query.IDPUserLink {
+	return &query.IDPUserLink{IDPID: "idp1", UserID: userID, ProvidedUserID: externalID, ResourceOwner: "org1"}
+}
+
+func Test_synchronizer_run(t *testing.T) {
+	type want struct {
+		report  instance.LDAPSyncReport
+		added   []string
+		changed []*command.ChangeHuman
+	}
+	tests := []struct {
+		name      string
+		config    *query.LDAPSync
+		queries   *testQueries
+		commands  *testCommands
+		directory *localDirectory
+		want      want
+	}{
+		{
+			name:     "create unlinked users over multiple pages",
+			config:   &query.LDAPSync{IDPID: "idp1", OrganizationID: "org1"},
+			queries:  &testQueries{},
+			commands: &testCommands{},
+			directory: &localDirectory{
+				pages: [][]*ldap.DirectoryUser{
+					{directoryUser("ext1", "user1", "first")},
+					{directoryUser("ext2", "user2", "first")},
+				},
+			},
+			want: want{
+				report: instance.LDAPSyncReport{Created: 2},
+				added:  []string{"user-user1", "user-user2"},
+			},
+		},
+		{
+			name:   "update changed and keep unchanged users",
+			config: &query.LDAPSync{IDPID: "idp1", OrganizationID: "org1"},
+			queries: &testQueries{
+				links: []*query.IDPUserLink{link("ext1", "user1"), link("ext2", "user2")},
+				users: []*query.User{
+					linkedUser("user1", "old", domain.UserStateActive),
+					linkedUser("user2", "first", domain.UserStateActive),
+				},
+			},
+			commands: &testCommands{},
+			directory: &localDirectory{
+				pages: [][]*ldap.DirectoryUser{
+					{directoryUser("ext1", "user1", "new"), directoryUser("ext2", "user2", "first")},
+				},
+			},
+			want: want{
+				report: instance.LDAPSyncReport{Updated: 1, Unchanged: 1},
+				changed: []*command.ChangeHuman{
+					{
+						ID:            "user1",
+						ResourceOwner: "org1",
+						Profile:       &command.Profile{FirstName: gu("new")},
+					},
+				},
+			},
+		},
+		{
+			name:   "deactivate missing and reactivate returned users",
+			config: &query.LDAPSync{IDPID: "idp1", OrganizationID: "org1", DeactivateMissing: true},
+			queries: &testQueries{
+				links: []*query.IDPUserLink{link("ext1", "user1"), link("ext2", "user2")},
+				users: []*query.User{
+					linkedUser("user1", "first", domain.UserStateInactive),
+					linkedUser("user2", "first", domain.UserStateActive),
+				},
+			},
+			commands: &testCommands{},
+			directory: &localDirectory{
+				pages: [][]*ldap.DirectoryUser{
+					{directoryUser("ext1", "user1", "first")},
+				},
+			},
+			want: want{
+				report: instance.LDAPSyncReport{Reactivated: 1, Deactivated: 1},
+				changed: []*command.ChangeHuman{
+					{ID: "user1", ResourceOwner: "org1", State: gu(domain.UserStateActive)},
+					{ID: "user2", ResourceOwner: "org1", State: gu(domain.UserStateInactive)},
+				},
+			},
+		},
+		{
+			name:   "directory failure, missing users not deactivated",
+			config: &query.LDAPSync{IDPID: "idp1", OrganizationID: "org1", DeactivateMissing: true},
+			queries: &testQueries{
+				links: []*query.IDPUserLink{link("ext2", "user2")},
+				users: []*query.User{linkedUser("user2", "first", domain.UserStateActive)},
+			},
+			commands: &testCommands{},
+			directory: &localDirectory{
+				pages: [][]*ldap.DirectoryUser{
+					{directoryUser("ext1", "user1", "first")},
+				},
+				err: errors.New("connection lost"),
+			},
+			want: want{
+				report: instance.LDAPSyncReport{Created: 1, Failed: 1, Errors: []string{"connection lost"}},
+				added:  []string{"user-user1"},
+			},
+		},
+		{
+			name:    "failed user is reported, others are synchronized",
+			config:  &query.LDAPSync{IDPID: "idp1", OrganizationID: "org1"},
+			queries: &testQueries{},
+			commands: &testCommands{
+				failUsers: []string{"user1"},
+			},
+			directory: &localDirectory{
+				pages: [][]*ldap.DirectoryUser{
+					{directoryUser("ext1", "user1", "first"), directoryUser("", "user2", "first"), directoryUser("ext3", "user3", "first")},
+				},
+			},
+			want: want{
+				report: instance.LDAPSyncReport{Created: 1, Failed: 2, Errors: []string{"ext1: creation failed", "user without id"}},
+				added:  []string{"user-user3"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newSynchronizer(tt.commands, tt.queries, nil, func() time.Time { return testNow }, tt.config).run(context.Background(), tt.directory)
+
+			tt.want.report.StartedAt = testNow
+			tt.want.report.EndedAt = testNow
+			assert.Equal(t, tt.want.report, got)
+			added := make([]string, len(tt.commands.added))
+			for i, human := range tt.commands.added {
+				added[i] = human.ID
+				assert.Equal(t, "idp1", human.Links[0].IDPID)
+			}
+			assert.ElementsMatch(t, tt.want.added, added)
+			assert.ElementsMatch(t, tt.want.changed, tt.commands.changed)
+		})
+	}
+}
+
+func Test_synchronizer_syncGrants(t *testing.T) {
+	config := &query.LDAPSync{
+		IDPID:          "idp1",
+		OrganizationID: "org1",
+		GroupAttribute: "memberOf",
+		GroupGrants: []*query.LDAPSyncGroupGrant{
+			{Group: "cn=sales", ProjectID: "project1", ProjectResourceOwner: "org2", RoleKeys: []string{"seller"}},
+			{Group: "cn=managers", ProjectID: "project1", ProjectResourceOwner: "org2", RoleKeys: []string{"manager"}},
+			{Group: "cn=support", ProjectID: "project2", ProjectResourceOwner: "org2", RoleKeys: []string{"agent"}},
+			{Group: "cn=admins", ProjectID: "project3", ProjectResourceOwner: "org2", RoleKeys: []string{"admin"}},
+		},
+	}
+	tests := []struct {
+		name          string
+		user          *ldap.DirectoryUser
+		grants        []*query.UserGrant
+		report        instance.LDAPSyncReport
+		addedGrants   []*domain.UserGrant
+		changedGrants []*domain.UserGrant
+		removedGrants []string
+	}{
+		{
+			name: "add grants of groups",
+			user: directoryUser("ext1", "user1", "first", "CN=Sales", "cn=managers"),
+			report: instance.LDAPSyncReport{
+				Unchanged:   1,
+				GrantsAdded: 1,
+			},
+			addedGrants: []*domain.UserGrant{
+				{UserID: "user1", ProjectID: "project1", RoleKeys: []string{"seller", "manager"}},
+			},
+		},
+		{
+			name: "change, keep and remove grants",
+			user: directoryUser("ext1", "user1", "first", "cn=sales", "cn=support"),
+			grants: []*query.UserGrant{
+				{ID: "grant1", ResourceOwner: "org2", ProjectID: "project1", Roles: []string{"seller", "manager"}},
+				{ID: "grant2", ResourceOwner: "org2", ProjectID: "project2", Roles: []string{"agent"}},
+				{ID: "grant3", ResourceOwner: "org2", ProjectID: "project3", Roles: []string{"admin"}},
+				{ID: "grant4", ResourceOwner: "org3", ProjectID: "project3", GrantID: "projectgrant1", Roles: []string{"admin"}},
+			},
+			report: instance.LDAPSyncReport{
+				Unchanged:     1,
+				GrantsChanged: 1,
+				GrantsRemoved: 1,
+			},
+			changedGrants: []*domain.UserGrant{
+				{
+					ObjectRoot: es_models.ObjectRoot{AggregateID: "grant1", ResourceOwner: "org2"},
+					UserID:     "user1",
+					ProjectID:  "project1",
+					RoleKeys:   []string{"seller"},
+				},
+			},
+			removedGrants: []string{"grant3"},
+		},
+		{
+			name: "keep roles not managed by the synchronization and ignore group grants",
+			user: directoryUser("ext1", "user1", "first", "cn=sales"),
+			grants: []*query.UserGrant{
+				{ID: "grant1", ResourceOwner: "org2", ProjectID: "project1", Roles: []string{"seller"}},
+				{ID: "grant2", ResourceOwner: "org2", ProjectID: "project2", Roles: []string{"agent", "viewer"}},
+				{ID: "groupgrant1", ResourceOwner: "org2", ProjectID: "project3", GroupID: "group1", Roles: []string{"admin"}},
+			},
+			report: instance.LDAPSyncReport{
+				Unchanged:     1,
+				GrantsChanged: 1,
+			},
+			changedGrants: []*domain.UserGrant{
+				{
+					ObjectRoot: es_models.ObjectRoot{AggregateID: "grant2", ResourceOwner: "org2"},
+					UserID:     "user1",
+					ProjectID:  "project2",
+					RoleKeys:   []string{"viewer"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries := &testQueries{
+				links:  []*query.IDPUserLink{link("ext1", "user1")},
+				users:  []*query.User{linkedUser("user1", "first", domain.UserStateActive)},
+				grants: tt.grants,
+			}
+			commands := &testCommands{}
+			got := newSynchronizer(commands, queries, nil, func() time.Time { return testNow }, config).run(context.Background(), &localDirectory{
+				pages: [][]*ldap.DirectoryUser{{tt.user}},
+			})
+
+			tt.report.StartedAt = testNow
+			tt.report.EndedAt = testNow
+			assert.Equal(t, tt.report, got)
+			assert.Equal(t, tt.addedGrants, commands.addedGrants)
+			assert.Equal(t, tt.changedGrants, commands.changedGrants)
+			assert.Equal(t, tt.removedGrants, commands.removedGrants)
+		})
+	}
+}
+
+func gu[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,114 @@
+package ldapsync
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/riverqueue/river"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	errConfigChanged = errors.New("sync configuration changed")
+	errNoDirectory   = errors.New("provider is not an ldap provider")
+)
+
+type Worker struct {
+	river.WorkerDefaults[*instance.LDAPSyncRequest]
+
+	config             WorkerConfig
+	commands           Commands
+	queries            Queries
+	userAlg            crypto.EncryptionAlgorithm
+	systemRoleMappings []authz.RoleMapping
+	now                nowFunc
+}
+
+// Timeout implements the Timeout-function of [river.Worker].
+// Maximum time a sync can run before the context gets cancelled, the users synchronized until then are kept.
+func (w *Worker) Timeout(*river.Job[*instance.LDAPSyncRequest]) time.Duration {
+	return w.config.TransactionDuration
+}
+
+// Work implements [river.Worker].
+// It synchronizes the users of the provider once, stores the report and snoozes the job until the next sync is due.
+// The job is cancelled if the instance, the provider or the synchronization do not exist anymore
+// or if the synchronization was changed, as the change started a new job.
+func (w *Worker) Work(ctx context.Context, job *river.Job[*instance.LDAPSyncRequest]) error {
+	instance, err := w.queries.InstanceByID(ctx, job.Args.InstanceID)
+	if err != nil {
+		return cancelIfNotFound(err)
+	}
+	ctx, err = ContextWithSyncer(ctx, instance, w.systemRoleMappings)
+	if err != nil {
+		return err
+	}
+
+	config, err := w.queries.LDAPSyncByIDPID(ctx, job.Args.IDPID)
+	if err != nil {
+		return cancelIfNotFound(err)
+	}
+	if config.Sequence != job.Args.Sequence {
+		return river.JobCancel(errConfigChanged)
+	}
+	provider, err := w.commands.GetProvider(ctx, config.IDPID, "", "")
+	if err != nil {
+		return cancelIfNotFound(err)
+	}
+	directory, ok := provider.(Directory)
+	if !ok {
+		return river.JobCancel(errNoDirectory)
+	}
+
+	report := newSynchronizer(w.commands, w.queries, w.userAlg, w.now, config).run(ctx, directory)
+	if _, err = w.commands.ReportInstanceLDAPSync(ctx, config.IDPID, report); err != nil {
+		return err
+	}
+	return river.JobSnooze(config.Interval)
+}
+
+func cancelIfNotFound(err error) error {
+	if zerrors.IsNotFound(err) {
+		return river.JobCancel(err)
+	}
+	return err
+}
+
+// nowFunc makes [time.Now] mockable
+type nowFunc func() time.Time
+
+type WorkerConfig struct {
+	Workers             uint8
+	TransactionDuration time.Duration
+}
+
+func NewWorker(
+	config WorkerConfig,
+	commands Commands,
+	queries Queries,
+	userAlg crypto.EncryptionAlgorithm,
+	systemRoleMappings []authz.RoleMapping,
+) *Worker {
+	return &Worker{
+		config:             config,
+		commands:           commands,
+		queries:            queries,
+		userAlg:            userAlg,
+		systemRoleMappings: systemRoleMappings,
+		now:                time.Now,
+	}
+}
+
+var _ river.Worker[*instance.LDAPSyncRequest] = (*Worker)(nil)
+
+func (w *Worker) Register(workers *river.Workers, queues map[string]river.QueueConfig) {
+	river.AddWorker(workers, w)
+	queues[instance.LDAPSyncQueueName] = river.QueueConfig{
+		MaxWorkers: int(w.config.Workers),
+	}
+}
@@ -0,0 +1,136 @@
+package ldapsync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type testInstance struct {
+	authz.Instance
+}
+
+func (i *testInstance) InstanceID() string {
+	return "instance1"
+}
+
+// testProvider is a non directory provider
+type testProvider struct {
+	idp.Provider
+}
+
+// testDirectoryProvider is a provider which returns the users of a [localDirectory]
+type testDirectoryProvider struct {
+	idp.Provider
+	*localDirectory
+}
+
+func TestWorker_Work(t *testing.T) {
+	sync := &query.LDAPSync{
+		IDPID:          "idp1",
+		Sequence:       2,
+		Interval:       time.Hour,
+		OrganizationID: "org1",
+	}
+	tests := []struct {
+		name        string
+		queries     *testQueries
+		commands    *testCommands
+		sequence    uint64
+		wantCancel  bool
+		wantSnooze  time.Duration
+		wantReports []instance.LDAPSyncReport
+	}{
+		{
+			name: "sync removed, cancel",
+			queries: &testQueries{
+				instance: &testInstance{},
+				syncErr:  zerrors.ThrowNotFound(nil, "QUERY-ooT6e", "Errors.IDPConfig.NotExisting"),
+			},
+			commands:   &testCommands{},
+			sequence:   2,
+			wantCancel: true,
+		},
+		{
+			name: "sync changed, cancel",
+			queries: &testQueries{
+				instance: &testInstance{},
+				sync:     sync,
+			},
+			commands:   &testCommands{},
+			sequence:   1,
+			wantCancel: true,
+		},
+		{
+			name: "no directory, cancel",
+			queries: &testQueries{
+				instance: &testInstance{},
+				sync:     sync,
+			},
+			commands: &testCommands{
+				provider: &testProvider{},
+			},
+			sequence:   2,
+			wantCancel: true,
+		},
+		{
+			name: "synchronized, reported and snoozed",
+			queries: &testQueries{
+				instance: &testInstance{},
+				sync:     sync,
+			},
+			commands: &testCommands{
+				provider: &testDirectoryProvider{
+					localDirectory: &localDirectory{
+						pages: [][]*ldap.DirectoryUser{{directoryUser("ext1", "user1", "first")}},
+					},
+				},
+			},
+			sequence:   2,
+			wantSnooze: time.Hour,
+			wantReports: []instance.LDAPSyncReport{
+				{StartedAt: testNow, EndedAt: testNow, Created: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWorker(WorkerConfig{Workers: 1, TransactionDuration: time.Minute}, tt.commands, tt.queries, nil, []authz.RoleMapping{
+				{Role: syncRole, Permissions: []string{domain.PermissionUserWrite}},
+			})
+			w.now = func() time.Time { return testNow }
+
+			err := w.Work(context.Background(), &river.Job[*instance.LDAPSyncRequest]{
+				JobRow: &rivertype.JobRow{},
+				Args: &instance.LDAPSyncRequest{
+					InstanceID: "instance1",
+					IDPID:      "idp1",
+					Sequence:   tt.sequence,
+				},
+			})
+
+			if tt.wantCancel {
+				var cancelErr *river.JobCancelError
+				assert.ErrorAs(t, err, &cancelErr)
+				return
+			}
+			var snoozeErr *river.JobSnoozeError
+			require.ErrorAs(t, err, &snoozeErr)
+			assert.Equal(t, tt.wantSnooze, snoozeErr.Duration)
+			assert.Equal(t, tt.wantReports, tt.commands.reports)
+		})
+	}
+}
@@ -83,7 +83,7 @@ func (a AccessLog) isUnaccountableEndpoint() bool {
 func (a AccessLog) Normalize() *AccessLog {
 	a.RequestedDomain = cutString(a.RequestedDomain, 200)
 	a.RequestURL = cutString(a.RequestURL, 200)
-	a.RequestHeaders = normalizeHeaders(a.RequestHeaders, strings.ToLower(zitadel_http.Authorization), "grpcgateway-authorization", "cookie", "grpcgateway-cookie")
+	a.RequestHeaders = normalizeHeaders(a.RequestHeaders, strings.ToLower(zitadel_http.Authorization), "grpcgateway-authorization", "cookie", "grpcgateway-cookie", zitadel_http.GatewaySecret)
 	a.ResponseHeaders = normalizeHeaders(a.ResponseHeaders, "set-cookie")
 	a.normalized = true
 	return &a
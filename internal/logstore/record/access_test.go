@@ -18,6 +18,7 @@ func TestRecord_Normalize(t *testing.T) {
 				"grpcgateway-authorization": {"AValue"},
 				"cookie":                    {"AValue"},
 				"grpcgateway-cookie":        {"AValue"},
+				"zitadel-gateway-secret":    {"AValue"},
 			}, ResponseHeaders: map[string][]string{
 				"set-cookie": {"AValue"},
 			},
@@ -28,6 +29,7 @@ func TestRecord_Normalize(t *testing.T) {
 				"grpcgateway-authorization": {"[REDACTED]"},
 				"cookie":                    {"[REDACTED]"},
 				"grpcgateway-cookie":        {"[REDACTED]"},
+				"zitadel-gateway-secret":    {"[REDACTED]"},
 			}, ResponseHeaders: map[string][]string{
 				"set-cookie": {"[REDACTED]"},
 			},
@@ -1,6 +1,7 @@
 package email
 
 import (
+	"github.com/zitadel/zitadel/internal/notification/channels/httpemail"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
 	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
 )
@@ -9,6 +10,7 @@ type Config struct {
 	ProviderConfig *Provider
 	SMTPConfig     *smtp.Config
 	WebhookConfig  *webhook.Config
+	APIConfig      *httpemail.Config
 }
 
 type Provider struct {
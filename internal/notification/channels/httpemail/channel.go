@@ -0,0 +1,115 @@
+package httpemail
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	requestTimeout = 10 * time.Second
+	// maxErrorBodySize limits how much of an error response is read to classify the error
+	maxErrorBodySize = 64 << 10
+)
+
+// now is used to sign the requests and can be replaced in tests
+var now = time.Now
+
+var _ channels.NotificationChannel = (*Email)(nil)
+
+// sender implements the API of a specific provider
+type sender interface {
+	// name of the provider, used in logs and errors
+	name() string
+	// newRequest builds the (signed) request sending the email
+	newRequest(ctx context.Context, msg *messages.Email) (*http.Request, error)
+	// parseError extracts the error code and message of an unsuccessful response
+	// and reports whether sending the same email again will fail as well.
+	parseError(statusCode int, header http.Header, body []byte) (code, message string, permanent bool)
+}
+
+type Email struct {
+	ctx            context.Context
+	client         *http.Client
+	sender         sender
+	senderAddress  string
+	senderName     string
+	replyToAddress string
+}
+
+func InitChannel(ctx context.Context, cfg *Config) (*Email, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	var s sender
+	switch cfg.Provider {
+	case domain.EmailAPIProviderSES:
+		s = newSES(cfg)
+	case domain.EmailAPIProviderSendGrid:
+		s = newSendGrid(cfg)
+	case domain.EmailAPIProviderMailgun:
+		s = newMailgun(cfg)
+	case domain.EmailAPIProviderJSON:
+		s = newJSON(cfg)
+	case domain.EmailAPIProviderUnspecified:
+		return nil, zerrors.ThrowInvalidArgument(nil, "HTTPEMAIL-Wm2sF", "unknown email api provider")
+	}
+	logging.WithFields("provider", s.name()).Debug("successfully initialized http email channel")
+	return &Email{
+		ctx:            ctx,
+		client:         http.DefaultClient,
+		sender:         s,
+		senderAddress:  cfg.SenderAddress,
+		senderName:     cfg.SenderName,
+		replyToAddress: cfg.ReplyToAddress,
+	}, nil
+}
+
+func (email *Email) HandleMessage(message channels.Message) error {
+	emailMsg, ok := message.(*messages.Email)
+	if !ok {
+		return zerrors.ThrowInternal(nil, "HTTPEMAIL-Pq6dL", "Errors.SMTP.NotEmailMessage")
+	}
+	if emailMsg.Content == "" || emailMsg.Subject == "" || len(emailMsg.Recipients) == 0 {
+		return zerrors.ThrowInternal(nil, "HTTPEMAIL-Ks3bY", "Errors.SMTP.RequiredAttributes")
+	}
+	emailMsg.SenderEmail = email.senderAddress
+	emailMsg.SenderName = email.senderName
+	emailMsg.ReplyToAddress = email.replyToAddress
+
+	ctx, cancel := context.WithTimeout(email.ctx, requestTimeout)
+	defer cancel()
+	req, err := email.sender.newRequest(ctx, emailMsg)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "HTTPEMAIL-Hn9gC", "could not create request")
+	}
+	resp, err := email.client.Do(req)
+	if err != nil {
+		// the provider could not be reached, which might be temporary
+		return zerrors.ThrowInternal(err, "HTTPEMAIL-Xa7rJ", "could not send email")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		logging.WithFields("provider", email.sender.name(), "status", resp.StatusCode).Debug("email sent")
+		return nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil {
+		return zerrors.ThrowInternal(err, "HTTPEMAIL-Bv5eT", "could not read error response")
+	}
+	code, msg, permanent := email.sender.parseError(resp.StatusCode, resp.Header, body)
+	return classifyError(&ProviderError{
+		Provider:   email.sender.name(),
+		StatusCode: resp.StatusCode,
+		Code:       code,
+		Message:    msg,
+	}, permanent)
+}
@@ -0,0 +1,263 @@
+package httpemail
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+	"github.com/zitadel/zitadel/pkg/actions"
+)
+
+func Test_signV4(t *testing.T) {
+	// get-vanilla of the AWS Signature Version 4 test suite
+	req := httptest.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	signV4(req, nil,
+		"AKIDEXAMPLE",
+		"wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		"us-east-1",
+		"service",
+		time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC),
+	)
+	assert.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+	assert.Equal(t,
+		"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		req.Header.Get("Authorization"),
+	)
+}
+
+func TestEmail_HandleMessage(t *testing.T) {
+	testNow := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	now = func() time.Time { return testNow }
+	defer func() { now = time.Now }()
+
+	type response struct {
+		status int
+		header http.Header
+		body   string
+	}
+	tests := []struct {
+		name          string
+		config        *Config
+		checkRequest  func(t *testing.T, r *http.Request, body []byte)
+		response      response
+		wantCancelErr bool
+		wantErr       bool
+	}{
+		{
+			name: "ses, ok",
+			config: &Config{
+				Provider: domain.EmailAPIProviderSES,
+				Region:   "eu-central-1",
+				KeyID:    "AKIDEXAMPLE",
+				APIKey:   "secret",
+			},
+			checkRequest: func(t *testing.T, r *http.Request, body []byte) {
+				assert.Equal(t, "/v2/email/outbound-emails", r.URL.Path)
+				assert.Equal(t, "20240102T030405Z", r.Header.Get("X-Amz-Date"))
+				assert.Contains(t, r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20240102/eu-central-1/ses/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature=")
+				sesReq := new(sesSendEmailRequest)
+				require.NoError(t, json.Unmarshal(body, sesReq))
+				assert.Equal(t, `"ZITADEL" <noreply@zitadel.example>`, sesReq.FromEmailAddress)
+				assert.Equal(t, []string{"reply@zitadel.example"}, sesReq.ReplyToAddresses)
+				assert.Equal(t, []string{"user@example.com"}, sesReq.Destination.ToAddresses)
+				assert.Equal(t, "subject", sesReq.Content.Simple.Subject.Data)
+				require.NotNil(t, sesReq.Content.Simple.Body.HTML)
+				assert.Nil(t, sesReq.Content.Simple.Body.Text)
+			},
+			response: response{status: http.StatusOK, body: `{"MessageId":"id"}`},
+		},
+		{
+			name: "ses, message rejected, cancel",
+			config: &Config{
+				Provider: domain.EmailAPIProviderSES,
+				Region:   "eu-central-1",
+				KeyID:    "AKIDEXAMPLE",
+				APIKey:   "secret",
+			},
+			response: response{
+				status: http.StatusBadRequest,
+				header: http.Header{sesErrorHeader: {"MessageRejected:http://internal.amazon.com/coral/com.amazonaws.sesv2/"}},
+				body:   `{"message":"Email address is not verified."}`,
+			},
+			wantCancelErr: true,
+		},
+		{
+			name: "ses, limit exceeded, retry",
+			config: &Config{
+				Provider: domain.EmailAPIProviderSES,
+				Region:   "eu-central-1",
+				KeyID:    "AKIDEXAMPLE",
+				APIKey:   "secret",
+			},
+			response: response{
+				status: http.StatusBadRequest,
+				header: http.Header{sesErrorHeader: {"LimitExceededException"}},
+				body:   `{"message":"Maximum sending rate exceeded."}`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sendgrid, ok",
+			config: &Config{
+				Provider: domain.EmailAPIProviderSendGrid,
+				APIKey:   "key",
+			},
+			checkRequest: func(t *testing.T, r *http.Request, body []byte) {
+				assert.Equal(t, "/v3/mail/send", r.URL.Path)
+				assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+				mail := new(sendGridMail)
+				require.NoError(t, json.Unmarshal(body, mail))
+				assert.Equal(t, sendGridAddress{Email: "noreply@zitadel.example", Name: "ZITADEL"}, mail.From)
+				assert.Equal(t, []sendGridAddress{{Email: "user@example.com"}}, mail.Personalizations[0].To)
+				assert.Equal(t, []sendGridContent{{Type: "text/html", Value: "<html><body>content</body></html>"}}, mail.Content)
+			},
+			response: response{status: http.StatusAccepted},
+		},
+		{
+			name: "sendgrid, unauthorized, cancel",
+			config: &Config{
+				Provider: domain.EmailAPIProviderSendGrid,
+				APIKey:   "key",
+			},
+			response: response{
+				status: http.StatusUnauthorized,
+				body:   `{"errors":[{"field":null,"message":"The provided authorization grant is invalid, expired, or revoked"}]}`,
+			},
+			wantCancelErr: true,
+		},
+		{
+			name: "sendgrid, rate limited, retry",
+			config: &Config{
+				Provider: domain.EmailAPIProviderSendGrid,
+				APIKey:   "key",
+			},
+			response: response{status: http.StatusTooManyRequests},
+			wantErr:  true,
+		},
+		{
+			name: "mailgun, ok",
+			config: &Config{
+				Provider: domain.EmailAPIProviderMailgun,
+				Domain:   "mg.zitadel.example",
+				APIKey:   "key",
+			},
+			checkRequest: func(t *testing.T, r *http.Request, body []byte) {
+				assert.Equal(t, "/v3/mg.zitadel.example/messages", r.URL.Path)
+				user, password, ok := r.BasicAuth()
+				assert.True(t, ok)
+				assert.Equal(t, "api", user)
+				assert.Equal(t, "key", password)
+				form, err := url.ParseQuery(string(body))
+				require.NoError(t, err)
+				assert.Equal(t, `"ZITADEL" <noreply@zitadel.example>`, form.Get("from"))
+				assert.Equal(t, "user@example.com", form.Get("to"))
+				assert.Equal(t, "reply@zitadel.example", form.Get("h:Reply-To"))
+				assert.Equal(t, "<html><body>content</body></html>", form.Get("html"))
+			},
+			response: response{status: http.StatusOK, body: `{"id":"<id>","message":"Queued. Thank you."}`},
+		},
+		{
+			name: "mailgun, server error, retry",
+			config: &Config{
+				Provider: domain.EmailAPIProviderMailgun,
+				Domain:   "mg.zitadel.example",
+				APIKey:   "key",
+			},
+			response: response{status: http.StatusServiceUnavailable},
+			wantErr:  true,
+		},
+		{
+			name: "json, signed, ok",
+			config: &Config{
+				Provider: domain.EmailAPIProviderJSON,
+				APIKey:   "signingkey",
+			},
+			checkRequest: func(t *testing.T, r *http.Request, body []byte) {
+				assert.Equal(t, "/", r.URL.Path)
+				assert.NoError(t, actions.ValidatePayloadWithTolerance(body, r.Header.Get(actions.SigningHeader), "signingkey", time.Since(testNow)+time.Minute))
+				email := new(jsonEmail)
+				require.NoError(t, json.Unmarshal(body, email))
+				assert.Equal(t, &jsonEmail{
+					From:        jsonAddress{Address: "noreply@zitadel.example", Name: "ZITADEL"},
+					ReplyTo:     "reply@zitadel.example",
+					To:          []string{"user@example.com"},
+					Subject:     "subject",
+					ContentType: "text/html",
+					Content:     "<html><body>content</body></html>",
+					EventType:   "user.human.initialization.code.added",
+				}, email)
+			},
+			response: response{status: http.StatusNoContent},
+		},
+		{
+			name: "json, suppressed recipient, cancel",
+			config: &Config{
+				Provider: domain.EmailAPIProviderJSON,
+			},
+			checkRequest: func(t *testing.T, r *http.Request, body []byte) {
+				assert.Empty(t, r.Header.Get(actions.SigningHeader))
+			},
+			response: response{
+				status: http.StatusUnprocessableEntity,
+				body:   `{"code":"suppressed","message":"recipient bounced"}`,
+			},
+			wantCancelErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.Equal(t, http.MethodPost, r.Method)
+				if tt.checkRequest != nil {
+					tt.checkRequest(t, r, body)
+				}
+				for key, values := range tt.response.header {
+					w.Header()[key] = values
+				}
+				w.WriteHeader(tt.response.status)
+				_, _ = w.Write([]byte(tt.response.body))
+			}))
+			defer server.Close()
+			tt.config.Endpoint = server.URL
+			tt.config.SenderAddress = "noreply@zitadel.example"
+			tt.config.SenderName = "ZITADEL"
+			tt.config.ReplyToAddress = "reply@zitadel.example"
+
+			channel, err := InitChannel(context.Background(), tt.config)
+			require.NoError(t, err)
+			err = channel.HandleMessage(&messages.Email{
+				Recipients:          []string{"user@example.com"},
+				Subject:             "subject",
+				Content:             "<html><body>content</body></html>",
+				TriggeringEventType: "user.human.initialization.code.added",
+			})
+			if tt.wantCancelErr {
+				assert.ErrorIs(t, err, &channels.CancelError{})
+				var providerErr *ProviderError
+				assert.True(t, errors.As(err, &providerErr))
+				assert.Equal(t, tt.response.status, providerErr.StatusCode)
+				return
+			}
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.NotErrorIs(t, err, &channels.CancelError{})
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package httpemail
+
+import (
+	"net/url"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type Config struct {
+	Provider       domain.EmailAPIProvider
+	SenderAddress  string
+	SenderName     string
+	ReplyToAddress string
+	// Endpoint overrides the default API base URL of the provider (e.g. Mailgun's EU region).
+	// For the generic JSON provider it's the full URL the emails are posted to.
+	Endpoint string
+	// Region of Amazon SES
+	Region string
+	// Domain is the sending domain of Mailgun
+	Domain string
+	// KeyID is the access key ID of Amazon SES
+	KeyID string
+	// APIKey is the secret access key of Amazon SES, the API key of SendGrid and Mailgun
+	// or the key the requests of the generic JSON provider are signed with.
+	APIKey string
+}
+
+func (c *Config) Validate() error {
+	if !c.Provider.Valid() {
+		return zerrors.ThrowInvalidArgument(nil, "HTTPEMAIL-Rz4wQ", "unknown email api provider")
+	}
+	if c.Endpoint != "" {
+		if _, err := url.Parse(c.Endpoint); err != nil {
+			return zerrors.ThrowInvalidArgument(err, "HTTPEMAIL-Tk8vN", "invalid endpoint")
+		}
+	}
+	return nil
+}
+
+func (c *Config) baseURL(defaultURL string) string {
+	if c.Endpoint == "" {
+		return defaultURL
+	}
+	return c.Endpoint
+}
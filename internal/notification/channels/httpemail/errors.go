@@ -0,0 +1,52 @@
+package httpemail
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/notification/channels"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// ProviderError is the error returned by the API of the provider.
+type ProviderError struct {
+	Provider   string
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *ProviderError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("%s returned status %d: %s", e.Provider, e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("%s returned status %d (%s): %s", e.Provider, e.StatusCode, e.Code, e.Message)
+}
+
+// classifyError cancels the notification if the provider rejected the email permanently,
+// e.g. because of invalid credentials, an unverified sender or a suppressed recipient,
+// as a retry would be rejected as well and only waste resources.
+// All other errors are returned to be retried.
+func classifyError(err *ProviderError, permanent bool) error {
+	if permanent {
+		logging.WithFields(
+			"provider", err.Provider,
+			"status", err.StatusCode,
+			"code", err.Code,
+			"error", err.Message,
+		).Warn("email rejected by provider")
+		return channels.NewCancelError(err)
+	}
+	return zerrors.ThrowInternal(err, "HTTPEMAIL-Fy3uW", "could not send email")
+}
+
+// isPermanentStatus reports whether the status code of the response means
+// that sending the same request again will fail as well.
+// Client errors are permanent, except timeouts and rate limits.
+func isPermanentStatus(statusCode int) bool {
+	return statusCode >= 400 && statusCode < 500 &&
+		statusCode != http.StatusRequestTimeout &&
+		statusCode != http.StatusTooManyRequests
+}
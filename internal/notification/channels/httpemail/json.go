@@ -0,0 +1,91 @@
+package httpemail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/notification/messages"
+	"github.com/zitadel/zitadel/pkg/actions"
+)
+
+// jsonProvider posts the emails to an arbitrary endpoint, e.g. a relay in front of a provider which isn't supported natively.
+// If a signing key is configured, the requests are signed the same way as the calls to action targets
+// and can be verified with [actions.ValidatePayload].
+// The endpoint can reject an email permanently by responding with a client error, except 408 and 429.
+type jsonProvider struct {
+	url        string
+	signingKey string
+}
+
+func newJSON(cfg *Config) *jsonProvider {
+	return &jsonProvider{
+		url:        cfg.Endpoint,
+		signingKey: cfg.APIKey,
+	}
+}
+
+func (*jsonProvider) name() string {
+	return "json"
+}
+
+type jsonEmail struct {
+	From        jsonAddress          `json:"from"`
+	ReplyTo     string               `json:"replyTo,omitempty"`
+	To          []string             `json:"to"`
+	CC          []string             `json:"cc,omitempty"`
+	BCC         []string             `json:"bcc,omitempty"`
+	Subject     string               `json:"subject"`
+	ContentType string               `json:"contentType"`
+	Content     string               `json:"content"`
+	EventType   eventstore.EventType `json:"eventType,omitempty"`
+}
+
+type jsonAddress struct {
+	Address string `json:"address"`
+	Name    string `json:"name,omitempty"`
+}
+
+func (j *jsonProvider) newRequest(ctx context.Context, msg *messages.Email) (*http.Request, error) {
+	body, err := json.Marshal(&jsonEmail{
+		From: jsonAddress{
+			Address: msg.SenderEmail,
+			Name:    msg.SenderName,
+		},
+		ReplyTo:     msg.ReplyToAddress,
+		To:          msg.Recipients,
+		CC:          msg.CC,
+		BCC:         msg.BCC,
+		Subject:     msg.Subject,
+		ContentType: contentType(msg),
+		Content:     msg.Content,
+		EventType:   msg.TriggeringEventType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, j.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if j.signingKey != "" {
+		req.Header.Set(actions.SigningHeader, actions.ComputeSignatureHeader(now(), body, j.signingKey))
+	}
+	return req, nil
+}
+
+type jsonError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (*jsonProvider) parseError(statusCode int, _ http.Header, body []byte) (code, message string, permanent bool) {
+	jsonErr := new(jsonError)
+	if err := json.Unmarshal(body, jsonErr); err != nil || jsonErr.Message == "" {
+		jsonErr.Message = string(body)
+	}
+	return jsonErr.Code, jsonErr.Message, isPermanentStatus(statusCode)
+}
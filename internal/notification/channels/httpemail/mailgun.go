@@ -0,0 +1,77 @@
+package httpemail
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/notification/messages"
+)
+
+const (
+	mailgunEndpoint = "https://api.mailgun.net"
+	mailgunSendPath = "/v3/%s/messages"
+	mailgunUser     = "api"
+)
+
+// mailgun sends the emails using the messages API of Mailgun.
+// Accounts in the EU region have to set https://api.eu.mailgun.net as endpoint.
+type mailgun struct {
+	url    string
+	apiKey string
+}
+
+func newMailgun(cfg *Config) *mailgun {
+	return &mailgun{
+		url:    strings.TrimSuffix(cfg.baseURL(mailgunEndpoint), "/") + fmt.Sprintf(mailgunSendPath, url.PathEscape(cfg.Domain)),
+		apiKey: cfg.APIKey,
+	}
+}
+
+func (*mailgun) name() string {
+	return "mailgun"
+}
+
+func (m *mailgun) newRequest(ctx context.Context, msg *messages.Email) (*http.Request, error) {
+	form := url.Values{
+		"from":    {formatAddress(msg.SenderName, msg.SenderEmail)},
+		"to":      msg.Recipients,
+		"subject": {msg.Subject},
+	}
+	if len(msg.CC) > 0 {
+		form["cc"] = msg.CC
+	}
+	if len(msg.BCC) > 0 {
+		form["bcc"] = msg.BCC
+	}
+	if msg.ReplyToAddress != "" {
+		form.Set("h:Reply-To", msg.ReplyToAddress)
+	}
+	if msg.IsHTML() {
+		form.Set("html", msg.Content)
+	} else {
+		form.Set("text", msg.Content)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(mailgunUser, m.apiKey)
+	return req, nil
+}
+
+type mailgunError struct {
+	Message string `json:"message"`
+}
+
+func (*mailgun) parseError(statusCode int, _ http.Header, body []byte) (code, message string, permanent bool) {
+	mailgunErr := new(mailgunError)
+	if err := json.Unmarshal(body, mailgunErr); err != nil || mailgunErr.Message == "" {
+		mailgunErr.Message = string(body)
+	}
+	return "", mailgunErr.Message, isPermanentStatus(statusCode)
+}
@@ -0,0 +1,127 @@
+package httpemail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/notification/messages"
+)
+
+const (
+	sendGridEndpoint = "https://api.sendgrid.com"
+	sendGridSendPath = "/v3/mail/send"
+)
+
+// sendGrid sends the emails using the v3 Mail Send API of SendGrid.
+type sendGrid struct {
+	url    string
+	apiKey string
+}
+
+func newSendGrid(cfg *Config) *sendGrid {
+	return &sendGrid{
+		url:    strings.TrimSuffix(cfg.baseURL(sendGridEndpoint), "/") + sendGridSendPath,
+		apiKey: cfg.APIKey,
+	}
+}
+
+func (*sendGrid) name() string {
+	return "sendgrid"
+}
+
+type sendGridMail struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	ReplyTo          *sendGridAddress          `json:"reply_to,omitempty"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+}
+
+type sendGridPersonalization struct {
+	To  []sendGridAddress `json:"to"`
+	CC  []sendGridAddress `json:"cc,omitempty"`
+	BCC []sendGridAddress `json:"bcc,omitempty"`
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+func (s *sendGrid) newRequest(ctx context.Context, msg *messages.Email) (*http.Request, error) {
+	mail := &sendGridMail{
+		Personalizations: []sendGridPersonalization{{
+			To:  sendGridAddresses(msg.Recipients),
+			CC:  sendGridAddresses(msg.CC),
+			BCC: sendGridAddresses(msg.BCC),
+		}},
+		From: sendGridAddress{
+			Email: msg.SenderEmail,
+			Name:  msg.SenderName,
+		},
+		Subject: msg.Subject,
+		Content: []sendGridContent{{
+			Type:  contentType(msg),
+			Value: msg.Content,
+		}},
+	}
+	if msg.ReplyToAddress != "" {
+		mail.ReplyTo = &sendGridAddress{Email: msg.ReplyToAddress}
+	}
+	body, err := json.Marshal(mail)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	return req, nil
+}
+
+type sendGridErrors struct {
+	Errors []struct {
+		Message string `json:"message"`
+		Field   string `json:"field"`
+	} `json:"errors"`
+}
+
+func (*sendGrid) parseError(statusCode int, _ http.Header, body []byte) (code, message string, permanent bool) {
+	sendGridErr := new(sendGridErrors)
+	if err := json.Unmarshal(body, sendGridErr); err != nil || len(sendGridErr.Errors) == 0 {
+		return "", string(body), isPermanentStatus(statusCode)
+	}
+	errMessages := make([]string, len(sendGridErr.Errors))
+	for i, e := range sendGridErr.Errors {
+		errMessages[i] = e.Message
+	}
+	return sendGridErr.Errors[0].Field, strings.Join(errMessages, "; "), isPermanentStatus(statusCode)
+}
+
+func sendGridAddresses(addresses []string) []sendGridAddress {
+	if len(addresses) == 0 {
+		return nil
+	}
+	sendGridAddresses := make([]sendGridAddress, len(addresses))
+	for i, address := range addresses {
+		sendGridAddresses[i] = sendGridAddress{Email: address}
+	}
+	return sendGridAddresses
+}
+
+func contentType(msg *messages.Email) string {
+	if msg.IsHTML() {
+		return "text/html"
+	}
+	return "text/plain"
+}
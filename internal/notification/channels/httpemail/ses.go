@@ -0,0 +1,214 @@
+package httpemail
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/notification/messages"
+)
+
+const (
+	sesService     = "ses"
+	sesEndpoint    = "https://email.%s.amazonaws.com"
+	sesSendPath    = "/v2/email/outbound-emails"
+	sesErrorHeader = "X-Amzn-Errortype"
+	sigV4Algorithm = "AWS4-HMAC-SHA256"
+	sigV4Date      = "20060102T150405Z"
+	utf8Charset    = "UTF-8"
+)
+
+// sesRetryableErrors are returned with a client error status code,
+// but are caused by limits of the account and will succeed later.
+var sesRetryableErrors = map[string]bool{
+	"TooManyRequestsException": true,
+	"LimitExceededException":   true,
+}
+
+// ses sends the emails using the SendEmail action of the Amazon SES API v2.
+// The requests are signed with AWS Signature Version 4.
+type ses struct {
+	url       string
+	region    string
+	keyID     string
+	secretKey string
+}
+
+func newSES(cfg *Config) *ses {
+	return &ses{
+		url:       strings.TrimSuffix(cfg.baseURL(fmt.Sprintf(sesEndpoint, cfg.Region)), "/") + sesSendPath,
+		region:    cfg.Region,
+		keyID:     cfg.KeyID,
+		secretKey: cfg.APIKey,
+	}
+}
+
+func (*ses) name() string {
+	return "ses"
+}
+
+type sesSendEmailRequest struct {
+	FromEmailAddress string         `json:"FromEmailAddress"`
+	Destination      sesDestination `json:"Destination"`
+	ReplyToAddresses []string       `json:"ReplyToAddresses,omitempty"`
+	Content          sesContent     `json:"Content"`
+}
+
+type sesDestination struct {
+	ToAddresses  []string `json:"ToAddresses"`
+	CcAddresses  []string `json:"CcAddresses,omitempty"`
+	BccAddresses []string `json:"BccAddresses,omitempty"`
+}
+
+type sesContent struct {
+	Simple sesMessage `json:"Simple"`
+}
+
+type sesMessage struct {
+	Subject sesData `json:"Subject"`
+	Body    sesBody `json:"Body"`
+}
+
+type sesBody struct {
+	HTML *sesData `json:"Html,omitempty"`
+	Text *sesData `json:"Text,omitempty"`
+}
+
+type sesData struct {
+	Data    string `json:"Data"`
+	Charset string `json:"Charset"`
+}
+
+func (s *ses) newRequest(ctx context.Context, msg *messages.Email) (*http.Request, error) {
+	sesReq := &sesSendEmailRequest{
+		FromEmailAddress: formatAddress(msg.SenderName, msg.SenderEmail),
+		Destination: sesDestination{
+			ToAddresses:  msg.Recipients,
+			CcAddresses:  msg.CC,
+			BccAddresses: msg.BCC,
+		},
+		Content: sesContent{
+			Simple: sesMessage{
+				Subject: sesData{Data: msg.Subject, Charset: utf8Charset},
+			},
+		},
+	}
+	if msg.ReplyToAddress != "" {
+		sesReq.ReplyToAddresses = []string{msg.ReplyToAddress}
+	}
+	content := &sesData{Data: msg.Content, Charset: utf8Charset}
+	if msg.IsHTML() {
+		sesReq.Content.Simple.Body.HTML = content
+	} else {
+		sesReq.Content.Simple.Body.Text = content
+	}
+	body, err := json.Marshal(sesReq)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	signV4(req, body, s.keyID, s.secretKey, s.region, sesService, now())
+	return req, nil
+}
+
+type sesError struct {
+	Message string `json:"message"`
+}
+
+func (*ses) parseError(statusCode int, header http.Header, body []byte) (code, message string, permanent bool) {
+	// the error type might be followed by a colon and the namespace of the type
+	code, _, _ = strings.Cut(header.Get(sesErrorHeader), ":")
+	sesErr := new(sesError)
+	if err := json.Unmarshal(body, sesErr); err != nil || sesErr.Message == "" {
+		sesErr.Message = string(body)
+	}
+	return code, sesErr.Message, isPermanentStatus(statusCode) && !sesRetryableErrors[code]
+}
+
+// signV4 adds the headers of AWS Signature Version 4 to the request.
+// The content type, host and date headers are signed.
+func signV4(req *http.Request, body []byte, keyID, secretKey, region, service string, t time.Time) {
+	t = t.UTC()
+	amzDate := t.Format(sigV4Date)
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{
+		"host":       req.URL.Host,
+		"x-amz-date": amzDate,
+	}
+	if contentType := req.Header.Get("Content-Type"); contentType != "" {
+		headers["content-type"] = contentType
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hexSHA256(body),
+	}, "\n")
+
+	scope := strings.Join([]string{date, region, service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		sigV4Algorithm,
+		amzDate,
+		scope,
+		hexSHA256([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+secretKey), date)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigV4Algorithm, keyID, scope, signedHeaders, signature))
+}
+
+func hexSHA256(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func formatAddress(name, address string) string {
+	if name == "" {
+		return address
+	}
+	return (&mail.Address{Name: name, Address: address}).String()
+}
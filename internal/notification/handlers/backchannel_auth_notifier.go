@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/riverqueue/river"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/queue"
+	"github.com/zitadel/zitadel/internal/repository/backchannelauth"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	BackchannelAuthNotificationsProjectionTable = "projections.notifications_backchannel_auth"
+)
+
+type BackchannelAuthCommands interface {
+	NotifyBackchannelAuthClient(ctx context.Context, id string) error
+}
+
+// backchannelAuthNotifier schedules the notification of clients using the ping mode
+// after the user approved or denied their backchannel authentication (CIBA) request.
+// The notifications are sent and retried by the [BackchannelAuthNotificationWorker].
+// If the legacy notification handling is enabled, they are sent by the projection itself.
+type backchannelAuthNotifier struct {
+	commands    BackchannelAuthCommands
+	eventstore  *eventstore.Eventstore
+	queue       Queue
+	maxAttempts uint8
+	legacy      bool
+}
+
+func NewBackchannelAuthNotifier(
+	ctx context.Context,
+	config handler.Config,
+	commands BackchannelAuthCommands,
+	es *eventstore.Eventstore,
+	workerConfig WorkerConfig,
+	queue Queue,
+) *handler.Handler {
+	return handler.NewHandler(ctx, &config, &backchannelAuthNotifier{
+		commands:    commands,
+		eventstore:  es,
+		queue:       queue,
+		maxAttempts: workerConfig.MaxAttempts,
+		legacy:      workerConfig.LegacyEnabled,
+	})
+}
+
+func (*backchannelAuthNotifier) Name() string {
+	return BackchannelAuthNotificationsProjectionTable
+}
+
+func (u *backchannelAuthNotifier) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: backchannelauth.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  backchannelauth.ApprovedEventType,
+					Reduce: u.reduceApproved,
+				},
+				{
+					Event:  backchannelauth.CanceledEventType,
+					Reduce: u.reduceCanceled,
+				},
+			},
+		},
+	}
+}
+
+func (u *backchannelAuthNotifier) reduceApproved(event eventstore.Event) (*handler.Statement, error) {
+	if _, ok := event.(*backchannelauth.ApprovedEvent); !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-ahY3o", "reduce.wrong.event.type %s", backchannelauth.ApprovedEventType)
+	}
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		return u.notify(event.Aggregate())
+	}), nil
+}
+
+func (u *backchannelAuthNotifier) reduceCanceled(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*backchannelauth.CanceledEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Ooth6", "reduce.wrong.event.type %s", backchannelauth.CanceledEventType)
+	}
+	// expired requests are only reported to the client when it requests the token
+	if e.Reason != domain.BackchannelAuthCanceledDenied {
+		return handler.NewNoOpStatement(e), nil
+	}
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		return u.notify(event.Aggregate())
+	}), nil
+}
+
+func (u *backchannelAuthNotifier) notify(aggregate *eventstore.Aggregate) error {
+	ctx := HandlerContext(aggregate)
+	model := command.NewBackchannelAuthWriteModel(aggregate.ID, aggregate.InstanceID)
+	if err := u.eventstore.FilterToQueryReducer(ctx, model); err != nil {
+		return err
+	}
+	if model.DeliveryMode != domain.OIDCBackchannelTokenDeliveryModePing || model.NotificationEndpoint == "" {
+		return nil
+	}
+	if u.legacy {
+		return u.commands.NotifyBackchannelAuthClient(ctx, aggregate.ID)
+	}
+	return u.queue.Insert(ctx,
+		&backchannelauth.NotificationRequest{
+			InstanceID: aggregate.InstanceID,
+			ID:         aggregate.ID,
+		},
+		queue.WithQueueName(backchannelauth.NotificationQueueName),
+		queue.WithMaxAttempts(u.maxAttempts),
+		// the events are reduced again if the projection is reset
+		queue.WithUniqueArgs(),
+	)
+}
+
+type BackchannelAuthNotificationWorker struct {
+	river.WorkerDefaults[*backchannelauth.NotificationRequest]
+
+	commands BackchannelAuthCommands
+	config   WorkerConfig
+}
+
+func NewBackchannelAuthNotificationWorker(
+	config WorkerConfig,
+	commands BackchannelAuthCommands,
+) *BackchannelAuthNotificationWorker {
+	return &BackchannelAuthNotificationWorker{
+		config:   config,
+		commands: commands,
+	}
+}
+
+// Timeout implements the Timeout-function of [river.Worker].
+// Maximum time a job can run before the context gets cancelled.
+func (w *BackchannelAuthNotificationWorker) Timeout(*river.Job[*backchannelauth.NotificationRequest]) time.Duration {
+	return w.config.TransactionDuration
+}
+
+// Work implements [river.Worker].
+// Failed notifications are retried, unless the client can't be notified at all.
+func (w *BackchannelAuthNotificationWorker) Work(ctx context.Context, job *river.Job[*backchannelauth.NotificationRequest]) error {
+	ctx = ContextWithNotifier(ctx, backchannelauth.NewAggregate(job.Args.ID, job.Args.InstanceID))
+	err := w.commands.NotifyBackchannelAuthClient(ctx, job.Args.ID)
+	if zerrors.IsNotFound(err) || zerrors.IsPreconditionFailed(err) {
+		return river.JobCancel(err)
+	}
+	return err
+}
+
+var _ river.Worker[*backchannelauth.NotificationRequest] = (*BackchannelAuthNotificationWorker)(nil)
+
+func (w *BackchannelAuthNotificationWorker) Register(workers *river.Workers, queues map[string]river.QueueConfig) {
+	river.AddWorker(workers, w)
+	queues[backchannelauth.NotificationQueueName] = river.QueueConfig{
+		MaxWorkers: int(w.config.Workers),
+	}
+}
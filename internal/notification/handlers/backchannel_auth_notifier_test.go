@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/repository/backchannelauth"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type testBackchannelAuthCommands struct {
+	err      error
+	notified []string
+	instance string
+}
+
+func (c *testBackchannelAuthCommands) NotifyBackchannelAuthClient(ctx context.Context, id string) error {
+	c.notified = append(c.notified, id)
+	c.instance = authz.GetInstance(ctx).InstanceID()
+	return c.err
+}
+
+func TestBackchannelAuthNotificationWorker_Work(t *testing.T) {
+	tests := []struct {
+		name       string
+		commands   *testBackchannelAuthCommands
+		wantCancel bool
+		wantErr    bool
+	}{
+		{
+			name: "request not found, cancel",
+			commands: &testBackchannelAuthCommands{
+				err: zerrors.ThrowNotFound(nil, "COMMAND-Xah6i", "Errors.BackchannelAuth.NotFound"),
+			},
+			wantCancel: true,
+		},
+		{
+			name: "client not using ping mode, cancel",
+			commands: &testBackchannelAuthCommands{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-ooG5e", "Errors.BackchannelAuth.NotificationDisabled"),
+			},
+			wantCancel: true,
+		},
+		{
+			name: "client unavailable, retry",
+			commands: &testBackchannelAuthCommands{
+				err: zerrors.ThrowInternal(nil, "COMMAND-Ciu0e", "Errors.BackchannelAuth.NotificationFailed"),
+			},
+			wantErr: true,
+		},
+		{
+			name:     "notified",
+			commands: &testBackchannelAuthCommands{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewBackchannelAuthNotificationWorker(WorkerConfig{Workers: 1, TransactionDuration: time.Minute}, tt.commands)
+
+			err := w.Work(context.Background(), &river.Job[*backchannelauth.NotificationRequest]{
+				JobRow: &rivertype.JobRow{},
+				Args: &backchannelauth.NotificationRequest{
+					InstanceID: "instance1",
+					ID:         "id",
+				},
+			})
+
+			assert.Equal(t, []string{"id"}, tt.commands.notified)
+			assert.Equal(t, "instance1", tt.commands.instance)
+			var cancelErr *river.JobCancelError
+			if tt.wantCancel {
+				assert.ErrorAs(t, err, &cancelErr)
+				return
+			}
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.False(t, errors.As(err, &cancelErr))
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/notification/channels/email"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpemail"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
 	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -55,5 +56,28 @@ func (n *NotificationQueries) GetActiveEmailConfig(ctx context.Context) (*email.
 			},
 		}, nil
 	}
+	if config.APIConfig != nil {
+		var apiKey string
+		if config.APIConfig.APIKey != nil {
+			apiKey, err = crypto.DecryptString(config.APIConfig.APIKey, n.SMTPPasswordCrypto)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return &email.Config{
+			ProviderConfig: provider,
+			APIConfig: &httpemail.Config{
+				Provider:       config.APIConfig.Provider,
+				SenderAddress:  config.APIConfig.SenderAddress,
+				SenderName:     config.APIConfig.SenderName,
+				ReplyToAddress: config.APIConfig.ReplyToAddress,
+				Endpoint:       config.APIConfig.Endpoint,
+				Region:         config.APIConfig.Region,
+				Domain:         config.APIConfig.Domain,
+				KeyID:          config.APIConfig.KeyID,
+				APIKey:         apiKey,
+			},
+		}, nil
+	}
 	return nil, zerrors.ThrowNotFound(err, "QUERY-KPQleOckOV", "Errors.SMTPConfig.NotFound")
 }
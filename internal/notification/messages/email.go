@@ -65,6 +65,11 @@ func (msg *Email) GetTriggeringEventType() eventstore.EventType {
 	return msg.TriggeringEventType
 }
 
+// IsHTML reports whether the content is sent as html instead of plain text.
+func (msg *Email) IsHTML() bool {
+	return isHTML(msg.Content)
+}
+
 func isHTML(input string) bool {
 	return isHTMLRgx.MatchString(input)
 }
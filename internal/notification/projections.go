@@ -25,7 +25,7 @@ var (
 
 func Register(
 	ctx context.Context,
-	userHandlerCustomConfig, quotaHandlerCustomConfig, telemetryHandlerCustomConfig, backChannelLogoutHandlerCustomConfig projection.CustomConfig,
+	userHandlerCustomConfig, quotaHandlerCustomConfig, telemetryHandlerCustomConfig, backChannelLogoutHandlerCustomConfig, backchannelAuthHandlerCustomConfig projection.CustomConfig,
 	notificationWorkerConfig handlers.WorkerConfig,
 	telemetryCfg handlers.TelemetryPusherConfig,
 	externalDomain string,
@@ -60,11 +60,22 @@ func Register(
 		c,
 		tokenLifetime,
 	))
+	projections = append(projections, handlers.NewBackchannelAuthNotifier(
+		ctx,
+		projection.ApplyCustomConfig(backchannelAuthHandlerCustomConfig),
+		commands,
+		es,
+		notificationWorkerConfig,
+		queue,
+	))
 	if telemetryCfg.Enabled {
 		projections = append(projections, handlers.NewTelemetryPusher(ctx, telemetryCfg, projection.ApplyCustomConfig(telemetryHandlerCustomConfig), commands, q, c))
 	}
 	if !notificationWorkerConfig.LegacyEnabled {
-		queue.AddWorkers(handlers.NewNotificationWorker(notificationWorkerConfig, commands, q, c))
+		queue.AddWorkers(
+			handlers.NewNotificationWorker(notificationWorkerConfig, commands, q, c),
+			handlers.NewBackchannelAuthNotificationWorker(notificationWorkerConfig, commands),
+		)
 	}
 }
 
@@ -9,13 +9,17 @@ import (
 	"github.com/zitadel/zitadel/internal/notification/channels"
 	"github.com/zitadel/zitadel/internal/notification/channels/email"
 	"github.com/zitadel/zitadel/internal/notification/channels/fs"
+	"github.com/zitadel/zitadel/internal/notification/channels/httpemail"
 	"github.com/zitadel/zitadel/internal/notification/channels/instrumenting"
 	"github.com/zitadel/zitadel/internal/notification/channels/log"
 	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
 	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
 )
 
-const smtpSpanName = "smtp.NotificationChannel"
+const (
+	smtpSpanName      = "smtp.NotificationChannel"
+	httpEmailSpanName = "httpemail.NotificationChannel"
+)
 
 func EmailChannels(
 	ctx context.Context,
@@ -25,7 +29,7 @@ func EmailChannels(
 	successMetricName,
 	failureMetricName string,
 ) (chain *Chain, err error) {
-	channels := make([]channels.NotificationChannel, 0, 3)
+	channels := make([]channels.NotificationChannel, 0, 4)
 	if emailConfig.SMTPConfig != nil {
 		p, err := smtp.InitChannel(emailConfig.SMTPConfig)
 		logging.WithFields(
@@ -63,6 +67,25 @@ func EmailChannels(
 			)
 		}
 	}
+	if emailConfig.APIConfig != nil {
+		apiChannel, err := httpemail.InitChannel(ctx, emailConfig.APIConfig)
+		logging.WithFields(
+			"instance", authz.GetInstance(ctx).InstanceID(),
+			"provider", emailConfig.APIConfig.Provider,
+		).OnError(err).Debug("initializing HTTP email channel failed")
+		if err == nil {
+			channels = append(
+				channels,
+				instrumenting.Wrap(
+					ctx,
+					apiChannel,
+					httpEmailSpanName,
+					successMetricName,
+					failureMetricName,
+				),
+			)
+		}
+	}
 	channels = append(channels, debugChannels(ctx, getFileSystemProvider, getLogProvider)...)
 	return ChainChannels(channels...), nil
 }
@@ -36,7 +36,7 @@ func generateEmail(
 	if lastEmail {
 		recipient = user.LastEmail
 	}
-	if config.SMTPConfig != nil {
+	if config.SMTPConfig != nil || config.APIConfig != nil {
 		message := &messages.Email{
 			Recipients:          []string{recipient},
 			Subject:             data.Subject,
@@ -36,6 +36,9 @@ type OIDCSessionAccessTokenReadModel struct {
 	UserAgent             *domain.UserAgent
 	Reason                domain.TokenReason
 	Actor                 *domain.TokenActor
+	DPoPJKT               string
+	CertThumbprint        string
+	AuthorizationDetails  domain.AuthorizationDetails
 }
 
 func newOIDCSessionAccessTokenReadModel(id string) *OIDCSessionAccessTokenReadModel {
@@ -95,6 +98,9 @@ func (wm *OIDCSessionAccessTokenReadModel) reduceAccessTokenAdded(e *oidcsession
 	wm.AccessTokenExpiration = e.CreationDate().Add(e.Lifetime)
 	wm.Reason = e.Reason
 	wm.Actor = e.Actor
+	wm.DPoPJKT = e.DPoPJKT
+	wm.CertThumbprint = e.CertThumbprint
+	wm.AuthorizationDetails = e.AuthorizationDetails
 }
 
 func (wm *OIDCSessionAccessTokenReadModel) reduceTokenRevoked(e eventstore.Event) {
@@ -102,6 +108,24 @@ func (wm *OIDCSessionAccessTokenReadModel) reduceTokenRevoked(e eventstore.Event
 	wm.AccessTokenExpiration = e.CreatedAt()
 }
 
+// CheckDPoPBinding checks that a DPoP bound token is presented with a proof of the bound key (thumbprint)
+// and a token without binding is presented without proof.
+func (wm *OIDCSessionAccessTokenReadModel) CheckDPoPBinding(thumbprint string) error {
+	if wm.DPoPJKT != thumbprint {
+		return zerrors.ThrowUnauthenticated(nil, "QUERY-ahR6u", "Errors.OIDCSession.Token.Invalid")
+	}
+	return nil
+}
+
+// CheckCertificateBinding checks that a certificate-bound token is presented
+// over a connection with the bound client certificate (thumbprint).
+func (wm *OIDCSessionAccessTokenReadModel) CheckCertificateBinding(thumbprint string) error {
+	if wm.CertThumbprint != "" && wm.CertThumbprint != thumbprint {
+		return zerrors.ThrowUnauthenticated(nil, "QUERY-Iek7a", "Errors.OIDCSession.Token.Invalid")
+	}
+	return nil
+}
+
 // ActiveAccessTokenByToken will check if the token is active by retrieving the OIDCSession events from the eventstore.
 // Refreshed or expired tokens will return an error as well as if the underlying sessions has been terminated.
 func (q *Queries) ActiveAccessTokenByToken(ctx context.Context, token string) (model *OIDCSessionAccessTokenReadModel, err error) {
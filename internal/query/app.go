@@ -41,27 +41,34 @@ type App struct {
 }
 
 type OIDCApp struct {
-	RedirectURIs             database.TextArray[string]
-	ResponseTypes            database.NumberArray[domain.OIDCResponseType]
-	GrantTypes               database.NumberArray[domain.OIDCGrantType]
-	AppType                  domain.OIDCApplicationType
-	ClientID                 string
-	AuthMethodType           domain.OIDCAuthMethodType
-	PostLogoutRedirectURIs   database.TextArray[string]
-	Version                  domain.OIDCVersion
-	ComplianceProblems       database.TextArray[string]
-	IsDevMode                bool
-	AccessTokenType          domain.OIDCTokenType
-	AssertAccessTokenRole    bool
-	AssertIDTokenRole        bool
-	AssertIDTokenUserinfo    bool
-	ClockSkew                time.Duration
-	AdditionalOrigins        database.TextArray[string]
-	AllowedOrigins           database.TextArray[string]
-	SkipNativeAppSuccessPage bool
-	BackChannelLogoutURI     string
-	LoginVersion             domain.LoginVersion
-	LoginBaseURI             *string
+	RedirectURIs                       database.TextArray[string]
+	ResponseTypes                      database.NumberArray[domain.OIDCResponseType]
+	GrantTypes                         database.NumberArray[domain.OIDCGrantType]
+	AppType                            domain.OIDCApplicationType
+	ClientID                           string
+	AuthMethodType                     domain.OIDCAuthMethodType
+	PostLogoutRedirectURIs             database.TextArray[string]
+	Version                            domain.OIDCVersion
+	ComplianceProblems                 database.TextArray[string]
+	IsDevMode                          bool
+	AccessTokenType                    domain.OIDCTokenType
+	AssertAccessTokenRole              bool
+	AssertIDTokenRole                  bool
+	AssertIDTokenUserinfo              bool
+	ClockSkew                          time.Duration
+	AdditionalOrigins                  database.TextArray[string]
+	AllowedOrigins                     database.TextArray[string]
+	SkipNativeAppSuccessPage           bool
+	BackChannelLogoutURI               string
+	LoginVersion                       domain.LoginVersion
+	LoginBaseURI                       *string
+	RequirePushedAuthorizationRequests bool
+	RequireDPoP                        bool
+
+	BackchannelTokenDeliveryMode          domain.OIDCBackchannelTokenDeliveryMode
+	BackchannelClientNotificationEndpoint string
+
+	TLSClientAuthSubjectDN string
 }
 
 type SAMLApp struct {
@@ -276,6 +283,26 @@ var (
 		name:  projection.AppOIDCConfigColumnLoginBaseURI,
 		table: appOIDCConfigsTable,
 	}
+	AppOIDCConfigColumnRequirePAR = Column{
+		name:  projection.AppOIDCConfigColumnRequirePAR,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnRequireDPoP = Column{
+		name:  projection.AppOIDCConfigColumnRequireDPoP,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnBackchannelTokenDeliveryMode = Column{
+		name:  projection.AppOIDCConfigColumnBackchannelTokenDeliveryMode,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnBackchannelNotificationEndpoint = Column{
+		name:  projection.AppOIDCConfigColumnBackchannelNotificationEndpoint,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnTLSClientAuthSubjectDN = Column{
+		name:  projection.AppOIDCConfigColumnTLSClientAuthSubjectDN,
+		table: appOIDCConfigsTable,
+	}
 )
 
 func (q *Queries) AppByProjectAndAppID(ctx context.Context, shouldTriggerBulk bool, projectID, appID string) (app *App, err error) {
@@ -667,6 +694,11 @@ func prepareAppQuery(activeOnly bool) (sq.SelectBuilder, func(*sql.Row) (*App, e
 		AppOIDCConfigColumnBackChannelLogoutURI.identifier(),
 		AppOIDCConfigColumnLoginVersion.identifier(),
 		AppOIDCConfigColumnLoginBaseURI.identifier(),
+		AppOIDCConfigColumnRequirePAR.identifier(),
+		AppOIDCConfigColumnRequireDPoP.identifier(),
+		AppOIDCConfigColumnBackchannelTokenDeliveryMode.identifier(),
+		AppOIDCConfigColumnBackchannelNotificationEndpoint.identifier(),
+		AppOIDCConfigColumnTLSClientAuthSubjectDN.identifier(),
 
 		AppSAMLConfigColumnAppID.identifier(),
 		AppSAMLConfigColumnEntityID.identifier(),
@@ -736,6 +768,11 @@ func scanApp(row *sql.Row) (*App, error) {
 		&oidcConfig.backChannelLogoutURI,
 		&oidcConfig.loginVersion,
 		&oidcConfig.loginBaseURI,
+		&oidcConfig.requirePAR,
+		&oidcConfig.requireDPoP,
+		&oidcConfig.backchannelTokenDeliveryMode,
+		&oidcConfig.backchannelNotificationEndpoint,
+		&oidcConfig.tlsClientAuthSubjectDN,
 
 		&samlConfig.appID,
 		&samlConfig.entityID,
@@ -790,6 +827,11 @@ func prepareOIDCAppQuery() (sq.SelectBuilder, func(*sql.Row) (*App, error)) {
 			AppOIDCConfigColumnBackChannelLogoutURI.identifier(),
 			AppOIDCConfigColumnLoginVersion.identifier(),
 			AppOIDCConfigColumnLoginBaseURI.identifier(),
+			AppOIDCConfigColumnRequirePAR.identifier(),
+			AppOIDCConfigColumnRequireDPoP.identifier(),
+			AppOIDCConfigColumnBackchannelTokenDeliveryMode.identifier(),
+			AppOIDCConfigColumnBackchannelNotificationEndpoint.identifier(),
+			AppOIDCConfigColumnTLSClientAuthSubjectDN.identifier(),
 		).From(appsTable.identifier()).
 			Join(join(AppOIDCConfigColumnAppID, AppColumnID)).
 			PlaceholderFormat(sq.Dollar), func(row *sql.Row) (*App, error) {
@@ -829,6 +871,11 @@ func prepareOIDCAppQuery() (sq.SelectBuilder, func(*sql.Row) (*App, error)) {
 				&oidcConfig.backChannelLogoutURI,
 				&oidcConfig.loginVersion,
 				&oidcConfig.loginBaseURI,
+				&oidcConfig.requirePAR,
+				&oidcConfig.requireDPoP,
+				&oidcConfig.backchannelTokenDeliveryMode,
+				&oidcConfig.backchannelNotificationEndpoint,
+				&oidcConfig.tlsClientAuthSubjectDN,
 			)
 
 			if err != nil {
@@ -988,6 +1035,11 @@ func prepareAppsQuery() (sq.SelectBuilder, func(*sql.Rows) (*Apps, error)) {
 			AppOIDCConfigColumnBackChannelLogoutURI.identifier(),
 			AppOIDCConfigColumnLoginVersion.identifier(),
 			AppOIDCConfigColumnLoginBaseURI.identifier(),
+			AppOIDCConfigColumnRequirePAR.identifier(),
+			AppOIDCConfigColumnRequireDPoP.identifier(),
+			AppOIDCConfigColumnBackchannelTokenDeliveryMode.identifier(),
+			AppOIDCConfigColumnBackchannelNotificationEndpoint.identifier(),
+			AppOIDCConfigColumnTLSClientAuthSubjectDN.identifier(),
 
 			AppSAMLConfigColumnAppID.identifier(),
 			AppSAMLConfigColumnEntityID.identifier(),
@@ -1045,6 +1097,11 @@ func prepareAppsQuery() (sq.SelectBuilder, func(*sql.Rows) (*Apps, error)) {
 					&oidcConfig.backChannelLogoutURI,
 					&oidcConfig.loginVersion,
 					&oidcConfig.loginBaseURI,
+					&oidcConfig.requirePAR,
+					&oidcConfig.requireDPoP,
+					&oidcConfig.backchannelTokenDeliveryMode,
+					&oidcConfig.backchannelNotificationEndpoint,
+					&oidcConfig.tlsClientAuthSubjectDN,
 
 					&samlConfig.appID,
 					&samlConfig.entityID,
@@ -1152,6 +1209,12 @@ type sqlOIDCConfig struct {
 	backChannelLogoutURI     sql.NullString
 	loginVersion             sql.NullInt16
 	loginBaseURI             sql.NullString
+	requirePAR               sql.NullBool
+	requireDPoP              sql.NullBool
+
+	backchannelTokenDeliveryMode    sql.NullInt16
+	backchannelNotificationEndpoint sql.NullString
+	tlsClientAuthSubjectDN          sql.NullString
 }
 
 func (c sqlOIDCConfig) set(app *App) {
@@ -1159,24 +1222,31 @@ func (c sqlOIDCConfig) set(app *App) {
 		return
 	}
 	app.OIDCConfig = &OIDCApp{
-		Version:                  domain.OIDCVersion(c.version.Int32),
-		ClientID:                 c.clientID.String,
-		RedirectURIs:             c.redirectUris,
-		AppType:                  domain.OIDCApplicationType(c.applicationType.Int16),
-		AuthMethodType:           domain.OIDCAuthMethodType(c.authMethodType.Int16),
-		PostLogoutRedirectURIs:   c.postLogoutRedirectUris,
-		IsDevMode:                c.devMode.Bool,
-		AccessTokenType:          domain.OIDCTokenType(c.accessTokenType.Int16),
-		AssertAccessTokenRole:    c.accessTokenRoleAssertion.Bool,
-		AssertIDTokenRole:        c.iDTokenRoleAssertion.Bool,
-		AssertIDTokenUserinfo:    c.iDTokenUserinfoAssertion.Bool,
-		ClockSkew:                time.Duration(c.clockSkew.Int64),
-		AdditionalOrigins:        c.additionalOrigins,
-		ResponseTypes:            c.responseTypes,
-		GrantTypes:               c.grantTypes,
-		SkipNativeAppSuccessPage: c.skipNativeAppSuccessPage.Bool,
-		BackChannelLogoutURI:     c.backChannelLogoutURI.String,
-		LoginVersion:             domain.LoginVersion(c.loginVersion.Int16),
+		Version:                            domain.OIDCVersion(c.version.Int32),
+		ClientID:                           c.clientID.String,
+		RedirectURIs:                       c.redirectUris,
+		AppType:                            domain.OIDCApplicationType(c.applicationType.Int16),
+		AuthMethodType:                     domain.OIDCAuthMethodType(c.authMethodType.Int16),
+		PostLogoutRedirectURIs:             c.postLogoutRedirectUris,
+		IsDevMode:                          c.devMode.Bool,
+		AccessTokenType:                    domain.OIDCTokenType(c.accessTokenType.Int16),
+		AssertAccessTokenRole:              c.accessTokenRoleAssertion.Bool,
+		AssertIDTokenRole:                  c.iDTokenRoleAssertion.Bool,
+		AssertIDTokenUserinfo:              c.iDTokenUserinfoAssertion.Bool,
+		ClockSkew:                          time.Duration(c.clockSkew.Int64),
+		AdditionalOrigins:                  c.additionalOrigins,
+		ResponseTypes:                      c.responseTypes,
+		GrantTypes:                         c.grantTypes,
+		SkipNativeAppSuccessPage:           c.skipNativeAppSuccessPage.Bool,
+		BackChannelLogoutURI:               c.backChannelLogoutURI.String,
+		LoginVersion:                       domain.LoginVersion(c.loginVersion.Int16),
+		RequirePushedAuthorizationRequests: c.requirePAR.Bool,
+		RequireDPoP:                        c.requireDPoP.Bool,
+
+		BackchannelTokenDeliveryMode:          domain.OIDCBackchannelTokenDeliveryMode(c.backchannelTokenDeliveryMode.Int16),
+		BackchannelClientNotificationEndpoint: c.backchannelNotificationEndpoint.String,
+
+		TLSClientAuthSubjectDN: c.tlsClientAuthSubjectDN.String,
 	}
 	if c.loginBaseURI.Valid {
 		app.OIDCConfig.LoginBaseURI = &c.loginBaseURI.String
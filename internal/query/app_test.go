@@ -51,6 +51,11 @@ var (
 		` projections.apps7_oidc_configs.back_channel_logout_uri,` +
 		` projections.apps7_oidc_configs.login_version,` +
 		` projections.apps7_oidc_configs.login_base_uri,` +
+		` projections.apps7_oidc_configs.require_par,` +
+		` projections.apps7_oidc_configs.require_dpop,` +
+		` projections.apps7_oidc_configs.backchannel_token_delivery_mode,` +
+		` projections.apps7_oidc_configs.backchannel_client_notification_endpoint,` +
+		` projections.apps7_oidc_configs.tls_client_auth_subject_dn,` +
 		//saml config
 		` projections.apps7_saml_configs.app_id,` +
 		` projections.apps7_saml_configs.entity_id,` +
@@ -99,6 +104,11 @@ var (
 		` projections.apps7_oidc_configs.back_channel_logout_uri,` +
 		` projections.apps7_oidc_configs.login_version,` +
 		` projections.apps7_oidc_configs.login_base_uri,` +
+		` projections.apps7_oidc_configs.require_par,` +
+		` projections.apps7_oidc_configs.require_dpop,` +
+		` projections.apps7_oidc_configs.backchannel_token_delivery_mode,` +
+		` projections.apps7_oidc_configs.backchannel_client_notification_endpoint,` +
+		` projections.apps7_oidc_configs.tls_client_auth_subject_dn,` +
 		//saml config
 		` projections.apps7_saml_configs.app_id,` +
 		` projections.apps7_saml_configs.entity_id,` +
@@ -172,6 +182,11 @@ var (
 		"back_channel_logout_uri",
 		"login_version",
 		"login_base_uri",
+		"require_par",
+		"require_dpop",
+		"backchannel_token_delivery_mode",
+		"backchannel_client_notification_endpoint",
+		"tls_client_auth_subject_dn",
 		//saml config
 		"app_id",
 		"entity_id",
@@ -248,6 +263,11 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -319,6 +339,11 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -393,6 +418,11 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
@@ -469,6 +499,11 @@ func Test_AppsPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							false,
+							false,
+							domain.OIDCBackchannelTokenDeliveryModePoll,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -563,6 +598,11 @@ func Test_AppsPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							false,
+							false,
+							domain.OIDCBackchannelTokenDeliveryModePoll,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -657,6 +697,11 @@ func Test_AppsPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							false,
+							false,
+							domain.OIDCBackchannelTokenDeliveryModePoll,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -751,6 +796,11 @@ func Test_AppsPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							false,
+							false,
+							domain.OIDCBackchannelTokenDeliveryModePoll,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -845,6 +895,11 @@ func Test_AppsPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							false,
+							false,
+							domain.OIDCBackchannelTokenDeliveryModePoll,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -939,6 +994,11 @@ func Test_AppsPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							false,
+							false,
+							domain.OIDCBackchannelTokenDeliveryModePoll,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1033,6 +1093,11 @@ func Test_AppsPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersion2,
 							"https://login.ch/",
+							false,
+							false,
+							domain.OIDCBackchannelTokenDeliveryModePoll,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1075,6 +1140,11 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1117,6 +1187,11 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"saml-app-id",
 							"https://test.com/saml/metadata",
@@ -1302,6 +1377,11 @@ func Test_AppPrepare(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 						// saml config
 						nil,
 						nil,
@@ -1367,6 +1447,11 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1437,6 +1522,11 @@ func Test_AppPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							false,
+							false,
+							domain.OIDCBackchannelTokenDeliveryModePoll,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1526,6 +1616,11 @@ func Test_AppPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							false,
+							false,
+							domain.OIDCBackchannelTokenDeliveryModePoll,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1615,6 +1710,11 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
@@ -1688,6 +1788,11 @@ func Test_AppPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							false,
+							false,
+							domain.OIDCBackchannelTokenDeliveryModePoll,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1777,6 +1882,11 @@ func Test_AppPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							false,
+							false,
+							domain.OIDCBackchannelTokenDeliveryModePoll,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1866,6 +1976,11 @@ func Test_AppPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							false,
+							false,
+							domain.OIDCBackchannelTokenDeliveryModePoll,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1955,6 +2070,11 @@ func Test_AppPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							false,
+							false,
+							domain.OIDCBackchannelTokenDeliveryModePoll,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
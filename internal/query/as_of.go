@@ -0,0 +1,36 @@
+package query
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// AsOf is the point in time a query is answered for.
+// Instead of reading the projections, the state is rebuilt from the events,
+// so it is only used for investigations and not on hot paths.
+//
+// If Position is set, all events up to and including the position are reduced.
+// Otherwise all events created before Date are reduced.
+type AsOf struct {
+	Position decimal.Decimal
+	Date     time.Time
+}
+
+func (a *AsOf) validate() error {
+	if a == nil || (a.Position.IsZero() && a.Date.IsZero()) {
+		return zerrors.ThrowInvalidArgument(nil, "QUERY-Ahf3u", "Errors.Query.InvalidRequest")
+	}
+	return nil
+}
+
+// filter restricts the search query to the events up to the point in time.
+func (a *AsOf) filter(builder *eventstore.SearchQueryBuilder) *eventstore.SearchQueryBuilder {
+	if !a.Position.IsZero() {
+		return builder.PositionAtMost(a.Position)
+	}
+	return builder.CreationDateBefore(a.Date)
+}
@@ -0,0 +1,464 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/member"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	user_view_model "github.com/zitadel/zitadel/internal/user/repository/view/model"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var asOfTestDate = time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC)
+
+func asOfTestBaseEvent(aggregateType eventstore.AggregateType, aggregateID string, seq uint64) eventstore.BaseEvent {
+	return eventstore.BaseEvent{
+		Agg: &eventstore.Aggregate{
+			ID:            aggregateID,
+			Type:          aggregateType,
+			ResourceOwner: "org1",
+			InstanceID:    "instance",
+		},
+		Seq:      seq,
+		Creation: asOfTestDate.Add(time.Duration(seq) * time.Hour),
+	}
+}
+
+func TestAsOf_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		asOf    *AsOf
+		wantErr bool
+	}{
+		{
+			name:    "nil",
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			asOf:    &AsOf{},
+			wantErr: true,
+		},
+		{
+			name: "position",
+			asOf: &AsOf{Position: decimal.NewFromFloat(1712662457.365215)},
+		},
+		{
+			name: "date",
+			asOf: &AsOf{Date: asOfTestDate},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.asOf.validate()
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestAsOf_filter(t *testing.T) {
+	position := decimal.NewFromFloat(1712662457.365215)
+	builder := (&AsOf{Position: position, Date: asOfTestDate}).filter(eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent))
+	assert.True(t, position.Equal(builder.GetPositionAtMost()))
+	assert.True(t, builder.GetCreationDateBefore().IsZero())
+
+	builder = (&AsOf{Date: asOfTestDate}).filter(eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent))
+	assert.True(t, builder.GetPositionAtMost().IsZero())
+	assert.Equal(t, asOfTestDate, builder.GetCreationDateBefore())
+}
+
+func asOfTestEvent(aggregateType eventstore.AggregateType, aggregateID string, seq uint64, typ eventstore.EventType, data string) *es_models.Event {
+	return &es_models.Event{
+		AggregateID:   aggregateID,
+		AggregateType: aggregateType,
+		ResourceOwner: "org1",
+		InstanceID:    "instance",
+		Seq:           seq,
+		CreationDate:  asOfTestDate.Add(time.Duration(seq) * time.Hour),
+		Typ:           typ,
+		Data:          []byte(data),
+	}
+}
+
+func Test_userAsOfReadModel_Reduce(t *testing.T) {
+	rm := newUserAsOfReadModel("user1", &AsOf{Date: asOfTestDate})
+	rm.AppendEvents(
+		asOfTestEvent(user.AggregateType, "user1", 1, user.HumanAddedType, `{"userName": "username", "firstName": "first", "lastName": "last", "email": "user@example.com"}`),
+		asOfTestEvent(user.AggregateType, "user1", 2, user.HumanEmailVerifiedType, ``),
+		asOfTestEvent(user.AggregateType, "user1", 3, user.HumanProfileChangedType, `{"lastName": "changed", "nickName": "nick"}`),
+		asOfTestEvent(user.AggregateType, "user1", 4, user.HumanPasswordlessInitCodeAddedType, `{"id": "code1"}`),
+	)
+	require.NoError(t, rm.Reduce())
+
+	loginNames := newLoginNamesAsOfReadModel("org1", &AsOf{Date: asOfTestDate})
+	loginNames.AppendEvents(
+		asOfTestEvent(instance.AggregateType, "instance", 1, instance.DomainPolicyAddedEventType, `{"userLoginMustBeDomain": false}`),
+		asOfTestEvent(org.AggregateType, "org1", 2, org.OrgDomainAddedEventType, `{"domain": "org.example.com"}`),
+		asOfTestEvent(org.AggregateType, "org1", 3, org.OrgDomainVerifiedEventType, `{"domain": "org.example.com"}`),
+		asOfTestEvent(org.AggregateType, "org1", 4, org.OrgDomainPrimarySetEventType, `{"domain": "org.example.com"}`),
+		asOfTestEvent(org.AggregateType, "org1", 5, org.OrgDomainAddedEventType, `{"domain": "unverified.example.com"}`),
+		asOfTestEvent(org.AggregateType, "org1", 6, org.DomainPolicyAddedEventType, `{"userLoginMustBeDomain": true}`),
+	)
+	require.NoError(t, loginNames.Reduce())
+
+	assert.Equal(t, &User{
+		ID:                 "user1",
+		CreationDate:       asOfTestDate.Add(time.Hour),
+		ChangeDate:         asOfTestDate.Add(4 * time.Hour),
+		ResourceOwner:      "org1",
+		Sequence:           4,
+		State:              domain.UserStateInitial,
+		Type:               domain.UserTypeHuman,
+		Username:           "username",
+		LoginNames:         database.TextArray[string]{"username@org.example.com"},
+		PreferredLoginName: "username@org.example.com",
+		Human: &Human{
+			FirstName:         "first",
+			LastName:          "changed",
+			NickName:          "nick",
+			PreferredLanguage: language.Und,
+			Email:             "user@example.com",
+			IsEmailVerified:   true,
+			PasswordChanged:   asOfTestDate.Add(time.Hour),
+		},
+	}, userFromViewAsOf(rm.user, loginNames))
+}
+
+func Test_loginNamesAsOfReadModel_loginNames(t *testing.T) {
+	rm := newLoginNamesAsOfReadModel("org1", &AsOf{Date: asOfTestDate})
+	rm.AppendEvents(
+		asOfTestEvent(instance.AggregateType, "instance", 1, instance.DomainPolicyAddedEventType, `{"userLoginMustBeDomain": true}`),
+		asOfTestEvent(org.AggregateType, "org1", 2, org.OrgDomainAddedEventType, `{"domain": "org.example.com"}`),
+		asOfTestEvent(org.AggregateType, "org1", 3, org.OrgDomainVerifiedEventType, `{"domain": "org.example.com"}`),
+		asOfTestEvent(instance.AggregateType, "instance", 4, instance.DomainPolicyChangedEventType, `{"userLoginMustBeDomain": false}`),
+	)
+	require.NoError(t, rm.Reduce())
+	loginNames, preferredLoginName := rm.loginNames(&user_view_model.UserView{UserName: "username"})
+	assert.Equal(t, []string{"username"}, loginNames)
+	assert.Equal(t, "username", preferredLoginName)
+}
+
+func Test_userStateAsOf(t *testing.T) {
+	tests := []struct {
+		name string
+		view *user_view_model.UserView
+		want domain.UserState
+	}{
+		{
+			name: "machine",
+			view: &user_view_model.UserView{State: int32(user_view_model.UserStateActive), MachineView: &user_view_model.MachineView{Name: "machine"}},
+			want: domain.UserStateActive,
+		},
+		{
+			name: "email not verified",
+			view: &user_view_model.UserView{State: int32(user_view_model.UserStateInitial), HumanView: &user_view_model.HumanView{FirstName: "first"}},
+			want: domain.UserStateActive,
+		},
+		{
+			name: "init required",
+			view: &user_view_model.UserView{State: int32(user_view_model.UserStateActive), HumanView: &user_view_model.HumanView{FirstName: "first", InitRequired: true}},
+			want: domain.UserStateInitial,
+		},
+		{
+			name: "passwordless init required",
+			view: &user_view_model.UserView{State: int32(user_view_model.UserStateInitial), HumanView: &user_view_model.HumanView{FirstName: "first", PasswordlessInitRequired: true}},
+			want: domain.UserStateInitial,
+		},
+		{
+			name: "locked",
+			view: &user_view_model.UserView{State: int32(user_view_model.UserStateLocked), HumanView: &user_view_model.HumanView{FirstName: "first", InitRequired: true}},
+			want: domain.UserStateLocked,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, userStateAsOf(tt.view))
+		})
+	}
+}
+
+func Test_userGrantsAsOfReadModel_Reduce(t *testing.T) {
+	rm := newUserGrantsAsOfReadModel([]string{"grant1", "grant2"}, &AsOf{Date: asOfTestDate})
+	rm.AppendEvents(
+		&usergrant.UserGrantAddedEvent{
+			BaseEvent: asOfTestBaseEvent(usergrant.AggregateType, "grant1", 1),
+			UserID:    "user1",
+			ProjectID: "project1",
+			RoleKeys:  []string{"role1"},
+		},
+		&usergrant.UserGrantAddedEvent{
+			BaseEvent: asOfTestBaseEvent(usergrant.AggregateType, "grant2", 1),
+			UserID:    "user1",
+			ProjectID: "project2",
+			RoleKeys:  []string{"role1"},
+		},
+		&usergrant.UserGrantChangedEvent{
+			BaseEvent: asOfTestBaseEvent(usergrant.AggregateType, "grant1", 2),
+			UserID:    "user1",
+			RoleKeys:  []string{"role1", "role2"},
+		},
+		&usergrant.UserGrantDeactivatedEvent{
+			BaseEvent: asOfTestBaseEvent(usergrant.AggregateType, "grant1", 3),
+		},
+		&usergrant.UserGrantRemovedEvent{
+			BaseEvent: asOfTestBaseEvent(usergrant.AggregateType, "grant2", 2),
+		},
+	)
+	require.NoError(t, rm.Reduce())
+	assert.Equal(t, []*UserGrant{
+		{
+			ID:            "grant1",
+			CreationDate:  asOfTestDate.Add(time.Hour),
+			ChangeDate:    asOfTestDate.Add(3 * time.Hour),
+			Sequence:      3,
+			Roles:         []string{"role1", "role2"},
+			State:         domain.UserGrantStateInactive,
+			UserID:        "user1",
+			ResourceOwner: "org1",
+			ProjectID:     "project1",
+		},
+	}, rm.userGrants())
+}
+
+func Test_groupGrantsAsOfReadModel_Reduce(t *testing.T) {
+	rm := newGroupGrantsAsOfReadModel("user1", []string{"group1", "group2"}, []string{"project1"}, &AsOf{Date: asOfTestDate})
+	rm.AppendEvents(
+		&group.MemberAddedEvent{
+			BaseEvent: asOfTestBaseEvent(group.AggregateType, "group1", 1),
+			UserID:    "user1",
+		},
+		&group.MemberAddedEvent{
+			BaseEvent: asOfTestBaseEvent(group.AggregateType, "group2", 1),
+			UserID:    "user1",
+		},
+		&group.GrantAddedEvent{
+			BaseEvent:      asOfTestBaseEvent(group.AggregateType, "group1", 2),
+			GrantID:        "grant1",
+			ProjectID:      "project1",
+			ProjectGrantID: "projectgrant1",
+			RoleKeys:       []string{"role1", "role2", "role3"},
+		},
+		&group.GrantAddedEvent{
+			BaseEvent: asOfTestBaseEvent(group.AggregateType, "group2", 2),
+			GrantID:   "grant2",
+			ProjectID: "project1",
+			RoleKeys:  []string{"role1"},
+		},
+		&project.RoleRemovedEvent{
+			BaseEvent: asOfTestBaseEvent(project.AggregateType, "project1", 3),
+			Key:       "role3",
+		},
+		&project.GrantChangedEvent{
+			BaseEvent: asOfTestBaseEvent(project.AggregateType, "project1", 4),
+			GrantID:   "projectgrant1",
+			RoleKeys:  []string{"role1"},
+		},
+		&group.MemberRemovedEvent{
+			BaseEvent: asOfTestBaseEvent(group.AggregateType, "group2", 3),
+			UserID:    "user1",
+		},
+	)
+	require.NoError(t, rm.Reduce())
+	assert.Equal(t, []*UserGrant{
+		{
+			ID:            "grant1",
+			CreationDate:  asOfTestDate.Add(2 * time.Hour),
+			ChangeDate:    asOfTestDate.Add(2 * time.Hour),
+			Sequence:      2,
+			Roles:         []string{"role1"},
+			GrantID:       "projectgrant1",
+			State:         domain.UserGrantStateActive,
+			UserID:        "user1",
+			ResourceOwner: "org1",
+			ProjectID:     "project1",
+			GroupID:       "group1",
+		},
+	}, rm.userGrants())
+}
+
+func Test_membershipsAsOfReadModel_Reduce(t *testing.T) {
+	rm := newMembershipsAsOfReadModel("user1", &AsOf{Date: asOfTestDate})
+	rm.AppendEvents(
+		&instance.MemberAddedEvent{
+			MemberAddedEvent: member.MemberAddedEvent{
+				BaseEvent: asOfTestBaseEvent(instance.AggregateType, "instance", 1),
+				UserID:    "user1",
+				Roles:     []string{"IAM_OWNER"},
+			},
+		},
+		&org.MemberAddedEvent{
+			MemberAddedEvent: member.MemberAddedEvent{
+				BaseEvent: asOfTestBaseEvent(org.AggregateType, "org1", 1),
+				UserID:    "user1",
+				Roles:     []string{"ORG_OWNER"},
+			},
+		},
+		&project.GrantMemberAddedEvent{
+			BaseEvent: asOfTestBaseEvent(project.AggregateType, "project1", 1),
+			UserID:    "user1",
+			GrantID:   "grant1",
+			Roles:     []string{"PROJECT_GRANT_OWNER"},
+		},
+		&org.MemberChangedEvent{
+			MemberChangedEvent: member.MemberChangedEvent{
+				BaseEvent: asOfTestBaseEvent(org.AggregateType, "org1", 2),
+				UserID:    "user1",
+				Roles:     []string{"ORG_USER_MANAGER"},
+			},
+		},
+		&instance.MemberRemovedEvent{
+			MemberRemovedEvent: member.MemberRemovedEvent{
+				BaseEvent: asOfTestBaseEvent(instance.AggregateType, "instance", 2),
+				UserID:    "user1",
+			},
+		},
+	)
+	require.NoError(t, rm.Reduce())
+	assert.Equal(t, []*Membership{
+		{
+			UserID:        "user1",
+			Roles:         []string{"ORG_USER_MANAGER"},
+			CreationDate:  asOfTestDate.Add(time.Hour),
+			ChangeDate:    asOfTestDate.Add(2 * time.Hour),
+			Sequence:      2,
+			ResourceOwner: "org1",
+			Org:           &OrgMembership{OrgID: "org1"},
+		},
+		{
+			UserID:        "user1",
+			Roles:         []string{"PROJECT_GRANT_OWNER"},
+			CreationDate:  asOfTestDate.Add(time.Hour),
+			ChangeDate:    asOfTestDate.Add(time.Hour),
+			Sequence:      1,
+			ResourceOwner: "org1",
+			ProjectGrant:  &ProjectGrantMembership{ProjectID: "project1", GrantID: "grant1"},
+		},
+	}, rm.memberships())
+}
+
+func Test_membershipsAsOfReadModel_Reduce_removed(t *testing.T) {
+	rm := newMembershipsAsOfReadModel("user1", &AsOf{Date: asOfTestDate})
+	rm.AppendEvents(
+		&org.MemberAddedEvent{
+			MemberAddedEvent: member.MemberAddedEvent{
+				BaseEvent: asOfTestBaseEvent(org.AggregateType, "org1", 1),
+				UserID:    "user1",
+				Roles:     []string{"ORG_OWNER"},
+			},
+		},
+		&org.MemberAddedEvent{
+			MemberAddedEvent: member.MemberAddedEvent{
+				BaseEvent: asOfTestBaseEvent(org.AggregateType, "org2", 1),
+				UserID:    "user1",
+				Roles:     []string{"ORG_OWNER"},
+			},
+		},
+		&project.MemberAddedEvent{
+			MemberAddedEvent: member.MemberAddedEvent{
+				BaseEvent: asOfTestBaseEvent(project.AggregateType, "project1", 1),
+				UserID:    "user1",
+				Roles:     []string{"PROJECT_OWNER"},
+			},
+		},
+		&project.GrantMemberAddedEvent{
+			BaseEvent: asOfTestBaseEvent(project.AggregateType, "project1", 2),
+			UserID:    "user1",
+			GrantID:   "grant1",
+			Roles:     []string{"PROJECT_GRANT_OWNER"},
+		},
+		&project.GrantMemberAddedEvent{
+			BaseEvent: asOfTestBaseEvent(project.AggregateType, "project2", 1),
+			UserID:    "user1",
+			GrantID:   "grant2",
+			Roles:     []string{"PROJECT_GRANT_OWNER"},
+		},
+		&project.GrantMemberAddedEvent{
+			BaseEvent: asOfTestBaseEvent(project.AggregateType, "project2", 2),
+			UserID:    "user1",
+			GrantID:   "grant3",
+			Roles:     []string{"PROJECT_GRANT_OWNER"},
+		},
+		&org.OrgRemovedEvent{
+			BaseEvent: asOfTestBaseEvent(org.AggregateType, "org1", 2),
+		},
+		&project.ProjectRemovedEvent{
+			BaseEvent: asOfTestBaseEvent(project.AggregateType, "project1", 3),
+		},
+		&project.GrantRemovedEvent{
+			BaseEvent: asOfTestBaseEvent(project.AggregateType, "project2", 3),
+			GrantID:   "grant2",
+		},
+	)
+	require.NoError(t, rm.Reduce())
+	assert.Equal(t, []*Membership{
+		{
+			UserID:        "user1",
+			Roles:         []string{"ORG_OWNER"},
+			CreationDate:  asOfTestDate.Add(time.Hour),
+			ChangeDate:    asOfTestDate.Add(time.Hour),
+			Sequence:      1,
+			ResourceOwner: "org1",
+			Org:           &OrgMembership{OrgID: "org2"},
+		},
+		{
+			UserID:        "user1",
+			Roles:         []string{"PROJECT_GRANT_OWNER"},
+			CreationDate:  asOfTestDate.Add(2 * time.Hour),
+			ChangeDate:    asOfTestDate.Add(2 * time.Hour),
+			Sequence:      2,
+			ResourceOwner: "org1",
+			ProjectGrant:  &ProjectGrantMembership{ProjectID: "project2", GrantID: "grant3"},
+		},
+	}, rm.memberships())
+}
+
+func Test_orgAsOfReadModel_Reduce(t *testing.T) {
+	rm := newOrgAsOfReadModel("org1", &AsOf{Date: asOfTestDate})
+	rm.AppendEvents(
+		asOfTestEvent(org.AggregateType, "org1", 1, org.OrgAddedEventType, `{"name": "org"}`),
+		asOfTestEvent(org.AggregateType, "org1", 2, org.OrgDomainAddedEventType, `{"domain": "org.example.com"}`),
+		asOfTestEvent(org.AggregateType, "org1", 3, org.OrgDomainVerifiedEventType, `{"domain": "org.example.com"}`),
+		asOfTestEvent(org.AggregateType, "org1", 4, org.OrgDomainPrimarySetEventType, `{"domain": "org.example.com"}`),
+		asOfTestEvent(org.AggregateType, "org1", 5, org.OrgDeactivatedEventType, ``),
+	)
+	require.NoError(t, rm.Reduce())
+	got, err := rm.orgAsOf()
+	require.NoError(t, err)
+	assert.Equal(t, &Org{
+		ID:            "org1",
+		CreationDate:  asOfTestDate.Add(time.Hour),
+		ChangeDate:    asOfTestDate.Add(5 * time.Hour),
+		ResourceOwner: "org1",
+		State:         domain.OrgStateInactive,
+		Sequence:      5,
+		instanceID:    "instance",
+		Name:          "org",
+		Domain:        "org.example.com",
+	}, got)
+}
+
+func Test_orgAsOfReadModel_Reduce_removed(t *testing.T) {
+	rm := newOrgAsOfReadModel("org1", &AsOf{Date: asOfTestDate})
+	rm.AppendEvents(
+		asOfTestEvent(org.AggregateType, "org1", 1, org.OrgAddedEventType, `{"name": "org"}`),
+		asOfTestEvent(org.AggregateType, "org1", 2, org.OrgRemovedEventType, ``),
+	)
+	require.NoError(t, rm.Reduce())
+	_, err := rm.orgAsOf()
+	assert.True(t, zerrors.IsNotFound(err))
+}
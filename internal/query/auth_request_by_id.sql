@@ -9,7 +9,8 @@ select
     ui_locales,
     login_hint,
     max_age,
-    hint_user_id
+    hint_user_id,
+    authorization_details
 from projections.auth_requests
 where id = $1 and instance_id = $2
 limit 1;
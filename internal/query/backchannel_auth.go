@@ -0,0 +1,137 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	backchannelAuthRequestTable = table{
+		name:          projection.BackchannelAuthRequestProjectionTable,
+		instanceIDCol: projection.BackchannelAuthRequestColumnInstanceID,
+	}
+	BackchannelAuthRequestColumnID = Column{
+		name:  projection.BackchannelAuthRequestColumnID,
+		table: backchannelAuthRequestTable,
+	}
+	BackchannelAuthRequestColumnClientID = Column{
+		name:  projection.BackchannelAuthRequestColumnClientID,
+		table: backchannelAuthRequestTable,
+	}
+	BackchannelAuthRequestColumnUserID = Column{
+		name:  projection.BackchannelAuthRequestColumnUserID,
+		table: backchannelAuthRequestTable,
+	}
+	BackchannelAuthRequestColumnScopes = Column{
+		name:  projection.BackchannelAuthRequestColumnScopes,
+		table: backchannelAuthRequestTable,
+	}
+	BackchannelAuthRequestColumnBindingMessage = Column{
+		name:  projection.BackchannelAuthRequestColumnBindingMessage,
+		table: backchannelAuthRequestTable,
+	}
+	BackchannelAuthRequestColumnExpires = Column{
+		name:  projection.BackchannelAuthRequestColumnExpires,
+		table: backchannelAuthRequestTable,
+	}
+	BackchannelAuthRequestColumnCreationDate = Column{
+		name:  projection.BackchannelAuthRequestColumnCreationDate,
+		table: backchannelAuthRequestTable,
+	}
+	BackchannelAuthRequestColumnInstanceID = Column{
+		name:  projection.BackchannelAuthRequestColumnInstanceID,
+		table: backchannelAuthRequestTable,
+	}
+)
+
+// BackchannelAuthRequestsByUserID returns the pending (not yet expired) client initiated backchannel authentication requests
+// of the user from the `backchannel_auth_requests` projection.
+func (q *Queries) BackchannelAuthRequestsByUserID(ctx context.Context, userID string) (authReqs []*domain.AuthRequestBackchannel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareBackchannelAuthQuery()
+	query, args, err := stmt.Where(sq.And{
+		sq.Eq{
+			BackchannelAuthRequestColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+			BackchannelAuthRequestColumnUserID.identifier():     userID,
+		},
+		sq.Gt{BackchannelAuthRequestColumnExpires.identifier(): time.Now()},
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Iequ4", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		authReqs, err = scan(rows)
+		return err
+	}, query, args...)
+	return authReqs, err
+}
+
+var backchannelAuthSelectColumns = []string{
+	BackchannelAuthRequestColumnID.identifier(),
+	BackchannelAuthRequestColumnCreationDate.identifier(),
+	BackchannelAuthRequestColumnClientID.identifier(),
+	BackchannelAuthRequestColumnUserID.identifier(),
+	BackchannelAuthRequestColumnScopes.identifier(),
+	BackchannelAuthRequestColumnBindingMessage.identifier(),
+	BackchannelAuthRequestColumnExpires.identifier(),
+	AppColumnName.identifier(),
+	ProjectColumnName.identifier(),
+}
+
+func prepareBackchannelAuthQuery() (sq.SelectBuilder, func(*sql.Rows) ([]*domain.AuthRequestBackchannel, error)) {
+	return sq.Select(backchannelAuthSelectColumns...).
+			From(backchannelAuthRequestTable.identifier()).
+			LeftJoin(join(AppOIDCConfigColumnClientID, BackchannelAuthRequestColumnClientID)).
+			LeftJoin(join(AppColumnID, AppOIDCConfigColumnAppID)).
+			LeftJoin(join(ProjectColumnID, AppColumnProjectID)).
+			OrderBy(BackchannelAuthRequestColumnCreationDate.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*domain.AuthRequestBackchannel, error) {
+			authReqs := make([]*domain.AuthRequestBackchannel, 0)
+			for rows.Next() {
+				authReq := new(domain.AuthRequestBackchannel)
+				var (
+					scopes         database.TextArray[string]
+					bindingMessage sql.NullString
+					appName        sql.NullString
+					projectName    sql.NullString
+				)
+				err := rows.Scan(
+					&authReq.ID,
+					&authReq.CreationDate,
+					&authReq.ClientID,
+					&authReq.UserID,
+					&scopes,
+					&bindingMessage,
+					&authReq.Expires,
+					&appName,
+					&projectName,
+				)
+				if err != nil {
+					return nil, zerrors.ThrowInternal(err, "QUERY-ooG2e", "Errors.Internal")
+				}
+				authReq.Scopes = scopes
+				authReq.BindingMessage = bindingMessage.String
+				authReq.AppName = appName.String
+				authReq.ProjectName = projectName.String
+				authReqs = append(authReqs, authReq)
+			}
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Jaeh3", "Errors.Query.CloseRows")
+			}
+			return authReqs, nil
+		}
+}
@@ -0,0 +1,136 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+const expectedBackchannelAuthQueryC = `SELECT` +
+	` projections.backchannel_auth_requests.id,` +
+	` projections.backchannel_auth_requests.creation_date,` +
+	` projections.backchannel_auth_requests.client_id,` +
+	` projections.backchannel_auth_requests.user_id,` +
+	` projections.backchannel_auth_requests.scopes,` +
+	` projections.backchannel_auth_requests.binding_message,` +
+	` projections.backchannel_auth_requests.expires,` +
+	` projections.apps7.name,` +
+	` projections.projects4.name` +
+	` FROM projections.backchannel_auth_requests` +
+	` LEFT JOIN projections.apps7_oidc_configs` +
+	` ON projections.backchannel_auth_requests.client_id = projections.apps7_oidc_configs.client_id` +
+	` AND projections.backchannel_auth_requests.instance_id = projections.apps7_oidc_configs.instance_id` +
+	` LEFT JOIN projections.apps7 ON projections.apps7_oidc_configs.app_id = projections.apps7.id` +
+	` AND projections.apps7_oidc_configs.instance_id = projections.apps7.instance_id` +
+	` LEFT JOIN projections.projects4 ON projections.apps7.project_id = projections.projects4.id` +
+	` AND projections.apps7.instance_id = projections.projects4.instance_id` +
+	` ORDER BY projections.backchannel_auth_requests.creation_date`
+
+func Test_prepareBackchannelAuthQuery(t *testing.T) {
+	expectedQuery := regexp.QuoteMeta(expectedBackchannelAuthQueryC)
+	now := time.Now()
+
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name   string
+		want   want
+		object []*domain.AuthRequestBackchannel
+	}{
+		{
+			name: "no results",
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedQuery,
+					backchannelAuthSelectColumns,
+					nil,
+				),
+			},
+			object: []*domain.AuthRequestBackchannel{},
+		},
+		{
+			name: "success",
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedQuery,
+					backchannelAuthSelectColumns,
+					[][]driver.Value{
+						{
+							"id1",
+							now,
+							"client-id",
+							"user-id",
+							database.TextArray[string]{"openid", "profile"},
+							"binding",
+							now,
+							"appName",
+							"projectName",
+						},
+						{
+							"id2",
+							now,
+							"client-id",
+							"user-id",
+							database.TextArray[string]{"openid"},
+							nil,
+							now,
+							nil,
+							nil,
+						},
+					},
+				),
+			},
+			object: []*domain.AuthRequestBackchannel{
+				{
+					ID:             "id1",
+					CreationDate:   now,
+					ClientID:       "client-id",
+					UserID:         "user-id",
+					Scopes:         []string{"openid", "profile"},
+					BindingMessage: "binding",
+					Expires:        now,
+					AppName:        "appName",
+					ProjectName:    "projectName",
+				},
+				{
+					ID:           "id2",
+					CreationDate: now,
+					ClientID:     "client-id",
+					UserID:       "user-id",
+					Scopes:       []string{"openid"},
+					Expires:      now,
+				},
+			},
+		},
+		{
+			name: "sql err",
+			want: want{
+				sqlExpectations: mockQueryErr(
+					expectedQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, prepareBackchannelAuthQuery, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
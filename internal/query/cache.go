@@ -9,12 +9,16 @@ import (
 
 	"github.com/zitadel/zitadel/internal/cache"
 	"github.com/zitadel/zitadel/internal/cache/connector"
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/eventstore"
 )
 
 type Caches struct {
-	instance cache.Cache[instanceIndex, string, *authzInstance]
-	org      cache.Cache[orgIndex, string, *Org]
+	instance            cache.Cache[instanceIndex, string, *authzInstance]
+	org                 cache.Cache[orgIndex, string, *Org]
+	introspectionClient cache.Cache[introspectionClientIndex, string, *introspectionClientEntry]
+	oidcUserInfo        cache.Cache[oidcUserInfoIndex, string, *oidcUserInfoEntry]
+	webKeySet           cache.Cache[webKeySetIndex, string, *webKeySetEntry]
 
 	activeInstances *expirable.LRU[string, bool]
 }
@@ -24,7 +28,7 @@ type ActiveInstanceConfig struct {
 	TTL        time.Duration
 }
 
-func startCaches(background context.Context, connectors connector.Connectors, instanceConfig ActiveInstanceConfig) (_ *Caches, err error) {
+func startCaches(background context.Context, connectors connector.Connectors, instanceConfig ActiveInstanceConfig, client *database.DB) (_ *Caches, err error) {
 	caches := new(Caches)
 	caches.instance, err = connector.StartCache[instanceIndex, string, *authzInstance](background, instanceIndexValues(), cache.PurposeAuthzInstance, connectors.Config.Instance, connectors)
 	if err != nil {
@@ -34,11 +38,26 @@ func startCaches(background context.Context, connectors connector.Connectors, in
 	if err != nil {
 		return nil, err
 	}
+	caches.introspectionClient, err = connector.StartCache[introspectionClientIndex, string, *introspectionClientEntry](background, introspectionClientIndexValues(), cache.PurposeIntrospectionClient, connectors.Config.IntrospectionClients, connectors)
+	if err != nil {
+		return nil, err
+	}
+	caches.oidcUserInfo, err = connector.StartCache[oidcUserInfoIndex, string, *oidcUserInfoEntry](background, oidcUserInfoIndexValues(), cache.PurposeOIDCUserInfo, connectors.Config.OIDCUserInfos, connectors)
+	if err != nil {
+		return nil, err
+	}
+	caches.webKeySet, err = connector.StartCache[webKeySetIndex, string, *webKeySetEntry](background, webKeySetIndexValues(), cache.PurposeWebKeySet, connectors.Config.WebKeySets, connectors)
+	if err != nil {
+		return nil, err
+	}
 
 	caches.activeInstances = expirable.NewLRU[string, bool](instanceConfig.MaxEntries, nil, instanceConfig.TTL)
 
 	caches.registerInstanceInvalidation()
 	caches.registerOrgInvalidation()
+	caches.registerIntrospectionClientInvalidation()
+	caches.registerOIDCUserInfoInvalidation(client)
+	caches.registerWebKeySetInvalidation()
 	return caches, nil
 }
 
@@ -64,3 +83,12 @@ func getAggregateID(aggregate *eventstore.Aggregate) string {
 func getResourceOwner(aggregate *eventstore.Aggregate) string {
 	return aggregate.ResourceOwner
 }
+
+// aggregateIDsByInstance groups the IDs of the aggregates by their instance.
+func aggregateIDsByInstance(aggregates []*eventstore.Aggregate) map[string][]string {
+	ids := make(map[string][]string)
+	for _, aggregate := range aggregates {
+		ids[aggregate.InstanceID] = append(ids[aggregate.InstanceID], aggregate.ID)
+	}
+	return ids
+}
@@ -0,0 +1,148 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func Test_introspectionClientEntry_isValid(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		entry   *introspectionClientEntry
+		getKeys bool
+		want    bool
+	}{
+		{
+			name:    "without keys",
+			entry:   &introspectionClientEntry{},
+			getKeys: false,
+			want:    true,
+		},
+		{
+			name:    "keys required, not queried",
+			entry:   &introspectionClientEntry{},
+			getKeys: true,
+			want:    false,
+		},
+		{
+			name:    "keys required, none existing",
+			entry:   &introspectionClientEntry{WithKeys: true},
+			getKeys: true,
+			want:    true,
+		},
+		{
+			name:    "keys required, valid",
+			entry:   &introspectionClientEntry{WithKeys: true, KeysExpiration: now.Add(time.Minute)},
+			getKeys: true,
+			want:    true,
+		},
+		{
+			name:    "keys required, expired",
+			entry:   &introspectionClientEntry{WithKeys: true, KeysExpiration: now.Add(-time.Minute)},
+			getKeys: true,
+			want:    false,
+		},
+		{
+			name:    "keys expired, not required",
+			entry:   &introspectionClientEntry{WithKeys: true, KeysExpiration: now.Add(-time.Minute)},
+			getKeys: false,
+			want:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.entry.isValid(tt.getKeys, now))
+		})
+	}
+}
+
+func Test_introspectionClientEntry_Keys(t *testing.T) {
+	entry := &introspectionClientEntry{
+		InstanceID: "instanceID",
+		Client: &IntrospectionClient{
+			ClientID:      "clientID",
+			ProjectID:     "projectID",
+			ResourceOwner: "orgID",
+		},
+	}
+	assert.Equal(t, []string{"instanceID-clientID"}, entry.Keys(introspectionClientIndexByClientID))
+	assert.Equal(t, []string{"instanceID-projectID"}, entry.Keys(introspectionClientIndexByProjectID))
+	assert.Equal(t, []string{"instanceID-orgID"}, entry.Keys(introspectionClientIndexByResourceOwner))
+	assert.Nil(t, entry.Keys(introspectionClientIndexUnspecified))
+}
+
+func Test_oidcUserInfoEntry_Keys(t *testing.T) {
+	entry := &oidcUserInfoEntry{
+		InstanceID: "instanceID",
+		QueryKey:   oidcUserInfoQueryKey("instanceID", "userID", []string{"project2", "project1"}, nil),
+		UserInfo: &OIDCUserInfo{
+			User: &User{
+				ID:            "userID",
+				ResourceOwner: "orgID",
+			},
+			Org: &UserInfoOrg{
+				ID: "orgID",
+			},
+			UserGrants: []UserGrant{
+				{
+					ID:            "grantID",
+					ResourceOwner: "orgID",
+					ProjectID:     "project1",
+				},
+				{
+					ID:            "groupGrantID",
+					ResourceOwner: "org2",
+					ProjectID:     "project2",
+					GroupID:       "groupID",
+				},
+			},
+			Groups: []UserInfoGroup{
+				{
+					ID: "groupID",
+				},
+			},
+		},
+	}
+	assert.Equal(t, []string{"instanceID-userID-project1,project2-"}, entry.Keys(oidcUserInfoIndexByQuery))
+	assert.Equal(t, []string{
+		"instanceID-grantID",
+		"instanceID-groupGrantID",
+		"instanceID-groupID",
+		"instanceID-instanceID",
+		"instanceID-org2",
+		"instanceID-orgID",
+		"instanceID-project1",
+		"instanceID-project2",
+		"instanceID-userID",
+	}, entry.Keys(oidcUserInfoIndexByRelatedID))
+	assert.Nil(t, entry.Keys(oidcUserInfoIndexUnspecified))
+}
+
+func Test_oidcUserInfoQueryKey(t *testing.T) {
+	assert.Equal(t,
+		oidcUserInfoQueryKey("instanceID", "userID", []string{"a", "b"}, []string{"c", "d"}),
+		oidcUserInfoQueryKey("instanceID", "userID", []string{"b", "a"}, []string{"d", "c"}),
+		"order of arguments must not matter",
+	)
+	assert.NotEqual(t,
+		oidcUserInfoQueryKey("instanceID", "userID", []string{"a", "b"}, nil),
+		oidcUserInfoQueryKey("instanceID", "userID", []string{"a"}, []string{"b"}),
+	)
+}
+
+func Test_aggregateIDsByInstance(t *testing.T) {
+	got := aggregateIDsByInstance([]*eventstore.Aggregate{
+		{ID: "1", InstanceID: "instance1"},
+		{ID: "2", InstanceID: "instance2"},
+		{ID: "3", InstanceID: "instance1"},
+	})
+	assert.Equal(t, map[string][]string{
+		"instance1": {"1", "3"},
+		"instance2": {"2"},
+	}, got)
+}
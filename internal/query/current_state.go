@@ -156,7 +156,7 @@ func tablesForReset(ctx context.Context, tx *sql.Tx, projectionName string) (tab
 		Where(
 			sq.And{
 				sq.Eq{"type": "table"},
-				sq.NotEq{"table_name": []string{"locks", "current_sequences", "current_states", "failed_events", "failed_events2"}},
+				sq.NotEq{"table_name": []string{"locks", "current_sequences", "current_states", "failed_events", "failed_events2", "blocked_aggregates", "shard_leases"}},
 				sq.Like{"table_name": tablePrefix + "%"},
 			}).
 		PlaceholderFormat(sq.Dollar).
@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/call"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -15,6 +17,7 @@ type Event struct {
 	Aggregate    *eventstore.Aggregate
 	Sequence     uint64
 	CreationDate time.Time
+	Position     decimal.Decimal
 	Type         string
 	Payload      []byte
 }
@@ -105,6 +108,7 @@ func (er *eventsReducer) convertEvent(ctx context.Context, event eventstore.Even
 		Aggregate:    event.Aggregate(),
 		Sequence:     event.Sequence(),
 		CreationDate: event.CreatedAt(),
+		Position:     event.Position(),
 		Type:         string(event.Type()),
 		Payload:      event.DataAsBytes(),
 	}
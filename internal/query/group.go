@@ -0,0 +1,541 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"slices"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	groupTable = table{
+		name:          projection.GroupProjectionTable,
+		instanceIDCol: projection.GroupInstanceIDCol,
+	}
+	GroupColumnID = Column{
+		name:  projection.GroupIDCol,
+		table: groupTable,
+	}
+	GroupColumnCreationDate = Column{
+		name:  projection.GroupCreationDateCol,
+		table: groupTable,
+	}
+	GroupColumnChangeDate = Column{
+		name:  projection.GroupChangeDateCol,
+		table: groupTable,
+	}
+	GroupColumnSequence = Column{
+		name:  projection.GroupSequenceCol,
+		table: groupTable,
+	}
+	GroupColumnState = Column{
+		name:  projection.GroupStateCol,
+		table: groupTable,
+	}
+	GroupColumnResourceOwner = Column{
+		name:  projection.GroupResourceOwnerCol,
+		table: groupTable,
+	}
+	GroupColumnInstanceID = Column{
+		name:  projection.GroupInstanceIDCol,
+		table: groupTable,
+	}
+	GroupColumnName = Column{
+		name:  projection.GroupNameCol,
+		table: groupTable,
+	}
+	GroupColumnDescription = Column{
+		name:  projection.GroupDescriptionCol,
+		table: groupTable,
+	}
+)
+
+var (
+	groupMemberTable = table{
+		name:          projection.GroupMemberTable,
+		instanceIDCol: projection.GroupMemberInstanceIDCol,
+	}
+	GroupMemberColumnGroupID = Column{
+		name:  projection.GroupMemberGroupIDCol,
+		table: groupMemberTable,
+	}
+	GroupMemberColumnUserID = Column{
+		name:  projection.GroupMemberUserIDCol,
+		table: groupMemberTable,
+	}
+	GroupMemberColumnInstanceID = Column{
+		name:  projection.GroupMemberInstanceIDCol,
+		table: groupMemberTable,
+	}
+	GroupMemberColumnCreationDate = Column{
+		name:  projection.GroupMemberCreationDateCol,
+		table: groupMemberTable,
+	}
+	GroupMemberColumnSequence = Column{
+		name:  projection.GroupMemberSequenceCol,
+		table: groupMemberTable,
+	}
+)
+
+var (
+	groupGrantTable = table{
+		name:          projection.GroupGrantTable,
+		instanceIDCol: projection.GroupGrantInstanceIDCol,
+	}
+	GroupGrantColumnID = Column{
+		name:  projection.GroupGrantIDCol,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnGroupID = Column{
+		name:  projection.GroupGrantGroupIDCol,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnInstanceID = Column{
+		name:  projection.GroupGrantInstanceIDCol,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnResourceOwner = Column{
+		name:  projection.GroupGrantResourceOwnerCol,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnCreationDate = Column{
+		name:  projection.GroupGrantCreationDateCol,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnChangeDate = Column{
+		name:  projection.GroupGrantChangeDateCol,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnSequence = Column{
+		name:  projection.GroupGrantSequenceCol,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnProjectID = Column{
+		name:  projection.GroupGrantProjectIDCol,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnProjectGrantID = Column{
+		name:  projection.GroupGrantProjectGrantIDCol,
+		table: groupGrantTable,
+	}
+	GroupGrantColumnRoles = Column{
+		name:  projection.GroupGrantRolesCol,
+		table: groupGrantTable,
+	}
+)
+
+type Groups struct {
+	SearchResponse
+	Groups []*Group
+}
+
+func (g *Groups) SetState(s *State) {
+	g.State = s
+}
+
+type Group struct {
+	ID            string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+	State         domain.GroupState
+	ResourceOwner string
+
+	Name        string
+	Description string
+}
+
+type GroupSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *GroupSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func groupsCheckPermission(ctx context.Context, groups *Groups, permissionCheck domain.PermissionCheck) {
+	groups.Groups = slices.DeleteFunc(groups.Groups,
+		func(group *Group) bool {
+			return groupCheckPermission(ctx, group.ResourceOwner, group.ID, permissionCheck) != nil
+		},
+	)
+}
+
+func groupCheckPermission(ctx context.Context, resourceOwner, groupID string, permissionCheck domain.PermissionCheck) error {
+	return permissionCheck(ctx, domain.PermissionGroupRead, resourceOwner, groupID)
+}
+
+func (q *Queries) GetGroupByID(ctx context.Context, id string, permissionCheck domain.PermissionCheck) (_ *Group, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		GroupColumnID.identifier():         id,
+		GroupColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	query, scan := prepareGroupQuery()
+	group, err := genericRowQuery(ctx, q.client, query.Where(eq), scan)
+	if err != nil {
+		return nil, err
+	}
+	if permissionCheck != nil {
+		if err := groupCheckPermission(ctx, group.ResourceOwner, group.ID, permissionCheck); err != nil {
+			return nil, err
+		}
+	}
+	return group, nil
+}
+
+func (q *Queries) SearchGroups(ctx context.Context, queries *GroupSearchQueries, permissionCheck domain.PermissionCheck) (_ *Groups, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		GroupColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	query, scan := prepareGroupsQuery()
+	groups, err := genericRowsQueryWithState(ctx, q.client, groupTable, combineToWhereStmt(query, queries.toQuery, eq), scan)
+	if err != nil {
+		return nil, err
+	}
+	if permissionCheck != nil {
+		groupsCheckPermission(ctx, groups, permissionCheck)
+	}
+	return groups, nil
+}
+
+func NewGroupIDsSearchQuery(ids []string) (SearchQuery, error) {
+	return NewListQuery(GroupColumnID, database.TextArray[string](ids), ListIn)
+}
+
+func NewGroupNameSearchQuery(method TextComparison, value string) (SearchQuery, error) {
+	return NewTextQuery(GroupColumnName, value, method)
+}
+
+func NewGroupResourceOwnerSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(GroupColumnResourceOwner, id, TextEquals)
+}
+
+// NewGroupMemberSearchQuery only returns the groups the given user is a member of.
+func NewGroupMemberSearchQuery(userID string) (SearchQuery, error) {
+	userQuery, err := NewTextQuery(GroupMemberColumnUserID, userID, TextEquals)
+	if err != nil {
+		return nil, err
+	}
+	subSelect, err := NewSubSelect(GroupMemberColumnGroupID, []SearchQuery{userQuery})
+	if err != nil {
+		return nil, err
+	}
+	return NewListQuery(
+		GroupColumnID,
+		subSelect,
+		ListIn,
+	)
+}
+
+func prepareGroupQuery() (sq.SelectBuilder, func(*sql.Row) (*Group, error)) {
+	return sq.Select(
+			GroupColumnID.identifier(),
+			GroupColumnCreationDate.identifier(),
+			GroupColumnChangeDate.identifier(),
+			GroupColumnSequence.identifier(),
+			GroupColumnState.identifier(),
+			GroupColumnResourceOwner.identifier(),
+			GroupColumnName.identifier(),
+			GroupColumnDescription.identifier(),
+		).From(groupTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*Group, error) {
+			group := new(Group)
+			err := row.Scan(
+				&group.ID,
+				&group.CreationDate,
+				&group.ChangeDate,
+				&group.Sequence,
+				&group.State,
+				&group.ResourceOwner,
+				&group.Name,
+				&group.Description,
+			)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-Gr0uP", "Errors.Group.NotFound")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-Bq2kd", "Errors.Internal")
+			}
+			return group, nil
+		}
+}
+
+func prepareGroupsQuery() (sq.SelectBuilder, func(*sql.Rows) (*Groups, error)) {
+	return sq.Select(
+			GroupColumnID.identifier(),
+			GroupColumnCreationDate.identifier(),
+			GroupColumnChangeDate.identifier(),
+			GroupColumnSequence.identifier(),
+			GroupColumnState.identifier(),
+			GroupColumnResourceOwner.identifier(),
+			GroupColumnName.identifier(),
+			GroupColumnDescription.identifier(),
+			countColumn.identifier(),
+		).From(groupTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*Groups, error) {
+			groups := make([]*Group, 0)
+			var count uint64
+			for rows.Next() {
+				group := new(Group)
+				err := rows.Scan(
+					&group.ID,
+					&group.CreationDate,
+					&group.ChangeDate,
+					&group.Sequence,
+					&group.State,
+					&group.ResourceOwner,
+					&group.Name,
+					&group.Description,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				groups = append(groups, group)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-K2nfw", "Errors.Query.CloseRows")
+			}
+
+			return &Groups{
+				Groups: groups,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+type GroupMembers struct {
+	SearchResponse
+	Members []*GroupMember
+}
+
+func (m *GroupMembers) SetState(s *State) {
+	m.State = s
+}
+
+type GroupMember struct {
+	GroupID      string
+	UserID       string
+	CreationDate time.Time
+	Sequence     uint64
+}
+
+type GroupMemberSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *GroupMemberSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+// SearchGroupMembers returns the members of the given group.
+// The permission is checked on the group itself.
+func (q *Queries) SearchGroupMembers(ctx context.Context, groupID string, queries *GroupMemberSearchQueries, permissionCheck domain.PermissionCheck) (_ *GroupMembers, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if _, err := q.GetGroupByID(ctx, groupID, permissionCheck); err != nil {
+		return nil, err
+	}
+	eq := sq.Eq{
+		GroupMemberColumnGroupID.identifier():    groupID,
+		GroupMemberColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	query, scan := prepareGroupMembersQuery()
+	return genericRowsQueryWithState(ctx, q.client, groupTable, combineToWhereStmt(query, queries.toQuery, eq), scan)
+}
+
+// GroupMembersByGroupIDs returns the members of all given groups.
+// The caller is responsible to check the permission on the groups.
+func (q *Queries) GroupMembersByGroupIDs(ctx context.Context, groupIDs []string) (_ *GroupMembers, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		GroupMemberColumnGroupID.identifier():    groupIDs,
+		GroupMemberColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	query, scan := prepareGroupMembersQuery()
+	return genericRowsQueryWithState(ctx, q.client, groupTable, query.Where(eq).OrderBy(GroupMemberColumnCreationDate.identifier()), scan)
+}
+
+func NewGroupMemberUserIDsSearchQuery(ids []string) (SearchQuery, error) {
+	return NewListQuery(GroupMemberColumnUserID, database.TextArray[string](ids), ListIn)
+}
+
+func prepareGroupMembersQuery() (sq.SelectBuilder, func(*sql.Rows) (*GroupMembers, error)) {
+	return sq.Select(
+			GroupMemberColumnGroupID.identifier(),
+			GroupMemberColumnUserID.identifier(),
+			GroupMemberColumnCreationDate.identifier(),
+			GroupMemberColumnSequence.identifier(),
+			countColumn.identifier(),
+		).From(groupMemberTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*GroupMembers, error) {
+			members := make([]*GroupMember, 0)
+			var count uint64
+			for rows.Next() {
+				member := new(GroupMember)
+				err := rows.Scan(
+					&member.GroupID,
+					&member.UserID,
+					&member.CreationDate,
+					&member.Sequence,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				members = append(members, member)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-d8Wql", "Errors.Query.CloseRows")
+			}
+
+			return &GroupMembers{
+				Members: members,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+type GroupGrants struct {
+	SearchResponse
+	Grants []*GroupGrant
+}
+
+func (g *GroupGrants) SetState(s *State) {
+	g.State = s
+}
+
+type GroupGrant struct {
+	ID             string
+	GroupID        string
+	CreationDate   time.Time
+	ChangeDate     time.Time
+	Sequence       uint64
+	ResourceOwner  string
+	ProjectID      string
+	ProjectGrantID string
+	Roles          database.TextArray[string]
+}
+
+type GroupGrantSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *GroupGrantSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+// SearchGroupGrants returns the project grants of the given group.
+// The permission is checked on the group itself.
+func (q *Queries) SearchGroupGrants(ctx context.Context, groupID string, queries *GroupGrantSearchQueries, permissionCheck domain.PermissionCheck) (_ *GroupGrants, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if _, err := q.GetGroupByID(ctx, groupID, permissionCheck); err != nil {
+		return nil, err
+	}
+	eq := sq.Eq{
+		GroupGrantColumnGroupID.identifier():    groupID,
+		GroupGrantColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	query, scan := prepareGroupGrantsQuery()
+	return genericRowsQueryWithState(ctx, q.client, groupTable, combineToWhereStmt(query, queries.toQuery, eq), scan)
+}
+
+func NewGroupGrantProjectIDSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(GroupGrantColumnProjectID, id, TextEquals)
+}
+
+func prepareGroupGrantsQuery() (sq.SelectBuilder, func(*sql.Rows) (*GroupGrants, error)) {
+	return sq.Select(
+			GroupGrantColumnID.identifier(),
+			GroupGrantColumnGroupID.identifier(),
+			GroupGrantColumnCreationDate.identifier(),
+			GroupGrantColumnChangeDate.identifier(),
+			GroupGrantColumnSequence.identifier(),
+			GroupGrantColumnResourceOwner.identifier(),
+			GroupGrantColumnProjectID.identifier(),
+			GroupGrantColumnProjectGrantID.identifier(),
+			GroupGrantColumnRoles.identifier(),
+			countColumn.identifier(),
+		).From(groupGrantTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*GroupGrants, error) {
+			grants := make([]*GroupGrant, 0)
+			var count uint64
+			for rows.Next() {
+				grant := new(GroupGrant)
+				err := rows.Scan(
+					&grant.ID,
+					&grant.GroupID,
+					&grant.CreationDate,
+					&grant.ChangeDate,
+					&grant.Sequence,
+					&grant.ResourceOwner,
+					&grant.ProjectID,
+					&grant.ProjectGrantID,
+					&grant.Roles,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				grants = append(grants, grant)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-oP3ma", "Errors.Query.CloseRows")
+			}
+
+			return &GroupGrants{
+				Grants: grants,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
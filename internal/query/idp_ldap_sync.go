@@ -0,0 +1,166 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// LDAPSync is the synchronization of an instance LDAP provider.
+type LDAPSync struct {
+	IDPID        string
+	CreationDate time.Time
+	ChangeDate   time.Time
+	// Sequence is the sequence of the event which set the current configuration
+	Sequence          uint64
+	Interval          time.Duration
+	Filter            string
+	PageSize          uint32
+	OrganizationID    string
+	DeactivateMissing bool
+	GroupAttribute    string
+	GroupGrants       []*LDAPSyncGroupGrant
+}
+
+type LDAPSyncGroupGrant struct {
+	Group                string
+	ProjectID            string
+	ProjectResourceOwner string
+	RoleKeys             []string
+}
+
+// LDAPSyncReport is the result of a sync run of an instance LDAP provider.
+type LDAPSyncReport struct {
+	IDPID        string
+	CreationDate time.Time
+	instance.LDAPSyncReport
+}
+
+// LDAPSyncByIDPID returns the synchronization of the instance LDAP provider.
+// The synchronization is read from the events as it's only read by the sync job and the admin API.
+func (q *Queries) LDAPSyncByIDPID(ctx context.Context, idpID string) (_ *LDAPSync, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if idpID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-eiG5a", "Errors.IDMissing")
+	}
+	readModel := newLDAPSyncReadModel(authz.GetInstance(ctx).InstanceID(), idpID)
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	if readModel.sync == nil {
+		return nil, zerrors.ThrowNotFound(nil, "QUERY-ooT6e", "Errors.IDPConfig.NotExisting")
+	}
+	return readModel.sync, nil
+}
+
+// LDAPSyncReports returns the latest reports of the synchronization of the instance LDAP provider, the newest first.
+func (q *Queries) LDAPSyncReports(ctx context.Context, idpID string, limit uint64) (_ []*LDAPSyncReport, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if idpID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-Ul4ie", "Errors.IDMissing")
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	events, err := q.eventstore.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(instanceID).
+		OrderDesc().
+		Limit(limit).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(instanceID).
+		EventTypes(instance.LDAPSyncReportedEventType).
+		EventData(map[string]interface{}{"id": idpID}).
+		Builder(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]*LDAPSyncReport, 0, len(events))
+	for _, event := range events {
+		e, ok := event.(*instance.LDAPSyncReportedEvent)
+		if !ok {
+			continue
+		}
+		reports = append(reports, &LDAPSyncReport{
+			IDPID:          e.ID,
+			CreationDate:   e.CreatedAt(),
+			LDAPSyncReport: e.LDAPSyncReport,
+		})
+	}
+	return reports, nil
+}
+
+type ldapSyncReadModel struct {
+	eventstore.ReadModel
+
+	idpID string
+	sync  *LDAPSync
+}
+
+func newLDAPSyncReadModel(instanceID, idpID string) *ldapSyncReadModel {
+	return &ldapSyncReadModel{
+		ReadModel: eventstore.ReadModel{
+			AggregateID:   instanceID,
+			ResourceOwner: instanceID,
+		},
+		idpID: idpID,
+	}
+}
+
+func (rm *ldapSyncReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *instance.LDAPSyncSetEvent:
+			creationDate := e.CreatedAt()
+			if rm.sync != nil {
+				creationDate = rm.sync.CreationDate
+			}
+			rm.sync = &LDAPSync{
+				IDPID:             e.ID,
+				CreationDate:      creationDate,
+				ChangeDate:        e.CreatedAt(),
+				Sequence:          e.Sequence(),
+				Interval:          e.Interval,
+				Filter:            e.Filter,
+				PageSize:          e.PageSize,
+				OrganizationID:    e.OrganizationID,
+				DeactivateMissing: e.DeactivateMissing,
+				GroupAttribute:    e.GroupAttribute,
+				GroupGrants:       make([]*LDAPSyncGroupGrant, len(e.GroupGrants)),
+			}
+			for i, grant := range e.GroupGrants {
+				rm.sync.GroupGrants[i] = &LDAPSyncGroupGrant{
+					Group:                grant.Group,
+					ProjectID:            grant.ProjectID,
+					ProjectResourceOwner: grant.ProjectResourceOwner,
+					RoleKeys:             grant.RoleKeys,
+				}
+			}
+		case *instance.LDAPSyncRemovedEvent:
+			rm.sync = nil
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *ldapSyncReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(rm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(rm.AggregateID).
+		EventTypes(
+			instance.LDAPSyncSetEventType,
+			instance.LDAPSyncRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": rm.idpID}).
+		Builder()
+}
@@ -0,0 +1,100 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+func ldapSyncTestBaseEvent(seq uint64) eventstore.BaseEvent {
+	created := time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC)
+	return eventstore.BaseEvent{
+		Agg: &eventstore.Aggregate{
+			ID:            "instance",
+			Type:          instance.AggregateType,
+			ResourceOwner: "instance",
+			InstanceID:    "instance",
+		},
+		Seq:      seq,
+		Creation: created.Add(time.Duration(seq) * time.Hour),
+	}
+}
+
+func Test_ldapSyncReadModel_Reduce(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []eventstore.Event
+		want   *LDAPSync
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name: "changed",
+			events: []eventstore.Event{
+				&instance.LDAPSyncSetEvent{
+					BaseEvent:      ldapSyncTestBaseEvent(1),
+					ID:             "idp1",
+					Interval:       time.Hour,
+					OrganizationID: "org1",
+				},
+				&instance.LDAPSyncSetEvent{
+					BaseEvent:         ldapSyncTestBaseEvent(2),
+					ID:                "idp1",
+					Interval:          2 * time.Hour,
+					Filter:            "(department=sales)",
+					PageSize:          100,
+					OrganizationID:    "org2",
+					DeactivateMissing: true,
+					GroupAttribute:    "memberOf",
+					GroupGrants: []*instance.LDAPSyncGroupGrant{
+						{Group: "cn=sales", ProjectID: "project1", ProjectResourceOwner: "org3", RoleKeys: []string{"role1"}},
+					},
+				},
+			},
+			want: &LDAPSync{
+				IDPID:             "idp1",
+				CreationDate:      ldapSyncTestBaseEvent(1).Creation,
+				ChangeDate:        ldapSyncTestBaseEvent(2).Creation,
+				Sequence:          2,
+				Interval:          2 * time.Hour,
+				Filter:            "(department=sales)",
+				PageSize:          100,
+				OrganizationID:    "org2",
+				DeactivateMissing: true,
+				GroupAttribute:    "memberOf",
+				GroupGrants: []*LDAPSyncGroupGrant{
+					{Group: "cn=sales", ProjectID: "project1", ProjectResourceOwner: "org3", RoleKeys: []string{"role1"}},
+				},
+			},
+		},
+		{
+			name: "removed",
+			events: []eventstore.Event{
+				&instance.LDAPSyncSetEvent{
+					BaseEvent:      ldapSyncTestBaseEvent(1),
+					ID:             "idp1",
+					Interval:       time.Hour,
+					OrganizationID: "org1",
+				},
+				&instance.LDAPSyncRemovedEvent{
+					BaseEvent: ldapSyncTestBaseEvent(2),
+					ID:        "idp1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rm := newLDAPSyncReadModel("instance", "idp1")
+			rm.AppendEvents(tt.events...)
+			require.NoError(t, rm.Reduce())
+			assert.Equal(t, tt.want, rm.sync)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package query
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/idp/mapping"
 	"github.com/zitadel/zitadel/internal/query/projection"
 	"github.com/zitadel/zitadel/internal/repository/idp"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -43,6 +45,11 @@ type IDPTemplate struct {
 	*GitHubEnterpriseIDPTemplate
 	*GitLabIDPTemplate
 	*GitLabSelfHostedIDPTemplate
+	*OktaIDPTemplate
+	*KeycloakIDPTemplate
+	*ADFSIDPTemplate
+	*BitbucketIDPTemplate
+	*DiscordIDPTemplate
 	*GoogleIDPTemplate
 	*LDAPIDPTemplate
 	*AppleIDPTemplate
@@ -64,6 +71,7 @@ type OAuthIDPTemplate struct {
 	Scopes                database.TextArray[string]
 	IDAttribute           string
 	UsePKCE               bool
+	ClaimMapping          *mapping.Mapping
 }
 
 type OIDCIDPTemplate struct {
@@ -74,6 +82,7 @@ type OIDCIDPTemplate struct {
 	Scopes           database.TextArray[string]
 	IsIDTokenMapping bool
 	UsePKCE          bool
+	ClaimMapping     *mapping.Mapping
 }
 
 type JWTIDPTemplate struct {
@@ -125,6 +134,44 @@ type GitLabSelfHostedIDPTemplate struct {
 	Scopes       database.TextArray[string]
 }
 
+type OktaIDPTemplate struct {
+	IDPID        string
+	Issuer       string
+	ClientID     string
+	ClientSecret *crypto.CryptoValue
+	Scopes       database.TextArray[string]
+}
+
+type KeycloakIDPTemplate struct {
+	IDPID        string
+	Issuer       string
+	ClientID     string
+	ClientSecret *crypto.CryptoValue
+	Scopes       database.TextArray[string]
+}
+
+type ADFSIDPTemplate struct {
+	IDPID        string
+	Issuer       string
+	ClientID     string
+	ClientSecret *crypto.CryptoValue
+	Scopes       database.TextArray[string]
+}
+
+type BitbucketIDPTemplate struct {
+	IDPID        string
+	ClientID     string
+	ClientSecret *crypto.CryptoValue
+	Scopes       database.TextArray[string]
+}
+
+type DiscordIDPTemplate struct {
+	IDPID        string
+	ClientID     string
+	ClientSecret *crypto.CryptoValue
+	Scopes       database.TextArray[string]
+}
+
 type GoogleIDPTemplate struct {
 	IDPID        string
 	ClientID     string
@@ -284,6 +331,10 @@ var (
 		name:  projection.OAuthUsePKCECol,
 		table: oauthIdpTemplateTable,
 	}
+	OAuthClaimMappingCol = Column{
+		name:  projection.OAuthClaimMappingCol,
+		table: oauthIdpTemplateTable,
+	}
 )
 
 var (
@@ -323,6 +374,10 @@ var (
 		name:  projection.OIDCUsePKCECol,
 		table: oidcIdpTemplateTable,
 	}
+	OIDCClaimMappingCol = Column{
+		name:  projection.OIDCClaimMappingCol,
+		table: oidcIdpTemplateTable,
+	}
 )
 
 var (
@@ -515,6 +570,153 @@ var (
 	}
 )
 
+var (
+	oktaIdpTemplateTable = table{
+		name:          projection.IDPTemplateOktaTable,
+		instanceIDCol: projection.OktaInstanceIDCol,
+	}
+	OktaIDCol = Column{
+		name:  projection.OktaIDCol,
+		table: oktaIdpTemplateTable,
+	}
+	OktaInstanceIDCol = Column{
+		name:  projection.OktaInstanceIDCol,
+		table: oktaIdpTemplateTable,
+	}
+	OktaIssuerCol = Column{
+		name:  projection.OktaIssuerCol,
+		table: oktaIdpTemplateTable,
+	}
+	OktaClientIDCol = Column{
+		name:  projection.OktaClientIDCol,
+		table: oktaIdpTemplateTable,
+	}
+	OktaClientSecretCol = Column{
+		name:  projection.OktaClientSecretCol,
+		table: oktaIdpTemplateTable,
+	}
+	OktaScopesCol = Column{
+		name:  projection.OktaScopesCol,
+		table: oktaIdpTemplateTable,
+	}
+)
+
+var (
+	keycloakIdpTemplateTable = table{
+		name:          projection.IDPTemplateKeycloakTable,
+		instanceIDCol: projection.KeycloakInstanceIDCol,
+	}
+	KeycloakIDCol = Column{
+		name:  projection.KeycloakIDCol,
+		table: keycloakIdpTemplateTable,
+	}
+	KeycloakInstanceIDCol = Column{
+		name:  projection.KeycloakInstanceIDCol,
+		table: keycloakIdpTemplateTable,
+	}
+	KeycloakIssuerCol = Column{
+		name:  projection.KeycloakIssuerCol,
+		table: keycloakIdpTemplateTable,
+	}
+	KeycloakClientIDCol = Column{
+		name:  projection.KeycloakClientIDCol,
+		table: keycloakIdpTemplateTable,
+	}
+	KeycloakClientSecretCol = Column{
+		name:  projection.KeycloakClientSecretCol,
+		table: keycloakIdpTemplateTable,
+	}
+	KeycloakScopesCol = Column{
+		name:  projection.KeycloakScopesCol,
+		table: keycloakIdpTemplateTable,
+	}
+)
+
+var (
+	adfsIdpTemplateTable = table{
+		name:          projection.IDPTemplateADFSTable,
+		instanceIDCol: projection.ADFSInstanceIDCol,
+	}
+	ADFSIDCol = Column{
+		name:  projection.ADFSIDCol,
+		table: adfsIdpTemplateTable,
+	}
+	ADFSInstanceIDCol = Column{
+		name:  projection.ADFSInstanceIDCol,
+		table: adfsIdpTemplateTable,
+	}
+	ADFSIssuerCol = Column{
+		name:  projection.ADFSIssuerCol,
+		table: adfsIdpTemplateTable,
+	}
+	ADFSClientIDCol = Column{
+		name:  projection.ADFSClientIDCol,
+		table: adfsIdpTemplateTable,
+	}
+	ADFSClientSecretCol = Column{
+		name:  projection.ADFSClientSecretCol,
+		table: adfsIdpTemplateTable,
+	}
+	ADFSScopesCol = Column{
+		name:  projection.ADFSScopesCol,
+		table: adfsIdpTemplateTable,
+	}
+)
+
+var (
+	bitbucketIdpTemplateTable = table{
+		name:          projection.IDPTemplateBitbucketTable,
+		instanceIDCol: projection.BitbucketInstanceIDCol,
+	}
+	BitbucketIDCol = Column{
+		name:  projection.BitbucketIDCol,
+		table: bitbucketIdpTemplateTable,
+	}
+	BitbucketInstanceIDCol = Column{
+		name:  projection.BitbucketInstanceIDCol,
+		table: bitbucketIdpTemplateTable,
+	}
+	BitbucketClientIDCol = Column{
+		name:  projection.BitbucketClientIDCol,
+		table: bitbucketIdpTemplateTable,
+	}
+	BitbucketClientSecretCol = Column{
+		name:  projection.BitbucketClientSecretCol,
+		table: bitbucketIdpTemplateTable,
+	}
+	BitbucketScopesCol = Column{
+		name:  projection.BitbucketScopesCol,
+		table: bitbucketIdpTemplateTable,
+	}
+)
+
+var (
+	discordIdpTemplateTable = table{
+		name:          projection.IDPTemplateDiscordTable,
+		instanceIDCol: projection.DiscordInstanceIDCol,
+	}
+	DiscordIDCol = Column{
+		name:  projection.DiscordIDCol,
+		table: discordIdpTemplateTable,
+	}
+	DiscordInstanceIDCol = Column{
+		name:  projection.DiscordInstanceIDCol,
+		table: discordIdpTemplateTable,
+	}
+	DiscordClientIDCol = Column{
+		name:  projection.DiscordClientIDCol,
+		table: discordIdpTemplateTable,
+	}
+	DiscordClientSecretCol = Column{
+		name:  projection.DiscordClientSecretCol,
+		table: discordIdpTemplateTable,
+	}
+	DiscordScopesCol = Column{
+		name:  projection.DiscordScopesCol,
+		table: discordIdpTemplateTable,
+	}
+)
+
 var (
 	googleIdpTemplateTable = table{
 		name:          projection.IDPTemplateGoogleTable,
@@ -894,6 +1096,7 @@ func prepareIDPTemplateByIDQuery() (sq.SelectBuilder, func(*sql.Row) (*IDPTempla
 			OAuthScopesCol.identifier(),
 			OAuthIDAttributeCol.identifier(),
 			OAuthUsePKCECol.identifier(),
+			OAuthClaimMappingCol.identifier(),
 			// oidc
 			OIDCIDCol.identifier(),
 			OIDCIssuerCol.identifier(),
@@ -902,6 +1105,7 @@ func prepareIDPTemplateByIDQuery() (sq.SelectBuilder, func(*sql.Row) (*IDPTempla
 			OIDCScopesCol.identifier(),
 			OIDCIDTokenMappingCol.identifier(),
 			OIDCUsePKCECol.identifier(),
+			OIDCClaimMappingCol.identifier(),
 			// jwt
 			JWTIDCol.identifier(),
 			JWTIssuerCol.identifier(),
@@ -939,6 +1143,34 @@ func prepareIDPTemplateByIDQuery() (sq.SelectBuilder, func(*sql.Row) (*IDPTempla
 			GitLabSelfHostedClientIDCol.identifier(),
 			GitLabSelfHostedClientSecretCol.identifier(),
 			GitLabSelfHostedScopesCol.identifier(),
+			// okta
+			OktaIDCol.identifier(),
+			OktaIssuerCol.identifier(),
+			OktaClientIDCol.identifier(),
+			OktaClientSecretCol.identifier(),
+			OktaScopesCol.identifier(),
+			// keycloak
+			KeycloakIDCol.identifier(),
+			KeycloakIssuerCol.identifier(),
+			KeycloakClientIDCol.identifier(),
+			KeycloakClientSecretCol.identifier(),
+			KeycloakScopesCol.identifier(),
+			// adfs
+			ADFSIDCol.identifier(),
+			ADFSIssuerCol.identifier(),
+			ADFSClientIDCol.identifier(),
+			ADFSClientSecretCol.identifier(),
+			ADFSScopesCol.identifier(),
+			// bitbucket
+			BitbucketIDCol.identifier(),
+			BitbucketClientIDCol.identifier(),
+			BitbucketClientSecretCol.identifier(),
+			BitbucketScopesCol.identifier(),
+			// discord
+			DiscordIDCol.identifier(),
+			DiscordClientIDCol.identifier(),
+			DiscordClientSecretCol.identifier(),
+			DiscordScopesCol.identifier(),
 			// google
 			GoogleIDCol.identifier(),
 			GoogleClientIDCol.identifier(),
@@ -995,6 +1227,11 @@ func prepareIDPTemplateByIDQuery() (sq.SelectBuilder, func(*sql.Row) (*IDPTempla
 			LeftJoin(join(GitHubEnterpriseIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(GitLabIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(GitLabSelfHostedIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(OktaIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(KeycloakIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(ADFSIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(BitbucketIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(DiscordIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(GoogleIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(SAMLIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(LDAPIDCol, IDPTemplateIDCol)).
@@ -1014,6 +1251,7 @@ func prepareIDPTemplateByIDQuery() (sq.SelectBuilder, func(*sql.Row) (*IDPTempla
 			oauthScopes := database.TextArray[string]{}
 			oauthIDAttribute := sql.NullString{}
 			oauthUserPKCE := sql.NullBool{}
+			var oauthClaimMapping []byte
 
 			oidcID := sql.NullString{}
 			oidcIssuer := sql.NullString{}
@@ -1022,6 +1260,7 @@ func prepareIDPTemplateByIDQuery() (sq.SelectBuilder, func(*sql.Row) (*IDPTempla
 			oidcScopes := database.TextArray[string]{}
 			oidcIDTokenMapping := sql.NullBool{}
 			oidcUserPKCE := sql.NullBool{}
+			var oidcClaimMapping []byte
 
 			jwtID := sql.NullString{}
 			jwtIssuer := sql.NullString{}
@@ -1060,6 +1299,34 @@ func prepareIDPTemplateByIDQuery() (sq.SelectBuilder, func(*sql.Row) (*IDPTempla
 			gitlabSelfHostedClientSecret := new(crypto.CryptoValue)
 			gitlabSelfHostedScopes := database.TextArray[string]{}
 
+			oktaID := sql.NullString{}
+			oktaIssuer := sql.NullString{}
+			oktaClientID := sql.NullString{}
+			oktaClientSecret := new(crypto.CryptoValue)
+			oktaScopes := database.TextArray[string]{}
+
+			keycloakID := sql.NullString{}
+			keycloakIssuer := sql.NullString{}
+			keycloakClientID := sql.NullString{}
+			keycloakClientSecret := new(crypto.CryptoValue)
+			keycloakScopes := database.TextArray[string]{}
+
+			adfsID := sql.NullString{}
+			adfsIssuer := sql.NullString{}
+			adfsClientID := sql.NullString{}
+			adfsClientSecret := new(crypto.CryptoValue)
+			adfsScopes := database.TextArray[string]{}
+
+			bitbucketID := sql.NullString{}
+			bitbucketClientID := sql.NullString{}
+			bitbucketClientSecret := new(crypto.CryptoValue)
+			bitbucketScopes := database.TextArray[string]{}
+
+			discordID := sql.NullString{}
+			discordClientID := sql.NullString{}
+			discordClientSecret := new(crypto.CryptoValue)
+			discordScopes := database.TextArray[string]{}
+
 			googleID := sql.NullString{}
 			googleClientID := sql.NullString{}
 			googleClientSecret := new(crypto.CryptoValue)
@@ -1132,6 +1399,7 @@ func prepareIDPTemplateByIDQuery() (sq.SelectBuilder, func(*sql.Row) (*IDPTempla
 				&oauthScopes,
 				&oauthIDAttribute,
 				&oauthUserPKCE,
+				&oauthClaimMapping,
 				// oidc
 				&oidcID,
 				&oidcIssuer,
@@ -1140,6 +1408,7 @@ func prepareIDPTemplateByIDQuery() (sq.SelectBuilder, func(*sql.Row) (*IDPTempla
 				&oidcScopes,
 				&oidcIDTokenMapping,
 				&oidcUserPKCE,
+				&oidcClaimMapping,
 				// jwt
 				&jwtID,
 				&jwtIssuer,
@@ -1177,6 +1446,34 @@ func prepareIDPTemplateByIDQuery() (sq.SelectBuilder, func(*sql.Row) (*IDPTempla
 				&gitlabSelfHostedClientID,
 				&gitlabSelfHostedClientSecret,
 				&gitlabSelfHostedScopes,
+				// okta
+				&oktaID,
+				&oktaIssuer,
+				&oktaClientID,
+				&oktaClientSecret,
+				&oktaScopes,
+				// keycloak
+				&keycloakID,
+				&keycloakIssuer,
+				&keycloakClientID,
+				&keycloakClientSecret,
+				&keycloakScopes,
+				// adfs
+				&adfsID,
+				&adfsIssuer,
+				&adfsClientID,
+				&adfsClientSecret,
+				&adfsScopes,
+				// bitbucket
+				&bitbucketID,
+				&bitbucketClientID,
+				&bitbucketClientSecret,
+				&bitbucketScopes,
+				// discord
+				&discordID,
+				&discordClientID,
+				&discordClientSecret,
+				&discordScopes,
 				// google
 				&googleID,
 				&googleClientID,
@@ -1235,6 +1532,10 @@ func prepareIDPTemplateByIDQuery() (sq.SelectBuilder, func(*sql.Row) (*IDPTempla
 			idpTemplate.Name = name.String
 
 			if oauthID.Valid {
+				claimMapping, err := claimMappingFromJSON(oauthClaimMapping)
+				if err != nil {
+					return nil, err
+				}
 				idpTemplate.OAuthIDPTemplate = &OAuthIDPTemplate{
 					IDPID:                 oauthID.String,
 					ClientID:              oauthClientID.String,
@@ -1245,9 +1546,14 @@ func prepareIDPTemplateByIDQuery() (sq.SelectBuilder, func(*sql.Row) (*IDPTempla
 					Scopes:                oauthScopes,
 					IDAttribute:           oauthIDAttribute.String,
 					UsePKCE:               oauthUserPKCE.Bool,
+					ClaimMapping:          claimMapping,
 				}
 			}
 			if oidcID.Valid {
+				claimMapping, err := claimMappingFromJSON(oidcClaimMapping)
+				if err != nil {
+					return nil, err
+				}
 				idpTemplate.OIDCIDPTemplate = &OIDCIDPTemplate{
 					IDPID:            oidcID.String,
 					ClientID:         oidcClientID.String,
@@ -1256,6 +1562,7 @@ func prepareIDPTemplateByIDQuery() (sq.SelectBuilder, func(*sql.Row) (*IDPTempla
 					Scopes:           oidcScopes,
 					IsIDTokenMapping: oidcIDTokenMapping.Bool,
 					UsePKCE:          oidcUserPKCE.Bool,
+					ClaimMapping:     claimMapping,
 				}
 			}
 			if jwtID.Valid {
@@ -1313,6 +1620,49 @@ func prepareIDPTemplateByIDQuery() (sq.SelectBuilder, func(*sql.Row) (*IDPTempla
 					Scopes:       gitlabSelfHostedScopes,
 				}
 			}
+			if oktaID.Valid {
+				idpTemplate.OktaIDPTemplate = &OktaIDPTemplate{
+					IDPID:        oktaID.String,
+					Issuer:       oktaIssuer.String,
+					ClientID:     oktaClientID.String,
+					ClientSecret: oktaClientSecret,
+					Scopes:       oktaScopes,
+				}
+			}
+			if keycloakID.Valid {
+				idpTemplate.KeycloakIDPTemplate = &KeycloakIDPTemplate{
+					IDPID:        keycloakID.String,
+					Issuer:       keycloakIssuer.String,
+					ClientID:     keycloakClientID.String,
+					ClientSecret: keycloakClientSecret,
+					Scopes:       keycloakScopes,
+				}
+			}
+			if adfsID.Valid {
+				idpTemplate.ADFSIDPTemplate = &ADFSIDPTemplate{
+					IDPID:        adfsID.String,
+					Issuer:       adfsIssuer.String,
+					ClientID:     adfsClientID.String,
+					ClientSecret: adfsClientSecret,
+					Scopes:       adfsScopes,
+				}
+			}
+			if bitbucketID.Valid {
+				idpTemplate.BitbucketIDPTemplate = &BitbucketIDPTemplate{
+					IDPID:        bitbucketID.String,
+					ClientID:     bitbucketClientID.String,
+					ClientSecret: bitbucketClientSecret,
+					Scopes:       bitbucketScopes,
+				}
+			}
+			if discordID.Valid {
+				idpTemplate.DiscordIDPTemplate = &DiscordIDPTemplate{
+					IDPID:        discordID.String,
+					ClientID:     discordClientID.String,
+					ClientSecret: discordClientSecret,
+					Scopes:       discordScopes,
+				}
+			}
 			if googleID.Valid {
 				idpTemplate.GoogleIDPTemplate = &GoogleIDPTemplate{
 					IDPID:        googleID.String,
@@ -1406,6 +1756,7 @@ func prepareIDPTemplatesQuery() (sq.SelectBuilder, func(*sql.Rows) (*IDPTemplate
 			OAuthScopesCol.identifier(),
 			OAuthIDAttributeCol.identifier(),
 			OAuthUsePKCECol.identifier(),
+			OAuthClaimMappingCol.identifier(),
 			// oidc
 			OIDCIDCol.identifier(),
 			OIDCIssuerCol.identifier(),
@@ -1414,6 +1765,7 @@ func prepareIDPTemplatesQuery() (sq.SelectBuilder, func(*sql.Rows) (*IDPTemplate
 			OIDCScopesCol.identifier(),
 			OIDCIDTokenMappingCol.identifier(),
 			OIDCUsePKCECol.identifier(),
+			OIDCClaimMappingCol.identifier(),
 			// jwt
 			JWTIDCol.identifier(),
 			JWTIssuerCol.identifier(),
@@ -1451,6 +1803,34 @@ func prepareIDPTemplatesQuery() (sq.SelectBuilder, func(*sql.Rows) (*IDPTemplate
 			GitLabSelfHostedClientIDCol.identifier(),
 			GitLabSelfHostedClientSecretCol.identifier(),
 			GitLabSelfHostedScopesCol.identifier(),
+			// okta
+			OktaIDCol.identifier(),
+			OktaIssuerCol.identifier(),
+			OktaClientIDCol.identifier(),
+			OktaClientSecretCol.identifier(),
+			OktaScopesCol.identifier(),
+			// keycloak
+			KeycloakIDCol.identifier(),
+			KeycloakIssuerCol.identifier(),
+			KeycloakClientIDCol.identifier(),
+			KeycloakClientSecretCol.identifier(),
+			KeycloakScopesCol.identifier(),
+			// adfs
+			ADFSIDCol.identifier(),
+			ADFSIssuerCol.identifier(),
+			ADFSClientIDCol.identifier(),
+			ADFSClientSecretCol.identifier(),
+			ADFSScopesCol.identifier(),
+			// bitbucket
+			BitbucketIDCol.identifier(),
+			BitbucketClientIDCol.identifier(),
+			BitbucketClientSecretCol.identifier(),
+			BitbucketScopesCol.identifier(),
+			// discord
+			DiscordIDCol.identifier(),
+			DiscordClientIDCol.identifier(),
+			DiscordClientSecretCol.identifier(),
+			DiscordScopesCol.identifier(),
 			// google
 			GoogleIDCol.identifier(),
 			GoogleClientIDCol.identifier(),
@@ -1509,6 +1889,11 @@ func prepareIDPTemplatesQuery() (sq.SelectBuilder, func(*sql.Rows) (*IDPTemplate
 			LeftJoin(join(GitHubEnterpriseIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(GitLabIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(GitLabSelfHostedIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(OktaIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(KeycloakIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(ADFSIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(BitbucketIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(DiscordIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(GoogleIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(SAMLIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(LDAPIDCol, IDPTemplateIDCol)).
@@ -1531,6 +1916,7 @@ func prepareIDPTemplatesQuery() (sq.SelectBuilder, func(*sql.Rows) (*IDPTemplate
 				oauthScopes := database.TextArray[string]{}
 				oauthIDAttribute := sql.NullString{}
 				oauthUserPKCE := sql.NullBool{}
+				var oauthClaimMapping []byte
 
 				oidcID := sql.NullString{}
 				oidcIssuer := sql.NullString{}
@@ -1539,6 +1925,7 @@ func prepareIDPTemplatesQuery() (sq.SelectBuilder, func(*sql.Rows) (*IDPTemplate
 				oidcScopes := database.TextArray[string]{}
 				oidcIDTokenMapping := sql.NullBool{}
 				oidcUserPKCE := sql.NullBool{}
+				var oidcClaimMapping []byte
 
 				jwtID := sql.NullString{}
 				jwtIssuer := sql.NullString{}
@@ -1577,6 +1964,34 @@ func prepareIDPTemplatesQuery() (sq.SelectBuilder, func(*sql.Rows) (*IDPTemplate
 				gitlabSelfHostedClientSecret := new(crypto.CryptoValue)
 				gitlabSelfHostedScopes := database.TextArray[string]{}
 
+				oktaID := sql.NullString{}
+				oktaIssuer := sql.NullString{}
+				oktaClientID := sql.NullString{}
+				oktaClientSecret := new(crypto.CryptoValue)
+				oktaScopes := database.TextArray[string]{}
+
+				keycloakID := sql.NullString{}
+				keycloakIssuer := sql.NullString{}
+				keycloakClientID := sql.NullString{}
+				keycloakClientSecret := new(crypto.CryptoValue)
+				keycloakScopes := database.TextArray[string]{}
+
+				adfsID := sql.NullString{}
+				adfsIssuer := sql.NullString{}
+				adfsClientID := sql.NullString{}
+				adfsClientSecret := new(crypto.CryptoValue)
+				adfsScopes := database.TextArray[string]{}
+
+				bitbucketID := sql.NullString{}
+				bitbucketClientID := sql.NullString{}
+				bitbucketClientSecret := new(crypto.CryptoValue)
+				bitbucketScopes := database.TextArray[string]{}
+
+				discordID := sql.NullString{}
+				discordClientID := sql.NullString{}
+				discordClientSecret := new(crypto.CryptoValue)
+				discordScopes := database.TextArray[string]{}
+
 				googleID := sql.NullString{}
 				googleClientID := sql.NullString{}
 				googleClientSecret := new(crypto.CryptoValue)
@@ -1649,6 +2064,7 @@ func prepareIDPTemplatesQuery() (sq.SelectBuilder, func(*sql.Rows) (*IDPTemplate
 					&oauthScopes,
 					&oauthIDAttribute,
 					&oauthUserPKCE,
+					&oauthClaimMapping,
 					// oidc
 					&oidcID,
 					&oidcIssuer,
@@ -1657,6 +2073,7 @@ func prepareIDPTemplatesQuery() (sq.SelectBuilder, func(*sql.Rows) (*IDPTemplate
 					&oidcScopes,
 					&oidcIDTokenMapping,
 					&oidcUserPKCE,
+					&oidcClaimMapping,
 					// jwt
 					&jwtID,
 					&jwtIssuer,
@@ -1694,6 +2111,34 @@ func prepareIDPTemplatesQuery() (sq.SelectBuilder, func(*sql.Rows) (*IDPTemplate
 					&gitlabSelfHostedClientID,
 					&gitlabSelfHostedClientSecret,
 					&gitlabSelfHostedScopes,
+					// okta
+					&oktaID,
+					&oktaIssuer,
+					&oktaClientID,
+					&oktaClientSecret,
+					&oktaScopes,
+					// keycloak
+					&keycloakID,
+					&keycloakIssuer,
+					&keycloakClientID,
+					&keycloakClientSecret,
+					&keycloakScopes,
+					// adfs
+					&adfsID,
+					&adfsIssuer,
+					&adfsClientID,
+					&adfsClientSecret,
+					&adfsScopes,
+					// bitbucket
+					&bitbucketID,
+					&bitbucketClientID,
+					&bitbucketClientSecret,
+					&bitbucketScopes,
+					// discord
+					&discordID,
+					&discordClientID,
+					&discordClientSecret,
+					&discordScopes,
 					// google
 					&googleID,
 					&googleClientID,
@@ -1751,6 +2196,10 @@ func prepareIDPTemplatesQuery() (sq.SelectBuilder, func(*sql.Rows) (*IDPTemplate
 				idpTemplate.Name = name.String
 
 				if oauthID.Valid {
+					claimMapping, err := claimMappingFromJSON(oauthClaimMapping)
+					if err != nil {
+						return nil, err
+					}
 					idpTemplate.OAuthIDPTemplate = &OAuthIDPTemplate{
 						IDPID:                 oauthID.String,
 						ClientID:              oauthClientID.String,
@@ -1761,9 +2210,14 @@ func prepareIDPTemplatesQuery() (sq.SelectBuilder, func(*sql.Rows) (*IDPTemplate
 						Scopes:                oauthScopes,
 						IDAttribute:           oauthIDAttribute.String,
 						UsePKCE:               oauthUserPKCE.Bool,
+						ClaimMapping:          claimMapping,
 					}
 				}
 				if oidcID.Valid {
+					claimMapping, err := claimMappingFromJSON(oidcClaimMapping)
+					if err != nil {
+						return nil, err
+					}
 					idpTemplate.OIDCIDPTemplate = &OIDCIDPTemplate{
 						IDPID:            oidcID.String,
 						ClientID:         oidcClientID.String,
@@ -1772,6 +2226,7 @@ func prepareIDPTemplatesQuery() (sq.SelectBuilder, func(*sql.Rows) (*IDPTemplate
 						Scopes:           oidcScopes,
 						IsIDTokenMapping: oidcIDTokenMapping.Bool,
 						UsePKCE:          oidcUserPKCE.Bool,
+						ClaimMapping:     claimMapping,
 					}
 				}
 				if jwtID.Valid {
@@ -1829,6 +2284,49 @@ func prepareIDPTemplatesQuery() (sq.SelectBuilder, func(*sql.Rows) (*IDPTemplate
 						Scopes:       gitlabSelfHostedScopes,
 					}
 				}
+				if oktaID.Valid {
+					idpTemplate.OktaIDPTemplate = &OktaIDPTemplate{
+						IDPID:        oktaID.String,
+						Issuer:       oktaIssuer.String,
+						ClientID:     oktaClientID.String,
+						ClientSecret: oktaClientSecret,
+						Scopes:       oktaScopes,
+					}
+				}
+				if keycloakID.Valid {
+					idpTemplate.KeycloakIDPTemplate = &KeycloakIDPTemplate{
+						IDPID:        keycloakID.String,
+						Issuer:       keycloakIssuer.String,
+						ClientID:     keycloakClientID.String,
+						ClientSecret: keycloakClientSecret,
+						Scopes:       keycloakScopes,
+					}
+				}
+				if adfsID.Valid {
+					idpTemplate.ADFSIDPTemplate = &ADFSIDPTemplate{
+						IDPID:        adfsID.String,
+						Issuer:       adfsIssuer.String,
+						ClientID:     adfsClientID.String,
+						ClientSecret: adfsClientSecret,
+						Scopes:       adfsScopes,
+					}
+				}
+				if bitbucketID.Valid {
+					idpTemplate.BitbucketIDPTemplate = &BitbucketIDPTemplate{
+						IDPID:        bitbucketID.String,
+						ClientID:     bitbucketClientID.String,
+						ClientSecret: bitbucketClientSecret,
+						Scopes:       bitbucketScopes,
+					}
+				}
+				if discordID.Valid {
+					idpTemplate.DiscordIDPTemplate = &DiscordIDPTemplate{
+						IDPID:        discordID.String,
+						ClientID:     discordClientID.String,
+						ClientSecret: discordClientSecret,
+						Scopes:       discordScopes,
+					}
+				}
 				if googleID.Valid {
 					idpTemplate.GoogleIDPTemplate = &GoogleIDPTemplate{
 						IDPID:        googleID.String,
@@ -1905,3 +2403,14 @@ func prepareIDPTemplatesQuery() (sq.SelectBuilder, func(*sql.Rows) (*IDPTemplate
 			}, nil
 		}
 }
+
+func claimMappingFromJSON(data []byte) (*mapping.Mapping, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	claimMapping := new(mapping.Mapping)
+	if err := json.Unmarshal(data, claimMapping); err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-ahT2e", "Errors.Internal")
+	}
+	return claimMapping, nil
+}
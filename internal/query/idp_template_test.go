@@ -40,6 +40,7 @@ var (
 		` projections.idp_templates6_oauth2.scopes,` +
 		` projections.idp_templates6_oauth2.id_attribute,` +
 		` projections.idp_templates6_oauth2.use_pkce,` +
+		` projections.idp_templates6_oauth2.claim_mapping,` +
 		// oidc
 		` projections.idp_templates6_oidc.idp_id,` +
 		` projections.idp_templates6_oidc.issuer,` +
@@ -48,6 +49,7 @@ var (
 		` projections.idp_templates6_oidc.scopes,` +
 		` projections.idp_templates6_oidc.id_token_mapping,` +
 		` projections.idp_templates6_oidc.use_pkce,` +
+		` projections.idp_templates6_oidc.claim_mapping,` +
 		// jwt
 		` projections.idp_templates6_jwt.idp_id,` +
 		` projections.idp_templates6_jwt.issuer,` +
@@ -85,6 +87,34 @@ var (
 		` projections.idp_templates6_gitlab_self_hosted.client_id,` +
 		` projections.idp_templates6_gitlab_self_hosted.client_secret,` +
 		` projections.idp_templates6_gitlab_self_hosted.scopes,` +
+		// okta
+		` projections.idp_templates6_okta.idp_id,` +
+		` projections.idp_templates6_okta.issuer,` +
+		` projections.idp_templates6_okta.client_id,` +
+		` projections.idp_templates6_okta.client_secret,` +
+		` projections.idp_templates6_okta.scopes,` +
+		// keycloak
+		` projections.idp_templates6_keycloak.idp_id,` +
+		` projections.idp_templates6_keycloak.issuer,` +
+		` projections.idp_templates6_keycloak.client_id,` +
+		` projections.idp_templates6_keycloak.client_secret,` +
+		` projections.idp_templates6_keycloak.scopes,` +
+		// adfs
+		` projections.idp_templates6_adfs.idp_id,` +
+		` projections.idp_templates6_adfs.issuer,` +
+		` projections.idp_templates6_adfs.client_id,` +
+		` projections.idp_templates6_adfs.client_secret,` +
+		` projections.idp_templates6_adfs.scopes,` +
+		// bitbucket
+		` projections.idp_templates6_bitbucket.idp_id,` +
+		` projections.idp_templates6_bitbucket.client_id,` +
+		` projections.idp_templates6_bitbucket.client_secret,` +
+		` projections.idp_templates6_bitbucket.scopes,` +
+		// discord
+		` projections.idp_templates6_discord.idp_id,` +
+		` projections.idp_templates6_discord.client_id,` +
+		` projections.idp_templates6_discord.client_secret,` +
+		` projections.idp_templates6_discord.scopes,` +
 		// google
 		` projections.idp_templates6_google.idp_id,` +
 		` projections.idp_templates6_google.client_id,` +
@@ -141,6 +171,11 @@ var (
 		` LEFT JOIN projections.idp_templates6_github_enterprise ON projections.idp_templates6.id = projections.idp_templates6_github_enterprise.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_github_enterprise.instance_id` +
 		` LEFT JOIN projections.idp_templates6_gitlab ON projections.idp_templates6.id = projections.idp_templates6_gitlab.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_gitlab.instance_id` +
 		` LEFT JOIN projections.idp_templates6_gitlab_self_hosted ON projections.idp_templates6.id = projections.idp_templates6_gitlab_self_hosted.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_gitlab_self_hosted.instance_id` +
+		` LEFT JOIN projections.idp_templates6_okta ON projections.idp_templates6.id = projections.idp_templates6_okta.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_okta.instance_id` +
+		` LEFT JOIN projections.idp_templates6_keycloak ON projections.idp_templates6.id = projections.idp_templates6_keycloak.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_keycloak.instance_id` +
+		` LEFT JOIN projections.idp_templates6_adfs ON projections.idp_templates6.id = projections.idp_templates6_adfs.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_adfs.instance_id` +
+		` LEFT JOIN projections.idp_templates6_bitbucket ON projections.idp_templates6.id = projections.idp_templates6_bitbucket.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_bitbucket.instance_id` +
+		` LEFT JOIN projections.idp_templates6_discord ON projections.idp_templates6.id = projections.idp_templates6_discord.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_discord.instance_id` +
 		` LEFT JOIN projections.idp_templates6_google ON projections.idp_templates6.id = projections.idp_templates6_google.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_google.instance_id` +
 		` LEFT JOIN projections.idp_templates6_saml ON projections.idp_templates6.id = projections.idp_templates6_saml.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_saml.instance_id` +
 		` LEFT JOIN projections.idp_templates6_ldap2 ON projections.idp_templates6.id = projections.idp_templates6_ldap2.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_ldap2.instance_id` +
@@ -170,6 +205,7 @@ var (
 		"scopes",
 		"id_attribute",
 		"use_pkce",
+		"claim_mapping",
 		// oidc config
 		"id_id",
 		"issuer",
@@ -178,6 +214,7 @@ var (
 		"scopes",
 		"id_token_mapping",
 		"use_pkce",
+		"claim_mapping",
 		// jwt
 		"idp_id",
 		"issuer",
@@ -215,6 +252,34 @@ var (
 		"client_id",
 		"client_secret",
 		"scopes",
+		// okta config
+		"idp_id",
+		"issuer",
+		"client_id",
+		"client_secret",
+		"scopes",
+		// keycloak config
+		"idp_id",
+		"issuer",
+		"client_id",
+		"client_secret",
+		"scopes",
+		// adfs config
+		"idp_id",
+		"issuer",
+		"client_id",
+		"client_secret",
+		"scopes",
+		// bitbucket config
+		"idp_id",
+		"client_id",
+		"client_secret",
+		"scopes",
+		// discord config
+		"idp_id",
+		"client_id",
+		"client_secret",
+		"scopes",
 		// google config
 		"idp_id",
 		"client_id",
@@ -287,6 +352,7 @@ var (
 		` projections.idp_templates6_oauth2.scopes,` +
 		` projections.idp_templates6_oauth2.id_attribute,` +
 		` projections.idp_templates6_oauth2.use_pkce,` +
+		` projections.idp_templates6_oauth2.claim_mapping,` +
 		// oidc
 		` projections.idp_templates6_oidc.idp_id,` +
 		` projections.idp_templates6_oidc.issuer,` +
@@ -295,6 +361,7 @@ var (
 		` projections.idp_templates6_oidc.scopes,` +
 		` projections.idp_templates6_oidc.id_token_mapping,` +
 		` projections.idp_templates6_oidc.use_pkce,` +
+		` projections.idp_templates6_oidc.claim_mapping,` +
 		// jwt
 		` projections.idp_templates6_jwt.idp_id,` +
 		` projections.idp_templates6_jwt.issuer,` +
@@ -332,6 +399,34 @@ var (
 		` projections.idp_templates6_gitlab_self_hosted.client_id,` +
 		` projections.idp_templates6_gitlab_self_hosted.client_secret,` +
 		` projections.idp_templates6_gitlab_self_hosted.scopes,` +
+		// okta
+		` projections.idp_templates6_okta.idp_id,` +
+		` projections.idp_templates6_okta.issuer,` +
+		` projections.idp_templates6_okta.client_id,` +
+		` projections.idp_templates6_okta.client_secret,` +
+		` projections.idp_templates6_okta.scopes,` +
+		// keycloak
+		` projections.idp_templates6_keycloak.idp_id,` +
+		` projections.idp_templates6_keycloak.issuer,` +
+		` projections.idp_templates6_keycloak.client_id,` +
+		` projections.idp_templates6_keycloak.client_secret,` +
+		` projections.idp_templates6_keycloak.scopes,` +
+		// adfs
+		` projections.idp_templates6_adfs.idp_id,` +
+		` projections.idp_templates6_adfs.issuer,` +
+		` projections.idp_templates6_adfs.client_id,` +
+		` projections.idp_templates6_adfs.client_secret,` +
+		` projections.idp_templates6_adfs.scopes,` +
+		// bitbucket
+		` projections.idp_templates6_bitbucket.idp_id,` +
+		` projections.idp_templates6_bitbucket.client_id,` +
+		` projections.idp_templates6_bitbucket.client_secret,` +
+		` projections.idp_templates6_bitbucket.scopes,` +
+		// discord
+		` projections.idp_templates6_discord.idp_id,` +
+		` projections.idp_templates6_discord.client_id,` +
+		` projections.idp_templates6_discord.client_secret,` +
+		` projections.idp_templates6_discord.scopes,` +
 		// google
 		` projections.idp_templates6_google.idp_id,` +
 		` projections.idp_templates6_google.client_id,` +
@@ -389,6 +484,11 @@ var (
 		` LEFT JOIN projections.idp_templates6_github_enterprise ON projections.idp_templates6.id = projections.idp_templates6_github_enterprise.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_github_enterprise.instance_id` +
 		` LEFT JOIN projections.idp_templates6_gitlab ON projections.idp_templates6.id = projections.idp_templates6_gitlab.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_gitlab.instance_id` +
 		` LEFT JOIN projections.idp_templates6_gitlab_self_hosted ON projections.idp_templates6.id = projections.idp_templates6_gitlab_self_hosted.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_gitlab_self_hosted.instance_id` +
+		` LEFT JOIN projections.idp_templates6_okta ON projections.idp_templates6.id = projections.idp_templates6_okta.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_okta.instance_id` +
+		` LEFT JOIN projections.idp_templates6_keycloak ON projections.idp_templates6.id = projections.idp_templates6_keycloak.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_keycloak.instance_id` +
+		` LEFT JOIN projections.idp_templates6_adfs ON projections.idp_templates6.id = projections.idp_templates6_adfs.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_adfs.instance_id` +
+		` LEFT JOIN projections.idp_templates6_bitbucket ON projections.idp_templates6.id = projections.idp_templates6_bitbucket.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_bitbucket.instance_id` +
+		` LEFT JOIN projections.idp_templates6_discord ON projections.idp_templates6.id = projections.idp_templates6_discord.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_discord.instance_id` +
 		` LEFT JOIN projections.idp_templates6_google ON projections.idp_templates6.id = projections.idp_templates6_google.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_google.instance_id` +
 		` LEFT JOIN projections.idp_templates6_saml ON projections.idp_templates6.id = projections.idp_templates6_saml.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_saml.instance_id` +
 		` LEFT JOIN projections.idp_templates6_ldap2 ON projections.idp_templates6.id = projections.idp_templates6_ldap2.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_ldap2.instance_id` +
@@ -418,6 +518,7 @@ var (
 		"scopes",
 		"id_attribute",
 		"use_pkce",
+		"claim_mapping",
 		// oidc config
 		"id_id",
 		"issuer",
@@ -426,6 +527,7 @@ var (
 		"scopes",
 		"id_token_mapping",
 		"use_pkce",
+		"claim_mapping",
 		// jwt
 		"idp_id",
 		"issuer",
@@ -463,6 +565,34 @@ var (
 		"client_id",
 		"client_secret",
 		"scopes",
+		// okta config
+		"idp_id",
+		"issuer",
+		"client_id",
+		"client_secret",
+		"scopes",
+		// keycloak config
+		"idp_id",
+		"issuer",
+		"client_id",
+		"client_secret",
+		"scopes",
+		// adfs config
+		"idp_id",
+		"issuer",
+		"client_id",
+		"client_secret",
+		"scopes",
+		// bitbucket config
+		"idp_id",
+		"client_id",
+		"client_secret",
+		"scopes",
+		// discord config
+		"idp_id",
+		"client_id",
+		"client_secret",
+		"scopes",
 		// google config
 		"idp_id",
 		"client_id",
@@ -575,6 +705,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						database.TextArray[string]{"profile"},
 						"id-attribute",
 						true,
+						nil,
 						// oidc
 						nil,
 						nil,
@@ -583,6 +714,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// jwt
 						nil,
 						nil,
@@ -620,6 +752,34 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// okta
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// keycloak
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// adfs
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// bitbucket
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
 						// google
 						nil,
 						nil,
@@ -730,6 +890,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// oidc
 						"idp-id",
 						"issuer",
@@ -738,6 +899,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						database.TextArray[string]{"profile"},
 						true,
 						true,
+						nil,
 						// jwt
 						nil,
 						nil,
@@ -775,6 +937,34 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// okta
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// keycloak
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// adfs
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// bitbucket
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
 						// google
 						nil,
 						nil,
@@ -883,6 +1073,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// oidc
 						nil,
 						nil,
@@ -891,6 +1082,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// jwt
 						"idp-id",
 						"issuer",
@@ -928,6 +1120,34 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// okta
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// keycloak
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// adfs
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// bitbucket
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
 						// google
 						nil,
 						nil,
@@ -1034,6 +1254,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// oidc
 						nil,
 						nil,
@@ -1042,6 +1263,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// jwt
 						nil,
 						nil,
@@ -1079,6 +1301,34 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// okta
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// keycloak
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// adfs
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// bitbucket
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
 						// google
 						nil,
 						nil,
@@ -1184,6 +1434,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// oidc
 						nil,
 						nil,
@@ -1192,6 +1443,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// jwt
 						nil,
 						nil,
@@ -1229,6 +1481,34 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// okta
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// keycloak
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// adfs
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// bitbucket
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
 						// google
 						nil,
 						nil,
@@ -1334,6 +1614,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// oidc
 						nil,
 						nil,
@@ -1342,6 +1623,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// jwt
 						nil,
 						nil,
@@ -1379,6 +1661,34 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						"client_id",
 						nil,
 						database.TextArray[string]{"profile"},
+						// okta
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// keycloak
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// adfs
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// bitbucket
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
 						// google
 						nil,
 						nil,
@@ -1454,7 +1764,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 			},
 		},
 		{
-			name:    "prepareIDPTemplateByIDQuery google idp",
+			name:    "prepareIDPTemplateByIDQuery okta idp",
 			prepare: prepareIDPTemplateByIDQuery,
 			want: want{
 				sqlExpectations: mockQuery(
@@ -1468,7 +1778,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						uint64(20211109),
 						domain.IDPConfigStateActive,
 						"idp-name",
-						domain.IDPTypeGoogle,
+						domain.IDPTypeOkta,
 						domain.IdentityProviderTypeOrg,
 						true,
 						true,
@@ -1485,6 +1795,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// oidc
 						nil,
 						nil,
@@ -1493,6 +1804,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// jwt
 						nil,
 						nil,
@@ -1530,11 +1842,39 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
-						// google
+						// okta
 						"idp-id",
+						"issuer",
 						"client_id",
 						nil,
 						database.TextArray[string]{"profile"},
+						// keycloak
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// adfs
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// bitbucket
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
+						// google
+						nil,
+						nil,
+						nil,
+						nil,
 						// saml
 						nil,
 						nil,
@@ -1588,15 +1928,16 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 				ID:                "idp-id",
 				State:             domain.IDPStateActive,
 				Name:              "idp-name",
-				Type:              domain.IDPTypeGoogle,
+				Type:              domain.IDPTypeOkta,
 				OwnerType:         domain.IdentityProviderTypeOrg,
 				IsCreationAllowed: true,
 				IsLinkingAllowed:  true,
 				IsAutoCreation:    true,
 				IsAutoUpdate:      true,
 				AutoLinking:       domain.AutoLinkingOptionUsername,
-				GoogleIDPTemplate: &GoogleIDPTemplate{
+				OktaIDPTemplate: &OktaIDPTemplate{
 					IDPID:        "idp-id",
+					Issuer:       "issuer",
 					ClientID:     "client_id",
 					ClientSecret: nil,
 					Scopes:       []string{"profile"},
@@ -1604,7 +1945,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 			},
 		},
 		{
-			name:    "prepareIDPTemplateByIDQuery saml idp",
+			name:    "prepareIDPTemplateByIDQuery keycloak idp",
 			prepare: prepareIDPTemplateByIDQuery,
 			want: want{
 				sqlExpectations: mockQuery(
@@ -1618,7 +1959,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						uint64(20211109),
 						domain.IDPConfigStateActive,
 						"idp-name",
-						domain.IDPTypeSAML,
+						domain.IDPTypeKeycloak,
 						domain.IdentityProviderTypeOrg,
 						true,
 						true,
@@ -1635,6 +1976,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// oidc
 						nil,
 						nil,
@@ -1643,6 +1985,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// jwt
 						nil,
 						nil,
@@ -1680,38 +2023,40 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
-						// google
+						// okta
 						nil,
 						nil,
 						nil,
 						nil,
-						// saml
+						nil,
+						// keycloak
 						"idp-id",
-						[]byte("metadata"),
+						"issuer",
+						"client_id",
 						nil,
+						database.TextArray[string]{"profile"},
+						// adfs
 						nil,
-						"binding",
-						false,
-						domain.SAMLNameIDFormatTransient,
-						"customAttribute",
-						true,
-						// ldap config
 						nil,
 						nil,
 						nil,
 						nil,
+						// bitbucket
 						nil,
 						nil,
 						nil,
 						nil,
+						// discord
 						nil,
 						nil,
 						nil,
 						nil,
+						// google
 						nil,
 						nil,
 						nil,
 						nil,
+						// saml
 						nil,
 						nil,
 						nil,
@@ -1720,20 +2065,948 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
-						// apple
 						nil,
+						// ldap config
 						nil,
 						nil,
 						nil,
 						nil,
 						nil,
-					},
-				),
-			},
-			object: &IDPTemplate{
-				CreationDate:      testNow,
-				ChangeDate:        testNow,
-				Sequence:          20211109,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+					},
+				),
+			},
+			object: &IDPTemplate{
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				Sequence:          20211109,
+				ResourceOwner:     "ro",
+				ID:                "idp-id",
+				State:             domain.IDPStateActive,
+				Name:              "idp-name",
+				Type:              domain.IDPTypeKeycloak,
+				OwnerType:         domain.IdentityProviderTypeOrg,
+				IsCreationAllowed: true,
+				IsLinkingAllowed:  true,
+				IsAutoCreation:    true,
+				IsAutoUpdate:      true,
+				AutoLinking:       domain.AutoLinkingOptionUsername,
+				KeycloakIDPTemplate: &KeycloakIDPTemplate{
+					IDPID:        "idp-id",
+					Issuer:       "issuer",
+					ClientID:     "client_id",
+					ClientSecret: nil,
+					Scopes:       []string{"profile"},
+				},
+			},
+		},
+		{
+			name:    "prepareIDPTemplateByIDQuery adfs idp",
+			prepare: prepareIDPTemplateByIDQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(idpTemplateQuery),
+					idpTemplateCols,
+					[]driver.Value{
+						"idp-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.IDPConfigStateActive,
+						"idp-name",
+						domain.IDPTypeADFS,
+						domain.IdentityProviderTypeOrg,
+						true,
+						true,
+						true,
+						true,
+						domain.AutoLinkingOptionUsername,
+						// oauth
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// oidc
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// jwt
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// azure
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// github
+						nil,
+						nil,
+						nil,
+						nil,
+						// github enterprise
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab self hosted
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// okta
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// keycloak
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// adfs
+						"idp-id",
+						"issuer",
+						"client_id",
+						nil,
+						database.TextArray[string]{"profile"},
+						// bitbucket
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
+						// google
+						nil,
+						nil,
+						nil,
+						nil,
+						// saml
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// ldap config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+					},
+				),
+			},
+			object: &IDPTemplate{
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				Sequence:          20211109,
+				ResourceOwner:     "ro",
+				ID:                "idp-id",
+				State:             domain.IDPStateActive,
+				Name:              "idp-name",
+				Type:              domain.IDPTypeADFS,
+				OwnerType:         domain.IdentityProviderTypeOrg,
+				IsCreationAllowed: true,
+				IsLinkingAllowed:  true,
+				IsAutoCreation:    true,
+				IsAutoUpdate:      true,
+				AutoLinking:       domain.AutoLinkingOptionUsername,
+				ADFSIDPTemplate: &ADFSIDPTemplate{
+					IDPID:        "idp-id",
+					Issuer:       "issuer",
+					ClientID:     "client_id",
+					ClientSecret: nil,
+					Scopes:       []string{"profile"},
+				},
+			},
+		},
+		{
+			name:    "prepareIDPTemplateByIDQuery bitbucket idp",
+			prepare: prepareIDPTemplateByIDQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(idpTemplateQuery),
+					idpTemplateCols,
+					[]driver.Value{
+						"idp-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.IDPConfigStateActive,
+						"idp-name",
+						domain.IDPTypeBitbucket,
+						domain.IdentityProviderTypeOrg,
+						true,
+						true,
+						true,
+						true,
+						domain.AutoLinkingOptionUsername,
+						// oauth
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// oidc
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// jwt
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// azure
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// github
+						nil,
+						nil,
+						nil,
+						nil,
+						// github enterprise
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab self hosted
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// okta
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// keycloak
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// adfs
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// bitbucket
+						"idp-id",
+						"client_id",
+						nil,
+						database.TextArray[string]{"profile"},
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
+						// google
+						nil,
+						nil,
+						nil,
+						nil,
+						// saml
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// ldap config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+					},
+				),
+			},
+			object: &IDPTemplate{
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				Sequence:          20211109,
+				ResourceOwner:     "ro",
+				ID:                "idp-id",
+				State:             domain.IDPStateActive,
+				Name:              "idp-name",
+				Type:              domain.IDPTypeBitbucket,
+				OwnerType:         domain.IdentityProviderTypeOrg,
+				IsCreationAllowed: true,
+				IsLinkingAllowed:  true,
+				IsAutoCreation:    true,
+				IsAutoUpdate:      true,
+				AutoLinking:       domain.AutoLinkingOptionUsername,
+				BitbucketIDPTemplate: &BitbucketIDPTemplate{
+					IDPID:        "idp-id",
+					ClientID:     "client_id",
+					ClientSecret: nil,
+					Scopes:       []string{"profile"},
+				},
+			},
+		},
+		{
+			name:    "prepareIDPTemplateByIDQuery discord idp",
+			prepare: prepareIDPTemplateByIDQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(idpTemplateQuery),
+					idpTemplateCols,
+					[]driver.Value{
+						"idp-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.IDPConfigStateActive,
+						"idp-name",
+						domain.IDPTypeDiscord,
+						domain.IdentityProviderTypeOrg,
+						true,
+						true,
+						true,
+						true,
+						domain.AutoLinkingOptionUsername,
+						// oauth
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// oidc
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// jwt
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// azure
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// github
+						nil,
+						nil,
+						nil,
+						nil,
+						// github enterprise
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab self hosted
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// okta
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// keycloak
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// adfs
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// bitbucket
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						"idp-id",
+						"client_id",
+						nil,
+						database.TextArray[string]{"profile"},
+						// google
+						nil,
+						nil,
+						nil,
+						nil,
+						// saml
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// ldap config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+					},
+				),
+			},
+			object: &IDPTemplate{
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				Sequence:          20211109,
+				ResourceOwner:     "ro",
+				ID:                "idp-id",
+				State:             domain.IDPStateActive,
+				Name:              "idp-name",
+				Type:              domain.IDPTypeDiscord,
+				OwnerType:         domain.IdentityProviderTypeOrg,
+				IsCreationAllowed: true,
+				IsLinkingAllowed:  true,
+				IsAutoCreation:    true,
+				IsAutoUpdate:      true,
+				AutoLinking:       domain.AutoLinkingOptionUsername,
+				DiscordIDPTemplate: &DiscordIDPTemplate{
+					IDPID:        "idp-id",
+					ClientID:     "client_id",
+					ClientSecret: nil,
+					Scopes:       []string{"profile"},
+				},
+			},
+		},
+		{
+			name:    "prepareIDPTemplateByIDQuery google idp",
+			prepare: prepareIDPTemplateByIDQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(idpTemplateQuery),
+					idpTemplateCols,
+					[]driver.Value{
+						"idp-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.IDPConfigStateActive,
+						"idp-name",
+						domain.IDPTypeGoogle,
+						domain.IdentityProviderTypeOrg,
+						true,
+						true,
+						true,
+						true,
+						domain.AutoLinkingOptionUsername,
+						// oauth
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// oidc
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// jwt
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// azure
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// github
+						nil,
+						nil,
+						nil,
+						nil,
+						// github enterprise
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab self hosted
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// okta
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// keycloak
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// adfs
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// bitbucket
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
+						// google
+						"idp-id",
+						"client_id",
+						nil,
+						database.TextArray[string]{"profile"},
+						// saml
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// ldap config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+					},
+				),
+			},
+			object: &IDPTemplate{
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				Sequence:          20211109,
+				ResourceOwner:     "ro",
+				ID:                "idp-id",
+				State:             domain.IDPStateActive,
+				Name:              "idp-name",
+				Type:              domain.IDPTypeGoogle,
+				OwnerType:         domain.IdentityProviderTypeOrg,
+				IsCreationAllowed: true,
+				IsLinkingAllowed:  true,
+				IsAutoCreation:    true,
+				IsAutoUpdate:      true,
+				AutoLinking:       domain.AutoLinkingOptionUsername,
+				GoogleIDPTemplate: &GoogleIDPTemplate{
+					IDPID:        "idp-id",
+					ClientID:     "client_id",
+					ClientSecret: nil,
+					Scopes:       []string{"profile"},
+				},
+			},
+		},
+		{
+			name:    "prepareIDPTemplateByIDQuery saml idp",
+			prepare: prepareIDPTemplateByIDQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(idpTemplateQuery),
+					idpTemplateCols,
+					[]driver.Value{
+						"idp-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.IDPConfigStateActive,
+						"idp-name",
+						domain.IDPTypeSAML,
+						domain.IdentityProviderTypeOrg,
+						true,
+						true,
+						true,
+						true,
+						domain.AutoLinkingOptionUsername,
+						// oauth
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// oidc
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// jwt
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// azure
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// github
+						nil,
+						nil,
+						nil,
+						nil,
+						// github enterprise
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab self hosted
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// okta
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// keycloak
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// adfs
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// bitbucket
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
+						// google
+						nil,
+						nil,
+						nil,
+						nil,
+						// saml
+						"idp-id",
+						[]byte("metadata"),
+						nil,
+						nil,
+						"binding",
+						false,
+						domain.SAMLNameIDFormatTransient,
+						"customAttribute",
+						true,
+						// ldap config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+					},
+				),
+			},
+			object: &IDPTemplate{
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				Sequence:          20211109,
 				ResourceOwner:     "ro",
 				ID:                "idp-id",
 				State:             domain.IDPStateActive,
@@ -1790,6 +3063,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// oidc
 						nil,
 						nil,
@@ -1798,39 +3072,68 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// jwt
 						nil,
 						nil,
 						nil,
 						nil,
 						nil,
-						// azure
+						// azure
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// github
+						nil,
+						nil,
+						nil,
+						nil,
+						// github enterprise
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab self hosted
+						nil,
+						nil,
 						nil,
 						nil,
 						nil,
+						// okta
 						nil,
 						nil,
 						nil,
-						// github
 						nil,
 						nil,
+						// keycloak
 						nil,
 						nil,
-						// github enterprise
 						nil,
 						nil,
 						nil,
+						// adfs
 						nil,
 						nil,
 						nil,
 						nil,
-						// gitlab
 						nil,
+						// bitbucket
 						nil,
 						nil,
 						nil,
-						// gitlab self hosted
 						nil,
+						// discord
 						nil,
 						nil,
 						nil,
@@ -1961,6 +3264,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// oidc
 						nil,
 						nil,
@@ -1969,6 +3273,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// jwt
 						nil,
 						nil,
@@ -2006,6 +3311,34 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// okta
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// keycloak
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// adfs
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// bitbucket
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
 						// google
 						nil,
 						nil,
@@ -2113,6 +3446,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// oidc
 						nil,
 						nil,
@@ -2121,6 +3455,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						// jwt
 						nil,
 						nil,
@@ -2158,6 +3493,34 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// okta
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// keycloak
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// adfs
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// bitbucket
+						nil,
+						nil,
+						nil,
+						nil,
+						// discord
+						nil,
+						nil,
+						nil,
+						nil,
 						// google config
 						nil,
 						nil,
@@ -2293,6 +3656,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// oidc
 							nil,
 							nil,
@@ -2301,6 +3665,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// jwt
 							nil,
 							nil,
@@ -2338,6 +3703,34 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// okta
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// keycloak
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// adfs
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// bitbucket
+							nil,
+							nil,
+							nil,
+							nil,
+							// discord
+							nil,
+							nil,
+							nil,
+							nil,
 							// google config
 							nil,
 							nil,
@@ -2473,6 +3866,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// oidc
 							nil,
 							nil,
@@ -2481,6 +3875,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// jwt
 							nil,
 							nil,
@@ -2518,6 +3913,34 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// okta
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// keycloak
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// adfs
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// bitbucket
+							nil,
+							nil,
+							nil,
+							nil,
+							// discord
+							nil,
+							nil,
+							nil,
+							nil,
 							// google config
 							nil,
 							nil,
@@ -2626,6 +4049,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// oidc
 							nil,
 							nil,
@@ -2634,6 +4058,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// jwt
 							nil,
 							nil,
@@ -2671,6 +4096,34 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// okta
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// keycloak
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// adfs
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// bitbucket
+							nil,
+							nil,
+							nil,
+							nil,
+							// discord
+							nil,
+							nil,
+							nil,
+							nil,
 							// google config
 							nil,
 							nil,
@@ -2744,6 +4197,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// oidc
 							nil,
 							nil,
@@ -2752,6 +4206,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// jwt
 							nil,
 							nil,
@@ -2789,6 +4244,34 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// okta
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// keycloak
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// adfs
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// bitbucket
+							nil,
+							nil,
+							nil,
+							nil,
+							// discord
+							nil,
+							nil,
+							nil,
+							nil,
 							// google
 							nil,
 							nil,
@@ -2862,6 +4345,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// oidc
 							nil,
 							nil,
@@ -2870,6 +4354,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// jwt
 							nil,
 							nil,
@@ -2907,6 +4392,34 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// okta
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// keycloak
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// adfs
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// bitbucket
+							nil,
+							nil,
+							nil,
+							nil,
+							// discord
+							nil,
+							nil,
+							nil,
+							nil,
 							// google
 							"idp-id-google",
 							"client_id",
@@ -2980,6 +4493,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							database.TextArray[string]{"profile"},
 							"id-attribute",
 							true,
+							nil,
 							// oidc
 							nil,
 							nil,
@@ -2988,6 +4502,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// jwt
 							nil,
 							nil,
@@ -3025,6 +4540,34 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// okta
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// keycloak
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// adfs
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// bitbucket
+							nil,
+							nil,
+							nil,
+							nil,
+							// discord
+							nil,
+							nil,
+							nil,
+							nil,
 							// google
 							nil,
 							nil,
@@ -3098,6 +4641,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// oidc
 							"idp-id-oidc",
 							"issuer",
@@ -3106,6 +4650,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							database.TextArray[string]{"profile"},
 							true,
 							true,
+							nil,
 							// jwt
 							nil,
 							nil,
@@ -3143,6 +4688,34 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// okta
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// keycloak
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// adfs
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// bitbucket
+							nil,
+							nil,
+							nil,
+							nil,
+							// discord
+							nil,
+							nil,
+							nil,
+							nil,
 							// google
 							nil,
 							nil,
@@ -3216,6 +4789,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// oidc
 							nil,
 							nil,
@@ -3224,6 +4798,7 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							// jwt
 							"idp-id-jwt",
 							"issuer",
@@ -3261,6 +4836,34 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// okta
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// keycloak
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// adfs
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// bitbucket
+							nil,
+							nil,
+							nil,
+							nil,
+							// discord
+							nil,
+							nil,
+							nil,
+							nil,
 							// google
 							nil,
 							nil,
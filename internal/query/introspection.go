@@ -5,9 +5,11 @@ import (
 	"database/sql"
 	_ "embed"
 	"sync"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	"github.com/zitadel/zitadel/internal/query/projection"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -57,9 +59,13 @@ func (q *Queries) ActiveIntrospectionClientByID(ctx context.Context, clientID st
 	defer func() { span.EndWithError(err) }()
 
 	var (
-		instanceID = authz.GetInstance(ctx).InstanceID()
-		client     = new(IntrospectionClient)
+		instanceID     = authz.GetInstance(ctx).InstanceID()
+		client         = new(IntrospectionClient)
+		keysExpiration sql.NullTime
 	)
+	if entry, ok := q.caches.introspectionClient.Get(ctx, introspectionClientIndexByClientID, introspectionClientCacheKey(instanceID, clientID)); ok && entry.isValid(getKeys, time.Now()) {
+		return entry.Client, nil
+	}
 
 	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
 		return row.Scan(
@@ -71,6 +77,7 @@ func (q *Queries) ActiveIntrospectionClientByID(ctx context.Context, clientID st
 			&client.ResourceOwner,
 			&client.ProjectRoleAssertion,
 			&client.PublicKeys,
+			&keysExpiration,
 		)
 	},
 		introspectionClientByIDQuery,
@@ -80,5 +87,77 @@ func (q *Queries) ActiveIntrospectionClientByID(ctx context.Context, clientID st
 		return nil, err
 	}
 
+	q.caches.introspectionClient.Set(ctx, &introspectionClientEntry{
+		InstanceID:     instanceID,
+		Client:         client,
+		WithKeys:       getKeys,
+		KeysExpiration: keysExpiration.Time,
+	})
 	return client, nil
 }
+
+type introspectionClientIndex int
+
+//go:generate enumer -type introspectionClientIndex -linecomment
+const (
+	// Empty line comment ensures empty string for unspecified value
+	introspectionClientIndexUnspecified introspectionClientIndex = iota //
+	introspectionClientIndexByClientID
+	introspectionClientIndexByProjectID
+	introspectionClientIndexByResourceOwner
+)
+
+// introspectionClientEntry is the cached state of an [IntrospectionClient].
+type introspectionClientEntry struct {
+	InstanceID string
+	Client     *IntrospectionClient
+	// WithKeys is set when the public keys of the client were queried.
+	WithKeys bool
+	// KeysExpiration is the earliest expiration of the public keys.
+	KeysExpiration time.Time
+}
+
+// Keys implements [cache.Entry]
+func (e *introspectionClientEntry) Keys(index introspectionClientIndex) []string {
+	switch index {
+	case introspectionClientIndexByClientID:
+		return []string{introspectionClientCacheKey(e.InstanceID, e.Client.ClientID)}
+	case introspectionClientIndexByProjectID:
+		return []string{introspectionClientCacheKey(e.InstanceID, e.Client.ProjectID)}
+	case introspectionClientIndexByResourceOwner:
+		return []string{introspectionClientCacheKey(e.InstanceID, e.Client.ResourceOwner)}
+	case introspectionClientIndexUnspecified:
+	}
+	return nil
+}
+
+// isValid returns false if the keys are required but were not queried
+// or one of the keys expired since.
+func (e *introspectionClientEntry) isValid(getKeys bool, now time.Time) bool {
+	if !getKeys {
+		return true
+	}
+	if !e.WithKeys {
+		return false
+	}
+	return e.KeysExpiration.IsZero() || now.Before(e.KeysExpiration)
+}
+
+func introspectionClientCacheKey(instanceID, key string) string {
+	return instanceID + "-" + key
+}
+
+func (c *Caches) registerIntrospectionClientInvalidation() {
+	// Application and application key events are pushed on the project aggregate.
+	invalidate := cacheInvalidationFunc(c.introspectionClient, introspectionClientIndexByProjectID, func(aggregate *eventstore.Aggregate) string {
+		return introspectionClientCacheKey(aggregate.InstanceID, aggregate.ID)
+	})
+	projection.AppProjection.RegisterCacheInvalidation(invalidate)
+	projection.ProjectProjection.RegisterCacheInvalidation(invalidate)
+	projection.AuthNKeyProjection.RegisterCacheInvalidation(invalidate)
+
+	invalidate = cacheInvalidationFunc(c.introspectionClient, introspectionClientIndexByResourceOwner, func(aggregate *eventstore.Aggregate) string {
+		return introspectionClientCacheKey(aggregate.InstanceID, aggregate.ID)
+	})
+	projection.OrgProjection.RegisterCacheInvalidation(invalidate)
+}
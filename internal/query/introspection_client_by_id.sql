@@ -10,7 +10,7 @@ with config as (
 			and client_id = $2
 ),
 keys as (
-	select identifier as client_id, json_object_agg(id, encode(public_key, 'base64')) as public_keys
+	select identifier as client_id, json_object_agg(id, encode(public_key, 'base64')) as public_keys, min(expiration) as keys_expiration
 	from projections.authn_keys2
 	where $3 = true -- when argument is false, don't waste time on trying to query for keys.
 		and instance_id = $1
@@ -18,7 +18,7 @@ keys as (
 		and expiration > current_timestamp
 	group by identifier
 )
-select config.app_id, config.client_id, config.client_secret, config.app_type, apps.project_id, apps.resource_owner, p.project_role_assertion, keys.public_keys
+select config.app_id, config.client_id, config.client_secret, config.app_type, apps.project_id, apps.resource_owner, p.project_role_assertion, keys.public_keys, keys.keys_expiration
 from config
 join projections.apps7 apps on apps.id = config.app_id and apps.instance_id = config.instance_id and apps.state = 1
 join projections.projects4 p on p.id = apps.project_id and p.instance_id = $1 and p.state = 1
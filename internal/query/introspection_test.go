@@ -6,11 +6,13 @@ import (
 	_ "embed"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/cache/connector/noop"
 	"github.com/zitadel/zitadel/internal/database"
 )
 
@@ -50,8 +52,8 @@ func TestQueries_ActiveIntrospectionClientByID(t *testing.T) {
 				getKeys:  false,
 			},
 			mock: mockQuery(expQuery,
-				[]string{"app_id", "client_id", "client_secret", "app_type", "project_id", "resource_owner", "project_role_assertion", "public_keys"},
-				[]driver.Value{"appID", "clientID", "secret", "oidc", "projectID", "orgID", true, nil},
+				[]string{"app_id", "client_id", "client_secret", "app_type", "project_id", "resource_owner", "project_role_assertion", "public_keys", "keys_expiration"},
+				[]driver.Value{"appID", "clientID", "secret", "oidc", "projectID", "orgID", true, nil, nil},
 				"instanceID", "clientID", false),
 			want: &IntrospectionClient{
 				AppID:                "appID",
@@ -71,8 +73,8 @@ func TestQueries_ActiveIntrospectionClientByID(t *testing.T) {
 				getKeys:  true,
 			},
 			mock: mockQuery(expQuery,
-				[]string{"app_id", "client_id", "client_secret", "app_type", "project_id", "resource_owner", "project_role_assertion", "public_keys"},
-				[]driver.Value{"appID", "clientID", "", "oidc", "projectID", "orgID", true, encPubkeys},
+				[]string{"app_id", "client_id", "client_secret", "app_type", "project_id", "resource_owner", "project_role_assertion", "public_keys", "keys_expiration"},
+				[]driver.Value{"appID", "clientID", "", "oidc", "projectID", "orgID", true, encPubkeys, time.Now().Add(time.Hour)},
 				"instanceID", "clientID", true),
 			want: &IntrospectionClient{
 				AppID:                "appID",
@@ -93,6 +95,9 @@ func TestQueries_ActiveIntrospectionClientByID(t *testing.T) {
 					client: &database.DB{
 						DB: db,
 					},
+					caches: &Caches{
+						introspectionClient: noop.NewCache[introspectionClientIndex, string, *introspectionClientEntry](),
+					},
 				}
 				ctx := authz.NewMockContext("instanceID", "orgID", "userID")
 				got, err := q.ActiveIntrospectionClientByID(ctx, tt.args.clientID, tt.args.getKeys)
@@ -43,8 +43,17 @@ type OIDCClient struct {
 	ProjectRoleAssertion     bool                       `json:"project_role_assertion,omitempty"`
 	LoginVersion             domain.LoginVersion        `json:"login_version,omitempty"`
 	LoginBaseURI             *URL                       `json:"login_base_uri,omitempty"`
+	RequirePAR               bool                       `json:"require_par,omitempty"`
+	RequireDPoP              bool                       `json:"require_dpop,omitempty"`
 	ProjectRoleKeys          []string                   `json:"project_role_keys,omitempty"`
 	Settings                 *OIDCSettings              `json:"settings,omitempty"`
+
+	BackchannelTokenDeliveryMode          domain.OIDCBackchannelTokenDeliveryMode `json:"backchannel_token_delivery_mode,omitempty"`
+	BackchannelClientNotificationEndpoint string                                  `json:"backchannel_client_notification_endpoint,omitempty"`
+
+	TLSClientAuthSubjectDN string `json:"tls_client_auth_subject_dn,omitempty"`
+
+	AuthorizationDetailsTypes []string `json:"authorization_details_types,omitempty"`
 }
 
 type URL url.URL
@@ -0,0 +1,108 @@
+package query
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	org_model "github.com/zitadel/zitadel/internal/org/model"
+	org_es_model "github.com/zitadel/zitadel/internal/org/repository/eventsourcing/model"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// OrgByIDAsOf returns the organization as it was at the point in time.
+func (q *Queries) OrgByIDAsOf(ctx context.Context, id string, asOf *AsOf) (_ *Org, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-Eex0u", "Errors.IDMissing")
+	}
+	if err = asOf.validate(); err != nil {
+		return nil, err
+	}
+	readModel := newOrgAsOfReadModel(id, asOf)
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	return readModel.orgAsOf()
+}
+
+// orgAsOfReadModel reduces the events of the organization with the eventsourcing model of the organization.
+// The organization model has no removed state, so the removal is tracked separately.
+type orgAsOfReadModel struct {
+	eventstore.ReadModel
+
+	asOf    *AsOf
+	org     *org_es_model.Org
+	removed bool
+}
+
+func newOrgAsOfReadModel(id string, asOf *AsOf) *orgAsOfReadModel {
+	return &orgAsOfReadModel{
+		ReadModel: eventstore.ReadModel{
+			AggregateID: id,
+		},
+		asOf: asOf,
+		org:  new(org_es_model.Org),
+	}
+}
+
+func (rm *orgAsOfReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		if event.Type() == org.OrgRemovedEventType {
+			rm.removed = true
+		}
+		if err := rm.org.AppendEvent(event); err != nil {
+			return err
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *orgAsOfReadModel) orgAsOf() (*Org, error) {
+	if rm.org.IsZero() || rm.removed {
+		return nil, zerrors.ThrowNotFound(nil, "QUERY-Xoh7e", "Errors.Org.NotFound")
+	}
+	o := &Org{
+		ID:            rm.org.AggregateID,
+		CreationDate:  rm.org.CreationDate,
+		ChangeDate:    rm.org.ChangeDate,
+		ResourceOwner: rm.org.ResourceOwner,
+		State:         domain.OrgStateActive,
+		Sequence:      rm.org.Sequence,
+		Name:          rm.org.Name,
+		instanceID:    rm.org.InstanceID,
+	}
+	if org_model.OrgState(rm.org.State) == org_model.OrgStateInactive {
+		o.State = domain.OrgStateInactive
+	}
+	for _, orgDomain := range rm.org.Domains {
+		if orgDomain.Primary {
+			o.Domain = orgDomain.Domain
+		}
+	}
+	return o, nil
+}
+func (rm *orgAsOfReadModel) Query() *eventstore.SearchQueryBuilder {
+	return rm.asOf.filter(
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			AddQuery().
+			AggregateTypes(org.AggregateType).
+			AggregateIDs(rm.AggregateID).
+			EventTypes(
+				org.OrgAddedEventType,
+				org.OrgChangedEventType,
+				org.OrgDeactivatedEventType,
+				org.OrgReactivatedEventType,
+				org.OrgRemovedEventType,
+				org.OrgDomainAddedEventType,
+				org.OrgDomainVerifiedEventType,
+				org.OrgDomainPrimarySetEventType,
+				org.OrgDomainRemovedEventType,
+			).
+			Builder(),
+	)
+}
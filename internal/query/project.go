@@ -11,6 +11,7 @@ import (
 	"github.com/zitadel/logging"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	"github.com/zitadel/zitadel/internal/query/projection"
@@ -47,6 +48,10 @@ var (
 		name:  projection.ProjectColumnPrivateLabelingSetting,
 		table: projectsTable,
 	}
+	ProjectColumnAuthorizationDetailsTypes = Column{
+		name:  projection.ProjectColumnAuthorizationDetailsTypes,
+		table: projectsTable,
+	}
 	ProjectColumnCreationDate = Column{
 		name:  projection.ProjectColumnCreationDate,
 		table: projectsTable,
@@ -174,6 +179,8 @@ type Project struct {
 	ProjectRoleCheck       bool
 	HasProjectCheck        bool
 	PrivateLabelingSetting domain.PrivateLabelingSetting
+
+	AuthorizationDetailsTypes database.TextArray[string]
 }
 
 type ProjectSearchQueries struct {
@@ -408,7 +415,8 @@ func prepareProjectQuery() (sq.SelectBuilder, func(*sql.Row) (*Project, error))
 			ProjectColumnProjectRoleAssertion.identifier(),
 			ProjectColumnProjectRoleCheck.identifier(),
 			ProjectColumnHasProjectCheck.identifier(),
-			ProjectColumnPrivateLabelingSetting.identifier()).
+			ProjectColumnPrivateLabelingSetting.identifier(),
+			ProjectColumnAuthorizationDetailsTypes.identifier()).
 			From(projectsTable.identifier()).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*Project, error) {
@@ -425,6 +433,7 @@ func prepareProjectQuery() (sq.SelectBuilder, func(*sql.Row) (*Project, error))
 				&p.ProjectRoleCheck,
 				&p.HasProjectCheck,
 				&p.PrivateLabelingSetting,
+				&p.AuthorizationDetailsTypes,
 			)
 			if err != nil {
 				if errors.Is(err, sql.ErrNoRows) {
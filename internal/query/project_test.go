@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -65,7 +66,8 @@ var (
 		` projections.projects4.project_role_assertion,` +
 		` projections.projects4.project_role_check,` +
 		` projections.projects4.has_project_check,` +
-		` projections.projects4.private_labeling_setting` +
+		` projections.projects4.private_labeling_setting,` +
+		` projections.projects4.authorization_details_types` +
 		` FROM projections.projects4`
 	prepareProjectCols = []string{
 		"id",
@@ -79,6 +81,7 @@ var (
 		"project_role_check",
 		"has_project_check",
 		"private_labeling_setting",
+		"authorization_details_types",
 	}
 )
 
@@ -275,21 +278,23 @@ func Test_ProjectPrepares(t *testing.T) {
 						true,
 						true,
 						domain.PrivateLabelingSettingEnforceProjectResourceOwnerPolicy,
+						database.TextArray[string]{"payment_initiation"},
 					},
 				),
 			},
 			object: &Project{
-				ID:                     "id",
-				CreationDate:           testNow,
-				ChangeDate:             testNow,
-				ResourceOwner:          "ro",
-				State:                  domain.ProjectStateActive,
-				Sequence:               20211108,
-				Name:                   "project-name",
-				ProjectRoleAssertion:   true,
-				ProjectRoleCheck:       true,
-				HasProjectCheck:        true,
-				PrivateLabelingSetting: domain.PrivateLabelingSettingEnforceProjectResourceOwnerPolicy,
+				ID:                        "id",
+				CreationDate:              testNow,
+				ChangeDate:                testNow,
+				ResourceOwner:             "ro",
+				State:                     domain.ProjectStateActive,
+				Sequence:                  20211108,
+				Name:                      "project-name",
+				ProjectRoleAssertion:      true,
+				ProjectRoleCheck:          true,
+				HasProjectCheck:           true,
+				PrivateLabelingSetting:    domain.PrivateLabelingSettingEnforceProjectResourceOwnerPolicy,
+				AuthorizationDetailsTypes: database.TextArray[string]{"payment_initiation"},
 			},
 		},
 		{
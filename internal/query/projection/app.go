@@ -38,29 +38,34 @@ const (
 	AppAPIConfigColumnClientSecret = "client_secret"
 	AppAPIConfigColumnAuthMethod   = "auth_method"
 
-	appOIDCTableSuffix                          = "oidc_configs"
-	AppOIDCConfigColumnAppID                    = "app_id"
-	AppOIDCConfigColumnInstanceID               = "instance_id"
-	AppOIDCConfigColumnVersion                  = "version"
-	AppOIDCConfigColumnClientID                 = "client_id"
-	AppOIDCConfigColumnClientSecret             = "client_secret"
-	AppOIDCConfigColumnRedirectUris             = "redirect_uris"
-	AppOIDCConfigColumnResponseTypes            = "response_types"
-	AppOIDCConfigColumnGrantTypes               = "grant_types"
-	AppOIDCConfigColumnApplicationType          = "application_type"
-	AppOIDCConfigColumnAuthMethodType           = "auth_method_type"
-	AppOIDCConfigColumnPostLogoutRedirectUris   = "post_logout_redirect_uris"
-	AppOIDCConfigColumnDevMode                  = "is_dev_mode"
-	AppOIDCConfigColumnAccessTokenType          = "access_token_type"
-	AppOIDCConfigColumnAccessTokenRoleAssertion = "access_token_role_assertion"
-	AppOIDCConfigColumnIDTokenRoleAssertion     = "id_token_role_assertion"
-	AppOIDCConfigColumnIDTokenUserinfoAssertion = "id_token_userinfo_assertion"
-	AppOIDCConfigColumnClockSkew                = "clock_skew"
-	AppOIDCConfigColumnAdditionalOrigins        = "additional_origins"
-	AppOIDCConfigColumnSkipNativeAppSuccessPage = "skip_native_app_success_page"
-	AppOIDCConfigColumnBackChannelLogoutURI     = "back_channel_logout_uri"
-	AppOIDCConfigColumnLoginVersion             = "login_version"
-	AppOIDCConfigColumnLoginBaseURI             = "login_base_uri"
+	appOIDCTableSuffix                                 = "oidc_configs"
+	AppOIDCConfigColumnAppID                           = "app_id"
+	AppOIDCConfigColumnInstanceID                      = "instance_id"
+	AppOIDCConfigColumnVersion                         = "version"
+	AppOIDCConfigColumnClientID                        = "client_id"
+	AppOIDCConfigColumnClientSecret                    = "client_secret"
+	AppOIDCConfigColumnRedirectUris                    = "redirect_uris"
+	AppOIDCConfigColumnResponseTypes                   = "response_types"
+	AppOIDCConfigColumnGrantTypes                      = "grant_types"
+	AppOIDCConfigColumnApplicationType                 = "application_type"
+	AppOIDCConfigColumnAuthMethodType                  = "auth_method_type"
+	AppOIDCConfigColumnPostLogoutRedirectUris          = "post_logout_redirect_uris"
+	AppOIDCConfigColumnDevMode                         = "is_dev_mode"
+	AppOIDCConfigColumnAccessTokenType                 = "access_token_type"
+	AppOIDCConfigColumnAccessTokenRoleAssertion        = "access_token_role_assertion"
+	AppOIDCConfigColumnIDTokenRoleAssertion            = "id_token_role_assertion"
+	AppOIDCConfigColumnIDTokenUserinfoAssertion        = "id_token_userinfo_assertion"
+	AppOIDCConfigColumnClockSkew                       = "clock_skew"
+	AppOIDCConfigColumnAdditionalOrigins               = "additional_origins"
+	AppOIDCConfigColumnSkipNativeAppSuccessPage        = "skip_native_app_success_page"
+	AppOIDCConfigColumnBackChannelLogoutURI            = "back_channel_logout_uri"
+	AppOIDCConfigColumnLoginVersion                    = "login_version"
+	AppOIDCConfigColumnLoginBaseURI                    = "login_base_uri"
+	AppOIDCConfigColumnRequirePAR                      = "require_par"
+	AppOIDCConfigColumnRequireDPoP                     = "require_dpop"
+	AppOIDCConfigColumnBackchannelTokenDeliveryMode    = "backchannel_token_delivery_mode"
+	AppOIDCConfigColumnBackchannelNotificationEndpoint = "backchannel_client_notification_endpoint"
+	AppOIDCConfigColumnTLSClientAuthSubjectDN          = "tls_client_auth_subject_dn"
 
 	appSAMLTableSuffix              = "saml_configs"
 	AppSAMLConfigColumnAppID        = "app_id"
@@ -133,6 +138,11 @@ func (*appProjection) Init() *old_handler.Check {
 			handler.NewColumn(AppOIDCConfigColumnBackChannelLogoutURI, handler.ColumnTypeText, handler.Nullable()),
 			handler.NewColumn(AppOIDCConfigColumnLoginVersion, handler.ColumnTypeEnum, handler.Nullable()),
 			handler.NewColumn(AppOIDCConfigColumnLoginBaseURI, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(AppOIDCConfigColumnRequirePAR, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(AppOIDCConfigColumnRequireDPoP, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(AppOIDCConfigColumnBackchannelTokenDeliveryMode, handler.ColumnTypeEnum, handler.Default(0)),
+			handler.NewColumn(AppOIDCConfigColumnBackchannelNotificationEndpoint, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(AppOIDCConfigColumnTLSClientAuthSubjectDN, handler.ColumnTypeText, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(AppOIDCConfigColumnInstanceID, AppOIDCConfigColumnAppID),
 			appOIDCTableSuffix,
@@ -513,6 +523,11 @@ func (p *appProjection) reduceOIDCConfigAdded(event eventstore.Event) (*handler.
 				handler.NewCol(AppOIDCConfigColumnBackChannelLogoutURI, e.BackChannelLogoutURI),
 				handler.NewCol(AppOIDCConfigColumnLoginVersion, e.LoginVersion),
 				handler.NewCol(AppOIDCConfigColumnLoginBaseURI, e.LoginBaseURI),
+				handler.NewCol(AppOIDCConfigColumnRequirePAR, e.RequirePushedAuthorizationRequests),
+				handler.NewCol(AppOIDCConfigColumnRequireDPoP, e.RequireDPoP),
+				handler.NewCol(AppOIDCConfigColumnBackchannelTokenDeliveryMode, e.BackchannelTokenDeliveryMode),
+				handler.NewCol(AppOIDCConfigColumnBackchannelNotificationEndpoint, e.BackchannelClientNotificationEndpoint),
+				handler.NewCol(AppOIDCConfigColumnTLSClientAuthSubjectDN, e.TLSClientAuthSubjectDN),
 			},
 			handler.WithTableSuffix(appOIDCTableSuffix),
 		),
@@ -535,7 +550,7 @@ func (p *appProjection) reduceOIDCConfigChanged(event eventstore.Event) (*handle
 		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-GNHU1", "reduce.wrong.event.type %s", project.OIDCConfigChangedType)
 	}
 
-	cols := make([]handler.Column, 0, 18)
+	cols := make([]handler.Column, 0, 23)
 	if e.Version != nil {
 		cols = append(cols, handler.NewCol(AppOIDCConfigColumnVersion, *e.Version))
 	}
@@ -590,6 +605,21 @@ func (p *appProjection) reduceOIDCConfigChanged(event eventstore.Event) (*handle
 	if e.LoginBaseURI != nil {
 		cols = append(cols, handler.NewCol(AppOIDCConfigColumnLoginBaseURI, *e.LoginBaseURI))
 	}
+	if e.RequirePushedAuthorizationRequests != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnRequirePAR, *e.RequirePushedAuthorizationRequests))
+	}
+	if e.RequireDPoP != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnRequireDPoP, *e.RequireDPoP))
+	}
+	if e.BackchannelTokenDeliveryMode != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnBackchannelTokenDeliveryMode, *e.BackchannelTokenDeliveryMode))
+	}
+	if e.BackchannelClientNotificationEndpoint != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnBackchannelNotificationEndpoint, *e.BackchannelClientNotificationEndpoint))
+	}
+	if e.TLSClientAuthSubjectDN != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnTLSClientAuthSubjectDN, *e.TLSClientAuthSubjectDN))
+	}
 
 	if len(cols) == 0 {
 		return handler.NewNoOpStatement(e), nil
@@ -561,7 +561,12 @@ func TestAppProjection_reduces(t *testing.T) {
 						"skipNativeAppSuccessPage": true,
 						"backChannelLogoutURI": "back.channel.one.ch",
 						"loginVersion": 2,
-						"loginBaseURI": "https://login.ch/"
+						"loginBaseURI": "https://login.ch/",
+						"requirePushedAuthorizationRequests": true,
+						"requireDPoP": true,
+						"backchannelTokenDeliveryMode": 1,
+						"backchannelClientNotificationEndpoint": "https://client.ch/ciba",
+						"tlsClientAuthSubjectDn": "CN=client,O=zitadel"
 		}`),
 					), project.OIDCConfigAddedEventMapper),
 			},
@@ -572,7 +577,7 @@ func TestAppProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.apps7_oidc_configs (app_id, instance_id, version, client_id, client_secret, redirect_uris, response_types, grant_types, application_type, auth_method_type, post_logout_redirect_uris, is_dev_mode, access_token_type, access_token_role_assertion, id_token_role_assertion, id_token_userinfo_assertion, clock_skew, additional_origins, skip_native_app_success_page, back_channel_logout_uri, login_version, login_base_uri) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)",
+							expectedStmt: "INSERT INTO projections.apps7_oidc_configs (app_id, instance_id, version, client_id, client_secret, redirect_uris, response_types, grant_types, application_type, auth_method_type, post_logout_redirect_uris, is_dev_mode, access_token_type, access_token_role_assertion, id_token_role_assertion, id_token_userinfo_assertion, clock_skew, additional_origins, skip_native_app_success_page, back_channel_logout_uri, login_version, login_base_uri, require_par, require_dpop, backchannel_token_delivery_mode, backchannel_client_notification_endpoint, tls_client_auth_subject_dn) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27)",
 							expectedArgs: []interface{}{
 								"app-id",
 								"instance-id",
@@ -596,6 +601,11 @@ func TestAppProjection_reduces(t *testing.T) {
 								"back.channel.one.ch",
 								domain.LoginVersion2,
 								"https://login.ch/",
+								true,
+								true,
+								domain.OIDCBackchannelTokenDeliveryModePing,
+								"https://client.ch/ciba",
+								"CN=client,O=zitadel",
 							},
 						},
 						{
@@ -639,7 +649,12 @@ func TestAppProjection_reduces(t *testing.T) {
 						"skipNativeAppSuccessPage": true,
 						"backChannelLogoutURI": "back.channel.one.ch",
 						"loginVersion": 2,
-						"loginBaseURI": "https://login.ch/"
+						"loginBaseURI": "https://login.ch/",
+						"requirePushedAuthorizationRequests": true,
+						"requireDPoP": true,
+						"backchannelTokenDeliveryMode": 1,
+						"backchannelClientNotificationEndpoint": "https://client.ch/ciba",
+						"tlsClientAuthSubjectDn": "CN=client,O=zitadel"
 		}`),
 					), project.OIDCConfigAddedEventMapper),
 			},
@@ -650,7 +665,7 @@ func TestAppProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.apps7_oidc_configs (app_id, instance_id, version, client_id, client_secret, redirect_uris, response_types, grant_types, application_type, auth_method_type, post_logout_redirect_uris, is_dev_mode, access_token_type, access_token_role_assertion, id_token_role_assertion, id_token_userinfo_assertion, clock_skew, additional_origins, skip_native_app_success_page, back_channel_logout_uri, login_version, login_base_uri) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)",
+							expectedStmt: "INSERT INTO projections.apps7_oidc_configs (app_id, instance_id, version, client_id, client_secret, redirect_uris, response_types, grant_types, application_type, auth_method_type, post_logout_redirect_uris, is_dev_mode, access_token_type, access_token_role_assertion, id_token_role_assertion, id_token_userinfo_assertion, clock_skew, additional_origins, skip_native_app_success_page, back_channel_logout_uri, login_version, login_base_uri, require_par, require_dpop, backchannel_token_delivery_mode, backchannel_client_notification_endpoint, tls_client_auth_subject_dn) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27)",
 							expectedArgs: []interface{}{
 								"app-id",
 								"instance-id",
@@ -674,6 +689,11 @@ func TestAppProjection_reduces(t *testing.T) {
 								"back.channel.one.ch",
 								domain.LoginVersion2,
 								"https://login.ch/",
+								true,
+								true,
+								domain.OIDCBackchannelTokenDeliveryModePing,
+								"https://client.ch/ciba",
+								"CN=client,O=zitadel",
 							},
 						},
 						{
@@ -714,7 +734,12 @@ func TestAppProjection_reduces(t *testing.T) {
                         "additionalOrigins": ["origin.one.ch", "origin.two.ch"],
 						"skipNativeAppSuccessPage": true,
 						"backChannelLogoutURI": "back.channel.one.ch",
-						"loginVersion": 2
+						"loginVersion": 2,
+						"requirePushedAuthorizationRequests": true,
+						"requireDPoP": true,
+						"backchannelTokenDeliveryMode": 1,
+						"backchannelClientNotificationEndpoint": "https://client.ch/ciba",
+						"tlsClientAuthSubjectDn": "CN=client,O=zitadel"
 		}`),
 					), project.OIDCConfigChangedEventMapper),
 			},
@@ -725,7 +750,7 @@ func TestAppProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.apps7_oidc_configs SET (version, redirect_uris, response_types, grant_types, application_type, auth_method_type, post_logout_redirect_uris, is_dev_mode, access_token_type, access_token_role_assertion, id_token_role_assertion, id_token_userinfo_assertion, clock_skew, additional_origins, skip_native_app_success_page, back_channel_logout_uri, login_version) = ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17) WHERE (app_id = $18) AND (instance_id = $19)",
+							expectedStmt: "UPDATE projections.apps7_oidc_configs SET (version, redirect_uris, response_types, grant_types, application_type, auth_method_type, post_logout_redirect_uris, is_dev_mode, access_token_type, access_token_role_assertion, id_token_role_assertion, id_token_userinfo_assertion, clock_skew, additional_origins, skip_native_app_success_page, back_channel_logout_uri, login_version, require_par, require_dpop, backchannel_token_delivery_mode, backchannel_client_notification_endpoint, tls_client_auth_subject_dn) = ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22) WHERE (app_id = $23) AND (instance_id = $24)",
 							expectedArgs: []interface{}{
 								domain.OIDCVersionV1,
 								database.TextArray[string]{"redirect.one.ch", "redirect.two.ch"},
@@ -744,6 +769,11 @@ func TestAppProjection_reduces(t *testing.T) {
 								true,
 								"back.channel.one.ch",
 								domain.LoginVersion2,
+								true,
+								true,
+								domain.OIDCBackchannelTokenDeliveryModePing,
+								"https://client.ch/ciba",
+								"CN=client,O=zitadel",
 								"app-id",
 								"instance-id",
 							},
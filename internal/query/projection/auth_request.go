@@ -29,6 +29,8 @@ const (
 	AuthRequestColumnMaxAge        = "max_age"
 	AuthRequestColumnLoginHint     = "login_hint"
 	AuthRequestColumnHintUserID    = "hint_user_id"
+
+	AuthRequestColumnAuthorizationDetails = "authorization_details"
 )
 
 type authRequestProjection struct{}
@@ -60,6 +62,7 @@ func (*authRequestProjection) Init() *old_handler.Check {
 			handler.NewColumn(AuthRequestColumnMaxAge, handler.ColumnTypeInt64, handler.Nullable()),
 			handler.NewColumn(AuthRequestColumnLoginHint, handler.ColumnTypeText, handler.Nullable()),
 			handler.NewColumn(AuthRequestColumnHintUserID, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(AuthRequestColumnAuthorizationDetails, handler.ColumnTypeJSONB, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(AuthRequestColumnInstanceID, AuthRequestColumnID),
 		),
@@ -83,6 +86,10 @@ func (p *authRequestProjection) Reducers() []handler.AggregateReducer {
 					Event:  authrequest.FailedType,
 					Reduce: p.reduceAuthRequestEnded,
 				},
+				{
+					Event:  authrequest.RequestURIUsedType,
+					Reduce: p.reduceRequestURIUsed,
+				},
 			},
 		},
 		{
@@ -121,6 +128,7 @@ func (p *authRequestProjection) reduceAuthRequestAdded(event eventstore.Event) (
 			handler.NewCol(AuthRequestColumnMaxAge, e.MaxAge),
 			handler.NewCol(AuthRequestColumnLoginHint, e.LoginHint),
 			handler.NewCol(AuthRequestColumnHintUserID, e.HintUserID),
+			handler.NewCol(AuthRequestColumnAuthorizationDetails, e.AuthorizationDetails),
 		},
 	), nil
 }
@@ -142,3 +150,33 @@ func (p *authRequestProjection) reduceAuthRequestEnded(event eventstore.Event) (
 		},
 	), nil
 }
+
+func (p *authRequestProjection) reduceRequestURIUsed(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*authrequest.RequestURIUsedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-ooG6a", "reduce.wrong.event.type %s", authrequest.RequestURIUsedType)
+	}
+	// a pushed auth request used for the login v1 is only a template for the auth request of the login v1
+	if !e.LoginV2 {
+		return handler.NewDeleteStatement(
+			e,
+			[]handler.Condition{
+				handler.NewCond(AuthRequestColumnID, e.Aggregate().ID),
+				handler.NewCond(AuthRequestColumnInstanceID, e.Aggregate().InstanceID),
+			},
+		), nil
+	}
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(AuthRequestColumnChangeDate, e.CreationDate()),
+			handler.NewCol(AuthRequestColumnSequence, e.Sequence()),
+			handler.NewCol(AuthRequestColumnLoginClient, e.LoginClient),
+			handler.NewCol(AuthRequestColumnScope, e.Scope),
+		},
+		[]handler.Condition{
+			handler.NewCond(AuthRequestColumnID, e.Aggregate().ID),
+			handler.NewCond(AuthRequestColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
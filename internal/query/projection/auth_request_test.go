@@ -39,7 +39,7 @@ func TestAuthRequestProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.auth_requests (id, instance_id, creation_date, change_date, resource_owner, sequence, login_client, client_id, redirect_uri, scope, prompt, ui_locales, max_age, login_hint, hint_user_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)",
+							expectedStmt: "INSERT INTO projections.auth_requests (id, instance_id, creation_date, change_date, resource_owner, sequence, login_client, client_id, redirect_uri, scope, prompt, ui_locales, max_age, login_hint, hint_user_id, authorization_details) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								"instance-id",
@@ -56,6 +56,7 @@ func TestAuthRequestProjection_reduces(t *testing.T) {
 								gu.Ptr(time.Duration(0)),
 								gu.Ptr("loginHint"),
 								gu.Ptr("hintUserID"),
+								domain.AuthorizationDetails(nil),
 							},
 						},
 					},
@@ -114,6 +115,62 @@ func TestAuthRequestProjection_reduces(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "reduceRequestURIUsed",
+			args: args{
+				event: getEvent(testEvent(
+					authrequest.RequestURIUsedType,
+					authrequest.AggregateType,
+					nil,
+				), authrequest.RequestURIUsedEventMapper),
+			},
+			reduce: (&authRequestProjection{}).reduceRequestURIUsed,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("auth_request"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.auth_requests WHERE (id = $1) AND (instance_id = $2)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRequestURIUsed login v2",
+			args: args{
+				event: getEvent(testEvent(
+					authrequest.RequestURIUsedType,
+					authrequest.AggregateType,
+					[]byte(`{"login_v2":true,"login_client":"loginClient","scope":["openid"],"audience":["audience"]}`),
+				), authrequest.RequestURIUsedEventMapper),
+			},
+			reduce: (&authRequestProjection{}).reduceRequestURIUsed,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("auth_request"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.auth_requests SET (change_date, sequence, login_client, scope) = ($1, $2, $3, $4) WHERE (id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"loginClient",
+								[]string{"openid"},
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -206,7 +206,7 @@ func (p *authNKeyProjection) reduceAuthNKeyEnabledChanged(event eventstore.Event
 			return handler.NewNoOpStatement(event), nil
 		}
 		appID = e.AppID
-		enabled = *e.AuthMethodType == domain.OIDCAuthMethodTypePrivateKeyJWT
+		enabled = e.AuthMethodType.KeysAllowed()
 		changeDate = e.CreationDate()
 		sequence = e.Sequence()
 	default:
@@ -335,6 +335,36 @@ func TestAuthNKeyProjection_reduces(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "reduceAuthNKeyEnabledChanged oidc config self signed tls client auth",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.OIDCConfigChangedType,
+						project.AggregateType,
+						[]byte(`{"appId": "appId", "authMethodType": 5}`),
+					), project.OIDCConfigChangedEventMapper),
+			},
+			reduce: (&authNKeyProjection{}).reduceAuthNKeyEnabledChanged,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.authn_keys2 SET (change_date, sequence, enabled) = ($1, $2, $3) WHERE (object_id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								true,
+								"appId",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "reduceAuthNKeyRemoved app key removed",
 			args: args{
@@ -0,0 +1,124 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/backchannelauth"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	BackchannelAuthRequestProjectionTable = "projections.backchannel_auth_requests"
+
+	BackchannelAuthRequestColumnID             = "id"
+	BackchannelAuthRequestColumnClientID       = "client_id"
+	BackchannelAuthRequestColumnUserID         = "user_id"
+	BackchannelAuthRequestColumnScopes         = "scopes"
+	BackchannelAuthRequestColumnBindingMessage = "binding_message"
+	BackchannelAuthRequestColumnExpires        = "expires"
+	BackchannelAuthRequestColumnCreationDate   = "creation_date"
+	BackchannelAuthRequestColumnChangeDate     = "change_date"
+	BackchannelAuthRequestColumnSequence       = "sequence"
+	BackchannelAuthRequestColumnInstanceID     = "instance_id"
+)
+
+// backchannelAuthRequestProjection holds pending client initiated backchannel authentication requests
+// and makes them search-able by the user they were initiated for.
+// In principle the projected data is only needed for the login UI to let the user approve the request.
+// The token endpoint uses the eventstore directly.
+type backchannelAuthRequestProjection struct{}
+
+func newBackchannelAuthProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(backchannelAuthRequestProjection))
+}
+
+func (*backchannelAuthRequestProjection) Name() string {
+	return BackchannelAuthRequestProjectionTable
+}
+
+func (*backchannelAuthRequestProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(BackchannelAuthRequestColumnID, handler.ColumnTypeText),
+			handler.NewColumn(BackchannelAuthRequestColumnClientID, handler.ColumnTypeText),
+			handler.NewColumn(BackchannelAuthRequestColumnUserID, handler.ColumnTypeText),
+			handler.NewColumn(BackchannelAuthRequestColumnScopes, handler.ColumnTypeTextArray, handler.Nullable()),
+			handler.NewColumn(BackchannelAuthRequestColumnBindingMessage, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(BackchannelAuthRequestColumnExpires, handler.ColumnTypeTimestamp),
+			handler.NewColumn(BackchannelAuthRequestColumnCreationDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(BackchannelAuthRequestColumnChangeDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(BackchannelAuthRequestColumnSequence, handler.ColumnTypeInt64),
+			handler.NewColumn(BackchannelAuthRequestColumnInstanceID, handler.ColumnTypeText),
+		},
+			handler.NewPrimaryKey(BackchannelAuthRequestColumnInstanceID, BackchannelAuthRequestColumnID),
+			handler.WithIndex(handler.NewIndex("user_id", []string{BackchannelAuthRequestColumnInstanceID, BackchannelAuthRequestColumnUserID})),
+		),
+	)
+}
+
+func (p *backchannelAuthRequestProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: backchannelauth.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  backchannelauth.AddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  backchannelauth.ApprovedEventType,
+					Reduce: p.reduceDoneEvents,
+				},
+				{
+					Event:  backchannelauth.CanceledEventType,
+					Reduce: p.reduceDoneEvents,
+				},
+				{
+					Event:  backchannelauth.DoneEventType,
+					Reduce: p.reduceDoneEvents,
+				},
+			},
+		},
+	}
+}
+
+func (p *backchannelAuthRequestProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*backchannelauth.AddedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Ohx6e", "reduce.wrong.event.type %T != %s", event, backchannelauth.AddedEventType)
+	}
+	return handler.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(BackchannelAuthRequestColumnID, e.Aggregate().ID),
+			handler.NewCol(BackchannelAuthRequestColumnClientID, e.ClientID),
+			handler.NewCol(BackchannelAuthRequestColumnUserID, e.UserID),
+			handler.NewCol(BackchannelAuthRequestColumnScopes, e.Scopes),
+			handler.NewCol(BackchannelAuthRequestColumnBindingMessage, e.BindingMessage),
+			handler.NewCol(BackchannelAuthRequestColumnExpires, e.Expires),
+			handler.NewCol(BackchannelAuthRequestColumnCreationDate, e.CreationDate()),
+			handler.NewCol(BackchannelAuthRequestColumnChangeDate, e.CreationDate()),
+			handler.NewCol(BackchannelAuthRequestColumnSequence, e.Sequence()),
+			handler.NewCol(BackchannelAuthRequestColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+// reduceDoneEvents removes the backchannel auth request from the projection.
+func (p *backchannelAuthRequestProjection) reduceDoneEvents(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *backchannelauth.ApprovedEvent, *backchannelauth.CanceledEvent, *backchannelauth.DoneEvent:
+		return handler.NewDeleteStatement(event,
+			[]handler.Condition{
+				handler.NewCond(BackchannelAuthRequestColumnInstanceID, event.Aggregate().InstanceID),
+				handler.NewCond(BackchannelAuthRequestColumnID, event.Aggregate().ID),
+			},
+		), nil
+
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Cai4e", "reduce.wrong.event.type %T", event)
+	}
+}
@@ -2,6 +2,8 @@ package projection
 
 import (
 	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 )
 
 type Config struct {
@@ -14,6 +16,7 @@ type Config struct {
 	HandleActiveInstances time.Duration
 	MaxActiveInstances    uint32
 	TransactionDuration   time.Duration
+	Sharding              handler.Sharding
 	ActiveInstancer       interface {
 		ActiveInstances() []string
 	}
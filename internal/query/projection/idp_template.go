@@ -26,6 +26,11 @@ const (
 	IDPTemplateGitHubEnterpriseTable = IDPTemplateTable + "_" + IDPTemplateGitHubEnterpriseSuffix
 	IDPTemplateGitLabTable           = IDPTemplateTable + "_" + IDPTemplateGitLabSuffix
 	IDPTemplateGitLabSelfHostedTable = IDPTemplateTable + "_" + IDPTemplateGitLabSelfHostedSuffix
+	IDPTemplateOktaTable             = IDPTemplateTable + "_" + IDPTemplateOktaSuffix
+	IDPTemplateKeycloakTable         = IDPTemplateTable + "_" + IDPTemplateKeycloakSuffix
+	IDPTemplateADFSTable             = IDPTemplateTable + "_" + IDPTemplateADFSSuffix
+	IDPTemplateBitbucketTable        = IDPTemplateTable + "_" + IDPTemplateBitbucketSuffix
+	IDPTemplateDiscordTable          = IDPTemplateTable + "_" + IDPTemplateDiscordSuffix
 	IDPTemplateGoogleTable           = IDPTemplateTable + "_" + IDPTemplateGoogleSuffix
 	IDPTemplateLDAPTable             = IDPTemplateTable + "_" + IDPTemplateLDAPSuffix
 	IDPTemplateAppleTable            = IDPTemplateTable + "_" + IDPTemplateAppleSuffix
@@ -39,6 +44,11 @@ const (
 	IDPTemplateGitHubEnterpriseSuffix = "github_enterprise"
 	IDPTemplateGitLabSuffix           = "gitlab"
 	IDPTemplateGitLabSelfHostedSuffix = "gitlab_self_hosted"
+	IDPTemplateOktaSuffix             = "okta"
+	IDPTemplateKeycloakSuffix         = "keycloak"
+	IDPTemplateADFSSuffix             = "adfs"
+	IDPTemplateBitbucketSuffix        = "bitbucket"
+	IDPTemplateDiscordSuffix          = "discord"
 	IDPTemplateGoogleSuffix           = "google"
 	IDPTemplateLDAPSuffix             = "ldap2"
 	IDPTemplateAppleSuffix            = "apple"
@@ -71,6 +81,7 @@ const (
 	OAuthScopesCol                = "scopes"
 	OAuthIDAttributeCol           = "id_attribute"
 	OAuthUsePKCECol               = "use_pkce"
+	OAuthClaimMappingCol          = "claim_mapping"
 
 	OIDCIDCol             = "idp_id"
 	OIDCInstanceIDCol     = "instance_id"
@@ -80,6 +91,7 @@ const (
 	OIDCScopesCol         = "scopes"
 	OIDCIDTokenMappingCol = "id_token_mapping"
 	OIDCUsePKCECol        = "use_pkce"
+	OIDCClaimMappingCol   = "claim_mapping"
 
 	JWTIDCol           = "idp_id"
 	JWTInstanceIDCol   = "instance_id"
@@ -124,6 +136,39 @@ const (
 	GitLabSelfHostedClientSecretCol = "client_secret"
 	GitLabSelfHostedScopesCol       = "scopes"
 
+	OktaIDCol           = "idp_id"
+	OktaInstanceIDCol   = "instance_id"
+	OktaIssuerCol       = "issuer"
+	OktaClientIDCol     = "client_id"
+	OktaClientSecretCol = "client_secret"
+	OktaScopesCol       = "scopes"
+
+	KeycloakIDCol           = "idp_id"
+	KeycloakInstanceIDCol   = "instance_id"
+	KeycloakIssuerCol       = "issuer"
+	KeycloakClientIDCol     = "client_id"
+	KeycloakClientSecretCol = "client_secret"
+	KeycloakScopesCol       = "scopes"
+
+	ADFSIDCol           = "idp_id"
+	ADFSInstanceIDCol   = "instance_id"
+	ADFSIssuerCol       = "issuer"
+	ADFSClientIDCol     = "client_id"
+	ADFSClientSecretCol = "client_secret"
+	ADFSScopesCol       = "scopes"
+
+	BitbucketIDCol           = "idp_id"
+	BitbucketInstanceIDCol   = "instance_id"
+	BitbucketClientIDCol     = "client_id"
+	BitbucketClientSecretCol = "client_secret"
+	BitbucketScopesCol       = "scopes"
+
+	DiscordIDCol           = "idp_id"
+	DiscordInstanceIDCol   = "instance_id"
+	DiscordClientIDCol     = "client_id"
+	DiscordClientSecretCol = "client_secret"
+	DiscordScopesCol       = "scopes"
+
 	GoogleIDCol           = "idp_id"
 	GoogleInstanceIDCol   = "instance_id"
 	GoogleClientIDCol     = "client_id"
@@ -221,6 +266,7 @@ func (*idpTemplateProjection) Init() *old_handler.Check {
 			handler.NewColumn(OAuthScopesCol, handler.ColumnTypeTextArray, handler.Nullable()),
 			handler.NewColumn(OAuthIDAttributeCol, handler.ColumnTypeText),
 			handler.NewColumn(OAuthUsePKCECol, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(OAuthClaimMappingCol, handler.ColumnTypeJSONB, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(OAuthInstanceIDCol, OAuthIDCol),
 			IDPTemplateOAuthSuffix,
@@ -235,6 +281,7 @@ func (*idpTemplateProjection) Init() *old_handler.Check {
 			handler.NewColumn(OIDCScopesCol, handler.ColumnTypeTextArray, handler.Nullable()),
 			handler.NewColumn(OIDCIDTokenMappingCol, handler.ColumnTypeBool, handler.Default(false)),
 			handler.NewColumn(OIDCUsePKCECol, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(OIDCClaimMappingCol, handler.ColumnTypeJSONB, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(OIDCInstanceIDCol, OIDCIDCol),
 			IDPTemplateOIDCSuffix,
@@ -313,6 +360,64 @@ func (*idpTemplateProjection) Init() *old_handler.Check {
 			IDPTemplateGitLabSelfHostedSuffix,
 			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
 		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(OktaIDCol, handler.ColumnTypeText),
+			handler.NewColumn(OktaInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(OktaIssuerCol, handler.ColumnTypeText),
+			handler.NewColumn(OktaClientIDCol, handler.ColumnTypeText),
+			handler.NewColumn(OktaClientSecretCol, handler.ColumnTypeJSONB),
+			handler.NewColumn(OktaScopesCol, handler.ColumnTypeTextArray, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(OktaInstanceIDCol, OktaIDCol),
+			IDPTemplateOktaSuffix,
+			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
+		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(KeycloakIDCol, handler.ColumnTypeText),
+			handler.NewColumn(KeycloakInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(KeycloakIssuerCol, handler.ColumnTypeText),
+			handler.NewColumn(KeycloakClientIDCol, handler.ColumnTypeText),
+			handler.NewColumn(KeycloakClientSecretCol, handler.ColumnTypeJSONB),
+			handler.NewColumn(KeycloakScopesCol, handler.ColumnTypeTextArray, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(KeycloakInstanceIDCol, KeycloakIDCol),
+			IDPTemplateKeycloakSuffix,
+			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
+		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(ADFSIDCol, handler.ColumnTypeText),
+			handler.NewColumn(ADFSInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(ADFSIssuerCol, handler.ColumnTypeText),
+			handler.NewColumn(ADFSClientIDCol, handler.ColumnTypeText),
+			handler.NewColumn(ADFSClientSecretCol, handler.ColumnTypeJSONB),
+			handler.NewColumn(ADFSScopesCol, handler.ColumnTypeTextArray, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(ADFSInstanceIDCol, ADFSIDCol),
+			IDPTemplateADFSSuffix,
+			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
+		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(BitbucketIDCol, handler.ColumnTypeText),
+			handler.NewColumn(BitbucketInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(BitbucketClientIDCol, handler.ColumnTypeText),
+			handler.NewColumn(BitbucketClientSecretCol, handler.ColumnTypeJSONB),
+			handler.NewColumn(BitbucketScopesCol, handler.ColumnTypeTextArray, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(BitbucketInstanceIDCol, BitbucketIDCol),
+			IDPTemplateBitbucketSuffix,
+			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
+		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(DiscordIDCol, handler.ColumnTypeText),
+			handler.NewColumn(DiscordInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(DiscordClientIDCol, handler.ColumnTypeText),
+			handler.NewColumn(DiscordClientSecretCol, handler.ColumnTypeJSONB),
+			handler.NewColumn(DiscordScopesCol, handler.ColumnTypeTextArray, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(DiscordInstanceIDCol, DiscordIDCol),
+			IDPTemplateDiscordSuffix,
+			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
+		),
 		handler.NewSuffixedTable([]*handler.InitColumn{
 			handler.NewColumn(GoogleIDCol, handler.ColumnTypeText),
 			handler.NewColumn(GoogleInstanceIDCol, handler.ColumnTypeText),
@@ -488,6 +593,46 @@ func (p *idpTemplateProjection) Reducers() []handler.AggregateReducer {
 					Event:  instance.GitLabSelfHostedIDPChangedEventType,
 					Reduce: p.reduceGitLabSelfHostedIDPChanged,
 				},
+				{
+					Event:  instance.OktaIDPAddedEventType,
+					Reduce: p.reduceOktaIDPAdded,
+				},
+				{
+					Event:  instance.OktaIDPChangedEventType,
+					Reduce: p.reduceOktaIDPChanged,
+				},
+				{
+					Event:  instance.KeycloakIDPAddedEventType,
+					Reduce: p.reduceKeycloakIDPAdded,
+				},
+				{
+					Event:  instance.KeycloakIDPChangedEventType,
+					Reduce: p.reduceKeycloakIDPChanged,
+				},
+				{
+					Event:  instance.ADFSIDPAddedEventType,
+					Reduce: p.reduceADFSIDPAdded,
+				},
+				{
+					Event:  instance.ADFSIDPChangedEventType,
+					Reduce: p.reduceADFSIDPChanged,
+				},
+				{
+					Event:  instance.BitbucketIDPAddedEventType,
+					Reduce: p.reduceBitbucketIDPAdded,
+				},
+				{
+					Event:  instance.BitbucketIDPChangedEventType,
+					Reduce: p.reduceBitbucketIDPChanged,
+				},
+				{
+					Event:  instance.DiscordIDPAddedEventType,
+					Reduce: p.reduceDiscordIDPAdded,
+				},
+				{
+					Event:  instance.DiscordIDPChangedEventType,
+					Reduce: p.reduceDiscordIDPChanged,
+				},
 				{
 					Event:  instance.GoogleIDPAddedEventType,
 					Reduce: p.reduceGoogleIDPAdded,
@@ -633,6 +778,46 @@ func (p *idpTemplateProjection) Reducers() []handler.AggregateReducer {
 					Event:  org.GitLabSelfHostedIDPChangedEventType,
 					Reduce: p.reduceGitLabSelfHostedIDPChanged,
 				},
+				{
+					Event:  org.OktaIDPAddedEventType,
+					Reduce: p.reduceOktaIDPAdded,
+				},
+				{
+					Event:  org.OktaIDPChangedEventType,
+					Reduce: p.reduceOktaIDPChanged,
+				},
+				{
+					Event:  org.KeycloakIDPAddedEventType,
+					Reduce: p.reduceKeycloakIDPAdded,
+				},
+				{
+					Event:  org.KeycloakIDPChangedEventType,
+					Reduce: p.reduceKeycloakIDPChanged,
+				},
+				{
+					Event:  org.ADFSIDPAddedEventType,
+					Reduce: p.reduceADFSIDPAdded,
+				},
+				{
+					Event:  org.ADFSIDPChangedEventType,
+					Reduce: p.reduceADFSIDPChanged,
+				},
+				{
+					Event:  org.BitbucketIDPAddedEventType,
+					Reduce: p.reduceBitbucketIDPAdded,
+				},
+				{
+					Event:  org.BitbucketIDPChangedEventType,
+					Reduce: p.reduceBitbucketIDPChanged,
+				},
+				{
+					Event:  org.DiscordIDPAddedEventType,
+					Reduce: p.reduceDiscordIDPAdded,
+				},
+				{
+					Event:  org.DiscordIDPChangedEventType,
+					Reduce: p.reduceDiscordIDPChanged,
+				},
 				{
 					Event:  org.GoogleIDPAddedEventType,
 					Reduce: p.reduceGoogleIDPAdded,
@@ -718,18 +903,7 @@ func (p *idpTemplateProjection) reduceOAuthIDPAdded(event eventstore.Event) (*ha
 			},
 		),
 		handler.AddCreateStatement(
-			[]handler.Column{
-				handler.NewCol(OAuthIDCol, idpEvent.ID),
-				handler.NewCol(OAuthInstanceIDCol, idpEvent.Aggregate().InstanceID),
-				handler.NewCol(OAuthClientIDCol, idpEvent.ClientID),
-				handler.NewCol(OAuthClientSecretCol, idpEvent.ClientSecret),
-				handler.NewCol(OAuthAuthorizationEndpointCol, idpEvent.AuthorizationEndpoint),
-				handler.NewCol(OAuthTokenEndpointCol, idpEvent.TokenEndpoint),
-				handler.NewCol(OAuthUserEndpointCol, idpEvent.UserEndpoint),
-				handler.NewCol(OAuthScopesCol, database.TextArray[string](idpEvent.Scopes)),
-				handler.NewCol(OAuthIDAttributeCol, idpEvent.IDAttribute),
-				handler.NewCol(OAuthUsePKCECol, idpEvent.UsePKCE),
-			},
+			reduceOAuthIDPAddedColumns(idpEvent),
 			handler.WithTableSuffix(IDPTemplateOAuthSuffix),
 		),
 	), nil
@@ -812,16 +986,7 @@ func (p *idpTemplateProjection) reduceOIDCIDPAdded(event eventstore.Event) (*han
 			},
 		),
 		handler.AddCreateStatement(
-			[]handler.Column{
-				handler.NewCol(OIDCIDCol, idpEvent.ID),
-				handler.NewCol(OIDCInstanceIDCol, idpEvent.Aggregate().InstanceID),
-				handler.NewCol(OIDCIssuerCol, idpEvent.Issuer),
-				handler.NewCol(OIDCClientIDCol, idpEvent.ClientID),
-				handler.NewCol(OIDCClientSecretCol, idpEvent.ClientSecret),
-				handler.NewCol(OIDCScopesCol, database.TextArray[string](idpEvent.Scopes)),
-				handler.NewCol(OIDCIDTokenMappingCol, idpEvent.IsIDTokenMapping),
-				handler.NewCol(OIDCUsePKCECol, idpEvent.UsePKCE),
-			},
+			reduceOIDCIDPAddedColumns(idpEvent),
 			handler.WithTableSuffix(IDPTemplateOIDCSuffix),
 		),
 	), nil
@@ -1770,6 +1935,454 @@ func (p *idpTemplateProjection) reduceGitLabSelfHostedIDPChanged(event eventstor
 	), nil
 }
 
+func (p *idpTemplateProjection) reduceOktaIDPAdded(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.OktaIDPAddedEvent
+	var idpOwnerType domain.IdentityProviderType
+	switch e := event.(type) {
+	case *org.OktaIDPAddedEvent:
+		idpEvent = e.OktaIDPAddedEvent
+		idpOwnerType = domain.IdentityProviderTypeOrg
+	case *instance.OktaIDPAddedEvent:
+		idpEvent = e.OktaIDPAddedEvent
+		idpOwnerType = domain.IdentityProviderTypeSystem
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-l9VSL", "reduce.wrong.event.type %v", []eventstore.EventType{org.OktaIDPAddedEventType, instance.OktaIDPAddedEventType})
+	}
+
+	return handler.NewMultiStatement(
+		&idpEvent,
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(IDPTemplateIDCol, idpEvent.ID),
+				handler.NewCol(IDPTemplateCreationDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPTemplateChangeDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPTemplateSequenceCol, idpEvent.Sequence()),
+				handler.NewCol(IDPTemplateResourceOwnerCol, idpEvent.Aggregate().ResourceOwner),
+				handler.NewCol(IDPTemplateInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(IDPTemplateStateCol, domain.IDPStateActive),
+				handler.NewCol(IDPTemplateNameCol, idpEvent.Name),
+				handler.NewCol(IDPTemplateOwnerTypeCol, idpOwnerType),
+				handler.NewCol(IDPTemplateTypeCol, domain.IDPTypeOkta),
+				handler.NewCol(IDPTemplateIsCreationAllowedCol, idpEvent.IsCreationAllowed),
+				handler.NewCol(IDPTemplateIsLinkingAllowedCol, idpEvent.IsLinkingAllowed),
+				handler.NewCol(IDPTemplateIsAutoCreationCol, idpEvent.IsAutoCreation),
+				handler.NewCol(IDPTemplateIsAutoUpdateCol, idpEvent.IsAutoUpdate),
+				handler.NewCol(IDPTemplateAutoLinkingCol, idpEvent.AutoLinkingOption),
+			},
+		),
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(OktaIDCol, idpEvent.ID),
+				handler.NewCol(OktaInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(OktaIssuerCol, idpEvent.Issuer),
+				handler.NewCol(OktaClientIDCol, idpEvent.ClientID),
+				handler.NewCol(OktaClientSecretCol, idpEvent.ClientSecret),
+				handler.NewCol(OktaScopesCol, database.TextArray[string](idpEvent.Scopes)),
+			},
+			handler.WithTableSuffix(IDPTemplateOktaSuffix),
+		),
+	), nil
+}
+
+func (p *idpTemplateProjection) reduceOktaIDPChanged(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.OktaIDPChangedEvent
+	switch e := event.(type) {
+	case *org.OktaIDPChangedEvent:
+		idpEvent = e.OktaIDPChangedEvent
+	case *instance.OktaIDPChangedEvent:
+		idpEvent = e.OktaIDPChangedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-WBsEE", "reduce.wrong.event.type %v", []eventstore.EventType{org.OktaIDPChangedEventType, instance.OktaIDPChangedEventType})
+	}
+
+	ops := make([]func(eventstore.Event) handler.Exec, 0, 2)
+	ops = append(ops,
+		handler.AddUpdateStatement(
+			reduceIDPChangedTemplateColumns(idpEvent.Name, idpEvent.CreationDate(), idpEvent.Sequence(), idpEvent.OptionChanges),
+			[]handler.Condition{
+				handler.NewCond(IDPTemplateIDCol, idpEvent.ID),
+				handler.NewCond(IDPTemplateInstanceIDCol, idpEvent.Aggregate().InstanceID),
+			},
+		),
+	)
+	oktaCols := reduceOktaIDPChangedColumns(idpEvent)
+	if len(oktaCols) > 0 {
+		ops = append(ops,
+			handler.AddUpdateStatement(
+				oktaCols,
+				[]handler.Condition{
+					handler.NewCond(OktaIDCol, idpEvent.ID),
+					handler.NewCond(OktaInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				},
+				handler.WithTableSuffix(IDPTemplateOktaSuffix),
+			),
+		)
+	}
+
+	return handler.NewMultiStatement(
+		&idpEvent,
+		ops...,
+	), nil
+}
+
+func (p *idpTemplateProjection) reduceKeycloakIDPAdded(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.KeycloakIDPAddedEvent
+	var idpOwnerType domain.IdentityProviderType
+	switch e := event.(type) {
+	case *org.KeycloakIDPAddedEvent:
+		idpEvent = e.KeycloakIDPAddedEvent
+		idpOwnerType = domain.IdentityProviderTypeOrg
+	case *instance.KeycloakIDPAddedEvent:
+		idpEvent = e.KeycloakIDPAddedEvent
+		idpOwnerType = domain.IdentityProviderTypeSystem
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Qcn46", "reduce.wrong.event.type %v", []eventstore.EventType{org.KeycloakIDPAddedEventType, instance.KeycloakIDPAddedEventType})
+	}
+
+	return handler.NewMultiStatement(
+		&idpEvent,
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(IDPTemplateIDCol, idpEvent.ID),
+				handler.NewCol(IDPTemplateCreationDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPTemplateChangeDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPTemplateSequenceCol, idpEvent.Sequence()),
+				handler.NewCol(IDPTemplateResourceOwnerCol, idpEvent.Aggregate().ResourceOwner),
+				handler.NewCol(IDPTemplateInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(IDPTemplateStateCol, domain.IDPStateActive),
+				handler.NewCol(IDPTemplateNameCol, idpEvent.Name),
+				handler.NewCol(IDPTemplateOwnerTypeCol, idpOwnerType),
+				handler.NewCol(IDPTemplateTypeCol, domain.IDPTypeKeycloak),
+				handler.NewCol(IDPTemplateIsCreationAllowedCol, idpEvent.IsCreationAllowed),
+				handler.NewCol(IDPTemplateIsLinkingAllowedCol, idpEvent.IsLinkingAllowed),
+				handler.NewCol(IDPTemplateIsAutoCreationCol, idpEvent.IsAutoCreation),
+				handler.NewCol(IDPTemplateIsAutoUpdateCol, idpEvent.IsAutoUpdate),
+				handler.NewCol(IDPTemplateAutoLinkingCol, idpEvent.AutoLinkingOption),
+			},
+		),
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(KeycloakIDCol, idpEvent.ID),
+				handler.NewCol(KeycloakInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(KeycloakIssuerCol, idpEvent.Issuer),
+				handler.NewCol(KeycloakClientIDCol, idpEvent.ClientID),
+				handler.NewCol(KeycloakClientSecretCol, idpEvent.ClientSecret),
+				handler.NewCol(KeycloakScopesCol, database.TextArray[string](idpEvent.Scopes)),
+			},
+			handler.WithTableSuffix(IDPTemplateKeycloakSuffix),
+		),
+	), nil
+}
+
+func (p *idpTemplateProjection) reduceKeycloakIDPChanged(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.KeycloakIDPChangedEvent
+	switch e := event.(type) {
+	case *org.KeycloakIDPChangedEvent:
+		idpEvent = e.KeycloakIDPChangedEvent
+	case *instance.KeycloakIDPChangedEvent:
+		idpEvent = e.KeycloakIDPChangedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Ow6Dd", "reduce.wrong.event.type %v", []eventstore.EventType{org.KeycloakIDPChangedEventType, instance.KeycloakIDPChangedEventType})
+	}
+
+	ops := make([]func(eventstore.Event) handler.Exec, 0, 2)
+	ops = append(ops,
+		handler.AddUpdateStatement(
+			reduceIDPChangedTemplateColumns(idpEvent.Name, idpEvent.CreationDate(), idpEvent.Sequence(), idpEvent.OptionChanges),
+			[]handler.Condition{
+				handler.NewCond(IDPTemplateIDCol, idpEvent.ID),
+				handler.NewCond(IDPTemplateInstanceIDCol, idpEvent.Aggregate().InstanceID),
+			},
+		),
+	)
+	keycloakCols := reduceKeycloakIDPChangedColumns(idpEvent)
+	if len(keycloakCols) > 0 {
+		ops = append(ops,
+			handler.AddUpdateStatement(
+				keycloakCols,
+				[]handler.Condition{
+					handler.NewCond(KeycloakIDCol, idpEvent.ID),
+					handler.NewCond(KeycloakInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				},
+				handler.WithTableSuffix(IDPTemplateKeycloakSuffix),
+			),
+		)
+	}
+
+	return handler.NewMultiStatement(
+		&idpEvent,
+		ops...,
+	), nil
+}
+
+func (p *idpTemplateProjection) reduceADFSIDPAdded(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.ADFSIDPAddedEvent
+	var idpOwnerType domain.IdentityProviderType
+	switch e := event.(type) {
+	case *org.ADFSIDPAddedEvent:
+		idpEvent = e.ADFSIDPAddedEvent
+		idpOwnerType = domain.IdentityProviderTypeOrg
+	case *instance.ADFSIDPAddedEvent:
+		idpEvent = e.ADFSIDPAddedEvent
+		idpOwnerType = domain.IdentityProviderTypeSystem
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-zPlAM", "reduce.wrong.event.type %v", []eventstore.EventType{org.ADFSIDPAddedEventType, instance.ADFSIDPAddedEventType})
+	}
+
+	return handler.NewMultiStatement(
+		&idpEvent,
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(IDPTemplateIDCol, idpEvent.ID),
+				handler.NewCol(IDPTemplateCreationDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPTemplateChangeDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPTemplateSequenceCol, idpEvent.Sequence()),
+				handler.NewCol(IDPTemplateResourceOwnerCol, idpEvent.Aggregate().ResourceOwner),
+				handler.NewCol(IDPTemplateInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(IDPTemplateStateCol, domain.IDPStateActive),
+				handler.NewCol(IDPTemplateNameCol, idpEvent.Name),
+				handler.NewCol(IDPTemplateOwnerTypeCol, idpOwnerType),
+				handler.NewCol(IDPTemplateTypeCol, domain.IDPTypeADFS),
+				handler.NewCol(IDPTemplateIsCreationAllowedCol, idpEvent.IsCreationAllowed),
+				handler.NewCol(IDPTemplateIsLinkingAllowedCol, idpEvent.IsLinkingAllowed),
+				handler.NewCol(IDPTemplateIsAutoCreationCol, idpEvent.IsAutoCreation),
+				handler.NewCol(IDPTemplateIsAutoUpdateCol, idpEvent.IsAutoUpdate),
+				handler.NewCol(IDPTemplateAutoLinkingCol, idpEvent.AutoLinkingOption),
+			},
+		),
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(ADFSIDCol, idpEvent.ID),
+				handler.NewCol(ADFSInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(ADFSIssuerCol, idpEvent.Issuer),
+				handler.NewCol(ADFSClientIDCol, idpEvent.ClientID),
+				handler.NewCol(ADFSClientSecretCol, idpEvent.ClientSecret),
+				handler.NewCol(ADFSScopesCol, database.TextArray[string](idpEvent.Scopes)),
+			},
+			handler.WithTableSuffix(IDPTemplateADFSSuffix),
+		),
+	), nil
+}
+
+func (p *idpTemplateProjection) reduceADFSIDPChanged(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.ADFSIDPChangedEvent
+	switch e := event.(type) {
+	case *org.ADFSIDPChangedEvent:
+		idpEvent = e.ADFSIDPChangedEvent
+	case *instance.ADFSIDPChangedEvent:
+		idpEvent = e.ADFSIDPChangedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-DxlGy", "reduce.wrong.event.type %v", []eventstore.EventType{org.ADFSIDPChangedEventType, instance.ADFSIDPChangedEventType})
+	}
+
+	ops := make([]func(eventstore.Event) handler.Exec, 0, 2)
+	ops = append(ops,
+		handler.AddUpdateStatement(
+			reduceIDPChangedTemplateColumns(idpEvent.Name, idpEvent.CreationDate(), idpEvent.Sequence(), idpEvent.OptionChanges),
+			[]handler.Condition{
+				handler.NewCond(IDPTemplateIDCol, idpEvent.ID),
+				handler.NewCond(IDPTemplateInstanceIDCol, idpEvent.Aggregate().InstanceID),
+			},
+		),
+	)
+	adfsCols := reduceADFSIDPChangedColumns(idpEvent)
+	if len(adfsCols) > 0 {
+		ops = append(ops,
+			handler.AddUpdateStatement(
+				adfsCols,
+				[]handler.Condition{
+					handler.NewCond(ADFSIDCol, idpEvent.ID),
+					handler.NewCond(ADFSInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				},
+				handler.WithTableSuffix(IDPTemplateADFSSuffix),
+			),
+		)
+	}
+
+	return handler.NewMultiStatement(
+		&idpEvent,
+		ops...,
+	), nil
+}
+
+func (p *idpTemplateProjection) reduceBitbucketIDPAdded(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.BitbucketIDPAddedEvent
+	var idpOwnerType domain.IdentityProviderType
+	switch e := event.(type) {
+	case *org.BitbucketIDPAddedEvent:
+		idpEvent = e.BitbucketIDPAddedEvent
+		idpOwnerType = domain.IdentityProviderTypeOrg
+	case *instance.BitbucketIDPAddedEvent:
+		idpEvent = e.BitbucketIDPAddedEvent
+		idpOwnerType = domain.IdentityProviderTypeSystem
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-FrSpv", "reduce.wrong.event.type %v", []eventstore.EventType{org.BitbucketIDPAddedEventType, instance.BitbucketIDPAddedEventType})
+	}
+
+	return handler.NewMultiStatement(
+		&idpEvent,
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(IDPTemplateIDCol, idpEvent.ID),
+				handler.NewCol(IDPTemplateCreationDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPTemplateChangeDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPTemplateSequenceCol, idpEvent.Sequence()),
+				handler.NewCol(IDPTemplateResourceOwnerCol, idpEvent.Aggregate().ResourceOwner),
+				handler.NewCol(IDPTemplateInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(IDPTemplateStateCol, domain.IDPStateActive),
+				handler.NewCol(IDPTemplateNameCol, idpEvent.Name),
+				handler.NewCol(IDPTemplateOwnerTypeCol, idpOwnerType),
+				handler.NewCol(IDPTemplateTypeCol, domain.IDPTypeBitbucket),
+				handler.NewCol(IDPTemplateIsCreationAllowedCol, idpEvent.IsCreationAllowed),
+				handler.NewCol(IDPTemplateIsLinkingAllowedCol, idpEvent.IsLinkingAllowed),
+				handler.NewCol(IDPTemplateIsAutoCreationCol, idpEvent.IsAutoCreation),
+				handler.NewCol(IDPTemplateIsAutoUpdateCol, idpEvent.IsAutoUpdate),
+				handler.NewCol(IDPTemplateAutoLinkingCol, idpEvent.AutoLinkingOption),
+			},
+		),
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(BitbucketIDCol, idpEvent.ID),
+				handler.NewCol(BitbucketInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(BitbucketClientIDCol, idpEvent.ClientID),
+				handler.NewCol(BitbucketClientSecretCol, idpEvent.ClientSecret),
+				handler.NewCol(BitbucketScopesCol, database.TextArray[string](idpEvent.Scopes)),
+			},
+			handler.WithTableSuffix(IDPTemplateBitbucketSuffix),
+		),
+	), nil
+}
+
+func (p *idpTemplateProjection) reduceBitbucketIDPChanged(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.BitbucketIDPChangedEvent
+	switch e := event.(type) {
+	case *org.BitbucketIDPChangedEvent:
+		idpEvent = e.BitbucketIDPChangedEvent
+	case *instance.BitbucketIDPChangedEvent:
+		idpEvent = e.BitbucketIDPChangedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-IqiVu", "reduce.wrong.event.type %v", []eventstore.EventType{org.BitbucketIDPChangedEventType, instance.BitbucketIDPChangedEventType})
+	}
+
+	ops := make([]func(eventstore.Event) handler.Exec, 0, 2)
+	ops = append(ops,
+		handler.AddUpdateStatement(
+			reduceIDPChangedTemplateColumns(idpEvent.Name, idpEvent.CreationDate(), idpEvent.Sequence(), idpEvent.OptionChanges),
+			[]handler.Condition{
+				handler.NewCond(IDPTemplateIDCol, idpEvent.ID),
+				handler.NewCond(IDPTemplateInstanceIDCol, idpEvent.Aggregate().InstanceID),
+			},
+		),
+	)
+	bitbucketCols := reduceBitbucketIDPChangedColumns(idpEvent)
+	if len(bitbucketCols) > 0 {
+		ops = append(ops,
+			handler.AddUpdateStatement(
+				bitbucketCols,
+				[]handler.Condition{
+					handler.NewCond(BitbucketIDCol, idpEvent.ID),
+					handler.NewCond(BitbucketInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				},
+				handler.WithTableSuffix(IDPTemplateBitbucketSuffix),
+			),
+		)
+	}
+
+	return handler.NewMultiStatement(
+		&idpEvent,
+		ops...,
+	), nil
+}
+
+func (p *idpTemplateProjection) reduceDiscordIDPAdded(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.DiscordIDPAddedEvent
+	var idpOwnerType domain.IdentityProviderType
+	switch e := event.(type) {
+	case *org.DiscordIDPAddedEvent:
+		idpEvent = e.DiscordIDPAddedEvent
+		idpOwnerType = domain.IdentityProviderTypeOrg
+	case *instance.DiscordIDPAddedEvent:
+		idpEvent = e.DiscordIDPAddedEvent
+		idpOwnerType = domain.IdentityProviderTypeSystem
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-TU5yw", "reduce.wrong.event.type %v", []eventstore.EventType{org.DiscordIDPAddedEventType, instance.DiscordIDPAddedEventType})
+	}
+
+	return handler.NewMultiStatement(
+		&idpEvent,
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(IDPTemplateIDCol, idpEvent.ID),
+				handler.NewCol(IDPTemplateCreationDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPTemplateChangeDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPTemplateSequenceCol, idpEvent.Sequence()),
+				handler.NewCol(IDPTemplateResourceOwnerCol, idpEvent.Aggregate().ResourceOwner),
+				handler.NewCol(IDPTemplateInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(IDPTemplateStateCol, domain.IDPStateActive),
+				handler.NewCol(IDPTemplateNameCol, idpEvent.Name),
+				handler.NewCol(IDPTemplateOwnerTypeCol, idpOwnerType),
+				handler.NewCol(IDPTemplateTypeCol, domain.IDPTypeDiscord),
+				handler.NewCol(IDPTemplateIsCreationAllowedCol, idpEvent.IsCreationAllowed),
+				handler.NewCol(IDPTemplateIsLinkingAllowedCol, idpEvent.IsLinkingAllowed),
+				handler.NewCol(IDPTemplateIsAutoCreationCol, idpEvent.IsAutoCreation),
+				handler.NewCol(IDPTemplateIsAutoUpdateCol, idpEvent.IsAutoUpdate),
+				handler.NewCol(IDPTemplateAutoLinkingCol, idpEvent.AutoLinkingOption),
+			},
+		),
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(DiscordIDCol, idpEvent.ID),
+				handler.NewCol(DiscordInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(DiscordClientIDCol, idpEvent.ClientID),
+				handler.NewCol(DiscordClientSecretCol, idpEvent.ClientSecret),
+				handler.NewCol(DiscordScopesCol, database.TextArray[string](idpEvent.Scopes)),
+			},
+			handler.WithTableSuffix(IDPTemplateDiscordSuffix),
+		),
+	), nil
+}
+
+func (p *idpTemplateProjection) reduceDiscordIDPChanged(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.DiscordIDPChangedEvent
+	switch e := event.(type) {
+	case *org.DiscordIDPChangedEvent:
+		idpEvent = e.DiscordIDPChangedEvent
+	case *instance.DiscordIDPChangedEvent:
+		idpEvent = e.DiscordIDPChangedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-HLjnu", "reduce.wrong.event.type %v", []eventstore.EventType{org.DiscordIDPChangedEventType, instance.DiscordIDPChangedEventType})
+	}
+
+	ops := make([]func(eventstore.Event) handler.Exec, 0, 2)
+	ops = append(ops,
+		handler.AddUpdateStatement(
+			reduceIDPChangedTemplateColumns(idpEvent.Name, idpEvent.CreationDate(), idpEvent.Sequence(), idpEvent.OptionChanges),
+			[]handler.Condition{
+				handler.NewCond(IDPTemplateIDCol, idpEvent.ID),
+				handler.NewCond(IDPTemplateInstanceIDCol, idpEvent.Aggregate().InstanceID),
+			},
+		),
+	)
+	discordCols := reduceDiscordIDPChangedColumns(idpEvent)
+	if len(discordCols) > 0 {
+		ops = append(ops,
+			handler.AddUpdateStatement(
+				discordCols,
+				[]handler.Condition{
+					handler.NewCond(DiscordIDCol, idpEvent.ID),
+					handler.NewCond(DiscordInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				},
+				handler.WithTableSuffix(IDPTemplateDiscordSuffix),
+			),
+		)
+	}
+
+	return handler.NewMultiStatement(
+		&idpEvent,
+		ops...,
+	), nil
+}
+
 func (p *idpTemplateProjection) reduceGoogleIDPAdded(event eventstore.Event) (*handler.Statement, error) {
 	var idpEvent idp.GoogleIDPAddedEvent
 	var idpOwnerType domain.IdentityProviderType
@@ -2240,6 +2853,43 @@ func reduceIDPChangedTemplateColumns(name *string, creationDate time.Time, seque
 	)
 }
 
+func reduceOAuthIDPAddedColumns(idpEvent idp.OAuthIDPAddedEvent) []handler.Column {
+	oauthCols := []handler.Column{
+		handler.NewCol(OAuthIDCol, idpEvent.ID),
+		handler.NewCol(OAuthInstanceIDCol, idpEvent.Aggregate().InstanceID),
+		handler.NewCol(OAuthClientIDCol, idpEvent.ClientID),
+		handler.NewCol(OAuthClientSecretCol, idpEvent.ClientSecret),
+		handler.NewCol(OAuthAuthorizationEndpointCol, idpEvent.AuthorizationEndpoint),
+		handler.NewCol(OAuthTokenEndpointCol, idpEvent.TokenEndpoint),
+		handler.NewCol(OAuthUserEndpointCol, idpEvent.UserEndpoint),
+		handler.NewCol(OAuthScopesCol, database.TextArray[string](idpEvent.Scopes)),
+		handler.NewCol(OAuthIDAttributeCol, idpEvent.IDAttribute),
+		handler.NewCol(OAuthUsePKCECol, idpEvent.UsePKCE),
+	}
+	// the column is only set if a mapping was configured, so the stored default stays NULL
+	if idpEvent.ClaimMapping != nil {
+		oauthCols = append(oauthCols, handler.NewCol(OAuthClaimMappingCol, idpEvent.ClaimMapping))
+	}
+	return oauthCols
+}
+
+func reduceOIDCIDPAddedColumns(idpEvent idp.OIDCIDPAddedEvent) []handler.Column {
+	oidcCols := []handler.Column{
+		handler.NewCol(OIDCIDCol, idpEvent.ID),
+		handler.NewCol(OIDCInstanceIDCol, idpEvent.Aggregate().InstanceID),
+		handler.NewCol(OIDCIssuerCol, idpEvent.Issuer),
+		handler.NewCol(OIDCClientIDCol, idpEvent.ClientID),
+		handler.NewCol(OIDCClientSecretCol, idpEvent.ClientSecret),
+		handler.NewCol(OIDCScopesCol, database.TextArray[string](idpEvent.Scopes)),
+		handler.NewCol(OIDCIDTokenMappingCol, idpEvent.IsIDTokenMapping),
+		handler.NewCol(OIDCUsePKCECol, idpEvent.UsePKCE),
+	}
+	if idpEvent.ClaimMapping != nil {
+		oidcCols = append(oidcCols, handler.NewCol(OIDCClaimMappingCol, idpEvent.ClaimMapping))
+	}
+	return oidcCols
+}
+
 func reduceOAuthIDPChangedColumns(idpEvent idp.OAuthIDPChangedEvent) []handler.Column {
 	oauthCols := make([]handler.Column, 0, 7)
 	if idpEvent.ClientID != nil {
@@ -2266,6 +2916,9 @@ func reduceOAuthIDPChangedColumns(idpEvent idp.OAuthIDPChangedEvent) []handler.C
 	if idpEvent.UsePKCE != nil {
 		oauthCols = append(oauthCols, handler.NewCol(OAuthUsePKCECol, *idpEvent.UsePKCE))
 	}
+	if idpEvent.ClaimMapping != nil {
+		oauthCols = append(oauthCols, handler.NewCol(OAuthClaimMappingCol, idpEvent.ClaimMapping))
+	}
 	return oauthCols
 }
 
@@ -2289,6 +2942,9 @@ func reduceOIDCIDPChangedColumns(idpEvent idp.OIDCIDPChangedEvent) []handler.Col
 	if idpEvent.UsePKCE != nil {
 		oidcCols = append(oidcCols, handler.NewCol(OIDCUsePKCECol, *idpEvent.UsePKCE))
 	}
+	if idpEvent.ClaimMapping != nil {
+		oidcCols = append(oidcCols, handler.NewCol(OIDCClaimMappingCol, idpEvent.ClaimMapping))
+	}
 	return oidcCols
 }
 
@@ -2397,6 +3053,85 @@ func reduceGitLabSelfHostedIDPChangedColumns(idpEvent idp.GitLabSelfHostedIDPCha
 	return gitlabCols
 }
 
+func reduceOktaIDPChangedColumns(idpEvent idp.OktaIDPChangedEvent) []handler.Column {
+	oktaCols := make([]handler.Column, 0, 4)
+	if idpEvent.Issuer != nil {
+		oktaCols = append(oktaCols, handler.NewCol(OktaIssuerCol, *idpEvent.Issuer))
+	}
+	if idpEvent.ClientID != nil {
+		oktaCols = append(oktaCols, handler.NewCol(OktaClientIDCol, *idpEvent.ClientID))
+	}
+	if idpEvent.ClientSecret != nil {
+		oktaCols = append(oktaCols, handler.NewCol(OktaClientSecretCol, *idpEvent.ClientSecret))
+	}
+	if idpEvent.Scopes != nil {
+		oktaCols = append(oktaCols, handler.NewCol(OktaScopesCol, database.TextArray[string](idpEvent.Scopes)))
+	}
+	return oktaCols
+}
+
+func reduceKeycloakIDPChangedColumns(idpEvent idp.KeycloakIDPChangedEvent) []handler.Column {
+	keycloakCols := make([]handler.Column, 0, 4)
+	if idpEvent.Issuer != nil {
+		keycloakCols = append(keycloakCols, handler.NewCol(KeycloakIssuerCol, *idpEvent.Issuer))
+	}
+	if idpEvent.ClientID != nil {
+		keycloakCols = append(keycloakCols, handler.NewCol(KeycloakClientIDCol, *idpEvent.ClientID))
+	}
+	if idpEvent.ClientSecret != nil {
+		keycloakCols = append(keycloakCols, handler.NewCol(KeycloakClientSecretCol, *idpEvent.ClientSecret))
+	}
+	if idpEvent.Scopes != nil {
+		keycloakCols = append(keycloakCols, handler.NewCol(KeycloakScopesCol, database.TextArray[string](idpEvent.Scopes)))
+	}
+	return keycloakCols
+}
+
+func reduceADFSIDPChangedColumns(idpEvent idp.ADFSIDPChangedEvent) []handler.Column {
+	adfsCols := make([]handler.Column, 0, 4)
+	if idpEvent.Issuer != nil {
+		adfsCols = append(adfsCols, handler.NewCol(ADFSIssuerCol, *idpEvent.Issuer))
+	}
+	if idpEvent.ClientID != nil {
+		adfsCols = append(adfsCols, handler.NewCol(ADFSClientIDCol, *idpEvent.ClientID))
+	}
+	if idpEvent.ClientSecret != nil {
+		adfsCols = append(adfsCols, handler.NewCol(ADFSClientSecretCol, *idpEvent.ClientSecret))
+	}
+	if idpEvent.Scopes != nil {
+		adfsCols = append(adfsCols, handler.NewCol(ADFSScopesCol, database.TextArray[string](idpEvent.Scopes)))
+	}
+	return adfsCols
+}
+
+func reduceBitbucketIDPChangedColumns(idpEvent idp.BitbucketIDPChangedEvent) []handler.Column {
+	bitbucketCols := make([]handler.Column, 0, 3)
+	if idpEvent.ClientID != nil {
+		bitbucketCols = append(bitbucketCols, handler.NewCol(BitbucketClientIDCol, *idpEvent.ClientID))
+	}
+	if idpEvent.ClientSecret != nil {
+		bitbucketCols = append(bitbucketCols, handler.NewCol(BitbucketClientSecretCol, *idpEvent.ClientSecret))
+	}
+	if idpEvent.Scopes != nil {
+		bitbucketCols = append(bitbucketCols, handler.NewCol(BitbucketScopesCol, database.TextArray[string](idpEvent.Scopes)))
+	}
+	return bitbucketCols
+}
+
+func reduceDiscordIDPChangedColumns(idpEvent idp.DiscordIDPChangedEvent) []handler.Column {
+	discordCols := make([]handler.Column, 0, 3)
+	if idpEvent.ClientID != nil {
+		discordCols = append(discordCols, handler.NewCol(DiscordClientIDCol, *idpEvent.ClientID))
+	}
+	if idpEvent.ClientSecret != nil {
+		discordCols = append(discordCols, handler.NewCol(DiscordClientSecretCol, *idpEvent.ClientSecret))
+	}
+	if idpEvent.Scopes != nil {
+		discordCols = append(discordCols, handler.NewCol(DiscordScopesCol, database.TextArray[string](idpEvent.Scopes)))
+	}
+	return discordCols
+}
+
 func reduceGoogleIDPChangedColumns(idpEvent idp.GoogleIDPChangedEvent) []handler.Column {
 	googleCols := make([]handler.Column, 0, 3)
 	if idpEvent.ClientID != nil {
@@ -3,6 +3,7 @@ package projection
 import (
 	"testing"
 
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
@@ -169,6 +170,36 @@ func TestProjectProjection_reduces(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "reduceProjectChanged authorization details types",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.ProjectChangedType,
+						project.AggregateType,
+						[]byte(`{"authorizationDetailsTypes": ["payment_initiation"]}`),
+					), project.ProjectChangeEventMapper),
+			},
+			reduce: (&projectProjection{}).reduceProjectChanged,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.projects4 SET (change_date, sequence, authorization_details_types) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								database.TextArray[string]{"payment_initiation"},
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "reduceProjectChanged no changes",
 			args: args{
@@ -13,6 +13,7 @@ import (
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	"github.com/zitadel/zitadel/internal/migration"
+	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
 const (
@@ -72,6 +73,7 @@ var (
 	NotificationsQuotaProjection        interface{}
 	TelemetryPusherProjection           interface{}
 	DeviceAuthProjection                *handler.Handler
+	BackchannelAuthProjection           *handler.Handler
 	SessionProjection                   *handler.Handler
 	AuthRequestProjection               *handler.Handler
 	SamlRequestProjection               *handler.Handler
@@ -87,6 +89,7 @@ var (
 	WebKeyProjection                    *handler.Handler
 	DebugEventsProjection               *handler.Handler
 	HostedLoginTranslationProjection    *handler.Handler
+	GroupProjection                     *handler.Handler
 
 	ProjectGrantFields      *handler.FieldHandler
 	OrgDomainVerifiedFields *handler.FieldHandler
@@ -100,6 +103,7 @@ type projection interface {
 	Start(ctx context.Context)
 	Init(ctx context.Context) error
 	Trigger(ctx context.Context, opts ...handler.TriggerOpt) (_ context.Context, err error)
+	Rebuild(ctx context.Context) error
 	migration.Migration
 }
 
@@ -118,6 +122,8 @@ func Create(ctx context.Context, sqlClient *database.DB, es handler.EventStore,
 		RetryFailedAfter:    config.RetryFailedAfter,
 		TransactionDuration: config.TransactionDuration,
 		ActiveInstancer:     config.ActiveInstancer,
+		ConcurrentInstances: config.ConcurrentInstances,
+		Sharding:            config.Sharding,
 	}
 
 	OrgProjection = newOrgProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["orgs"]))
@@ -166,6 +172,7 @@ func Create(ctx context.Context, sqlClient *database.DB, es handler.EventStore,
 	SecurityPolicyProjection = newSecurityPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["security_policies"]))
 	NotificationPolicyProjection = newNotificationPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["notification_policies"]))
 	DeviceAuthProjection = newDeviceAuthProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["device_auth"]))
+	BackchannelAuthProjection = newBackchannelAuthProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["backchannel_auth"]))
 	SessionProjection = newSessionProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["sessions"]))
 	AuthRequestProjection = newAuthRequestProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["auth_requests"]))
 	SamlRequestProjection = newSamlRequestProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["saml_requests"]))
@@ -181,6 +188,7 @@ func Create(ctx context.Context, sqlClient *database.DB, es handler.EventStore,
 	WebKeyProjection = newWebKeyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["web_keys"]))
 	DebugEventsProjection = newDebugEventsProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["debug_events"]))
 	HostedLoginTranslationProjection = newHostedLoginTranslationProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["hosted_login_translation"]))
+	GroupProjection = newGroupProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["groups"]))
 
 	ProjectGrantFields = newFillProjectGrantFields(applyCustomConfig(projectionConfig, config.Customizations[fieldsProjectGrant]))
 	OrgDomainVerifiedFields = newFillOrgDomainVerifiedFields(applyCustomConfig(projectionConfig, config.Customizations[fieldsOrgDomainVerified]))
@@ -198,6 +206,17 @@ func Projections() []projection {
 	return projections
 }
 
+// Rebuild rebuilds the projection with the given name into shadow tables
+// and swaps them with the live tables as soon as they caught up.
+func Rebuild(ctx context.Context, name string) error {
+	for _, p := range projections {
+		if p.ProjectionName() == name {
+			return p.Rebuild(ctx)
+		}
+	}
+	return zerrors.ThrowNotFoundf(nil, "PROJE-Ohx4a", "projection %s not found", name)
+}
+
 func Init(ctx context.Context) error {
 	for _, p := range projections {
 		if err := p.Init(ctx); err != nil {
@@ -273,6 +292,9 @@ func applyCustomConfig(config handler.Config, customConfig CustomConfig) handler
 	if customConfig.TransactionDuration != nil {
 		config.TransactionDuration = *customConfig.TransactionDuration
 	}
+	if customConfig.ConcurrentInstances != nil {
+		config.ConcurrentInstances = *customConfig.ConcurrentInstances
+	}
 
 	return config
 }
@@ -345,6 +367,7 @@ func newProjectionsList() {
 		SecurityPolicyProjection,
 		NotificationPolicyProjection,
 		DeviceAuthProjection,
+		BackchannelAuthProjection,
 		SessionProjection,
 		AuthRequestProjection,
 		SamlRequestProjection,
@@ -360,5 +383,6 @@ func newProjectionsList() {
 		WebKeyProjection,
 		DebugEventsProjection,
 		HostedLoginTranslationProjection,
+		GroupProjection,
 	}
 }
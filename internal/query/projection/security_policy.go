@@ -19,6 +19,11 @@ const (
 	SecurityPolicyColumnEnableIframeEmbedding = "enable_iframe_embedding"
 	SecurityPolicyColumnAllowedOrigins        = "origins"
 	SecurityPolicyColumnEnableImpersonation   = "enable_impersonation"
+
+	SecurityPolicyColumnWebAuthNAttestationRequired = "webauthn_attestation_required"
+	SecurityPolicyColumnWebAuthNAllowedAAGUIDs      = "webauthn_allowed_aaguids"
+	SecurityPolicyColumnWebAuthNDeniedAAGUIDs       = "webauthn_denied_aaguids"
+	SecurityPolicyColumnWebAuthNCertificationLevels = "webauthn_certification_levels"
 )
 
 type securityPolicyProjection struct{}
@@ -41,6 +46,10 @@ func (*securityPolicyProjection) Init() *old_handler.Check {
 			handler.NewColumn(SecurityPolicyColumnEnableIframeEmbedding, handler.ColumnTypeBool, handler.Default(false)),
 			handler.NewColumn(SecurityPolicyColumnAllowedOrigins, handler.ColumnTypeTextArray, handler.Nullable()),
 			handler.NewColumn(SecurityPolicyColumnEnableImpersonation, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(SecurityPolicyColumnWebAuthNAttestationRequired, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(SecurityPolicyColumnWebAuthNAllowedAAGUIDs, handler.ColumnTypeTextArray, handler.Nullable()),
+			handler.NewColumn(SecurityPolicyColumnWebAuthNDeniedAAGUIDs, handler.ColumnTypeTextArray, handler.Nullable()),
+			handler.NewColumn(SecurityPolicyColumnWebAuthNCertificationLevels, handler.ColumnTypeTextArray, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(SecurityPolicyColumnInstanceID),
 		),
@@ -87,6 +96,18 @@ func (p *securityPolicyProjection) reduceSecurityPolicySet(event eventstore.Even
 	if e.EnableImpersonation != nil {
 		changes = append(changes, handler.NewCol(SecurityPolicyColumnEnableImpersonation, e.EnableImpersonation))
 	}
+	if e.WebAuthNAttestationRequired != nil {
+		changes = append(changes, handler.NewCol(SecurityPolicyColumnWebAuthNAttestationRequired, *e.WebAuthNAttestationRequired))
+	}
+	if e.WebAuthNAllowedAAGUIDs != nil {
+		changes = append(changes, handler.NewCol(SecurityPolicyColumnWebAuthNAllowedAAGUIDs, e.WebAuthNAllowedAAGUIDs))
+	}
+	if e.WebAuthNDeniedAAGUIDs != nil {
+		changes = append(changes, handler.NewCol(SecurityPolicyColumnWebAuthNDeniedAAGUIDs, e.WebAuthNDeniedAAGUIDs))
+	}
+	if e.WebAuthNCertificationLevels != nil {
+		changes = append(changes, handler.NewCol(SecurityPolicyColumnWebAuthNCertificationLevels, e.WebAuthNCertificationLevels))
+	}
 	return handler.NewUpsertStatement(
 		e,
 		[]handler.Column{
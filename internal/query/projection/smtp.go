@@ -11,9 +11,10 @@ import (
 )
 
 const (
-	SMTPConfigProjectionTable = "projections.smtp_configs5"
+	SMTPConfigProjectionTable = "projections.smtp_configs6"
 	SMTPConfigTable           = SMTPConfigProjectionTable + "_" + smtpConfigSMTPTableSuffix
 	SMTPConfigHTTPTable       = SMTPConfigProjectionTable + "_" + smtpConfigHTTPTableSuffix
+	SMTPConfigAPITable        = SMTPConfigProjectionTable + "_" + smtpConfigAPITableSuffix
 
 	SMTPConfigColumnInstanceID    = "instance_id"
 	SMTPConfigColumnResourceOwner = "resource_owner"
@@ -40,6 +41,19 @@ const (
 	SMTPConfigHTTPColumnInstanceID = "instance_id"
 	SMTPConfigHTTPColumnID         = "id"
 	SMTPConfigHTTPColumnEndpoint   = "endpoint"
+
+	smtpConfigAPITableSuffix          = "api"
+	SMTPConfigAPIColumnInstanceID     = "instance_id"
+	SMTPConfigAPIColumnID             = "id"
+	SMTPConfigAPIColumnProvider       = "provider"
+	SMTPConfigAPIColumnSenderAddress  = "sender_address"
+	SMTPConfigAPIColumnSenderName     = "sender_name"
+	SMTPConfigAPIColumnReplyToAddress = "reply_to_address"
+	SMTPConfigAPIColumnEndpoint       = "endpoint"
+	SMTPConfigAPIColumnRegion         = "region"
+	SMTPConfigAPIColumnDomain         = "domain"
+	SMTPConfigAPIColumnKeyID          = "key_id"
+	SMTPConfigAPIColumnAPIKey         = "api_key"
 )
 
 type smtpConfigProjection struct{}
@@ -91,6 +105,23 @@ func (*smtpConfigProjection) Init() *old_handler.Check {
 			smtpConfigHTTPTableSuffix,
 			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
 		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(SMTPConfigAPIColumnID, handler.ColumnTypeText),
+			handler.NewColumn(SMTPConfigAPIColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(SMTPConfigAPIColumnProvider, handler.ColumnTypeEnum),
+			handler.NewColumn(SMTPConfigAPIColumnSenderAddress, handler.ColumnTypeText),
+			handler.NewColumn(SMTPConfigAPIColumnSenderName, handler.ColumnTypeText),
+			handler.NewColumn(SMTPConfigAPIColumnReplyToAddress, handler.ColumnTypeText),
+			handler.NewColumn(SMTPConfigAPIColumnEndpoint, handler.ColumnTypeText),
+			handler.NewColumn(SMTPConfigAPIColumnRegion, handler.ColumnTypeText),
+			handler.NewColumn(SMTPConfigAPIColumnDomain, handler.ColumnTypeText),
+			handler.NewColumn(SMTPConfigAPIColumnKeyID, handler.ColumnTypeText),
+			handler.NewColumn(SMTPConfigAPIColumnAPIKey, handler.ColumnTypeJSONB, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(SMTPConfigAPIColumnInstanceID, SMTPConfigAPIColumnID),
+			smtpConfigAPITableSuffix,
+			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
+		),
 	)
 }
 
@@ -119,6 +150,14 @@ func (p *smtpConfigProjection) Reducers() []handler.AggregateReducer {
 					Event:  instance.SMTPConfigHTTPChangedEventType,
 					Reduce: p.reduceSMTPConfigHTTPChanged,
 				},
+				{
+					Event:  instance.SMTPConfigAPIAddedEventType,
+					Reduce: p.reduceSMTPConfigAPIAdded,
+				},
+				{
+					Event:  instance.SMTPConfigAPIChangedEventType,
+					Reduce: p.reduceSMTPConfigAPIChanged,
+				},
 				{
 					Event:  instance.SMTPConfigActivatedEventType,
 					Reduce: p.reduceSMTPConfigActivated,
@@ -259,6 +298,107 @@ func (p *smtpConfigProjection) reduceSMTPConfigHTTPChanged(event eventstore.Even
 	return handler.NewMultiStatement(e, stmts...), nil
 }
 
+func (p *smtpConfigProjection) reduceSMTPConfigAPIAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.SMTPConfigAPIAddedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewMultiStatement(
+		e,
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(SMTPConfigColumnCreationDate, e.CreationDate()),
+				handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
+				handler.NewCol(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(SMTPConfigColumnResourceOwner, e.Aggregate().ResourceOwner),
+				handler.NewCol(SMTPConfigColumnAggregateID, e.Aggregate().ID),
+				handler.NewCol(SMTPConfigColumnID, getSMTPConfigID(e.ID, e.Aggregate())),
+				handler.NewCol(SMTPConfigColumnSequence, e.Sequence()),
+				handler.NewCol(SMTPConfigColumnState, domain.SMTPConfigStateInactive),
+				handler.NewCol(SMTPConfigColumnDescription, e.Description),
+			},
+		),
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(SMTPConfigAPIColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(SMTPConfigAPIColumnID, getSMTPConfigID(e.ID, e.Aggregate())),
+				handler.NewCol(SMTPConfigAPIColumnProvider, e.Provider),
+				handler.NewCol(SMTPConfigAPIColumnSenderAddress, e.SenderAddress),
+				handler.NewCol(SMTPConfigAPIColumnSenderName, e.SenderName),
+				handler.NewCol(SMTPConfigAPIColumnReplyToAddress, e.ReplyToAddress),
+				handler.NewCol(SMTPConfigAPIColumnEndpoint, e.Endpoint),
+				handler.NewCol(SMTPConfigAPIColumnRegion, e.Region),
+				handler.NewCol(SMTPConfigAPIColumnDomain, e.Domain),
+				handler.NewCol(SMTPConfigAPIColumnKeyID, e.KeyID),
+				handler.NewCol(SMTPConfigAPIColumnAPIKey, e.APIKey),
+			},
+			handler.WithTableSuffix(smtpConfigAPITableSuffix),
+		),
+	), nil
+}
+
+func (p *smtpConfigProjection) reduceSMTPConfigAPIChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.SMTPConfigAPIChangedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	stmts := make([]func(eventstore.Event) handler.Exec, 0, 2)
+	columns := []handler.Column{
+		handler.NewCol(SMTPConfigColumnChangeDate, e.CreationDate()),
+		handler.NewCol(SMTPConfigColumnSequence, e.Sequence()),
+	}
+	if e.Description != nil {
+		columns = append(columns, handler.NewCol(SMTPConfigColumnDescription, *e.Description))
+	}
+	stmts = append(stmts, handler.AddUpdateStatement(
+		columns,
+		[]handler.Condition{
+			handler.NewCond(SMTPConfigColumnID, getSMTPConfigID(e.ID, e.Aggregate())),
+			handler.NewCond(SMTPConfigColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	))
+
+	apiColumns := make([]handler.Column, 0, 8)
+	if e.SenderAddress != nil {
+		apiColumns = append(apiColumns, handler.NewCol(SMTPConfigAPIColumnSenderAddress, *e.SenderAddress))
+	}
+	if e.SenderName != nil {
+		apiColumns = append(apiColumns, handler.NewCol(SMTPConfigAPIColumnSenderName, *e.SenderName))
+	}
+	if e.ReplyToAddress != nil {
+		apiColumns = append(apiColumns, handler.NewCol(SMTPConfigAPIColumnReplyToAddress, *e.ReplyToAddress))
+	}
+	if e.Endpoint != nil {
+		apiColumns = append(apiColumns, handler.NewCol(SMTPConfigAPIColumnEndpoint, *e.Endpoint))
+	}
+	if e.Region != nil {
+		apiColumns = append(apiColumns, handler.NewCol(SMTPConfigAPIColumnRegion, *e.Region))
+	}
+	if e.Domain != nil {
+		apiColumns = append(apiColumns, handler.NewCol(SMTPConfigAPIColumnDomain, *e.Domain))
+	}
+	if e.KeyID != nil {
+		apiColumns = append(apiColumns, handler.NewCol(SMTPConfigAPIColumnKeyID, *e.KeyID))
+	}
+	if e.APIKey != nil {
+		apiColumns = append(apiColumns, handler.NewCol(SMTPConfigAPIColumnAPIKey, e.APIKey))
+	}
+	if len(apiColumns) > 0 {
+		stmts = append(stmts, handler.AddUpdateStatement(
+			apiColumns,
+			[]handler.Condition{
+				handler.NewCond(SMTPConfigAPIColumnID, getSMTPConfigID(e.ID, e.Aggregate())),
+				handler.NewCond(SMTPConfigAPIColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			handler.WithTableSuffix(smtpConfigAPITableSuffix),
+		))
+	}
+
+	return handler.NewMultiStatement(e, stmts...), nil
+}
+
 func (p *smtpConfigProjection) reduceSMTPConfigChanged(event eventstore.Event) (*handler.Statement, error) {
 	e, err := assertEvent[*instance.SMTPConfigChangedEvent](event)
 	if err != nil {
@@ -3,6 +3,8 @@ package projection
 import (
 	"context"
 
+	"github.com/google/uuid"
+
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
@@ -27,6 +29,8 @@ const (
 	UserAuthMethodStateCol         = "state"
 	UserAuthMethodNameCol          = "name"
 	UserAuthMethodDomainCol        = "domain"
+	UserAuthMethodAAGUIDCol        = "aaguid"
+	UserAuthMethodModelNameCol     = "model_name"
 )
 
 type userAuthMethodProjection struct{}
@@ -53,6 +57,8 @@ func (*userAuthMethodProjection) Init() *old_handler.Check {
 			handler.NewColumn(UserAuthMethodInstanceIDCol, handler.ColumnTypeText),
 			handler.NewColumn(UserAuthMethodNameCol, handler.ColumnTypeText),
 			handler.NewColumn(UserAuthMethodDomainCol, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(UserAuthMethodAAGUIDCol, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(UserAuthMethodModelNameCol, handler.ColumnTypeText, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(UserAuthMethodInstanceIDCol, UserAuthMethodUserIDCol, UserAuthMethodTypeCol, UserAuthMethodTokenIDCol),
 			handler.WithIndex(handler.NewIndex("resource_owner", []string{UserAuthMethodResourceOwnerCol})),
@@ -97,6 +103,10 @@ func (p *userAuthMethodProjection) Reducers() []handler.AggregateReducer {
 					Event:  user.HumanOTPEmailAddedType,
 					Reduce: p.reduceAddAuthMethod,
 				},
+				{
+					Event:  user.HumanRecoveryCodesAddedType,
+					Reduce: p.reduceRecoveryCodesAdded,
+				},
 				{
 					Event:  user.HumanPasswordlessTokenRemovedType,
 					Reduce: p.reduceRemoveAuthMethod,
@@ -125,6 +135,10 @@ func (p *userAuthMethodProjection) Reducers() []handler.AggregateReducer {
 					Event:  user.HumanOTPEmailRemovedType,
 					Reduce: p.reduceRemoveAuthMethod,
 				},
+				{
+					Event:  user.HumanRecoveryCodesRemovedType,
+					Reduce: p.reduceRemoveAuthMethod,
+				},
 				{
 					Event:  user.UserRemovedType,
 					Reduce: p.reduceUserRemoved,
@@ -201,30 +215,40 @@ func (p *userAuthMethodProjection) reduceActivateEvent(event eventstore.Event) (
 	tokenID := ""
 	name := ""
 	var methodType domain.UserAuthMethodType
+	var webAuthN *user.HumanWebAuthNVerifiedEvent
 
 	switch e := event.(type) {
 	case *user.HumanPasswordlessVerifiedEvent:
 		methodType = domain.UserAuthMethodTypePasswordless
 		tokenID = e.WebAuthNTokenID
 		name = e.WebAuthNTokenName
+		webAuthN = &e.HumanWebAuthNVerifiedEvent
 	case *user.HumanU2FVerifiedEvent:
 		methodType = domain.UserAuthMethodTypeU2F
 		tokenID = e.WebAuthNTokenID
 		name = e.WebAuthNTokenName
+		webAuthN = &e.HumanWebAuthNVerifiedEvent
 	case *user.HumanOTPVerifiedEvent:
 		methodType = domain.UserAuthMethodTypeTOTP
 	default:
 		return nil, zerrors.ThrowInvalidArgumentf(nil, "PROJE-f92f", "reduce.wrong.event.type %v", []eventstore.EventType{user.HumanPasswordlessTokenAddedType, user.HumanU2FTokenAddedType})
 	}
 
+	cols := []handler.Column{
+		handler.NewCol(UserAuthMethodChangeDateCol, event.CreatedAt()),
+		handler.NewCol(UserAuthMethodSequenceCol, event.Sequence()),
+		handler.NewCol(UserAuthMethodNameCol, name),
+		handler.NewCol(UserAuthMethodStateCol, domain.MFAStateReady),
+	}
+	if webAuthN != nil {
+		cols = append(cols,
+			handler.NewCol(UserAuthMethodAAGUIDCol, aaguidToString(webAuthN.AAGUID)),
+			handler.NewCol(UserAuthMethodModelNameCol, webAuthN.ModelName),
+		)
+	}
 	return handler.NewUpdateStatement(
 		event,
-		[]handler.Column{
-			handler.NewCol(UserAuthMethodChangeDateCol, event.CreatedAt()),
-			handler.NewCol(UserAuthMethodSequenceCol, event.Sequence()),
-			handler.NewCol(UserAuthMethodNameCol, name),
-			handler.NewCol(UserAuthMethodStateCol, domain.MFAStateReady),
-		},
+		cols,
 		[]handler.Condition{
 			handler.NewCond(UserAuthMethodUserIDCol, event.Aggregate().ID),
 			handler.NewCond(UserAuthMethodTypeCol, methodType),
@@ -263,6 +287,35 @@ func (p *userAuthMethodProjection) reduceAddAuthMethod(event eventstore.Event) (
 	), nil
 }
 
+// reduceRecoveryCodesAdded upserts the auth method, because the recovery codes can be regenerated without being removed.
+func (p *userAuthMethodProjection) reduceRecoveryCodesAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*user.HumanRecoveryCodesAddedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(UserAuthMethodInstanceIDCol, nil),
+			handler.NewCol(UserAuthMethodUserIDCol, nil),
+			handler.NewCol(UserAuthMethodTypeCol, nil),
+			handler.NewCol(UserAuthMethodTokenIDCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(UserAuthMethodTokenIDCol, ""),
+			handler.NewCol(UserAuthMethodCreationDateCol, handler.OnlySetValueOnInsert(UserAuthMethodTable, e.CreatedAt())),
+			handler.NewCol(UserAuthMethodChangeDateCol, e.CreatedAt()),
+			handler.NewCol(UserAuthMethodResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCol(UserAuthMethodInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(UserAuthMethodUserIDCol, e.Aggregate().ID),
+			handler.NewCol(UserAuthMethodSequenceCol, e.Sequence()),
+			handler.NewCol(UserAuthMethodStateCol, domain.MFAStateReady),
+			handler.NewCol(UserAuthMethodTypeCol, domain.UserAuthMethodTypeRecoveryCode),
+			handler.NewCol(UserAuthMethodNameCol, ""),
+		},
+	), nil
+}
+
 func (p *userAuthMethodProjection) reduceRemoveAuthMethod(event eventstore.Event) (*handler.Statement, error) {
 	var tokenID string
 	var methodType domain.UserAuthMethodType
@@ -280,11 +333,13 @@ func (p *userAuthMethodProjection) reduceRemoveAuthMethod(event eventstore.Event
 		methodType = domain.UserAuthMethodTypeOTPSMS
 	case *user.HumanOTPEmailRemovedEvent:
 		methodType = domain.UserAuthMethodTypeOTPEmail
+	case *user.HumanRecoveryCodesRemovedEvent:
+		methodType = domain.UserAuthMethodTypeRecoveryCode
 
 	default:
 		return nil, zerrors.ThrowInvalidArgumentf(nil, "PROJE-f92f", "reduce.wrong.event.type %v",
 			[]eventstore.EventType{user.HumanPasswordlessTokenAddedType, user.HumanU2FTokenAddedType, user.HumanMFAOTPRemovedType,
-				user.HumanOTPSMSRemovedType, user.HumanPhoneRemovedType, user.HumanOTPEmailRemovedType})
+				user.HumanOTPSMSRemovedType, user.HumanPhoneRemovedType, user.HumanOTPEmailRemovedType, user.HumanRecoveryCodesRemovedType})
 	}
 	conditions := []handler.Condition{
 		handler.NewCond(UserAuthMethodUserIDCol, event.Aggregate().ID),
@@ -330,3 +385,13 @@ func (p *userAuthMethodProjection) reduceUserRemoved(event eventstore.Event) (*h
 		},
 	), nil
 }
+
+// aaguidToString formats the AAGUID of an authenticator in its canonical UUID form.
+// Authenticators without attestation report an AAGUID of all zeros, which is returned as an empty string.
+func aaguidToString(aaguid []byte) string {
+	id, err := uuid.FromBytes(aaguid)
+	if err != nil || id == uuid.Nil {
+		return ""
+	}
+	return id.String()
+}
@@ -186,7 +186,9 @@ func TestUserAuthMethodProjection_reduces(t *testing.T) {
 						user.AggregateType,
 						[]byte(`{
 						"webAuthNTokenId": "token-id",
-						"webAuthNTokenName": "name"
+						"webAuthNTokenName": "name",
+						"aaguid": "y2lIHo/3QDmT7AonKaFUqA==",
+						"modelName": "YubiKey 5 Series"
 					}`),
 					), user.HumanPasswordlessVerifiedEventMapper),
 			},
@@ -197,12 +199,14 @@ func TestUserAuthMethodProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.user_auth_methods5 SET (change_date, sequence, name, state) = ($1, $2, $3, $4) WHERE (user_id = $5) AND (method_type = $6) AND (resource_owner = $7) AND (token_id = $8) AND (instance_id = $9)",
+							expectedStmt: "UPDATE projections.user_auth_methods5 SET (change_date, sequence, name, state, aaguid, model_name) = ($1, $2, $3, $4, $5, $6) WHERE (user_id = $7) AND (method_type = $8) AND (resource_owner = $9) AND (token_id = $10) AND (instance_id = $11)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
 								"name",
 								domain.MFAStateReady,
+								"cb69481e-8ff7-4039-93ec-0a2729a154a8",
+								"YubiKey 5 Series",
 								"agg-id",
 								domain.UserAuthMethodTypePasswordless,
 								"ro-id",
@@ -234,12 +238,14 @@ func TestUserAuthMethodProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.user_auth_methods5 SET (change_date, sequence, name, state) = ($1, $2, $3, $4) WHERE (user_id = $5) AND (method_type = $6) AND (resource_owner = $7) AND (token_id = $8) AND (instance_id = $9)",
+							expectedStmt: "UPDATE projections.user_auth_methods5 SET (change_date, sequence, name, state, aaguid, model_name) = ($1, $2, $3, $4, $5, $6) WHERE (user_id = $7) AND (method_type = $8) AND (resource_owner = $9) AND (token_id = $10) AND (instance_id = $11)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
 								"name",
 								domain.MFAStateReady,
+								"",
+								"",
 								"agg-id",
 								domain.UserAuthMethodTypeU2F,
 								"ro-id",
@@ -528,6 +534,68 @@ func TestUserAuthMethodProjection_reduces(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "reduceAddedRecoveryCodes",
+			args: args{
+				event: getEvent(testEvent(
+					user.HumanRecoveryCodesAddedType,
+					user.AggregateType,
+					[]byte(`{"hashedCodes": ["hash1", "hash2"]}`),
+				), eventstore.GenericEventMapper[user.HumanRecoveryCodesAddedEvent]),
+			},
+			reduce: (&userAuthMethodProjection{}).reduceRecoveryCodesAdded,
+			want: wantReduce{
+				aggregateType: user.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.user_auth_methods5 (token_id, creation_date, change_date, resource_owner, instance_id, user_id, sequence, state, method_type, name) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) ON CONFLICT (instance_id, user_id, method_type, token_id) DO UPDATE SET (creation_date, change_date, resource_owner, sequence, state, name) = (projections.user_auth_methods5.creation_date, EXCLUDED.change_date, EXCLUDED.resource_owner, EXCLUDED.sequence, EXCLUDED.state, EXCLUDED.name)",
+							expectedArgs: []interface{}{
+								"",
+								anyArg{},
+								anyArg{},
+								"ro-id",
+								"instance-id",
+								"agg-id",
+								uint64(15),
+								domain.MFAStateReady,
+								domain.UserAuthMethodTypeRecoveryCode,
+								"",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRemoveRecoveryCodes",
+			args: args{
+				event: getEvent(testEvent(
+					user.HumanRecoveryCodesRemovedType,
+					user.AggregateType,
+					nil,
+				), eventstore.GenericEventMapper[user.HumanRecoveryCodesRemovedEvent]),
+			},
+			reduce: (&userAuthMethodProjection{}).reduceRemoveAuthMethod,
+			want: wantReduce{
+				aggregateType: user.AggregateType,
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.user_auth_methods5 WHERE (user_id = $1) AND (method_type = $2) AND (resource_owner = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								domain.UserAuthMethodTypeRecoveryCode,
+								"ro-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name:   "reduceUserRemoved",
 			reduce: (&userAuthMethodProjection{}).reduceUserRemoved,
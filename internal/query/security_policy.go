@@ -47,6 +47,22 @@ var (
 		name:  projection.SecurityPolicyColumnEnableImpersonation,
 		table: securityPolicyTable,
 	}
+	SecurityPolicyColumnWebAuthNAttestationRequired = Column{
+		name:  projection.SecurityPolicyColumnWebAuthNAttestationRequired,
+		table: securityPolicyTable,
+	}
+	SecurityPolicyColumnWebAuthNAllowedAAGUIDs = Column{
+		name:  projection.SecurityPolicyColumnWebAuthNAllowedAAGUIDs,
+		table: securityPolicyTable,
+	}
+	SecurityPolicyColumnWebAuthNDeniedAAGUIDs = Column{
+		name:  projection.SecurityPolicyColumnWebAuthNDeniedAAGUIDs,
+		table: securityPolicyTable,
+	}
+	SecurityPolicyColumnWebAuthNCertificationLevels = Column{
+		name:  projection.SecurityPolicyColumnWebAuthNCertificationLevels,
+		table: securityPolicyTable,
+	}
 )
 
 type SecurityPolicy struct {
@@ -59,6 +75,11 @@ type SecurityPolicy struct {
 	EnableIframeEmbedding bool
 	AllowedOrigins        database.TextArray[string]
 	EnableImpersonation   bool
+
+	WebAuthNAttestationRequired bool
+	WebAuthNAllowedAAGUIDs      database.TextArray[string]
+	WebAuthNDeniedAAGUIDs       database.TextArray[string]
+	WebAuthNCertificationLevels database.TextArray[string]
 }
 
 func (q *Queries) SecurityPolicy(ctx context.Context) (policy *SecurityPolicy, err error) {
@@ -86,7 +107,11 @@ func prepareSecurityPolicyQuery() (sq.SelectBuilder, func(*sql.Row) (*SecurityPo
 			SecurityPolicyColumnSequence.identifier(),
 			SecurityPolicyColumnEnableIframeEmbedding.identifier(),
 			SecurityPolicyColumnAllowedOrigins.identifier(),
-			SecurityPolicyColumnEnableImpersonation.identifier()).
+			SecurityPolicyColumnEnableImpersonation.identifier(),
+			SecurityPolicyColumnWebAuthNAttestationRequired.identifier(),
+			SecurityPolicyColumnWebAuthNAllowedAAGUIDs.identifier(),
+			SecurityPolicyColumnWebAuthNDeniedAAGUIDs.identifier(),
+			SecurityPolicyColumnWebAuthNCertificationLevels.identifier()).
 			From(securityPolicyTable.identifier()).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*SecurityPolicy, error) {
@@ -100,6 +125,10 @@ func prepareSecurityPolicyQuery() (sq.SelectBuilder, func(*sql.Row) (*SecurityPo
 				&securityPolicy.EnableIframeEmbedding,
 				&securityPolicy.AllowedOrigins,
 				&securityPolicy.EnableImpersonation,
+				&securityPolicy.WebAuthNAttestationRequired,
+				&securityPolicy.WebAuthNAllowedAAGUIDs,
+				&securityPolicy.WebAuthNDeniedAAGUIDs,
+				&securityPolicy.WebAuthNCertificationLevels,
 			)
 			if err != nil && !errors.Is(err, sql.ErrNoRows) { // ignore not found errors
 				return nil, zerrors.ThrowInternal(err, "QUERY-Dfrt2", "Errors.Internal")
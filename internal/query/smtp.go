@@ -121,6 +121,55 @@ var (
 		name:  projection.SMTPConfigHTTPColumnEndpoint,
 		table: smtpConfigsHTTPTable,
 	}
+
+	smtpConfigsAPITable = table{
+		name:          projection.SMTPConfigAPITable,
+		instanceIDCol: projection.SMTPConfigAPIColumnInstanceID,
+	}
+	SMTPConfigAPIColumnInstanceID = Column{
+		name:  projection.SMTPConfigAPIColumnInstanceID,
+		table: smtpConfigsAPITable,
+	}
+	SMTPConfigAPIColumnID = Column{
+		name:  projection.SMTPConfigAPIColumnID,
+		table: smtpConfigsAPITable,
+	}
+	SMTPConfigAPIColumnProvider = Column{
+		name:  projection.SMTPConfigAPIColumnProvider,
+		table: smtpConfigsAPITable,
+	}
+	SMTPConfigAPIColumnSenderAddress = Column{
+		name:  projection.SMTPConfigAPIColumnSenderAddress,
+		table: smtpConfigsAPITable,
+	}
+	SMTPConfigAPIColumnSenderName = Column{
+		name:  projection.SMTPConfigAPIColumnSenderName,
+		table: smtpConfigsAPITable,
+	}
+	SMTPConfigAPIColumnReplyToAddress = Column{
+		name:  projection.SMTPConfigAPIColumnReplyToAddress,
+		table: smtpConfigsAPITable,
+	}
+	SMTPConfigAPIColumnEndpoint = Column{
+		name:  projection.SMTPConfigAPIColumnEndpoint,
+		table: smtpConfigsAPITable,
+	}
+	SMTPConfigAPIColumnRegion = Column{
+		name:  projection.SMTPConfigAPIColumnRegion,
+		table: smtpConfigsAPITable,
+	}
+	SMTPConfigAPIColumnDomain = Column{
+		name:  projection.SMTPConfigAPIColumnDomain,
+		table: smtpConfigsAPITable,
+	}
+	SMTPConfigAPIColumnKeyID = Column{
+		name:  projection.SMTPConfigAPIColumnKeyID,
+		table: smtpConfigsAPITable,
+	}
+	SMTPConfigAPIColumnAPIKey = Column{
+		name:  projection.SMTPConfigAPIColumnAPIKey,
+		table: smtpConfigsAPITable,
+	}
 )
 
 type SMTPConfig struct {
@@ -134,6 +183,7 @@ type SMTPConfig struct {
 
 	SMTPConfig *SMTP
 	HTTPConfig *HTTP
+	APIConfig  *EmailAPI
 
 	State domain.SMTPConfigState
 }
@@ -148,6 +198,18 @@ type SMTP struct {
 	Password       *crypto.CryptoValue
 }
 
+type EmailAPI struct {
+	Provider       domain.EmailAPIProvider
+	SenderAddress  string
+	SenderName     string
+	ReplyToAddress string
+	Endpoint       string
+	Region         string
+	Domain         string
+	KeyID          string
+	APIKey         *crypto.CryptoValue
+}
+
 func (q *Queries) SMTPConfigActive(ctx context.Context, resourceOwner string) (config *SMTPConfig, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -191,6 +253,7 @@ func (q *Queries) SMTPConfigByID(ctx context.Context, instanceID, id string) (co
 
 func prepareSMTPConfigQuery() (sq.SelectBuilder, func(*sql.Row) (*SMTPConfig, error)) {
 	password := new(crypto.CryptoValue)
+	apiKey := new(crypto.CryptoValue)
 
 	return sq.Select(
 			SMTPConfigColumnCreationDate.identifier(),
@@ -211,16 +274,29 @@ func prepareSMTPConfigQuery() (sq.SelectBuilder, func(*sql.Row) (*SMTPConfig, er
 			SMTPConfigSMTPColumnPassword.identifier(),
 
 			SMTPConfigHTTPColumnID.identifier(),
-			SMTPConfigHTTPColumnEndpoint.identifier()).
+			SMTPConfigHTTPColumnEndpoint.identifier(),
+
+			SMTPConfigAPIColumnID.identifier(),
+			SMTPConfigAPIColumnProvider.identifier(),
+			SMTPConfigAPIColumnSenderAddress.identifier(),
+			SMTPConfigAPIColumnSenderName.identifier(),
+			SMTPConfigAPIColumnReplyToAddress.identifier(),
+			SMTPConfigAPIColumnEndpoint.identifier(),
+			SMTPConfigAPIColumnRegion.identifier(),
+			SMTPConfigAPIColumnDomain.identifier(),
+			SMTPConfigAPIColumnKeyID.identifier(),
+			SMTPConfigAPIColumnAPIKey.identifier()).
 			From(smtpConfigsTable.identifier()).
 			LeftJoin(join(SMTPConfigSMTPColumnID, SMTPConfigColumnID)).
 			LeftJoin(join(SMTPConfigHTTPColumnID, SMTPConfigColumnID)).
+			LeftJoin(join(SMTPConfigAPIColumnID, SMTPConfigColumnID)).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*SMTPConfig, error) {
 			config := new(SMTPConfig)
 			var (
 				smtpConfig = sqlSmtpConfig{}
 				httpConfig = sqlHTTPConfig{}
+				apiConfig  = sqlEmailAPIConfig{}
 			)
 			err := row.Scan(
 				&config.CreationDate,
@@ -240,6 +316,16 @@ func prepareSMTPConfigQuery() (sq.SelectBuilder, func(*sql.Row) (*SMTPConfig, er
 				&password,
 				&httpConfig.id,
 				&httpConfig.endpoint,
+				&apiConfig.id,
+				&apiConfig.provider,
+				&apiConfig.senderAddress,
+				&apiConfig.senderName,
+				&apiConfig.replyToAddress,
+				&apiConfig.endpoint,
+				&apiConfig.region,
+				&apiConfig.domain,
+				&apiConfig.keyID,
+				&apiKey,
 			)
 			if err != nil {
 				if errors.Is(err, sql.ErrNoRows) {
@@ -250,6 +336,8 @@ func prepareSMTPConfigQuery() (sq.SelectBuilder, func(*sql.Row) (*SMTPConfig, er
 			smtpConfig.password = password
 			smtpConfig.set(config)
 			httpConfig.setSMTP(config)
+			apiConfig.apiKey = apiKey
+			apiConfig.set(config)
 			return config, nil
 		}
 }
@@ -275,19 +363,33 @@ func prepareSMTPConfigsQuery() (sq.SelectBuilder, func(*sql.Rows) (*SMTPConfigs,
 
 			SMTPConfigHTTPColumnID.identifier(),
 			SMTPConfigHTTPColumnEndpoint.identifier(),
+
+			SMTPConfigAPIColumnID.identifier(),
+			SMTPConfigAPIColumnProvider.identifier(),
+			SMTPConfigAPIColumnSenderAddress.identifier(),
+			SMTPConfigAPIColumnSenderName.identifier(),
+			SMTPConfigAPIColumnReplyToAddress.identifier(),
+			SMTPConfigAPIColumnEndpoint.identifier(),
+			SMTPConfigAPIColumnRegion.identifier(),
+			SMTPConfigAPIColumnDomain.identifier(),
+			SMTPConfigAPIColumnKeyID.identifier(),
+			SMTPConfigAPIColumnAPIKey.identifier(),
 			countColumn.identifier(),
 		).From(smtpConfigsTable.identifier()).
 			LeftJoin(join(SMTPConfigSMTPColumnID, SMTPConfigColumnID)).
 			LeftJoin(join(SMTPConfigHTTPColumnID, SMTPConfigColumnID)).
+			LeftJoin(join(SMTPConfigAPIColumnID, SMTPConfigColumnID)).
 			PlaceholderFormat(sq.Dollar),
 		func(rows *sql.Rows) (*SMTPConfigs, error) {
 			configs := &SMTPConfigs{Configs: []*SMTPConfig{}}
 			for rows.Next() {
 				config := new(SMTPConfig)
 				password := new(crypto.CryptoValue)
+				apiKey := new(crypto.CryptoValue)
 				var (
 					smtpConfig = sqlSmtpConfig{}
 					httpConfig = sqlHTTPConfig{}
+					apiConfig  = sqlEmailAPIConfig{}
 				)
 				err := rows.Scan(
 					&config.CreationDate,
@@ -307,6 +409,16 @@ func prepareSMTPConfigsQuery() (sq.SelectBuilder, func(*sql.Rows) (*SMTPConfigs,
 					&password,
 					&httpConfig.id,
 					&httpConfig.endpoint,
+					&apiConfig.id,
+					&apiConfig.provider,
+					&apiConfig.senderAddress,
+					&apiConfig.senderName,
+					&apiConfig.replyToAddress,
+					&apiConfig.endpoint,
+					&apiConfig.region,
+					&apiConfig.domain,
+					&apiConfig.keyID,
+					&apiKey,
 					&configs.Count,
 				)
 				if err != nil {
@@ -318,6 +430,8 @@ func prepareSMTPConfigsQuery() (sq.SelectBuilder, func(*sql.Rows) (*SMTPConfigs,
 				smtpConfig.password = password
 				smtpConfig.set(config)
 				httpConfig.setSMTP(config)
+				apiConfig.apiKey = apiKey
+				apiConfig.set(config)
 				configs.Configs = append(configs.Configs, config)
 			}
 			return configs, nil
@@ -382,3 +496,33 @@ func (c sqlHTTPConfig) setSMTP(smtpConfig *SMTPConfig) {
 		Endpoint: c.endpoint.String,
 	}
 }
+
+type sqlEmailAPIConfig struct {
+	id             sql.NullString
+	provider       sql.NullInt16
+	senderAddress  sql.NullString
+	senderName     sql.NullString
+	replyToAddress sql.NullString
+	endpoint       sql.NullString
+	region         sql.NullString
+	domain         sql.NullString
+	keyID          sql.NullString
+	apiKey         *crypto.CryptoValue
+}
+
+func (c sqlEmailAPIConfig) set(smtpConfig *SMTPConfig) {
+	if !c.id.Valid {
+		return
+	}
+	smtpConfig.APIConfig = &EmailAPI{
+		Provider:       domain.EmailAPIProvider(c.provider.Int16),
+		SenderAddress:  c.senderAddress.String,
+		SenderName:     c.senderName.String,
+		ReplyToAddress: c.replyToAddress.String,
+		Endpoint:       c.endpoint.String,
+		Region:         c.region.String,
+		Domain:         c.domain.String,
+		KeyID:          c.keyID.String,
+		APIKey:         c.apiKey,
+	}
+}
@@ -14,26 +14,37 @@ import (
 )
 
 var (
-	prepareSMTPConfigStmt = `SELECT projections.smtp_configs5.creation_date,` +
-		` projections.smtp_configs5.change_date,` +
-		` projections.smtp_configs5.resource_owner,` +
-		` projections.smtp_configs5.sequence,` +
-		` projections.smtp_configs5.id,` +
-		` projections.smtp_configs5.state,` +
-		` projections.smtp_configs5.description,` +
-		` projections.smtp_configs5_smtp.id,` +
-		` projections.smtp_configs5_smtp.tls,` +
-		` projections.smtp_configs5_smtp.sender_address,` +
-		` projections.smtp_configs5_smtp.sender_name,` +
-		` projections.smtp_configs5_smtp.reply_to_address,` +
-		` projections.smtp_configs5_smtp.host,` +
-		` projections.smtp_configs5_smtp.username,` +
-		` projections.smtp_configs5_smtp.password,` +
-		` projections.smtp_configs5_http.id,` +
-		` projections.smtp_configs5_http.endpoint` +
-		` FROM projections.smtp_configs5` +
-		` LEFT JOIN projections.smtp_configs5_smtp ON projections.smtp_configs5.id = projections.smtp_configs5_smtp.id AND projections.smtp_configs5.instance_id = projections.smtp_configs5_smtp.instance_id` +
-		` LEFT JOIN projections.smtp_configs5_http ON projections.smtp_configs5.id = projections.smtp_configs5_http.id AND projections.smtp_configs5.instance_id = projections.smtp_configs5_http.instance_id`
+	prepareSMTPConfigStmt = `SELECT projections.smtp_configs6.creation_date,` +
+		` projections.smtp_configs6.change_date,` +
+		` projections.smtp_configs6.resource_owner,` +
+		` projections.smtp_configs6.sequence,` +
+		` projections.smtp_configs6.id,` +
+		` projections.smtp_configs6.state,` +
+		` projections.smtp_configs6.description,` +
+		` projections.smtp_configs6_smtp.id,` +
+		` projections.smtp_configs6_smtp.tls,` +
+		` projections.smtp_configs6_smtp.sender_address,` +
+		` projections.smtp_configs6_smtp.sender_name,` +
+		` projections.smtp_configs6_smtp.reply_to_address,` +
+		` projections.smtp_configs6_smtp.host,` +
+		` projections.smtp_configs6_smtp.username,` +
+		` projections.smtp_configs6_smtp.password,` +
+		` projections.smtp_configs6_http.id,` +
+		` projections.smtp_configs6_http.endpoint,` +
+		` projections.smtp_configs6_api.id,` +
+		` projections.smtp_configs6_api.provider,` +
+		` projections.smtp_configs6_api.sender_address,` +
+		` projections.smtp_configs6_api.sender_name,` +
+		` projections.smtp_configs6_api.reply_to_address,` +
+		` projections.smtp_configs6_api.endpoint,` +
+		` projections.smtp_configs6_api.region,` +
+		` projections.smtp_configs6_api.domain,` +
+		` projections.smtp_configs6_api.key_id,` +
+		` projections.smtp_configs6_api.api_key` +
+		` FROM projections.smtp_configs6` +
+		` LEFT JOIN projections.smtp_configs6_smtp ON projections.smtp_configs6.id = projections.smtp_configs6_smtp.id AND projections.smtp_configs6.instance_id = projections.smtp_configs6_smtp.instance_id` +
+		` LEFT JOIN projections.smtp_configs6_http ON projections.smtp_configs6.id = projections.smtp_configs6_http.id AND projections.smtp_configs6.instance_id = projections.smtp_configs6_http.instance_id` +
+		` LEFT JOIN projections.smtp_configs6_api ON projections.smtp_configs6.id = projections.smtp_configs6_api.id AND projections.smtp_configs6.instance_id = projections.smtp_configs6_api.instance_id`
 	prepareSMTPConfigCols = []string{
 		"creation_date",
 		"change_date",
@@ -52,6 +63,16 @@ var (
 		"smtp_password",
 		"id",
 		"endpoint",
+		"id",
+		"provider",
+		"sender_address",
+		"sender_name",
+		"reply_to_address",
+		"endpoint",
+		"region",
+		"domain",
+		"key_id",
+		"api_key",
 	}
 )
 
@@ -109,6 +130,16 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 						&crypto.CryptoValue{},
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -156,6 +187,16 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 						nil,
 						"2232323",
 						"endpoint",
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -172,6 +213,63 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 				Description: "test",
 			},
 		},
+		{
+			name:    "prepareSMTPConfigQuery found, api",
+			prepare: prepareSMTPConfigQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareSMTPConfigStmt),
+					prepareSMTPConfigCols,
+					[]driver.Value{
+						testNow,
+						testNow,
+						"ro",
+						uint64(20211108),
+						"2232323",
+						domain.SMTPConfigStateActive,
+						"test",
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						"2232323",
+						domain.EmailAPIProviderMailgun,
+						"sender",
+						"name",
+						"reply-to",
+						"https://api.eu.mailgun.net",
+						"",
+						"mg.domain.ch",
+						"",
+						&crypto.CryptoValue{},
+					},
+				),
+			},
+			object: &SMTPConfig{
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "ro",
+				Sequence:      20211108,
+				APIConfig: &EmailAPI{
+					Provider:       domain.EmailAPIProviderMailgun,
+					SenderAddress:  "sender",
+					SenderName:     "name",
+					ReplyToAddress: "reply-to",
+					Endpoint:       "https://api.eu.mailgun.net",
+					Domain:         "mg.domain.ch",
+					APIKey:         &crypto.CryptoValue{},
+				},
+				ID:          "2232323",
+				State:       domain.SMTPConfigStateActive,
+				Description: "test",
+			},
+		},
 		{
 			name:    "prepareSMTPConfigQuery another config found",
 			prepare: prepareSMTPConfigQuery,
@@ -197,6 +295,16 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 						&crypto.CryptoValue{},
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -244,6 +352,16 @@ func Test_SMTPConfigsPrepares(t *testing.T) {
 						&crypto.CryptoValue{},
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
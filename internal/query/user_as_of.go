@@ -0,0 +1,231 @@
+package query
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	iam_es_model "github.com/zitadel/zitadel/internal/iam/repository/eventsourcing/model"
+	org_es_model "github.com/zitadel/zitadel/internal/org/repository/eventsourcing/model"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	user_view_model "github.com/zitadel/zitadel/internal/user/repository/view/model"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// GetUserByIDAsOf returns the user as it was at the point in time.
+// The user is reduced the same way as the user view of the login
+// and the login names are computed from the domains and the domain policy of the organization at that time.
+func (q *Queries) GetUserByIDAsOf(ctx context.Context, userID string, asOf *AsOf) (_ *User, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-ieY4u", "Errors.User.UserIDMissing")
+	}
+	if err = asOf.validate(); err != nil {
+		return nil, err
+	}
+	userModel := newUserAsOfReadModel(userID, asOf)
+	if err = q.eventstore.FilterToQueryReducer(ctx, userModel); err != nil {
+		return nil, err
+	}
+	if userModel.user.ID == "" || userModel.user.State == int32(user_view_model.UserStateDeleted) {
+		return nil, zerrors.ThrowNotFound(nil, "QUERY-Ooh3a", "Errors.User.NotFound")
+	}
+	loginNamesModel := newLoginNamesAsOfReadModel(userModel.user.ResourceOwner, asOf)
+	if err = q.eventstore.FilterToQueryReducer(ctx, loginNamesModel); err != nil {
+		return nil, err
+	}
+	return userFromViewAsOf(userModel.user, loginNamesModel), nil
+}
+
+type userAsOfReadModel struct {
+	eventstore.ReadModel
+
+	asOf *AsOf
+	user *user_view_model.UserView
+}
+
+func newUserAsOfReadModel(userID string, asOf *AsOf) *userAsOfReadModel {
+	return &userAsOfReadModel{
+		ReadModel: eventstore.ReadModel{
+			AggregateID: userID,
+		},
+		asOf: asOf,
+		user: new(user_view_model.UserView),
+	}
+}
+
+func (rm *userAsOfReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		if err := rm.user.AppendEvent(event); err != nil {
+			return err
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *userAsOfReadModel) Query() *eventstore.SearchQueryBuilder {
+	return rm.asOf.filter(
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			AddQuery().
+			AggregateTypes(user.AggregateType).
+			AggregateIDs(rm.AggregateID).
+			EventTypes(rm.user.EventTypes()...).
+			Builder(),
+	)
+}
+
+// loginNamesAsOfReadModel reduces the domains and the domain policy of the organization
+// and the default domain policy of the instance, which are needed to compute the login names.
+type loginNamesAsOfReadModel struct {
+	eventstore.ReadModel
+
+	asOf          *AsOf
+	org           *org_es_model.Org
+	defaultPolicy *iam_es_model.DomainPolicy
+}
+
+func newLoginNamesAsOfReadModel(orgID string, asOf *AsOf) *loginNamesAsOfReadModel {
+	return &loginNamesAsOfReadModel{
+		ReadModel: eventstore.ReadModel{
+			AggregateID: orgID,
+		},
+		asOf: asOf,
+		org:  new(org_es_model.Org),
+	}
+}
+
+func (rm *loginNamesAsOfReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		if event.Aggregate().Type == org.AggregateType {
+			if err := rm.org.AppendEvent(event); err != nil {
+				return err
+			}
+			continue
+		}
+		if event.Type() == instance.DomainPolicyAddedEventType {
+			rm.defaultPolicy = new(iam_es_model.DomainPolicy)
+		}
+		if rm.defaultPolicy == nil {
+			continue
+		}
+		if err := rm.defaultPolicy.SetData(event); err != nil {
+			return err
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *loginNamesAsOfReadModel) userLoginMustBeDomain() bool {
+	if rm.org.DomainPolicy != nil {
+		return rm.org.DomainPolicy.UserLoginMustBeDomain
+	}
+	return rm.defaultPolicy != nil && rm.defaultPolicy.UserLoginMustBeDomain
+}
+
+// loginNames returns the login names of the user the same way as the login names projection:
+// the username suffixed with every verified domain if the policy requires it, otherwise only the username.
+func (rm *loginNamesAsOfReadModel) loginNames(view *user_view_model.UserView) (loginNames []string, preferredLoginName string) {
+	if !rm.userLoginMustBeDomain() {
+		return []string{view.UserName}, view.UserName
+	}
+	loginNames = make([]string, 0, len(rm.org.Domains))
+	for _, d := range rm.org.Domains {
+		if !d.Verified {
+			continue
+		}
+		loginName := view.GenerateLoginName(d.Domain, true)
+		loginNames = append(loginNames, loginName)
+		if d.Primary {
+			preferredLoginName = loginName
+		}
+	}
+	return loginNames, preferredLoginName
+}
+
+func (rm *loginNamesAsOfReadModel) Query() *eventstore.SearchQueryBuilder {
+	return rm.asOf.filter(
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			AddQuery().
+			AggregateTypes(org.AggregateType).
+			AggregateIDs(rm.AggregateID).
+			EventTypes(
+				org.OrgDomainAddedEventType,
+				org.OrgDomainVerifiedEventType,
+				org.OrgDomainPrimarySetEventType,
+				org.OrgDomainRemovedEventType,
+				org.DomainPolicyAddedEventType,
+				org.DomainPolicyChangedEventType,
+				org.DomainPolicyRemovedEventType,
+			).
+			Or().
+			AggregateTypes(instance.AggregateType).
+			EventTypes(
+				instance.DomainPolicyAddedEventType,
+				instance.DomainPolicyChangedEventType,
+			).
+			Builder(),
+	)
+}
+
+func userFromViewAsOf(view *user_view_model.UserView, loginNamesModel *loginNamesAsOfReadModel) *User {
+	u := &User{
+		ID:            view.ID,
+		CreationDate:  view.CreationDate,
+		ChangeDate:    view.ChangeDate,
+		ResourceOwner: view.ResourceOwner,
+		Sequence:      view.Sequence,
+		State:         userStateAsOf(view),
+		Username:      view.UserName,
+	}
+	u.LoginNames, u.PreferredLoginName = loginNamesModel.loginNames(view)
+	if !view.HumanView.IsZero() {
+		u.Type = domain.UserTypeHuman
+		u.Human = &Human{
+			FirstName:              view.FirstName,
+			LastName:               view.LastName,
+			NickName:               view.NickName,
+			DisplayName:            view.DisplayName,
+			AvatarKey:              view.AvatarKey,
+			PreferredLanguage:      language.Make(view.PreferredLanguage),
+			Gender:                 domain.Gender(view.Gender),
+			Email:                  domain.EmailAddress(view.Email),
+			IsEmailVerified:        view.IsEmailVerified,
+			Phone:                  domain.PhoneNumber(view.Phone),
+			IsPhoneVerified:        view.IsPhoneVerified,
+			PasswordChangeRequired: view.PasswordChangeRequired,
+			PasswordChanged:        view.PasswordChanged,
+			MFAInitSkipped:         view.MFAInitSkipped,
+		}
+		return u
+	}
+	if !view.MachineView.IsZero() {
+		u.Type = domain.UserTypeMachine
+		u.Machine = &Machine{
+			Name:            view.MachineView.Name,
+			Description:     view.MachineView.Description,
+			AccessTokenType: domain.OIDCTokenType(view.MachineView.AccessTokenType),
+		}
+	}
+	return u
+}
+
+// userStateAsOf returns the state of the user view as the user projection states it:
+// a human is initial as long as it has to be initialized by code or a passwordless authenticator,
+// unverified email addresses do not matter.
+func userStateAsOf(view *user_view_model.UserView) domain.UserState {
+	state := domain.UserState(view.State)
+	if view.HumanView.IsZero() || (state != domain.UserStateActive && state != domain.UserStateInitial) {
+		return state
+	}
+	if view.InitRequired || view.PasswordlessInitRequired {
+		return domain.UserStateInitial
+	}
+	return domain.UserStateActive
+}
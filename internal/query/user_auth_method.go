@@ -67,6 +67,14 @@ var (
 		name:  projection.UserAuthMethodDomainCol,
 		table: userAuthMethodTable,
 	}
+	UserAuthMethodColumnAAGUID = Column{
+		name:  projection.UserAuthMethodAAGUIDCol,
+		table: userAuthMethodTable,
+	}
+	UserAuthMethodColumnModelName = Column{
+		name:  projection.UserAuthMethodModelNameCol,
+		table: userAuthMethodTable,
+	}
 
 	authMethodTypeTable      = userAuthMethodTable.setAlias("auth_method_types")
 	authMethodTypeUserID     = UserAuthMethodColumnUserID.setTable(authMethodTypeTable)
@@ -128,6 +136,10 @@ type AuthMethod struct {
 	TokenID string
 	Name    string
 	Type    domain.UserAuthMethodType
+	// AAGUID identifies the model of a WebAuthN authenticator
+	AAGUID string
+	// ModelName is the description of the authenticator model in the FIDO metadata
+	ModelName string
 }
 
 type AuthMethodTypes struct {
@@ -391,6 +403,8 @@ func prepareUserAuthMethodsQuery() (sq.SelectBuilder, func(*sql.Rows) (*AuthMeth
 			UserAuthMethodColumnName.identifier(),
 			UserAuthMethodColumnState.identifier(),
 			UserAuthMethodColumnMethodType.identifier(),
+			UserAuthMethodColumnAAGUID.identifier(),
+			UserAuthMethodColumnModelName.identifier(),
 			countColumn.identifier()).
 			From(userAuthMethodTable.identifier()).
 			PlaceholderFormat(sq.Dollar),
@@ -399,6 +413,7 @@ func prepareUserAuthMethodsQuery() (sq.SelectBuilder, func(*sql.Rows) (*AuthMeth
 			var count uint64
 			for rows.Next() {
 				authMethod := new(AuthMethod)
+				var aaguid, modelName sql.NullString
 				err := rows.Scan(
 					&authMethod.TokenID,
 					&authMethod.CreationDate,
@@ -409,11 +424,15 @@ func prepareUserAuthMethodsQuery() (sq.SelectBuilder, func(*sql.Rows) (*AuthMeth
 					&authMethod.Name,
 					&authMethod.State,
 					&authMethod.Type,
+					&aaguid,
+					&modelName,
 					&count,
 				)
 				if err != nil {
 					return nil, err
 				}
+				authMethod.AAGUID = aaguid.String
+				authMethod.ModelName = modelName.String
 				userAuthMethods = append(userAuthMethods, authMethod)
 			}
 
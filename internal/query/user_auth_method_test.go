@@ -189,6 +189,8 @@ var (
 		` projections.user_auth_methods5.name,` +
 		` projections.user_auth_methods5.state,` +
 		` projections.user_auth_methods5.method_type,` +
+		` projections.user_auth_methods5.aaguid,` +
+		` projections.user_auth_methods5.model_name,` +
 		` COUNT(*) OVER ()` +
 		` FROM projections.user_auth_methods5`
 	prepareUserAuthMethodsCols = []string{
@@ -201,6 +203,8 @@ var (
 		"name",
 		"state",
 		"method_type",
+		"aaguid",
+		"model_name",
 		"count",
 	}
 	prepareActiveAuthMethodTypesStmt = `SELECT projections.users14_notifications.password_set,` +
@@ -307,6 +311,8 @@ func Test_UserAuthMethodPrepares(t *testing.T) {
 							"name",
 							domain.MFAStateReady,
 							domain.UserAuthMethodTypeU2F,
+							"cb69481e-8ff7-4039-93ec-0a2729a154a8",
+							"YubiKey 5 Series",
 						},
 					},
 				),
@@ -326,6 +332,8 @@ func Test_UserAuthMethodPrepares(t *testing.T) {
 						Name:          "name",
 						State:         domain.MFAStateReady,
 						Type:          domain.UserAuthMethodTypeU2F,
+						AAGUID:        "cb69481e-8ff7-4039-93ec-0a2729a154a8",
+						ModelName:     "YubiKey 5 Series",
 					},
 				},
 			},
@@ -348,6 +356,8 @@ func Test_UserAuthMethodPrepares(t *testing.T) {
 							"name",
 							domain.MFAStateReady,
 							domain.UserAuthMethodTypeU2F,
+							"cb69481e-8ff7-4039-93ec-0a2729a154a8",
+							"YubiKey 5 Series",
 						},
 						{
 							"token_id-2",
@@ -359,6 +369,8 @@ func Test_UserAuthMethodPrepares(t *testing.T) {
 							"name-2",
 							domain.MFAStateReady,
 							domain.UserAuthMethodTypePasswordless,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -378,6 +390,8 @@ func Test_UserAuthMethodPrepares(t *testing.T) {
 						Name:          "name",
 						State:         domain.MFAStateReady,
 						Type:          domain.UserAuthMethodTypeU2F,
+						AAGUID:        "cb69481e-8ff7-4039-93ec-0a2729a154a8",
+						ModelName:     "YubiKey 5 Series",
 					},
 					{
 						TokenID:       "token_id-2",
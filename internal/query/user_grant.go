@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strconv"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -50,6 +51,9 @@ type UserGrant struct {
 	GrantedOrgID     string `json:"granted_org_id,omitempty"`
 	GrantedOrgName   string `json:"granted_org_name,omitempty"`
 	GrantedOrgDomain string `json:"granted_org_domain,omitempty"`
+
+	// GroupID is set if the grant is inherited through a group membership
+	GroupID string `json:"group_id,omitempty"`
 }
 
 type UserGrants struct {
@@ -60,6 +64,10 @@ type UserGrants struct {
 type UserGrantsQueries struct {
 	SearchRequest
 	Queries []SearchQuery
+	// IncludeGroupGrants adds the grants inherited through group memberships to the result.
+	// They are returned with the id of the group grant and the [UserGrant.GroupID],
+	// so they must not be used where the ids are passed to the commands of user grants.
+	IncludeGroupGrants bool
 }
 
 func (q *UserGrantsQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
@@ -169,6 +177,7 @@ func NewUserGrantContainsRolesSearchQuery(roles ...string) (SearchQuery, error)
 var (
 	userGrantTable = table{
 		name:          projection.UserGrantProjectionTable,
+		alias:         "user_grants",
 		instanceIDCol: projection.UserGrantInstanceID,
 	}
 	UserGrantID = Column{
@@ -215,6 +224,11 @@ var (
 		name:  projection.UserGrantState,
 		table: userGrantTable,
 	}
+	// UserGrantGroupID is only available in the list of grants (see [userGrantsFromQuery])
+	UserGrantGroupID = Column{
+		name:  projection.GroupGrantGroupIDCol,
+		table: userGrantTable,
+	}
 	GrantedOrgsTable = table{
 		name:          projection.OrgProjectionTable,
 		alias:         "granted_orgs",
@@ -271,16 +285,27 @@ func (q *Queries) UserGrants(ctx context.Context, queries *UserGrantsQueries, sh
 		ctx, err = projection.UserGrantProjection.Trigger(ctx, handler.WithAwaitRunning())
 		logging.OnError(err).Debug("unable to trigger")
 		traceSpan.EndWithError(err)
+
+		if queries.IncludeGroupGrants {
+			_, traceSpan = tracing.NewNamedSpan(ctx, "TriggerGroupProjection")
+			ctx, err = projection.GroupProjection.Trigger(ctx, handler.WithAwaitRunning())
+			logging.OnError(err).Debug("unable to trigger")
+			traceSpan.EndWithError(err)
+		}
 	}
 
-	query, scan := prepareUserGrantsQuery()
+	query, scan := prepareUserGrantsQuery(queries.IncludeGroupGrants)
 	eq := sq.Eq{UserGrantInstanceID.identifier(): authz.GetInstance(ctx).InstanceID()}
 	stmt, args, err := queries.toQuery(query).Where(eq).ToSql()
 	if err != nil {
 		return nil, zerrors.ThrowInternal(err, "QUERY-wXnQR", "Errors.Query.SQLStatement")
 	}
 
-	latestState, err := q.latestState(ctx, userGrantTable)
+	projections := []table{userGrantTable}
+	if queries.IncludeGroupGrants {
+		projections = append(projections, groupTable)
+	}
+	latestState, err := q.latestState(ctx, projections...)
 	if err != nil {
 		return nil, err
 	}
@@ -420,7 +445,52 @@ func prepareUserGrantQuery() (sq.SelectBuilder, func(*sql.Row) (*UserGrant, erro
 		}
 }
 
-func prepareUserGrantsQuery() (sq.SelectBuilder, func(*sql.Rows) (*UserGrants, error)) {
+// userGrantsFromQuery returns the grants of the users and, if requested,
+// combines them with the grants of the groups they are a member of.
+// The grants of a group are active and returned for each member with the id of the group.
+func userGrantsFromQuery(includeGroupGrants bool) string {
+	userGrants, _ := sq.Select(
+		UserGrantID.name,
+		UserGrantCreationDate.name,
+		UserGrantChangeDate.name,
+		UserGrantSequence.name,
+		UserGrantGrantID.name,
+		UserGrantRoles.name,
+		UserGrantState.name,
+		UserGrantUserID.name,
+		UserGrantResourceOwner.name,
+		UserGrantProjectID.name,
+		UserGrantInstanceID.name,
+		"NULL::TEXT AS "+UserGrantGroupID.name,
+	).From(userGrantTable.name).MustSql()
+	if !includeGroupGrants {
+		return "(" + userGrants + ") AS " + userGrantTable.alias
+	}
+	groupGrants, _ := sq.Select(
+		GroupGrantColumnID.identifier(),
+		GroupGrantColumnCreationDate.identifier(),
+		GroupGrantColumnChangeDate.identifier(),
+		GroupGrantColumnSequence.identifier(),
+		GroupGrantColumnProjectGrantID.identifier(),
+		GroupGrantColumnRoles.identifier(),
+		strconv.Itoa(int(domain.UserGrantStateActive)),
+		GroupMemberColumnUserID.identifier(),
+		GroupGrantColumnResourceOwner.identifier(),
+		GroupGrantColumnProjectID.identifier(),
+		GroupGrantColumnInstanceID.identifier(),
+		GroupGrantColumnGroupID.identifier(),
+	).From(groupGrantTable.identifier()).
+		Join(join(GroupMemberColumnGroupID, GroupGrantColumnGroupID)).
+		MustSql()
+
+	return "(" +
+		userGrants +
+		" UNION ALL " +
+		groupGrants +
+		") AS " + userGrantTable.alias
+}
+
+func prepareUserGrantsQuery(includeGroupGrants bool) (sq.SelectBuilder, func(*sql.Rows) (*UserGrants, error)) {
 	return sq.Select(
 			UserGrantID.identifier(),
 			UserGrantCreationDate.identifier(),
@@ -452,9 +522,10 @@ func prepareUserGrantsQuery() (sq.SelectBuilder, func(*sql.Rows) (*UserGrants, e
 			GrantedOrgColumnName.identifier(),
 			GrantedOrgColumnDomain.identifier(),
 
+			UserGrantGroupID.identifier(),
 			countColumn.identifier(),
 		).
-			From(userGrantTable.identifier()).
+			From(userGrantsFromQuery(includeGroupGrants)).
 			LeftJoin(join(UserIDCol, UserGrantUserID)).
 			LeftJoin(join(HumanUserIDCol, UserGrantUserID)).
 			LeftJoin(join(OrgColumnID, UserGrantResourceOwner)).
@@ -489,6 +560,7 @@ func prepareUserGrantsQuery() (sq.SelectBuilder, func(*sql.Rows) (*UserGrants, e
 					grantedOrgDomain sql.NullString
 
 					projectName sql.NullString
+					groupID     sql.NullString
 				)
 
 				err := rows.Scan(
@@ -522,6 +594,7 @@ func prepareUserGrantsQuery() (sq.SelectBuilder, func(*sql.Rows) (*UserGrants, e
 					&grantedOrgName,
 					&grantedOrgDomain,
 
+					&groupID,
 					&count,
 				)
 				if err != nil {
@@ -543,6 +616,7 @@ func prepareUserGrantsQuery() (sq.SelectBuilder, func(*sql.Rows) (*UserGrants, e
 				g.GrantedOrgID = grantedOrgID.String
 				g.GrantedOrgName = grantedOrgName.String
 				g.GrantedOrgDomain = grantedOrgDomain.String
+				g.GroupID = groupID.String
 
 				userGrants = append(userGrants, g)
 			}
@@ -0,0 +1,347 @@
+package query
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// UserGrantsAsOf returns the grants of the user as they were at the point in time,
+// including the grants inherited through groups the user was a member of.
+// Only the fields stored on the grant events are set,
+// the names of the user, organization and project are not.
+func (q *Queries) UserGrantsAsOf(ctx context.Context, userID string, asOf *AsOf) (_ []*UserGrant, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-aeR5i", "Errors.User.UserIDMissing")
+	}
+	if err = asOf.validate(); err != nil {
+		return nil, err
+	}
+	grants, err := q.directUserGrantsAsOf(ctx, userID, asOf)
+	if err != nil {
+		return nil, err
+	}
+	groupGrants, err := q.groupUserGrantsAsOf(ctx, userID, asOf)
+	if err != nil {
+		return nil, err
+	}
+	return append(grants, groupGrants...), nil
+}
+
+func (q *Queries) directUserGrantsAsOf(ctx context.Context, userID string, asOf *AsOf) ([]*UserGrant, error) {
+	// only the added events contain the user id, so the ids of the grants are filtered first
+	grantIDs, err := q.aggregateIDsAsOf(ctx, asOf, usergrant.AggregateType, usergrant.UserGrantAddedType, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(grantIDs) == 0 {
+		return []*UserGrant{}, nil
+	}
+	readModel := newUserGrantsAsOfReadModel(grantIDs, asOf)
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	return readModel.userGrants(), nil
+}
+
+func (q *Queries) groupUserGrantsAsOf(ctx context.Context, userID string, asOf *AsOf) ([]*UserGrant, error) {
+	// only the member events contain the user id, so the ids of the groups are filtered first
+	groupIDs, err := q.aggregateIDsAsOf(ctx, asOf, group.AggregateType, group.MemberAddedEventType, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(groupIDs) == 0 {
+		return nil, nil
+	}
+	// the roles of the group grants are cascaded by the project events, which are filtered by the granted projects
+	grantsAdded, err := q.eventstore.Filter(ctx, asOf.filter(
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			AddQuery().
+			AggregateTypes(group.AggregateType).
+			AggregateIDs(groupIDs...).
+			EventTypes(group.GrantAddedEventType).
+			Builder(),
+	))
+	if err != nil {
+		return nil, err
+	}
+	if len(grantsAdded) == 0 {
+		return nil, nil
+	}
+	projectIDs := make([]string, 0, len(grantsAdded))
+	for _, event := range grantsAdded {
+		if e, ok := event.(*group.GrantAddedEvent); ok && !slices.Contains(projectIDs, e.ProjectID) {
+			projectIDs = append(projectIDs, e.ProjectID)
+		}
+	}
+	readModel := newGroupGrantsAsOfReadModel(userID, groupIDs, projectIDs, asOf)
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	return readModel.userGrants(), nil
+}
+
+// aggregateIDsAsOf returns the ids of the aggregates which added the user until the point in time.
+func (q *Queries) aggregateIDsAsOf(ctx context.Context, asOf *AsOf, aggregateType eventstore.AggregateType, eventType eventstore.EventType, userID string) ([]string, error) {
+	added, err := q.eventstore.Filter(ctx, asOf.filter(
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			AddQuery().
+			AggregateTypes(aggregateType).
+			EventTypes(eventType).
+			EventData(map[string]interface{}{"userId": userID}).
+			Builder(),
+	))
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(added))
+	for _, event := range added {
+		if !slices.Contains(ids, event.Aggregate().ID) {
+			ids = append(ids, event.Aggregate().ID)
+		}
+	}
+	return ids, nil
+}
+
+type userGrantsAsOfReadModel struct {
+	eventstore.ReadModel
+
+	asOf     *AsOf
+	grantIDs []string
+	grants   map[string]*UserGrant
+}
+
+func newUserGrantsAsOfReadModel(grantIDs []string, asOf *AsOf) *userGrantsAsOfReadModel {
+	return &userGrantsAsOfReadModel{
+		asOf:     asOf,
+		grantIDs: grantIDs,
+		grants:   make(map[string]*UserGrant, len(grantIDs)),
+	}
+}
+
+func (rm *userGrantsAsOfReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		if e, ok := event.(*usergrant.UserGrantAddedEvent); ok {
+			rm.grants[e.Aggregate().ID] = &UserGrant{
+				ID:            e.Aggregate().ID,
+				CreationDate:  e.CreatedAt(),
+				Roles:         e.RoleKeys,
+				GrantID:       e.ProjectGrantID,
+				State:         domain.UserGrantStateActive,
+				UserID:        e.UserID,
+				ResourceOwner: e.Aggregate().ResourceOwner,
+				ProjectID:     e.ProjectID,
+			}
+		}
+		grant, ok := rm.grants[event.Aggregate().ID]
+		if !ok {
+			continue
+		}
+		switch e := event.(type) {
+		case *usergrant.UserGrantChangedEvent:
+			grant.Roles = e.RoleKeys
+		case *usergrant.UserGrantCascadeChangedEvent:
+			grant.Roles = e.RoleKeys
+		case *usergrant.UserGrantDeactivatedEvent:
+			grant.State = domain.UserGrantStateInactive
+		case *usergrant.UserGrantReactivatedEvent:
+			grant.State = domain.UserGrantStateActive
+		case *usergrant.UserGrantRemovedEvent, *usergrant.UserGrantCascadeRemovedEvent:
+			grant.State = domain.UserGrantStateRemoved
+		}
+		grant.Sequence = event.Sequence()
+		grant.ChangeDate = event.CreatedAt()
+	}
+	return rm.ReadModel.Reduce()
+}
+
+// userGrants returns the grants which were not removed in the order they were added.
+func (rm *userGrantsAsOfReadModel) userGrants() []*UserGrant {
+	grants := make([]*UserGrant, 0, len(rm.grants))
+	for _, id := range rm.grantIDs {
+		grant, ok := rm.grants[id]
+		if !ok || grant.State == domain.UserGrantStateRemoved {
+			continue
+		}
+		grants = append(grants, grant)
+	}
+	return grants
+}
+
+func (rm *userGrantsAsOfReadModel) Query() *eventstore.SearchQueryBuilder {
+	return rm.asOf.filter(
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			AddQuery().
+			AggregateTypes(usergrant.AggregateType).
+			AggregateIDs(rm.grantIDs...).
+			EventTypes(
+				usergrant.UserGrantAddedType,
+				usergrant.UserGrantChangedType,
+				usergrant.UserGrantCascadeChangedType,
+				usergrant.UserGrantDeactivatedType,
+				usergrant.UserGrantReactivatedType,
+				usergrant.UserGrantRemovedType,
+				usergrant.UserGrantCascadeRemovedType,
+			).
+			Builder(),
+	)
+}
+
+// groupGrantsAsOfReadModel reduces the grants of the groups the user was a member of
+// the same way as the group projection does.
+type groupGrantsAsOfReadModel struct {
+	eventstore.ReadModel
+
+	asOf       *AsOf
+	userID     string
+	groupIDs   []string
+	projectIDs []string
+	// memberOf contains the ids of the groups the user is a member of
+	memberOf map[string]bool
+	// grantIDs contains the ids of the group grants in the order they were added
+	grantIDs []string
+	grants   map[string]*UserGrant
+}
+
+func newGroupGrantsAsOfReadModel(userID string, groupIDs, projectIDs []string, asOf *AsOf) *groupGrantsAsOfReadModel {
+	return &groupGrantsAsOfReadModel{
+		asOf:       asOf,
+		userID:     userID,
+		groupIDs:   groupIDs,
+		projectIDs: projectIDs,
+		memberOf:   make(map[string]bool, len(groupIDs)),
+		grants:     make(map[string]*UserGrant),
+	}
+}
+
+func (rm *groupGrantsAsOfReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *group.MemberAddedEvent:
+			if e.UserID == rm.userID {
+				rm.memberOf[e.Aggregate().ID] = true
+			}
+		case *group.MemberRemovedEvent:
+			if e.UserID == rm.userID {
+				delete(rm.memberOf, e.Aggregate().ID)
+			}
+		case *user.UserRemovedEvent:
+			clear(rm.memberOf)
+		case *group.RemovedEvent:
+			delete(rm.memberOf, e.Aggregate().ID)
+			rm.removeGrants(func(grant *UserGrant) bool { return grant.GroupID == e.Aggregate().ID })
+		case *group.GrantAddedEvent:
+			rm.grantIDs = append(rm.grantIDs, e.GrantID)
+			rm.grants[e.GrantID] = &UserGrant{
+				ID:            e.GrantID,
+				CreationDate:  e.CreatedAt(),
+				ChangeDate:    e.CreatedAt(),
+				Sequence:      e.Sequence(),
+				Roles:         e.RoleKeys,
+				GrantID:       e.ProjectGrantID,
+				State:         domain.UserGrantStateActive,
+				UserID:        rm.userID,
+				ResourceOwner: e.Aggregate().ResourceOwner,
+				ProjectID:     e.ProjectID,
+				GroupID:       e.Aggregate().ID,
+			}
+		case *group.GrantChangedEvent:
+			if grant, ok := rm.grants[e.GrantID]; ok {
+				grant.Roles = e.RoleKeys
+				grant.ChangeDate = e.CreatedAt()
+				grant.Sequence = e.Sequence()
+			}
+		case *group.GrantRemovedEvent:
+			delete(rm.grants, e.GrantID)
+		case *project.ProjectRemovedEvent:
+			rm.removeGrants(func(grant *UserGrant) bool { return grant.ProjectID == e.Aggregate().ID })
+		case *project.GrantRemovedEvent:
+			rm.removeGrants(func(grant *UserGrant) bool { return grant.GrantID == e.GrantID })
+		case *project.RoleRemovedEvent:
+			for _, grant := range rm.grants {
+				if grant.ProjectID == e.Aggregate().ID {
+					grant.Roles = slices.DeleteFunc(slices.Clone(grant.Roles), func(role string) bool { return role == e.Key })
+				}
+			}
+		case *project.GrantChangedEvent:
+			rm.intersectRoles(e.GrantID, e.RoleKeys)
+		case *project.GrantCascadeChangedEvent:
+			rm.intersectRoles(e.GrantID, e.RoleKeys)
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *groupGrantsAsOfReadModel) removeGrants(remove func(grant *UserGrant) bool) {
+	for id, grant := range rm.grants {
+		if remove(grant) {
+			delete(rm.grants, id)
+		}
+	}
+}
+
+// intersectRoles removes the roles of the group grants on the project grant which are no longer granted.
+func (rm *groupGrantsAsOfReadModel) intersectRoles(projectGrantID string, roleKeys []string) {
+	for _, grant := range rm.grants {
+		if grant.GrantID != projectGrantID {
+			continue
+		}
+		grant.Roles = slices.DeleteFunc(slices.Clone(grant.Roles), func(role string) bool { return !slices.Contains(roleKeys, role) })
+	}
+}
+
+// userGrants returns the grants of the groups the user is a member of in the order they were added.
+func (rm *groupGrantsAsOfReadModel) userGrants() []*UserGrant {
+	grants := make([]*UserGrant, 0, len(rm.grants))
+	for _, id := range rm.grantIDs {
+		grant, ok := rm.grants[id]
+		if !ok || !rm.memberOf[grant.GroupID] {
+			continue
+		}
+		grants = append(grants, grant)
+	}
+	return grants
+}
+
+func (rm *groupGrantsAsOfReadModel) Query() *eventstore.SearchQueryBuilder {
+	return rm.asOf.filter(
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			AddQuery().
+			AggregateTypes(group.AggregateType).
+			AggregateIDs(rm.groupIDs...).
+			EventTypes(
+				group.RemovedEventType,
+				group.MemberAddedEventType,
+				group.MemberRemovedEventType,
+				group.GrantAddedEventType,
+				group.GrantChangedEventType,
+				group.GrantRemovedEventType,
+			).
+			Or().
+			AggregateTypes(project.AggregateType).
+			AggregateIDs(rm.projectIDs...).
+			EventTypes(
+				project.ProjectRemovedType,
+				project.GrantRemovedType,
+				project.RoleRemovedType,
+				project.GrantChangedType,
+				project.GrantCascadeChangedType,
+			).
+			Or().
+			AggregateTypes(user.AggregateType).
+			AggregateIDs(rm.userID).
+			EventTypes(user.UserRemovedType).
+			Builder(),
+	)
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"reflect"
 	"regexp"
 	"testing"
 
@@ -15,14 +16,14 @@ import (
 
 var (
 	userGrantStmt = regexp.QuoteMeta(
-		"SELECT projections.user_grants5.id" +
-			", projections.user_grants5.creation_date" +
-			", projections.user_grants5.change_date" +
-			", projections.user_grants5.sequence" +
-			", projections.user_grants5.grant_id" +
-			", projections.user_grants5.roles" +
-			", projections.user_grants5.state" +
-			", projections.user_grants5.user_id" +
+		"SELECT user_grants.id" +
+			", user_grants.creation_date" +
+			", user_grants.change_date" +
+			", user_grants.sequence" +
+			", user_grants.grant_id" +
+			", user_grants.roles" +
+			", user_grants.state" +
+			", user_grants.user_id" +
 			", projections.users14.username" +
 			", projections.users14.type" +
 			", projections.users14.resource_owner" +
@@ -32,21 +33,21 @@ var (
 			", projections.users14_humans.display_name" +
 			", projections.users14_humans.avatar_key" +
 			", projections.login_names3.login_name" +
-			", projections.user_grants5.resource_owner" +
+			", user_grants.resource_owner" +
 			", projections.orgs1.name" +
 			", projections.orgs1.primary_domain" +
-			", projections.user_grants5.project_id" +
+			", user_grants.project_id" +
 			", projections.projects4.name" +
 			", granted_orgs.id" +
 			", granted_orgs.name" +
 			", granted_orgs.primary_domain" +
-			" FROM projections.user_grants5" +
-			" LEFT JOIN projections.users14 ON projections.user_grants5.user_id = projections.users14.id AND projections.user_grants5.instance_id = projections.users14.instance_id" +
-			" LEFT JOIN projections.users14_humans ON projections.user_grants5.user_id = projections.users14_humans.user_id AND projections.user_grants5.instance_id = projections.users14_humans.instance_id" +
-			" LEFT JOIN projections.orgs1 ON projections.user_grants5.resource_owner = projections.orgs1.id AND projections.user_grants5.instance_id = projections.orgs1.instance_id" +
-			" LEFT JOIN projections.projects4 ON projections.user_grants5.project_id = projections.projects4.id AND projections.user_grants5.instance_id = projections.projects4.instance_id" +
+			" FROM projections.user_grants5 AS user_grants" +
+			" LEFT JOIN projections.users14 ON user_grants.user_id = projections.users14.id AND user_grants.instance_id = projections.users14.instance_id" +
+			" LEFT JOIN projections.users14_humans ON user_grants.user_id = projections.users14_humans.user_id AND user_grants.instance_id = projections.users14_humans.instance_id" +
+			" LEFT JOIN projections.orgs1 ON user_grants.resource_owner = projections.orgs1.id AND user_grants.instance_id = projections.orgs1.instance_id" +
+			" LEFT JOIN projections.projects4 ON user_grants.project_id = projections.projects4.id AND user_grants.instance_id = projections.projects4.instance_id" +
 			" LEFT JOIN projections.orgs1 AS granted_orgs ON projections.users14.resource_owner = granted_orgs.id AND projections.users14.instance_id = granted_orgs.instance_id" +
-			" LEFT JOIN projections.login_names3 ON projections.user_grants5.user_id = projections.login_names3.user_id AND projections.user_grants5.instance_id = projections.login_names3.instance_id" +
+			" LEFT JOIN projections.login_names3 ON user_grants.user_id = projections.login_names3.user_id AND user_grants.instance_id = projections.login_names3.instance_id" +
 			" WHERE projections.login_names3.is_primary = $1")
 	userGrantCols = []string{
 		"id",
@@ -76,14 +77,14 @@ var (
 		"primary_domain", // granted org domain
 	}
 	userGrantsStmt = regexp.QuoteMeta(
-		"SELECT projections.user_grants5.id" +
-			", projections.user_grants5.creation_date" +
-			", projections.user_grants5.change_date" +
-			", projections.user_grants5.sequence" +
-			", projections.user_grants5.grant_id" +
-			", projections.user_grants5.roles" +
-			", projections.user_grants5.state" +
-			", projections.user_grants5.user_id" +
+		"SELECT user_grants.id" +
+			", user_grants.creation_date" +
+			", user_grants.change_date" +
+			", user_grants.sequence" +
+			", user_grants.grant_id" +
+			", user_grants.roles" +
+			", user_grants.state" +
+			", user_grants.user_id" +
 			", projections.users14.username" +
 			", projections.users14.type" +
 			", projections.users14.resource_owner" +
@@ -93,25 +94,65 @@ var (
 			", projections.users14_humans.display_name" +
 			", projections.users14_humans.avatar_key" +
 			", projections.login_names3.login_name" +
-			", projections.user_grants5.resource_owner" +
+			", user_grants.resource_owner" +
 			", projections.orgs1.name" +
 			", projections.orgs1.primary_domain" +
-			", projections.user_grants5.project_id" +
+			", user_grants.project_id" +
 			", projections.projects4.name" +
 			", granted_orgs.id" +
 			", granted_orgs.name" +
 			", granted_orgs.primary_domain" +
+			", user_grants.group_id" +
 			", COUNT(*) OVER ()" +
-			" FROM projections.user_grants5" +
-			" LEFT JOIN projections.users14 ON projections.user_grants5.user_id = projections.users14.id AND projections.user_grants5.instance_id = projections.users14.instance_id" +
-			" LEFT JOIN projections.users14_humans ON projections.user_grants5.user_id = projections.users14_humans.user_id AND projections.user_grants5.instance_id = projections.users14_humans.instance_id" +
-			" LEFT JOIN projections.orgs1 ON projections.user_grants5.resource_owner = projections.orgs1.id AND projections.user_grants5.instance_id = projections.orgs1.instance_id" +
-			" LEFT JOIN projections.projects4 ON projections.user_grants5.project_id = projections.projects4.id AND projections.user_grants5.instance_id = projections.projects4.instance_id" +
+			" FROM (SELECT id, creation_date, change_date, sequence, grant_id, roles, state, user_id, resource_owner, project_id, instance_id, NULL::TEXT AS group_id FROM projections.user_grants5) AS user_grants" +
+			" LEFT JOIN projections.users14 ON user_grants.user_id = projections.users14.id AND user_grants.instance_id = projections.users14.instance_id" +
+			" LEFT JOIN projections.users14_humans ON user_grants.user_id = projections.users14_humans.user_id AND user_grants.instance_id = projections.users14_humans.instance_id" +
+			" LEFT JOIN projections.orgs1 ON user_grants.resource_owner = projections.orgs1.id AND user_grants.instance_id = projections.orgs1.instance_id" +
+			" LEFT JOIN projections.projects4 ON user_grants.project_id = projections.projects4.id AND user_grants.instance_id = projections.projects4.instance_id" +
 			" LEFT JOIN projections.orgs1 AS granted_orgs ON projections.users14.resource_owner = granted_orgs.id AND projections.users14.instance_id = granted_orgs.instance_id" +
-			" LEFT JOIN projections.login_names3 ON projections.user_grants5.user_id = projections.login_names3.user_id AND projections.user_grants5.instance_id = projections.login_names3.instance_id" +
+			" LEFT JOIN projections.login_names3 ON user_grants.user_id = projections.login_names3.user_id AND user_grants.instance_id = projections.login_names3.instance_id" +
+			" WHERE projections.login_names3.is_primary = $1")
+	userGrantsWithGroupsStmt = regexp.QuoteMeta(
+		"SELECT user_grants.id" +
+			", user_grants.creation_date" +
+			", user_grants.change_date" +
+			", user_grants.sequence" +
+			", user_grants.grant_id" +
+			", user_grants.roles" +
+			", user_grants.state" +
+			", user_grants.user_id" +
+			", projections.users14.username" +
+			", projections.users14.type" +
+			", projections.users14.resource_owner" +
+			", projections.users14_humans.first_name" +
+			", projections.users14_humans.last_name" +
+			", projections.users14_humans.email" +
+			", projections.users14_humans.display_name" +
+			", projections.users14_humans.avatar_key" +
+			", projections.login_names3.login_name" +
+			", user_grants.resource_owner" +
+			", projections.orgs1.name" +
+			", projections.orgs1.primary_domain" +
+			", user_grants.project_id" +
+			", projections.projects4.name" +
+			", granted_orgs.id" +
+			", granted_orgs.name" +
+			", granted_orgs.primary_domain" +
+			", user_grants.group_id" +
+			", COUNT(*) OVER ()" +
+			" FROM (SELECT id, creation_date, change_date, sequence, grant_id, roles, state, user_id, resource_owner, project_id, instance_id, NULL::TEXT AS group_id FROM projections.user_grants5" +
+			" UNION ALL SELECT projections.groups1_grants.id, projections.groups1_grants.creation_date, projections.groups1_grants.change_date, projections.groups1_grants.sequence, projections.groups1_grants.project_grant_id, projections.groups1_grants.roles, 1, projections.groups1_members.user_id, projections.groups1_grants.resource_owner, projections.groups1_grants.project_id, projections.groups1_grants.instance_id, projections.groups1_grants.group_id" +
+			" FROM projections.groups1_grants JOIN projections.groups1_members ON projections.groups1_grants.group_id = projections.groups1_members.group_id AND projections.groups1_grants.instance_id = projections.groups1_members.instance_id) AS user_grants" +
+			" LEFT JOIN projections.users14 ON user_grants.user_id = projections.users14.id AND user_grants.instance_id = projections.users14.instance_id" +
+			" LEFT JOIN projections.users14_humans ON user_grants.user_id = projections.users14_humans.user_id AND user_grants.instance_id = projections.users14_humans.instance_id" +
+			" LEFT JOIN projections.orgs1 ON user_grants.resource_owner = projections.orgs1.id AND user_grants.instance_id = projections.orgs1.instance_id" +
+			" LEFT JOIN projections.projects4 ON user_grants.project_id = projections.projects4.id AND user_grants.instance_id = projections.projects4.instance_id" +
+			" LEFT JOIN projections.orgs1 AS granted_orgs ON projections.users14.resource_owner = granted_orgs.id AND projections.users14.instance_id = granted_orgs.instance_id" +
+			" LEFT JOIN projections.login_names3 ON user_grants.user_id = projections.login_names3.user_id AND user_grants.instance_id = projections.login_names3.instance_id" +
 			" WHERE projections.login_names3.is_primary = $1")
 	userGrantsCols = append(
 		userGrantCols,
+		"group_id",
 		"count",
 	)
 )
@@ -122,10 +163,11 @@ func Test_UserGrantPrepares(t *testing.T) {
 		err             checkErr
 	}
 	tests := []struct {
-		name    string
-		prepare interface{}
-		want    want
-		object  interface{}
+		name        string
+		prepare     interface{}
+		prepareArgs []reflect.Value
+		want        want
+		object      interface{}
 	}{
 		{
 			name:    "prepareUserGrantQuery no result",
@@ -483,8 +525,9 @@ func Test_UserGrantPrepares(t *testing.T) {
 			object: (*UserGrant)(nil),
 		},
 		{
-			name:    "prepareUserGrantsQuery no result",
-			prepare: prepareUserGrantsQuery,
+			name:        "prepareUserGrantsQuery no result",
+			prepare:     prepareUserGrantsQuery,
+			prepareArgs: []reflect.Value{reflect.ValueOf(false)},
 			want: want{
 				sqlExpectations: mockQueries(
 					userGrantsStmt,
@@ -495,8 +538,9 @@ func Test_UserGrantPrepares(t *testing.T) {
 			object: &UserGrants{UserGrants: []*UserGrant{}},
 		},
 		{
-			name:    "prepareUserGrantsQuery one grant",
-			prepare: prepareUserGrantsQuery,
+			name:        "prepareUserGrantsQuery one grant",
+			prepare:     prepareUserGrantsQuery,
+			prepareArgs: []reflect.Value{reflect.ValueOf(false)},
 			want: want{
 				sqlExpectations: mockQueries(
 					userGrantsStmt,
@@ -528,6 +572,7 @@ func Test_UserGrantPrepares(t *testing.T) {
 							"granted-org-id",
 							"granted-org-name",
 							"granted-org-domain",
+							nil,
 						},
 					},
 				),
@@ -568,8 +613,85 @@ func Test_UserGrantPrepares(t *testing.T) {
 			},
 		},
 		{
-			name:    "prepareUserGrantsQuery one grant (machine user)",
-			prepare: prepareUserGrantsQuery,
+			name:        "prepareUserGrantsQuery one grant of a group",
+			prepare:     prepareUserGrantsQuery,
+			prepareArgs: []reflect.Value{reflect.ValueOf(true)},
+			want: want{
+				sqlExpectations: mockQueries(
+					userGrantsWithGroupsStmt,
+					userGrantsCols,
+					[][]driver.Value{
+						{
+							"id",
+							testNow,
+							testNow,
+							20211111,
+							"grant-id",
+							database.TextArray[string]{"role-key"},
+							domain.UserGrantStateActive,
+							"user-id",
+							"username",
+							domain.UserTypeHuman,
+							"resource-owner",
+							"first-name",
+							"last-name",
+							"email",
+							"display-name",
+							"avatar-key",
+							"login-name",
+							"ro",
+							"org-name",
+							"primary-domain",
+							"project-id",
+							"project-name",
+							"granted-org-id",
+							"granted-org-name",
+							"granted-org-domain",
+							"group-id",
+						},
+					},
+				),
+			},
+			object: &UserGrants{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				UserGrants: []*UserGrant{
+					{
+						ID:                 "id",
+						CreationDate:       testNow,
+						ChangeDate:         testNow,
+						Sequence:           20211111,
+						Roles:              database.TextArray[string]{"role-key"},
+						GrantID:            "grant-id",
+						State:              domain.UserGrantStateActive,
+						UserID:             "user-id",
+						Username:           "username",
+						UserType:           domain.UserTypeHuman,
+						UserResourceOwner:  "resource-owner",
+						FirstName:          "first-name",
+						LastName:           "last-name",
+						Email:              "email",
+						DisplayName:        "display-name",
+						AvatarURL:          "avatar-key",
+						PreferredLoginName: "login-name",
+						ResourceOwner:      "ro",
+						OrgName:            "org-name",
+						OrgPrimaryDomain:   "primary-domain",
+						ProjectID:          "project-id",
+						ProjectName:        "project-name",
+						GrantedOrgID:       "granted-org-id",
+						GrantedOrgName:     "granted-org-name",
+						GrantedOrgDomain:   "granted-org-domain",
+						GroupID:            "group-id",
+					},
+				},
+			},
+		},
+		{
+			name:        "prepareUserGrantsQuery one grant (machine user)",
+			prepare:     prepareUserGrantsQuery,
+			prepareArgs: []reflect.Value{reflect.ValueOf(false)},
 			want: want{
 				sqlExpectations: mockQueries(
 					userGrantsStmt,
@@ -601,6 +723,7 @@ func Test_UserGrantPrepares(t *testing.T) {
 							"granted-org-id",
 							"granted-org-name",
 							"granted-org-domain",
+							nil,
 						},
 					},
 				),
@@ -641,8 +764,9 @@ func Test_UserGrantPrepares(t *testing.T) {
 			},
 		},
 		{
-			name:    "prepareUserGrantsQuery one grant (no org)",
-			prepare: prepareUserGrantsQuery,
+			name:        "prepareUserGrantsQuery one grant (no org)",
+			prepare:     prepareUserGrantsQuery,
+			prepareArgs: []reflect.Value{reflect.ValueOf(false)},
 			want: want{
 				sqlExpectations: mockQueries(
 					userGrantsStmt,
@@ -674,6 +798,7 @@ func Test_UserGrantPrepares(t *testing.T) {
 							"granted-org-id",
 							"granted-org-name",
 							"granted-org-domain",
+							nil,
 						},
 					},
 				),
@@ -714,8 +839,9 @@ func Test_UserGrantPrepares(t *testing.T) {
 			},
 		},
 		{
-			name:    "prepareUserGrantsQuery one grant (no project)",
-			prepare: prepareUserGrantsQuery,
+			name:        "prepareUserGrantsQuery one grant (no project)",
+			prepare:     prepareUserGrantsQuery,
+			prepareArgs: []reflect.Value{reflect.ValueOf(false)},
 			want: want{
 				sqlExpectations: mockQueries(
 					userGrantsStmt,
@@ -747,6 +873,7 @@ func Test_UserGrantPrepares(t *testing.T) {
 							"granted-org-id",
 							"granted-org-name",
 							"granted-org-domain",
+							nil,
 						},
 					},
 				),
@@ -787,8 +914,9 @@ func Test_UserGrantPrepares(t *testing.T) {
 			},
 		},
 		{
-			name:    "prepareUserGrantsQuery one grant (no loginname)",
-			prepare: prepareUserGrantsQuery,
+			name:        "prepareUserGrantsQuery one grant (no loginname)",
+			prepare:     prepareUserGrantsQuery,
+			prepareArgs: []reflect.Value{reflect.ValueOf(false)},
 			want: want{
 				sqlExpectations: mockQueries(
 					userGrantsStmt,
@@ -820,6 +948,7 @@ func Test_UserGrantPrepares(t *testing.T) {
 							"granted-org-id",
 							"granted-org-name",
 							"granted-org-domain",
+							nil,
 						},
 					},
 				),
@@ -860,8 +989,9 @@ func Test_UserGrantPrepares(t *testing.T) {
 			},
 		},
 		{
-			name:    "prepareUserGrantsQuery multiple grants",
-			prepare: prepareUserGrantsQuery,
+			name:        "prepareUserGrantsQuery multiple grants",
+			prepare:     prepareUserGrantsQuery,
+			prepareArgs: []reflect.Value{reflect.ValueOf(false)},
 			want: want{
 				sqlExpectations: mockQueries(
 					userGrantsStmt,
@@ -893,6 +1023,7 @@ func Test_UserGrantPrepares(t *testing.T) {
 							"granted-org-id",
 							"granted-org-name",
 							"granted-org-domain",
+							nil,
 						},
 						{
 							"id",
@@ -920,6 +1051,7 @@ func Test_UserGrantPrepares(t *testing.T) {
 							"granted-org-id",
 							"granted-org-name",
 							"granted-org-domain",
+							nil,
 						},
 					},
 				),
@@ -987,8 +1119,9 @@ func Test_UserGrantPrepares(t *testing.T) {
 			},
 		},
 		{
-			name:    "prepareUserGrantsQuery sql err",
-			prepare: prepareUserGrantsQuery,
+			name:        "prepareUserGrantsQuery sql err",
+			prepare:     prepareUserGrantsQuery,
+			prepareArgs: []reflect.Value{reflect.ValueOf(false)},
 			want: want{
 				sqlExpectations: mockQueryErr(
 					userGrantsStmt,
@@ -1006,7 +1139,7 @@ func Test_UserGrantPrepares(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, tt.prepareArgs...)
 		})
 	}
 }
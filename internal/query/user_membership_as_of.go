@@ -0,0 +1,253 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// MembershipsAsOf returns the memberships of the user as they were at the point in time.
+// The names of the organizations and projects are not set.
+func (q *Queries) MembershipsAsOf(ctx context.Context, userID string, asOf *AsOf) (_ []*Membership, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-Sho6e", "Errors.User.UserIDMissing")
+	}
+	if err = asOf.validate(); err != nil {
+		return nil, err
+	}
+	readModel := newMembershipsAsOfReadModel(userID, asOf)
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	return readModel.memberships(), nil
+}
+
+// membershipsAsOfReadModel reduces the events of each membership of the user
+// with the write model of the member the commands use.
+type membershipsAsOfReadModel struct {
+	eventstore.ReadModel
+
+	asOf   *AsOf
+	userID string
+	// keys contains the keys of the memberships in the order they were added
+	keys    []string
+	members map[string]*membershipAsOf
+}
+
+type membershipAsOf struct {
+	model        eventstore.QueryReducer
+	creationDate time.Time
+	membership   *Membership
+}
+
+func newMembershipsAsOfReadModel(userID string, asOf *AsOf) *membershipsAsOfReadModel {
+	return &membershipsAsOfReadModel{
+		asOf:    asOf,
+		userID:  userID,
+		members: make(map[string]*membershipAsOf),
+	}
+}
+
+func (rm *membershipsAsOfReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *instance.MemberAddedEvent, *org.MemberAddedEvent, *project.MemberAddedEvent:
+			rm.add(event, "")
+		case *project.GrantMemberAddedEvent:
+			rm.add(event, e.GrantID)
+		case *project.GrantMemberChangedEvent:
+			rm.append(event, e.GrantID)
+		case *project.GrantMemberRemovedEvent:
+			rm.append(event, e.GrantID)
+		case *project.GrantMemberCascadeRemovedEvent:
+			rm.append(event, e.GrantID)
+		case *org.OrgRemovedEvent:
+			delete(rm.members, membershipAsOfKey(event, ""))
+		case *project.ProjectRemovedEvent:
+			delete(rm.members, membershipAsOfKey(event, ""))
+			rm.appendGrants(event)
+		case *project.GrantRemovedEvent:
+			rm.appendGrants(event)
+		default:
+			rm.append(event, "")
+		}
+	}
+	for _, m := range rm.members {
+		if err := m.model.Reduce(); err != nil {
+			return err
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *membershipsAsOfReadModel) add(event eventstore.Event, grantID string) {
+	key := membershipAsOfKey(event, grantID)
+	m, ok := rm.members[key]
+	if !ok {
+		m = rm.newMembership(event.Aggregate(), grantID)
+		rm.keys = append(rm.keys, key)
+		rm.members[key] = m
+	}
+	m.creationDate = event.CreatedAt()
+	m.model.AppendEvents(event)
+}
+
+func (rm *membershipsAsOfReadModel) newMembership(aggregate *eventstore.Aggregate, grantID string) *membershipAsOf {
+	m := &membershipAsOf{
+		membership: &Membership{
+			UserID:        rm.userID,
+			ResourceOwner: aggregate.ResourceOwner,
+		},
+	}
+	switch {
+	case aggregate.Type == instance.AggregateType:
+		m.model = &command.InstanceMemberWriteModel{
+			MemberWriteModel: command.MemberWriteModel{
+				WriteModel: eventstore.WriteModel{
+					AggregateID:   aggregate.ID,
+					ResourceOwner: aggregate.ResourceOwner,
+				},
+				UserID: rm.userID,
+			},
+		}
+		m.membership.IAM = &IAMMembership{IAMID: aggregate.ID}
+	case aggregate.Type == org.AggregateType:
+		m.model = command.NewOrgMemberWriteModel(aggregate.ID, rm.userID)
+		m.membership.Org = &OrgMembership{OrgID: aggregate.ID}
+	case grantID != "":
+		m.model = command.NewProjectGrantMemberWriteModel(aggregate.ID, rm.userID, grantID)
+		m.membership.ProjectGrant = &ProjectGrantMembership{ProjectID: aggregate.ID, GrantID: grantID}
+	default:
+		m.model = command.NewProjectMemberWriteModel(aggregate.ID, rm.userID, aggregate.ResourceOwner)
+		m.membership.Project = &ProjectMembership{ProjectID: aggregate.ID}
+	}
+	return m
+}
+
+// append passes the event to the write model of the membership, if it was added before.
+func (rm *membershipsAsOfReadModel) append(event eventstore.Event, grantID string) {
+	m, ok := rm.members[membershipAsOfKey(event, grantID)]
+	if !ok {
+		return
+	}
+	m.model.AppendEvents(event)
+}
+
+// appendGrants passes the event to the write models of all project grant memberships of the project,
+// which filter the removals of other grants.
+func (rm *membershipsAsOfReadModel) appendGrants(event eventstore.Event) {
+	for _, m := range rm.members {
+		grant := m.membership.ProjectGrant
+		if grant == nil || grant.ProjectID != event.Aggregate().ID {
+			continue
+		}
+		m.model.AppendEvents(event)
+	}
+}
+
+// memberships returns the memberships which were not removed in the order they were added.
+func (rm *membershipsAsOfReadModel) memberships() []*Membership {
+	memberships := make([]*Membership, 0, len(rm.members))
+	for _, key := range rm.keys {
+		m, ok := rm.members[key]
+		if !ok {
+			continue
+		}
+		if membership := m.reduced(); membership != nil {
+			memberships = append(memberships, membership)
+		}
+	}
+	return memberships
+}
+
+// reduced returns the membership with the state of the reduced write model
+// or nil if the membership was removed.
+func (m *membershipAsOf) reduced() *Membership {
+	var (
+		writeModel *eventstore.WriteModel
+		state      domain.MemberState
+		roles      []string
+	)
+	switch model := m.model.(type) {
+	case *command.InstanceMemberWriteModel:
+		writeModel, state, roles = &model.WriteModel, model.State, model.Roles
+	case *command.OrgMemberWriteModel:
+		writeModel, state, roles = &model.WriteModel, model.State, model.Roles
+	case *command.ProjectMemberWriteModel:
+		writeModel, state, roles = &model.WriteModel, model.State, model.Roles
+	case *command.ProjectGrantMemberWriteModel:
+		writeModel, state, roles = &model.WriteModel, model.State, model.Roles
+	}
+	if state != domain.MemberStateActive {
+		return nil
+	}
+	membership := *m.membership
+	membership.Roles = roles
+	membership.CreationDate = m.creationDate
+	membership.ChangeDate = writeModel.ChangeDate
+	membership.Sequence = writeModel.ProcessedSequence
+	return &membership
+}
+
+func membershipAsOfKey(event eventstore.Event, grantID string) string {
+	return string(event.Aggregate().Type) + ":" + event.Aggregate().ID + ":" + grantID
+}
+
+func (rm *membershipsAsOfReadModel) Query() *eventstore.SearchQueryBuilder {
+	userID := map[string]interface{}{"userId": rm.userID}
+	return rm.asOf.filter(
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			AddQuery().
+			AggregateTypes(instance.AggregateType).
+			EventTypes(
+				instance.MemberAddedEventType,
+				instance.MemberChangedEventType,
+				instance.MemberRemovedEventType,
+				instance.MemberCascadeRemovedEventType,
+			).
+			EventData(userID).
+			Or().
+			AggregateTypes(org.AggregateType).
+			EventTypes(
+				org.MemberAddedEventType,
+				org.MemberChangedEventType,
+				org.MemberRemovedEventType,
+				org.MemberCascadeRemovedEventType,
+			).
+			EventData(userID).
+			Or().
+			AggregateTypes(org.AggregateType).
+			EventTypes(org.OrgRemovedEventType).
+			Or().
+			AggregateTypes(project.AggregateType).
+			EventTypes(
+				project.MemberAddedEventType,
+				project.MemberChangedEventType,
+				project.MemberRemovedEventType,
+				project.MemberCascadeRemovedEventType,
+				project.GrantMemberAddedType,
+				project.GrantMemberChangedType,
+				project.GrantMemberRemovedType,
+				project.GrantMemberCascadeRemovedType,
+			).
+			EventData(userID).
+			Or().
+			AggregateTypes(project.AggregateType).
+			EventTypes(
+				project.ProjectRemovedType,
+				project.GrantRemovedType,
+			).
+			Builder(),
+	)
+}
@@ -5,12 +5,16 @@ import (
 	"database/sql"
 	_ "embed"
 	"errors"
+	"slices"
 	"strings"
 	"sync"
 	"text/template"
 
+	"github.com/zitadel/logging"
+
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	"github.com/zitadel/zitadel/internal/query/projection"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -28,6 +32,7 @@ var oidcUserInfoTriggerHandlers = sync.OnceValue(func() []*handler.Handler {
 		projection.UserGrantProjection,
 		projection.OrgProjection,
 		projection.ProjectProjection,
+		projection.GroupProjection,
 	}
 })
 
@@ -65,13 +70,19 @@ func (q *Queries) GetOIDCUserInfo(ctx context.Context, userID string, roleAudien
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	queryKey := oidcUserInfoQueryKey(instanceID, userID, roleAudience, roleOrgIDs)
+	if entry, ok := q.caches.oidcUserInfo.Get(ctx, oidcUserInfoIndexByQuery, queryKey); ok {
+		return entry.UserInfo, nil
+	}
+
 	if len(roleOrgIDs) > 0 {
 		userInfo, err = database.QueryJSONObject[OIDCUserInfo](ctx, q.client, oidcUserInfoWithRoleOrgIDsQuery,
-			userID, authz.GetInstance(ctx).InstanceID(), database.TextArray[string](roleAudience), database.TextArray[string](roleOrgIDs),
+			userID, instanceID, database.TextArray[string](roleAudience), database.TextArray[string](roleOrgIDs),
 		)
 	} else {
 		userInfo, err = database.QueryJSONObject[OIDCUserInfo](ctx, q.client, oidcUserInfoQuery,
-			userID, authz.GetInstance(ctx).InstanceID(), database.TextArray[string](roleAudience),
+			userID, instanceID, database.TextArray[string](roleAudience),
 		)
 	}
 	if errors.Is(err, sql.ErrNoRows) {
@@ -84,14 +95,26 @@ func (q *Queries) GetOIDCUserInfo(ctx context.Context, userID string, roleAudien
 		return nil, zerrors.ThrowNotFound(nil, "QUERY-ahs4S", "Errors.User.NotFound")
 	}
 
+	q.caches.oidcUserInfo.Set(ctx, &oidcUserInfoEntry{
+		InstanceID: instanceID,
+		QueryKey:   queryKey,
+		UserInfo:   userInfo,
+	})
 	return userInfo, nil
 }
 
 type OIDCUserInfo struct {
-	User       *User          `json:"user,omitempty"`
-	Metadata   []UserMetadata `json:"metadata,omitempty"`
-	Org        *UserInfoOrg   `json:"org,omitempty"`
-	UserGrants []UserGrant    `json:"user_grants,omitempty"`
+	User       *User           `json:"user,omitempty"`
+	Metadata   []UserMetadata  `json:"metadata,omitempty"`
+	Org        *UserInfoOrg    `json:"org,omitempty"`
+	UserGrants []UserGrant     `json:"user_grants,omitempty"`
+	Groups     []UserInfoGroup `json:"groups,omitempty"`
+}
+
+type UserInfoGroup struct {
+	ID            string `json:"id,omitempty"`
+	Name          string `json:"name,omitempty"`
+	ResourceOwner string `json:"resource_owner,omitempty"`
 }
 
 type UserInfoOrg struct {
@@ -121,3 +144,120 @@ func (q *Queries) GetOIDCUserinfoClientByID(ctx context.Context, clientID string
 	}
 	return projectID, projectRoleAssertion, nil
 }
+
+type oidcUserInfoIndex int
+
+//go:generate enumer -type oidcUserInfoIndex -linecomment
+const (
+	// Empty line comment ensures empty string for unspecified value
+	oidcUserInfoIndexUnspecified oidcUserInfoIndex = iota //
+	oidcUserInfoIndexByQuery
+	oidcUserInfoIndexByRelatedID
+)
+
+// oidcUserInfoEntry is the cached result of [Queries.GetOIDCUserInfo].
+type oidcUserInfoEntry struct {
+	InstanceID string
+	// QueryKey identifies the user and query arguments the user info was obtained with.
+	QueryKey string
+	UserInfo *OIDCUserInfo
+}
+
+// Keys implements [cache.Entry]
+func (e *oidcUserInfoEntry) Keys(index oidcUserInfoIndex) []string {
+	switch index {
+	case oidcUserInfoIndexByQuery:
+		return []string{e.QueryKey}
+	case oidcUserInfoIndexByRelatedID:
+		return e.relatedIDKeys()
+	case oidcUserInfoIndexUnspecified:
+	}
+	return nil
+}
+
+// relatedIDKeys returns the keys of all aggregates the user info was built from,
+// so that a change on any of them invalidates the entry.
+// The instance ID is included for instance level settings, like the domain policy.
+func (e *oidcUserInfoEntry) relatedIDKeys() []string {
+	ids := []string{e.InstanceID}
+	if e.UserInfo.User != nil {
+		ids = append(ids, e.UserInfo.User.ID, e.UserInfo.User.ResourceOwner)
+	}
+	if e.UserInfo.Org != nil {
+		ids = append(ids, e.UserInfo.Org.ID)
+	}
+	for _, grant := range e.UserInfo.UserGrants {
+		ids = append(ids, grant.ID, grant.ResourceOwner, grant.ProjectID)
+		if grant.GroupID != "" {
+			ids = append(ids, grant.GroupID)
+		}
+	}
+	for _, group := range e.UserInfo.Groups {
+		ids = append(ids, group.ID)
+	}
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = oidcUserInfoCacheKey(e.InstanceID, id)
+	}
+	return keys
+}
+
+func oidcUserInfoCacheKey(instanceID, key string) string {
+	return instanceID + "-" + key
+}
+
+// oidcUserInfoQueryKey builds a key from the arguments of [Queries.GetOIDCUserInfo].
+// The arguments are sorted, as their order does not change the result.
+func oidcUserInfoQueryKey(instanceID, userID string, roleAudience, roleOrgIDs []string) string {
+	return strings.Join([]string{
+		instanceID,
+		userID,
+		strings.Join(slices.Sorted(slices.Values(roleAudience)), ","),
+		strings.Join(slices.Sorted(slices.Values(roleOrgIDs)), ","),
+	}, "-")
+}
+
+//go:embed userinfo_related_users.sql
+var oidcUserInfoRelatedUsersQuery string
+
+func (c *Caches) registerOIDCUserInfoInvalidation(client *database.DB) {
+	invalidate := cacheInvalidationFunc(c.oidcUserInfo, oidcUserInfoIndexByRelatedID, func(aggregate *eventstore.Aggregate) string {
+		return oidcUserInfoCacheKey(aggregate.InstanceID, aggregate.ID)
+	})
+	projection.UserProjection.RegisterCacheInvalidation(invalidate)
+	projection.UserMetadataProjection.RegisterCacheInvalidation(invalidate)
+	projection.LoginNameProjection.RegisterCacheInvalidation(invalidate)
+	projection.OrgProjection.RegisterCacheInvalidation(invalidate)
+	projection.ProjectProjection.RegisterCacheInvalidation(invalidate)
+	projection.UserGrantProjection.RegisterCacheInvalidation(invalidate)
+	projection.GroupProjection.RegisterCacheInvalidation(invalidate)
+
+	// New user grants and group memberships are not yet related to a cached user info.
+	// Find the affected users in the (already updated) projections.
+	invalidateUsers := func(ctx context.Context, aggregates []*eventstore.Aggregate) {
+		for instanceID, ids := range aggregateIDsByInstance(aggregates) {
+			var keys []string
+			err := client.QueryContext(ctx, func(rows *sql.Rows) error {
+				for rows.Next() {
+					var userID string
+					if err := rows.Scan(&userID); err != nil {
+						return err
+					}
+					keys = append(keys, oidcUserInfoCacheKey(instanceID, userID))
+				}
+				return rows.Err()
+			}, oidcUserInfoRelatedUsersQuery, instanceID, database.TextArray[string](ids))
+			if err != nil {
+				logging.WithError(err).Warn("cache invalidation failed")
+				continue
+			}
+			err = c.oidcUserInfo.Invalidate(ctx, oidcUserInfoIndexByRelatedID, keys...)
+			logging.OnError(err).Warn("cache invalidation failed")
+		}
+	}
+	projection.UserGrantProjection.RegisterCacheInvalidation(invalidateUsers)
+	projection.GroupProjection.RegisterCacheInvalidation(invalidateUsers)
+}
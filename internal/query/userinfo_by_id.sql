@@ -31,9 +31,17 @@ metadata as (
 		and instance_id = $2
 	) r
 ),
--- get all user grants, needed for the orgs query
+-- find the groups the user is a member of
+user_groups as (
+	select g.id, g.name, g.resource_owner
+	from projections.groups1_members m
+	join projections.groups1 g on g.id = m.group_id and g.instance_id = m.instance_id
+	where m.user_id = $1
+	and m.instance_id = $2
+),
+-- get all user grants, including the grants inherited through groups, needed for the orgs query
 user_grants as (
-	select id, grant_id, state, creation_date, change_date, sequence, user_id, roles, resource_owner, project_id
+	select id, grant_id, state, creation_date, change_date, sequence, user_id, roles, resource_owner, project_id, null as group_id
 	from projections.user_grants5
 	where user_id = $1
 	and instance_id = $2
@@ -42,6 +50,15 @@ user_grants as (
 	{{ if . -}}
 	and resource_owner = any($4)
 	{{- end }}
+	union all
+	select gg.id, gg.project_grant_id as grant_id, 1 as state, gg.creation_date, gg.change_date, gg.sequence, $1 as user_id, gg.roles, gg.resource_owner, gg.project_id, gg.group_id
+	from projections.groups1_grants gg
+	join user_groups ug on ug.id = gg.group_id
+	where gg.instance_id = $2
+	and gg.project_id = any($3)
+	{{ if . -}}
+	and gg.resource_owner = any($4)
+	{{- end }}
 ),
 -- filter all orgs we are interested in.
 orgs as (
@@ -87,5 +104,6 @@ select json_build_object(
 	),
 	'org', (select organization from user_org),
 	'metadata', (select metadata from metadata),
-	'user_grants', (select grants from grants)
+	'user_grants', (select grants from grants),
+	'groups', (select json_agg(row_to_json(r)) from (select id, name, resource_owner from user_groups) r)
 );
@@ -0,0 +1,10 @@
+-- find the users related to user grants or groups, used for cache invalidation.
+select user_id
+from projections.user_grants5
+where instance_id = $1
+	and id = any($2)
+union
+select user_id
+from projections.groups1_members
+where instance_id = $1
+	and group_id = any($2);
@@ -14,6 +14,7 @@ import (
 	"golang.org/x/text/language"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/cache/connector/noop"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -431,6 +432,9 @@ func TestQueries_GetOIDCUserInfo(t *testing.T) {
 					client: &database.DB{
 						DB: db,
 					},
+					caches: &Caches{
+						oidcUserInfo: noop.NewCache[oidcUserInfoIndex, string, *oidcUserInfoEntry](),
+					},
 				}
 				ctx := authz.NewMockContext("instanceID", "orgID", "loginClient")
 
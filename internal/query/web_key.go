@@ -6,6 +6,7 @@ import (
 	_ "embed"
 	"encoding/json"
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/go-jose/go-jose/v4"
@@ -13,6 +14,8 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query/projection"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -128,6 +131,12 @@ func (q *Queries) GetWebKeySet(ctx context.Context) (_ *jose.JSONWebKeySet, err
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	// the keys are cloned, as callers may append to the set.
+	if entry, ok := q.caches.webKeySet.Get(ctx, webKeySetIndexByInstanceID, instanceID); ok {
+		return &jose.JSONWebKeySet{Keys: slices.Clone(entry.WebKeys)}, nil
+	}
+
 	var keys []jose.JSONWebKey
 
 	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
@@ -145,10 +154,46 @@ func (q *Queries) GetWebKeySet(ctx context.Context) (_ *jose.JSONWebKeySet, err
 		return rows.Err()
 	},
 		webKeyPublicKeysQuery,
-		authz.GetInstance(ctx).InstanceID(),
+		instanceID,
 	)
 	if err != nil {
 		return nil, zerrors.ThrowInternal(err, "QUERY-Eeng7", "Errors.Internal")
 	}
-	return &jose.JSONWebKeySet{Keys: keys}, nil
+	q.caches.webKeySet.Set(ctx, &webKeySetEntry{
+		InstanceID: instanceID,
+		WebKeys:    keys,
+	})
+	return &jose.JSONWebKeySet{Keys: slices.Clone(keys)}, nil
+}
+
+type webKeySetIndex int
+
+//go:generate enumer -type webKeySetIndex -linecomment
+const (
+	// Empty line comment ensures empty string for unspecified value
+	webKeySetIndexUnspecified webKeySetIndex = iota //
+	webKeySetIndexByInstanceID
+)
+
+// webKeySetEntry is the cached public web key set of an instance.
+type webKeySetEntry struct {
+	InstanceID string
+	WebKeys    []jose.JSONWebKey
+}
+
+// Keys implements [cache.Entry]
+func (e *webKeySetEntry) Keys(index webKeySetIndex) []string {
+	switch index {
+	case webKeySetIndexByInstanceID:
+		return []string{e.InstanceID}
+	case webKeySetIndexUnspecified:
+	}
+	return nil
+}
+
+func (c *Caches) registerWebKeySetInvalidation() {
+	invalidate := cacheInvalidationFunc(c.webKeySet, webKeySetIndexByInstanceID, func(aggregate *eventstore.Aggregate) string {
+		return aggregate.InstanceID
+	})
+	projection.WebKeyProjection.RegisterCacheInvalidation(invalidate)
 }
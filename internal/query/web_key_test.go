@@ -21,6 +21,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/cache/connector/noop"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -369,6 +370,9 @@ func TestQueries_GetWebKeySet(t *testing.T) {
 					client: &database.DB{
 						DB: db,
 					},
+					caches: &Caches{
+						webKeySet: noop.NewCache[webKeySetIndex, string, *webKeySetEntry](),
+					},
 				}
 				got, err := q.GetWebKeySet(ctx)
 				require.ErrorIs(t, err, tt.wantErr)
@@ -2,6 +2,7 @@ package queue
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/riverqueue/river"
@@ -89,6 +90,22 @@ func WithQueueName(name string) InsertOpt {
 	}
 }
 
+// WithUniqueArgs prevents that a job with the same kind and arguments is inserted again
+// as long as it is not cancelled or discarded.
+func WithUniqueArgs() InsertOpt {
+	return func(opts *river.InsertOpts) {
+		opts.UniqueOpts = river.UniqueOpts{ByArgs: true}
+	}
+}
+
+// WithScheduledAt delays the job until the provided time.
+// A time in the past makes the job available immediately.
+func WithScheduledAt(scheduledAt time.Time) InsertOpt {
+	return func(opts *river.InsertOpts) {
+		opts.ScheduledAt = scheduledAt
+	}
+}
+
 func (q *Queue) Insert(ctx context.Context, args river.JobArgs, opts ...InsertOpt) error {
 	options := new(river.InsertOpts)
 	ctx = WithQueue(ctx)
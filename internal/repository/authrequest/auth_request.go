@@ -17,6 +17,10 @@ const (
 	SessionLinkedType      = authRequestEventPrefix + "session.linked"
 	CodeExchangedType      = authRequestEventPrefix + "code.exchanged"
 	SucceededType          = authRequestEventPrefix + "succeeded"
+	PushedType             = authRequestEventPrefix + "pushed"
+	RequestURIUsedType     = authRequestEventPrefix + "request_uri.used"
+
+	UniqueRequestURIType = "auth_request_uri"
 )
 
 type AddedEvent struct {
@@ -39,6 +43,8 @@ type AddedEvent struct {
 	HintUserID       *string                   `json:"hint_user_id,omitempty"`
 	NeedRefreshToken bool                      `json:"need_refresh_token,omitempty"`
 	Issuer           string                    `json:"issuer,omitempty"`
+	// AuthorizationDetails are the authorization_details of Rich Authorization Requests (RFC 9396).
+	AuthorizationDetails domain.AuthorizationDetails `json:"authorization_details,omitempty"`
 }
 
 func (e *AddedEvent) Payload() interface{} {
@@ -68,6 +74,7 @@ func NewAddedEvent(ctx context.Context,
 	hintUserID *string,
 	needRefreshToken bool,
 	issuer string,
+	authorizationDetails domain.AuthorizationDetails,
 ) *AddedEvent {
 	return &AddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -92,6 +99,8 @@ func NewAddedEvent(ctx context.Context,
 		HintUserID:       hintUserID,
 		NeedRefreshToken: needRefreshToken,
 		Issuer:           issuer,
+
+		AuthorizationDetails: authorizationDetails,
 	}
 }
 
@@ -292,3 +301,116 @@ func SucceededEventMapper(event eventstore.Event) (eventstore.Event, error) {
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
 	}, nil
 }
+
+// PushedEvent marks an auth request as pushed authorization request (RFC 9126).
+// Such a request can only be referenced once by its request_uri until it expires.
+type PushedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Expiration time.Time `json:"expiration"`
+}
+
+func (e *PushedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *PushedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewPushedEvent(ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	expiration time.Time,
+) *PushedEvent {
+	return &PushedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			PushedType,
+		),
+		Expiration: expiration,
+	}
+}
+
+func PushedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	pushed := &PushedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(pushed)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "AUTHR-Pu5h3", "unable to unmarshal auth request pushed")
+	}
+
+	return pushed, nil
+}
+
+type RequestURIUsedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	// LoginV2 is set if the pushed auth request is continued as auth request of the login v2
+	// with the login client, scope and audience resolved at the authorization endpoint.
+	LoginV2          bool     `json:"login_v2,omitempty"`
+	LoginClient      string   `json:"login_client,omitempty"`
+	Scope            []string `json:"scope,omitempty"`
+	Audience         []string `json:"audience,omitempty"`
+	NeedRefreshToken bool     `json:"need_refresh_token,omitempty"`
+}
+
+func (e *RequestURIUsedEvent) Payload() interface{} {
+	return e
+}
+
+// UniqueConstraints ensures the request_uri is only used once,
+// even if it is redeemed concurrently.
+func (e *RequestURIUsedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{
+		eventstore.NewAddEventUniqueConstraint(UniqueRequestURIType, e.Aggregate().ID, "Errors.AuthRequest.RequestURIInvalid"),
+	}
+}
+
+func NewRequestURIUsedEvent(ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *RequestURIUsedEvent {
+	return &RequestURIUsedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RequestURIUsedType,
+		),
+	}
+}
+
+// NewRequestURIUsedLoginV2Event uses the request_uri and continues the pushed auth request
+// as auth request of the login v2.
+func NewRequestURIUsedLoginV2Event(ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	loginClient string,
+	scope,
+	audience []string,
+	needRefreshToken bool,
+) *RequestURIUsedEvent {
+	return &RequestURIUsedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RequestURIUsedType,
+		),
+		LoginV2:          true,
+		LoginClient:      loginClient,
+		Scope:            scope,
+		Audience:         audience,
+		NeedRefreshToken: needRefreshToken,
+	}
+}
+
+func RequestURIUsedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	used := &RequestURIUsedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(used)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "AUTHR-ohT4i", "unable to unmarshal auth request request uri used")
+	}
+
+	return used, nil
+}
@@ -9,4 +9,6 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, CodeExchangedType, CodeExchangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, FailedType, FailedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SucceededType, SucceededEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, PushedType, PushedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, RequestURIUsedType, RequestURIUsedEventMapper)
 }
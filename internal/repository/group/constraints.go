@@ -0,0 +1,27 @@
+package group
+
+import (
+	"fmt"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	UniqueGroupName    = "group_name"
+	DuplicateGroupName = "Errors.Group.AlreadyExists"
+)
+
+func NewAddGroupNameUniqueConstraint(name, resourceOwner string) *eventstore.UniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueGroupName,
+		fmt.Sprintf("%s:%s", resourceOwner, name),
+		DuplicateGroupName,
+	)
+}
+
+func NewRemoveGroupNameUniqueConstraint(name, resourceOwner string) *eventstore.UniqueConstraint {
+	return eventstore.NewRemoveUniqueConstraint(
+		UniqueGroupName,
+		fmt.Sprintf("%s:%s", resourceOwner, name),
+	)
+}
@@ -0,0 +1,63 @@
+package group
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	memberEventTypePrefix  = eventTypePrefix + "member."
+	MemberAddedEventType   = memberEventTypePrefix + "added"
+	MemberRemovedEventType = memberEventTypePrefix + "removed"
+)
+
+type MemberAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserID string `json:"userId"`
+}
+
+func (e *MemberAddedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *MemberAddedEvent) Payload() any {
+	return e
+}
+
+func (e *MemberAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewMemberAddedEvent(ctx context.Context, aggregate *eventstore.Aggregate, userID string) *MemberAddedEvent {
+	return &MemberAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(ctx, aggregate, MemberAddedEventType),
+		UserID:    userID,
+	}
+}
+
+type MemberRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserID string `json:"userId"`
+}
+
+func (e *MemberRemovedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *MemberRemovedEvent) Payload() any {
+	return e
+}
+
+func (e *MemberRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewMemberRemovedEvent(ctx context.Context, aggregate *eventstore.Aggregate, userID string) *MemberRemovedEvent {
+	return &MemberRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(ctx, aggregate, MemberRemovedEventType),
+		UserID:    userID,
+	}
+}
@@ -0,0 +1,151 @@
+package idp
+
+import (
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type ADFSIDPAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID           string              `json:"id"`
+	Name         string              `json:"name"`
+	Issuer       string              `json:"issuer"`
+	ClientID     string              `json:"client_id"`
+	ClientSecret *crypto.CryptoValue `json:"client_secret"`
+	Scopes       []string            `json:"scopes,omitempty"`
+	Options
+}
+
+func NewADFSIDPAddedEvent(
+	base *eventstore.BaseEvent,
+	id,
+	name,
+	issuer,
+	clientID string,
+	clientSecret *crypto.CryptoValue,
+	scopes []string,
+	options Options,
+) *ADFSIDPAddedEvent {
+	return &ADFSIDPAddedEvent{
+		BaseEvent:    *base,
+		ID:           id,
+		Name:         name,
+		Issuer:       issuer,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scopes:       scopes,
+		Options:      options,
+	}
+}
+
+func (e *ADFSIDPAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *ADFSIDPAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func ADFSIDPAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &ADFSIDPAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-DEd1B", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
+type ADFSIDPChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID           string              `json:"id"`
+	Name         *string             `json:"name,omitempty"`
+	Issuer       *string             `json:"issuer,omitempty"`
+	ClientID     *string             `json:"client_id,omitempty"`
+	ClientSecret *crypto.CryptoValue `json:"client_secret,omitempty"`
+	Scopes       []string            `json:"scopes,omitempty"`
+	OptionChanges
+}
+
+func NewADFSIDPChangedEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	changes []ADFSIDPChanges,
+) (*ADFSIDPChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "IDP-QX8G0", "Errors.NoChangesFound")
+	}
+	changedEvent := &ADFSIDPChangedEvent{
+		BaseEvent: *base,
+		ID:        id,
+	}
+	for _, change := range changes {
+		change(changedEvent)
+	}
+	return changedEvent, nil
+}
+
+type ADFSIDPChanges func(*ADFSIDPChangedEvent)
+
+func ChangeADFSName(name string) func(*ADFSIDPChangedEvent) {
+	return func(e *ADFSIDPChangedEvent) {
+		e.Name = &name
+	}
+}
+
+func ChangeADFSIssuer(issuer string) func(*ADFSIDPChangedEvent) {
+	return func(e *ADFSIDPChangedEvent) {
+		e.Issuer = &issuer
+	}
+}
+
+func ChangeADFSClientID(clientID string) func(*ADFSIDPChangedEvent) {
+	return func(e *ADFSIDPChangedEvent) {
+		e.ClientID = &clientID
+	}
+}
+
+func ChangeADFSClientSecret(clientSecret *crypto.CryptoValue) func(*ADFSIDPChangedEvent) {
+	return func(e *ADFSIDPChangedEvent) {
+		e.ClientSecret = clientSecret
+	}
+}
+
+func ChangeADFSScopes(scopes []string) func(*ADFSIDPChangedEvent) {
+	return func(e *ADFSIDPChangedEvent) {
+		e.Scopes = scopes
+	}
+}
+
+func ChangeADFSOptions(options OptionChanges) func(*ADFSIDPChangedEvent) {
+	return func(e *ADFSIDPChangedEvent) {
+		e.OptionChanges = options
+	}
+}
+
+func (e *ADFSIDPChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *ADFSIDPChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func ADFSIDPChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &ADFSIDPChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-Tlau2", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
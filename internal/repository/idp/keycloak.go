@@ -0,0 +1,151 @@
+package idp
+
+import (
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type KeycloakIDPAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID           string              `json:"id"`
+	Name         string              `json:"name"`
+	Issuer       string              `json:"issuer"`
+	ClientID     string              `json:"client_id"`
+	ClientSecret *crypto.CryptoValue `json:"client_secret"`
+	Scopes       []string            `json:"scopes,omitempty"`
+	Options
+}
+
+func NewKeycloakIDPAddedEvent(
+	base *eventstore.BaseEvent,
+	id,
+	name,
+	issuer,
+	clientID string,
+	clientSecret *crypto.CryptoValue,
+	scopes []string,
+	options Options,
+) *KeycloakIDPAddedEvent {
+	return &KeycloakIDPAddedEvent{
+		BaseEvent:    *base,
+		ID:           id,
+		Name:         name,
+		Issuer:       issuer,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scopes:       scopes,
+		Options:      options,
+	}
+}
+
+func (e *KeycloakIDPAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *KeycloakIDPAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func KeycloakIDPAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &KeycloakIDPAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-dmltD", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
+type KeycloakIDPChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID           string              `json:"id"`
+	Name         *string             `json:"name,omitempty"`
+	Issuer       *string             `json:"issuer,omitempty"`
+	ClientID     *string             `json:"client_id,omitempty"`
+	ClientSecret *crypto.CryptoValue `json:"client_secret,omitempty"`
+	Scopes       []string            `json:"scopes,omitempty"`
+	OptionChanges
+}
+
+func NewKeycloakIDPChangedEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	changes []KeycloakIDPChanges,
+) (*KeycloakIDPChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "IDP-GxMfn", "Errors.NoChangesFound")
+	}
+	changedEvent := &KeycloakIDPChangedEvent{
+		BaseEvent: *base,
+		ID:        id,
+	}
+	for _, change := range changes {
+		change(changedEvent)
+	}
+	return changedEvent, nil
+}
+
+type KeycloakIDPChanges func(*KeycloakIDPChangedEvent)
+
+func ChangeKeycloakName(name string) func(*KeycloakIDPChangedEvent) {
+	return func(e *KeycloakIDPChangedEvent) {
+		e.Name = &name
+	}
+}
+
+func ChangeKeycloakIssuer(issuer string) func(*KeycloakIDPChangedEvent) {
+	return func(e *KeycloakIDPChangedEvent) {
+		e.Issuer = &issuer
+	}
+}
+
+func ChangeKeycloakClientID(clientID string) func(*KeycloakIDPChangedEvent) {
+	return func(e *KeycloakIDPChangedEvent) {
+		e.ClientID = &clientID
+	}
+}
+
+func ChangeKeycloakClientSecret(clientSecret *crypto.CryptoValue) func(*KeycloakIDPChangedEvent) {
+	return func(e *KeycloakIDPChangedEvent) {
+		e.ClientSecret = clientSecret
+	}
+}
+
+func ChangeKeycloakScopes(scopes []string) func(*KeycloakIDPChangedEvent) {
+	return func(e *KeycloakIDPChangedEvent) {
+		e.Scopes = scopes
+	}
+}
+
+func ChangeKeycloakOptions(options OptionChanges) func(*KeycloakIDPChangedEvent) {
+	return func(e *KeycloakIDPChangedEvent) {
+		e.OptionChanges = options
+	}
+}
+
+func (e *KeycloakIDPChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *KeycloakIDPChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func KeycloakIDPChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &KeycloakIDPChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-HvgKK", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
@@ -3,6 +3,7 @@ package idp
 import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/idp/mapping"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
@@ -19,6 +20,7 @@ type OAuthIDPAddedEvent struct {
 	Scopes                []string            `json:"scopes,omitempty"`
 	IDAttribute           string              `json:"idAttribute,omitempty"`
 	UsePKCE               bool                `json:"usePKCE,omitempty"`
+	ClaimMapping          *mapping.Mapping    `json:"claimMapping,omitempty"`
 	Options
 }
 
@@ -34,6 +36,7 @@ func NewOAuthIDPAddedEvent(
 	idAttribute string,
 	scopes []string,
 	usePKCE bool,
+	claimMapping *mapping.Mapping,
 	options Options,
 ) *OAuthIDPAddedEvent {
 	return &OAuthIDPAddedEvent{
@@ -48,6 +51,7 @@ func NewOAuthIDPAddedEvent(
 		Scopes:                scopes,
 		IDAttribute:           idAttribute,
 		UsePKCE:               usePKCE,
+		ClaimMapping:          claimMapping,
 		Options:               options,
 	}
 }
@@ -86,6 +90,7 @@ type OAuthIDPChangedEvent struct {
 	Scopes                []string            `json:"scopes,omitempty"`
 	IDAttribute           *string             `json:"idAttribute,omitempty"`
 	UsePKCE               *bool               `json:"usePKCE,omitempty"`
+	ClaimMapping          *mapping.Mapping    `json:"claimMapping,omitempty"`
 	OptionChanges
 }
 
@@ -168,6 +173,12 @@ func ChangeOAuthUsePKCE(usePKCE bool) func(*OAuthIDPChangedEvent) {
 	}
 }
 
+func ChangeOAuthClaimMapping(claimMapping *mapping.Mapping) func(*OAuthIDPChangedEvent) {
+	return func(e *OAuthIDPChangedEvent) {
+		e.ClaimMapping = claimMapping
+	}
+}
+
 func (e *OAuthIDPChangedEvent) Payload() interface{} {
 	return e
 }
@@ -3,6 +3,7 @@ package idp
 import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/idp/mapping"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
@@ -17,6 +18,7 @@ type OIDCIDPAddedEvent struct {
 	Scopes           []string            `json:"scopes,omitempty"`
 	IsIDTokenMapping bool                `json:"idTokenMapping,omitempty"`
 	UsePKCE          bool                `json:"usePKCE,omitempty"`
+	ClaimMapping     *mapping.Mapping    `json:"claimMapping,omitempty"`
 	Options
 }
 
@@ -29,6 +31,7 @@ func NewOIDCIDPAddedEvent(
 	clientSecret *crypto.CryptoValue,
 	scopes []string,
 	isIDTokenMapping, usePKCE bool,
+	claimMapping *mapping.Mapping,
 	options Options,
 ) *OIDCIDPAddedEvent {
 	return &OIDCIDPAddedEvent{
@@ -41,6 +44,7 @@ func NewOIDCIDPAddedEvent(
 		Scopes:           scopes,
 		IsIDTokenMapping: isIDTokenMapping,
 		UsePKCE:          usePKCE,
+		ClaimMapping:     claimMapping,
 		Options:          options,
 	}
 }
@@ -77,6 +81,7 @@ type OIDCIDPChangedEvent struct {
 	Scopes           []string            `json:"scopes,omitempty"`
 	IsIDTokenMapping *bool               `json:"idTokenMapping,omitempty"`
 	UsePKCE          *bool               `json:"usePKCE,omitempty"`
+	ClaimMapping     *mapping.Mapping    `json:"claimMapping,omitempty"`
 	OptionChanges
 }
 
@@ -148,6 +153,12 @@ func ChangeOIDCUsePKCE(usePKCE bool) func(*OIDCIDPChangedEvent) {
 	}
 }
 
+func ChangeOIDCClaimMapping(claimMapping *mapping.Mapping) func(*OIDCIDPChangedEvent) {
+	return func(e *OIDCIDPChangedEvent) {
+		e.ClaimMapping = claimMapping
+	}
+}
+
 func (e *OIDCIDPChangedEvent) Payload() interface{} {
 	return e
 }
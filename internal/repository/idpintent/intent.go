@@ -79,9 +79,10 @@ type SucceededEvent struct {
 	IDPUserName string `json:"idpUserName,omitempty"`
 	UserID      string `json:"userId,omitempty"`
 
-	IDPAccessToken *crypto.CryptoValue `json:"idpAccessToken,omitempty"`
-	IDPIDToken     string              `json:"idpIdToken,omitempty"`
-	ExpiresAt      time.Time           `json:"expiresAt,omitempty"`
+	IDPAccessToken  *crypto.CryptoValue `json:"idpAccessToken,omitempty"`
+	IDPRefreshToken *crypto.CryptoValue `json:"idpRefreshToken,omitempty"`
+	IDPIDToken      string              `json:"idpIdToken,omitempty"`
+	ExpiresAt       time.Time           `json:"expiresAt,omitempty"`
 }
 
 func NewSucceededEvent(
@@ -91,7 +92,8 @@ func NewSucceededEvent(
 	idpUserID,
 	idpUserName,
 	userID string,
-	idpAccessToken *crypto.CryptoValue,
+	idpAccessToken,
+	idpRefreshToken *crypto.CryptoValue,
 	idpIDToken string,
 	expiresAt time.Time,
 ) *SucceededEvent {
@@ -101,13 +103,14 @@ func NewSucceededEvent(
 			aggregate,
 			SucceededEventType,
 		),
-		IDPUser:        idpUser,
-		IDPUserID:      idpUserID,
-		IDPUserName:    idpUserName,
-		UserID:         userID,
-		IDPAccessToken: idpAccessToken,
-		IDPIDToken:     idpIDToken,
-		ExpiresAt:      expiresAt,
+		IDPUser:         idpUser,
+		IDPUserID:       idpUserID,
+		IDPUserName:     idpUserName,
+		UserID:          userID,
+		IDPAccessToken:  idpAccessToken,
+		IDPRefreshToken: idpRefreshToken,
+		IDPIDToken:      idpIDToken,
+		ExpiresAt:       expiresAt,
 	}
 }
 
@@ -19,6 +19,8 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, SMTPConfigPasswordChangedEventType, eventstore.GenericEventMapper[SMTPConfigPasswordChangedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, SMTPConfigHTTPAddedEventType, eventstore.GenericEventMapper[SMTPConfigHTTPAddedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, SMTPConfigHTTPChangedEventType, eventstore.GenericEventMapper[SMTPConfigHTTPChangedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, SMTPConfigAPIAddedEventType, eventstore.GenericEventMapper[SMTPConfigAPIAddedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, SMTPConfigAPIChangedEventType, eventstore.GenericEventMapper[SMTPConfigAPIChangedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, SMTPConfigRemovedEventType, eventstore.GenericEventMapper[SMTPConfigRemovedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, SMSConfigTwilioAddedEventType, eventstore.GenericEventMapper[SMSConfigTwilioAddedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, SMSConfigTwilioChangedEventType, eventstore.GenericEventMapper[SMSConfigTwilioChangedEvent])
@@ -105,6 +107,16 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, AppleIDPChangedEventType, AppleIDPChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SAMLIDPAddedEventType, SAMLIDPAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SAMLIDPChangedEventType, SAMLIDPChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, OktaIDPAddedEventType, OktaIDPAddedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, OktaIDPChangedEventType, OktaIDPChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, KeycloakIDPAddedEventType, KeycloakIDPAddedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, KeycloakIDPChangedEventType, KeycloakIDPChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, ADFSIDPAddedEventType, ADFSIDPAddedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, ADFSIDPChangedEventType, ADFSIDPChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, BitbucketIDPAddedEventType, BitbucketIDPAddedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, BitbucketIDPChangedEventType, BitbucketIDPChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, DiscordIDPAddedEventType, DiscordIDPAddedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, DiscordIDPChangedEventType, DiscordIDPChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, IDPRemovedEventType, IDPRemovedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, LoginPolicyIDPProviderAddedEventType, IdentityProviderAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, LoginPolicyIDPProviderRemovedEventType, IdentityProviderRemovedEventMapper)
@@ -131,4 +143,7 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, TrustedDomainAddedEventType, eventstore.GenericEventMapper[TrustedDomainAddedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, TrustedDomainRemovedEventType, eventstore.GenericEventMapper[TrustedDomainRemovedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, HostedLoginTranslationSet, HostedLoginTranslationSetEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, LDAPSyncSetEventType, eventstore.GenericEventMapper[LDAPSyncSetEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, LDAPSyncRemovedEventType, eventstore.GenericEventMapper[LDAPSyncRemovedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, LDAPSyncReportedEventType, eventstore.GenericEventMapper[LDAPSyncReportedEvent])
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/idp/mapping"
 	"github.com/zitadel/zitadel/internal/repository/idp"
 )
 
@@ -37,6 +38,16 @@ const (
 	AppleIDPChangedEventType            eventstore.EventType = "instance.idp.apple.changed"
 	SAMLIDPAddedEventType               eventstore.EventType = "instance.idp.saml.added"
 	SAMLIDPChangedEventType             eventstore.EventType = "instance.idp.saml.changed"
+	OktaIDPAddedEventType               eventstore.EventType = "instance.idp.okta.added"
+	OktaIDPChangedEventType             eventstore.EventType = "instance.idp.okta.changed"
+	KeycloakIDPAddedEventType           eventstore.EventType = "instance.idp.keycloak.added"
+	KeycloakIDPChangedEventType         eventstore.EventType = "instance.idp.keycloak.changed"
+	ADFSIDPAddedEventType               eventstore.EventType = "instance.idp.adfs.added"
+	ADFSIDPChangedEventType             eventstore.EventType = "instance.idp.adfs.changed"
+	BitbucketIDPAddedEventType          eventstore.EventType = "instance.idp.bitbucket.added"
+	BitbucketIDPChangedEventType        eventstore.EventType = "instance.idp.bitbucket.changed"
+	DiscordIDPAddedEventType            eventstore.EventType = "instance.idp.discord.added"
+	DiscordIDPChangedEventType          eventstore.EventType = "instance.idp.discord.changed"
 	IDPRemovedEventType                 eventstore.EventType = "instance.idp.removed"
 )
 
@@ -57,6 +68,7 @@ func NewOAuthIDPAddedEvent(
 	idAttribute string,
 	scopes []string,
 	usePKCE bool,
+	claimMapping *mapping.Mapping,
 	options idp.Options,
 ) *OAuthIDPAddedEvent {
 
@@ -77,6 +89,7 @@ func NewOAuthIDPAddedEvent(
 			idAttribute,
 			scopes,
 			usePKCE,
+			claimMapping,
 			options,
 		),
 	}
@@ -140,6 +153,7 @@ func NewOIDCIDPAddedEvent(
 	clientSecret *crypto.CryptoValue,
 	scopes []string,
 	isIDTokenMapping, usePKCE bool,
+	claimMapping *mapping.Mapping,
 	options idp.Options,
 ) *OIDCIDPAddedEvent {
 
@@ -158,6 +172,7 @@ func NewOIDCIDPAddedEvent(
 			scopes,
 			isIDTokenMapping,
 			usePKCE,
+			claimMapping,
 			options,
 		),
 	}
@@ -1093,6 +1108,392 @@ func SAMLIDPChangedEventMapper(event eventstore.Event) (eventstore.Event, error)
 	return &SAMLIDPChangedEvent{SAMLIDPChangedEvent: *e.(*idp.SAMLIDPChangedEvent)}, nil
 }
 
+type OktaIDPAddedEvent struct {
+	idp.OktaIDPAddedEvent
+}
+
+func NewOktaIDPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	issuer,
+	clientID string,
+	clientSecret *crypto.CryptoValue,
+	scopes []string,
+	options idp.Options,
+) *OktaIDPAddedEvent {
+
+	return &OktaIDPAddedEvent{
+		OktaIDPAddedEvent: *idp.NewOktaIDPAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				OktaIDPAddedEventType,
+			),
+			id,
+			name,
+			issuer,
+			clientID,
+			clientSecret,
+			scopes,
+			options,
+		),
+	}
+}
+
+func OktaIDPAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.OktaIDPAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OktaIDPAddedEvent{OktaIDPAddedEvent: *e.(*idp.OktaIDPAddedEvent)}, nil
+}
+
+type OktaIDPChangedEvent struct {
+	idp.OktaIDPChangedEvent
+}
+
+func NewOktaIDPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []idp.OktaIDPChanges,
+) (*OktaIDPChangedEvent, error) {
+
+	changedEvent, err := idp.NewOktaIDPChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			OktaIDPChangedEventType,
+		),
+		id,
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &OktaIDPChangedEvent{OktaIDPChangedEvent: *changedEvent}, nil
+}
+
+func OktaIDPChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.OktaIDPChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OktaIDPChangedEvent{OktaIDPChangedEvent: *e.(*idp.OktaIDPChangedEvent)}, nil
+}
+
+type KeycloakIDPAddedEvent struct {
+	idp.KeycloakIDPAddedEvent
+}
+
+func NewKeycloakIDPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	issuer,
+	clientID string,
+	clientSecret *crypto.CryptoValue,
+	scopes []string,
+	options idp.Options,
+) *KeycloakIDPAddedEvent {
+
+	return &KeycloakIDPAddedEvent{
+		KeycloakIDPAddedEvent: *idp.NewKeycloakIDPAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				KeycloakIDPAddedEventType,
+			),
+			id,
+			name,
+			issuer,
+			clientID,
+			clientSecret,
+			scopes,
+			options,
+		),
+	}
+}
+
+func KeycloakIDPAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.KeycloakIDPAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeycloakIDPAddedEvent{KeycloakIDPAddedEvent: *e.(*idp.KeycloakIDPAddedEvent)}, nil
+}
+
+type KeycloakIDPChangedEvent struct {
+	idp.KeycloakIDPChangedEvent
+}
+
+func NewKeycloakIDPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []idp.KeycloakIDPChanges,
+) (*KeycloakIDPChangedEvent, error) {
+
+	changedEvent, err := idp.NewKeycloakIDPChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			KeycloakIDPChangedEventType,
+		),
+		id,
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &KeycloakIDPChangedEvent{KeycloakIDPChangedEvent: *changedEvent}, nil
+}
+
+func KeycloakIDPChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.KeycloakIDPChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeycloakIDPChangedEvent{KeycloakIDPChangedEvent: *e.(*idp.KeycloakIDPChangedEvent)}, nil
+}
+
+type ADFSIDPAddedEvent struct {
+	idp.ADFSIDPAddedEvent
+}
+
+func NewADFSIDPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	issuer,
+	clientID string,
+	clientSecret *crypto.CryptoValue,
+	scopes []string,
+	options idp.Options,
+) *ADFSIDPAddedEvent {
+
+	return &ADFSIDPAddedEvent{
+		ADFSIDPAddedEvent: *idp.NewADFSIDPAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				ADFSIDPAddedEventType,
+			),
+			id,
+			name,
+			issuer,
+			clientID,
+			clientSecret,
+			scopes,
+			options,
+		),
+	}
+}
+
+func ADFSIDPAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.ADFSIDPAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ADFSIDPAddedEvent{ADFSIDPAddedEvent: *e.(*idp.ADFSIDPAddedEvent)}, nil
+}
+
+type ADFSIDPChangedEvent struct {
+	idp.ADFSIDPChangedEvent
+}
+
+func NewADFSIDPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []idp.ADFSIDPChanges,
+) (*ADFSIDPChangedEvent, error) {
+
+	changedEvent, err := idp.NewADFSIDPChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ADFSIDPChangedEventType,
+		),
+		id,
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &ADFSIDPChangedEvent{ADFSIDPChangedEvent: *changedEvent}, nil
+}
+
+func ADFSIDPChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.ADFSIDPChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ADFSIDPChangedEvent{ADFSIDPChangedEvent: *e.(*idp.ADFSIDPChangedEvent)}, nil
+}
+
+type BitbucketIDPAddedEvent struct {
+	idp.BitbucketIDPAddedEvent
+}
+
+func NewBitbucketIDPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	clientID string,
+	clientSecret *crypto.CryptoValue,
+	scopes []string,
+	options idp.Options,
+) *BitbucketIDPAddedEvent {
+
+	return &BitbucketIDPAddedEvent{
+		BitbucketIDPAddedEvent: *idp.NewBitbucketIDPAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				BitbucketIDPAddedEventType,
+			),
+			id,
+			name,
+			clientID,
+			clientSecret,
+			scopes,
+			options,
+		),
+	}
+}
+
+func BitbucketIDPAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.BitbucketIDPAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BitbucketIDPAddedEvent{BitbucketIDPAddedEvent: *e.(*idp.BitbucketIDPAddedEvent)}, nil
+}
+
+type BitbucketIDPChangedEvent struct {
+	idp.BitbucketIDPChangedEvent
+}
+
+func NewBitbucketIDPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []idp.BitbucketIDPChanges,
+) (*BitbucketIDPChangedEvent, error) {
+
+	changedEvent, err := idp.NewBitbucketIDPChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			BitbucketIDPChangedEventType,
+		),
+		id,
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &BitbucketIDPChangedEvent{BitbucketIDPChangedEvent: *changedEvent}, nil
+}
+
+func BitbucketIDPChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.BitbucketIDPChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BitbucketIDPChangedEvent{BitbucketIDPChangedEvent: *e.(*idp.BitbucketIDPChangedEvent)}, nil
+}
+
+type DiscordIDPAddedEvent struct {
+	idp.DiscordIDPAddedEvent
+}
+
+func NewDiscordIDPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	clientID string,
+	clientSecret *crypto.CryptoValue,
+	scopes []string,
+	options idp.Options,
+) *DiscordIDPAddedEvent {
+
+	return &DiscordIDPAddedEvent{
+		DiscordIDPAddedEvent: *idp.NewDiscordIDPAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				DiscordIDPAddedEventType,
+			),
+			id,
+			name,
+			clientID,
+			clientSecret,
+			scopes,
+			options,
+		),
+	}
+}
+
+func DiscordIDPAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.DiscordIDPAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiscordIDPAddedEvent{DiscordIDPAddedEvent: *e.(*idp.DiscordIDPAddedEvent)}, nil
+}
+
+type DiscordIDPChangedEvent struct {
+	idp.DiscordIDPChangedEvent
+}
+
+func NewDiscordIDPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []idp.DiscordIDPChanges,
+) (*DiscordIDPChangedEvent, error) {
+
+	changedEvent, err := idp.NewDiscordIDPChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			DiscordIDPChangedEventType,
+		),
+		id,
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &DiscordIDPChangedEvent{DiscordIDPChangedEvent: *changedEvent}, nil
+}
+
+func DiscordIDPChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.DiscordIDPChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiscordIDPChangedEvent{DiscordIDPChangedEvent: *e.(*idp.DiscordIDPChangedEvent)}, nil
+}
+
 type IDPRemovedEvent struct {
 	idp.RemovedEvent
 }
@@ -0,0 +1,186 @@
+package instance
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	ldapSyncPrefix               = "idp.ldap.sync."
+	LDAPSyncSetEventType         = instanceEventTypePrefix + ldapSyncPrefix + "set"
+	LDAPSyncRemovedEventType     = instanceEventTypePrefix + ldapSyncPrefix + "removed"
+	LDAPSyncReportedEventType    = instanceEventTypePrefix + ldapSyncPrefix + "reported"
+	ldapSyncReportMaxErrorsCount = 100
+
+	LDAPSyncQueueName = "ldap_sync"
+)
+
+// LDAPSyncGroupGrant grants the roles of the project to all members of the group.
+type LDAPSyncGroupGrant struct {
+	Group     string `json:"group"`
+	ProjectID string `json:"projectId"`
+	// ProjectResourceOwner is the organization of the project, the grants are created in
+	ProjectResourceOwner string   `json:"projectResourceOwner"`
+	RoleKeys             []string `json:"roleKeys,omitempty"`
+}
+
+type LDAPSyncSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	// ID is the id of the LDAP identity provider
+	ID                string                `json:"id"`
+	Interval          time.Duration         `json:"interval"`
+	Filter            string                `json:"filter,omitempty"`
+	PageSize          uint32                `json:"pageSize,omitempty"`
+	OrganizationID    string                `json:"organizationId"`
+	DeactivateMissing bool                  `json:"deactivateMissing,omitempty"`
+	GroupAttribute    string                `json:"groupAttribute,omitempty"`
+	GroupGrants       []*LDAPSyncGroupGrant `json:"groupGrants,omitempty"`
+}
+
+func (e *LDAPSyncSetEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewLDAPSyncSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	interval time.Duration,
+	filter string,
+	pageSize uint32,
+	organizationID string,
+	deactivateMissing bool,
+	groupAttribute string,
+	groupGrants []*LDAPSyncGroupGrant,
+) *LDAPSyncSetEvent {
+	return &LDAPSyncSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			LDAPSyncSetEventType,
+		),
+		ID:                id,
+		Interval:          interval,
+		Filter:            filter,
+		PageSize:          pageSize,
+		OrganizationID:    organizationID,
+		DeactivateMissing: deactivateMissing,
+		GroupAttribute:    groupAttribute,
+		GroupGrants:       groupGrants,
+	}
+}
+
+func (e *LDAPSyncSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *LDAPSyncSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+type LDAPSyncRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	// ID is the id of the LDAP identity provider
+	ID string `json:"id"`
+}
+
+func (e *LDAPSyncRemovedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewLDAPSyncRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+) *LDAPSyncRemovedEvent {
+	return &LDAPSyncRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			LDAPSyncRemovedEventType,
+		),
+		ID: id,
+	}
+}
+
+func (e *LDAPSyncRemovedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *LDAPSyncRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+// LDAPSyncReport is the result of a single sync run.
+type LDAPSyncReport struct {
+	StartedAt     time.Time `json:"startedAt"`
+	EndedAt       time.Time `json:"endedAt"`
+	Created       uint32    `json:"created,omitempty"`
+	Updated       uint32    `json:"updated,omitempty"`
+	Deactivated   uint32    `json:"deactivated,omitempty"`
+	Reactivated   uint32    `json:"reactivated,omitempty"`
+	Unchanged     uint32    `json:"unchanged,omitempty"`
+	GrantsAdded   uint32    `json:"grantsAdded,omitempty"`
+	GrantsChanged uint32    `json:"grantsChanged,omitempty"`
+	GrantsRemoved uint32    `json:"grantsRemoved,omitempty"`
+	Failed        uint32    `json:"failed,omitempty"`
+	// Errors contains the messages of the failures, it's truncated to the first 100 messages
+	Errors []string `json:"errors,omitempty"`
+}
+
+type LDAPSyncReportedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	// ID is the id of the LDAP identity provider
+	ID string `json:"id"`
+	LDAPSyncReport
+}
+
+func (e *LDAPSyncReportedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewLDAPSyncReportedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	report LDAPSyncReport,
+) *LDAPSyncReportedEvent {
+	if len(report.Errors) > ldapSyncReportMaxErrorsCount {
+		report.Errors = report.Errors[:ldapSyncReportMaxErrorsCount]
+	}
+	return &LDAPSyncReportedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			LDAPSyncReportedEventType,
+		),
+		ID:             id,
+		LDAPSyncReport: report,
+	}
+}
+
+func (e *LDAPSyncReportedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *LDAPSyncReportedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+// LDAPSyncRequest are the arguments of the job which synchronizes the users of an LDAP identity provider periodically.
+type LDAPSyncRequest struct {
+	InstanceID string `json:"instanceID"`
+	IDPID      string `json:"idpID"`
+	// Sequence is the sequence of the event which set the configuration,
+	// the job stops as soon as the configuration is changed or removed.
+	Sequence uint64 `json:"sequence"`
+}
+
+func (r *LDAPSyncRequest) Kind() string {
+	return "ldap_sync_request"
+}
@@ -21,6 +21,11 @@ type SecurityPolicySetEvent struct {
 	EnableIframeEmbedding *bool     `json:"enable_iframe_embedding,omitempty"`
 	AllowedOrigins        *[]string `json:"allowedOrigins,omitempty"`
 	EnableImpersonation   *bool     `json:"enable_impersonation,omitempty"`
+
+	WebAuthNAttestationRequired *bool     `json:"webauthn_attestation_required,omitempty"`
+	WebAuthNAllowedAAGUIDs      *[]string `json:"webauthn_allowed_aaguids,omitempty"`
+	WebAuthNDeniedAAGUIDs       *[]string `json:"webauthn_denied_aaguids,omitempty"`
+	WebAuthNCertificationLevels *[]string `json:"webauthn_certification_levels,omitempty"`
 }
 
 func NewSecurityPolicySetEvent(
@@ -67,6 +72,39 @@ func ChangeSecurityPolicyEnableImpersonation(enabled bool) func(event *SecurityP
 	}
 }
 
+func ChangeSecurityPolicyWebAuthNAttestationRequired(required bool) func(event *SecurityPolicySetEvent) {
+	return func(e *SecurityPolicySetEvent) {
+		e.WebAuthNAttestationRequired = &required
+	}
+}
+
+func ChangeSecurityPolicyWebAuthNAllowedAAGUIDs(aaguids []string) func(event *SecurityPolicySetEvent) {
+	return func(e *SecurityPolicySetEvent) {
+		if len(aaguids) == 0 {
+			aaguids = []string{}
+		}
+		e.WebAuthNAllowedAAGUIDs = &aaguids
+	}
+}
+
+func ChangeSecurityPolicyWebAuthNDeniedAAGUIDs(aaguids []string) func(event *SecurityPolicySetEvent) {
+	return func(e *SecurityPolicySetEvent) {
+		if len(aaguids) == 0 {
+			aaguids = []string{}
+		}
+		e.WebAuthNDeniedAAGUIDs = &aaguids
+	}
+}
+
+func ChangeSecurityPolicyWebAuthNCertificationLevels(levels []string) func(event *SecurityPolicySetEvent) {
+	return func(e *SecurityPolicySetEvent) {
+		if len(levels) == 0 {
+			levels = []string{}
+		}
+		e.WebAuthNCertificationLevels = &levels
+	}
+}
+
 func (e *SecurityPolicySetEvent) Payload() interface{} {
 	return e
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -11,11 +12,14 @@ import (
 const (
 	smtpConfigPrefix                   = "smtp.config."
 	httpConfigPrefix                   = "http."
+	apiConfigPrefix                    = "api."
 	SMTPConfigAddedEventType           = instanceEventTypePrefix + smtpConfigPrefix + "added"
 	SMTPConfigChangedEventType         = instanceEventTypePrefix + smtpConfigPrefix + "changed"
 	SMTPConfigPasswordChangedEventType = instanceEventTypePrefix + smtpConfigPrefix + "password.changed"
 	SMTPConfigHTTPAddedEventType       = instanceEventTypePrefix + smtpConfigPrefix + httpConfigPrefix + "added"
 	SMTPConfigHTTPChangedEventType     = instanceEventTypePrefix + smtpConfigPrefix + httpConfigPrefix + "changed"
+	SMTPConfigAPIAddedEventType        = instanceEventTypePrefix + smtpConfigPrefix + apiConfigPrefix + "added"
+	SMTPConfigAPIChangedEventType      = instanceEventTypePrefix + smtpConfigPrefix + apiConfigPrefix + "changed"
 	SMTPConfigRemovedEventType         = instanceEventTypePrefix + smtpConfigPrefix + "removed"
 	SMTPConfigActivatedEventType       = instanceEventTypePrefix + smtpConfigPrefix + "activated"
 	SMTPConfigDeactivatedEventType     = instanceEventTypePrefix + smtpConfigPrefix + "deactivated"
@@ -314,6 +318,179 @@ func ChangeSMTPConfigHTTPEndpoint(endpoint string) func(event *SMTPConfigHTTPCha
 	}
 }
 
+type SMTPConfigAPIAddedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	ID             string                  `json:"id,omitempty"`
+	Description    string                  `json:"description,omitempty"`
+	Provider       domain.EmailAPIProvider `json:"provider,omitempty"`
+	SenderAddress  string                  `json:"senderAddress,omitempty"`
+	SenderName     string                  `json:"senderName,omitempty"`
+	ReplyToAddress string                  `json:"replyToAddress,omitempty"`
+	Endpoint       string                  `json:"endpoint,omitempty"`
+	Region         string                  `json:"region,omitempty"`
+	Domain         string                  `json:"domain,omitempty"`
+	KeyID          string                  `json:"keyId,omitempty"`
+	APIKey         *crypto.CryptoValue     `json:"apiKey,omitempty"`
+}
+
+func NewSMTPConfigAPIAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id, description string,
+	provider domain.EmailAPIProvider,
+	senderAddress,
+	senderName,
+	replyToAddress,
+	endpoint,
+	region,
+	sendingDomain,
+	keyID string,
+	apiKey *crypto.CryptoValue,
+) *SMTPConfigAPIAddedEvent {
+	return &SMTPConfigAPIAddedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMTPConfigAPIAddedEventType,
+		),
+		ID:             id,
+		Description:    description,
+		Provider:       provider,
+		SenderAddress:  senderAddress,
+		SenderName:     senderName,
+		ReplyToAddress: replyToAddress,
+		Endpoint:       endpoint,
+		Region:         region,
+		Domain:         sendingDomain,
+		KeyID:          keyID,
+		APIKey:         apiKey,
+	}
+}
+
+func (e *SMTPConfigAPIAddedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func (e *SMTPConfigAPIAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMTPConfigAPIAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+type SMTPConfigAPIChangedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+	ID                    string              `json:"id,omitempty"`
+	Description           *string             `json:"description,omitempty"`
+	SenderAddress         *string             `json:"senderAddress,omitempty"`
+	SenderName            *string             `json:"senderName,omitempty"`
+	ReplyToAddress        *string             `json:"replyToAddress,omitempty"`
+	Endpoint              *string             `json:"endpoint,omitempty"`
+	Region                *string             `json:"region,omitempty"`
+	Domain                *string             `json:"domain,omitempty"`
+	KeyID                 *string             `json:"keyId,omitempty"`
+	APIKey                *crypto.CryptoValue `json:"apiKey,omitempty"`
+}
+
+func (e *SMTPConfigAPIChangedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = event
+}
+
+func (e *SMTPConfigAPIChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SMTPConfigAPIChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewSMTPConfigAPIChangeEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []SMTPConfigAPIChanges,
+) (*SMTPConfigAPIChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "IAM-Mq4xE", "Errors.NoChangesFound")
+	}
+	changeEvent := &SMTPConfigAPIChangedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SMTPConfigAPIChangedEventType,
+		),
+		ID: id,
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type SMTPConfigAPIChanges func(event *SMTPConfigAPIChangedEvent)
+
+func ChangeSMTPConfigAPIID(id string) func(event *SMTPConfigAPIChangedEvent) {
+	return func(e *SMTPConfigAPIChangedEvent) {
+		e.ID = id
+	}
+}
+
+func ChangeSMTPConfigAPIDescription(description string) func(event *SMTPConfigAPIChangedEvent) {
+	return func(e *SMTPConfigAPIChangedEvent) {
+		e.Description = &description
+	}
+}
+
+func ChangeSMTPConfigAPISenderAddress(senderAddress string) func(event *SMTPConfigAPIChangedEvent) {
+	return func(e *SMTPConfigAPIChangedEvent) {
+		e.SenderAddress = &senderAddress
+	}
+}
+
+func ChangeSMTPConfigAPISenderName(senderName string) func(event *SMTPConfigAPIChangedEvent) {
+	return func(e *SMTPConfigAPIChangedEvent) {
+		e.SenderName = &senderName
+	}
+}
+
+func ChangeSMTPConfigAPIReplyToAddress(replyToAddress string) func(event *SMTPConfigAPIChangedEvent) {
+	return func(e *SMTPConfigAPIChangedEvent) {
+		e.ReplyToAddress = &replyToAddress
+	}
+}
+
+func ChangeSMTPConfigAPIEndpoint(endpoint string) func(event *SMTPConfigAPIChangedEvent) {
+	return func(e *SMTPConfigAPIChangedEvent) {
+		e.Endpoint = &endpoint
+	}
+}
+
+func ChangeSMTPConfigAPIRegion(region string) func(event *SMTPConfigAPIChangedEvent) {
+	return func(e *SMTPConfigAPIChangedEvent) {
+		e.Region = &region
+	}
+}
+
+func ChangeSMTPConfigAPIDomain(sendingDomain string) func(event *SMTPConfigAPIChangedEvent) {
+	return func(e *SMTPConfigAPIChangedEvent) {
+		e.Domain = &sendingDomain
+	}
+}
+
+func ChangeSMTPConfigAPIKeyID(keyID string) func(event *SMTPConfigAPIChangedEvent) {
+	return func(e *SMTPConfigAPIChangedEvent) {
+		e.KeyID = &keyID
+	}
+}
+
+func ChangeSMTPConfigAPIKey(apiKey *crypto.CryptoValue) func(event *SMTPConfigAPIChangedEvent) {
+	return func(e *SMTPConfigAPIChangedEvent) {
+		e.APIKey = apiKey
+	}
+}
+
 type SMTPConfigActivatedEvent struct {
 	*eventstore.BaseEvent `json:"-"`
 	ID                    string `json:"id,omitempty"`
@@ -34,6 +34,8 @@ type AddedEvent struct {
 	Nonce             string                      `json:"nonce,omitempty"`
 	PreferredLanguage *language.Tag               `json:"preferredLanguage,omitempty"`
 	UserAgent         *domain.UserAgent           `json:"userAgent,omitempty"`
+	// AuthorizationDetails are the authorization_details (RFC 9396) granted to the session.
+	AuthorizationDetails domain.AuthorizationDetails `json:"authorizationDetails,omitempty"`
 }
 
 func (e *AddedEvent) Payload() interface{} {
@@ -61,6 +63,7 @@ func NewAddedEvent(ctx context.Context,
 	nonce string,
 	preferredLanguage *language.Tag,
 	userAgent *domain.UserAgent,
+	authorizationDetails domain.AuthorizationDetails,
 ) *AddedEvent {
 	return &AddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -79,6 +82,8 @@ func NewAddedEvent(ctx context.Context,
 		Nonce:             nonce,
 		PreferredLanguage: preferredLanguage,
 		UserAgent:         userAgent,
+
+		AuthorizationDetails: authorizationDetails,
 	}
 }
 
@@ -90,6 +95,13 @@ type AccessTokenAddedEvent struct {
 	Lifetime time.Duration      `json:"lifetime,omitempty"`
 	Reason   domain.TokenReason `json:"reason,omitempty"`
 	Actor    *domain.TokenActor `json:"actor,omitempty"`
+	// DPoPJKT is the JWK thumbprint of the DPoP proof the token is bound to.
+	DPoPJKT string `json:"dpopJkt,omitempty"`
+	// CertThumbprint is the SHA-256 thumbprint of the client certificate the token is bound to (mutual TLS).
+	CertThumbprint string `json:"x5tS256,omitempty"`
+	// AuthorizationDetails are the authorization_details (RFC 9396) the token was issued for,
+	// which are the ones granted to the session or a subset of them.
+	AuthorizationDetails domain.AuthorizationDetails `json:"authorizationDetails,omitempty"`
 }
 
 func (e *AccessTokenAddedEvent) Payload() interface{} {
@@ -112,6 +124,9 @@ func NewAccessTokenAddedEvent(
 	lifetime time.Duration,
 	reason domain.TokenReason,
 	actor *domain.TokenActor,
+	dpopJKT string,
+	certThumbprint string,
+	authorizationDetails domain.AuthorizationDetails,
 ) *AccessTokenAddedEvent {
 	return &AccessTokenAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -124,6 +139,10 @@ func NewAccessTokenAddedEvent(
 		Lifetime: lifetime,
 		Reason:   reason,
 		Actor:    actor,
+		DPoPJKT:  dpopJKT,
+
+		CertThumbprint:       certThumbprint,
+		AuthorizationDetails: authorizationDetails,
 	}
 }
 
@@ -162,6 +181,8 @@ type RefreshTokenAddedEvent struct {
 	ID           string        `json:"id"`
 	Lifetime     time.Duration `json:"lifetime"`
 	IdleLifetime time.Duration `json:"idleLifetime"`
+	// DPoPJKT is the JWK thumbprint of the DPoP proof the token is bound to.
+	DPoPJKT string `json:"dpopJkt,omitempty"`
 }
 
 func (e *RefreshTokenAddedEvent) Payload() interface{} {
@@ -182,6 +203,7 @@ func NewRefreshTokenAddedEvent(
 	id string,
 	lifetime,
 	idleLifetime time.Duration,
+	dpopJKT string,
 ) *RefreshTokenAddedEvent {
 	return &RefreshTokenAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -192,6 +214,7 @@ func NewRefreshTokenAddedEvent(
 		ID:           id,
 		Lifetime:     lifetime,
 		IdleLifetime: idleLifetime,
+		DPoPJKT:      dpopJKT,
 	}
 }
 
@@ -104,6 +104,16 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, AppleIDPChangedEventType, AppleIDPChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SAMLIDPAddedEventType, SAMLIDPAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SAMLIDPChangedEventType, SAMLIDPChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, OktaIDPAddedEventType, OktaIDPAddedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, OktaIDPChangedEventType, OktaIDPChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, KeycloakIDPAddedEventType, KeycloakIDPAddedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, KeycloakIDPChangedEventType, KeycloakIDPChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, ADFSIDPAddedEventType, ADFSIDPAddedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, ADFSIDPChangedEventType, ADFSIDPChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, BitbucketIDPAddedEventType, BitbucketIDPAddedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, BitbucketIDPChangedEventType, BitbucketIDPChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, DiscordIDPAddedEventType, DiscordIDPAddedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, DiscordIDPChangedEventType, DiscordIDPChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, IDPRemovedEventType, IDPRemovedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, TriggerActionsSetEventType, TriggerActionsSetEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, TriggerActionsCascadeRemovedEventType, TriggerActionsCascadeRemovedEventMapper)
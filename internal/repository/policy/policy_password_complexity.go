@@ -14,11 +14,12 @@ const (
 type PasswordComplexityPolicyAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	MinLength    uint64 `json:"minLength,omitempty"`
-	HasLowercase bool   `json:"hasLowercase,omitempty"`
-	HasUppercase bool   `json:"hasUppercase,omitempty"`
-	HasNumber    bool   `json:"hasNumber,omitempty"`
-	HasSymbol    bool   `json:"hasSymbol,omitempty"`
+	MinLength     uint64 `json:"minLength,omitempty"`
+	HasLowercase  bool   `json:"hasLowercase,omitempty"`
+	HasUppercase  bool   `json:"hasUppercase,omitempty"`
+	HasNumber     bool   `json:"hasNumber,omitempty"`
+	HasSymbol     bool   `json:"hasSymbol,omitempty"`
+	CheckBreached bool   `json:"checkBreached,omitempty"`
 }
 
 func (e *PasswordComplexityPolicyAddedEvent) Payload() interface{} {
@@ -35,15 +36,17 @@ func NewPasswordComplexityPolicyAddedEvent(
 	hasLowerCase,
 	hasUpperCase,
 	hasNumber,
-	hasSymbol bool,
+	hasSymbol,
+	checkBreached bool,
 ) *PasswordComplexityPolicyAddedEvent {
 	return &PasswordComplexityPolicyAddedEvent{
-		BaseEvent:    *base,
-		MinLength:    minLength,
-		HasLowercase: hasLowerCase,
-		HasUppercase: hasUpperCase,
-		HasNumber:    hasNumber,
-		HasSymbol:    hasSymbol,
+		BaseEvent:     *base,
+		MinLength:     minLength,
+		HasLowercase:  hasLowerCase,
+		HasUppercase:  hasUpperCase,
+		HasNumber:     hasNumber,
+		HasSymbol:     hasSymbol,
+		CheckBreached: checkBreached,
 	}
 }
 
@@ -63,11 +66,12 @@ func PasswordComplexityPolicyAddedEventMapper(event eventstore.Event) (eventstor
 type PasswordComplexityPolicyChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	MinLength    *uint64 `json:"minLength,omitempty"`
-	HasLowercase *bool   `json:"hasLowercase,omitempty"`
-	HasUppercase *bool   `json:"hasUppercase,omitempty"`
-	HasNumber    *bool   `json:"hasNumber,omitempty"`
-	HasSymbol    *bool   `json:"hasSymbol,omitempty"`
+	MinLength     *uint64 `json:"minLength,omitempty"`
+	HasLowercase  *bool   `json:"hasLowercase,omitempty"`
+	HasUppercase  *bool   `json:"hasUppercase,omitempty"`
+	HasNumber     *bool   `json:"hasNumber,omitempty"`
+	HasSymbol     *bool   `json:"hasSymbol,omitempty"`
+	CheckBreached *bool   `json:"checkBreached,omitempty"`
 }
 
 func (e *PasswordComplexityPolicyChangedEvent) Payload() interface{} {
@@ -126,6 +130,12 @@ func ChangeHasSymbol(hasSymbol bool) func(*PasswordComplexityPolicyChangedEvent)
 	}
 }
 
+func ChangeCheckBreached(checkBreached bool) func(*PasswordComplexityPolicyChangedEvent) {
+	return func(e *PasswordComplexityPolicyChangedEvent) {
+		e.CheckBreached = &checkBreached
+	}
+}
+
 func PasswordComplexityPolicyChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	e := &PasswordComplexityPolicyChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -46,6 +46,14 @@ type OIDCConfigAddedEvent struct {
 	BackChannelLogoutURI     string                     `json:"backChannelLogoutURI,omitempty"`
 	LoginVersion             domain.LoginVersion        `json:"loginVersion,omitempty"`
 	LoginBaseURI             string                     `json:"loginBaseURI,omitempty"`
+
+	RequirePushedAuthorizationRequests bool `json:"requirePushedAuthorizationRequests,omitempty"`
+	RequireDPoP                        bool `json:"requireDPoP,omitempty"`
+
+	BackchannelTokenDeliveryMode          domain.OIDCBackchannelTokenDeliveryMode `json:"backchannelTokenDeliveryMode,omitempty"`
+	BackchannelClientNotificationEndpoint string                                  `json:"backchannelClientNotificationEndpoint,omitempty"`
+
+	TLSClientAuthSubjectDN string `json:"tlsClientAuthSubjectDn,omitempty"`
 }
 
 func (e *OIDCConfigAddedEvent) Payload() interface{} {
@@ -80,6 +88,11 @@ func NewOIDCConfigAddedEvent(
 	backChannelLogoutURI string,
 	loginVersion domain.LoginVersion,
 	loginBaseURI string,
+	requirePushedAuthorizationRequests bool,
+	requireDPoP bool,
+	backchannelTokenDeliveryMode domain.OIDCBackchannelTokenDeliveryMode,
+	backchannelClientNotificationEndpoint string,
+	tlsClientAuthSubjectDN string,
 ) *OIDCConfigAddedEvent {
 	return &OIDCConfigAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -108,6 +121,14 @@ func NewOIDCConfigAddedEvent(
 		BackChannelLogoutURI:     backChannelLogoutURI,
 		LoginVersion:             loginVersion,
 		LoginBaseURI:             loginBaseURI,
+
+		RequirePushedAuthorizationRequests: requirePushedAuthorizationRequests,
+		RequireDPoP:                        requireDPoP,
+
+		BackchannelTokenDeliveryMode:          backchannelTokenDeliveryMode,
+		BackchannelClientNotificationEndpoint: backchannelClientNotificationEndpoint,
+
+		TLSClientAuthSubjectDN: tlsClientAuthSubjectDN,
 	}
 }
 
@@ -202,7 +223,22 @@ func (e *OIDCConfigAddedEvent) Validate(cmd eventstore.Command) bool {
 	if e.LoginVersion != c.LoginVersion {
 		return false
 	}
-	return e.LoginBaseURI == c.LoginBaseURI
+	if e.LoginBaseURI != c.LoginBaseURI {
+		return false
+	}
+	if e.RequirePushedAuthorizationRequests != c.RequirePushedAuthorizationRequests {
+		return false
+	}
+	if e.RequireDPoP != c.RequireDPoP {
+		return false
+	}
+	if e.BackchannelTokenDeliveryMode != c.BackchannelTokenDeliveryMode {
+		return false
+	}
+	if e.BackchannelClientNotificationEndpoint != c.BackchannelClientNotificationEndpoint {
+		return false
+	}
+	return e.TLSClientAuthSubjectDN == c.TLSClientAuthSubjectDN
 }
 
 func OIDCConfigAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
@@ -240,6 +276,14 @@ type OIDCConfigChangedEvent struct {
 	BackChannelLogoutURI     *string                     `json:"backChannelLogoutURI,omitempty"`
 	LoginVersion             *domain.LoginVersion        `json:"loginVersion,omitempty"`
 	LoginBaseURI             *string                     `json:"loginBaseURI,omitempty"`
+
+	RequirePushedAuthorizationRequests *bool `json:"requirePushedAuthorizationRequests,omitempty"`
+	RequireDPoP                        *bool `json:"requireDPoP,omitempty"`
+
+	BackchannelTokenDeliveryMode          *domain.OIDCBackchannelTokenDeliveryMode `json:"backchannelTokenDeliveryMode,omitempty"`
+	BackchannelClientNotificationEndpoint *string                                  `json:"backchannelClientNotificationEndpoint,omitempty"`
+
+	TLSClientAuthSubjectDN *string `json:"tlsClientAuthSubjectDn,omitempty"`
 }
 
 func (e *OIDCConfigChangedEvent) Payload() interface{} {
@@ -396,6 +440,36 @@ func ChangeOIDCLoginBaseURI(loginBaseURI string) func(event *OIDCConfigChangedEv
 	}
 }
 
+func ChangeRequirePushedAuthorizationRequests(requirePushedAuthorizationRequests bool) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.RequirePushedAuthorizationRequests = &requirePushedAuthorizationRequests
+	}
+}
+
+func ChangeRequireDPoP(requireDPoP bool) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.RequireDPoP = &requireDPoP
+	}
+}
+
+func ChangeBackchannelTokenDeliveryMode(backchannelTokenDeliveryMode domain.OIDCBackchannelTokenDeliveryMode) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.BackchannelTokenDeliveryMode = &backchannelTokenDeliveryMode
+	}
+}
+
+func ChangeBackchannelClientNotificationEndpoint(backchannelClientNotificationEndpoint string) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.BackchannelClientNotificationEndpoint = &backchannelClientNotificationEndpoint
+	}
+}
+
+func ChangeTLSClientAuthSubjectDN(tlsClientAuthSubjectDN string) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.TLSClientAuthSubjectDN = &tlsClientAuthSubjectDN
+	}
+}
+
 func OIDCConfigChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	e := &OIDCConfigChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
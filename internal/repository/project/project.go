@@ -139,6 +139,9 @@ type ProjectChangeEvent struct {
 	HasProjectCheck        *bool                          `json:"hasProjectCheck,omitempty"`
 	PrivateLabelingSetting *domain.PrivateLabelingSetting `json:"privateLabelingSetting,omitempty"`
 	oldName                string
+
+	// AuthorizationDetailsTypes are the types of authorization_details (RFC 9396) the applications of the project accept.
+	AuthorizationDetailsTypes *[]string `json:"authorizationDetailsTypes,omitempty"`
 }
 
 func (e *ProjectChangeEvent) Payload() interface{} {
@@ -231,6 +234,16 @@ func ChangePrivateLabelingSetting(ChangePrivateLabelingSetting domain.PrivateLab
 	}
 }
 
+func ChangeAuthorizationDetailsTypes(types []string) func(event *ProjectChangeEvent) {
+	// an empty list must be stored as such to remove all types
+	if types == nil {
+		types = []string{}
+	}
+	return func(e *ProjectChangeEvent) {
+		e.AuthorizationDetailsTypes = &types
+	}
+}
+
 func ProjectChangeEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	e := &ProjectChangeEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
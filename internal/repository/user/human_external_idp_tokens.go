@@ -0,0 +1,159 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	UserIDPLinkTokensSetType     = UserIDPLinkEventPrefix + "tokens.set"
+	UserIDPLinkTokensRemovedType = UserIDPLinkEventPrefix + "tokens.removed"
+	UserIDPLinkTokensUsedType    = UserIDPLinkEventPrefix + "tokens.used"
+
+	IDPLinkTokensRefreshQueueName = "idp_link_tokens_refresh"
+)
+
+// UserIDPLinkTokensSetEvent stores the (encrypted) tokens issued by the identity provider of the link,
+// so they can be refreshed and used to call the APIs of the provider on behalf of the user.
+type UserIDPLinkTokensSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	IDPConfigID    string              `json:"idpConfigId"`
+	ExternalUserID string              `json:"userId"`
+	AccessToken    *crypto.CryptoValue `json:"accessToken,omitempty"`
+	RefreshToken   *crypto.CryptoValue `json:"refreshToken,omitempty"`
+	// Expiry of the access token, a zero value means the token does not expire
+	Expiry time.Time `json:"expiry,omitempty"`
+}
+
+func (e *UserIDPLinkTokensSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *UserIDPLinkTokensSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *UserIDPLinkTokensSetEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewUserIDPLinkTokensSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpConfigID,
+	externalUserID string,
+	accessToken,
+	refreshToken *crypto.CryptoValue,
+	expiry time.Time,
+) *UserIDPLinkTokensSetEvent {
+	return &UserIDPLinkTokensSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserIDPLinkTokensSetType,
+		),
+		IDPConfigID:    idpConfigID,
+		ExternalUserID: externalUserID,
+		AccessToken:    accessToken,
+		RefreshToken:   refreshToken,
+		Expiry:         expiry,
+	}
+}
+
+// UserIDPLinkTokensRemovedEvent removes the stored tokens of the link,
+// e.g. because the identity provider revoked the refresh token.
+type UserIDPLinkTokensRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	IDPConfigID    string `json:"idpConfigId"`
+	ExternalUserID string `json:"userId"`
+}
+
+func (e *UserIDPLinkTokensRemovedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *UserIDPLinkTokensRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *UserIDPLinkTokensRemovedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewUserIDPLinkTokensRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpConfigID,
+	externalUserID string,
+) *UserIDPLinkTokensRemovedEvent {
+	return &UserIDPLinkTokensRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserIDPLinkTokensRemovedType,
+		),
+		IDPConfigID:    idpConfigID,
+		ExternalUserID: externalUserID,
+	}
+}
+
+// UserIDPLinkTokensUsedEvent marks the stored tokens of the link as used,
+// it is pushed on the first read of the tokens after they were set.
+// Tokens which are not used anymore are not refreshed in the background.
+type UserIDPLinkTokensUsedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	IDPConfigID    string `json:"idpConfigId"`
+	ExternalUserID string `json:"userId"`
+}
+
+func (e *UserIDPLinkTokensUsedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *UserIDPLinkTokensUsedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *UserIDPLinkTokensUsedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewUserIDPLinkTokensUsedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpConfigID,
+	externalUserID string,
+) *UserIDPLinkTokensUsedEvent {
+	return &UserIDPLinkTokensUsedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserIDPLinkTokensUsedType,
+		),
+		IDPConfigID:    idpConfigID,
+		ExternalUserID: externalUserID,
+	}
+}
+
+// IDPLinkTokensRefreshRequest are the arguments of the job which refreshes the stored tokens of a link
+// before the access token expires.
+type IDPLinkTokensRefreshRequest struct {
+	InstanceID     string `json:"instanceID"`
+	UserID         string `json:"userID"`
+	ResourceOwner  string `json:"resourceOwner"`
+	IDPConfigID    string `json:"idpConfigID"`
+	ExternalUserID string `json:"externalUserID"`
+	// Sequence is the sequence of the event which set the tokens,
+	// it makes the job unique per set of tokens.
+	Sequence uint64 `json:"sequence"`
+}
+
+func (r *IDPLinkTokensRefreshRequest) Kind() string {
+	return "idp_link_tokens_refresh_request"
+}
@@ -58,6 +58,7 @@ type HumanWebAuthNVerifiedEvent struct {
 	SignCount         uint32 `json:"signCount"`
 	WebAuthNTokenName string `json:"webAuthNTokenName"`
 	UserAgentID       string `json:"userAgentID,omitempty"`
+	ModelName         string `json:"modelName,omitempty"`
 }
 
 func (e *HumanWebAuthNVerifiedEvent) Payload() interface{} {
@@ -77,7 +78,8 @@ func NewHumanWebAuthNVerifiedEvent(
 	publicKey,
 	aaguid []byte,
 	signCount uint32,
-	userAgentID string,
+	userAgentID,
+	modelName string,
 ) *HumanWebAuthNVerifiedEvent {
 	return &HumanWebAuthNVerifiedEvent{
 		BaseEvent:         *base,
@@ -89,6 +91,7 @@ func NewHumanWebAuthNVerifiedEvent(
 		SignCount:         signCount,
 		WebAuthNTokenName: webAuthNTokenName,
 		UserAgentID:       userAgentID,
+		ModelName:         modelName,
 	}
 }
 
@@ -51,6 +51,7 @@ type HumanView struct {
 	OTPState                 MFAState
 	OTPSMSAdded              bool
 	OTPEmailAdded            bool
+	RecoveryCodesAdded       bool
 	U2FTokens                []*WebAuthNView
 	PasswordlessTokens       []*WebAuthNView
 	MFAMaxSetUp              domain.MFALevel
@@ -210,6 +211,10 @@ func (u *UserView) MFATypesAllowed(level domain.MFALevel, policy *domain.LoginPo
 			}
 		}
 	}
+	// recovery codes are only a backup and therefore only allowed if the user can use any other factor
+	if len(types) > 0 && u.RecoveryCodesAdded {
+		types = append(types, domain.MFATypeRecoveryCode)
+	}
 	return types, required
 }
 
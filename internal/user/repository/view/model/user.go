@@ -90,6 +90,7 @@ type HumanView struct {
 	OTPState                 int32          `json:"-" gorm:"column:otp_state"`
 	OTPSMSAdded              bool           `json:"-" gorm:"column:otp_sms_added"`
 	OTPEmailAdded            bool           `json:"-" gorm:"column:otp_email_added"`
+	RecoveryCodesAdded       bool           `json:"-" gorm:"column:recovery_codes_added"`
 	U2FTokens                WebAuthNTokens `json:"-" gorm:"column:u2f_tokens"`
 	MFAMaxSetUp              int32          `json:"-" gorm:"column:mfa_max_set_up"`
 	MFAInitSkipped           time.Time      `json:"-" gorm:"column:mfa_init_skipped"`
@@ -133,8 +134,9 @@ func (h *HumanView) IsZero() bool {
 }
 
 type MachineView struct {
-	Name        string `json:"name" gorm:"column:machine_name"`
-	Description string `json:"description" gorm:"column:machine_description"`
+	Name            string `json:"name" gorm:"column:machine_name"`
+	Description     string `json:"description" gorm:"column:machine_description"`
+	AccessTokenType int32  `json:"accessTokenType" gorm:"-"`
 }
 
 func (m *MachineView) IsZero() bool {
@@ -182,6 +184,7 @@ func UserToModel(user *UserView) *model.UserView {
 			OTPState:                 model.MFAState(user.OTPState),
 			OTPSMSAdded:              user.OTPSMSAdded,
 			OTPEmailAdded:            user.OTPEmailAdded,
+			RecoveryCodesAdded:       user.RecoveryCodesAdded,
 			MFAMaxSetUp:              domain.MFALevel(user.MFAMaxSetUp),
 			MFAInitSkipped:           user.MFAInitSkipped,
 			InitRequired:             user.InitRequired,
@@ -341,6 +344,10 @@ func (u *UserView) AppendEvent(event eventstore.Event) (err error) {
 	case user.HumanOTPEmailRemovedType:
 		u.OTPEmailAdded = false
 		u.MFAInitSkipped = time.Time{}
+	case user.HumanRecoveryCodesAddedType:
+		u.RecoveryCodesAdded = true
+	case user.HumanRecoveryCodesRemovedType:
+		u.RecoveryCodesAdded = false
 	case user.HumanU2FTokenAddedType:
 		err = u.addU2FToken(event)
 	case user.HumanU2FTokenVerifiedType:
@@ -599,6 +606,8 @@ func (u *UserView) EventTypes() []eventstore.EventType {
 		user.HumanOTPSMSRemovedType,
 		user.HumanOTPEmailAddedType,
 		user.HumanOTPEmailRemovedType,
+		user.HumanRecoveryCodesAddedType,
+		user.HumanRecoveryCodesRemovedType,
 		user.HumanU2FTokenAddedType,
 		user.HumanU2FTokenVerifiedType,
 		user.HumanU2FTokenRemovedType,
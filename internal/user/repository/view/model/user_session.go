@@ -184,6 +184,15 @@ func (v *UserSessionView) AppendEvent(event eventstore.Event) error {
 		if v.UserAgentID == data.UserAgentID {
 			v.setSecondFactorVerification(event.CreatedAt(), domain.MFATypeOTPEmail)
 		}
+	case user.HumanRecoveryCodeCheckSucceededType:
+		data := new(es_model.OTPVerified)
+		err := data.SetData(event)
+		if err != nil {
+			return err
+		}
+		if v.UserAgentID == data.UserAgentID {
+			v.setSecondFactorVerification(event.CreatedAt(), domain.MFATypeRecoveryCode)
+		}
 	case user.UserV1MFAOTPCheckFailedType,
 		user.UserV1MFAOTPRemovedType,
 		user.HumanMFAOTPCheckFailedType,
@@ -191,7 +200,8 @@ func (v *UserSessionView) AppendEvent(event eventstore.Event) error {
 		user.HumanU2FTokenCheckFailedType,
 		user.HumanU2FTokenRemovedType,
 		user.HumanOTPSMSCheckFailedType,
-		user.HumanOTPEmailCheckFailedType:
+		user.HumanOTPEmailCheckFailedType,
+		user.HumanRecoveryCodeCheckFailedType:
 		v.SecondFactorVerification = sql.NullTime{Time: time.Time{}, Valid: true}
 	case user.HumanU2FTokenVerifiedType:
 		data := new(es_model.WebAuthNVerify)
@@ -253,6 +263,8 @@ func (v *UserSessionView) EventTypes() []eventstore.EventType {
 		user.HumanOTPSMSCheckFailedType,
 		user.HumanOTPEmailCheckSucceededType,
 		user.HumanOTPEmailCheckFailedType,
+		user.HumanRecoveryCodeCheckSucceededType,
+		user.HumanRecoveryCodeCheckFailedType,
 		user.HumanU2FTokenCheckFailedType,
 		user.HumanU2FTokenRemovedType,
 		user.HumanU2FTokenVerifiedType,
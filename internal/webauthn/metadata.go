@@ -0,0 +1,332 @@
+package webauthn
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-webauthn/webauthn/metadata"
+	"github.com/google/uuid"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// MetadataConfig points to a locally cached BLOB of the FIDO Metadata Service (MDS3).
+// The BLOB is used to verify the attestation of authenticators, if required by the security settings of an instance.
+type MetadataConfig struct {
+	// BLOBPath is the path to the BLOB as downloaded from https://mds3.fidoalliance.org/.
+	// The file is reloaded when it is modified, so it can be updated by a cron job without restarting.
+	BLOBPath string
+	// RootCertificatePath is the path to a PEM encoded certificate the BLOB is signed with.
+	// If empty, the root certificate of the FIDO Alliance is used.
+	RootCertificatePath string
+}
+
+// certificationLevels are the FIDO certification levels an attestation policy can require.
+var certificationLevels = []metadata.AuthenticatorStatus{
+	metadata.FidoCertified,
+	metadata.FidoCertifiedL1,
+	metadata.FidoCertifiedL1plus,
+	metadata.FidoCertifiedL2,
+	metadata.FidoCertifiedL2plus,
+	metadata.FidoCertifiedL3,
+	metadata.FidoCertifiedL3plus,
+}
+
+// blobSignatureAlgorithms are the algorithms accepted for the signature of the BLOB.
+var blobSignatureAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+}
+
+// Metadata holds the entries of a verified MDS3 BLOB.
+type Metadata struct {
+	path  string
+	roots *x509.CertPool
+
+	mu      sync.RWMutex
+	modTime time.Time
+	entries map[uuid.UUID]*metadataEntry
+}
+
+// metadataBLOB contains only the parts of the MDS3 payload needed for the attestation verification,
+// so changes to unused parts of the format do not break the parsing.
+type metadataBLOB struct {
+	Number     int              `json:"no"`
+	NextUpdate string           `json:"nextUpdate"`
+	Entries    []*metadataEntry `json:"entries"`
+}
+
+type metadataEntry struct {
+	AAGUID            string `json:"aaguid"`
+	MetadataStatement struct {
+		Description                 string   `json:"description"`
+		AttestationRootCertificates []string `json:"attestationRootCertificates"`
+	} `json:"metadataStatement"`
+	StatusReports []struct {
+		Status metadata.AuthenticatorStatus `json:"status"`
+	} `json:"statusReports"`
+}
+
+// Load reads and verifies the BLOB.
+// It returns nil if no BLOBPath is configured.
+func (c *MetadataConfig) Load() (*Metadata, error) {
+	if c == nil || c.BLOBPath == "" {
+		return nil, nil
+	}
+	roots, err := metadataRoots(c.RootCertificatePath)
+	if err != nil {
+		return nil, err
+	}
+	m := &Metadata{
+		path:  c.BLOBPath,
+		roots: roots,
+	}
+	if err = m.reload(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func metadataRoots(path string) (*x509.CertPool, error) {
+	roots := x509.NewCertPool()
+	if path == "" {
+		root, err := parseBase64Certificate(metadata.ProductionMDSRoot)
+		if err != nil {
+			return nil, err
+		}
+		roots.AddCert(root)
+		return roots, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("webauthn metadata root certificate: %w", err)
+	}
+	if !roots.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("webauthn metadata root certificate: no certificate found in %s", path)
+	}
+	return roots, nil
+}
+
+// reload parses the BLOB again if the file was modified since it was last loaded.
+func (m *Metadata) reload() error {
+	info, err := os.Stat(m.path)
+	if err != nil {
+		return fmt.Errorf("webauthn metadata blob: %w", err)
+	}
+	m.mu.RLock()
+	modified := !info.ModTime().Equal(m.modTime)
+	m.mu.RUnlock()
+	if !modified {
+		return nil
+	}
+	data, err := os.ReadFile(m.path)
+	if err != nil {
+		return fmt.Errorf("webauthn metadata blob: %w", err)
+	}
+	entries, err := parseMetadataBLOB(data, m.roots, time.Now())
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = entries
+	m.modTime = info.ModTime()
+	return nil
+}
+
+// parseMetadataBLOB verifies the signature of the JWT encoded BLOB using the certificate chain of its header
+// and returns the entries by AAGUID. Entries of UAF authenticators (without an AAGUID) are skipped.
+func parseMetadataBLOB(data []byte, roots *x509.CertPool, now time.Time) (map[uuid.UUID]*metadataEntry, error) {
+	jws, err := jose.ParseSigned(strings.TrimSpace(string(data)), blobSignatureAlgorithms)
+	if err != nil {
+		return nil, fmt.Errorf("webauthn metadata blob: %w", err)
+	}
+	if len(jws.Signatures) != 1 {
+		return nil, errors.New("webauthn metadata blob: expected exactly one signature")
+	}
+	chains, err := jws.Signatures[0].Protected.Certificates(x509.VerifyOptions{
+		Roots:       roots,
+		CurrentTime: now,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("webauthn metadata blob: invalid certificate chain: %w", err)
+	}
+	payload, err := jws.Verify(chains[0][0].PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("webauthn metadata blob: %w", err)
+	}
+	blob := new(metadataBLOB)
+	if err = json.Unmarshal(payload, blob); err != nil {
+		return nil, fmt.Errorf("webauthn metadata blob: %w", err)
+	}
+	if nextUpdate, err := time.Parse(time.DateOnly, blob.NextUpdate); err == nil && now.After(nextUpdate) {
+		logging.WithFields("number", blob.Number, "nextUpdate", blob.NextUpdate).Warn("webauthn metadata blob is outdated")
+	}
+	entries := make(map[uuid.UUID]*metadataEntry, len(blob.Entries))
+	for _, entry := range blob.Entries {
+		aaguid, err := uuid.Parse(entry.AAGUID)
+		if err != nil {
+			continue
+		}
+		entries[aaguid] = entry
+	}
+	return entries, nil
+}
+
+func (m *Metadata) entry(aaguid uuid.UUID) *metadataEntry {
+	if m == nil {
+		return nil
+	}
+	logging.OnError(m.reload()).Warn("unable to reload webauthn metadata blob, using previous version")
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.entries[aaguid]
+}
+
+// modelName returns the description of the authenticator, if it is part of the metadata.
+func (m *Metadata) modelName(aaguid []byte) string {
+	id, err := uuid.FromBytes(aaguid)
+	if err != nil {
+		return ""
+	}
+	if entry := m.entry(id); entry != nil {
+		return entry.MetadataStatement.Description
+	}
+	return ""
+}
+
+// verifyAttestation checks the authenticator against the policy.
+// x5c is the attestation certificate chain of the attestation statement, starting with the attestation certificate.
+func (m *Metadata) verifyAttestation(policy *domain.WebAuthNAttestationPolicy, aaguid []byte, x5c [][]byte, now time.Time) error {
+	if policy == nil {
+		return nil
+	}
+	id, err := uuid.FromBytes(aaguid)
+	if err != nil {
+		return zerrors.ThrowPreconditionFailed(err, "WEBAU-ahR5u", "Errors.User.WebAuthN.AttestationInvalid")
+	}
+	if containsAAGUID(policy.DeniedAAGUIDs, id) {
+		return zerrors.ThrowPreconditionFailed(nil, "WEBAU-ooJ6a", "Errors.User.WebAuthN.AuthenticatorNotAllowed")
+	}
+	if !policy.AttestationRequired() {
+		return nil
+	}
+	if m == nil {
+		return zerrors.ThrowInternal(nil, "WEBAU-Quee0", "Errors.User.WebAuthN.MetadataMissing")
+	}
+	if len(policy.AllowedAAGUIDs) > 0 && !containsAAGUID(policy.AllowedAAGUIDs, id) {
+		return zerrors.ThrowPreconditionFailed(nil, "WEBAU-Ied8o", "Errors.User.WebAuthN.AuthenticatorNotAllowed")
+	}
+	if len(x5c) == 0 {
+		return zerrors.ThrowPreconditionFailed(nil, "WEBAU-Eeph1", "Errors.User.WebAuthN.AttestationRequired")
+	}
+	entry := m.entry(id)
+	if entry == nil {
+		return zerrors.ThrowPreconditionFailed(nil, "WEBAU-Gie9k", "Errors.User.WebAuthN.AuthenticatorNotAllowed")
+	}
+	if err = entry.verifyChain(x5c, now); err != nil {
+		return zerrors.ThrowPreconditionFailed(err, "WEBAU-oo4Xe", "Errors.User.WebAuthN.AttestationInvalid")
+	}
+	if !entry.hasStatus(policy.CertificationLevels) {
+		return zerrors.ThrowPreconditionFailed(nil, "WEBAU-Jo7ah", "Errors.User.WebAuthN.AuthenticatorNotAllowed")
+	}
+	return nil
+}
+
+// verifyChain checks that the attestation certificate chains to one of the roots of the metadata statement.
+func (e *metadataEntry) verifyChain(x5c [][]byte, now time.Time) error {
+	certificates := make([]*x509.Certificate, len(x5c))
+	for i, der := range x5c {
+		certificate, err := x509.ParseCertificate(der)
+		if err != nil {
+			return err
+		}
+		certificates[i] = certificate
+	}
+	roots := x509.NewCertPool()
+	for _, root := range e.MetadataStatement.AttestationRootCertificates {
+		certificate, err := parseBase64Certificate(root)
+		if err != nil {
+			return err
+		}
+		roots.AddCert(certificate)
+	}
+	intermediates := x509.NewCertPool()
+	for _, certificate := range certificates[1:] {
+		intermediates.AddCert(certificate)
+	}
+	_, err := certificates[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err
+}
+
+// hasStatus returns false if the authenticator was reported with an undesired status (e.g. REVOKED)
+// or does not have one of the required certification levels.
+func (e *metadataEntry) hasStatus(levels []string) bool {
+	certified := len(levels) == 0
+	for _, report := range e.StatusReports {
+		if metadata.IsUndesiredAuthenticatorStatus(report.Status) {
+			return false
+		}
+		if slices.Contains(levels, string(report.Status)) {
+			certified = true
+		}
+	}
+	return certified
+}
+
+// ValidateAttestationPolicy checks the AAGUIDs and certification levels of the policy.
+func ValidateAttestationPolicy(policy *domain.WebAuthNAttestationPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	for _, aaguid := range slices.Concat(policy.AllowedAAGUIDs, policy.DeniedAAGUIDs) {
+		if _, err := uuid.Parse(aaguid); err != nil {
+			return zerrors.ThrowInvalidArgument(err, "WEBAU-ka9Ae", "Errors.User.WebAuthN.InvalidAAGUID")
+		}
+	}
+	for _, level := range policy.CertificationLevels {
+		if !slices.Contains(certificationLevels, metadata.AuthenticatorStatus(level)) {
+			return zerrors.ThrowInvalidArgument(nil, "WEBAU-Dah4a", "Errors.User.WebAuthN.InvalidCertificationLevel")
+		}
+	}
+	return nil
+}
+
+func containsAAGUID(aaguids []string, aaguid uuid.UUID) bool {
+	return slices.ContainsFunc(aaguids, func(s string) bool {
+		id, err := uuid.Parse(s)
+		return err == nil && id == aaguid
+	})
+}
+
+func parseBase64Certificate(encoded string) (*x509.Certificate, error) {
+	der, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		// some metadata statements contain PEM encoded certificates
+		block, _ := pem.Decode([]byte(encoded))
+		if block == nil {
+			return nil, err
+		}
+		der = block.Bytes
+	}
+	return x509.ParseCertificate(der)
+}
@@ -0,0 +1,363 @@
+package webauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	testAAGUID        = uuid.MustParse("cb69481e-8ff7-4039-93ec-0a2729a154a8")
+	testRevokedAAGUID = uuid.MustParse("ee882879-721c-4913-9775-3dfcce97072a")
+	testUnknownAAGUID = uuid.MustParse("fa2b99dc-9e39-4257-8f92-4a30d23c4118")
+)
+
+type testCertificate struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+}
+
+func newTestCertificate(t *testing.T, name string, parent *testCertificate) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  parent == nil,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	issuer, signer := template, key
+	if parent != nil {
+		issuer, signer = parent.certificate, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, signer)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCertificate{certificate: certificate, key: key}
+}
+
+func (c *testCertificate) base64() string {
+	return base64.StdEncoding.EncodeToString(c.certificate.Raw)
+}
+
+func testMetadataBLOB(t *testing.T, signer *testCertificate, chain []string, payload any) []byte {
+	joseSigner, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.ES256, Key: signer.key},
+		(&jose.SignerOptions{}).WithHeader("x5c", chain),
+	)
+	require.NoError(t, err)
+	data, err := json.Marshal(payload)
+	require.NoError(t, err)
+	jws, err := joseSigner.Sign(data)
+	require.NoError(t, err)
+	blob, err := jws.CompactSerialize()
+	require.NoError(t, err)
+	return []byte(blob)
+}
+
+func testMetadataPayload(attestationRoot *testCertificate) map[string]any {
+	return map[string]any{
+		"no":         42,
+		"nextUpdate": time.Now().AddDate(0, 1, 0).Format(time.DateOnly),
+		"entries": []map[string]any{
+			{
+				"aaguid": testAAGUID.String(),
+				"metadataStatement": map[string]any{
+					"description":                 "YubiKey 5 Series",
+					"attestationRootCertificates": []string{attestationRoot.base64()},
+				},
+				"statusReports": []map[string]any{
+					{"status": "FIDO_CERTIFIED"},
+					{"status": "FIDO_CERTIFIED_L1"},
+				},
+			},
+			{
+				"aaguid": testRevokedAAGUID.String(),
+				"metadataStatement": map[string]any{
+					"description":                 "Revoked Key",
+					"attestationRootCertificates": []string{attestationRoot.base64()},
+				},
+				"statusReports": []map[string]any{
+					{"status": "FIDO_CERTIFIED_L1"},
+					{"status": "REVOKED"},
+				},
+			},
+			{
+				"aaid": "4e4e#4005",
+				"metadataStatement": map[string]any{
+					"description": "UAF Authenticator",
+				},
+			},
+		},
+	}
+}
+
+func Test_parseMetadataBLOB(t *testing.T) {
+	root := newTestCertificate(t, "MDS Root", nil)
+	signer := newTestCertificate(t, "MDS Signer", root)
+	otherRoot := newTestCertificate(t, "Other Root", nil)
+	attestationRoot := newTestCertificate(t, "Attestation Root", nil)
+	roots := x509.NewCertPool()
+	roots.AddCert(root.certificate)
+
+	t.Run("valid", func(t *testing.T) {
+		blob := testMetadataBLOB(t, signer, []string{signer.base64()}, testMetadataPayload(attestationRoot))
+		entries, err := parseMetadataBLOB(blob, roots, time.Now())
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "YubiKey 5 Series", entries[testAAGUID].MetadataStatement.Description)
+	})
+	t.Run("untrusted root", func(t *testing.T) {
+		otherSigner := newTestCertificate(t, "MDS Signer", otherRoot)
+		blob := testMetadataBLOB(t, otherSigner, []string{otherSigner.base64()}, testMetadataPayload(attestationRoot))
+		_, err := parseMetadataBLOB(blob, roots, time.Now())
+		require.Error(t, err)
+	})
+	t.Run("signature of other key", func(t *testing.T) {
+		blob := testMetadataBLOB(t, otherRoot, []string{signer.base64()}, testMetadataPayload(attestationRoot))
+		_, err := parseMetadataBLOB(blob, roots, time.Now())
+		require.Error(t, err)
+	})
+	t.Run("no jwt", func(t *testing.T) {
+		_, err := parseMetadataBLOB([]byte(`{"entries":[]}`), roots, time.Now())
+		require.Error(t, err)
+	})
+}
+
+func TestMetadataConfig_Load(t *testing.T) {
+	root := newTestCertificate(t, "MDS Root", nil)
+	signer := newTestCertificate(t, "MDS Signer", root)
+	attestationRoot := newTestCertificate(t, "Attestation Root", nil)
+	dir := t.TempDir()
+	rootPath := filepath.Join(dir, "root.pem")
+	require.NoError(t, os.WriteFile(rootPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: root.certificate.Raw}), 0o600))
+	blobPath := filepath.Join(dir, "blob.jwt")
+	require.NoError(t, os.WriteFile(blobPath, testMetadataBLOB(t, signer, []string{signer.base64()}, testMetadataPayload(attestationRoot)), 0o600))
+
+	t.Run("not configured", func(t *testing.T) {
+		m, err := (&MetadataConfig{}).Load()
+		require.NoError(t, err)
+		assert.Nil(t, m)
+	})
+	t.Run("blob missing", func(t *testing.T) {
+		_, err := (&MetadataConfig{BLOBPath: filepath.Join(dir, "missing.jwt"), RootCertificatePath: rootPath}).Load()
+		require.Error(t, err)
+	})
+	t.Run("signed by fido alliance expected", func(t *testing.T) {
+		_, err := (&MetadataConfig{BLOBPath: blobPath}).Load()
+		require.Error(t, err)
+	})
+	t.Run("loaded", func(t *testing.T) {
+		m, err := (&MetadataConfig{BLOBPath: blobPath, RootCertificatePath: rootPath}).Load()
+		require.NoError(t, err)
+		assert.Equal(t, "YubiKey 5 Series", m.modelName(testAAGUID[:]))
+		assert.Equal(t, "", m.modelName(testUnknownAAGUID[:]))
+	})
+}
+
+func TestMetadata_verifyAttestation(t *testing.T) {
+	attestationRoot := newTestCertificate(t, "Attestation Root", nil)
+	attestation := newTestCertificate(t, "Attestation", attestationRoot)
+	otherAttestation := newTestCertificate(t, "Attestation", newTestCertificate(t, "Other Root", nil))
+
+	metadata := &Metadata{entries: make(map[uuid.UUID]*metadataEntry)}
+	data, err := json.Marshal(testMetadataPayload(attestationRoot))
+	require.NoError(t, err)
+	blob := new(metadataBLOB)
+	require.NoError(t, json.Unmarshal(data, blob))
+	for _, entry := range blob.Entries[:2] {
+		metadata.entries[uuid.MustParse(entry.AAGUID)] = entry
+	}
+	// prevent reloading from file
+	metadata.path = filepath.Join(t.TempDir(), "blob.jwt")
+
+	type args struct {
+		metadata *Metadata
+		policy   *domain.WebAuthNAttestationPolicy
+		aaguid   uuid.UUID
+		x5c      [][]byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "no policy",
+			args: args{
+				metadata: metadata,
+				aaguid:   testUnknownAAGUID,
+			},
+		},
+		{
+			name: "not required",
+			args: args{
+				metadata: metadata,
+				policy:   &domain.WebAuthNAttestationPolicy{},
+				aaguid:   testUnknownAAGUID,
+			},
+		},
+		{
+			name: "denied",
+			args: args{
+				policy: &domain.WebAuthNAttestationPolicy{
+					DeniedAAGUIDs: []string{testUnknownAAGUID.String()},
+				},
+				aaguid: testUnknownAAGUID,
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "WEBAU-ooJ6a", "Errors.User.WebAuthN.AuthenticatorNotAllowed"),
+		},
+		{
+			name: "metadata missing",
+			args: args{
+				policy: &domain.WebAuthNAttestationPolicy{Required: true},
+				aaguid: testAAGUID,
+				x5c:    [][]byte{attestation.certificate.Raw},
+			},
+			wantErr: zerrors.ThrowInternal(nil, "WEBAU-Quee0", "Errors.User.WebAuthN.MetadataMissing"),
+		},
+		{
+			name: "not in allowed list",
+			args: args{
+				metadata: metadata,
+				policy: &domain.WebAuthNAttestationPolicy{
+					AllowedAAGUIDs: []string{testRevokedAAGUID.String()},
+				},
+				aaguid: testAAGUID,
+				x5c:    [][]byte{attestation.certificate.Raw},
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "WEBAU-Ied8o", "Errors.User.WebAuthN.AuthenticatorNotAllowed"),
+		},
+		{
+			name: "no attestation",
+			args: args{
+				metadata: metadata,
+				policy:   &domain.WebAuthNAttestationPolicy{Required: true},
+				aaguid:   testAAGUID,
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "WEBAU-Eeph1", "Errors.User.WebAuthN.AttestationRequired"),
+		},
+		{
+			name: "unknown authenticator",
+			args: args{
+				metadata: metadata,
+				policy:   &domain.WebAuthNAttestationPolicy{Required: true},
+				aaguid:   testUnknownAAGUID,
+				x5c:      [][]byte{attestation.certificate.Raw},
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "WEBAU-Gie9k", "Errors.User.WebAuthN.AuthenticatorNotAllowed"),
+		},
+		{
+			name: "untrusted attestation",
+			args: args{
+				metadata: metadata,
+				policy:   &domain.WebAuthNAttestationPolicy{Required: true},
+				aaguid:   testAAGUID,
+				x5c:      [][]byte{otherAttestation.certificate.Raw},
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "WEBAU-oo4Xe", "Errors.User.WebAuthN.AttestationInvalid"),
+		},
+		{
+			name: "revoked",
+			args: args{
+				metadata: metadata,
+				policy:   &domain.WebAuthNAttestationPolicy{Required: true},
+				aaguid:   testRevokedAAGUID,
+				x5c:      [][]byte{attestation.certificate.Raw},
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "WEBAU-Jo7ah", "Errors.User.WebAuthN.AuthenticatorNotAllowed"),
+		},
+		{
+			name: "certification level too low",
+			args: args{
+				metadata: metadata,
+				policy: &domain.WebAuthNAttestationPolicy{
+					CertificationLevels: []string{"FIDO_CERTIFIED_L2", "FIDO_CERTIFIED_L3"},
+				},
+				aaguid: testAAGUID,
+				x5c:    [][]byte{attestation.certificate.Raw},
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "WEBAU-Jo7ah", "Errors.User.WebAuthN.AuthenticatorNotAllowed"),
+		},
+		{
+			name: "attested and certified",
+			args: args{
+				metadata: metadata,
+				policy: &domain.WebAuthNAttestationPolicy{
+					AllowedAAGUIDs:      []string{testAAGUID.String()},
+					CertificationLevels: []string{"FIDO_CERTIFIED_L1"},
+				},
+				aaguid: testAAGUID,
+				x5c:    [][]byte{attestation.certificate.Raw},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.args.metadata.verifyAttestation(tt.args.policy, tt.args.aaguid[:], tt.args.x5c, time.Now())
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateAttestationPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *domain.WebAuthNAttestationPolicy
+		wantErr error
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "invalid aaguid",
+			policy: &domain.WebAuthNAttestationPolicy{
+				DeniedAAGUIDs: []string{"yubikey"},
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "WEBAU-ka9Ae", "Errors.User.WebAuthN.InvalidAAGUID"),
+		},
+		{
+			name: "invalid certification level",
+			policy: &domain.WebAuthNAttestationPolicy{
+				CertificationLevels: []string{"REVOKED"},
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "WEBAU-Dah4a", "Errors.User.WebAuthN.InvalidCertificationLevel"),
+		},
+		{
+			name: "valid",
+			policy: &domain.WebAuthNAttestationPolicy{
+				Required:            true,
+				AllowedAAGUIDs:      []string{testAAGUID.String()},
+				DeniedAAGUIDs:       []string{testRevokedAAGUID.String()},
+				CertificationLevels: []string{"FIDO_CERTIFIED_L2", "FIDO_CERTIFIED_L3plus"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, ValidateAttestationPolicy(tt.policy), tt.wantErr)
+		})
+	}
+}
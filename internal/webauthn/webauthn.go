@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/go-webauthn/webauthn/protocol"
 	"github.com/go-webauthn/webauthn/webauthn"
@@ -18,6 +19,8 @@ import (
 type Config struct {
 	DisplayName    string
 	ExternalSecure bool
+	// Metadata is used to verify the attestation of authenticators, it is nil if no BLOB is configured
+	Metadata *Metadata
 }
 
 type webUser struct {
@@ -52,7 +55,7 @@ func (u *webUser) WebAuthnCredentials() []webauthn.Credential {
 	return u.credentials
 }
 
-func (w *Config) BeginRegistration(ctx context.Context, user *domain.Human, accountName string, authType domain.AuthenticatorAttachment, userVerification domain.UserVerificationRequirement, rpID string, webAuthNs ...*domain.WebAuthNToken) (*domain.WebAuthNToken, error) {
+func (w *Config) BeginRegistration(ctx context.Context, user *domain.Human, accountName string, authType domain.AuthenticatorAttachment, userVerification domain.UserVerificationRequirement, rpID string, attestationPolicy *domain.WebAuthNAttestationPolicy, webAuthNs ...*domain.WebAuthNToken) (*domain.WebAuthNToken, error) {
 	webAuthNServer, err := w.serverFromContext(ctx, rpID, "")
 	if err != nil {
 		return nil, err
@@ -65,6 +68,10 @@ func (w *Config) BeginRegistration(ctx context.Context, user *domain.Human, acco
 			CredentialID: cred.ID,
 		}
 	}
+	conveyance := protocol.PreferNoAttestation
+	if attestationPolicy.AttestationRequired() {
+		conveyance = protocol.PreferDirectAttestation
+	}
 	credentialOptions, sessionData, err := webAuthNServer.BeginRegistration(
 		&webUser{
 			Human:       user,
@@ -75,7 +82,7 @@ func (w *Config) BeginRegistration(ctx context.Context, user *domain.Human, acco
 			UserVerification:        UserVerificationFromDomain(userVerification),
 			AuthenticatorAttachment: AuthenticatorAttachmentFromDomain(authType),
 		}),
-		webauthn.WithConveyancePreference(protocol.PreferNoAttestation),
+		webauthn.WithConveyancePreference(conveyance),
 		webauthn.WithExclusions(existing),
 	)
 	if err != nil {
@@ -94,7 +101,7 @@ func (w *Config) BeginRegistration(ctx context.Context, user *domain.Human, acco
 	}, nil
 }
 
-func (w *Config) FinishRegistration(ctx context.Context, user *domain.Human, webAuthN *domain.WebAuthNToken, tokenName string, credData []byte) (*domain.WebAuthNToken, error) {
+func (w *Config) FinishRegistration(ctx context.Context, user *domain.Human, webAuthN *domain.WebAuthNToken, tokenName string, credData []byte, attestationPolicy *domain.WebAuthNAttestationPolicy) (*domain.WebAuthNToken, error) {
 	if webAuthN == nil {
 		return nil, zerrors.ThrowInternal(nil, "WEBAU-5M9so", "Errors.User.WebAuthN.NotFound")
 	}
@@ -118,6 +125,10 @@ func (w *Config) FinishRegistration(ctx context.Context, user *domain.Human, web
 		logging.WithFields("error", tryExtractProtocolErrMsg(err), "err_id", "WEBAU-3Vb9s").Debug("webauthn credential could not be created")
 		return nil, zerrors.ThrowInternal(err, "WEBAU-3Vb9s", "Errors.User.WebAuthN.CreateCredentialFailed")
 	}
+	if err = w.Metadata.verifyAttestation(attestationPolicy, credential.Authenticator.AAGUID, attestationCertificates(credentialData), time.Now()); err != nil {
+		logging.WithFields("error", err, "attestation_type", credential.AttestationType, "err_id", "WEBAU-Aek2d").Debug("webauthn attestation rejected")
+		return nil, err
+	}
 
 	webAuthN.KeyID = credential.ID
 	webAuthN.PublicKey = credential.PublicKey
@@ -126,9 +137,28 @@ func (w *Config) FinishRegistration(ctx context.Context, user *domain.Human, web
 	webAuthN.SignCount = credential.Authenticator.SignCount
 	webAuthN.WebAuthNTokenName = tokenName
 	webAuthN.RPID = webAuthNServer.Config.RPID
+	webAuthN.ModelName = w.Metadata.modelName(credential.Authenticator.AAGUID)
 	return webAuthN, nil
 }
 
+// attestationCertificates returns the DER encoded certificate chain of the attestation statement.
+// Formats without a chain (none, self attestation and android-safetynet) return nil.
+func attestationCertificates(credentialData *protocol.ParsedCredentialCreationData) [][]byte {
+	x5c, ok := credentialData.Response.AttestationObject.AttStatement["x5c"].([]interface{})
+	if !ok {
+		return nil
+	}
+	certificates := make([][]byte, 0, len(x5c))
+	for _, certificate := range x5c {
+		der, ok := certificate.([]byte)
+		if !ok {
+			return nil
+		}
+		certificates = append(certificates, der)
+	}
+	return certificates
+}
+
 func (w *Config) BeginLogin(ctx context.Context, user *domain.Human, userVerification domain.UserVerificationRequirement, rpID string, webAuthNs ...*domain.WebAuthNToken) (*domain.WebAuthNLogin, error) {
 	webAuthNServer, err := w.serverFromContext(ctx, rpID, "")
 	if err != nil {
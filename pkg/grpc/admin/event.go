@@ -42,6 +42,18 @@ func EventsToPb(ctx context.Context, events []*query.Event) (_ *ListEventsRespon
 	}, nil
 }
 
+func StreamEventToPb(event *query.Event, offset uint32) (*StreamEventsResponse, error) {
+	res, err := event_grpc.EventToPb(event)
+	if err != nil {
+		return nil, err
+	}
+	return &StreamEventsResponse{
+		Event:    res,
+		Position: event.Position.String(),
+		Offset:   offset,
+	}, nil
+}
+
 func (resp *ListEventTypesResponse) Localizers() []middleware.Localizer {
 	if resp == nil {
 		return nil
@@ -77,3 +89,10 @@ func (resp *ListEventsResponse) Localizers() []middleware.Localizer {
 	}
 	return localizers
 }
+
+func (resp *StreamEventsResponse) Localizers() []middleware.Localizer {
+	if resp == nil || resp.Event == nil {
+		return nil
+	}
+	return []middleware.Localizer{resp.Event.Type.Localized, resp.Event.Aggregate.Type.Localized}
+}